@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -9,11 +10,18 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/artmexbet/avito_test_task/internal/auth"
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/internal/memory"
+	"github.com/artmexbet/avito_test_task/internal/metrics"
+	"github.com/artmexbet/avito_test_task/internal/migrator"
 	"github.com/artmexbet/avito_test_task/internal/postgres"
 	"github.com/artmexbet/avito_test_task/internal/repository"
 	"github.com/artmexbet/avito_test_task/internal/router"
 	"github.com/artmexbet/avito_test_task/internal/service"
 	statsRetriever "github.com/artmexbet/avito_test_task/internal/stats-retriever"
+	"github.com/artmexbet/avito_test_task/internal/webhook"
+	"github.com/artmexbet/avito_test_task/migrations"
 	"github.com/artmexbet/avito_test_task/pkg/config"
 	"github.com/artmexbet/avito_test_task/pkg/logger"
 )
@@ -28,34 +36,114 @@ func main() {
 	ctx := context.Background()
 	slog.InfoContext(ctx, "reading configuration completed", "config", cfg)
 
-	pool, err := pgxpool.New(ctx, cfg.Postgres.DSN())
-	if err != nil {
-		panic(err)
+	// `api migrate up|down|status` только работает со схемой и завершается
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		pool := mustConnectPostgres(ctx, cfg.Postgres)
+		err := runMigrate(ctx, mustNewMigrator(pool), os.Args[2:], os.Stdout)
+		pool.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "migration failed", "error", err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	err = pool.Ping(ctx)
-	if err != nil {
-		panic(err)
+	// `api stats reconcile` пересобирает счётчики статистики и завершается
+	if len(os.Args) > 1 && os.Args[1] == "stats" {
+		pool := mustConnectPostgres(ctx, cfg.Postgres)
+		reconciler := statsRetriever.NewStatsRetriever(repository.NewStatsRepository(postgres.New(pool)))
+		err := runStats(ctx, reconciler, os.Args[2:], os.Stdout)
+		pool.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "stats command failed", "error", err)
+			os.Exit(1)
+		}
+		return
 	}
-	slog.InfoContext(ctx, "connected to postgres database")
 
-	pg := postgres.New(pool)
+	serviceMetrics := metrics.New()
+
+	storage, closeStorage := mustNewStorage(ctx, cfg, serviceMetrics)
+	slog.InfoContext(ctx, "storage initialized", "backend", cfg.Storage.Backend)
 
-	userRepository := repository.NewUserRepository(pg)
-	reviewersRepository := repository.NewReviewersRepository(pg)
-	pullRequestRepository := repository.NewPRRepository(pg)
-	teamRepository := repository.NewTeamRepository(pg)
+	userRepository := repository.NewUserRepository(storage)
+	reviewersRepository := repository.NewReviewersRepository(storage)
+	pullRequestRepository := repository.NewPRRepository(storage)
+	teamRepository := repository.NewTeamRepository(storage)
+	auditRepository := repository.NewAuditRepository(storage)
+	webhookRepository := repository.NewWebhookRepository(storage)
+	externalLoginRepository := repository.NewExternalLoginRepository(storage)
+	absenceRepository := repository.NewAbsenceRepository(storage)
+	reviewerPoolRepository := repository.NewReviewerPoolRepository(storage)
+	transactor := repository.NewTransactor(storage)
 
-	statsRepository := repository.NewStatsRepository(pg)
+	statsRepository := repository.NewStatsRepository(storage)
+	serviceMetrics.RegisterEntities(statsRepository)
 	slog.InfoContext(ctx, "repositories initialized")
 
-	prService := service.NewPullRequestService(pullRequestRepository, reviewersRepository, userRepository)
-	userService := service.NewUserService(userRepository)
-	teamService := service.NewTeamService(teamRepository, userRepository)
+	reviewerSelector, err := service.NewReviewerSelector(cfg.Reviewers, reviewersRepository, reviewerPoolRepository)
+	if err != nil {
+		panic(err)
+	}
+
+	prService := service.NewPullRequestService(
+		pullRequestRepository,
+		reviewersRepository,
+		userRepository,
+		reviewerPoolRepository,
+		reviewerSelector,
+		domain.MergePolicy{
+			MinApprovals:            cfg.MergePolicy.MinApprovals,
+			BlockOnChangesRequested: cfg.MergePolicy.BlockOnChangesRequested,
+			RequireOutsideApproval:  cfg.MergePolicy.RequireOutsideApproval,
+		},
+		auditRepository,
+		webhookRepository,
+		transactor,
+		serviceMetrics,
+	)
+	userService := service.NewUserService(userRepository, prService, auditRepository, transactor)
+	teamService := service.NewTeamService(
+		teamRepository, userRepository, reviewerPoolRepository, prService, auditRepository, transactor,
+	)
+	reviewerPoolService := service.NewReviewerPoolService(
+		reviewerPoolRepository, userRepository, auditRepository, transactor,
+	)
+	auditService := service.NewAuditService(auditRepository)
+	webhookService := service.NewWebhookService(webhookRepository)
+	externalEventService := service.NewExternalEventService(externalLoginRepository, prService, cfg.Ingest)
+	absenceService := service.NewAbsenceService(
+		absenceRepository, userRepository, prService, auditRepository, transactor, cfg.Absences,
+	)
 
 	statsService := statsRetriever.NewStatsRetriever(statsRepository)
 
-	_router := router.New(cfg.Router, userService, prService, teamService, statsService)
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	if cfg.Stats.ReconcileInterval > 0 {
+		go statsService.RunReconciliation(jobsCtx, cfg.Stats.ReconcileInterval)
+	}
+	if cfg.Webhooks.DispatchInterval > 0 {
+		go webhook.NewDispatcher(webhookRepository, cfg.Webhooks).Run(jobsCtx)
+	}
+	if cfg.Absences.ReassignInterval > 0 {
+		go absenceService.RunReassignment(jobsCtx)
+	}
+
+	_router := router.New(
+		cfg.Router,
+		userService,
+		prService,
+		teamService,
+		reviewerPoolService,
+		auditService,
+		webhookService,
+		externalEventService,
+		absenceService,
+		statsService,
+		serviceMetrics,
+		mustNewAuthenticator(ctx, cfg.Auth),
+	)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -70,6 +158,7 @@ func main() {
 	<-quit // wait for shutdown signal
 
 	slog.InfoContext(ctx, "shutting down server...")
+	stopJobs()
 	ctx, cancel := context.WithTimeout(ctx, cfg.Router.ShutdownTimeout)
 	defer cancel()
 	err = _router.Shutdown(ctx)
@@ -77,6 +166,64 @@ func main() {
 		panic(err)
 	}
 
-	pool.Close()
+	closeStorage()
 	slog.InfoContext(ctx, "server gracefully stopped")
 }
+
+// mustNewStorage creates the configured storage backend. The returned func releases its resources.
+func mustNewStorage(
+	ctx context.Context,
+	cfg config.Config,
+	serviceMetrics *metrics.Metrics,
+) (repository.Storage, func()) {
+	switch cfg.Storage.Backend {
+	case config.StorageBackendPostgres:
+		pool := mustConnectPostgres(ctx, cfg.Postgres)
+		if cfg.Migrations.Auto {
+			applied, err := mustNewMigrator(pool).Up(ctx)
+			if err != nil {
+				panic(err)
+			}
+			slog.InfoContext(ctx, "migrations applied", "count", len(applied))
+		}
+		serviceMetrics.RegisterPool(pool)
+		return postgres.New(pool), pool.Close
+	case config.StorageBackendMemory:
+		return memory.New(), func() {}
+	default:
+		panic(fmt.Sprintf("unsupported storage backend %q", cfg.Storage.Backend))
+	}
+}
+
+func mustNewAuthenticator(ctx context.Context, cfg config.AuthConfig) *auth.Authenticator {
+	authenticator, err := auth.New(cfg)
+	if err != nil {
+		panic(err)
+	}
+	if cfg.Disabled {
+		slog.WarnContext(ctx, "authentication is disabled, every request is served as an anonymous admin")
+	}
+	return authenticator
+}
+
+func mustConnectPostgres(ctx context.Context, cfg config.PostgresConfig) *pgxpool.Pool {
+	pool, err := pgxpool.New(ctx, cfg.DSN())
+	if err != nil {
+		panic(err)
+	}
+
+	err = pool.Ping(ctx)
+	if err != nil {
+		panic(err)
+	}
+	slog.InfoContext(ctx, "connected to postgres database")
+	return pool
+}
+
+func mustNewMigrator(pool *pgxpool.Pool) *migrator.Migrator {
+	schemaMigrator, err := migrator.New(pool, migrations.FS)
+	if err != nil {
+		panic(err)
+	}
+	return schemaMigrator
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	statsRetriever "github.com/artmexbet/avito_test_task/internal/stats-retriever"
+)
+
+const statsUsage = "usage: api stats reconcile"
+
+type statsReconciler interface {
+	Reconcile(ctx context.Context) (statsRetriever.ReconcileResult, error)
+}
+
+// runStats handles the `stats` subcommand
+func runStats(ctx context.Context, r statsReconciler, args []string, out io.Writer) error {
+	if len(args) != 1 {
+		return errors.New(statsUsage)
+	}
+
+	switch args[0] {
+	case "reconcile":
+		res, err := r.Reconcile(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "rebuilt stats: %d reviewers and %d teams had drifted\n", res.ReviewersFixed, res.TeamsFixed)
+	default:
+		return fmt.Errorf("unknown stats command %q, %s", args[0], statsUsage)
+	}
+	return nil
+}
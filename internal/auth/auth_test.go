@@ -0,0 +1,276 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/pkg/config"
+)
+
+const (
+	testAdminToken = "local-admin-token-0123"
+	testIssuer     = "https://sso.example.com"
+	testAudience   = "reviewers"
+)
+
+// AuthTestSuite проверяет статические токены и JWT, подписанные ключами из JWKS-файла
+type AuthTestSuite struct {
+	suite.Suite
+	rsaKey   *rsa.PrivateKey
+	ecKey    *ecdsa.PrivateKey
+	jwksFile string
+	auth     *Authenticator
+	clock    time.Time
+}
+
+// SetupSuite генерирует ключи один раз, RSA-ключ генерируется долго
+func (s *AuthTestSuite) SetupSuite() {
+	var err error
+	s.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	s.jwksFile = s.writeJWKS(
+		map[string]string{
+			"kty": "RSA", "kid": "rsa", "use": "sig",
+			"n": b64(s.rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(s.rsaKey.E)).Bytes()),
+		},
+		map[string]string{
+			"kty": "EC", "kid": "ec", "crv": "P-256",
+			"x": b64(s.ecKey.X.FillBytes(make([]byte, 32))), "y": b64(s.ecKey.Y.FillBytes(make([]byte, 32))),
+		},
+		// ключ шифрования пропускается
+		map[string]string{"kty": "oct", "kid": "enc", "use": "enc"},
+	)
+}
+
+// SetupTest выполняется перед каждым тестом
+func (s *AuthTestSuite) SetupTest() {
+	var err error
+	s.auth, err = New(config.AuthConfig{
+		AdminTokens: map[string]string{"ci": testAdminToken},
+		JWKSFile:    s.jwksFile,
+		Issuer:      testIssuer,
+		Audience:    testAudience,
+		Leeway:      time.Minute,
+	})
+	s.Require().NoError(err)
+	s.clock = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.auth.now = func() time.Time { return s.clock }
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func (s *AuthTestSuite) writeJWKS(keys ...map[string]string) string {
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	s.Require().NoError(err)
+	path := filepath.Join(s.T().TempDir(), "jwks.json")
+	s.Require().NoError(os.WriteFile(path, data, 0o600))
+	return path
+}
+
+// claims возвращает действующие утверждения пользователя user-1, которые тесты портят по одному
+func (s *AuthTestSuite) claims() map[string]any {
+	return map[string]any{
+		"sub":  "user-1",
+		"role": "member",
+		"iss":  testIssuer,
+		"aud":  []string{"other", testAudience},
+		"exp":  s.clock.Add(time.Hour).Unix(),
+		"nbf":  s.clock.Add(-time.Hour).Unix(),
+	}
+}
+
+func (s *AuthTestSuite) sign(alg, kid string, claims map[string]any) string {
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	s.Require().NoError(err)
+	payload, err := json.Marshal(claims)
+	s.Require().NoError(err)
+	signingInput := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch alg {
+	case algRS256:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, s.rsaKey, crypto.SHA256, digest[:])
+		s.Require().NoError(err)
+	case algES256:
+		r, sig, err := ecdsa.Sign(rand.Reader, s.ecKey, digest[:])
+		s.Require().NoError(err)
+		signature = append(r.FillBytes(make([]byte, 32)), sig.FillBytes(make([]byte, 32))...)
+	}
+	return signingInput + "." + b64(signature)
+}
+
+// TestAdminToken проверяет статические токены администраторов
+func (s *AuthTestSuite) TestAdminToken() {
+	principal, err := s.auth.Authenticate(testAdminToken)
+	s.Require().NoError(err)
+	s.Equal(domain.Principal{Subject: "admin:ci", Role: domain.RoleAdmin, TeamName: ""}, principal)
+
+	_, err = s.auth.Authenticate(testAdminToken + "x")
+	s.ErrorIs(err, domain.ErrInvalidToken)
+	_, err = s.auth.Authenticate("")
+	s.ErrorIs(err, domain.ErrInvalidToken)
+}
+
+// TestJWT проверяет токены, подписанные RSA- и EC-ключами
+func (s *AuthTestSuite) TestJWT() {
+	principal, err := s.auth.Authenticate(s.sign(algRS256, "rsa", s.claims()))
+	s.Require().NoError(err)
+	s.Equal(domain.Principal{Subject: "user-1", Role: domain.RoleMember, TeamName: ""}, principal)
+
+	claims := s.claims()
+	claims["role"] = "team_lead"
+	claims["team_name"] = "backend"
+	claims["aud"] = testAudience
+	principal, err = s.auth.Authenticate(s.sign(algES256, "ec", claims))
+	s.Require().NoError(err)
+	s.Equal(domain.Principal{Subject: "user-1", Role: domain.RoleTeamLead, TeamName: "backend"}, principal)
+
+	// команда в токене участника ни на что не влияет
+	claims = s.claims()
+	claims["team_name"] = "backend"
+	principal, err = s.auth.Authenticate(s.sign(algRS256, "rsa", claims))
+	s.Require().NoError(err)
+	s.Empty(principal.TeamName)
+
+	// часы провайдера могут немного убегать вперёд
+	s.clock = s.clock.Add(time.Hour + 30*time.Second)
+	_, err = s.auth.Authenticate(s.sign(algRS256, "rsa", s.claims()))
+	s.NoError(err)
+}
+
+// TestInvalidClaims проверяет отказ в токенах с негодными утверждениями
+func (s *AuthTestSuite) TestInvalidClaims() {
+	tests := map[string]func(claims map[string]any){
+		"expired":        func(c map[string]any) { c["exp"] = s.clock.Add(-2 * time.Minute).Unix() },
+		"no exp":         func(c map[string]any) { delete(c, "exp") },
+		"not yet valid":  func(c map[string]any) { c["nbf"] = s.clock.Add(2 * time.Minute).Unix() },
+		"foreign issuer": func(c map[string]any) { c["iss"] = "https://evil.example.com" },
+		"foreign aud":    func(c map[string]any) { c["aud"] = "billing" },
+		"no sub":         func(c map[string]any) { delete(c, "sub") },
+		"unknown role":   func(c map[string]any) { c["role"] = "owner" },
+		"no role":        func(c map[string]any) { delete(c, "role") },
+		"lead no team":   func(c map[string]any) { c["role"] = "team_lead" },
+	}
+	for name, spoil := range tests {
+		claims := s.claims()
+		spoil(claims)
+		_, err := s.auth.Authenticate(s.sign(algRS256, "rsa", claims))
+		s.ErrorIs(err, domain.ErrInvalidToken, name)
+	}
+}
+
+// TestInvalidSignature проверяет отказ в токенах с чужой или подделанной подписью
+func (s *AuthTestSuite) TestInvalidSignature() {
+	token := s.sign(algRS256, "rsa", s.claims())
+	parts := strings.Split(token, ".")
+
+	// подменённые утверждения не сходятся с подписью
+	claims := s.claims()
+	claims["role"] = "admin"
+	payload, err := json.Marshal(claims)
+	s.Require().NoError(err)
+	forged := parts[0] + "." + b64(payload) + "." + parts[2]
+
+	// заголовок подписан одним ключом, а указывает на другой
+	wrongKey := s.sign(algRS256, "ec", s.claims())
+	unknownKey := s.sign(algRS256, "missing", s.claims())
+	// подпись снята, alg none не поддерживается
+	none, err := json.Marshal(map[string]string{"alg": "none", "kid": "rsa"})
+	s.Require().NoError(err)
+	unsigned := b64(none) + "." + parts[1] + "."
+
+	for name, token := range map[string]string{
+		"forged":      forged,
+		"wrong key":   wrongKey,
+		"unknown key": unknownKey,
+		"unsigned":    unsigned,
+		"no kid":      s.sign(algRS256, "", s.claims()),
+		"malformed":   parts[0] + "." + parts[1],
+		"garbage":     "not-a-token",
+	} {
+		_, err := s.auth.Authenticate(token)
+		s.ErrorIs(err, domain.ErrInvalidToken, name)
+	}
+}
+
+// TestSingleKeyWithoutKid проверяет, что при единственном ключе kid в токене не обязателен
+func (s *AuthTestSuite) TestSingleKeyWithoutKid() {
+	auth, err := New(config.AuthConfig{
+		JWKSFile: s.writeJWKS(map[string]string{
+			"kty": "RSA", "n": b64(s.rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(s.rsaKey.E)).Bytes()),
+		}),
+	})
+	s.Require().NoError(err)
+	claims := s.claims()
+	delete(claims, "aud")
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+	claims["nbf"] = time.Now().Add(-time.Hour).Unix()
+
+	principal, err := auth.Authenticate(s.sign(algRS256, "", claims))
+	s.Require().NoError(err)
+	s.Equal("user-1", principal.Subject)
+
+	// статических токенов нет, чужие строки не принимаются
+	_, err = auth.Authenticate(testAdminToken)
+	s.ErrorIs(err, domain.ErrInvalidToken)
+}
+
+// TestDisabled проверяет, что без аутентификации все запросы идут от анонимного администратора
+func (s *AuthTestSuite) TestDisabled() {
+	auth, err := New(config.AuthConfig{Disabled: true})
+	s.Require().NoError(err)
+
+	principal, err := auth.Authenticate("")
+	s.Require().NoError(err)
+	s.Equal(domain.Principal{Subject: domain.AnonymousActor, Role: domain.RoleAdmin, TeamName: ""}, principal)
+}
+
+// TestNewErrors проверяет отказ запускаться с негодной конфигурацией
+func (s *AuthTestSuite) TestNewErrors() {
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	s.Require().NoError(err)
+
+	tests := map[string]config.AuthConfig{
+		"nothing configured": {},
+		"short admin token":  {AdminTokens: map[string]string{"ci": "short"}},
+		"missing jwks":       {JWKSFile: filepath.Join(s.T().TempDir(), "missing.json")},
+		"no signing keys":    {JWKSFile: s.writeJWKS(map[string]string{"kty": "RSA", "use": "enc"})},
+		"small rsa key": {JWKSFile: s.writeJWKS(map[string]string{
+			"kty": "RSA", "n": b64(smallKey.N.Bytes()), "e": b64(big.NewInt(int64(smallKey.E)).Bytes()),
+		})},
+		"unknown curve": {JWKSFile: s.writeJWKS(map[string]string{"kty": "EC", "crv": "P-521"})},
+		"point off curve": {JWKSFile: s.writeJWKS(map[string]string{
+			"kty": "EC", "crv": "P-256", "x": b64(make([]byte, 32)), "y": b64(make([]byte, 32)),
+		})},
+	}
+	for name, cfg := range tests {
+		_, err := New(cfg)
+		s.Error(err, name)
+	}
+}
+
+// TestAuthSuite запускает test suite
+func TestAuthSuite(t *testing.T) {
+	suite.Run(t, new(AuthTestSuite))
+}
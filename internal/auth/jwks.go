@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/MicahParks/jwkset"
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms of bearer tokens
+const (
+	algRS256 = "RS256"
+	algES256 = "ES256"
+	algES384 = "ES384"
+)
+
+// minRSABits is the smallest RSA key accepted from the JWKS
+const minRSABits = 2048
+
+// keySet finds the key that signed a token among the signing keys of a JWKS
+type keySet struct {
+	keyfunc keyfunc.Keyfunc
+	// single lets tokens without kid through, only one key could have signed them
+	single bool
+}
+
+// loadKeySet reads the signing keys of a JWKS file. Encryption keys are skipped
+func loadKeySet(path string) (keySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return keySet{}, fmt.Errorf("error reading JWKS file: %w", err)
+	}
+	var set jwkset.JWKSMarshal
+	if err := json.Unmarshal(data, &set); err != nil {
+		return keySet{}, fmt.Errorf("error decoding JWKS file: %w", err)
+	}
+
+	storage := jwkset.NewMemoryStorage()
+	count := 0
+	for i, marshal := range set.Keys {
+		if marshal.USE != "" && marshal.USE != jwkset.UseSig {
+			continue
+		}
+		jwk, err := jwkset.NewJWKFromMarshal(marshal, jwkset.JWKMarshalOptions{}, jwkset.JWKValidateOptions{})
+		if err == nil {
+			err = checkKey(jwk)
+		}
+		if err != nil {
+			return keySet{}, fmt.Errorf("key %d (kid %q): %w", i, marshal.KID, err)
+		}
+		if err := storage.KeyWrite(context.Background(), jwk); err != nil {
+			return keySet{}, fmt.Errorf("key %d (kid %q): %w", i, marshal.KID, err)
+		}
+		count++
+	}
+	if count == 0 {
+		return keySet{}, errors.New("JWKS file has no signing keys")
+	}
+
+	kf, err := keyfunc.New(keyfunc.Options{Storage: storage}) //nolint:exhaustruct
+	if err != nil {
+		return keySet{}, fmt.Errorf("error creating JWKS keyfunc: %w", err)
+	}
+	return keySet{keyfunc: kf, single: count == 1}, nil
+}
+
+// checkKey accepts only keys of the supported algorithms, so that no token is verified by a weak key
+func checkKey(jwk jwkset.JWK) error {
+	alg := jwk.Marshal().ALG.String()
+	switch key := jwk.Key().(type) {
+	case *rsa.PublicKey:
+		if alg != "" && alg != algRS256 {
+			return fmt.Errorf("unsupported RSA algorithm %q", alg)
+		}
+		if key.N.BitLen() < minRSABits {
+			return fmt.Errorf("RSA key is shorter than %d bits", minRSABits)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		var want string
+		switch key.Curve.Params().Name {
+		case "P-256":
+			want = algES256
+		case "P-384":
+			want = algES384
+		default:
+			return fmt.Errorf("unsupported curve %q", key.Curve.Params().Name)
+		}
+		if alg != "" && alg != want {
+			return fmt.Errorf("algorithm %q does not match curve %s", alg, key.Curve.Params().Name)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %q", jwk.Marshal().KTY)
+	}
+}
+
+// Keyfunc returns the key named by kid of the token. A token without kid is accepted only if the JWKS
+// has a single key
+func (k keySet) Keyfunc(token *jwt.Token) (any, error) {
+	if kid, _ := token.Header["kid"].(string); kid != "" {
+		return k.keyfunc.Keyfunc(token)
+	}
+	if !k.single {
+		return nil, errors.New("kid is required")
+	}
+	return k.keyfunc.VerificationKeySet(context.Background())
+}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// tokenClaims are the claims of a bearer JWT. The registered ones are checked by the parser
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Role     domain.Role `json:"role"`
+	TeamName string      `json:"team_name"`
+}
+
+// Validate checks the claims the parser doesn't know about, the parser calls it after its own checks
+func (c tokenClaims) Validate() error {
+	if c.Subject == "" {
+		return errors.New("sub is required")
+	}
+	if !domain.IsValidRole(c.Role) {
+		return fmt.Errorf("unknown role %q", c.Role)
+	}
+	if c.Role == domain.RoleTeamLead && c.TeamName == "" {
+		return errors.New("team_name is required for team_lead")
+	}
+	return nil
+}
+
+// parseToken verifies the signature of the token and checks its claims against the configuration.
+// The algorithm has to be one of the supported ones, so that a token can't pick a weaker one or "none"
+func (a *Authenticator) parseToken(token string) (tokenClaims, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{algRS256, algES256, algES384}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(a.leeway),
+		jwt.WithTimeFunc(a.now),
+	}
+	if a.issuer != "" {
+		options = append(options, jwt.WithIssuer(a.issuer))
+	}
+	if a.audience != "" {
+		options = append(options, jwt.WithAudience(a.audience))
+	}
+
+	var claims tokenClaims
+	if _, err := jwt.ParseWithClaims(token, &claims, a.keys.Keyfunc, options...); err != nil {
+		return tokenClaims{}, err
+	}
+	return claims, nil
+}
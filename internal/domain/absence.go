@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// MaxAbsenceReasonLength limits the free-form reason of an absence
+const MaxAbsenceReasonLength = 255
+
+// Absence is a period when the user is away (vacation, sick leave) and gets no reviews,
+// while staying active. The period includes StartsAt and excludes EndsAt
+type Absence struct {
+	ID       int64
+	UserID   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+	// ReassignedAt is set when open reviews of the user were handed over to teammates, zero until then
+	ReassignedAt time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// CoversAt reports whether the user is away at t
+func (a Absence) CoversAt(t time.Time) bool {
+	return !t.Before(a.StartsAt) && t.Before(a.EndsAt)
+}
+
+// AbsenceFilter narrows down the list of absences. Zero fields don't filter
+type AbsenceFilter struct {
+	UserID   string
+	TeamName string
+	// EndsAfter drops absences that are over by this time
+	EndsAfter time.Time
+}
+
+// AbsenceReassignment tells how open reviews of an absent user were handed over
+type AbsenceReassignment struct {
+	Absence      Absence
+	Replacements []ReviewerReplacement
+}
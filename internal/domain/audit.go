@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AuditAction names a mutation recorded in the audit log
+type AuditAction string
+
+const (
+	AuditActionTeamAdd         AuditAction = "team.add"
+	AuditActionTeamRename      AuditAction = "team.rename"
+	AuditActionTeamDelete      AuditAction = "team.delete"
+	AuditActionTeamFallbacks   AuditAction = "team.set_fallbacks"
+	AuditActionUserUpsert      AuditAction = "user.upsert"
+	AuditActionUserMove        AuditAction = "user.move"
+	AuditActionUserSetIsActive AuditAction = "user.set_is_active"
+	AuditActionUserSetLimit    AuditAction = "user.set_max_open_reviews"
+	AuditActionUserDelete      AuditAction = "user.delete"
+	AuditActionPRCreate        AuditAction = "pull_request.create"
+	AuditActionPRMerge         AuditAction = "pull_request.merge"
+	// AuditActionPRForceMerge is a merge that bypassed the merge policy
+	AuditActionPRForceMerge AuditAction = "pull_request.force_merge"
+	AuditActionPRReassign   AuditAction = "pull_request.reassign"
+	// AuditActionPRSetStatus is a draft marked ready, a closed or a reopened pull request
+	AuditActionPRSetStatus   AuditAction = "pull_request.set_status"
+	AuditActionPRReview      AuditAction = "pull_request.review"
+	AuditActionAbsenceAdd    AuditAction = "absence.add"
+	AuditActionAbsenceUpdate AuditAction = "absence.update"
+	AuditActionAbsenceDelete AuditAction = "absence.delete"
+	AuditActionPoolSet       AuditAction = "reviewer_pool.set"
+	AuditActionPoolDelete    AuditAction = "reviewer_pool.delete"
+)
+
+// AuditEntityType is the kind of entity an audit event is about
+type AuditEntityType string
+
+const (
+	AuditEntityTeam        AuditEntityType = "team"
+	AuditEntityUser        AuditEntityType = "user"
+	AuditEntityPullRequest AuditEntityType = "pull_request"
+	AuditEntityAbsence     AuditEntityType = "absence"
+	AuditEntityPool        AuditEntityType = "reviewer_pool"
+)
+
+// AnonymousActor is the actor of requests that don't tell who made them
+const AnonymousActor = "anonymous"
+
+// Limits of an audit log page
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 500
+)
+
+// AuditEvent is an entry of the append-only audit log. It is written in the transaction of the mutation it describes
+type AuditEvent struct {
+	ID         int64
+	Action     AuditAction
+	EntityType AuditEntityType
+	EntityID   string
+	Actor      string
+	// RequestID is the ID of the HTTP request that made the mutation, empty for background jobs
+	RequestID string
+	// Before and After are JSON snapshots of the entity. Before is nil for created entities
+	Before    json.RawMessage
+	After     json.RawMessage
+	CreatedAt time.Time
+}
+
+// AuditFilter narrows down the audit log. Zero fields don't filter.
+// The time range includes the lower bound and excludes the upper one.
+type AuditFilter struct {
+	Action     AuditAction
+	EntityType AuditEntityType
+	EntityID   string
+	Actor      string
+	RequestID  string
+	From       time.Time
+	To         time.Time
+
+	// Limit is the page size, DefaultAuditPageSize if zero
+	Limit int
+	// AfterID is the ID of the last event of the previous page, zero for the first page
+	AfterID int64
+}
+
+// AuditPage is a page of the audit log, newest events first
+type AuditPage struct {
+	Events []AuditEvent
+	// NextAfterID is the AfterID of the next page, zero if there are no more pages
+	NextAfterID int64
+}
+
+type actorKey struct{}
+
+type requestIDKey struct{}
+
+// WithActor returns a copy of ctx that carries the actor of the request
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor, AnonymousActor if there is none
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// WithRequestID returns a copy of ctx that carries the ID of the HTTP request
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID, empty if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"slices"
+)
+
+// Role defines what an authenticated caller is allowed to do
+type Role string
+
+const (
+	// RoleAdmin may do everything, including force merges and managing integrations
+	RoleAdmin Role = "admin"
+	// RoleTeamLead may also add teams and change activity flags of users, but only in their own team
+	RoleTeamLead Role = "team_lead"
+	// RoleMember works with pull requests and may query only their own reviews
+	RoleMember Role = "member"
+)
+
+// IsValidRole reports whether r is one of the known roles
+func IsValidRole(r Role) bool {
+	return r == RoleAdmin || r == RoleTeamLead || r == RoleMember
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	// Subject is the user ID for bearer tokens and "admin:<name>" for static admin tokens.
+	// It becomes the actor in the audit log
+	Subject string
+	Role    Role
+	// TeamName is the team a team lead manages, it's empty for other roles
+	TeamName string
+}
+
+// CanManageTeam reports whether the principal may change the team and its members. Team leads manage only their own
+func (p Principal) CanManageTeam(teamName string) bool {
+	return p.Role != RoleTeamLead || p.TeamName == teamName
+}
+
+// HasRole reports whether the principal has one of the roles
+func (p Principal) HasRole(roles ...Role) bool {
+	return slices.Contains(roles, p.Role)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx that carries the authenticated caller, who also becomes the actor of the request
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey{}, principal)
+	return WithActor(ctx, principal.Subject)
+}
+
+// PrincipalFromContext returns the principal stored by WithPrincipal
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
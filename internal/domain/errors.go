@@ -1,13 +1,58 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
 	ErrTeamAlreadyExists    = errors.New("team already exists")
+	ErrTeamNotFound         = errors.New("team not found")
+	ErrTeamNotEmpty         = errors.New("team has members")
+	ErrInvalidTeamChange    = errors.New("invalid team change")
+	ErrTeamMemberConflict   = errors.New("team members conflict with existing users")
 	ErrUserNotFound         = errors.New("user not found")
+	ErrInvalidReviewLimit   = errors.New("invalid review limit")
 	ErrPRAlreadyExists      = errors.New("pull request already exists")
 	ErrPRNotFound           = errors.New("pull request not found")
 	ErrReviewerNotAssigned  = errors.New("reviewer not assigned to the pull request")
 	ErrNoAvailableReviewers = errors.New("no available reviewers to assign")
 	ErrPRAlreadyMerged      = errors.New("pull request already merged")
+	ErrMergePolicyNotMet    = errors.New("merge policy not met")
+	ErrPRNotOpen            = errors.New("pull request is not open")
+	ErrInvalidPRTransition  = errors.New("invalid pull request status transition")
+	ErrInvalidPRFilter      = errors.New("invalid pull request filter")
+	ErrInvalidAuditFilter   = errors.New("invalid audit log filter")
+	ErrInvalidUserFilter    = errors.New("invalid user filter")
+	ErrReviewerPoolNotFound = errors.New("reviewer pool not found")
+	ErrInvalidReviewerPool  = errors.New("invalid reviewer pool")
+
+	ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrInvalidWebhook              = errors.New("invalid webhook request")
+
+	ErrExternalLoginNotMapped = errors.New("external login is not mapped to a user")
+	ErrExternalLoginNotFound  = errors.New("external login not found")
+	ErrInvalidExternalLogin   = errors.New("invalid external login")
+	ErrInvalidExternalEvent   = errors.New("invalid external event payload")
+	ErrInvalidSignature       = errors.New("invalid webhook signature")
+	ErrProviderNotConfigured  = errors.New("provider is not configured")
+
+	ErrInvalidToken = errors.New("invalid token")
+
+	ErrAbsenceNotFound = errors.New("absence not found")
+	ErrInvalidAbsence  = errors.New("invalid absence")
 )
+
+// MergePolicyError lists the merge policy rules a pull request breaks. It matches ErrMergePolicyNotMet
+type MergePolicyError struct {
+	Violations []string
+}
+
+func (e *MergePolicyError) Error() string {
+	return ErrMergePolicyNotMet.Error() + ": " + strings.Join(e.Violations, "; ")
+}
+
+func (e *MergePolicyError) Unwrap() error {
+	return ErrMergePolicyNotMet
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// ExternalProvider is a code hosting that sends pull request events to the service
+type ExternalProvider string
+
+const (
+	ExternalProviderGitHub ExternalProvider = "github"
+	ExternalProviderGitLab ExternalProvider = "gitlab"
+)
+
+// IsValidExternalProvider reports whether p is one of the supported providers
+func IsValidExternalProvider(p ExternalProvider) bool {
+	return p == ExternalProviderGitHub || p == ExternalProviderGitLab
+}
+
+// ExternalLogin maps a login of the provider onto a user of the service
+type ExternalLogin struct {
+	Provider  ExternalProvider
+	Login     string
+	UserID    string
+	CreatedAt time.Time
+}
+
+// ExternalPRAction is what happened to a pull request on the provider side
+type ExternalPRAction string
+
+const (
+	ExternalPROpened ExternalPRAction = "opened"
+	// ExternalPRReady is sent when a draft is marked ready for review
+	ExternalPRReady    ExternalPRAction = "ready"
+	ExternalPRMerged   ExternalPRAction = "merged"
+	ExternalPRClosed   ExternalPRAction = "closed"
+	ExternalPRReopened ExternalPRAction = "reopened"
+)
+
+// ExternalPREvent is a pull request event of a provider mapped onto the domain
+type ExternalPREvent struct {
+	Provider ExternalProvider
+	Action   ExternalPRAction
+	// PullRequestID is the ID of the pull request in the service, built from the repository and the number
+	PullRequestID string
+	Name          string
+	AuthorLogin   string
+	// Sender is the login of whoever triggered the event, it becomes the actor in the audit log
+	Sender string
+	Draft  bool
+}
+
+// ExternalEventResult tells what the service did with an event of a provider
+type ExternalEventResult struct {
+	// Event is zero if the payload is not about a pull request
+	Event ExternalPREvent
+	// Applied is false if the event changed nothing, e.g. it was delivered twice
+	Applied bool
+	// Reason explains why the event was not applied
+	Reason string
+}
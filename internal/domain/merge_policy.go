@@ -0,0 +1,48 @@
+package domain
+
+import "fmt"
+
+// MergePolicy defines the conditions an open pull request has to meet to be merged.
+// The zero value allows merging any pull request.
+type MergePolicy struct {
+	// MinApprovals is the number of reviewers whose latest verdict must be APPROVED
+	MinApprovals int
+	// BlockOnChangesRequested forbids merging while the latest verdict of any reviewer is CHANGES_REQUESTED
+	BlockOnChangesRequested bool
+	// RequireOutsideApproval requires an approval from someone outside the author's team
+	RequireOutsideApproval bool
+}
+
+// Violations returns the rules of the policy the pull request breaks, nil if it can be merged.
+// reviews are the latest verdicts of the assigned reviewers, reviewerTeams maps the reviewer IDs to their teams.
+func (p MergePolicy) Violations(reviews []Review, authorTeam string, reviewerTeams map[string]string) []string {
+	var (
+		violations       []string
+		approvals        int
+		outsideApproval  bool
+		changesRequested []string
+	)
+	for _, r := range reviews {
+		switch r.Verdict {
+		case ReviewVerdictApproved:
+			approvals++
+			if team, ok := reviewerTeams[r.ReviewerID]; ok && team != authorTeam {
+				outsideApproval = true
+			}
+		case ReviewVerdictChangesRequested:
+			changesRequested = append(changesRequested, r.ReviewerID)
+		case ReviewVerdictCommented:
+		}
+	}
+
+	if approvals < p.MinApprovals {
+		violations = append(violations, fmt.Sprintf("%d of %d required approvals", approvals, p.MinApprovals))
+	}
+	if p.BlockOnChangesRequested && len(changesRequested) > 0 {
+		violations = append(violations, fmt.Sprintf("changes requested by %v", changesRequested))
+	}
+	if p.RequireOutsideApproval && !outsideApproval {
+		violations = append(violations, "no approval from outside the author's team")
+	}
+	return violations
+}
@@ -2,14 +2,31 @@ package domain
 
 import "time"
 
+// MaxReviewLimit is the largest number of open reviews a user may be limited to
+const MaxReviewLimit = 1000
+
 // User represents a user in the system.
 type User struct {
-	ID        string
-	Username  string
-	TeamName  string
-	IsActive  bool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID       string
+	Username string
+	TeamName string
+	IsActive bool
+	// MaxOpenReviews limits the number of open pull requests the user reviews at once, 0 means no limit
+	MaxOpenReviews int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	// DeletedAt is set when the user was deleted, zero for an existing one
+	DeletedAt time.Time
+}
+
+// IsDeleted reports whether the user was deleted. A deleted user keeps their pull requests and reviews
+func (u User) IsDeleted() bool {
+	return !u.DeletedAt.IsZero()
+}
+
+// HasReviewCapacity reports whether the user reviewing openReviews open pull requests may get one more
+func (u User) HasReviewCapacity(openReviews int) bool {
+	return u.MaxOpenReviews == 0 || openReviews < u.MaxOpenReviews
 }
 
 // PullRequest represents the status of a pull request.
@@ -20,14 +37,46 @@ type PullRequest struct {
 	Status    PRStatus
 	Author    *User // Not mapped to DB
 	Reviewers []User
+	// ReviewerPools holds the pool each reviewer was picked from by reviewer ID
+	ReviewerPools map[string]ReviewerPool
+	// Reviews holds the latest verdict of every assigned reviewer who submitted one
+	Reviews   []Review
 	CreatedAt time.Time
 	MergedAt  time.Time
+	// ClosedAt is the time the pull request was last closed, zero unless it is CLOSED
+	ClosedAt time.Time
+	// NeedMoreReviewers is set when fewer reviewers than required could be assigned
+	NeedMoreReviewers bool
+}
+
+// Review represents a verdict submitted by a reviewer on a pull request.
+type Review struct {
+	PullRequestID string
+	ReviewerID    string
+	Verdict       ReviewVerdict
+	SubmittedAt   time.Time
 }
 
 // Team represents a team in the system.
 type Team struct {
-	Name      string
-	Members   []User
+	Name    string
+	Members []User
+	// Fallbacks are the pools reviewers are picked from, in order, when the team can't supply enough of them
+	Fallbacks []ReviewerPool
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// ReviewerReplacement describes a reviewer swap on a pull request.
+// NewReviewerID is empty when nobody could replace the old reviewer and they were just removed.
+type ReviewerReplacement struct {
+	PullRequestID string
+	OldReviewerID string
+	NewReviewerID string
+}
+
+// ReviewerHandover is a reviewer replacement along with the pool the new reviewer was picked from
+type ReviewerHandover struct {
+	ReviewerReplacement
+	Pool ReviewerPool
+}
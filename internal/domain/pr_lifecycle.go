@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+)
+
+// prTransitions lists the statuses a pull request can move to from each status.
+// MERGED is final, a CLOSED pull request can only be reopened
+var prTransitions = map[PRStatus][]PRStatus{
+	PRStatusDraft:  {PRStatusOpen, PRStatusClosed},
+	PRStatusOpen:   {PRStatusMerged, PRStatusClosed},
+	PRStatusClosed: {PRStatusOpen},
+}
+
+// CheckPRTransition returns ErrInvalidPRTransition if a pull request can't move from one status to another.
+// Merging a merged pull request is reported with ErrPRAlreadyMerged, so that merge stays idempotent
+func CheckPRTransition(from, to PRStatus) error {
+	if from == PRStatusMerged && to == PRStatusMerged {
+		return ErrPRAlreadyMerged
+	}
+	if !slices.Contains(prTransitions[from], to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidPRTransition, from, to)
+	}
+	return nil
+}
+
+// IsValidPRStatus reports whether the status is one of the known pull request statuses
+func IsValidPRStatus(status PRStatus) bool {
+	_, ok := prTransitions[status]
+	return ok || status == PRStatusMerged
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Limits of a pull request list page
+const (
+	DefaultPullRequestPageSize = 20
+	MaxPullRequestPageSize     = 100
+)
+
+// PullRequestFilter narrows down the list of pull requests. Zero fields don't filter.
+// Time ranges include the lower bound and exclude the upper one.
+type PullRequestFilter struct {
+	Status   PRStatus
+	AuthorID string
+	// TeamName is the team of the author
+	TeamName string
+	// ReviewerID matches pull requests the user is currently assigned to
+	ReviewerID  string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	MergedFrom  time.Time
+	MergedTo    time.Time
+
+	// Limit is the page size, DefaultPullRequestPageSize if zero
+	Limit int
+	// After is the position of the last pull request of the previous page, nil for the first page
+	After *PullRequestCursor
+}
+
+// PullRequestCursor points at a pull request in the list. The list is ordered from newest to oldest,
+// pull requests created at the same time are ordered by ID descending
+type PullRequestCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// PullRequestPage is a page of the pull request list
+type PullRequestPage struct {
+	PullRequests []PullRequest
+	// Next points at the last pull request of the page, nil if there are no more pages
+	Next *PullRequestCursor
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// ReviewerPoolKind tells where the reviewers of a pool come from
+type ReviewerPoolKind string
+
+// Possible values for ReviewerPoolKind
+const (
+	// ReviewerPoolKindTeam - active members of a team
+	ReviewerPoolKindTeam ReviewerPoolKind = "team"
+	// ReviewerPoolKindShared - active members of a shared reviewer pool
+	ReviewerPoolKindShared ReviewerPoolKind = "shared"
+)
+
+// MaxTeamFallbacks limits the number of fallback pools a team may declare
+const MaxTeamFallbacks = 10
+
+// ReviewerPool is a source of reviewers: a team or a shared reviewer pool
+type ReviewerPool struct {
+	Kind ReviewerPoolKind
+	Name string
+}
+
+// TeamPool returns the pool of the team members
+func TeamPool(teamName string) ReviewerPool {
+	return ReviewerPool{Kind: ReviewerPoolKindTeam, Name: teamName}
+}
+
+// SharedPool returns the shared reviewer pool with the name
+func SharedPool(poolName string) ReviewerPool {
+	return ReviewerPool{Kind: ReviewerPoolKindShared, Name: poolName}
+}
+
+// SharedReviewerPool is a named group of users who review pull requests of the teams that fall back to it.
+// Its members stay in their own teams
+type SharedReviewerPool struct {
+	Name      string
+	Members   []User
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TeamAddMode tells how /team/add treats an existing team and users that already exist
+type TeamAddMode string
+
+// Possible values for TeamAddMode
+const (
+	// TeamAddModeCreateOnly creates a new team of new users only
+	TeamAddModeCreateOnly TeamAddMode = "create_only"
+	// TeamAddModeMerge creates the team if needed and adds the listed users, moving them from other teams
+	TeamAddModeMerge TeamAddMode = "merge"
+	// TeamAddModeReplace works like TeamAddModeMerge and deactivates active members who are not listed
+	TeamAddModeReplace TeamAddMode = "replace"
+)
+
+// TeamConflictReason explains why a listed member can't be applied to the team
+type TeamConflictReason string
+
+// Possible values for TeamConflictReason
+const (
+	// TeamConflictUserExists - the user already exists and create_only mode never touches existing users
+	TeamConflictUserExists TeamConflictReason = "user_exists"
+	// TeamConflictAttributesDiffer - the listed username or max_open_reviews differ from the stored ones,
+	// or an active user is listed as inactive
+	TeamConflictAttributesDiffer TeamConflictReason = "attributes_differ"
+	// TeamConflictDuplicateMember - the user is listed more than once
+	TeamConflictDuplicateMember TeamConflictReason = "duplicate_member"
+	// TeamConflictUserDeleted - the user was deleted, their ID can't be taken again
+	TeamConflictUserDeleted TeamConflictReason = "user_deleted"
+)
+
+// TeamMemberConflict is a listed member that was not applied. CurrentTeam is empty for a new user
+type TeamMemberConflict struct {
+	UserID      string
+	Reason      TeamConflictReason
+	CurrentTeam string
+}
+
+// TeamAddResult tells what adding a team changed. Members hold user IDs
+type TeamAddResult struct {
+	Team        Team
+	TeamCreated bool
+	Created     []string
+	Moved       []string
+	Untouched   []string
+	// Activated are inactive users listed as active, they may be among Moved too
+	Activated []string
+	// Deactivated are active members the replace mode found unlisted. A user can't be left without a team,
+	// so they stay in it inactive, with their open reviews handed over
+	Deactivated []string
+	// Replacements are reviewer swaps caused by moved and deactivated members
+	Replacements []ReviewerReplacement
+}
+
+// TeamConflictError lists the members that conflict with stored users. It matches ErrTeamMemberConflict
+type TeamConflictError struct {
+	Conflicts []TeamMemberConflict
+}
+
+func (e *TeamConflictError) Error() string {
+	conflicts := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		conflicts[i] = fmt.Sprintf("%s: %s", c.UserID, c.Reason)
+	}
+	return ErrTeamMemberConflict.Error() + ": " + strings.Join(conflicts, "; ")
+}
+
+func (e *TeamConflictError) Unwrap() error {
+	return ErrTeamMemberConflict
+}
@@ -14,6 +14,18 @@ func (p *PRStatus) Set(value string) {
 
 // Possible values for PRStatus
 const (
+	PRStatusDraft  PRStatus = "DRAFT"
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
+)
+
+// ReviewVerdict represents the outcome of a review submitted by a reviewer.
+type ReviewVerdict string
+
+// Possible values for ReviewVerdict
+const (
+	ReviewVerdictApproved         ReviewVerdict = "APPROVED"
+	ReviewVerdictChangesRequested ReviewVerdict = "CHANGES_REQUESTED"
+	ReviewVerdictCommented        ReviewVerdict = "COMMENTED"
 )
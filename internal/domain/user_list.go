@@ -0,0 +1,28 @@
+package domain
+
+// Limits of a user list page
+const (
+	DefaultUserPageSize = 20
+	MaxUserPageSize     = 100
+)
+
+// UserFilter narrows down the user directory. Zero fields don't filter, deleted users are never listed
+type UserFilter struct {
+	TeamName string
+	// IsActive matches users with the given active status, nil matches both
+	IsActive *bool
+	// UsernamePrefix matches usernames starting with it, case-insensitively
+	UsernamePrefix string
+
+	// Limit is the page size, DefaultUserPageSize if zero
+	Limit int
+	// AfterID is the ID of the last user of the previous page, empty for the first page
+	AfterID string
+}
+
+// UserPage is a page of the user directory ordered by user ID
+type UserPage struct {
+	Users []User
+	// NextAfterID is the AfterID of the next page, empty if there are no more pages
+	NextAfterID string
+}
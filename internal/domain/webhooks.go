@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType names a domain event delivered to webhook subscribers
+type EventType string
+
+const (
+	// EventReviewersAssigned is emitted when reviewers are assigned to a pull request
+	EventReviewersAssigned EventType = "pull_request.reviewers_assigned"
+	// EventReviewerReassigned is emitted when a reviewer of a pull request is replaced or removed
+	EventReviewerReassigned EventType = "pull_request.reviewer_reassigned"
+	// EventPullRequestMerged is emitted when a pull request is merged
+	EventPullRequestMerged EventType = "pull_request.merged"
+	// EventPullRequestStatusChanged is emitted when a pull request is marked ready, closed or reopened
+	EventPullRequestStatusChanged EventType = "pull_request.status_changed"
+)
+
+// IsValidEventType reports whether t is one of the known event types
+func IsValidEventType(t EventType) bool {
+	switch t {
+	case EventReviewersAssigned, EventReviewerReassigned, EventPullRequestMerged, EventPullRequestStatusChanged:
+		return true
+	}
+	return false
+}
+
+// OutboxEvent is a domain event stored in the outbox. It is written in the transaction of the mutation
+// and delivered to webhook subscribers later
+type OutboxEvent struct {
+	ID        int64
+	Type      EventType
+	Payload   json.RawMessage
+	CreatedAt time.Time
+}
+
+// WebhookSubscription is a URL that receives events of the given types
+type WebhookSubscription struct {
+	ID  int64
+	URL string
+	// Secret signs the deliveries with HMAC-SHA256, so that the receiver can check where they came from
+	Secret     string
+	EventTypes []EventType
+	CreatedAt  time.Time
+}
+
+// DeliveryStatus is the state of an event delivery to a subscriber
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "PENDING"
+	DeliveryStatusDelivered DeliveryStatus = "DELIVERED"
+	// DeliveryStatusDead is set when the delivery failed too many times, it is not retried until redelivered
+	DeliveryStatusDead DeliveryStatus = "DEAD"
+)
+
+// IsValidDeliveryStatus reports whether s is one of the known delivery statuses
+func IsValidDeliveryStatus(s DeliveryStatus) bool {
+	switch s {
+	case DeliveryStatusPending, DeliveryStatusDelivered, DeliveryStatusDead:
+		return true
+	}
+	return false
+}
+
+// WebhookDelivery is an attempt to deliver an outbox event to a subscriber
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventID        int64
+	Status         DeliveryStatus
+	// Attempts is the number of failed and successful sends so far
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	// DeliveredAt is zero unless the delivery is DELIVERED
+	DeliveredAt time.Time
+	CreatedAt   time.Time
+}
+
+// ClaimedDelivery is a delivery taken by the dispatcher along with everything needed to send it
+type ClaimedDelivery struct {
+	WebhookDelivery
+	URL    string
+	Secret string
+	Event  OutboxEvent
+}
+
+// Limits of a webhook delivery list page
+const (
+	DefaultDeliveryPageSize = 50
+	MaxDeliveryPageSize     = 500
+)
+
+// WebhookDeliveryFilter narrows down the list of deliveries. Zero fields don't filter
+type WebhookDeliveryFilter struct {
+	SubscriptionID int64
+	Status         DeliveryStatus
+
+	// Limit is the page size, DefaultDeliveryPageSize if zero
+	Limit int
+	// AfterID is the ID of the last delivery of the previous page, zero for the first page
+	AfterID int64
+}
+
+// WebhookDeliveryPage is a page of deliveries, newest first
+type WebhookDeliveryPage struct {
+	Deliveries []WebhookDelivery
+	// NextAfterID is the AfterID of the next page, zero if there are no more pages
+	NextAfterID int64
+}
@@ -0,0 +1,106 @@
+package ingest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// Headers of a GitHub webhook
+const (
+	HeaderGitHubEvent = "X-GitHub-Event"
+	// HeaderGitHubSignature holds "sha256=" and the hex HMAC-SHA256 of the body keyed with the webhook secret
+	HeaderGitHubSignature = "X-Hub-Signature-256"
+)
+
+const githubEventPullRequest = "pull_request"
+
+type githubPullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int64  `json:"number"`
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		ID int64 `json:"id"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+}
+
+// VerifyGitHub checks the signature of a GitHub webhook
+func VerifyGitHub(secret, signature string, body []byte) error {
+	hexDigest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return fmt.Errorf("%w: expected sha256 signature", domain.ErrInvalidSignature)
+	}
+	got, err := hex.DecodeString(hexDigest)
+	if err != nil {
+		return fmt.Errorf("%w: signature is not hex", domain.ErrInvalidSignature)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return domain.ErrInvalidSignature
+	}
+	return nil
+}
+
+// ParseGitHub maps a GitHub webhook onto a pull request event.
+// It returns false if the webhook is not about a pull request or its action does not matter to the service
+func ParseGitHub(event string, body []byte) (domain.ExternalPREvent, bool, error) {
+	if event != githubEventPullRequest {
+		return domain.ExternalPREvent{}, false, nil
+	}
+	var payload githubPullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return domain.ExternalPREvent{}, false, fmt.Errorf("%w: %w", domain.ErrInvalidExternalEvent, err)
+	}
+
+	var action domain.ExternalPRAction
+	switch payload.Action {
+	case "opened":
+		action = domain.ExternalPROpened
+	case "ready_for_review":
+		action = domain.ExternalPRReady
+	case "reopened":
+		action = domain.ExternalPRReopened
+	case "closed":
+		action = domain.ExternalPRClosed
+		if payload.PullRequest.Merged {
+			action = domain.ExternalPRMerged
+		}
+	default:
+		return domain.ExternalPREvent{}, false, nil
+	}
+
+	pr := payload.PullRequest
+	if payload.Repository.ID == 0 || pr.Number == 0 {
+		return domain.ExternalPREvent{}, false, fmt.Errorf("%w: repository id and pull request number are required",
+			domain.ErrInvalidExternalEvent)
+	}
+	if action == domain.ExternalPROpened && (pr.Title == "" || pr.User.Login == "") {
+		return domain.ExternalPREvent{}, false, fmt.Errorf("%w: title and author are required",
+			domain.ErrInvalidExternalEvent)
+	}
+	return domain.ExternalPREvent{
+		Provider:      domain.ExternalProviderGitHub,
+		Action:        action,
+		PullRequestID: pullRequestID(domain.ExternalProviderGitHub, payload.Repository.ID, pr.Number),
+		Name:          pr.Title,
+		AuthorLogin:   pr.User.Login,
+		Sender:        payload.Sender.Login,
+		Draft:         pr.Draft,
+	}, true, nil
+}
@@ -0,0 +1,107 @@
+package ingest
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// Headers of a GitLab webhook
+const (
+	HeaderGitLabEvent = "X-Gitlab-Event"
+	// HeaderGitLabToken holds the secret token of the webhook as is
+	HeaderGitLabToken = "X-Gitlab-Token"
+)
+
+const (
+	gitlabEventMergeRequest = "Merge Request Hook"
+	gitlabKindMergeRequest  = "merge_request"
+)
+
+type gitlabMergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	// User is whoever triggered the event, GitLab sends only the numeric ID of the author
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		ID int64 `json:"id"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID    int64  `json:"iid"`
+		Title  string `json:"title"`
+		Action string `json:"action"`
+		Draft  bool   `json:"draft"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Draft *struct {
+			Previous bool `json:"previous"`
+			Current  bool `json:"current"`
+		} `json:"draft"`
+	} `json:"changes"`
+}
+
+// VerifyGitLab checks the secret token of a GitLab webhook
+func VerifyGitLab(expected, token string) error {
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(token)) != 1 {
+		return domain.ErrInvalidSignature
+	}
+	return nil
+}
+
+// ParseGitLab maps a GitLab webhook onto a pull request event.
+// It returns false if the webhook is not about a merge request or its action does not matter to the service.
+// The merge request is opened by whoever triggered the event, so the author is taken from the user of the webhook
+func ParseGitLab(event string, body []byte) (domain.ExternalPREvent, bool, error) {
+	if event != gitlabEventMergeRequest {
+		return domain.ExternalPREvent{}, false, nil
+	}
+	var payload gitlabMergeRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return domain.ExternalPREvent{}, false, fmt.Errorf("%w: %w", domain.ErrInvalidExternalEvent, err)
+	}
+	if payload.ObjectKind != gitlabKindMergeRequest {
+		return domain.ExternalPREvent{}, false, nil
+	}
+
+	mr := payload.ObjectAttributes
+	var action domain.ExternalPRAction
+	switch mr.Action {
+	case "open":
+		action = domain.ExternalPROpened
+	case "reopen":
+		action = domain.ExternalPRReopened
+	case "close":
+		action = domain.ExternalPRClosed
+	case "merge":
+		action = domain.ExternalPRMerged
+	case "update":
+		// из обновлений интересно только снятие черновика
+		if draft := payload.Changes.Draft; draft == nil || !draft.Previous || draft.Current {
+			return domain.ExternalPREvent{}, false, nil
+		}
+		action = domain.ExternalPRReady
+	default:
+		return domain.ExternalPREvent{}, false, nil
+	}
+
+	if payload.Project.ID == 0 || mr.IID == 0 {
+		return domain.ExternalPREvent{}, false, fmt.Errorf("%w: project id and merge request iid are required",
+			domain.ErrInvalidExternalEvent)
+	}
+	if action == domain.ExternalPROpened && (mr.Title == "" || payload.User.Username == "") {
+		return domain.ExternalPREvent{}, false, fmt.Errorf("%w: title and user are required",
+			domain.ErrInvalidExternalEvent)
+	}
+	return domain.ExternalPREvent{
+		Provider:      domain.ExternalProviderGitLab,
+		Action:        action,
+		PullRequestID: pullRequestID(domain.ExternalProviderGitLab, payload.Project.ID, mr.IID),
+		Name:          mr.Title,
+		AuthorLogin:   payload.User.Username,
+		Sender:        payload.User.Username,
+		Draft:         mr.Draft,
+	}, true, nil
+}
@@ -0,0 +1,14 @@
+// Package ingest verifies and parses pull request webhooks of code hostings into domain events
+package ingest
+
+import (
+	"fmt"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// pullRequestID builds the ID of an external pull request. Numbers restart in every repository,
+// so the ID of the repository is a part of it
+func pullRequestID(provider domain.ExternalProvider, repositoryID, number int64) string {
+	return fmt.Sprintf("%s-%d-%d", provider, repositoryID, number)
+}
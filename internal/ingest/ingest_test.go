@@ -0,0 +1,263 @@
+package ingest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// IngestTestSuite проверяет разбор вебхуков провайдеров на фикстурах из testdata
+type IngestTestSuite struct {
+	suite.Suite
+}
+
+func (s *IngestTestSuite) fixture(name string) []byte {
+	payload, err := os.ReadFile(filepath.Join("testdata", name))
+	s.Require().NoError(err)
+	return payload
+}
+
+// TestVerifyGitHub проверяет подпись HMAC-SHA256 тела запроса
+func (s *IngestTestSuite) TestVerifyGitHub() {
+	body := s.fixture("github_pull_request_opened.json")
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	s.NoError(VerifyGitHub("secret", signature, body))
+	s.ErrorIs(VerifyGitHub("other-secret", signature, body), domain.ErrInvalidSignature)
+	s.ErrorIs(VerifyGitHub("secret", signature, append(body, ' ')), domain.ErrInvalidSignature)
+	s.ErrorIs(VerifyGitHub("secret", "", body), domain.ErrInvalidSignature)
+	s.ErrorIs(VerifyGitHub("secret", "sha1=abc", body), domain.ErrInvalidSignature)
+	s.ErrorIs(VerifyGitHub("secret", "sha256=not-hex", body), domain.ErrInvalidSignature)
+}
+
+// TestVerifyGitLab проверяет сравнение токена
+func (s *IngestTestSuite) TestVerifyGitLab() {
+	s.NoError(VerifyGitLab("token", "token"))
+	s.ErrorIs(VerifyGitLab("token", "other"), domain.ErrInvalidSignature)
+	s.ErrorIs(VerifyGitLab("token", ""), domain.ErrInvalidSignature)
+}
+
+// TestParseGitHub проверяет сопоставление действий GitHub с событиями PR
+func (s *IngestTestSuite) TestParseGitHub() {
+	tests := []struct {
+		name    string
+		event   string
+		fixture string
+		want    domain.ExternalPREvent
+		wantOK  bool
+	}{
+		{
+			name:    "opened",
+			event:   "pull_request",
+			fixture: "github_pull_request_opened.json",
+			want: domain.ExternalPREvent{
+				Provider:      domain.ExternalProviderGitHub,
+				Action:        domain.ExternalPROpened,
+				PullRequestID: "github-1296269-1347",
+				Name:          "Amazing new feature",
+				AuthorLogin:   "octocat",
+				Sender:        "octocat",
+			},
+			wantOK: true,
+		},
+		{
+			name:    "opened as draft",
+			event:   "pull_request",
+			fixture: "github_pull_request_opened_draft.json",
+			want: domain.ExternalPREvent{
+				Provider:      domain.ExternalProviderGitHub,
+				Action:        domain.ExternalPROpened,
+				PullRequestID: "github-1296269-1347",
+				Name:          "Amazing new feature",
+				AuthorLogin:   "octocat",
+				Sender:        "octocat",
+				Draft:         true,
+			},
+			wantOK: true,
+		},
+		{
+			name:    "closed with merge",
+			event:   "pull_request",
+			fixture: "github_pull_request_merged.json",
+			want: domain.ExternalPREvent{
+				Provider:      domain.ExternalProviderGitHub,
+				Action:        domain.ExternalPRMerged,
+				PullRequestID: "github-1296269-1347",
+				Name:          "Amazing new feature",
+				AuthorLogin:   "octocat",
+				Sender:        "hubot",
+			},
+			wantOK: true,
+		},
+		{
+			name:    "closed without merge",
+			event:   "pull_request",
+			fixture: "github_pull_request_closed.json",
+			want: domain.ExternalPREvent{
+				Provider:      domain.ExternalProviderGitHub,
+				Action:        domain.ExternalPRClosed,
+				PullRequestID: "github-1296269-1347",
+				Name:          "Amazing new feature",
+				AuthorLogin:   "octocat",
+				Sender:        "hubot",
+			},
+			wantOK: true,
+		},
+		{
+			name:    "ready for review",
+			event:   "pull_request",
+			fixture: "github_pull_request_ready_for_review.json",
+			want: domain.ExternalPREvent{
+				Provider:      domain.ExternalProviderGitHub,
+				Action:        domain.ExternalPRReady,
+				PullRequestID: "github-1296269-1347",
+				Name:          "Amazing new feature",
+				AuthorLogin:   "octocat",
+				Sender:        "octocat",
+			},
+			wantOK: true,
+		},
+		{
+			name:    "reopened",
+			event:   "pull_request",
+			fixture: "github_pull_request_reopened.json",
+			want: domain.ExternalPREvent{
+				Provider:      domain.ExternalProviderGitHub,
+				Action:        domain.ExternalPRReopened,
+				PullRequestID: "github-1296269-1347",
+				Name:          "Amazing new feature",
+				AuthorLogin:   "octocat",
+				Sender:        "hubot",
+			},
+			wantOK: true,
+		},
+		{
+			name:    "action that does not matter",
+			event:   "pull_request",
+			fixture: "github_pull_request_labeled.json",
+		},
+		{
+			name:    "ping",
+			event:   "ping",
+			fixture: "github_ping.json",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			got, ok, err := ParseGitHub(tt.event, s.fixture(tt.fixture))
+
+			s.Require().NoError(err)
+			s.Equal(tt.wantOK, ok)
+			s.Equal(tt.want, got)
+		})
+	}
+
+	s.Run("malformed payload", func() {
+		_, _, err := ParseGitHub("pull_request", []byte(`{"action":`))
+		s.ErrorIs(err, domain.ErrInvalidExternalEvent)
+
+		_, _, err = ParseGitHub("pull_request", []byte(`{"action":"opened","pull_request":{"number":1}}`))
+		s.ErrorIs(err, domain.ErrInvalidExternalEvent)
+	})
+}
+
+// TestParseGitLab проверяет сопоставление действий GitLab с событиями PR
+func (s *IngestTestSuite) TestParseGitLab() {
+	event := func(action domain.ExternalPRAction, user string) domain.ExternalPREvent {
+		return domain.ExternalPREvent{
+			Provider:      domain.ExternalProviderGitLab,
+			Action:        action,
+			PullRequestID: "gitlab-15-7",
+			Name:          "MS-Viewport",
+			AuthorLogin:   user,
+			Sender:        user,
+		}
+	}
+	tests := []struct {
+		name    string
+		event   string
+		fixture string
+		want    domain.ExternalPREvent
+		wantOK  bool
+	}{
+		{
+			name:    "open",
+			event:   "Merge Request Hook",
+			fixture: "gitlab_merge_request_open.json",
+			want:    event(domain.ExternalPROpened, "root"),
+			wantOK:  true,
+		},
+		{
+			name:    "draft removed",
+			event:   "Merge Request Hook",
+			fixture: "gitlab_merge_request_update_ready.json",
+			want:    event(domain.ExternalPRReady, "root"),
+			wantOK:  true,
+		},
+		{
+			name:    "merge",
+			event:   "Merge Request Hook",
+			fixture: "gitlab_merge_request_merge.json",
+			want:    event(domain.ExternalPRMerged, "maintainer"),
+			wantOK:  true,
+		},
+		{
+			name:    "close",
+			event:   "Merge Request Hook",
+			fixture: "gitlab_merge_request_close.json",
+			want:    event(domain.ExternalPRClosed, "maintainer"),
+			wantOK:  true,
+		},
+		{
+			name:    "reopen",
+			event:   "Merge Request Hook",
+			fixture: "gitlab_merge_request_reopen.json",
+			want:    event(domain.ExternalPRReopened, "maintainer"),
+			wantOK:  true,
+		},
+		{
+			name:    "update that does not matter",
+			event:   "Merge Request Hook",
+			fixture: "gitlab_merge_request_update_title.json",
+		},
+		{
+			name:    "other hook",
+			event:   "Push Hook",
+			fixture: "gitlab_merge_request_open.json",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			got, ok, err := ParseGitLab(tt.event, s.fixture(tt.fixture))
+
+			s.Require().NoError(err)
+			s.Equal(tt.wantOK, ok)
+			s.Equal(tt.want, got)
+		})
+	}
+
+	s.Run("malformed payload", func() {
+		_, _, err := ParseGitLab("Merge Request Hook", []byte(`[]`))
+		s.ErrorIs(err, domain.ErrInvalidExternalEvent)
+
+		_, _, err = ParseGitLab("Merge Request Hook",
+			[]byte(`{"object_kind":"merge_request","object_attributes":{"action":"open","iid":7}}`))
+		s.ErrorIs(err, domain.ErrInvalidExternalEvent)
+	})
+}
+
+// TestIngestSuite запускает test suite
+func TestIngestSuite(t *testing.T) {
+	suite.Run(t, new(IngestTestSuite))
+}
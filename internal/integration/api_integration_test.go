@@ -3,9 +3,17 @@ package integration
 import (
 	"bytes"
 	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -13,86 +21,88 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/suite"
-	"github.com/testcontainers/testcontainers-go"
-	"github.com/testcontainers/testcontainers-go/modules/postgres"
-	"github.com/testcontainers/testcontainers-go/wait"
 
-	postgresRepo "github.com/artmexbet/avito_test_task/internal/postgres"
+	"github.com/artmexbet/avito_test_task/internal/auth"
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/internal/ingest"
 	"github.com/artmexbet/avito_test_task/internal/repository"
 	"github.com/artmexbet/avito_test_task/internal/router"
 	"github.com/artmexbet/avito_test_task/internal/service"
+	stats_retriever "github.com/artmexbet/avito_test_task/internal/stats-retriever"
 	"github.com/artmexbet/avito_test_task/pkg/config"
 )
 
 // APIIntegrationTestSuite определяет test suite для интеграционных тестов API
 type APIIntegrationTestSuite struct {
 	suite.Suite
-	ctx         context.Context
-	pgContainer *postgres.PostgresContainer
-	pool        *pgxpool.Pool
-	router      *router.Router
-	app         *fiber.App
-	client      *http.Client
-	baseURL     string
+	ctx     context.Context
+	storage *testStorage
+	router  *router.Router
+	app     *fiber.App
+	client  *http.Client
+	baseURL string
+
+	signingKey *rsa.PrivateKey
 }
 
 // SetupSuite выполняется один раз перед всеми тестами
 func (s *APIIntegrationTestSuite) SetupSuite() {
 	s.ctx = context.Background()
 
-	// Получаем путь к директории с миграциями
-	migrationsPath, err := filepath.Abs("../../migrations")
+	storage, err := newTestStorage(s.ctx)
 	s.Require().NoError(err)
-
-	// Создаем PostgreSQL контейнер
-	pgContainer, err := postgres.Run(s.ctx,
-		"postgres:latest",
-		postgres.WithDatabase("testdb"),
-		postgres.WithUsername("testuser"),
-		postgres.WithPassword("testpass"),
-		postgres.WithInitScripts(
-			filepath.Join(migrationsPath, "01_teams.up.sql"),
-			filepath.Join(migrationsPath, "02_users.up.sql"),
-			filepath.Join(migrationsPath, "03_pull_requests.up.sql"),
-			filepath.Join(migrationsPath, "04_pull_requests_reviewers.up.sql"),
-		),
-		testcontainers.WithWaitStrategy(
-			wait.ForLog("database system is ready to accept connections").
-				WithOccurrence(2).
-				WithStartupTimeout(120*time.Second),
-		),
-	)
-	s.Require().NoError(err)
-	s.pgContainer = pgContainer
-
-	// Получаем connection string
-	connStr, err := pgContainer.ConnectionString(s.ctx, "sslmode=disable")
-	s.Require().NoError(err)
-
-	// Создаем connection pool
-	pool, err := pgxpool.New(s.ctx, connStr)
-	s.Require().NoError(err)
-	s.pool = pool
+	s.storage = storage
 
 	// Инициализируем репозитории и сервисы
-	pg := postgresRepo.New(pool)
-	userRepo := repository.NewUserRepository(pg)
-	reviewersRepo := repository.NewReviewersRepository(pg)
-	prRepo := repository.NewPRRepository(pg)
-	teamRepo := repository.NewTeamRepository(pg)
-
-	prService := service.NewPullRequestService(prRepo, reviewersRepo, userRepo)
-	userService := service.NewUserService(userRepo)
-	teamService := service.NewTeamService(teamRepo, userRepo)
+	userRepo := repository.NewUserRepository(storage)
+	reviewersRepo := repository.NewReviewersRepository(storage)
+	prRepo := repository.NewPRRepository(storage)
+	teamRepo := repository.NewTeamRepository(storage)
+	auditRepo := repository.NewAuditRepository(storage)
+	webhookRepo := repository.NewWebhookRepository(storage)
+	poolRepo := repository.NewReviewerPoolRepository(storage)
+	transactor := repository.NewTransactor(storage)
+
+	prService := service.NewPullRequestService(
+		prRepo, reviewersRepo, userRepo, poolRepo, service.NewRandomSelector(), domain.MergePolicy{}, auditRepo,
+		webhookRepo, transactor, nil,
+	)
+	userService := service.NewUserService(userRepo, prService, auditRepo, transactor)
+	teamService := service.NewTeamService(teamRepo, userRepo, poolRepo, prService, auditRepo, transactor)
+	poolService := service.NewReviewerPoolService(poolRepo, userRepo, auditRepo, transactor)
+	auditService := service.NewAuditService(auditRepo)
+	webhookService := service.NewWebhookService(webhookRepo)
+	externalService := service.NewExternalEventService(
+		repository.NewExternalLoginRepository(storage), prService,
+		config.IngestConfig{GitHubSecret: testGitHubSecret, GitLabToken: testGitLabToken},
+	)
+	absenceService := service.NewAbsenceService(
+		repository.NewAbsenceRepository(storage), userRepo, prService, auditRepo, transactor,
+		config.AbsencesConfig{},
+	)
+	statsRetriever := stats_retriever.NewStatsRetriever(repository.NewStatsRepository(storage))
 
 	// Инициализируем роутер
 	cfg := config.RouterConfig{
 		Host: "localhost",
 		Port: 5000,
 	}
-	s.router = router.New(cfg, userService, prService, teamService, nil)
+	// Ключ, которым подписываются токены пользователей, его публичная часть лежит в JWKS-файле
+	s.signingKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	jwksFile := filepath.Join(s.T().TempDir(), "jwks.json")
+	s.Require().NoError(os.WriteFile(jwksFile, rsaJWKS(&s.signingKey.PublicKey), 0o600))
+	authenticator, err := auth.New(config.AuthConfig{
+		AdminTokens: map[string]string{"ci": testAdminToken},
+		JWKSFile:    jwksFile,
+		Issuer:      testTokenIssuer,
+		Leeway:      time.Second,
+	})
+	s.Require().NoError(err)
+
+	s.router = router.New(cfg, userService, prService, teamService, poolService, auditService, webhookService,
+		externalService, absenceService, statsRetriever, nil, authenticator)
 
 	// Запускаем сервер в фоновом режиме
 	go func() {
@@ -111,23 +121,27 @@ func (s *APIIntegrationTestSuite) TearDownSuite() {
 	if s.router != nil {
 		_ = s.router.Shutdown(s.ctx)
 	}
-	if s.pool != nil {
-		s.pool.Close()
-	}
-	if s.pgContainer != nil {
-		_ = s.pgContainer.Terminate(s.ctx)
+	if s.storage != nil {
+		_ = s.storage.Close(s.ctx)
 	}
 }
 
 // SetupTest выполняется перед каждым тестом
 func (s *APIIntegrationTestSuite) SetupTest() {
-	// Очищаем все таблицы перед каждым тестом
-	_, err := s.pool.Exec(s.ctx, "TRUNCATE TABLE pull_requests_reviewers, pull_requests, users, teams CASCADE")
-	s.Require().NoError(err)
+	// Очищаем хранилище перед каждым тестом
+	s.Require().NoError(s.storage.Reset(s.ctx))
 }
 
-// makeRequest - вспомогательная функция для выполнения HTTP запросов
+// makeRequest - вспомогательная функция для выполнения HTTP запросов от имени администратора
 func (s *APIIntegrationTestSuite) makeRequest(method, path string, body interface{}) (*http.Response, []byte) {
+	return s.makeRequestAs(testAdminToken, method, path, body)
+}
+
+// makeRequestAs выполняет HTTP запрос с токеном, пустой токен - запрос без авторизации
+func (s *APIIntegrationTestSuite) makeRequestAs(
+	token, method, path string,
+	body interface{},
+) (*http.Response, []byte) {
 	var bodyReader io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -141,6 +155,9 @@ func (s *APIIntegrationTestSuite) makeRequest(method, path string, body interfac
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
 	resp, err := s.client.Do(req)
 	s.Require().NoError(err)
@@ -382,6 +399,443 @@ func (s *APIIntegrationTestSuite) TestReassignReviewerAPI() {
 	s.NotEqual(oldReviewerID, newReviewerID)
 }
 
+// TestReviewPRAPI тестирует POST /pullRequest/review
+func (s *APIIntegrationTestSuite) TestReviewPRAPI() {
+	teamReq := map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "user-1", "username": "Alice", "is_active": true},
+			{"user_id": "user-2", "username": "Bob", "is_active": true},
+		},
+	}
+	resp, _ := s.makeRequest("POST", "/team/add", teamReq)
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	createReq := map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Review",
+		"author_id":         "user-1",
+	}
+	resp, _ = s.makeRequest("POST", "/pullRequest/create", createReq)
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	reviewReq := map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"reviewer_id":     "user-2",
+		"verdict":         "APPROVED",
+	}
+	resp, body := s.makeRequest("POST", "/pullRequest/review", reviewReq)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var response map[string]interface{}
+	s.Require().NoError(json.Unmarshal(body, &response))
+	reviews := response["pr"].(map[string]interface{})["reviews"].([]interface{})
+	s.Require().Len(reviews, 1)
+	review := reviews[0].(map[string]interface{})
+	s.Equal("user-2", review["reviewer_id"])
+	s.Equal("APPROVED", review["verdict"])
+	s.NotEmpty(review["submitted_at"])
+
+	// Неизвестный вердикт
+	reviewReq["verdict"] = "LGTM"
+	resp, _ = s.makeRequest("POST", "/pullRequest/review", reviewReq)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	// Автор не ревьювер своего PR
+	reviewReq["verdict"] = "COMMENTED"
+	reviewReq["reviewer_id"] = "user-1"
+	resp, body = s.makeRequest("POST", "/pullRequest/review", reviewReq)
+	s.Equal(http.StatusConflict, resp.StatusCode)
+	s.Require().NoError(json.Unmarshal(body, &response))
+	s.Equal("NOT_ASSIGNED", response["error"].(map[string]interface{})["code"])
+
+	resp, _ = s.makeRequest("POST", "/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	reviewReq["reviewer_id"] = "user-2"
+	resp, body = s.makeRequest("POST", "/pullRequest/review", reviewReq)
+	s.Equal(http.StatusConflict, resp.StatusCode)
+	s.Require().NoError(json.Unmarshal(body, &response))
+	s.Equal("PR_MERGED", response["error"].(map[string]interface{})["code"])
+}
+
+// TestPullRequestLifecycleAPI тестирует /pullRequest/ready, /pullRequest/close и /pullRequest/reopen
+func (s *APIIntegrationTestSuite) TestPullRequestLifecycleAPI() {
+	teamReq := map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "user-1", "username": "Alice", "is_active": true},
+			{"user_id": "user-2", "username": "Bob", "is_active": true},
+		},
+	}
+	resp, _ := s.makeRequest("POST", "/team/add", teamReq)
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	createReq := map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Draft",
+		"author_id":         "user-1",
+		"draft":             true,
+	}
+	resp, body := s.makeRequest("POST", "/pullRequest/create", createReq)
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var response map[string]interface{}
+	s.Require().NoError(json.Unmarshal(body, &response))
+	s.Equal("DRAFT", response["pr"].(map[string]interface{})["status"])
+
+	prReq := map[string]interface{}{"pull_request_id": "pr-1"}
+	resp, body = s.makeRequest("POST", "/pullRequest/reopen", prReq)
+	s.Equal(http.StatusConflict, resp.StatusCode)
+	s.Require().NoError(json.Unmarshal(body, &response))
+	s.Equal("INVALID_TRANSITION", response["error"].(map[string]interface{})["code"])
+
+	resp, body = s.makeRequest("POST", "/pullRequest/ready", prReq)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Require().NoError(json.Unmarshal(body, &response))
+	pr := response["pr"].(map[string]interface{})
+	s.Equal("OPEN", pr["status"])
+	s.Equal([]interface{}{"user-2"}, pr["assigned_reviewers"])
+
+	resp, body = s.makeRequest("POST", "/pullRequest/close", prReq)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Require().NoError(json.Unmarshal(body, &response))
+	s.Equal("CLOSED", response["pr"].(map[string]interface{})["status"])
+
+	resp, body = s.makeRequest("GET", "/users/getReview?user_id=user-2", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Require().NoError(json.Unmarshal(body, &response))
+	s.Empty(response["pull_requests"])
+
+	resp, body = s.makeRequest("POST", "/pullRequest/merge", prReq)
+	s.Equal(http.StatusConflict, resp.StatusCode)
+	s.Require().NoError(json.Unmarshal(body, &response))
+	s.Equal("INVALID_TRANSITION", response["error"].(map[string]interface{})["code"])
+
+	resp, _ = s.makeRequest("POST", "/pullRequest/reopen", prReq)
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	resp, _ = s.makeRequest("POST", "/pullRequest/close", map[string]interface{}{"pull_request_id": "ghost"})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+// TestGetAndListPRAPI тестирует GET /pullRequest/get и GET /pullRequest/list
+func (s *APIIntegrationTestSuite) TestGetAndListPRAPI() {
+	teamReq := map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"user_id": "user-1", "username": "Alice", "is_active": true},
+			{"user_id": "user-2", "username": "Bob", "is_active": true},
+		},
+	}
+	resp, _ := s.makeRequest("POST", "/team/add", teamReq)
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	for _, id := range []string{"pr-1", "pr-2", "pr-3"} {
+		createReq := map[string]interface{}{"pull_request_id": id, "pull_request_name": "List", "author_id": "user-1"}
+		resp, _ = s.makeRequest("POST", "/pullRequest/create", createReq)
+		s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	}
+
+	resp, body := s.makeRequest("GET", "/pullRequest/get?pull_request_id=pr-2", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var response map[string]interface{}
+	s.Require().NoError(json.Unmarshal(body, &response))
+	s.Equal("pr-2", response["pr"].(map[string]interface{})["pull_request_id"])
+
+	resp, _ = s.makeRequest("GET", "/pullRequest/get?pull_request_id=ghost", nil)
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	resp, _ = s.makeRequest("GET", "/pullRequest/get", nil)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	var ids []interface{}
+	path := "/pullRequest/list?author_id=user-1&limit=2"
+	for {
+		resp, body = s.makeRequest("GET", path, nil)
+		s.Require().Equal(http.StatusOK, resp.StatusCode)
+		response = map[string]interface{}{}
+		s.Require().NoError(json.Unmarshal(body, &response))
+		for _, pr := range response["pull_requests"].([]interface{}) {
+			ids = append(ids, pr.(map[string]interface{})["pull_request_id"])
+		}
+		cursor, ok := response["next_cursor"].(string)
+		if !ok {
+			break
+		}
+		path = "/pullRequest/list?author_id=user-1&limit=2&cursor=" + cursor
+	}
+	s.ElementsMatch([]interface{}{"pr-1", "pr-2", "pr-3"}, ids)
+
+	for _, query := range []string{"limit=1000", "limit=abc", "status=REVIEWED", "cursor=%21%21", "created_from=yesterday"} {
+		resp, _ = s.makeRequest("GET", "/pullRequest/list?"+query, nil)
+		s.Equal(http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+// TestAuditListAPI тестирует GET /audit/list: автор берётся из токена, ID запроса - из X-Request-ID
+func (s *APIIntegrationTestSuite) TestAuditListAPI() {
+	teamReq := map[string]interface{}{
+		"team_name": "audit",
+		"members": []map[string]interface{}{
+			{"user_id": "user-1", "username": "Alice", "is_active": true},
+		},
+	}
+	jsonBody, err := json.Marshal(teamReq)
+	s.Require().NoError(err)
+	req, err := http.NewRequest("POST", s.baseURL+"/team/add", bytes.NewBuffer(jsonBody))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	req.Header.Set("X-Request-ID", "req-team")
+	resp, err := s.client.Do(req)
+	s.Require().NoError(err)
+	_ = resp.Body.Close()
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	resp, body := s.makeRequest("GET", "/audit/list?request_id=req-team&limit=1", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var response struct {
+		Events []struct {
+			Action    string                 `json:"action"`
+			EntityID  string                 `json:"entity_id"`
+			Actor     string                 `json:"actor"`
+			RequestID string                 `json:"request_id"`
+			Before    map[string]interface{} `json:"before"`
+			After     map[string]interface{} `json:"after"`
+		} `json:"events"`
+		NextCursor string `json:"next_cursor"`
+	}
+	s.Require().NoError(json.Unmarshal(body, &response))
+	s.Require().Len(response.Events, 1)
+	s.Equal("user.upsert", response.Events[0].Action)
+	s.Equal("user-1", response.Events[0].EntityID)
+	s.Equal("admin:ci", response.Events[0].Actor)
+	s.Equal("req-team", response.Events[0].RequestID)
+	s.Nil(response.Events[0].Before)
+	s.Equal("audit", response.Events[0].After["team_name"])
+	s.Require().NotEmpty(response.NextCursor)
+
+	resp, body = s.makeRequest("GET", "/audit/list?request_id=req-team&cursor="+response.NextCursor, nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	response.NextCursor = ""
+	s.Require().NoError(json.Unmarshal(body, &response))
+	s.Require().Len(response.Events, 1)
+	s.Equal("team.add", response.Events[0].Action)
+	s.Empty(response.NextCursor)
+
+	for _, query := range []string{"limit=1000", "limit=abc", "cursor=abc", "from=yesterday"} {
+		resp, _ = s.makeRequest("GET", "/audit/list?"+query, nil)
+		s.Equal(http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+// TestWebhooksAPI проверяет управление подписками и просмотр доставок через API
+func (s *APIIntegrationTestSuite) TestWebhooksAPI() {
+	resp, body := s.makeRequest("POST", "/webhooks/subscribe", map[string]interface{}{
+		"url":    "https://example.com/hooks",
+		"events": []string{"pull_request.merged", "pull_request.reviewers_assigned"},
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	var created struct {
+		Subscription struct {
+			ID     int64    `json:"id"`
+			URL    string   `json:"url"`
+			Events []string `json:"events"`
+			Secret string   `json:"secret"`
+		} `json:"subscription"`
+	}
+	s.Require().NoError(json.Unmarshal(body, &created))
+	s.NotZero(created.Subscription.ID)
+	s.Equal("https://example.com/hooks", created.Subscription.URL)
+	s.Equal([]string{"pull_request.merged", "pull_request.reviewers_assigned"}, created.Subscription.Events)
+	s.NotEmpty(created.Subscription.Secret)
+
+	// Секрет показывается только при создании
+	resp, body = s.makeRequest("GET", "/webhooks/list", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var list struct {
+		Subscriptions []map[string]interface{} `json:"subscriptions"`
+	}
+	s.Require().NoError(json.Unmarshal(body, &list))
+	s.Require().Len(list.Subscriptions, 1)
+	s.NotContains(list.Subscriptions[0], "secret")
+
+	for _, req := range []map[string]interface{}{
+		{"url": "not a url", "events": []string{"pull_request.merged"}},
+		{"url": "https://example.com/hooks", "events": []string{}},
+		{"url": "https://example.com/hooks", "events": []string{"pull_request.deleted"}},
+		{"url": "https://example.com/hooks", "events": []string{"pull_request.merged"}, "secret": "short"},
+	} {
+		resp, _ = s.makeRequest("POST", "/webhooks/subscribe", req)
+		s.Equal(http.StatusBadRequest, resp.StatusCode, req)
+	}
+
+	resp, body = s.makeRequest("GET", fmt.Sprintf("/webhooks/deliveries?subscription_id=%d", created.Subscription.ID), nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"deliveries":[]}`, string(body))
+	for _, query := range []string{"status=LOST", "limit=1000", "cursor=abc", "subscription_id=abc"} {
+		resp, _ = s.makeRequest("GET", "/webhooks/deliveries?"+query, nil)
+		s.Equal(http.StatusBadRequest, resp.StatusCode, query)
+	}
+
+	resp, _ = s.makeRequest("POST", "/webhooks/redeliver", map[string]interface{}{"delivery_id": 42})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp, _ = s.makeRequest("POST", "/webhooks/unsubscribe", map[string]interface{}{"id": created.Subscription.ID})
+	s.Equal(http.StatusOK, resp.StatusCode)
+	resp, _ = s.makeRequest("POST", "/webhooks/unsubscribe", map[string]interface{}{"id": created.Subscription.ID})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+// Секреты провайдеров, с которыми поднимается тестовый сервер
+const (
+	testGitHubSecret = "github-test-secret"
+	testGitLabToken  = "gitlab-test-token"
+)
+
+// postProviderWebhook отправляет фикстуру из internal/ingest/testdata с заголовками провайдера
+func (s *APIIntegrationTestSuite) postProviderWebhook(
+	path, fixture string,
+	headers map[string]string,
+) (*http.Response, map[string]interface{}) {
+	payload, err := os.ReadFile(filepath.Join("..", "ingest", "testdata", fixture))
+	s.Require().NoError(err)
+
+	req, err := http.NewRequest("POST", s.baseURL+path, bytes.NewReader(payload))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	s.Require().NoError(err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var response map[string]interface{}
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+	return resp, response
+}
+
+// githubHeaders возвращает заголовки GitHub с подписью тела фикстуры
+func (s *APIIntegrationTestSuite) githubHeaders(event, fixture, secret string) map[string]string {
+	payload, err := os.ReadFile(filepath.Join("..", "ingest", "testdata", fixture))
+	s.Require().NoError(err)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return map[string]string{
+		ingest.HeaderGitHubEvent:     event,
+		ingest.HeaderGitHubSignature: "sha256=" + hex.EncodeToString(mac.Sum(nil)),
+	}
+}
+
+// TestProviderWebhooksAPI тестирует приём вебхуков GitHub и GitLab и таблицу соответствия логинов
+func (s *APIIntegrationTestSuite) TestProviderWebhooksAPI() {
+	resp, _ := s.makeRequest("POST", "/team/add", map[string]interface{}{
+		"team_name": "hooks-team",
+		"members": []map[string]interface{}{
+			{"user_id": "user-1", "username": "alice", "is_active": true},
+			{"user_id": "user-2", "username": "bob", "is_active": true},
+			{"user_id": "user-3", "username": "carol", "is_active": true},
+		},
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	// Логин автора ещё не сопоставлен с пользователем
+	opened := s.githubHeaders("pull_request", "github_pull_request_opened.json", testGitHubSecret)
+	resp, response := s.postProviderWebhook("/webhooks/github", "github_pull_request_opened.json", opened)
+	s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	s.Equal("UNKNOWN_LOGIN", response["error"].(map[string]interface{})["code"])
+
+	resp, body := s.makeRequest("POST", "/externalLogins/set", map[string]interface{}{
+		"provider": "github", "login": "octocat", "user_id": "user-1",
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(string(body), `"user_id":"user-1"`)
+	resp, _ = s.makeRequest("POST", "/externalLogins/set", map[string]interface{}{
+		"provider": "gitlab", "login": "root", "user_id": "user-2",
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	resp, _ = s.makeRequest("POST", "/externalLogins/set", map[string]interface{}{
+		"provider": "github", "login": "ghost", "user_id": "user-404",
+	})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	resp, _ = s.makeRequest("POST", "/externalLogins/set", map[string]interface{}{
+		"provider": "bitbucket", "login": "octocat", "user_id": "user-1",
+	})
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	resp, body = s.makeRequest("GET", "/externalLogins/list?provider=github", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var logins struct {
+		ExternalLogins []map[string]interface{} `json:"external_logins"`
+	}
+	s.Require().NoError(json.Unmarshal(body, &logins))
+	s.Require().Len(logins.ExternalLogins, 1)
+	s.Equal("octocat", logins.ExternalLogins[0]["login"])
+
+	// Подпись чужим секретом и неверный токен отклоняются
+	forged := s.githubHeaders("pull_request", "github_pull_request_opened.json", "wrong-secret")
+	resp, response = s.postProviderWebhook("/webhooks/github", "github_pull_request_opened.json", forged)
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+	s.Equal("UNAUTHORIZED", response["error"].(map[string]interface{})["code"])
+	resp, _ = s.postProviderWebhook("/webhooks/gitlab", "gitlab_merge_request_open.json", map[string]string{
+		ingest.HeaderGitLabEvent: "Merge Request Hook", ingest.HeaderGitLabToken: "wrong-token",
+	})
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+
+	// ping подтверждается, но ничего не меняет
+	ping := s.githubHeaders("ping", "github_ping.json", testGitHubSecret)
+	resp, response = s.postProviderWebhook("/webhooks/github", "github_ping.json", ping)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(false, response["applied"])
+
+	// Открытие создаёт PR, повторная доставка пропускается
+	resp, response = s.postProviderWebhook("/webhooks/github", "github_pull_request_opened.json", opened)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(true, response["applied"])
+	s.Equal("github-1296269-1347", response["pull_request_id"])
+	resp, response = s.postProviderWebhook("/webhooks/github", "github_pull_request_opened.json", opened)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(false, response["applied"])
+	s.NotEmpty(response["reason"])
+
+	resp, body = s.makeRequest("GET", "/pullRequest/get?pull_request_id=github-1296269-1347", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(string(body), `"author_id":"user-1"`)
+	s.Contains(string(body), `"status":"OPEN"`)
+
+	// Мердж у провайдера мерджит PR в обход политики
+	merged := s.githubHeaders("pull_request", "github_pull_request_merged.json", testGitHubSecret)
+	resp, response = s.postProviderWebhook("/webhooks/github", "github_pull_request_merged.json", merged)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(true, response["applied"])
+	s.Equal("merged", response["action"])
+
+	// GitLab: открытие и закрытие merge request
+	gitlab := map[string]string{ingest.HeaderGitLabEvent: "Merge Request Hook", ingest.HeaderGitLabToken: testGitLabToken}
+	resp, response = s.postProviderWebhook("/webhooks/gitlab", "gitlab_merge_request_open.json", gitlab)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(true, response["applied"])
+	s.Equal("gitlab-15-7", response["pull_request_id"])
+	resp, response = s.postProviderWebhook("/webhooks/gitlab", "gitlab_merge_request_close.json", gitlab)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(true, response["applied"])
+
+	resp, body = s.makeRequest("GET", "/pullRequest/get?pull_request_id=gitlab-15-7", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(string(body), `"author_id":"user-2"`)
+	s.Contains(string(body), `"status":"CLOSED"`)
+
+	// После удаления соответствия события автора отклоняются
+	resp, _ = s.makeRequest("POST", "/externalLogins/delete", map[string]interface{}{
+		"provider": "gitlab", "login": "root",
+	})
+	s.Equal(http.StatusOK, resp.StatusCode)
+	resp, _ = s.makeRequest("POST", "/externalLogins/delete", map[string]interface{}{
+		"provider": "gitlab", "login": "root",
+	})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
 // TestGetUserReviewAPI тестирует GET /users/getReview
 func (s *APIIntegrationTestSuite) TestGetUserReviewAPI() {
 	// Создаем команду и PR
@@ -420,6 +874,364 @@ func (s *APIIntegrationTestSuite) TestGetUserReviewAPI() {
 	s.GreaterOrEqual(len(pullRequests), 0)
 }
 
+// TestReviewCapacityAPI проверяет установку лимита открытых ревью и нагрузку в /users/getReview
+func (s *APIIntegrationTestSuite) TestReviewCapacityAPI() {
+	teamReq := map[string]interface{}{
+		"team_name": "capacity-team",
+		"members": []map[string]interface{}{
+			{"user_id": "user-1", "username": "alice", "is_active": true},
+			{"user_id": "user-2", "username": "bob", "is_active": true, "max_open_reviews": 1},
+			{"user_id": "user-3", "username": "charlie", "is_active": true},
+		},
+	}
+	resp, _ := s.makeRequest("POST", "/team/add", teamReq)
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	resp, _ = s.makeRequest("POST", "/pullRequest/create", map[string]interface{}{
+		"pull_request_id": "pr-1", "pull_request_name": "Feature", "author_id": "user-1",
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	resp, body := s.makeRequest("GET", "/users/getReview?user_id=user-2", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"user_id":"user-2","pull_requests":[{"pull_request_id":"pr-1","pull_request_name":"Feature",`+
+		`"author_id":"user-1","status":"OPEN"}],"open_reviews":1,"max_open_reviews":1}`, string(body))
+
+	resp, body = s.makeRequest("POST", "/users/setMaxOpenReviews", map[string]interface{}{
+		"user_id": "user-2", "max_open_reviews": 0,
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"user":{"user_id":"user-2","username":"bob","team_name":"capacity-team","is_active":true}}`, string(body))
+
+	resp, _ = s.makeRequest("POST", "/users/setMaxOpenReviews", map[string]interface{}{
+		"user_id": "user-2", "max_open_reviews": 5000,
+	})
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	resp, _ = s.makeRequest("POST", "/users/setMaxOpenReviews", map[string]interface{}{
+		"user_id": "ghost", "max_open_reviews": 1,
+	})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	resp, _ = s.makeRequestAs(s.userToken("user-2", domain.RoleMember), "POST", "/users/setMaxOpenReviews",
+		map[string]interface{}{"user_id": "user-2", "max_open_reviews": 3})
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+}
+
+// TestTeamManagementAPI проверяет эндпоинты перевода пользователей, переименования и удаления команд
+func (s *APIIntegrationTestSuite) TestTeamManagementAPI() {
+	for _, teamReq := range []map[string]interface{}{
+		{
+			"team_name": "backend-team",
+			"members": []map[string]interface{}{
+				{"user_id": "user-1", "username": "alice", "is_active": true},
+				{"user_id": "user-2", "username": "bob", "is_active": true},
+			},
+		},
+		{
+			"team_name": "frontend-team",
+			"members":   []map[string]interface{}{{"user_id": "user-3", "username": "charlie", "is_active": true}},
+		},
+	} {
+		resp, _ := s.makeRequest("POST", "/team/add", teamReq)
+		s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	}
+	resp, _ := s.makeRequest("POST", "/pullRequest/create", map[string]interface{}{
+		"pull_request_id": "pr-1", "pull_request_name": "Feature", "author_id": "user-1",
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	// user-2 уходит из команды автора, заменить его некем
+	resp, body := s.makeRequest("POST", "/team/moveUsers", map[string]interface{}{
+		"team_name": "frontend-team", "user_ids": []string{"user-2"},
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"team_name":"frontend-team","moved_user_ids":["user-2"],`+
+		`"reassignments":[{"pull_request_id":"pr-1","old_reviewer_id":"user-2"}]}`, string(body))
+	resp, _ = s.makeRequest("POST", "/team/moveUsers", map[string]interface{}{
+		"team_name": "frontend-team", "user_ids": []string{"ghost"},
+	})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp, body = s.makeRequest("POST", "/team/rename", map[string]interface{}{
+		"team_name": "frontend-team", "new_team_name": "web-team",
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var renamed map[string]map[string]interface{}
+	s.Require().NoError(json.Unmarshal(body, &renamed))
+	s.Equal("web-team", renamed["team"]["team_name"])
+	s.Len(renamed["team"]["members"], 2)
+	resp, body = s.makeRequest("POST", "/team/rename", map[string]interface{}{
+		"team_name": "web-team", "new_team_name": "backend-team",
+	})
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	s.Contains(string(body), "TEAM_EXISTS")
+
+	resp, body = s.makeRequest("POST", "/team/delete", map[string]interface{}{"team_name": "backend-team"})
+	s.Equal(http.StatusConflict, resp.StatusCode)
+	s.Contains(string(body), "TEAM_NOT_EMPTY")
+	resp, body = s.makeRequest("POST", "/team/delete", map[string]interface{}{
+		"team_name": "backend-team", "move_members_to": "web-team",
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"team_name":"backend-team","moved_to":"web-team","moved_user_ids":["user-1"],"reassignments":[]}`,
+		string(body))
+	resp, _ = s.makeRequest("GET", "/team/get?team_name=backend-team", nil)
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	resp, _ = s.makeRequest("POST", "/team/delete", map[string]interface{}{"team_name": "backend-team"})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp, _ = s.makeRequestAs(s.userToken("user-1", domain.RoleMember), "POST", "/team/rename",
+		map[string]interface{}{"team_name": "web-team", "new_team_name": "mobile-team"})
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+}
+
+// TestReviewerPoolsAPI проверяет общие пулы ревьюверов и запасные пулы команды
+func (s *APIIntegrationTestSuite) TestReviewerPoolsAPI() {
+	for _, teamReq := range []map[string]interface{}{
+		{"team_name": "solo-team", "members": []map[string]interface{}{
+			{"user_id": "user-1", "username": "alice", "is_active": true},
+		}},
+		{"team_name": "platform", "members": []map[string]interface{}{
+			{"user_id": "user-2", "username": "bob", "is_active": true},
+		}},
+		{"team_name": "mobile", "members": []map[string]interface{}{
+			{"user_id": "user-3", "username": "charlie", "is_active": true},
+			{"user_id": "user-4", "username": "diana", "is_active": true},
+		}},
+	} {
+		resp, _ := s.makeRequest("POST", "/team/add", teamReq)
+		s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	}
+	// в команде из одного человека ревьюверов не набрать
+	resp, body := s.makeRequest("POST", "/pullRequest/create", map[string]interface{}{
+		"pull_request_id": "pr-1", "pull_request_name": "Feature", "author_id": "user-1",
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	s.NotContains(string(body), "assigned_reviewers")
+
+	resp, body = s.makeRequest("POST", "/reviewerPools/set", map[string]interface{}{
+		"pool_name": "guild", "user_ids": []string{"user-3", "user-4"},
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var poolResponse map[string]map[string]interface{}
+	s.Require().NoError(json.Unmarshal(body, &poolResponse))
+	s.Len(poolResponse["pool"]["members"], 2)
+	resp, _ = s.makeRequest("POST", "/reviewerPools/set", map[string]interface{}{
+		"pool_name": "guild", "user_ids": []string{"ghost"},
+	})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	resp, _ = s.makeRequestAs(s.userToken("user-1", domain.RoleMember), "POST", "/reviewerPools/set",
+		map[string]interface{}{"pool_name": "guild", "user_ids": []string{"user-1"}})
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+
+	for fallbacks, status := range map[string]int{
+		`[{"pool_type":"team","pool_name":"solo-team"}]`: http.StatusBadRequest,
+		`[{"pool_type":"group","pool_name":"guild"}]`:    http.StatusBadRequest,
+		`[{"pool_type":"shared","pool_name":"ghost"}]`:   http.StatusNotFound,
+		`[{"pool_type":"team","pool_name":"ghost"}]`:     http.StatusNotFound,
+	} {
+		resp, _ = s.makeRequest("POST", "/team/setFallbacks", map[string]interface{}{
+			"team_name": "solo-team", "fallbacks": json.RawMessage(fallbacks),
+		})
+		s.Equal(status, resp.StatusCode, fallbacks)
+	}
+	resp, body = s.makeRequest("POST", "/team/setFallbacks", map[string]interface{}{
+		"team_name": "solo-team",
+		"fallbacks": []map[string]string{
+			{"pool_type": "team", "pool_name": "platform"},
+			{"pool_type": "shared", "pool_name": "guild"},
+		},
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(string(body), `"fallbacks":[{"pool_type":"team","pool_name":"platform"},`+
+		`{"pool_type":"shared","pool_name":"guild"}]`)
+
+	// открытый PR добрал ревьюверов из запасных пулов: одного из команды и одного из общего пула
+	resp, body = s.makeRequest("GET", "/pullRequest/get?pull_request_id=pr-1", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var prResponse struct {
+		PR struct {
+			Reviewers     []string `json:"assigned_reviewers"`
+			ReviewerPools []struct {
+				ReviewerID string `json:"reviewer_id"`
+				PoolType   string `json:"pool_type"`
+				PoolName   string `json:"pool_name"`
+			} `json:"reviewer_pools"`
+			NeedMoreReviewers bool `json:"need_more_reviewers"`
+		} `json:"pr"`
+	}
+	s.Require().NoError(json.Unmarshal(body, &prResponse))
+	s.False(prResponse.PR.NeedMoreReviewers)
+	s.Require().Len(prResponse.PR.ReviewerPools, 2)
+	var guildReviewer string
+	for i, pool := range prResponse.PR.ReviewerPools {
+		s.Equal(prResponse.PR.Reviewers[i], pool.ReviewerID)
+		if pool.PoolType == "shared" {
+			s.Equal("guild", pool.PoolName)
+			guildReviewer = pool.ReviewerID
+		} else {
+			s.Equal("platform", pool.PoolName)
+			s.Equal("user-2", pool.ReviewerID)
+		}
+	}
+	s.Require().NotEmpty(guildReviewer)
+
+	// замена ревьюверу из общего пула ищется в том же пуле
+	resp, body = s.makeRequest("POST", "/pullRequest/reassign", map[string]interface{}{
+		"pull_request_id": "pr-1", "old_user_id": guildReviewer,
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(string(body), `"pool_type":"shared","pool_name":"guild"`)
+	s.NotContains(string(body), `"reviewer_id":"`+guildReviewer+`"`)
+
+	resp, _ = s.makeRequest("POST", "/reviewerPools/delete", map[string]interface{}{"pool_name": "guild"})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	resp, _ = s.makeRequest("GET", "/reviewerPools/get?pool_name=guild", nil)
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	resp, body = s.makeRequest("GET", "/team/get?team_name=solo-team", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(string(body), `"fallbacks":[{"pool_type":"team","pool_name":"platform"}]`)
+}
+
+// TestTeamAddModesAPI проверяет режимы /team/add и ответ с конфликтами
+func (s *APIIntegrationTestSuite) TestTeamAddModesAPI() {
+	resp, body := s.makeRequest("POST", "/team/add", map[string]interface{}{
+		"team_name": "backend-team",
+		"members": []map[string]interface{}{
+			{"user_id": "user-1", "username": "alice", "is_active": true},
+			{"user_id": "user-2", "username": "bob", "is_active": true},
+		},
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	s.JSONEq(`{"team":{"team_name":"backend-team","members":[`+
+		`{"user_id":"user-1","username":"alice","is_active":true},{"user_id":"user-2","username":"bob","is_active":true}]},`+
+		`"created_user_ids":["user-1","user-2"],"moved_user_ids":[],"untouched_user_ids":[],"activated_user_ids":[],`+
+		`"deactivated_user_ids":[],"reassignments":[]}`, string(body))
+
+	frontend := map[string]interface{}{
+		"team_name": "frontend-team",
+		"members": []map[string]interface{}{
+			{"user_id": "user-2", "username": "bob", "is_active": true},
+			{"user_id": "user-3", "username": "charlie", "is_active": true},
+		},
+	}
+	resp, body = s.makeRequest("POST", "/team/add", frontend)
+	s.Equal(http.StatusConflict, resp.StatusCode)
+	s.JSONEq(`{"error":{"code":"TEAM_MEMBER_CONFLICT","message":"team members conflict with existing users, `+
+		`nothing was changed"},"conflicts":[{"user_id":"user-2","reason":"user_exists","current_team":"backend-team"}]}`,
+		string(body))
+
+	frontend["mode"] = "merge"
+	resp, body = s.makeRequest("POST", "/team/add", frontend)
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	var added map[string]interface{}
+	s.Require().NoError(json.Unmarshal(body, &added))
+	s.Equal([]interface{}{"user-3"}, added["created_user_ids"])
+	s.Equal([]interface{}{"user-2"}, added["moved_user_ids"])
+
+	// повторный merge ничего не меняет
+	resp, body = s.makeRequest("POST", "/team/add", frontend)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Require().NoError(json.Unmarshal(body, &added))
+	s.Equal([]interface{}{"user-2", "user-3"}, added["untouched_user_ids"])
+
+	resp, body = s.makeRequest("POST", "/team/add", map[string]interface{}{
+		"team_name": "frontend-team",
+		"mode":      "replace",
+		"members":   []map[string]interface{}{{"user_id": "user-3", "username": "charlie", "is_active": true}},
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Require().NoError(json.Unmarshal(body, &added))
+	s.Equal([]interface{}{"user-2"}, added["deactivated_user_ids"])
+	resp, body = s.makeRequest("GET", "/users/get?user_id=user-2", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(string(body), `"team_name":"frontend-team"`)
+	s.Contains(string(body), `"is_active":false`)
+
+	frontend["mode"] = "upsert"
+	resp, _ = s.makeRequest("POST", "/team/add", frontend)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestUserDirectoryAPI проверяет получение, список и удаление пользователей
+func (s *APIIntegrationTestSuite) TestUserDirectoryAPI() {
+	resp, _ := s.makeRequest("POST", "/team/add", map[string]interface{}{
+		"team_name": "backend-team",
+		"members": []map[string]interface{}{
+			{"user_id": "user-1", "username": "alice", "is_active": true},
+			{"user_id": "user-2", "username": "Alex", "is_active": false},
+			{"user_id": "user-3", "username": "bob", "is_active": true},
+			{"user_id": "user-4", "username": "carol", "is_active": true},
+		},
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	resp, body := s.makeRequest("POST", "/pullRequest/create", map[string]interface{}{
+		"pull_request_id": "pr-1", "pull_request_name": "Feature", "author_id": "user-1",
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	var created map[string]map[string]interface{}
+	s.Require().NoError(json.Unmarshal(body, &created))
+	s.Require().Len(created["pr"]["assigned_reviewers"], 2)
+
+	resp, body = s.makeRequestAs(s.userToken("user-3", domain.RoleMember), "GET", "/users/get?user_id=user-1", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"user":{"user_id":"user-1","username":"alice","team_name":"backend-team","is_active":true}}`,
+		string(body))
+	resp, _ = s.makeRequest("GET", "/users/get?user_id=ghost", nil)
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	resp, _ = s.makeRequest("GET", "/users/get", nil)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	resp, body = s.makeRequest("GET", "/users/list?username_prefix=al&limit=1", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"users":[{"user_id":"user-1","username":"alice","team_name":"backend-team","is_active":true}],`+
+		`"next_cursor":"user-1"}`, string(body))
+	resp, body = s.makeRequest("GET", "/users/list?username_prefix=al&limit=1&cursor=user-1", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"users":[{"user_id":"user-2","username":"Alex","team_name":"backend-team","is_active":false}]}`,
+		string(body))
+	resp, body = s.makeRequest("GET", "/users/list?team_name=backend-team&is_active=true", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var page struct {
+		Users []map[string]interface{} `json:"users"`
+	}
+	s.Require().NoError(json.Unmarshal(body, &page))
+	s.Len(page.Users, 3)
+	for _, query := range []string{"is_active=maybe", "limit=0x", "limit=101"} {
+		resp, body = s.makeRequest("GET", "/users/list?"+query, nil)
+		s.Equal(http.StatusBadRequest, resp.StatusCode, query)
+		s.Contains(string(body), "BAD_REQUEST")
+	}
+
+	// удалять может только администратор
+	resp, _ = s.makeRequestAs(s.leadToken("user-1", "backend"), "DELETE", "/users/delete?user_id=user-3", nil)
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	reviewer := created["pr"]["assigned_reviewers"].([]interface{})[0].(string)
+	resp, body = s.makeRequest("DELETE", "/users/delete?user_id="+reviewer, nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var deleted struct {
+		User          map[string]interface{}   `json:"user"`
+		Reassignments []map[string]interface{} `json:"reassignments"`
+	}
+	s.Require().NoError(json.Unmarshal(body, &deleted))
+	s.Equal(reviewer, deleted.User["user_id"])
+	s.Equal(false, deleted.User["is_active"])
+	s.Require().Len(deleted.Reassignments, 1)
+	s.Equal(reviewer, deleted.Reassignments[0]["old_reviewer_id"])
+
+	resp, _ = s.makeRequest("DELETE", "/users/delete?user_id="+reviewer, nil)
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	resp, _ = s.makeRequest("GET", "/users/get?user_id="+reviewer, nil)
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	resp, body = s.makeRequest("GET", "/users/list?team_name=backend-team", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.NotContains(string(body), `"`+reviewer+`"`)
+	resp, _ = s.makeRequest("POST", "/users/setIsActive", map[string]interface{}{"user_id": reviewer, "is_active": true})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp, body = s.makeRequest("GET", "/pullRequest/get?pull_request_id=pr-1", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.NotContains(string(body), `"`+reviewer+`"`)
+}
+
 // TestErrorCases тестирует различные ошибочные случаи
 func (s *APIIntegrationTestSuite) TestErrorCases() {
 	// Попытка получить несуществующую команду
@@ -463,6 +1275,374 @@ func (s *APIIntegrationTestSuite) TestErrorCases() {
 	s.Equal("NOT_FOUND", errorObj["code"])
 }
 
+// TestGetStatsAPI тестирует GET /stats/get с фильтрами
+func (s *APIIntegrationTestSuite) TestGetStatsAPI() {
+	teamReq := map[string]interface{}{
+		"team_name": "stats-team",
+		"members": []map[string]interface{}{
+			{"user_id": "user-1", "username": "Alice", "is_active": true},
+			{"user_id": "user-2", "username": "Bob", "is_active": true},
+		},
+	}
+	resp, _ := s.makeRequest("POST", "/team/add", teamReq)
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	createReq := map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Stats",
+		"author_id":         "user-1",
+	}
+	resp, _ = s.makeRequest("POST", "/pullRequest/create", createReq)
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	today := time.Now().UTC().Format(time.DateOnly)
+	resp, body := s.makeRequest("GET", "/stats/get?team_name=stats-team&period=month&from="+today, nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var stats stats_retriever.Stats
+	s.Require().NoError(json.Unmarshal(body, &stats))
+	s.Require().Len(stats.PullRequests, 1)
+	s.Equal(1, stats.PullRequests[0].Opened)
+	s.Require().Len(stats.Assignments, 1)
+	s.Equal("user-2", stats.Assignments[0].ReviewerID)
+
+	// Некорректные параметры
+	for _, query := range []string{"period=year", "from=yesterday", "from=2025-02-01&to=2025-01-01"} {
+		resp, body = s.makeRequest("GET", "/stats/get?"+query, nil)
+		s.Equal(http.StatusBadRequest, resp.StatusCode, query)
+
+		var response map[string]interface{}
+		s.Require().NoError(json.Unmarshal(body, &response))
+		s.Equal("BAD_REQUEST", response["error"].(map[string]interface{})["code"])
+	}
+}
+
+// Статический токен администратора и издатель токенов пользователей тестового сервера
+const (
+	testAdminToken  = "integration-admin-token"
+	testTokenIssuer = "https://sso.example.com"
+)
+
+// rsaJWKS возвращает JWKS с одним RSA-ключом
+func rsaJWKS(key *rsa.PublicKey) []byte {
+	jwks, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test-key",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	return jwks
+}
+
+// signToken выпускает RS256 JWT с заданными утверждениями
+func (s *APIIntegrationTestSuite) signToken(claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": "test-key", "typ": "JWT"})
+	s.Require().NoError(err)
+	payload, err := json.Marshal(claims)
+	s.Require().NoError(err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.signingKey, crypto.SHA256, digest[:])
+	s.Require().NoError(err)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// userToken выпускает действующий токен пользователя с ролью
+func (s *APIIntegrationTestSuite) userToken(userID string, role domain.Role) string {
+	return s.signToken(map[string]interface{}{
+		"sub":  userID,
+		"role": role,
+		"iss":  testTokenIssuer,
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	})
+}
+
+// leadToken выпускает действующий токен тимлида команды
+func (s *APIIntegrationTestSuite) leadToken(userID, teamName string) string {
+	return s.signToken(map[string]interface{}{
+		"sub":       userID,
+		"role":      domain.RoleTeamLead,
+		"team_name": teamName,
+		"iss":       testTokenIssuer,
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	})
+}
+
+// TestAuthAPI проверяет аутентификацию и права ролей
+func (s *APIIntegrationTestSuite) TestAuthAPI() {
+	resp, _ := s.makeRequest("POST", "/team/add", map[string]interface{}{
+		"team_name": "auth-team",
+		"members": []map[string]interface{}{
+			{"user_id": "user-1", "username": "alice", "is_active": true},
+			{"user_id": "user-2", "username": "bob", "is_active": true},
+			{"user_id": "user-3", "username": "carol", "is_active": true},
+		},
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	// Без токена и с негодными токенами запросы отклоняются
+	expired := s.signToken(map[string]interface{}{
+		"sub": "user-1", "role": "member", "iss": testTokenIssuer, "exp": time.Now().Add(-time.Hour).Unix(),
+	})
+	foreign := s.signToken(map[string]interface{}{
+		"sub": "user-1", "role": "member", "iss": "https://evil.example.com", "exp": time.Now().Add(time.Hour).Unix(),
+	})
+	unknownRole := s.signToken(map[string]interface{}{
+		"sub": "user-1", "role": "owner", "iss": testTokenIssuer, "exp": time.Now().Add(time.Hour).Unix(),
+	})
+	leadWithoutTeam := s.userToken("user-2", domain.RoleTeamLead)
+	for name, token := range map[string]string{
+		"no token":          "",
+		"wrong secret":      "integration-admin-token-2",
+		"expired":           expired,
+		"foreign":           foreign,
+		"unknown role":      unknownRole,
+		"lead without team": leadWithoutTeam,
+	} {
+		resp, body := s.makeRequestAs(token, "GET", "/team/get?team_name=auth-team", nil)
+		s.Equal(http.StatusUnauthorized, resp.StatusCode, name)
+		s.Contains(string(body), "UNAUTHORIZED", name)
+	}
+
+	member := s.userToken("user-1", domain.RoleMember)
+	lead := s.leadToken("user-2", "auth-team")
+
+	// Участник не меняет команды и флаги активности и видит только свои ревью
+	resp, _ = s.makeRequestAs(member, "GET", "/team/get?team_name=auth-team", nil)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	resp, body := s.makeRequestAs(member, "POST", "/users/setIsActive", map[string]interface{}{
+		"user_id": "user-3", "is_active": false,
+	})
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	s.Contains(string(body), "FORBIDDEN")
+	resp, _ = s.makeRequestAs(member, "POST", "/team/add", map[string]interface{}{
+		"team_name": "member-team",
+		"members":   []map[string]interface{}{{"user_id": "user-9", "username": "eve", "is_active": true}},
+	})
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	resp, _ = s.makeRequestAs(member, "GET", "/users/getReview?user_id=user-1", nil)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	resp, _ = s.makeRequestAs(member, "GET", "/users/getReview?user_id=user-2", nil)
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	resp, _ = s.makeRequestAs(member, "GET", "/audit/list", nil)
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+
+	// Участник работает с PR и ревью от своего имени, но мерджить в обход политики может только администратор
+	resp, _ = s.makeRequestAs(member, "POST", "/pullRequest/create", map[string]interface{}{
+		"pull_request_id": "pr-auth", "pull_request_name": "Auth", "author_id": "user-1",
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	resp, body = s.makeRequestAs(member, "POST", "/pullRequest/review", map[string]interface{}{
+		"pull_request_id": "pr-auth", "reviewer_id": "user-2", "verdict": "APPROVED",
+	})
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	s.Contains(string(body), "FORBIDDEN")
+	resp, _ = s.makeRequestAs(member, "GET", "/pullRequest/list?reviewer_id=user-2", nil)
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	resp, _ = s.makeRequestAs(member, "GET", "/pullRequest/list?reviewer_id=user-1", nil)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	resp, _ = s.makeRequestAs(member, "GET", "/pullRequest/list?author_id=user-1", nil)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	resp, _ = s.makeRequestAs(lead, "POST", "/pullRequest/merge", map[string]interface{}{
+		"pull_request_id": "pr-auth", "force": true,
+	})
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	resp, _ = s.makeRequestAs(member, "POST", "/pullRequest/merge", map[string]interface{}{
+		"pull_request_id": "pr-auth",
+	})
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	// Тимлид меняет флаги активности и смотрит чужие ревью
+	resp, _ = s.makeRequestAs(lead, "POST", "/users/setIsActive", map[string]interface{}{
+		"user_id": "user-3", "is_active": false,
+	})
+	s.Equal(http.StatusOK, resp.StatusCode)
+	resp, _ = s.makeRequestAs(lead, "GET", "/users/getReview?user_id=user-1", nil)
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	// Чужой командой и её участниками тимлид не управляет
+	resp, _ = s.makeRequest("POST", "/team/add", map[string]interface{}{
+		"team_name": "other-team",
+		"members":   []map[string]interface{}{{"user_id": "user-4", "username": "dave", "is_active": true}},
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	for name, req := range map[string]struct {
+		path string
+		body map[string]interface{}
+	}{
+		"set is active": {
+			path: "/users/setIsActive",
+			body: map[string]interface{}{"user_id": "user-4", "is_active": false},
+		},
+		"set max reviews": {
+			path: "/users/setMaxOpenReviews",
+			body: map[string]interface{}{"user_id": "user-4", "max_open_reviews": 1},
+		},
+		"deactivate users": {
+			path: "/team/deactivateUsers",
+			body: map[string]interface{}{"team_name": "other-team", "user_ids": []string{"user-4"}},
+		},
+		"move foreign users": {
+			path: "/team/moveUsers",
+			body: map[string]interface{}{"team_name": "auth-team", "user_ids": []string{"user-4"}},
+		},
+		"add to foreign team": {
+			path: "/team/add",
+			body: map[string]interface{}{
+				"team_name": "other-team", "mode": "merge",
+				"members": []map[string]interface{}{{"user_id": "user-5", "username": "erin", "is_active": true}},
+			},
+		},
+		"take foreign member": {
+			path: "/team/add",
+			body: map[string]interface{}{
+				"team_name": "auth-team", "mode": "merge",
+				"members": []map[string]interface{}{{"user_id": "user-4", "username": "dave", "is_active": true}},
+			},
+		},
+	} {
+		resp, body = s.makeRequestAs(lead, "POST", req.path, req.body)
+		s.Equal(http.StatusForbidden, resp.StatusCode, name)
+		s.Contains(string(body), "FORBIDDEN", name)
+	}
+	resp, body = s.makeRequest("GET", "/users/get?user_id=user-4", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(string(body), `"team_name":"other-team"`)
+	s.Contains(string(body), `"is_active":true`)
+	resp, _ = s.makeRequestAs(lead, "POST", "/webhooks/subscribe", map[string]interface{}{
+		"url": "https://example.com/hooks", "events": []string{"pull_request.merged"},
+	})
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+
+	// Автором событий аудита становится пользователь из токена
+	resp, body = s.makeRequest("GET", "/audit/list?entity_id=pr-auth", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var audit struct {
+		Events []struct {
+			Action string `json:"action"`
+			Actor  string `json:"actor"`
+		} `json:"events"`
+	}
+	s.Require().NoError(json.Unmarshal(body, &audit))
+	actors := make(map[string]string, len(audit.Events))
+	for _, event := range audit.Events {
+		actors[event.Action] = event.Actor
+	}
+	s.Equal("user-1", actors[string(domain.AuditActionPRCreate)])
+	s.Equal("user-1", actors[string(domain.AuditActionPRMerge)])
+}
+
+// TestAbsencesAPI тестирует /absences/*: отсутствующий пользователь не назначается ревьювером,
+// участник планирует только свои отсутствия
+func (s *APIIntegrationTestSuite) TestAbsencesAPI() {
+	resp, _ := s.makeRequest("POST", "/team/add", map[string]interface{}{
+		"team_name": "absence-team",
+		"members": []map[string]interface{}{
+			{"user_id": "user-1", "username": "alice", "is_active": true},
+			{"user_id": "user-2", "username": "bob", "is_active": true},
+			{"user_id": "user-3", "username": "carol", "is_active": true},
+		},
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	now := time.Now().UTC()
+	member := s.userToken("user-2", domain.RoleMember)
+	resp, body := s.makeRequestAs(member, "POST", "/absences/add", map[string]interface{}{
+		"user_id":   "user-2",
+		"starts_at": now.Add(-time.Minute).Format(time.RFC3339),
+		"ends_at":   now.Add(time.Hour).Format(time.RFC3339),
+		"reason":    "vacation",
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	var added struct {
+		Absence struct {
+			ID     int64  `json:"absence_id"`
+			UserID string `json:"user_id"`
+			Reason string `json:"reason"`
+		} `json:"absence"`
+	}
+	s.Require().NoError(json.Unmarshal(body, &added))
+	s.Equal("user-2", added.Absence.UserID)
+	s.Equal("vacation", added.Absence.Reason)
+
+	// Участник не планирует отсутствия за других и не видит их
+	resp, _ = s.makeRequestAs(member, "POST", "/absences/add", map[string]interface{}{
+		"user_id":   "user-3",
+		"starts_at": now.Format(time.RFC3339),
+		"ends_at":   now.Add(time.Hour).Format(time.RFC3339),
+	})
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	resp, _ = s.makeRequestAs(s.userToken("user-3", domain.RoleMember), "POST", "/absences/delete",
+		map[string]interface{}{"absence_id": added.Absence.ID})
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	resp, _ = s.makeRequestAs(member, "GET", "/absences/list?user_id=user-3", nil)
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+
+	// Негодный период
+	resp, body = s.makeRequest("POST", "/absences/add", map[string]interface{}{
+		"user_id":   "user-3",
+		"starts_at": now.Format(time.RFC3339),
+		"ends_at":   now.Add(-time.Hour).Format(time.RFC3339),
+	})
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	s.Contains(string(body), "ends_at must be after starts_at")
+	resp, _ = s.makeRequest("POST", "/absences/update", map[string]interface{}{
+		"absence_id": 100500,
+		"starts_at":  now.Format(time.RFC3339),
+		"ends_at":    now.Add(time.Hour).Format(time.RFC3339),
+	})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+
+	// user-2 в отпуске, у PR остаётся единственный ревьювер
+	resp, body = s.makeRequest("POST", "/pullRequest/create", map[string]interface{}{
+		"pull_request_id": "pr-absence", "pull_request_name": "Absence", "author_id": "user-1",
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	s.Contains(string(body), `"assigned_reviewers":["user-3"]`)
+
+	resp, body = s.makeRequestAs(member, "GET", "/absences/list", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	var listed struct {
+		Absences []struct {
+			ID int64 `json:"absence_id"`
+		} `json:"absences"`
+	}
+	s.Require().NoError(json.Unmarshal(body, &listed))
+	s.Require().Len(listed.Absences, 1)
+	s.Equal(added.Absence.ID, listed.Absences[0].ID)
+
+	// Досрочное возвращение: отсутствие закончилось, PR добирает ревьювера
+	resp, _ = s.makeRequestAs(member, "POST", "/absences/update", map[string]interface{}{
+		"absence_id": added.Absence.ID,
+		"starts_at":  now.Add(-time.Hour).Format(time.RFC3339),
+		"ends_at":    now.Add(-time.Minute).Format(time.RFC3339),
+		"reason":     "back early",
+	})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	resp, body = s.makeRequest("GET", "/pullRequest/get?pull_request_id=pr-absence", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(string(body), "user-2")
+
+	// Прошедшие отсутствия видны только по include_past
+	resp, body = s.makeRequest("GET", "/absences/list?team_name=absence-team", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"absences":[]}`, string(body))
+	resp, body = s.makeRequest("GET", "/absences/list?team_name=absence-team&include_past=true", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(string(body), "back early")
+
+	resp, _ = s.makeRequestAs(member, "POST", "/absences/delete", map[string]interface{}{
+		"absence_id": added.Absence.ID,
+	})
+	s.Equal(http.StatusOK, resp.StatusCode)
+	resp, _ = s.makeRequest("POST", "/absences/delete", map[string]interface{}{"absence_id": added.Absence.ID})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
 // TestAPIIntegrationTestSuite запускает test suite
 func TestAPIIntegrationTestSuite(t *testing.T) {
 	if os.Getenv("INTEGRATION_TESTS") == "" {
@@ -4,19 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"sync"
 	"testing"
-	"time"
 
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/suite"
-	"github.com/testcontainers/testcontainers-go"
-	"github.com/testcontainers/testcontainers-go/modules/postgres"
-	"github.com/testcontainers/testcontainers-go/wait"
 
 	"github.com/artmexbet/avito_test_task/internal/domain"
-	postgresRepo "github.com/artmexbet/avito_test_task/internal/postgres"
 	"github.com/artmexbet/avito_test_task/internal/repository"
 	"github.com/artmexbet/avito_test_task/internal/service"
 )
@@ -25,72 +18,67 @@ import (
 type EdgeCasesTestSuite struct {
 	suite.Suite
 	ctx         context.Context
-	pgContainer *postgres.PostgresContainer
-	pool        *pgxpool.Pool
+	storage     *testStorage
 	prService   *service.PullRequestService
 	userService *service.UserService
 	teamService *service.TeamService
+	// prServiceLeastLoaded использует стратегию least_loaded вместо случайной
+	prServiceLeastLoaded *service.PullRequestService
+	reviewersRepo        *repository.ReviewersRepository
+	prRepo               *repository.PRRepository
+	userRepo             *repository.UserRepository
+	auditRepo            *repository.AuditRepository
+	webhookRepo          *repository.WebhookRepository
+	transactor           *repository.Transactor
+	poolRepo             *repository.ReviewerPoolRepository
 }
 
 // SetupSuite выполняется один раз перед всеми тестами
 func (s *EdgeCasesTestSuite) SetupSuite() {
 	s.ctx = context.Background()
 
-	migrationsPath, err := filepath.Abs("../../migrations")
-	s.Require().NoError(err)
-
-	pgContainer, err := postgres.Run(s.ctx,
-		"postgres:latest",
-		postgres.WithDatabase("testdb"),
-		postgres.WithUsername("testuser"),
-		postgres.WithPassword("testpass"),
-		postgres.WithInitScripts(
-			filepath.Join(migrationsPath, "01_teams.up.sql"),
-			filepath.Join(migrationsPath, "02_users.up.sql"),
-			filepath.Join(migrationsPath, "03_pull_requests.up.sql"),
-			filepath.Join(migrationsPath, "04_pull_requests_reviewers.up.sql"),
-		),
-		testcontainers.WithWaitStrategy(
-			wait.ForLog("database system is ready to accept connections").
-				WithOccurrence(2).
-				WithStartupTimeout(120*time.Second),
-		),
-	)
-	s.Require().NoError(err)
-	s.pgContainer = pgContainer
-
-	connStr, err := pgContainer.ConnectionString(s.ctx, "sslmode=disable")
+	storage, err := newTestStorage(s.ctx)
 	s.Require().NoError(err)
-
-	pool, err := pgxpool.New(s.ctx, connStr)
-	s.Require().NoError(err)
-	s.pool = pool
-
-	pg := postgresRepo.New(pool)
-	userRepo := repository.NewUserRepository(pg)
-	reviewersRepo := repository.NewReviewersRepository(pg)
-	prRepo := repository.NewPRRepository(pg)
-	teamRepo := repository.NewTeamRepository(pg)
-
-	s.prService = service.NewPullRequestService(prRepo, reviewersRepo, userRepo)
-	s.userService = service.NewUserService(userRepo)
-	s.teamService = service.NewTeamService(teamRepo, userRepo)
+	s.storage = storage
+
+	userRepo := repository.NewUserRepository(storage)
+	reviewersRepo := repository.NewReviewersRepository(storage)
+	prRepo := repository.NewPRRepository(storage)
+	teamRepo := repository.NewTeamRepository(storage)
+	auditRepo := repository.NewAuditRepository(storage)
+	webhookRepo := repository.NewWebhookRepository(storage)
+	poolRepo := repository.NewReviewerPoolRepository(storage)
+	transactor := repository.NewTransactor(storage)
+	s.prRepo = prRepo
+	s.poolRepo = poolRepo
+	s.auditRepo = auditRepo
+	s.webhookRepo = webhookRepo
+	s.userRepo = userRepo
+	s.transactor = transactor
+
+	s.prService = service.NewPullRequestService(
+		prRepo, reviewersRepo, userRepo, poolRepo, service.NewRandomSelector(), domain.MergePolicy{}, auditRepo,
+		webhookRepo, transactor, nil,
+	)
+	s.prServiceLeastLoaded = service.NewPullRequestService(
+		prRepo, reviewersRepo, userRepo, poolRepo, service.NewLeastLoadedSelector(reviewersRepo), domain.MergePolicy{},
+		auditRepo, webhookRepo, transactor, nil,
+	)
+	s.reviewersRepo = reviewersRepo
+	s.userService = service.NewUserService(userRepo, s.prService, auditRepo, transactor)
+	s.teamService = service.NewTeamService(teamRepo, userRepo, poolRepo, s.prService, auditRepo, transactor)
 }
 
 // TearDownSuite выполняется один раз после всех тестов
 func (s *EdgeCasesTestSuite) TearDownSuite() {
-	if s.pool != nil {
-		s.pool.Close()
-	}
-	if s.pgContainer != nil {
-		_ = s.pgContainer.Terminate(s.ctx)
+	if s.storage != nil {
+		_ = s.storage.Close(s.ctx)
 	}
 }
 
 // SetupTest выполняется перед каждым тестом
 func (s *EdgeCasesTestSuite) SetupTest() {
-	_, err := s.pool.Exec(s.ctx, "TRUNCATE TABLE pull_requests_reviewers, pull_requests, users, teams CASCADE")
-	s.Require().NoError(err)
+	s.Require().NoError(s.storage.Reset(s.ctx))
 }
 
 // TestSingleUserTeam тестирует команду с одним пользователем
@@ -102,10 +90,10 @@ func (s *EdgeCasesTestSuite) TestSingleUserTeam() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
-	// Попытка создать PR в команде с одним человеком
+	// PR в команде с одним человеком создаётся без ревьюверов и помечается флагом
 	pr := domain.PullRequest{
 		ID:       "pr-solo",
 		Name:     "Solo PR",
@@ -113,8 +101,95 @@ func (s *EdgeCasesTestSuite) TestSingleUserTeam() {
 		Status:   domain.PRStatusOpen,
 	}
 
-	_, err = s.prService.Create(s.ctx, pr)
+	createdPR, err := s.prService.Create(s.ctx, pr)
+	s.Require().NoError(err)
+	s.Empty(createdPR.Reviewers)
+	s.True(createdPR.NeedMoreReviewers)
+
+	fromDB, err := s.prRepo.GetByID(s.ctx, "pr-solo")
+	s.Require().NoError(err)
+	s.True(fromDB.NeedMoreReviewers)
+}
+
+// TestCreateRollback проверяет, что при ошибке создания PR транзакция откатывается
+func (s *EdgeCasesTestSuite) TestCreateRollback() {
+	team := domain.Team{
+		Name: "rollback-team",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "rollback-team", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "rollback-team", IsActive: true},
+		},
+	}
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+
+	// Селектор возвращает несуществующего ревьювера - назначение падает после вставки PR
+	prService := service.NewPullRequestService(
+		s.prRepo, s.reviewersRepo, s.userRepo, s.poolRepo, ghostSelector{}, domain.MergePolicy{}, s.auditRepo,
+		s.webhookRepo, s.transactor, nil,
+	)
+	_, err = prService.Create(s.ctx, domain.PullRequest{ID: "pr-rollback", Name: "Rollback", AuthorID: "user-1"})
 	s.Require().Error(err)
+
+	// Транзакция откатилась - PR не должен остаться в базе
+	exists, err := s.prRepo.Exists(s.ctx, "pr-rollback")
+	s.Require().NoError(err)
+	s.False(exists)
+
+	// Событие аудита пишется в той же транзакции и откатывается вместе с PR
+	events, err := s.auditRepo.List(s.ctx, domain.AuditFilter{EntityID: "pr-rollback", Limit: 10})
+	s.Require().NoError(err)
+	s.Empty(events)
+
+	// В outbox тоже ничего не остаётся
+	fannedOut, err := s.webhookRepo.FanOutEvents(s.ctx, 10)
+	s.Require().NoError(err)
+	s.Zero(fannedOut)
+}
+
+// TestTopUpReviewers проверяет добор ревьюверов, когда в команде появляются активные участники
+func (s *EdgeCasesTestSuite) TestTopUpReviewers() {
+	team := domain.Team{
+		Name: "growing-team",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "growing-team", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "growing-team", IsActive: false},
+			{ID: "user-3", Username: "charlie", TeamName: "growing-team", IsActive: false},
+		},
+	}
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+
+	createdPR, err := s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-grow", Name: "Grow", AuthorID: "user-1"})
+	s.Require().NoError(err)
+	s.Empty(createdPR.Reviewers)
+	s.True(createdPR.NeedMoreReviewers)
+
+	// Активация участника добирает одного ревьювера, флаг остаётся
+	_, err = s.userService.SetIsActive(s.ctx, "user-2", true)
+	s.Require().NoError(err)
+
+	pr, err := s.prRepo.GetByID(s.ctx, "pr-grow")
+	s.Require().NoError(err)
+	s.Require().Len(pr.Reviewers, 1)
+	s.Equal("user-2", pr.Reviewers[0].ID)
+	s.True(pr.NeedMoreReviewers)
+
+	// Активация ещё одного участника добирает второго ревьювера и снимает флаг
+	_, err = s.userService.SetIsActive(s.ctx, "user-3", true)
+	s.Require().NoError(err)
+
+	pr, err = s.prRepo.GetByID(s.ctx, "pr-grow")
+	s.Require().NoError(err)
+	s.Len(pr.Reviewers, 2)
+	s.False(pr.NeedMoreReviewers)
+}
+
+// ghostSelector всегда выбирает несуществующего пользователя
+type ghostSelector struct{}
+
+func (ghostSelector) Select(_ context.Context, _ domain.ReviewerPool, _ []domain.User, _ int) ([]domain.User, error) {
+	return []domain.User{{ID: "ghost"}}, nil
 }
 
 // TestLargeTeam тестирует команду с большим количеством участников
@@ -135,7 +210,7 @@ func (s *EdgeCasesTestSuite) TestLargeTeam() {
 		Members: members,
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Создаем PR
@@ -175,7 +250,7 @@ func (s *EdgeCasesTestSuite) TestMultipleReassignments() {
 		Members: members,
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Создаем PR
@@ -225,7 +300,7 @@ func (s *EdgeCasesTestSuite) TestInactiveUsersNotAssigned() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Создаем PR от имени активного пользователя
@@ -261,7 +336,7 @@ func (s *EdgeCasesTestSuite) TestDeactivateUserWithPRs() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Создаем PR
@@ -311,7 +386,7 @@ func (s *EdgeCasesTestSuite) TestConcurrentReassignments() {
 		Members: members,
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Создаем PR
@@ -355,6 +430,68 @@ func (s *EdgeCasesTestSuite) TestConcurrentReassignments() {
 	s.GreaterOrEqual(successCount, 1)
 }
 
+// TestParallelReassignSamePR проверяет, что параллельные переназначения одного ревьювера сериализуются:
+// ровно одно успешно, остальные получают доменную ошибку вместо 500, дубликатов ревьюверов нет
+func (s *EdgeCasesTestSuite) TestParallelReassignSamePR() {
+	members := make([]domain.User, 6)
+	for i := range members {
+		members[i] = domain.User{
+			ID:       fmt.Sprintf("user-%d", i),
+			Username: fmt.Sprintf("user%d", i),
+			TeamName: "parallel-team",
+			IsActive: true,
+		}
+	}
+	_, err := s.teamService.Add(s.ctx, domain.Team{Name: "parallel-team", Members: members}, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+
+	createdPR, err := s.prService.Create(s.ctx, domain.PullRequest{
+		ID:       "pr-parallel",
+		Name:     "Parallel reassign",
+		AuthorID: "user-0",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(createdPR.Reviewers, 2)
+	oldReviewerID := createdPR.Reviewers[0].ID
+
+	const calls = 20
+	errs := make(chan error, calls)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, _, err := s.prService.ReassignReviewer(s.ctx, "pr-parallel", oldReviewerID)
+			errs <- err
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	successCount := 0
+	for err := range errs {
+		if err == nil {
+			successCount++
+			continue
+		}
+		// Проигравшие гонку видят, что ревьювер уже снят
+		s.ErrorIs(err, domain.ErrReviewerNotAssigned)
+	}
+	s.Equal(1, successCount)
+
+	pr, err := s.prRepo.GetByID(s.ctx, "pr-parallel")
+	s.Require().NoError(err)
+	s.Require().Len(pr.Reviewers, 2)
+	s.NotEqual(pr.Reviewers[0].ID, pr.Reviewers[1].ID)
+	for _, reviewer := range pr.Reviewers {
+		s.NotEqual("user-0", reviewer.ID)
+		s.NotEqual(oldReviewerID, reviewer.ID)
+	}
+}
+
 // TestManyPRsInTeam тестирует создание множества PR в одной команде
 func (s *EdgeCasesTestSuite) TestManyPRsInTeam() {
 	// Создаем команду
@@ -373,7 +510,7 @@ func (s *EdgeCasesTestSuite) TestManyPRsInTeam() {
 		Members: members,
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Создаем 50 PR
@@ -412,7 +549,7 @@ func (s *EdgeCasesTestSuite) TestMergeAndReassign() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	pr := domain.PullRequest{
@@ -428,7 +565,7 @@ func (s *EdgeCasesTestSuite) TestMergeAndReassign() {
 	reviewerID := createdPR.Reviewers[0].ID
 
 	// Мерджим PR
-	_, err = s.prService.Merge(s.ctx, "pr-merge-reassign")
+	_, err = s.prService.Merge(s.ctx, "pr-merge-reassign", false)
 	s.Require().NoError(err)
 
 	// Попытка переназначения после мерджа должна провалиться
@@ -449,7 +586,7 @@ func (s *EdgeCasesTestSuite) TestUpdateTeamMembers() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Получаем команду
@@ -458,7 +595,7 @@ func (s *EdgeCasesTestSuite) TestUpdateTeamMembers() {
 	s.Len(retrievedTeam.Members, 2)
 
 	// Попытка добавить команду с тем же именем должна провалиться
-	_, err = s.teamService.Add(s.ctx, team)
+	_, err = s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Error(err)
 	s.ErrorIs(err, domain.ErrTeamAlreadyExists)
 }
@@ -472,7 +609,7 @@ func (s *EdgeCasesTestSuite) TestEmptyReviewingList() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Получаем список PR для пользователя, который ничего не ревьюит
@@ -481,6 +618,44 @@ func (s *EdgeCasesTestSuite) TestEmptyReviewingList() {
 	s.Len(prs, 0)
 }
 
+// TestLeastLoadedDistribution проверяет, что least_loaded распределяет ревью равномерно
+func (s *EdgeCasesTestSuite) TestLeastLoadedDistribution() {
+	members := []domain.User{
+		{ID: "author", Username: "author", TeamName: "balanced-team", IsActive: true},
+		{ID: "user-1", Username: "user1", TeamName: "balanced-team", IsActive: true},
+		{ID: "user-2", Username: "user2", TeamName: "balanced-team", IsActive: true},
+		{ID: "user-3", Username: "user3", TeamName: "balanced-team", IsActive: true},
+		{ID: "user-4", Username: "user4", TeamName: "balanced-team", IsActive: true},
+	}
+	_, err := s.teamService.Add(s.ctx, domain.Team{Name: "balanced-team", Members: members}, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+
+	// 4 PR по 2 ревьювера на 4 кандидата - каждому должно достаться ровно по 2 ревью
+	for i := 0; i < 4; i++ {
+		_, err := s.prServiceLeastLoaded.Create(s.ctx, domain.PullRequest{
+			ID:       fmt.Sprintf("pr-balanced-%d", i),
+			Name:     "Balanced PR",
+			AuthorID: "author",
+		})
+		s.Require().NoError(err)
+	}
+
+	load, err := s.reviewersRepo.CountOpenReviews(s.ctx, []string{"author", "user-1", "user-2", "user-3", "user-4"})
+	s.Require().NoError(err)
+	s.Equal(map[string]int{"author": 0, "user-1": 2, "user-2": 2, "user-3": 2, "user-4": 2}, load)
+
+	// Смерженные PR не считаются нагрузкой
+	_, err = s.prServiceLeastLoaded.Merge(s.ctx, "pr-balanced-0", false)
+	s.Require().NoError(err)
+	load, err = s.reviewersRepo.CountOpenReviews(s.ctx, []string{"user-1", "user-2", "user-3", "user-4"})
+	s.Require().NoError(err)
+	total := 0
+	for _, l := range load {
+		total += l
+	}
+	s.Equal(6, total)
+}
+
 // TestEdgeCasesTestSuite запускает test suite
 func TestEdgeCasesTestSuite(t *testing.T) {
 	if os.Getenv("INTEGRATION_TESTS") == "" {
@@ -1,107 +1,96 @@
 package integration
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
-	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/suite"
-	"github.com/testcontainers/testcontainers-go"
-	"github.com/testcontainers/testcontainers-go/modules/postgres"
-	"github.com/testcontainers/testcontainers-go/wait"
 
 	"github.com/artmexbet/avito_test_task/internal/domain"
-	postgresRepo "github.com/artmexbet/avito_test_task/internal/postgres"
 	"github.com/artmexbet/avito_test_task/internal/repository"
 	"github.com/artmexbet/avito_test_task/internal/service"
+	stats_retriever "github.com/artmexbet/avito_test_task/internal/stats-retriever"
+	"github.com/artmexbet/avito_test_task/internal/webhook"
+	"github.com/artmexbet/avito_test_task/pkg/config"
 )
 
 // IntegrationTestSuite определяет test suite для интеграционных тестов
 type IntegrationTestSuite struct {
 	suite.Suite
-	ctx           context.Context
-	pgContainer   *postgres.PostgresContainer
-	pool          *pgxpool.Pool
-	prService     *service.PullRequestService
-	userService   *service.UserService
-	teamService   *service.TeamService
-	userRepo      *repository.UserRepository
-	prRepo        *repository.PRRepository
-	reviewersRepo *repository.ReviewersRepository
-	teamRepo      *repository.TeamRepository
+	ctx            context.Context
+	storage        *testStorage
+	prService      *service.PullRequestService
+	userService    *service.UserService
+	teamService    *service.TeamService
+	absenceService *service.AbsenceService
+	poolService    *service.ReviewerPoolService
+	userRepo       *repository.UserRepository
+	prRepo         *repository.PRRepository
+	reviewersRepo  *repository.ReviewersRepository
+	teamRepo       *repository.TeamRepository
+	statsRepo      *repository.StatsRepository
+	auditRepo      *repository.AuditRepository
+	webhookRepo    *repository.WebhookRepository
+	poolRepo       *repository.ReviewerPoolRepository
 }
 
 // SetupSuite выполняется один раз перед всеми тестами
 func (s *IntegrationTestSuite) SetupSuite() {
 	s.ctx = context.Background()
 
-	// Получаем путь к директории с миграциями
-	migrationsPath, err := filepath.Abs("../../migrations")
-	s.Require().NoError(err)
-
-	// Создаем PostgreSQL контейнер
-	pgContainer, err := postgres.Run(s.ctx,
-		"postgres:latest",
-		postgres.WithDatabase("testdb"),
-		postgres.WithUsername("testuser"),
-		postgres.WithPassword("testpass"),
-		postgres.WithInitScripts(
-			filepath.Join(migrationsPath, "01_teams.up.sql"),
-			filepath.Join(migrationsPath, "02_users.up.sql"),
-			filepath.Join(migrationsPath, "03_pull_requests.up.sql"),
-			filepath.Join(migrationsPath, "04_pull_requests_reviewers.up.sql"),
-		),
-		testcontainers.WithWaitStrategy(
-			wait.ForLog("database system is ready to accept connections").
-				WithOccurrence(2).
-				WithStartupTimeout(120*time.Second), // Увеличиваем timeout для Windows
-		),
-	)
-	s.Require().NoError(err)
-	s.pgContainer = pgContainer
-
-	// Получаем connection string
-	connStr, err := pgContainer.ConnectionString(s.ctx, "sslmode=disable")
+	storage, err := newTestStorage(s.ctx)
 	s.Require().NoError(err)
-
-	// Создаем connection pool
-	pool, err := pgxpool.New(s.ctx, connStr)
-	s.Require().NoError(err)
-	s.pool = pool
+	s.storage = storage
 
 	// Инициализируем репозитории
-	pg := postgresRepo.New(pool)
-	s.userRepo = repository.NewUserRepository(pg)
-	s.reviewersRepo = repository.NewReviewersRepository(pg)
-	s.prRepo = repository.NewPRRepository(pg)
-	s.teamRepo = repository.NewTeamRepository(pg)
+	s.userRepo = repository.NewUserRepository(storage)
+	s.reviewersRepo = repository.NewReviewersRepository(storage)
+	s.prRepo = repository.NewPRRepository(storage)
+	s.teamRepo = repository.NewTeamRepository(storage)
+	s.statsRepo = repository.NewStatsRepository(storage)
+	s.auditRepo = repository.NewAuditRepository(storage)
+	s.webhookRepo = repository.NewWebhookRepository(storage)
+	s.poolRepo = repository.NewReviewerPoolRepository(storage)
+	transactor := repository.NewTransactor(storage)
 
 	// Инициализируем сервисы
-	s.prService = service.NewPullRequestService(s.prRepo, s.reviewersRepo, s.userRepo)
-	s.userService = service.NewUserService(s.userRepo)
-	s.teamService = service.NewTeamService(s.teamRepo, s.userRepo)
+	s.prService = service.NewPullRequestService(
+		s.prRepo, s.reviewersRepo, s.userRepo, s.poolRepo, service.NewRandomSelector(), domain.MergePolicy{},
+		s.auditRepo, s.webhookRepo, transactor, nil,
+	)
+	s.userService = service.NewUserService(s.userRepo, s.prService, s.auditRepo, transactor)
+	s.teamService = service.NewTeamService(
+		s.teamRepo, s.userRepo, s.poolRepo, s.prService, s.auditRepo, transactor,
+	)
+	s.poolService = service.NewReviewerPoolService(s.poolRepo, s.userRepo, s.auditRepo, transactor)
+	s.absenceService = service.NewAbsenceService(
+		repository.NewAbsenceRepository(storage), s.userRepo, s.prService, s.auditRepo, transactor,
+		config.AbsencesConfig{ReassignInterval: time.Minute, BatchSize: 10},
+	)
 }
 
 // TearDownSuite выполняется один раз после всех тестов
 func (s *IntegrationTestSuite) TearDownSuite() {
-	if s.pool != nil {
-		s.pool.Close()
-	}
-	if s.pgContainer != nil {
-		err := s.pgContainer.Terminate(s.ctx)
-		s.Require().NoError(err)
+	if s.storage != nil {
+		s.Require().NoError(s.storage.Close(s.ctx))
 	}
 }
 
 // SetupTest выполняется перед каждым тестом
 func (s *IntegrationTestSuite) SetupTest() {
-	// Очищаем все таблицы перед каждым тестом
-	_, err := s.pool.Exec(s.ctx, "TRUNCATE TABLE pull_requests_reviewers, pull_requests, users, teams CASCADE")
-	s.Require().NoError(err)
+	// Очищаем хранилище перед каждым тестом
+	s.Require().NoError(s.storage.Reset(s.ctx))
 }
 
 // TestFullPRWorkflow тестирует полный workflow создания PR, назначения ревьюверов и мерджа
@@ -116,10 +105,10 @@ func (s *IntegrationTestSuite) TestFullPRWorkflow() {
 		},
 	}
 
-	createdTeam, err := s.teamService.Add(s.ctx, team)
+	createdTeam, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
-	s.Equal("backend-team", createdTeam.Name)
-	s.Len(createdTeam.Members, 3)
+	s.Equal("backend-team", createdTeam.Team.Name)
+	s.Len(createdTeam.Team.Members, 3)
 
 	// Создаем PR от имени user-1
 	pr := domain.PullRequest{
@@ -150,13 +139,13 @@ func (s *IntegrationTestSuite) TestFullPRWorkflow() {
 	s.Equal("pr-1", reviewingPRs[0].ID)
 
 	// Мерджим PR
-	mergedPR, err := s.prService.Merge(s.ctx, "pr-1")
+	mergedPR, err := s.prService.Merge(s.ctx, "pr-1", false)
 	s.Require().NoError(err)
 	s.Equal(domain.PRStatusMerged, mergedPR.Status)
 	s.NotNil(mergedPR.MergedAt)
 
 	// Попытка мерджа уже смердженного PR должна вернуть ошибку
-	_, err = s.prService.Merge(s.ctx, "pr-1")
+	_, err = s.prService.Merge(s.ctx, "pr-1", false)
 	s.Error(err)
 	s.ErrorIs(err, domain.ErrPRAlreadyMerged)
 }
@@ -174,7 +163,7 @@ func (s *IntegrationTestSuite) TestReassignReviewer() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Создаем PR
@@ -241,7 +230,7 @@ func (s *IntegrationTestSuite) TestUserActivationDeactivation() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Деактивируем пользователя
@@ -266,7 +255,7 @@ func (s *IntegrationTestSuite) TestSmallTeamPRCreation() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Создаем PR от имени user-30
@@ -294,7 +283,7 @@ func (s *IntegrationTestSuite) TestNoAvailableReviewers() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Создаем PR от имени user-40
@@ -328,7 +317,7 @@ func (s *IntegrationTestSuite) TestMultiplePRsForSameUser() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Создаем несколько PR
@@ -366,18 +355,18 @@ func (s *IntegrationTestSuite) TestTeamAlreadyExists() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Попытка создать команду с таким же именем
-	_, err = s.teamService.Add(s.ctx, team)
+	_, err = s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Error(err)
 	s.ErrorIs(err, domain.ErrTeamAlreadyExists)
 }
 
 // TestPRNotFound тестирует попытку мерджа несуществующего PR
 func (s *IntegrationTestSuite) TestPRNotFound() {
-	_, err := s.prService.Merge(s.ctx, "non-existent-pr")
+	_, err := s.prService.Merge(s.ctx, "non-existent-pr", false)
 	s.Error(err)
 	s.ErrorIs(err, domain.ErrPRNotFound)
 }
@@ -400,7 +389,7 @@ func (s *IntegrationTestSuite) TestGetTeam() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Получаем команду
@@ -422,7 +411,7 @@ func (s *IntegrationTestSuite) TestConcurrentPRCreation() {
 		},
 	}
 
-	_, err := s.teamService.Add(s.ctx, team)
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
 	s.Require().NoError(err)
 
 	// Создаем PR одновременно в разных горутинах
@@ -447,6 +436,1077 @@ func (s *IntegrationTestSuite) TestConcurrentPRCreation() {
 	}
 }
 
+// TestDeactivateTeamUsers тестирует массовую деактивацию с переназначением открытых PR
+func (s *IntegrationTestSuite) TestDeactivateTeamUsers() {
+	team := domain.Team{
+		Name: "backend-team",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "backend-team", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "backend-team", IsActive: true},
+			{ID: "user-3", Username: "charlie", TeamName: "backend-team", IsActive: true},
+			{ID: "user-4", Username: "dave", TeamName: "backend-team", IsActive: true},
+		},
+	}
+	_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+
+	createdPR, err := s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-1", Name: "Feature", AuthorID: "user-1"})
+	s.Require().NoError(err)
+	s.Require().Len(createdPR.Reviewers, 2)
+	oldReviewer := createdPR.Reviewers[0].ID
+
+	users, replacements, err := s.teamService.DeactivateUsers(s.ctx, "backend-team", []string{oldReviewer})
+	s.Require().NoError(err)
+	s.Len(users, 1)
+	s.False(users[0].IsActive)
+	s.Require().Len(replacements, 1)
+	s.Equal(oldReviewer, replacements[0].OldReviewerID)
+	s.NotEmpty(replacements[0].NewReviewerID)
+
+	reviewers, err := s.reviewersRepo.GetByPRID(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Len(reviewers, 2)
+	for _, r := range reviewers {
+		s.NotEqual(oldReviewer, r.ID)
+		s.NotEqual("user-1", r.ID)
+	}
+
+	// Деактивируем всю команду - ревьюверов заменить некем, они просто снимаются
+	users, replacements, err = s.teamService.DeactivateUsers(s.ctx, "backend-team", nil)
+	s.Require().NoError(err)
+	s.Len(users, 4)
+	s.Len(replacements, 2)
+	for _, r := range replacements {
+		s.Empty(r.NewReviewerID)
+	}
+	reviewers, err = s.reviewersRepo.GetByPRID(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Empty(reviewers)
+
+	// Пользователь из чужой команды - ничего не меняем
+	_, _, err = s.teamService.DeactivateUsers(s.ctx, "backend-team", []string{"stranger"})
+	s.ErrorIs(err, domain.ErrUserNotFound)
+	_, _, err = s.teamService.DeactivateUsers(s.ctx, "unknown-team", nil)
+	s.ErrorIs(err, domain.ErrTeamNotFound)
+}
+
+// TestAbsences проверяет, что отсутствующий пользователь не получает ревью, его открытые ревью
+// передаются при начале отсутствия, а после досрочного возвращения команда добирает ревьюверов
+func (s *IntegrationTestSuite) TestAbsences() {
+	_, err := s.teamService.Add(s.ctx, domain.Team{
+		Name: "backend-team",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "backend-team", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "backend-team", IsActive: true},
+			{ID: "user-3", Username: "charlie", TeamName: "backend-team", IsActive: true},
+		},
+	}, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+
+	pr, err := s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-1", Name: "Feature", AuthorID: "user-1"})
+	s.Require().NoError(err)
+	s.Require().Len(pr.Reviewers, 2)
+
+	now := time.Now().UTC()
+	vacation, err := s.absenceService.Add(s.ctx, domain.Absence{
+		UserID: "user-2", StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), Reason: "vacation",
+	})
+	s.Require().NoError(err)
+
+	// user-2 в отпуске и не попадает в ревьюверы новых PR
+	active, err := s.userRepo.GetActiveByTeamName(s.ctx, "backend-team")
+	s.Require().NoError(err)
+	for _, user := range active {
+		s.NotEqual("user-2", user.ID)
+	}
+	pr2, err := s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-2", Name: "Fix", AuthorID: "user-1"})
+	s.Require().NoError(err)
+	s.Require().Len(pr2.Reviewers, 1)
+	s.Equal("user-3", pr2.Reviewers[0].ID)
+
+	// фоновая задача снимает user-2 с открытых ревью, заменить его некем
+	reassignments, err := s.absenceService.ReassignStarted(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Len(reassignments, 1)
+	s.Equal(vacation.ID, reassignments[0].Absence.ID)
+	s.Require().Len(reassignments[0].Replacements, 1)
+	s.Equal("user-2", reassignments[0].Replacements[0].OldReviewerID)
+	s.Empty(reassignments[0].Replacements[0].NewReviewerID)
+	reassignments, err = s.absenceService.ReassignStarted(s.ctx)
+	s.Require().NoError(err)
+	s.Empty(reassignments)
+
+	// после досрочного возвращения user-2 снова ревьюит PR, где не хватает ревьюверов
+	s.Require().NoError(s.absenceService.Delete(s.ctx, vacation.ID))
+	reviewers, err := s.reviewersRepo.GetByPRID(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	ids := make([]string, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		ids = append(ids, reviewer.ID)
+	}
+	s.ElementsMatch([]string{"user-2", "user-3"}, ids)
+
+	_, err = s.absenceService.Add(s.ctx, domain.Absence{UserID: "ghost", StartsAt: now, EndsAt: now.Add(time.Hour)})
+	s.ErrorIs(err, domain.ErrUserNotFound)
+	err = s.absenceService.Delete(s.ctx, vacation.ID)
+	s.ErrorIs(err, domain.ErrAbsenceNotFound)
+}
+
+// TestReviewCapacity проверяет, что ревьюверы с исчерпанным лимитом открытых ревью пропускаются
+func (s *IntegrationTestSuite) TestReviewCapacity() {
+	_, err := s.teamService.Add(s.ctx, domain.Team{
+		Name: "backend-team",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "backend-team", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "backend-team", IsActive: true, MaxOpenReviews: 1},
+			{ID: "user-3", Username: "charlie", TeamName: "backend-team", IsActive: true},
+		},
+	}, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+	user, err := s.userRepo.GetByID(s.ctx, "user-2")
+	s.Require().NoError(err)
+	s.Equal(1, user.MaxOpenReviews)
+
+	pr, err := s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-1", Name: "Feature", AuthorID: "user-1"})
+	s.Require().NoError(err)
+	s.Require().Len(pr.Reviewers, 2)
+
+	// у user-2 уже одно открытое ревью
+	pr2, err := s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-2", Name: "Fix", AuthorID: "user-1"})
+	s.Require().NoError(err)
+	s.Require().Len(pr2.Reviewers, 1)
+	s.Equal("user-3", pr2.Reviewers[0].ID)
+	_, _, err = s.prService.ReassignReviewer(s.ctx, "pr-2", "user-3")
+	s.ErrorIs(err, domain.ErrNoAvailableReviewers)
+
+	// повышенный лимит сразу добирает ревьюверов
+	_, err = s.userService.SetMaxOpenReviews(s.ctx, "user-2", 2)
+	s.Require().NoError(err)
+	reviewers, err := s.reviewersRepo.GetByPRID(s.ctx, "pr-2")
+	s.Require().NoError(err)
+	s.Len(reviewers, 2)
+
+	_, err = s.userService.SetMaxOpenReviews(s.ctx, "user-2", domain.MaxReviewLimit+1)
+	s.ErrorIs(err, domain.ErrInvalidReviewLimit)
+	_, err = s.userService.SetMaxOpenReviews(s.ctx, "ghost", 1)
+	s.ErrorIs(err, domain.ErrUserNotFound)
+}
+
+// TestTeamManagement проверяет перевод пользователей, переименование и удаление команд
+func (s *IntegrationTestSuite) TestTeamManagement() {
+	for _, team := range []domain.Team{
+		{
+			Name: "backend-team",
+			Members: []domain.User{
+				{ID: "user-1", Username: "alice", TeamName: "backend-team", IsActive: true},
+				{ID: "user-2", Username: "bob", TeamName: "backend-team", IsActive: true},
+				{ID: "user-3", Username: "charlie", TeamName: "backend-team", IsActive: true},
+				{ID: "user-4", Username: "dave", TeamName: "backend-team", IsActive: true},
+			},
+		},
+		{
+			Name:    "frontend-team",
+			Members: []domain.User{{ID: "user-5", Username: "eve", TeamName: "frontend-team", IsActive: true}},
+		},
+	} {
+		_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
+		s.Require().NoError(err)
+	}
+	pr, err := s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-1", Name: "Feature", AuthorID: "user-1"})
+	s.Require().NoError(err)
+	s.Require().Len(pr.Reviewers, 2)
+	movedReviewer := pr.Reviewers[0].ID
+
+	// ушедший в другую команду ревьювер заменяется бывшим коллегой
+	moved, replacements, err := s.teamService.MoveUsers(s.ctx, "frontend-team", []string{movedReviewer})
+	s.Require().NoError(err)
+	s.Require().Len(moved, 1)
+	s.Equal("frontend-team", moved[0].TeamName)
+	s.Require().Len(replacements, 1)
+	s.Equal(movedReviewer, replacements[0].OldReviewerID)
+	s.NotEmpty(replacements[0].NewReviewerID)
+	s.NotEqual("user-1", replacements[0].NewReviewerID)
+	_, _, err = s.teamService.MoveUsers(s.ctx, "frontend-team", []string{movedReviewer})
+	s.ErrorIs(err, domain.ErrInvalidTeamChange)
+	_, _, err = s.teamService.MoveUsers(s.ctx, "unknown-team", []string{"user-2"})
+	s.ErrorIs(err, domain.ErrTeamNotFound)
+
+	team, err := s.teamService.Rename(s.ctx, "frontend-team", "web-team")
+	s.Require().NoError(err)
+	s.Len(team.Members, 2)
+	_, err = s.teamService.Rename(s.ctx, "web-team", "backend-team")
+	s.ErrorIs(err, domain.ErrTeamAlreadyExists)
+	user, err := s.userRepo.GetByID(s.ctx, movedReviewer)
+	s.Require().NoError(err)
+	s.Equal("web-team", user.TeamName)
+
+	_, _, err = s.teamService.Delete(s.ctx, "backend-team", "")
+	s.ErrorIs(err, domain.ErrTeamNotEmpty)
+	// автор переезжает вместе с ревьюверами, поэтому их ревью остаются на месте
+	moved, replacements, err = s.teamService.Delete(s.ctx, "backend-team", "web-team")
+	s.Require().NoError(err)
+	s.Len(moved, 3)
+	s.Empty(replacements)
+	exists, err := s.teamRepo.Exists(s.ctx, "backend-team")
+	s.Require().NoError(err)
+	s.False(exists)
+	reviewers, err := s.reviewersRepo.GetByPRID(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Len(reviewers, 2)
+
+	events, err := s.auditRepo.List(s.ctx, domain.AuditFilter{EntityType: domain.AuditEntityTeam, Limit: 100})
+	s.Require().NoError(err)
+	actions := make([]domain.AuditAction, 0, len(events))
+	for _, event := range events {
+		actions = append(actions, event.Action)
+	}
+	s.Contains(actions, domain.AuditActionTeamRename)
+	s.Contains(actions, domain.AuditActionTeamDelete)
+
+	// счётчики команд переехали вместе с пользователями
+	res, err := s.statsRepo.Reconcile(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(res.ReviewersFixed)
+	s.Zero(res.TeamsFixed)
+}
+
+// TestTeamAddModes проверяет режимы добавления команды и отчёт о конфликтах
+func (s *IntegrationTestSuite) TestTeamAddModes() {
+	_, err := s.teamService.Add(s.ctx, domain.Team{
+		Name: "backend-team",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "backend-team", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "backend-team", IsActive: true},
+			{ID: "user-3", Username: "charlie", TeamName: "backend-team", IsActive: true},
+		},
+	}, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+	_, err = s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-1", Name: "Feature", AuthorID: "user-1"})
+	s.Require().NoError(err)
+
+	frontend := domain.Team{
+		Name: "frontend-team",
+		Members: []domain.User{
+			{ID: "user-3", Username: "charlie", TeamName: "frontend-team", IsActive: true},
+			{ID: "user-4", Username: "dave", TeamName: "frontend-team", IsActive: true},
+		},
+	}
+	// create_only не переносит существующих пользователей и ничего не меняет
+	_, err = s.teamService.Add(s.ctx, frontend, domain.TeamAddModeCreateOnly)
+	var conflictErr *domain.TeamConflictError
+	s.Require().ErrorAs(err, &conflictErr)
+	s.Equal([]domain.TeamMemberConflict{
+		{UserID: "user-3", Reason: domain.TeamConflictUserExists, CurrentTeam: "backend-team"},
+	}, conflictErr.Conflicts)
+	exists, err := s.teamRepo.Exists(s.ctx, "frontend-team")
+	s.Require().NoError(err)
+	s.False(exists)
+	_, err = s.userRepo.GetByID(s.ctx, "user-4")
+	s.ErrorIs(err, domain.ErrUserNotFound)
+
+	// merge явно переносит user-3, его ревью у бывшего коллеги передать некому
+	result, err := s.teamService.Add(s.ctx, frontend, domain.TeamAddModeMerge)
+	s.Require().NoError(err)
+	s.True(result.TeamCreated)
+	s.Equal([]string{"user-4"}, result.Created)
+	s.Equal([]string{"user-3"}, result.Moved)
+	s.Require().Len(result.Replacements, 1)
+	s.Equal("user-3", result.Replacements[0].OldReviewerID)
+	s.Empty(result.Replacements[0].NewReviewerID)
+	s.Len(result.Team.Members, 2)
+
+	result, err = s.teamService.Add(s.ctx, frontend, domain.TeamAddModeMerge)
+	s.Require().NoError(err)
+	s.False(result.TeamCreated)
+	s.Equal([]string{"user-3", "user-4"}, result.Untouched)
+	s.Empty(result.Created)
+	s.Empty(result.Moved)
+
+	frontend.Members[1].IsActive = false
+	_, err = s.teamService.Add(s.ctx, frontend, domain.TeamAddModeMerge)
+	s.Require().ErrorAs(err, &conflictErr)
+	s.Equal(domain.TeamConflictAttributesDiffer, conflictErr.Conflicts[0].Reason)
+
+	// а выключенного участника merge снова включает
+	_, err = s.userService.SetIsActive(s.ctx, "user-4", false)
+	s.Require().NoError(err)
+	frontend.Members[1].IsActive = true
+	result, err = s.teamService.Add(s.ctx, frontend, domain.TeamAddModeMerge)
+	s.Require().NoError(err)
+	s.Equal([]string{"user-3"}, result.Untouched)
+	s.Equal([]string{"user-4"}, result.Activated)
+	s.True(result.Team.Members[1].IsActive)
+
+	// replace выключает не перечисленных участников и снимает их с ревью, но не удаляет
+	result, err = s.teamService.Add(s.ctx, domain.Team{
+		Name:    "backend-team",
+		Members: []domain.User{{ID: "user-1", Username: "alice", TeamName: "backend-team", IsActive: true}},
+	}, domain.TeamAddModeReplace)
+	s.Require().NoError(err)
+	s.Equal([]string{"user-1"}, result.Untouched)
+	s.Equal([]string{"user-2"}, result.Deactivated)
+	s.Require().Len(result.Team.Members, 2)
+	s.False(result.Team.Members[1].IsActive)
+	s.Require().Len(result.Replacements, 1)
+	reviewers, err := s.reviewersRepo.GetByPRID(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Empty(reviewers)
+
+	res, err := s.statsRepo.Reconcile(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(res.ReviewersFixed)
+	s.Zero(res.TeamsFixed)
+}
+
+// TestUserDelete проверяет мягкое удаление пользователя, справочник и удаление его команды
+func (s *IntegrationTestSuite) TestUserDelete() {
+	for _, team := range []domain.Team{
+		{
+			Name: "backend-team",
+			Members: []domain.User{
+				{ID: "user-1", Username: "alice", TeamName: "backend-team", IsActive: true},
+				{ID: "user-2", Username: "bob", TeamName: "backend-team", IsActive: true},
+				{ID: "user-3", Username: "charlie", TeamName: "backend-team", IsActive: true},
+			},
+		},
+		{Name: "frontend-team", Members: []domain.User{}},
+	} {
+		_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
+		s.Require().NoError(err)
+	}
+	pr, err := s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-1", Name: "Feature", AuthorID: "user-1"})
+	s.Require().NoError(err)
+	s.Require().Len(pr.Reviewers, 2)
+
+	// удалённый ревьювер заменить некем: второй коллега уже назначен
+	user, replacements, err := s.userService.Delete(s.ctx, "user-2")
+	s.Require().NoError(err)
+	s.True(user.IsDeleted())
+	s.False(user.IsActive)
+	s.Equal([]domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-2"}}, replacements)
+	_, _, err = s.userService.Delete(s.ctx, "user-2")
+	s.ErrorIs(err, domain.ErrUserNotFound)
+	_, err = s.userService.Get(s.ctx, "user-2")
+	s.ErrorIs(err, domain.ErrUserNotFound)
+
+	// PR удалённого автора остаётся, новые от его имени не создаются
+	_, _, err = s.userService.Delete(s.ctx, "user-1")
+	s.Require().NoError(err)
+	pr, err = s.prService.Get(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Equal("user-1", pr.AuthorID)
+	_, err = s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-2", Name: "Fix", AuthorID: "user-1"})
+	s.ErrorIs(err, domain.ErrUserNotFound)
+
+	page, err := s.userService.List(s.ctx, domain.UserFilter{})
+	s.Require().NoError(err)
+	s.Require().Len(page.Users, 1)
+	s.Equal("user-3", page.Users[0].ID)
+	team, err := s.teamService.Get(s.ctx, "backend-team")
+	s.Require().NoError(err)
+	s.Len(team.Members, 1)
+
+	// ID удалённого не занять заново
+	_, err = s.teamService.Add(s.ctx, domain.Team{
+		Name:    "frontend-team",
+		Members: []domain.User{{ID: "user-2", Username: "bob", TeamName: "frontend-team"}},
+	}, domain.TeamAddModeMerge)
+	var conflictErr *domain.TeamConflictError
+	s.Require().ErrorAs(err, &conflictErr)
+	s.Equal(domain.TeamConflictUserDeleted, conflictErr.Conflicts[0].Reason)
+
+	// удалённые держат команду, пока их не перенесут вместе с остальными
+	_, _, err = s.teamService.MoveUsers(s.ctx, "frontend-team", []string{"user-3"})
+	s.Require().NoError(err)
+	_, _, err = s.teamService.Delete(s.ctx, "backend-team", "")
+	s.ErrorIs(err, domain.ErrTeamNotEmpty)
+	moved, _, err := s.teamService.Delete(s.ctx, "backend-team", "frontend-team")
+	s.Require().NoError(err)
+	s.Empty(moved)
+	user, err = s.userRepo.GetByID(s.ctx, "user-1")
+	s.Require().NoError(err)
+	s.Equal("frontend-team", user.TeamName)
+
+	events, err := s.auditRepo.List(s.ctx, domain.AuditFilter{Action: domain.AuditActionUserDelete, Limit: 100})
+	s.Require().NoError(err)
+	s.Len(events, 2)
+
+	res, err := s.statsRepo.Reconcile(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(res.ReviewersFixed)
+	s.Zero(res.TeamsFixed)
+}
+
+// TestStatsCounters проверяет, что счётчики статистики сходятся с данными после всех операций
+func (s *IntegrationTestSuite) TestStatsCounters() {
+	for _, team := range []domain.Team{
+		{
+			Name: "stats-a",
+			Members: []domain.User{
+				{ID: "user-1", Username: "alice", TeamName: "stats-a", IsActive: true},
+				{ID: "user-2", Username: "bob", TeamName: "stats-a", IsActive: true},
+				{ID: "user-3", Username: "charlie", TeamName: "stats-a", IsActive: true},
+				{ID: "user-4", Username: "dave", TeamName: "stats-a", IsActive: true},
+			},
+		},
+		{
+			Name:    "stats-b",
+			Members: []domain.User{{ID: "user-5", Username: "eve", TeamName: "stats-b", IsActive: false}},
+		},
+	} {
+		_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
+		s.Require().NoError(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := s.prService.Create(s.ctx, domain.PullRequest{ID: fmt.Sprintf("pr-%d", i), Name: "Stats", AuthorID: "user-1"})
+		s.Require().NoError(err)
+	}
+	_, err := s.prService.Merge(s.ctx, "pr-0", false)
+	s.Require().NoError(err)
+	_, err = s.prService.Merge(s.ctx, "pr-0", false)
+	s.Require().ErrorIs(err, domain.ErrPRAlreadyMerged)
+
+	pr, err := s.prRepo.GetByID(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	_, _, err = s.prService.ReassignReviewer(s.ctx, "pr-1", pr.Reviewers[0].ID)
+	s.Require().NoError(err)
+	_, _, err = s.teamService.DeactivateUsers(s.ctx, "stats-a", []string{"user-2"})
+	s.Require().NoError(err)
+
+	stats, err := s.statsRepo.Get(s.ctx, stats_retriever.Filter{Period: stats_retriever.PeriodDay})
+	s.Require().NoError(err)
+
+	// 3 PR по 2 ревьювера, все назначения внутри stats-a
+	s.Require().Len(stats.TeamStats, 1)
+	s.Equal("stats-a", stats.TeamStats[0].TeamName)
+	s.Equal(6, stats.TeamStats[0].TotalPRs)
+	total := 0
+	for _, a := range stats.AssignStats {
+		total += a.PRCount
+		s.NotEqual("user-1", a.ReviewerID)
+	}
+	s.Equal(6, total)
+	users := make(map[bool]int)
+	for _, u := range stats.UserStats {
+		users[u.IsActive] = u.Total
+	}
+	s.Equal(map[bool]int{true: 3, false: 2}, users)
+
+	// Счётчики велись инкрементально без ошибок - пересборка ничего не меняет
+	res, err := s.statsRepo.Reconcile(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(res.ReviewersFixed)
+	s.Zero(res.TeamsFixed)
+}
+
+// TestStatsReconcile проверяет, что пересборка чинит разъехавшиеся счётчики
+func (s *IntegrationTestSuite) TestStatsReconcile() {
+	if s.storage.pool == nil {
+		s.T().Skip("in-memory storage has no counters to break")
+	}
+
+	_, err := s.teamService.Add(s.ctx, domain.Team{
+		Name: "drift",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "drift", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "drift", IsActive: true},
+		},
+	}, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+	_, err = s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-1", Name: "Drift", AuthorID: "user-1"})
+	s.Require().NoError(err)
+
+	_, err = s.storage.pool.Exec(s.ctx, "UPDATE reviewer_stats SET assigned_reviews = 42")
+	s.Require().NoError(err)
+	_, err = s.storage.pool.Exec(s.ctx, "DELETE FROM team_stats")
+	s.Require().NoError(err)
+
+	res, err := s.statsRepo.Reconcile(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(1, res.ReviewersFixed)
+	s.Equal(1, res.TeamsFixed)
+
+	stats, err := s.statsRepo.Get(s.ctx, stats_retriever.Filter{Period: stats_retriever.PeriodDay})
+	s.Require().NoError(err)
+	s.Require().Len(stats.AssignStats, 1)
+	s.Equal(1, stats.AssignStats[0].PRCount)
+	s.Require().Len(stats.TeamStats, 1)
+	s.Equal(1, stats.TeamStats[0].TotalPRs)
+}
+
+// TestStatsFilters проверяет фильтры и метрики по периодам
+func (s *IntegrationTestSuite) TestStatsFilters() {
+	for _, team := range []domain.Team{
+		{
+			Name: "filter-a",
+			Members: []domain.User{
+				{ID: "user-1", Username: "alice", TeamName: "filter-a", IsActive: true},
+				{ID: "user-2", Username: "bob", TeamName: "filter-a", IsActive: true},
+				{ID: "user-3", Username: "charlie", TeamName: "filter-a", IsActive: true},
+			},
+		},
+		{
+			Name: "filter-b",
+			Members: []domain.User{
+				{ID: "user-4", Username: "dave", TeamName: "filter-b", IsActive: true},
+				{ID: "user-5", Username: "eve", TeamName: "filter-b", IsActive: true},
+			},
+		},
+	} {
+		_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
+		s.Require().NoError(err)
+	}
+
+	for i, authorID := range []string{"user-1", "user-1", "user-2", "user-4"} {
+		_, err := s.prService.Create(s.ctx, domain.PullRequest{ID: fmt.Sprintf("pr-%d", i), Name: "Filter", AuthorID: authorID})
+		s.Require().NoError(err)
+	}
+	for _, prID := range []string{"pr-0", "pr-3"} {
+		_, err := s.prService.Merge(s.ctx, prID, false)
+		s.Require().NoError(err)
+	}
+
+	// Все PR созданы и смерджены только что, поэтому попадают в один день
+	all, err := s.statsRepo.Get(s.ctx, stats_retriever.Filter{Period: stats_retriever.PeriodDay})
+	s.Require().NoError(err)
+	s.Equal(2, all.TimeToMerge.MergedCount)
+	s.LessOrEqual(all.TimeToMerge.MedianSeconds, all.TimeToMerge.P90Seconds)
+	s.Require().Len(all.PullRequests, 1)
+	s.Equal(4, all.PullRequests[0].Opened)
+	s.Equal(2, all.PullRequests[0].Merged)
+	assignments := 0
+	for _, a := range all.Assignments {
+		assignments += a.Assignments
+	}
+	// В filter-b только один кандидат в ревьюверы
+	s.Equal(3*2+1, assignments)
+
+	team, err := s.statsRepo.Get(s.ctx, stats_retriever.Filter{TeamName: "filter-a", Period: stats_retriever.PeriodMonth})
+	s.Require().NoError(err)
+	s.Equal(1, team.TimeToMerge.MergedCount)
+	s.Require().Len(team.PullRequests, 1)
+	s.Equal(3, team.PullRequests[0].Opened)
+	s.Equal(1, team.PullRequests[0].Merged)
+	s.Equal(1, team.PullRequests[0].PeriodStart.Day())
+	s.Require().Len(team.TeamStats, 1)
+	s.Equal("filter-a", team.TeamStats[0].TeamName)
+	for _, a := range team.Assignments {
+		s.NotEqual("user-4", a.ReviewerID)
+		s.NotEqual("user-5", a.ReviewerID)
+	}
+
+	user, err := s.statsRepo.Get(s.ctx, stats_retriever.Filter{UserID: "user-1", Period: stats_retriever.PeriodWeek})
+	s.Require().NoError(err)
+	s.Require().Len(user.PullRequests, 1)
+	s.Equal(2, user.PullRequests[0].Opened)
+	s.Equal(time.Monday, user.PullRequests[0].PeriodStart.Weekday())
+	s.Require().Len(user.Assignments, 1)
+	s.Equal("user-1", user.Assignments[0].ReviewerID)
+	s.Equal(1, user.Assignments[0].Assignments)
+
+	future, err := s.statsRepo.Get(s.ctx, stats_retriever.Filter{
+		From:   time.Now().UTC().Add(time.Hour),
+		Period: stats_retriever.PeriodDay,
+	})
+	s.Require().NoError(err)
+	s.Zero(future.TimeToMerge.MergedCount)
+	s.Empty(future.PullRequests)
+	s.Empty(future.Assignments)
+}
+
+// TestReviews проверяет вердикты ревьюверов и статистику по ним
+func (s *IntegrationTestSuite) TestReviews() {
+	_, err := s.teamService.Add(s.ctx, domain.Team{
+		Name: "review",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "review", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "review", IsActive: true},
+			{ID: "user-3", Username: "charlie", TeamName: "review", IsActive: true},
+		},
+	}, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+	_, err = s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-1", Name: "Review", AuthorID: "user-1"})
+	s.Require().NoError(err)
+
+	for _, r := range []struct {
+		reviewerID string
+		verdict    domain.ReviewVerdict
+	}{
+		{"user-2", domain.ReviewVerdictChangesRequested},
+		{"user-3", domain.ReviewVerdictCommented},
+		{"user-2", domain.ReviewVerdictApproved},
+	} {
+		_, err = s.prService.Review(s.ctx, "pr-1", r.reviewerID, r.verdict)
+		s.Require().NoError(err)
+	}
+
+	// Автор не назначен ревьювером
+	_, err = s.prService.Review(s.ctx, "pr-1", "user-1", domain.ReviewVerdictApproved)
+	s.ErrorIs(err, domain.ErrReviewerNotAssigned)
+
+	// На PR остаётся последний вердикт каждого ревьювера
+	merged, err := s.prService.Merge(s.ctx, "pr-1", false)
+	s.Require().NoError(err)
+	s.Require().Len(merged.Reviews, 2)
+	s.Equal("user-2", merged.Reviews[0].ReviewerID)
+	s.Equal(domain.ReviewVerdictApproved, merged.Reviews[0].Verdict)
+	s.Equal(domain.ReviewVerdictCommented, merged.Reviews[1].Verdict)
+	s.False(merged.Reviews[1].SubmittedAt.IsZero())
+
+	_, err = s.prService.Review(s.ctx, "pr-1", "user-3", domain.ReviewVerdictApproved)
+	s.ErrorIs(err, domain.ErrPRAlreadyMerged)
+
+	stats, err := s.statsRepo.Get(s.ctx, stats_retriever.Filter{Period: stats_retriever.PeriodDay})
+	s.Require().NoError(err)
+	s.Equal(1, stats.TimeToFirstReview.ReviewedCount)
+	s.Equal([]stats_retriever.ReviewerApprovalStats{
+		{ReviewerID: "user-2", Reviewed: 1, Approved: 1, ApprovalRate: 1},
+		{ReviewerID: "user-3", Reviewed: 1},
+	}, stats.Approvals)
+
+	stats, err = s.statsRepo.Get(s.ctx, stats_retriever.Filter{UserID: "user-3", Period: stats_retriever.PeriodDay})
+	s.Require().NoError(err)
+	s.Require().Len(stats.Approvals, 1)
+	s.Equal("user-3", stats.Approvals[0].ReviewerID)
+	// Фильтр по пользователю для времени до ревью относится к автору PR
+	s.Zero(stats.TimeToFirstReview.ReviewedCount)
+}
+
+// TestMergePolicy проверяет, что PR без нужных одобрений не мерджится без force
+func (s *IntegrationTestSuite) TestMergePolicy() {
+	prService := service.NewPullRequestService(
+		s.prRepo, s.reviewersRepo, s.userRepo, s.poolRepo, service.NewRandomSelector(),
+		domain.MergePolicy{MinApprovals: 2, BlockOnChangesRequested: true},
+		s.auditRepo,
+		s.webhookRepo,
+		repository.NewTransactor(s.storage),
+		nil,
+	)
+
+	_, err := s.teamService.Add(s.ctx, domain.Team{
+		Name: "policy",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "policy", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "policy", IsActive: true},
+			{ID: "user-3", Username: "charlie", TeamName: "policy", IsActive: true},
+		},
+	}, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+	for _, prID := range []string{"pr-1", "pr-2"} {
+		_, err = prService.Create(s.ctx, domain.PullRequest{ID: prID, Name: "Policy", AuthorID: "user-1"})
+		s.Require().NoError(err)
+	}
+
+	_, err = prService.Review(s.ctx, "pr-1", "user-2", domain.ReviewVerdictApproved)
+	s.Require().NoError(err)
+	_, err = prService.Review(s.ctx, "pr-1", "user-3", domain.ReviewVerdictChangesRequested)
+	s.Require().NoError(err)
+
+	_, err = prService.Merge(s.ctx, "pr-1", false)
+	var policyErr *domain.MergePolicyError
+	s.Require().ErrorAs(err, &policyErr)
+	s.Len(policyErr.Violations, 2)
+	pr, err := s.prRepo.GetByID(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Equal(domain.PRStatusOpen, pr.Status)
+
+	_, err = prService.Review(s.ctx, "pr-1", "user-3", domain.ReviewVerdictApproved)
+	s.Require().NoError(err)
+	merged, err := prService.Merge(s.ctx, "pr-1", false)
+	s.Require().NoError(err)
+	s.Equal(domain.PRStatusMerged, merged.Status)
+
+	// force мерджит PR без ревью
+	_, err = prService.Merge(s.ctx, "pr-2", false)
+	s.ErrorIs(err, domain.ErrMergePolicyNotMet)
+	merged, err = prService.Merge(s.ctx, "pr-2", true)
+	s.Require().NoError(err)
+	s.Equal(domain.PRStatusMerged, merged.Status)
+}
+
+// TestMergePolicyAfterReassign проверяет, что вердикты снятых с PR ревьюверов не учитываются политикой мерджа
+func (s *IntegrationTestSuite) TestMergePolicyAfterReassign() {
+	prService := service.NewPullRequestService(
+		s.prRepo, s.reviewersRepo, s.userRepo, s.poolRepo, service.NewRandomSelector(),
+		domain.MergePolicy{MinApprovals: 2, BlockOnChangesRequested: true},
+		s.auditRepo,
+		s.webhookRepo,
+		repository.NewTransactor(s.storage),
+		nil,
+	)
+
+	_, err := s.teamService.Add(s.ctx, domain.Team{
+		Name: "policy",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "policy", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "policy", IsActive: true},
+			{ID: "user-3", Username: "charlie", TeamName: "policy", IsActive: true},
+			{ID: "user-4", Username: "dave", TeamName: "policy", IsActive: true},
+		},
+	}, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+
+	// CHANGES_REQUESTED снятого ревьювера больше не блокирует мердж
+	pr, err := prService.Create(s.ctx, domain.PullRequest{ID: "pr-1", Name: "Blocked", AuthorID: "user-1"})
+	s.Require().NoError(err)
+	s.Require().Len(pr.Reviewers, 2)
+	blocker, approver := pr.Reviewers[0].ID, pr.Reviewers[1].ID
+	_, err = prService.Review(s.ctx, "pr-1", blocker, domain.ReviewVerdictChangesRequested)
+	s.Require().NoError(err)
+	_, err = prService.Review(s.ctx, "pr-1", approver, domain.ReviewVerdictApproved)
+	s.Require().NoError(err)
+
+	_, replacement, err := prService.ReassignReviewer(s.ctx, "pr-1", blocker)
+	s.Require().NoError(err)
+	_, err = prService.Merge(s.ctx, "pr-1", false)
+	var policyErr *domain.MergePolicyError
+	s.Require().ErrorAs(err, &policyErr)
+	s.Equal([]string{"1 of 2 required approvals"}, policyErr.Violations)
+
+	_, err = prService.Review(s.ctx, "pr-1", replacement, domain.ReviewVerdictApproved)
+	s.Require().NoError(err)
+	merged, err := prService.Merge(s.ctx, "pr-1", false)
+	s.Require().NoError(err)
+	s.Equal(domain.PRStatusMerged, merged.Status)
+
+	// APPROVED снятого ревьювера не засчитывается и не показывается в PR
+	pr, err = prService.Create(s.ctx, domain.PullRequest{ID: "pr-2", Name: "Approved", AuthorID: "user-1"})
+	s.Require().NoError(err)
+	for _, reviewer := range pr.Reviewers {
+		_, err = prService.Review(s.ctx, "pr-2", reviewer.ID, domain.ReviewVerdictApproved)
+		s.Require().NoError(err)
+	}
+
+	removed, kept := pr.Reviewers[0].ID, pr.Reviewers[1].ID
+	_, _, err = prService.ReassignReviewer(s.ctx, "pr-2", removed)
+	s.Require().NoError(err)
+	_, err = prService.Merge(s.ctx, "pr-2", false)
+	s.Require().ErrorAs(err, &policyErr)
+	s.Equal([]string{"1 of 2 required approvals"}, policyErr.Violations)
+	pr, err = prService.Get(s.ctx, "pr-2")
+	s.Require().NoError(err)
+	s.Require().Len(pr.Reviews, 1)
+	s.Equal(kept, pr.Reviews[0].ReviewerID)
+}
+
+// TestPullRequestLifecycle проверяет черновики, закрытие и переоткрытие PR
+func (s *IntegrationTestSuite) TestPullRequestLifecycle() {
+	_, err := s.teamService.Add(s.ctx, domain.Team{
+		Name: "lifecycle",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "lifecycle", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "lifecycle", IsActive: true},
+			{ID: "user-3", Username: "charlie", TeamName: "lifecycle", IsActive: true},
+		},
+	}, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+
+	// Черновику ревьюверы не назначаются
+	draft, err := s.prService.Create(s.ctx, domain.PullRequest{
+		ID: "pr-1", Name: "Draft", AuthorID: "user-1", Status: domain.PRStatusDraft,
+	})
+	s.Require().NoError(err)
+	s.Equal(domain.PRStatusDraft, draft.Status)
+	s.Empty(draft.Reviewers)
+	_, err = s.prService.Merge(s.ctx, "pr-1", false)
+	s.ErrorIs(err, domain.ErrInvalidPRTransition)
+
+	ready, err := s.prService.MarkReady(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Equal(domain.PRStatusOpen, ready.Status)
+	s.Len(ready.Reviewers, 2)
+	_, err = s.prService.MarkReady(s.ctx, "pr-1")
+	s.ErrorIs(err, domain.ErrInvalidPRTransition)
+
+	// Закрытие освобождает ревьюверов
+	closed, err := s.prService.Close(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Equal(domain.PRStatusClosed, closed.Status)
+	s.False(closed.ClosedAt.IsZero())
+	for _, reviewer := range ready.Reviewers {
+		prs, err := s.prService.GetReviewingPRs(s.ctx, reviewer.ID)
+		s.Require().NoError(err)
+		s.Empty(prs)
+	}
+	_, err = s.prService.Review(s.ctx, "pr-1", ready.Reviewers[0].ID, domain.ReviewVerdictApproved)
+	s.ErrorIs(err, domain.ErrPRNotOpen)
+
+	// После переоткрытия ревью возвращаются прежним ревьюверам
+	reopened, err := s.prService.Reopen(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Equal(domain.PRStatusOpen, reopened.Status)
+	s.True(reopened.ClosedAt.IsZero())
+	s.ElementsMatch(ready.Reviewers, reopened.Reviewers)
+	prs, err := s.prService.GetReviewingPRs(s.ctx, ready.Reviewers[0].ID)
+	s.Require().NoError(err)
+	s.Len(prs, 1)
+
+	merged, err := s.prService.Merge(s.ctx, "pr-1", false)
+	s.Require().NoError(err)
+	s.Equal(domain.PRStatusMerged, merged.Status)
+	_, err = s.prService.Close(s.ctx, "pr-1")
+	s.ErrorIs(err, domain.ErrInvalidPRTransition)
+
+	// Счётчики открытых ревью не разъехались
+	res, err := s.statsRepo.Reconcile(s.ctx)
+	s.Require().NoError(err)
+	s.Zero(res.ReviewersFixed)
+}
+
+// TestListPullRequests проверяет фильтры и keyset-пагинацию списка PR
+func (s *IntegrationTestSuite) TestListPullRequests() {
+	for _, team := range []domain.Team{
+		{
+			Name: "list-a",
+			Members: []domain.User{
+				{ID: "user-1", Username: "alice", TeamName: "list-a", IsActive: true},
+				{ID: "user-2", Username: "bob", TeamName: "list-a", IsActive: true},
+			},
+		},
+		{
+			Name:    "list-b",
+			Members: []domain.User{{ID: "user-3", Username: "charlie", TeamName: "list-b", IsActive: true}},
+		},
+	} {
+		_, err := s.teamService.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
+		s.Require().NoError(err)
+	}
+	for i := 0; i < 5; i++ {
+		_, err := s.prService.Create(s.ctx, domain.PullRequest{ID: fmt.Sprintf("pr-a%d", i), Name: "A", AuthorID: "user-1"})
+		s.Require().NoError(err)
+	}
+	_, err := s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-b", Name: "B", AuthorID: "user-3"})
+	s.Require().NoError(err)
+	_, err = s.prService.Merge(s.ctx, "pr-a0", false)
+	s.Require().NoError(err)
+
+	// Проходим весь список страницами по 2, PR не повторяются и идут от новых к старым
+	var (
+		seen   []domain.PullRequest
+		filter = domain.PullRequestFilter{Limit: 2}
+	)
+	for pages := 0; ; pages++ {
+		s.Require().Less(pages, 4)
+		page, err := s.prService.List(s.ctx, filter)
+		s.Require().NoError(err)
+		seen = append(seen, page.PullRequests...)
+		if page.Next == nil {
+			break
+		}
+		filter.After = page.Next
+	}
+	s.Require().Len(seen, 6)
+	for i := 1; i < len(seen); i++ {
+		prev, cur := seen[i-1], seen[i]
+		s.True(prev.CreatedAt.After(cur.CreatedAt) || prev.CreatedAt.Equal(cur.CreatedAt) && prev.ID > cur.ID)
+	}
+
+	page, err := s.prService.List(s.ctx, domain.PullRequestFilter{TeamName: "list-b"})
+	s.Require().NoError(err)
+	s.Require().Len(page.PullRequests, 1)
+	s.Equal("pr-b", page.PullRequests[0].ID)
+
+	page, err = s.prService.List(s.ctx, domain.PullRequestFilter{Status: domain.PRStatusMerged, AuthorID: "user-1"})
+	s.Require().NoError(err)
+	s.Require().Len(page.PullRequests, 1)
+	s.Equal("pr-a0", page.PullRequests[0].ID)
+	s.Require().Len(page.PullRequests[0].Reviewers, 1)
+	s.Equal("bob", page.PullRequests[0].Reviewers[0].Username)
+
+	page, err = s.prService.List(s.ctx, domain.PullRequestFilter{ReviewerID: "user-2"})
+	s.Require().NoError(err)
+	s.Len(page.PullRequests, 5)
+
+	page, err = s.prService.List(s.ctx, domain.PullRequestFilter{MergedFrom: time.Now().Add(-time.Hour)})
+	s.Require().NoError(err)
+	s.Len(page.PullRequests, 1)
+	page, err = s.prService.List(s.ctx, domain.PullRequestFilter{CreatedTo: time.Now().Add(-time.Hour)})
+	s.Require().NoError(err)
+	s.Empty(page.PullRequests)
+
+	pr, err := s.prService.Get(s.ctx, "pr-b")
+	s.Require().NoError(err)
+	s.Equal("user-3", pr.AuthorID)
+	_, err = s.prService.Get(s.ctx, "ghost")
+	s.ErrorIs(err, domain.ErrPRNotFound)
+}
+
+// TestAuditLog проверяет, что изменения попадают в журнал аудита с автором, ID запроса и снимками до и после
+func (s *IntegrationTestSuite) TestAuditLog() {
+	ctx := domain.WithRequestID(domain.WithActor(s.ctx, "admin"), "req-audit")
+	_, err := s.teamService.Add(ctx, domain.Team{
+		Name: "audit",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "audit", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "audit", IsActive: true},
+			{ID: "user-3", Username: "charlie", TeamName: "audit", IsActive: true},
+			{ID: "user-4", Username: "dave", TeamName: "audit", IsActive: true},
+		},
+	}, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+	_, err = s.userService.SetIsActive(ctx, "user-4", false)
+	s.Require().NoError(err)
+	pr, err := s.prService.Create(ctx, domain.PullRequest{ID: "pr-1", Name: "Audit", AuthorID: "user-1"})
+	s.Require().NoError(err)
+	s.Require().Len(pr.Reviewers, 2)
+	_, err = s.userService.SetIsActive(ctx, "user-4", true)
+	s.Require().NoError(err)
+	_, _, err = s.prService.ReassignReviewer(ctx, "pr-1", pr.Reviewers[0].ID)
+	s.Require().NoError(err)
+	_, err = s.prService.Merge(s.ctx, "pr-1", false)
+	s.Require().NoError(err)
+
+	events, err := s.auditRepo.List(s.ctx, domain.AuditFilter{Limit: 100})
+	s.Require().NoError(err)
+	actions := make([]domain.AuditAction, 0, len(events))
+	for _, event := range events {
+		actions = append(actions, event.Action)
+	}
+	// События идут от новых к старым
+	s.Equal([]domain.AuditAction{
+		domain.AuditActionPRMerge,
+		domain.AuditActionPRReassign,
+		domain.AuditActionUserSetIsActive,
+		domain.AuditActionPRCreate,
+		domain.AuditActionUserSetIsActive,
+		domain.AuditActionUserUpsert,
+		domain.AuditActionUserUpsert,
+		domain.AuditActionUserUpsert,
+		domain.AuditActionUserUpsert,
+		domain.AuditActionTeamAdd,
+	}, actions)
+
+	// Мердж сделан без автора и ID запроса
+	s.Equal(domain.AnonymousActor, events[0].Actor)
+	s.Empty(events[0].RequestID)
+
+	deactivation := events[4]
+	s.Equal("admin", deactivation.Actor)
+	s.Equal("req-audit", deactivation.RequestID)
+	s.Equal(domain.AuditEntityUser, deactivation.EntityType)
+	s.Equal("user-4", deactivation.EntityID)
+	s.JSONEq(`{"user_id":"user-4","username":"dave","team_name":"audit","is_active":true}`, string(deactivation.Before))
+	s.JSONEq(`{"user_id":"user-4","username":"dave","team_name":"audit","is_active":false}`, string(deactivation.After))
+
+	created := events[3]
+	s.Nil(created.Before)
+	s.Contains(string(created.After), `"pull_request_id":"pr-1"`)
+
+	// Фильтры и пагинация
+	byEntity, err := s.auditRepo.List(s.ctx, domain.AuditFilter{
+		EntityType: domain.AuditEntityPullRequest,
+		EntityID:   "pr-1",
+		Limit:      100,
+	})
+	s.Require().NoError(err)
+	s.Len(byEntity, 3)
+	byRequest, err := s.auditRepo.List(s.ctx, domain.AuditFilter{RequestID: "req-audit", Limit: 100})
+	s.Require().NoError(err)
+	s.Len(byRequest, 9)
+	byAction, err := s.auditRepo.List(s.ctx, domain.AuditFilter{Action: domain.AuditActionUserUpsert, Limit: 100})
+	s.Require().NoError(err)
+	s.Len(byAction, 4)
+	page, err := s.auditRepo.List(s.ctx, domain.AuditFilter{Limit: 2, AfterID: events[1].ID})
+	s.Require().NoError(err)
+	s.Require().Len(page, 2)
+	s.Equal(events[2].ID, page[0].ID)
+	s.Equal(events[3].ID, page[1].ID)
+}
+
+// TestWebhooks проверяет, что назначения, переназначения и мердж доходят до подписчика с подписью
+func (s *IntegrationTestSuite) TestWebhooks() {
+	var (
+		mu       sync.Mutex
+		received []webhook.Envelope
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		if r.Header.Get(webhook.HeaderSignature) != webhook.Sign("integration-secret", timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var envelope webhook.Envelope
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		received = append(received, envelope)
+		mu.Unlock()
+	}))
+	defer receiver.Close()
+
+	webhookService := service.NewWebhookService(s.webhookRepo)
+	_, err := webhookService.Subscribe(s.ctx, domain.WebhookSubscription{
+		URL:    receiver.URL,
+		Secret: "integration-secret",
+		EventTypes: []domain.EventType{
+			domain.EventReviewersAssigned, domain.EventReviewerReassigned, domain.EventPullRequestMerged,
+		},
+	})
+	s.Require().NoError(err)
+
+	_, err = s.teamService.Add(s.ctx, domain.Team{
+		Name: "hooks",
+		Members: []domain.User{
+			{ID: "user-1", Username: "alice", TeamName: "hooks", IsActive: true},
+			{ID: "user-2", Username: "bob", TeamName: "hooks", IsActive: true},
+			{ID: "user-3", Username: "charlie", TeamName: "hooks", IsActive: true},
+			{ID: "user-4", Username: "dave", TeamName: "hooks", IsActive: true},
+		},
+	}, domain.TeamAddModeCreateOnly)
+	s.Require().NoError(err)
+	pr, err := s.prService.Create(s.ctx, domain.PullRequest{ID: "pr-1", Name: "Hooks", AuthorID: "user-1"})
+	s.Require().NoError(err)
+	s.Require().Len(pr.Reviewers, 2)
+	oldReviewer := pr.Reviewers[0].ID
+	_, newReviewer, err := s.prService.ReassignReviewer(s.ctx, "pr-1", oldReviewer)
+	s.Require().NoError(err)
+	_, err = s.prService.Merge(s.ctx, "pr-1", false)
+	s.Require().NoError(err)
+
+	dispatcher := webhook.NewDispatcher(s.webhookRepo, config.WebhooksConfig{
+		BatchSize:   100,
+		Timeout:     5 * time.Second,
+		MaxAttempts: 3,
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Minute,
+	})
+	res, err := dispatcher.DispatchOnce(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(webhook.DispatchResult{FannedOut: 3, Delivered: 3}, res)
+
+	// Доставки уходят параллельно, поэтому порядок восстанавливается по ID события
+	slices.SortFunc(received, func(a, b webhook.Envelope) int { return cmp.Compare(a.ID, b.ID) })
+	s.Require().Len(received, 3)
+	s.Equal(string(domain.EventReviewersAssigned), received[0].Type)
+	s.Equal(string(domain.EventReviewerReassigned), received[1].Type)
+	s.JSONEq(fmt.Sprintf(`{"pull_request_id":"pr-1","old_reviewer_id":%q,"new_reviewer_id":%q}`,
+		oldReviewer, newReviewer), string(received[1].Data))
+	s.Equal(string(domain.EventPullRequestMerged), received[2].Type)
+	s.Contains(string(received[2].Data), `"forced":false`)
+
+	deliveries, err := webhookService.ListDeliveries(s.ctx, domain.WebhookDeliveryFilter{
+		Status: domain.DeliveryStatusDelivered,
+	})
+	s.Require().NoError(err)
+	s.Len(deliveries.Deliveries, 3)
+}
+
+// TestDeactivateLargeTeam проверяет, что деактивация команды из ~200 человек укладывается в 100 мс
+func (s *IntegrationTestSuite) TestDeactivateLargeTeam() {
+	ids := seedLargeTeam(s.T(), s.ctx, s.teamService, s.prService)
+
+	start := time.Now()
+	users, _, err := s.teamService.DeactivateUsers(s.ctx, "large-team", ids)
+	elapsed := time.Since(start)
+	s.Require().NoError(err)
+	s.Len(users, largeTeamSize/2)
+	s.Less(elapsed, deactivationBudget)
+}
+
 // TestIntegrationTestSuite запускает test suite
 func TestIntegrationTestSuite(t *testing.T) {
 	if os.Getenv("INTEGRATION_TESTS") == "" {
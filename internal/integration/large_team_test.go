@@ -0,0 +1,108 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/internal/memory"
+	"github.com/artmexbet/avito_test_task/internal/repository"
+	"github.com/artmexbet/avito_test_task/internal/service"
+)
+
+const (
+	// largeTeamSize — размер команды, для которого обещано уложиться в deactivationBudget
+	largeTeamSize      = 200
+	deactivationBudget = 100 * time.Millisecond
+)
+
+// seedLargeTeam создаёт команду из largeTeamSize человек с открытым PR у каждого
+// и возвращает ID половины участников, которых будем деактивировать
+func seedLargeTeam(
+	tb testing.TB,
+	ctx context.Context,
+	teamService *service.TeamService,
+	prService *service.PullRequestService,
+) []string {
+	tb.Helper()
+	members := make([]domain.User, largeTeamSize)
+	ids := make([]string, 0, largeTeamSize/2)
+	for i := range members {
+		members[i] = domain.User{
+			ID:       fmt.Sprintf("user-%d", i),
+			Username: fmt.Sprintf("user%d", i),
+			TeamName: "large-team",
+			IsActive: true,
+		}
+		if i%2 == 0 {
+			ids = append(ids, members[i].ID)
+		}
+	}
+	_, err := teamService.Add(ctx, domain.Team{Name: "large-team", Members: members}, domain.TeamAddModeCreateOnly)
+	require.NoError(tb, err)
+
+	for i := 0; i < largeTeamSize; i++ {
+		_, err := prService.Create(ctx, domain.PullRequest{
+			ID:       fmt.Sprintf("pr-%d", i),
+			Name:     "PR",
+			AuthorID: fmt.Sprintf("user-%d", i),
+		})
+		require.NoError(tb, err)
+	}
+	return ids
+}
+
+// newMemoryTeamServices собирает сервисы команд и PR поверх нового in-memory хранилища
+func newMemoryTeamServices() (*service.TeamService, *service.PullRequestService) {
+	storage := memory.New()
+	userRepo := repository.NewUserRepository(storage)
+	poolRepo := repository.NewReviewerPoolRepository(storage)
+	auditRepo := repository.NewAuditRepository(storage)
+	transactor := repository.NewTransactor(storage)
+
+	prService := service.NewPullRequestService(
+		repository.NewPRRepository(storage), repository.NewReviewersRepository(storage), userRepo, poolRepo,
+		service.NewRandomSelector(), domain.MergePolicy{}, auditRepo, repository.NewWebhookRepository(storage),
+		transactor, nil,
+	)
+	teamService := service.NewTeamService(
+		repository.NewTeamRepository(storage), userRepo, poolRepo, prService, auditRepo, transactor,
+	)
+	return teamService, prService
+}
+
+// TestDeactivateLargeTeamInMemory проверяет бюджет деактивации большой команды без базы,
+// поэтому в отличие от TestDeactivateLargeTeam запускается всегда
+func TestDeactivateLargeTeamInMemory(t *testing.T) {
+	ctx := context.Background()
+	teamService, prService := newMemoryTeamServices()
+	ids := seedLargeTeam(t, ctx, teamService, prService)
+
+	start := time.Now()
+	users, replacements, err := teamService.DeactivateUsers(ctx, "large-team", ids)
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	require.Len(t, users, largeTeamSize/2)
+	require.NotEmpty(t, replacements)
+	require.Less(t, elapsed, deactivationBudget)
+}
+
+// BenchmarkDeactivateLargeTeam измеряет деактивацию половины команды из largeTeamSize человек
+func BenchmarkDeactivateLargeTeam(b *testing.B) {
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		teamService, prService := newMemoryTeamServices()
+		ids := seedLargeTeam(b, ctx, teamService, prService)
+		b.StartTimer()
+
+		if _, _, err := teamService.DeactivateUsers(ctx, "large-team", ids); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package integration
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/artmexbet/avito_test_task/internal/migrator"
+	"github.com/artmexbet/avito_test_task/migrations"
+)
+
+// applyMigrations накатывает все вшитые миграции
+func applyMigrations(ctx context.Context, pool *pgxpool.Pool) error {
+	m, err := migrator.New(pool, migrations.FS)
+	if err != nil {
+		return err
+	}
+	_, err = m.Up(ctx)
+	return err
+}
+
+// MigrationsTestSuite тестирует мигратор на чистой базе
+type MigrationsTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pgContainer *postgres.PostgresContainer
+	pool        *pgxpool.Pool
+	migrator    *migrator.Migrator
+}
+
+// SetupSuite выполняется один раз перед всеми тестами
+func (s *MigrationsTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	pgContainer, err := postgres.Run(s.ctx,
+		"postgres:latest",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(120*time.Second),
+		),
+	)
+	s.Require().NoError(err)
+	s.pgContainer = pgContainer
+
+	connStr, err := pgContainer.ConnectionString(s.ctx, "sslmode=disable")
+	s.Require().NoError(err)
+
+	pool, err := pgxpool.New(s.ctx, connStr)
+	s.Require().NoError(err)
+	s.pool = pool
+
+	s.migrator, err = migrator.New(pool, migrations.FS)
+	s.Require().NoError(err)
+}
+
+// TearDownSuite выполняется один раз после всех тестов
+func (s *MigrationsTestSuite) TearDownSuite() {
+	if s.pool != nil {
+		s.pool.Close()
+	}
+	if s.pgContainer != nil {
+		err := s.pgContainer.Terminate(s.ctx)
+		s.Require().NoError(err)
+	}
+}
+
+// TestUpDownStatus проверяет полный цикл: накат, повторный накат, откат и статус
+func (s *MigrationsTestSuite) TestUpDownStatus() {
+	statuses, err := s.migrator.Status(s.ctx)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(statuses)
+	for _, status := range statuses {
+		s.False(status.Applied())
+	}
+
+	applied, err := s.migrator.Up(s.ctx)
+	s.Require().NoError(err)
+	s.Len(applied, len(statuses))
+
+	// Повторный накат ничего не делает
+	applied, err = s.migrator.Up(s.ctx)
+	s.Require().NoError(err)
+	s.Empty(applied)
+
+	statuses, err = s.migrator.Status(s.ctx)
+	s.Require().NoError(err)
+	for _, status := range statuses {
+		s.True(status.Applied())
+	}
+
+	// Откатываем последнюю миграцию - только она становится pending
+	last := statuses[len(statuses)-1]
+	reverted, err := s.migrator.Down(s.ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(reverted)
+	s.Equal(last.Version, reverted.Version)
+
+	statuses, err = s.migrator.Status(s.ctx)
+	s.Require().NoError(err)
+	s.False(statuses[len(statuses)-1].Applied())
+	s.True(statuses[0].Applied())
+
+	applied, err = s.migrator.Up(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Len(applied, 1)
+	s.Equal(last.Version, applied[0].Version)
+
+	// Откатываем всё до пустой базы
+	for range statuses {
+		_, err := s.migrator.Down(s.ctx)
+		s.Require().NoError(err)
+	}
+	reverted, err = s.migrator.Down(s.ctx)
+	s.Require().NoError(err)
+	s.Nil(reverted)
+
+	var tables int
+	err = s.pool.QueryRow(s.ctx,
+		`SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = 'public' AND table_name <> 'schema_migrations'`,
+	).Scan(&tables)
+	s.Require().NoError(err)
+	s.Zero(tables)
+}
+
+// TestMigrationsSuite запускает test suite
+func TestMigrationsSuite(t *testing.T) {
+	if os.Getenv("INTEGRATION_TESTS") == "" {
+		t.Skip("Skipping integration tests. Set INTEGRATION_TESTS=1 to run them.")
+	}
+	if os.Getenv("INTEGRATION_STORAGE") == "memory" {
+		t.Skip("Migrations are only applicable to PostgreSQL storage.")
+	}
+	suite.Run(t, new(MigrationsTestSuite))
+}
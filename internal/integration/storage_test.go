@@ -0,0 +1,109 @@
+package integration
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/artmexbet/avito_test_task/internal/memory"
+	postgresRepo "github.com/artmexbet/avito_test_task/internal/postgres"
+	"github.com/artmexbet/avito_test_task/internal/repository"
+)
+
+// testStorage — хранилище, на котором гоняются интеграционные тесты.
+// По умолчанию это PostgreSQL в контейнере, INTEGRATION_STORAGE=memory переключает на in-memory хранилище
+type testStorage struct {
+	repository.Storage
+	// pool есть только у PostgreSQL, через него тесты могут править данные в обход сервиса
+	pool  *pgxpool.Pool
+	reset func(ctx context.Context) error
+	close func(ctx context.Context) error
+}
+
+func newTestStorage(ctx context.Context) (*testStorage, error) {
+	if os.Getenv("INTEGRATION_STORAGE") == "memory" {
+		m := memory.New()
+		return &testStorage{
+			Storage: m,
+			reset: func(context.Context) error {
+				m.Reset()
+				return nil
+			},
+			close: func(context.Context) error { return nil },
+		}, nil
+	}
+	return newPostgresTestStorage(ctx)
+}
+
+func newPostgresTestStorage(ctx context.Context) (*testStorage, error) {
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:latest",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(120*time.Second), // Увеличиваем timeout для Windows
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	closeStorage := func(ctx context.Context) error {
+		return pgContainer.Terminate(ctx)
+	}
+
+	connStr, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		_ = closeStorage(ctx)
+		return nil, err
+	}
+
+	pool, err := pgxpool.New(ctx, connStr)
+	if err != nil {
+		_ = closeStorage(ctx)
+		return nil, err
+	}
+	closeStorage = func(ctx context.Context) error {
+		pool.Close()
+		return pgContainer.Terminate(ctx)
+	}
+
+	// Схему накатываем тем же мигратором, что и сервис
+	if err := applyMigrations(ctx, pool); err != nil {
+		_ = closeStorage(ctx)
+		return nil, err
+	}
+
+	return &testStorage{
+		Storage: postgresRepo.New(pool),
+		pool:    pool,
+		reset: func(ctx context.Context) error {
+			_, err := pool.Exec(ctx,
+				"TRUNCATE TABLE reviewer_cursors, team_fallback_pools, reviewer_pool_members, reviewer_pools, "+
+					"user_absences, external_logins, webhook_deliveries, webhook_subscriptions, outbox_events, "+
+					"audit_events, reviewer_stats, team_stats, pull_request_reviews, pull_requests_reviewers, "+
+					"pull_requests, users, teams CASCADE",
+			)
+			return err
+		},
+		close: closeStorage,
+	}, nil
+}
+
+// Reset удаляет все данные, вызывается перед каждым тестом
+func (s *testStorage) Reset(ctx context.Context) error {
+	return s.reset(ctx)
+}
+
+// Close освобождает ресурсы хранилища
+func (s *testStorage) Close(ctx context.Context) error {
+	return s.close(ctx)
+}
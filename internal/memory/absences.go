@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+func (m *Memory) AddAbsence(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	defer m.write(ctx)()
+
+	if _, ok := m.data.users[absence.UserID]; !ok {
+		return domain.Absence{}, domain.ErrUserNotFound
+	}
+	m.data.lastAbsenceID++
+	absence.ID = m.data.lastAbsenceID
+	absence.ReassignedAt = time.Time{}
+	absence.CreatedAt = now()
+	absence.UpdatedAt = absence.CreatedAt
+	m.data.absences[absence.ID] = absence
+	return absence, nil
+}
+
+func (m *Memory) GetAbsence(ctx context.Context, id int64) (domain.Absence, error) {
+	defer m.read(ctx)()
+
+	absence, ok := m.data.absences[id]
+	if !ok {
+		return domain.Absence{}, domain.ErrAbsenceNotFound
+	}
+	return absence, nil
+}
+
+// UpdateAbsence changes the period and the reason of the absence. Its reviews are handed over anew
+// if the changed period is going on
+func (m *Memory) UpdateAbsence(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	defer m.write(ctx)()
+
+	stored, ok := m.data.absences[absence.ID]
+	if !ok {
+		return domain.Absence{}, domain.ErrAbsenceNotFound
+	}
+	stored.StartsAt = absence.StartsAt
+	stored.EndsAt = absence.EndsAt
+	stored.Reason = absence.Reason
+	stored.ReassignedAt = time.Time{}
+	stored.UpdatedAt = now()
+	m.data.absences[stored.ID] = stored
+	return stored, nil
+}
+
+func (m *Memory) DeleteAbsence(ctx context.Context, id int64) error {
+	defer m.write(ctx)()
+
+	if _, ok := m.data.absences[id]; !ok {
+		return domain.ErrAbsenceNotFound
+	}
+	delete(m.data.absences, id)
+	return nil
+}
+
+// ListAbsences returns the absences matching the filter ordered by start
+func (m *Memory) ListAbsences(ctx context.Context, filter domain.AbsenceFilter) ([]domain.Absence, error) {
+	defer m.read(ctx)()
+
+	absences := make([]domain.Absence, 0)
+	for _, absence := range m.data.sortedAbsences() {
+		if filter.UserID != "" && absence.UserID != filter.UserID {
+			continue
+		}
+		if filter.TeamName != "" && m.data.users[absence.UserID].TeamName != filter.TeamName {
+			continue
+		}
+		if !filter.EndsAfter.IsZero() && !absence.EndsAt.After(filter.EndsAfter) {
+			continue
+		}
+		absences = append(absences, absence)
+	}
+	return absences, nil
+}
+
+// ClaimStartedAbsences marks up to batchSize absences going on at now, whose reviews were not handed over yet,
+// as handed over at now and returns them
+func (m *Memory) ClaimStartedAbsences(ctx context.Context, now time.Time, batchSize int) ([]domain.Absence, error) {
+	defer m.write(ctx)()
+
+	claimed := make([]domain.Absence, 0)
+	for _, absence := range m.data.sortedAbsences() {
+		if len(claimed) == batchSize {
+			break
+		}
+		if !absence.ReassignedAt.IsZero() || !absence.CoversAt(now) {
+			continue
+		}
+		absence.ReassignedAt = now
+		m.data.absences[absence.ID] = absence
+		claimed = append(claimed, absence)
+	}
+	return claimed, nil
+}
+
+// isAbsent reports whether the user is away at t
+func (s *state) isAbsent(userID string, t time.Time) bool {
+	for _, absence := range s.absences {
+		if absence.UserID == userID && absence.CoversAt(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedAbsences returns absences ordered by start, like the Postgres queries do
+func (s *state) sortedAbsences() []domain.Absence {
+	absences := slices.Collect(maps.Values(s.absences))
+	slices.SortFunc(absences, func(a, b domain.Absence) int {
+		return cmp.Or(a.StartsAt.Compare(b.StartsAt), cmp.Compare(a.ID, b.ID))
+	})
+	return absences
+}
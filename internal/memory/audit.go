@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// AddAuditEvent appends the event to the audit log. IDs grow with every event, like BIGSERIAL
+func (m *Memory) AddAuditEvent(ctx context.Context, event domain.AuditEvent) (domain.AuditEvent, error) {
+	defer m.write(ctx)()
+
+	return m.data.addAuditEvent(event), nil
+}
+
+// AddAuditEvents appends the events to the audit log in order
+func (m *Memory) AddAuditEvents(ctx context.Context, events []domain.AuditEvent) error {
+	defer m.write(ctx)()
+
+	for _, event := range events {
+		m.data.addAuditEvent(event)
+	}
+	return nil
+}
+
+func (s *state) addAuditEvent(event domain.AuditEvent) domain.AuditEvent {
+	event.ID = int64(len(s.audit)) + 1
+	event.CreatedAt = now()
+	// Полезная нагрузка копируется, чтобы вызывающий не мог переписать журнал
+	event.Before = slices.Clone(event.Before)
+	event.After = slices.Clone(event.After)
+	s.audit = append(s.audit, event)
+	return event
+}
+
+// ListAuditEvents returns at most filter.Limit events matching the filter, newest first
+func (m *Memory) ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	defer m.read(ctx)()
+
+	events := make([]domain.AuditEvent, 0)
+	for _, event := range slices.Backward(m.data.audit) {
+		if len(events) == filter.Limit {
+			break
+		}
+		if filter.AfterID != 0 && event.ID >= filter.AfterID {
+			continue
+		}
+		if !matchesAuditFilter(event, filter) {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func matchesAuditFilter(event domain.AuditEvent, filter domain.AuditFilter) bool {
+	switch {
+	case filter.Action != "" && event.Action != filter.Action,
+		filter.EntityType != "" && event.EntityType != filter.EntityType,
+		filter.EntityID != "" && event.EntityID != filter.EntityID,
+		filter.Actor != "" && event.Actor != filter.Actor,
+		filter.RequestID != "" && event.RequestID != filter.RequestID,
+		!filter.From.IsZero() && event.CreatedAt.Before(filter.From),
+		!filter.To.IsZero() && !event.CreatedAt.Before(filter.To):
+		return false
+	}
+	return true
+}
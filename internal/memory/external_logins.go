@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+type externalLoginKey struct {
+	provider domain.ExternalProvider
+	login    string
+}
+
+// SetExternalLogin maps the login of the provider onto the user, replacing the previous mapping of the login
+func (m *Memory) SetExternalLogin(ctx context.Context, login domain.ExternalLogin) (domain.ExternalLogin, error) {
+	defer m.write(ctx)()
+
+	if _, ok := m.data.users[login.UserID]; !ok {
+		return domain.ExternalLogin{}, domain.ErrUserNotFound
+	}
+	key := externalLoginKey{provider: login.Provider, login: login.Login}
+	login.CreatedAt = now()
+	if stored, ok := m.data.externalLogins[key]; ok {
+		login.CreatedAt = stored.CreatedAt
+	}
+	m.data.externalLogins[key] = login
+	return login, nil
+}
+
+func (m *Memory) GetExternalLogin(
+	ctx context.Context,
+	provider domain.ExternalProvider,
+	login string,
+) (domain.ExternalLogin, error) {
+	defer m.read(ctx)()
+
+	stored, ok := m.data.externalLogins[externalLoginKey{provider: provider, login: login}]
+	if !ok {
+		return domain.ExternalLogin{}, domain.ErrExternalLoginNotFound
+	}
+	return stored, nil
+}
+
+// ListExternalLogins returns the mappings of the provider, or of all providers if it is empty
+func (m *Memory) ListExternalLogins(
+	ctx context.Context,
+	provider domain.ExternalProvider,
+) ([]domain.ExternalLogin, error) {
+	defer m.read(ctx)()
+
+	logins := make([]domain.ExternalLogin, 0)
+	for _, login := range m.data.externalLogins {
+		if provider == "" || login.Provider == provider {
+			logins = append(logins, login)
+		}
+	}
+	slices.SortFunc(logins, func(a, b domain.ExternalLogin) int {
+		return cmp.Or(cmp.Compare(a.Provider, b.Provider), cmp.Compare(a.Login, b.Login))
+	})
+	return logins, nil
+}
+
+func (m *Memory) DeleteExternalLogin(ctx context.Context, provider domain.ExternalProvider, login string) error {
+	defer m.write(ctx)()
+
+	key := externalLoginKey{provider: provider, login: login}
+	if _, ok := m.data.externalLogins[key]; !ok {
+		return domain.ErrExternalLoginNotFound
+	}
+	delete(m.data.externalLogins, key)
+	return nil
+}
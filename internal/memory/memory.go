@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// Memory is an in-memory storage with the same methods as postgres.Postgres.
+// It is safe for concurrent use: reads share a lock, writes and transactions take it exclusively.
+type Memory struct {
+	mu   sync.RWMutex
+	data *state
+}
+
+type state struct {
+	teams map[string]domain.Team
+	users map[string]domain.User
+	// prs хранит PR без ревьюверов, они лежат отдельно, как в pull_requests_reviewers
+	prs       map[string]domain.PullRequest
+	reviewers map[string][]assignment    // pull request ID -> reviewers in order of assignment
+	reviews   map[string][]domain.Review // pull request ID -> verdicts in order of submission
+	// audit только дополняется, поэтому снимок может делить с ним массив: откат просто отбрасывает хвост
+	audit []domain.AuditEvent
+
+	outbox        []outboxEntry
+	subscriptions map[int64]domain.WebhookSubscription
+	deliveries    map[int64]domain.WebhookDelivery
+	// последние выданные ID, чтобы не переиспользовать ID удалённых записей, как BIGSERIAL
+	lastSubscriptionID int64
+	lastDeliveryID     int64
+
+	externalLogins map[externalLoginKey]domain.ExternalLogin
+
+	absences      map[int64]domain.Absence
+	lastAbsenceID int64
+
+	// pools хранит общие пулы без участников, участники лежат в poolMembers, как в reviewer_pool_members
+	pools       map[string]domain.SharedReviewerPool
+	poolMembers map[string][]string              // pool name -> member IDs
+	fallbacks   map[string][]domain.ReviewerPool // team name -> fallback pools in order
+	cursors     map[domain.ReviewerPool]string   // pool -> ID of the last member picked in turn
+}
+
+type outboxEntry struct {
+	event     domain.OutboxEvent
+	fannedOut bool
+}
+
+type assignment struct {
+	ReviewerID string
+	AssignedAt time.Time
+	Pool       domain.ReviewerPool
+}
+
+// New creates an empty in-memory storage.
+func New() *Memory {
+	return &Memory{data: newState()}
+}
+
+func newState() *state {
+	return &state{
+		teams:     make(map[string]domain.Team),
+		users:     make(map[string]domain.User),
+		prs:       make(map[string]domain.PullRequest),
+		reviewers: make(map[string][]assignment),
+		reviews:   make(map[string][]domain.Review),
+
+		subscriptions: make(map[int64]domain.WebhookSubscription),
+		deliveries:    make(map[int64]domain.WebhookDelivery),
+
+		externalLogins: make(map[externalLoginKey]domain.ExternalLogin),
+
+		absences: make(map[int64]domain.Absence),
+
+		pools:       make(map[string]domain.SharedReviewerPool),
+		poolMembers: make(map[string][]string),
+		fallbacks:   make(map[string][]domain.ReviewerPool),
+		cursors:     make(map[domain.ReviewerPool]string),
+	}
+}
+
+// Reset drops all the data.
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data = newState()
+}
+
+func (s *state) clone() *state {
+	reviewers := make(map[string][]assignment, len(s.reviewers))
+	for prID, assigned := range s.reviewers {
+		reviewers[prID] = slices.Clone(assigned)
+	}
+	reviews := make(map[string][]domain.Review, len(s.reviews))
+	for prID, submitted := range s.reviews {
+		reviews[prID] = slices.Clone(submitted)
+	}
+	return &state{
+		teams:     maps.Clone(s.teams),
+		users:     maps.Clone(s.users),
+		prs:       maps.Clone(s.prs),
+		reviewers: reviewers,
+		reviews:   reviews,
+		audit:     s.audit,
+
+		outbox:             slices.Clone(s.outbox),
+		subscriptions:      maps.Clone(s.subscriptions),
+		deliveries:         maps.Clone(s.deliveries),
+		lastSubscriptionID: s.lastSubscriptionID,
+		lastDeliveryID:     s.lastDeliveryID,
+
+		externalLogins: maps.Clone(s.externalLogins),
+
+		absences:      maps.Clone(s.absences),
+		lastAbsenceID: s.lastAbsenceID,
+
+		// списки участников и запасных пулов только заменяются целиком, копировать их не нужно
+		pools:       maps.Clone(s.pools),
+		poolMembers: maps.Clone(s.poolMembers),
+		fallbacks:   maps.Clone(s.fallbacks),
+		cursors:     maps.Clone(s.cursors),
+	}
+}
+
+type txKey struct{}
+
+// WithinTransaction runs fn holding the exclusive lock, so the whole group of calls is isolated.
+// If fn fails, all the changes it made are rolled back. Nested calls join the outer transaction.
+func (m *Memory) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.inTx(ctx) {
+		return fn(ctx)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := m.data.clone()
+	if err := fn(context.WithValue(ctx, txKey{}, m)); err != nil {
+		m.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (m *Memory) inTx(ctx context.Context) bool {
+	owner, _ := ctx.Value(txKey{}).(*Memory)
+	return owner == m
+}
+
+// read takes the shared lock unless ctx is inside a transaction, which already holds the exclusive one.
+func (m *Memory) read(ctx context.Context) (unlock func()) {
+	if m.inTx(ctx) {
+		return func() {}
+	}
+	m.mu.RLock()
+	return m.mu.RUnlock
+}
+
+// write takes the exclusive lock unless ctx is inside a transaction, which already holds it.
+func (m *Memory) write(ctx context.Context) (unlock func()) {
+	if m.inTx(ctx) {
+		return func() {}
+	}
+	m.mu.Lock()
+	return m.mu.Unlock
+}
+
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+func sortUsers(users []domain.User) {
+	slices.SortFunc(users, func(a, b domain.User) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+}
@@ -0,0 +1,537 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// MemoryTestSuite определяет test suite для in-memory хранилища
+type MemoryTestSuite struct {
+	suite.Suite
+	ctx    context.Context
+	memory *Memory
+}
+
+// backendPool - пул команды из SetupTest
+var backendPool = domain.TeamPool("backend")
+
+// SetupTest создаёт пустое хранилище с командой из трёх пользователей
+func (s *MemoryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.memory = New()
+
+	_, err := s.memory.AddTeam(s.ctx, domain.Team{Name: "backend"})
+	s.Require().NoError(err)
+	_, err = s.memory.AddUsers(s.ctx, []domain.User{
+		{ID: "u1", Username: "alice", TeamName: "backend", IsActive: true},
+		{ID: "u2", Username: "bob", TeamName: "backend", IsActive: true},
+		{ID: "u3", Username: "charlie", TeamName: "backend", IsActive: true},
+	})
+	s.Require().NoError(err)
+}
+
+// TestTransactionRollback проверяет, что ошибка в транзакции откатывает все изменения
+func (s *MemoryTestSuite) TestTransactionRollback() {
+	errBoom := errors.New("boom")
+
+	err := s.memory.WithinTransaction(s.ctx, func(ctx context.Context) error {
+		if _, err := s.memory.CreatePullRequest(ctx, domain.PullRequest{ID: "pr-1", AuthorID: "u1"}); err != nil {
+			return err
+		}
+		if err := s.memory.AssignReviewersToPR(ctx, "pr-1", backendPool, []string{"u2"}); err != nil {
+			return err
+		}
+		// Вложенная транзакция присоединяется к внешней
+		return s.memory.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := s.memory.SetUserIsActive(ctx, "u3", false); err != nil {
+				return err
+			}
+			return errBoom
+		})
+	})
+	s.Require().ErrorIs(err, errBoom)
+
+	exists, err := s.memory.ExistsPullRequest(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.False(exists)
+
+	user, err := s.memory.GetUserByID(s.ctx, "u3")
+	s.Require().NoError(err)
+	s.True(user.IsActive)
+}
+
+// TestAuditRollback проверяет, что откат транзакции отбрасывает её события аудита, а ID остаются последовательными
+func (s *MemoryTestSuite) TestAuditRollback() {
+	_, err := s.memory.AddAuditEvent(s.ctx, domain.AuditEvent{Action: domain.AuditActionTeamAdd, EntityID: "backend"})
+	s.Require().NoError(err)
+
+	errBoom := errors.New("boom")
+	err = s.memory.WithinTransaction(s.ctx, func(ctx context.Context) error {
+		if _, err := s.memory.AddAuditEvent(ctx, domain.AuditEvent{Action: domain.AuditActionPRCreate}); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	s.Require().ErrorIs(err, errBoom)
+
+	added, err := s.memory.AddAuditEvent(s.ctx, domain.AuditEvent{Action: domain.AuditActionPRMerge, EntityID: "pr-1"})
+	s.Require().NoError(err)
+	s.Equal(int64(2), added.ID)
+
+	events, err := s.memory.ListAuditEvents(s.ctx, domain.AuditFilter{Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(events, 2)
+	s.Equal(domain.AuditActionPRMerge, events[0].Action)
+	s.Equal(domain.AuditActionTeamAdd, events[1].Action)
+}
+
+// TestOutboxRollback проверяет, что откат транзакции отбрасывает её события и они не раскладываются по подпискам
+func (s *MemoryTestSuite) TestOutboxRollback() {
+	_, err := s.memory.AddWebhookSubscription(s.ctx, domain.WebhookSubscription{
+		URL:        "http://localhost/hooks",
+		EventTypes: []domain.EventType{domain.EventPullRequestMerged},
+	})
+	s.Require().NoError(err)
+
+	errBoom := errors.New("boom")
+	err = s.memory.WithinTransaction(s.ctx, func(ctx context.Context) error {
+		_, err := s.memory.AddOutboxEvent(ctx, domain.OutboxEvent{Type: domain.EventPullRequestMerged, Payload: []byte(`{}`)})
+		if err != nil {
+			return err
+		}
+		return errBoom
+	})
+	s.Require().ErrorIs(err, errBoom)
+
+	fannedOut, err := s.memory.FanOutOutboxEvents(s.ctx, 10)
+	s.Require().NoError(err)
+	s.Zero(fannedOut)
+	deliveries, err := s.memory.ListWebhookDeliveries(s.ctx, domain.WebhookDeliveryFilter{Limit: 10})
+	s.Require().NoError(err)
+	s.Empty(deliveries)
+}
+
+// TestExternalLogins проверяет замену соответствия логина и ссылку на пользователя, которую в PostgreSQL даёт ключ
+func (s *MemoryTestSuite) TestExternalLogins() {
+	_, err := s.memory.SetExternalLogin(s.ctx, domain.ExternalLogin{
+		Provider: domain.ExternalProviderGitHub, Login: "ghost", UserID: "u404",
+	})
+	s.Require().ErrorIs(err, domain.ErrUserNotFound)
+
+	first, err := s.memory.SetExternalLogin(s.ctx, domain.ExternalLogin{
+		Provider: domain.ExternalProviderGitHub, Login: "octocat", UserID: "u1",
+	})
+	s.Require().NoError(err)
+	replaced, err := s.memory.SetExternalLogin(s.ctx, domain.ExternalLogin{
+		Provider: domain.ExternalProviderGitHub, Login: "octocat", UserID: "u2",
+	})
+	s.Require().NoError(err)
+	s.Equal("u2", replaced.UserID)
+	s.Equal(first.CreatedAt, replaced.CreatedAt)
+
+	// тот же логин у другого провайдера - другой пользователь
+	_, err = s.memory.SetExternalLogin(s.ctx, domain.ExternalLogin{
+		Provider: domain.ExternalProviderGitLab, Login: "octocat", UserID: "u3",
+	})
+	s.Require().NoError(err)
+
+	logins, err := s.memory.ListExternalLogins(s.ctx, domain.ExternalProviderGitLab)
+	s.Require().NoError(err)
+	s.Require().Len(logins, 1)
+	s.Equal("u3", logins[0].UserID)
+	logins, err = s.memory.ListExternalLogins(s.ctx, "")
+	s.Require().NoError(err)
+	s.Len(logins, 2)
+
+	s.Require().NoError(s.memory.DeleteExternalLogin(s.ctx, domain.ExternalProviderGitHub, "octocat"))
+	s.ErrorIs(s.memory.DeleteExternalLogin(s.ctx, domain.ExternalProviderGitHub, "octocat"),
+		domain.ErrExternalLoginNotFound)
+	_, err = s.memory.GetExternalLogin(s.ctx, domain.ExternalProviderGitHub, "octocat")
+	s.ErrorIs(err, domain.ErrExternalLoginNotFound)
+}
+
+// TestAbsences проверяет, что отсутствующий пользователь не попадает в кандидаты,
+// а начавшееся отсутствие отдаётся на переназначение один раз
+func (s *MemoryTestSuite) TestAbsences() {
+	now := time.Now().UTC()
+	_, err := s.memory.AddAbsence(s.ctx, domain.Absence{UserID: "u404", StartsAt: now, EndsAt: now.Add(time.Hour)})
+	s.Require().ErrorIs(err, domain.ErrUserNotFound)
+
+	vacation, err := s.memory.AddAbsence(s.ctx, domain.Absence{
+		UserID: "u2", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), Reason: "vacation",
+	})
+	s.Require().NoError(err)
+	// будущее отсутствие пока ни на что не влияет
+	_, err = s.memory.AddAbsence(s.ctx, domain.Absence{
+		UserID: "u3", StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour),
+	})
+	s.Require().NoError(err)
+
+	active, err := s.memory.GetActiveUsersByTeamName(s.ctx, "backend")
+	s.Require().NoError(err)
+	ids := make([]string, 0, len(active))
+	for _, user := range active {
+		ids = append(ids, user.ID)
+	}
+	s.ElementsMatch([]string{"u1", "u3"}, ids)
+
+	claimed, err := s.memory.ClaimStartedAbsences(s.ctx, now, 10)
+	s.Require().NoError(err)
+	s.Require().Len(claimed, 1)
+	s.Equal(vacation.ID, claimed[0].ID)
+	claimed, err = s.memory.ClaimStartedAbsences(s.ctx, now, 10)
+	s.Require().NoError(err)
+	s.Empty(claimed)
+
+	// изменённое отсутствие обрабатывается заново
+	vacation.Reason = "sick leave"
+	_, err = s.memory.UpdateAbsence(s.ctx, vacation)
+	s.Require().NoError(err)
+	claimed, err = s.memory.ClaimStartedAbsences(s.ctx, now, 10)
+	s.Require().NoError(err)
+	s.Len(claimed, 1)
+
+	s.Require().NoError(s.memory.DeleteAbsence(s.ctx, vacation.ID))
+	s.ErrorIs(s.memory.DeleteAbsence(s.ctx, vacation.ID), domain.ErrAbsenceNotFound)
+	absences, err := s.memory.ListAbsences(s.ctx, domain.AbsenceFilter{TeamName: "backend"})
+	s.Require().NoError(err)
+	s.Len(absences, 1)
+}
+
+// TestReviewCapacity проверяет, что набравшие лимит открытых ревью не попадают в кандидаты
+func (s *MemoryTestSuite) TestReviewCapacity() {
+	_, err := s.memory.SetUserMaxOpenReviews(s.ctx, "u404", 1)
+	s.Require().ErrorIs(err, domain.ErrUserNotFound)
+	user, err := s.memory.SetUserMaxOpenReviews(s.ctx, "u2", 1)
+	s.Require().NoError(err)
+	s.Equal(1, user.MaxOpenReviews)
+
+	_, err = s.memory.CreatePullRequest(s.ctx, domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen})
+	s.Require().NoError(err)
+	s.Require().NoError(s.memory.AssignReviewersToPR(s.ctx, "pr-1", backendPool, []string{"u2"}))
+
+	activeIDs := func() []string {
+		active, err := s.memory.GetActiveUsersByTeamName(s.ctx, "backend")
+		s.Require().NoError(err)
+		ids := make([]string, 0, len(active))
+		for _, user := range active {
+			ids = append(ids, user.ID)
+		}
+		return ids
+	}
+	s.ElementsMatch([]string{"u1", "u3"}, activeIDs())
+
+	// смердженный PR больше не занимает место
+	_, err = s.memory.MergePullRequest(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"u1", "u2", "u3"}, activeIDs())
+}
+
+// TestAddUsersStrict проверяет, что AddUsers только создаёт пользователей и не трогает существующих
+func (s *MemoryTestSuite) TestAddUsersStrict() {
+	_, err := s.memory.AddTeam(s.ctx, domain.Team{Name: "frontend"})
+	s.Require().NoError(err)
+
+	_, err = s.memory.AddUsers(s.ctx, []domain.User{
+		{ID: "u4", Username: "dave", TeamName: "frontend", IsActive: true},
+		{ID: "u1", Username: "alice", TeamName: "frontend", IsActive: true},
+	})
+	s.Require().ErrorIs(err, domain.ErrTeamMemberConflict)
+	_, err = s.memory.AddUsers(s.ctx, []domain.User{{ID: "u5", Username: "bob", TeamName: "frontend"}})
+	s.Require().ErrorIs(err, domain.ErrTeamMemberConflict)
+
+	// ничего не записалось, u1 остался в своей команде
+	user, err := s.memory.GetUserByID(s.ctx, "u1")
+	s.Require().NoError(err)
+	s.Equal("backend", user.TeamName)
+	users, err := s.memory.GetUsersByIDs(s.ctx, []string{"u4", "u2", "u1", "u2"})
+	s.Require().NoError(err)
+	s.Require().Len(users, 2)
+	s.Equal("u1", users[0].ID)
+	s.Equal("u2", users[1].ID)
+}
+
+// TestTeamChanges проверяет перевод пользователей, переименование и удаление команд
+func (s *MemoryTestSuite) TestTeamChanges() {
+	_, err := s.memory.AddTeam(s.ctx, domain.Team{Name: "frontend"})
+	s.Require().NoError(err)
+	_, err = s.memory.AddUsers(s.ctx, []domain.User{{ID: "u4", Username: "dave", TeamName: "backend", IsActive: true}})
+	s.Require().NoError(err)
+	_, err = s.memory.CreatePullRequest(s.ctx, domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen})
+	s.Require().NoError(err)
+	s.Require().NoError(s.memory.AssignReviewersToPR(s.ctx, "pr-1", backendPool, []string{"u2", "u3"}))
+	// ревью на собственных PR переводимого остаются за прежними ревьюверами
+	_, err = s.memory.CreatePullRequest(s.ctx, domain.PullRequest{ID: "pr-2", AuthorID: "u2", Status: domain.PRStatusOpen})
+	s.Require().NoError(err)
+	s.Require().NoError(s.memory.AssignReviewersToPR(s.ctx, "pr-2", backendPool, []string{"u1", "u3"}))
+
+	_, err = s.memory.MoveUsersToTeam(s.ctx, "ghost", []string{"u2"})
+	s.Require().ErrorIs(err, domain.ErrTeamNotFound)
+	_, err = s.memory.MoveUsersToTeam(s.ctx, "frontend", []string{"u2", "u404"})
+	s.Require().ErrorIs(err, domain.ErrUserNotFound)
+
+	moved, err := s.memory.MoveUsersToTeam(s.ctx, "frontend", []string{"u2"})
+	s.Require().NoError(err)
+	s.Require().Len(moved, 1)
+	s.Equal("frontend", moved[0].TeamName)
+	// ревью переведённого не трогаются, их передаёт сервис
+	prs, err := s.memory.GetOpenPullRequestsByReviewerIDs(s.ctx, []string{"u2"}, "")
+	s.Require().NoError(err)
+	s.Require().Len(prs, 1)
+	s.Equal("pr-1", prs[0].ID)
+	s.Equal([]string{"u2", "u3"}, userIDs(prs[0].Reviewers))
+	s.Equal(backendPool, prs[0].ReviewerPools["u2"])
+	// PR переведённого автора уже не относятся к прежней команде
+	prs, err = s.memory.GetOpenPullRequestsByReviewerIDs(s.ctx, []string{"u3"}, "backend")
+	s.Require().NoError(err)
+	s.Require().Len(prs, 1)
+	s.Equal("pr-1", prs[0].ID)
+	prs, err = s.memory.GetOpenPullRequestsByReviewerIDs(s.ctx, []string{"u3"}, "")
+	s.Require().NoError(err)
+	s.Len(prs, 2)
+
+	s.Require().NoError(s.memory.UnassignReviewer(s.ctx, "pr-1", "u2"))
+	reviewers, err := s.memory.GetReviewersByPRID(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Require().Len(reviewers, 1)
+	s.Equal("u3", reviewers[0].ID)
+
+	team, err := s.memory.RenameTeam(s.ctx, "backend", "platform")
+	s.Require().NoError(err)
+	s.Equal("platform", team.Name)
+	_, err = s.memory.RenameTeam(s.ctx, "platform", "frontend")
+	s.Require().ErrorIs(err, domain.ErrTeamAlreadyExists)
+	members, err := s.memory.GetUsersByTeamName(s.ctx, "platform")
+	s.Require().NoError(err)
+	s.Len(members, 3)
+	exists, err := s.memory.ExistsTeamByName(s.ctx, "backend")
+	s.Require().NoError(err)
+	s.False(exists)
+
+	s.Require().ErrorIs(s.memory.DeleteTeam(s.ctx, "frontend"), domain.ErrTeamNotEmpty)
+	_, err = s.memory.MoveUsersToTeam(s.ctx, "platform", []string{"u2"})
+	s.Require().NoError(err)
+	s.Require().NoError(s.memory.DeleteTeam(s.ctx, "frontend"))
+	s.Require().ErrorIs(s.memory.DeleteTeam(s.ctx, "frontend"), domain.ErrTeamNotFound)
+}
+
+// TestUserDirectory проверяет фильтры и страницы списка пользователей и мягкое удаление
+func (s *MemoryTestSuite) TestUserDirectory() {
+	_, err := s.memory.AddTeam(s.ctx, domain.Team{Name: "frontend"})
+	s.Require().NoError(err)
+	_, err = s.memory.AddUsers(s.ctx, []domain.User{
+		{ID: "u4", Username: "Alina", TeamName: "frontend", IsActive: false},
+	})
+	s.Require().NoError(err)
+
+	inactive := false
+	users, err := s.memory.ListUsers(s.ctx, domain.UserFilter{UsernamePrefix: "AL", Limit: 10})
+	s.Require().NoError(err)
+	s.Equal([]string{"u1", "u4"}, userIDs(users))
+	users, err = s.memory.ListUsers(s.ctx, domain.UserFilter{IsActive: &inactive, Limit: 10})
+	s.Require().NoError(err)
+	s.Equal([]string{"u4"}, userIDs(users))
+	users, err = s.memory.ListUsers(s.ctx, domain.UserFilter{TeamName: "backend", AfterID: "u1", Limit: 1})
+	s.Require().NoError(err)
+	s.Equal([]string{"u2"}, userIDs(users))
+
+	deleted, err := s.memory.SoftDeleteUser(s.ctx, "u2")
+	s.Require().NoError(err)
+	s.True(deleted.IsDeleted())
+	s.False(deleted.IsActive)
+	_, err = s.memory.SoftDeleteUser(s.ctx, "u2")
+	s.Require().ErrorIs(err, domain.ErrUserNotFound)
+	_, err = s.memory.SetUserIsActive(s.ctx, "u2", true)
+	s.Require().ErrorIs(err, domain.ErrUserNotFound)
+
+	// удалённый пропадает из справочника и команды, но строка остаётся для истории
+	exists, err := s.memory.ExistsUserByID(s.ctx, "u2")
+	s.Require().NoError(err)
+	s.False(exists)
+	user, err := s.memory.GetUserByID(s.ctx, "u2")
+	s.Require().NoError(err)
+	s.True(user.IsDeleted())
+	users, err = s.memory.ListUsers(s.ctx, domain.UserFilter{Limit: 10})
+	s.Require().NoError(err)
+	s.Equal([]string{"u1", "u3", "u4"}, userIDs(users))
+	members, err := s.memory.GetUsersByTeamName(s.ctx, "backend")
+	s.Require().NoError(err)
+	s.Equal([]string{"u1", "u3"}, userIDs(members))
+
+	// удалённые держат команду, пока их не перенесут
+	_, err = s.memory.MoveUsersToTeam(s.ctx, "frontend", []string{"u1", "u3"})
+	s.Require().NoError(err)
+	s.Require().ErrorIs(s.memory.DeleteTeam(s.ctx, "backend"), domain.ErrTeamNotEmpty)
+	_, err = s.memory.MoveDeletedUsersToTeam(s.ctx, "backend", "ghost")
+	s.Require().ErrorIs(err, domain.ErrTeamNotFound)
+	moved, err := s.memory.MoveDeletedUsersToTeam(s.ctx, "backend", "frontend")
+	s.Require().NoError(err)
+	s.Equal([]string{"u2"}, userIDs(moved))
+	s.Require().NoError(s.memory.DeleteTeam(s.ctx, "backend"))
+}
+
+func userIDs(users []domain.User) []string {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+// TestGetOpenPullRequestsNeedingReviewers проверяет, что PR для добора возвращаются вместе с ревьюверами
+func (s *MemoryTestSuite) TestGetOpenPullRequestsNeedingReviewers() {
+	_, err := s.memory.CreatePullRequest(s.ctx, domain.PullRequest{
+		ID:                "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		NeedMoreReviewers: true,
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.memory.AssignReviewersToPR(s.ctx, "pr-1", backendPool, []string{"u2"}))
+
+	prs, err := s.memory.GetOpenPullRequestsNeedingReviewers(s.ctx, "backend")
+	s.Require().NoError(err)
+	s.Require().Len(prs, 1)
+	s.Equal([]string{"u2"}, userIDs(prs[0].Reviewers))
+	s.Equal(backendPool, prs[0].ReviewerPools["u2"])
+}
+
+// TestAssignReviewersValidation проверяет ограничения, которые в PostgreSQL дают ключи
+func (s *MemoryTestSuite) TestAssignReviewersValidation() {
+	_, err := s.memory.CreatePullRequest(s.ctx, domain.PullRequest{ID: "pr-1", AuthorID: "u1"})
+	s.Require().NoError(err)
+
+	_, err = s.memory.CreatePullRequest(s.ctx, domain.PullRequest{ID: "pr-1", AuthorID: "u1"})
+	s.Require().ErrorIs(err, domain.ErrPRAlreadyExists)
+	_, err = s.memory.CreatePullRequest(s.ctx, domain.PullRequest{ID: "pr-2", AuthorID: "ghost"})
+	s.Require().ErrorIs(err, domain.ErrUserNotFound)
+
+	s.Require().ErrorIs(s.memory.AssignReviewersToPR(s.ctx, "pr-1", backendPool, []string{"ghost"}),
+		domain.ErrUserNotFound)
+	s.Require().Error(s.memory.AssignReviewersToPR(s.ctx, "pr-1", backendPool, []string{"u2", "u2"}))
+
+	s.Require().NoError(s.memory.AssignReviewersToPR(s.ctx, "pr-1", backendPool, []string{"u2", "u3"}))
+	reviewers, err := s.memory.GetReviewersByPRID(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Require().Len(reviewers, 2)
+	s.Equal("u2", reviewers[0].ID)
+	s.Equal("u3", reviewers[1].ID)
+
+	// Переназначение на уже назначенного ревьювера запрещено
+	s.Require().Error(s.memory.ReassignReviewer(s.ctx, "pr-1", backendPool, "u3", "u2"))
+}
+
+// TestReviewerPools проверяет общие пулы ревьюверов, запасные пулы команд и пулы назначений
+func (s *MemoryTestSuite) TestReviewerPools() {
+	_, err := s.memory.AddTeam(s.ctx, domain.Team{Name: "frontend"})
+	s.Require().NoError(err)
+	_, err = s.memory.AddUsers(s.ctx, []domain.User{{ID: "u4", Username: "dave", TeamName: "frontend", IsActive: false}})
+	s.Require().NoError(err)
+
+	_, err = s.memory.SetReviewerPool(s.ctx, "guild", []string{"u3", "ghost"})
+	s.Require().ErrorIs(err, domain.ErrUserNotFound)
+	pool, err := s.memory.SetReviewerPool(s.ctx, "guild", []string{"u4", "u3"})
+	s.Require().NoError(err)
+	s.Equal([]string{"u3", "u4"}, userIDs(pool.Members))
+	// неактивные участники пула не ревьюят
+	active, err := s.memory.GetActiveReviewerPoolMembers(s.ctx, "guild")
+	s.Require().NoError(err)
+	s.Equal([]string{"u3"}, userIDs(active))
+
+	guild := domain.SharedPool("guild")
+	s.Require().ErrorIs(s.memory.SetTeamFallbacks(s.ctx, "frontend", []domain.ReviewerPool{domain.SharedPool("ghost")}),
+		domain.ErrReviewerPoolNotFound)
+	s.Require().ErrorIs(s.memory.SetTeamFallbacks(s.ctx, "frontend", []domain.ReviewerPool{domain.TeamPool("ghost")}),
+		domain.ErrTeamNotFound)
+	s.Require().NoError(s.memory.SetTeamFallbacks(s.ctx, "frontend", []domain.ReviewerPool{backendPool, guild}))
+	// backend подстраховывает frontend и напрямую, и через участника u3 в guild
+	teamNames, err := s.memory.GetTeamsFallingBackTo(s.ctx, "backend")
+	s.Require().NoError(err)
+	s.Equal([]string{"frontend"}, teamNames)
+
+	_, err = s.memory.CreatePullRequest(s.ctx, domain.PullRequest{ID: "pr-1", AuthorID: "u4", Status: domain.PRStatusOpen})
+	s.Require().NoError(err)
+	s.Require().NoError(s.memory.AssignReviewersToPR(s.ctx, "pr-1", backendPool, []string{"u1"}))
+	s.Require().NoError(s.memory.AssignReviewersToPR(s.ctx, "pr-1", guild, []string{"u3"}))
+	pools, err := s.memory.GetReviewerPoolsByPRID(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Equal(map[string]domain.ReviewerPool{"u1": backendPool, "u3": guild}, pools)
+
+	// переименование команды переносит её запасные пулы, ссылки на неё и пулы назначений
+	_, err = s.memory.RenameTeam(s.ctx, "backend", "platform")
+	s.Require().NoError(err)
+	fallbacks, err := s.memory.GetTeamFallbacks(s.ctx, "frontend")
+	s.Require().NoError(err)
+	s.Equal([]domain.ReviewerPool{domain.TeamPool("platform"), guild}, fallbacks)
+	pools, err = s.memory.GetReviewerPoolsByPRID(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Equal(domain.TeamPool("platform"), pools["u1"])
+
+	// удалённый пул пропадает из запасных, назначения из него остаются
+	s.Require().NoError(s.memory.DeleteReviewerPool(s.ctx, "guild"))
+	s.Require().ErrorIs(s.memory.DeleteReviewerPool(s.ctx, "guild"), domain.ErrReviewerPoolNotFound)
+	_, err = s.memory.GetReviewerPool(s.ctx, "guild")
+	s.Require().ErrorIs(err, domain.ErrReviewerPoolNotFound)
+	fallbacks, err = s.memory.GetTeamFallbacks(s.ctx, "frontend")
+	s.Require().NoError(err)
+	s.Equal([]domain.ReviewerPool{domain.TeamPool("platform")}, fallbacks)
+	pools, err = s.memory.GetReviewerPoolsByPRID(s.ctx, "pr-1")
+	s.Require().NoError(err)
+	s.Equal(guild, pools["u3"])
+}
+
+// TestLockRequiresTransaction проверяет, что блокировка PR возможна только в транзакции
+func (s *MemoryTestSuite) TestLockRequiresTransaction() {
+	_, err := s.memory.CreatePullRequest(s.ctx, domain.PullRequest{ID: "pr-1", AuthorID: "u1"})
+	s.Require().NoError(err)
+
+	_, err = s.memory.LockPullRequest(s.ctx, "pr-1")
+	s.Require().Error(err)
+
+	err = s.memory.WithinTransaction(s.ctx, func(ctx context.Context) error {
+		pr, err := s.memory.LockPullRequest(ctx, "pr-1")
+		s.Equal("pr-1", pr.ID)
+		return err
+	})
+	s.Require().NoError(err)
+}
+
+// TestConcurrentTransactions проверяет, что транзакции изолированы друг от друга
+func (s *MemoryTestSuite) TestConcurrentTransactions() {
+	const workers = 50
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			prID := fmt.Sprintf("pr-%d", i)
+			err := s.memory.WithinTransaction(s.ctx, func(ctx context.Context) error {
+				if _, err := s.memory.CreatePullRequest(ctx, domain.PullRequest{ID: prID, AuthorID: "u1"}); err != nil {
+					return err
+				}
+				return s.memory.AssignReviewersToPR(ctx, prID, backendPool, []string{"u2"})
+			})
+			s.NoError(err)
+			_, _ = s.memory.GetUsersReviewingPR(s.ctx, "u2")
+		}(i)
+	}
+	wg.Wait()
+
+	load, err := s.memory.CountOpenReviews(s.ctx, []string{"u2", "u3"})
+	s.Require().NoError(err)
+	s.Equal(map[string]int{"u2": workers, "u3": 0}, load)
+}
+
+// TestMemoryTestSuite запускает test suite
+func TestMemoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MemoryTestSuite))
+}
@@ -0,0 +1,238 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+func (m *Memory) CreatePullRequest(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
+	defer m.write(ctx)()
+
+	if _, ok := m.data.prs[pr.ID]; ok {
+		return domain.PullRequest{}, fmt.Errorf("error creating pull request: %w", domain.ErrPRAlreadyExists)
+	}
+	if _, ok := m.data.users[pr.AuthorID]; !ok {
+		return domain.PullRequest{}, fmt.Errorf("error creating pull request: author %s: %w", pr.AuthorID, domain.ErrUserNotFound)
+	}
+
+	status := pr.Status
+	if status == "" {
+		status = domain.PRStatusOpen
+	}
+	created := domain.PullRequest{ //nolint:exhaustruct // Ревьюверы хранятся отдельно
+		ID:        pr.ID,
+		Name:      pr.Name,
+		AuthorID:  pr.AuthorID,
+		Status:    status,
+		CreatedAt: now(),
+
+		NeedMoreReviewers: pr.NeedMoreReviewers,
+	}
+	m.data.prs[pr.ID] = created
+	return created, nil
+}
+
+func (m *Memory) GetPullRequestByID(ctx context.Context, prID string) (domain.PullRequest, error) {
+	defer m.read(ctx)()
+
+	pr, ok := m.data.prs[prID]
+	if !ok {
+		return domain.PullRequest{}, domain.ErrPRNotFound
+	}
+	return pr, nil
+}
+
+// LockPullRequest gets a pull request by ID. Transactions hold the exclusive lock of the whole storage,
+// so the pull request cannot be changed concurrently until the transaction from ctx ends.
+func (m *Memory) LockPullRequest(ctx context.Context, prID string) (domain.PullRequest, error) {
+	if !m.inTx(ctx) {
+		return domain.PullRequest{}, errors.New("locking pull request requires a transaction")
+	}
+	return m.GetPullRequestByID(ctx, prID)
+}
+
+func (m *Memory) MergePullRequest(ctx context.Context, prID string) (domain.PullRequest, error) {
+	defer m.write(ctx)()
+
+	pr, ok := m.data.prs[prID]
+	if !ok {
+		return domain.PullRequest{}, fmt.Errorf("error merging pull request: %w", domain.ErrPRNotFound)
+	}
+	pr.Status = domain.PRStatusMerged
+	pr.MergedAt = now()
+	m.data.prs[prID] = pr
+	return pr, nil
+}
+
+// SetPullRequestStatus moves a pull request to the status. The transition is expected to be checked by the caller.
+// Reviewers stay assigned, but only open pull requests count as their reviews.
+func (m *Memory) SetPullRequestStatus(
+	ctx context.Context,
+	prID string,
+	status domain.PRStatus,
+) (domain.PullRequest, error) {
+	defer m.write(ctx)()
+
+	pr, ok := m.data.prs[prID]
+	if !ok {
+		return domain.PullRequest{}, domain.ErrPRNotFound
+	}
+	pr.Status = status
+	pr.ClosedAt = time.Time{}
+	if status == domain.PRStatusClosed {
+		pr.ClosedAt = now()
+	}
+	pr.NeedMoreReviewers = pr.NeedMoreReviewers && status == domain.PRStatusOpen
+	m.data.prs[prID] = pr
+	return pr, nil
+}
+
+func (m *Memory) ExistsPullRequest(ctx context.Context, prID string) (bool, error) {
+	defer m.read(ctx)()
+
+	_, ok := m.data.prs[prID]
+	return ok, nil
+}
+
+func (m *Memory) SetPullRequestNeedMoreReviewers(ctx context.Context, prID string, needMore bool) error {
+	defer m.write(ctx)()
+
+	pr, ok := m.data.prs[prID]
+	if !ok {
+		return nil
+	}
+	pr.NeedMoreReviewers = needMore
+	m.data.prs[prID] = pr
+	return nil
+}
+
+// GetOpenPullRequestsNeedingReviewers returns open flagged pull requests authored by members of the team
+// along with their reviewers
+func (m *Memory) GetOpenPullRequestsNeedingReviewers(ctx context.Context, teamName string) ([]domain.PullRequest, error) {
+	defer m.read(ctx)()
+
+	pullRequests := make([]domain.PullRequest, 0)
+	for _, pr := range m.data.prs {
+		if !pr.NeedMoreReviewers || pr.Status != domain.PRStatusOpen {
+			continue
+		}
+		if author, ok := m.data.users[pr.AuthorID]; ok && author.TeamName == teamName {
+			m.data.fillReviewers(&pr)
+			pullRequests = append(pullRequests, pr)
+		}
+	}
+	sortPullRequests(pullRequests)
+	return pullRequests, nil
+}
+
+// GetOpenPullRequestsByReviewerIDs returns open pull requests any of the reviewers is assigned to, ordered by ID,
+// along with all their reviewers. With authorTeam set only pull requests authored by its members are returned
+func (m *Memory) GetOpenPullRequestsByReviewerIDs(
+	ctx context.Context,
+	reviewerIDs []string,
+	authorTeam string,
+) ([]domain.PullRequest, error) {
+	defer m.read(ctx)()
+
+	var pullRequests []domain.PullRequest
+	for _, prID := range m.data.sortedPRIDs() {
+		pr := m.data.prs[prID]
+		if pr.Status != domain.PRStatusOpen || authorTeam != "" && m.data.users[pr.AuthorID].TeamName != authorTeam {
+			continue
+		}
+		if !slices.ContainsFunc(m.data.reviewerIDs(prID), func(id string) bool {
+			return slices.Contains(reviewerIDs, id)
+		}) {
+			continue
+		}
+		m.data.fillReviewers(&pr)
+		pullRequests = append(pullRequests, pr)
+	}
+	return pullRequests, nil
+}
+
+// sortPullRequests orders pull requests by creation time, then by ID
+func sortPullRequests(prs []domain.PullRequest) {
+	slices.SortFunc(prs, func(a, b domain.PullRequest) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+func (s *state) sortedPRIDs() []string {
+	ids := make([]string, 0, len(s.prs))
+	for id := range s.prs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// ListPullRequests returns a page of pull requests matching the filter along with their reviewers,
+// at most filter.Limit of them. The order is the same as in postgres: newest first, then by ID descending
+func (m *Memory) ListPullRequests(ctx context.Context, filter domain.PullRequestFilter) ([]domain.PullRequest, error) {
+	defer m.read(ctx)()
+
+	pullRequests := make([]domain.PullRequest, 0)
+	for _, pr := range m.data.prs {
+		if !m.data.matchesPRFilter(pr, filter) {
+			continue
+		}
+		if filter.After != nil && !goesAfter(pr, *filter.After) {
+			continue
+		}
+		pullRequests = append(pullRequests, pr)
+	}
+	sortPullRequests(pullRequests)
+	slices.Reverse(pullRequests)
+	if len(pullRequests) > filter.Limit {
+		pullRequests = pullRequests[:filter.Limit]
+	}
+
+	for i := range pullRequests {
+		m.data.fillReviewers(&pullRequests[i])
+	}
+	return pullRequests, nil
+}
+
+// fillReviewers sets the reviewers of the pull request sorted by ID and their pools, as postgres does
+func (s *state) fillReviewers(pr *domain.PullRequest) {
+	ids := slices.Sorted(slices.Values(s.reviewerIDs(pr.ID)))
+	for _, id := range ids {
+		pr.Reviewers = append(pr.Reviewers, s.users[id])
+	}
+	if len(ids) > 0 {
+		pr.ReviewerPools = s.reviewerPools(pr.ID)
+	}
+}
+
+// goesAfter reports whether the pull request follows the cursor in the list order
+func goesAfter(pr domain.PullRequest, cursor domain.PullRequestCursor) bool {
+	if c := pr.CreatedAt.Compare(cursor.CreatedAt); c != 0 {
+		return c < 0
+	}
+	return pr.ID < cursor.ID
+}
+
+func (s *state) matchesPRFilter(pr domain.PullRequest, filter domain.PullRequestFilter) bool {
+	switch {
+	case filter.Status != "" && pr.Status != filter.Status,
+		filter.AuthorID != "" && pr.AuthorID != filter.AuthorID,
+		filter.TeamName != "" && s.users[pr.AuthorID].TeamName != filter.TeamName,
+		filter.ReviewerID != "" && !slices.Contains(s.reviewerIDs(pr.ID), filter.ReviewerID),
+		!filter.CreatedFrom.IsZero() && pr.CreatedAt.Before(filter.CreatedFrom),
+		!filter.CreatedTo.IsZero() && !pr.CreatedAt.Before(filter.CreatedTo),
+		!filter.MergedFrom.IsZero() && (pr.MergedAt.IsZero() || pr.MergedAt.Before(filter.MergedFrom)),
+		!filter.MergedTo.IsZero() && (pr.MergedAt.IsZero() || !pr.MergedAt.Before(filter.MergedTo)):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+func (m *Memory) SetReviewerPool(
+	ctx context.Context,
+	poolName string,
+	userIDs []string,
+) (domain.SharedReviewerPool, error) {
+	defer m.write(ctx)()
+
+	for _, userID := range userIDs {
+		if _, ok := m.data.users[userID]; !ok {
+			return domain.SharedReviewerPool{}, fmt.Errorf("members of reviewer pool %s: %w", poolName,
+				domain.ErrUserNotFound)
+		}
+	}
+	pool, ok := m.data.pools[poolName]
+	if !ok {
+		pool = domain.SharedReviewerPool{Name: poolName, Members: nil, CreatedAt: now()}
+	}
+	pool.UpdatedAt = now()
+	m.data.pools[poolName] = pool
+	m.data.poolMembers[poolName] = slices.Clone(userIDs)
+	return m.data.reviewerPool(poolName), nil
+}
+
+func (m *Memory) GetReviewerPool(ctx context.Context, poolName string) (domain.SharedReviewerPool, error) {
+	defer m.read(ctx)()
+
+	if _, ok := m.data.pools[poolName]; !ok {
+		return domain.SharedReviewerPool{}, fmt.Errorf("reviewer pool %s: %w", poolName, domain.ErrReviewerPoolNotFound)
+	}
+	return m.data.reviewerPool(poolName), nil
+}
+
+// reviewerPool returns the pool with its members ordered by ID, deleted users are not members
+func (s *state) reviewerPool(poolName string) domain.SharedReviewerPool {
+	pool := s.pools[poolName]
+	pool.Members = make([]domain.User, 0, len(s.poolMembers[poolName]))
+	for _, userID := range s.poolMembers[poolName] {
+		if user := s.users[userID]; !user.IsDeleted() {
+			pool.Members = append(pool.Members, user)
+		}
+	}
+	sortUsers(pool.Members)
+	return pool
+}
+
+// DeleteReviewerPool deletes the pool, teams falling back to it lose it like ON DELETE CASCADE in PostgreSQL
+func (m *Memory) DeleteReviewerPool(ctx context.Context, poolName string) error {
+	defer m.write(ctx)()
+
+	if _, ok := m.data.pools[poolName]; !ok {
+		return fmt.Errorf("reviewer pool %s: %w", poolName, domain.ErrReviewerPoolNotFound)
+	}
+	delete(m.data.pools, poolName)
+	delete(m.data.poolMembers, poolName)
+	m.data.replaceFallback(domain.SharedPool(poolName), domain.ReviewerPool{})
+	return nil
+}
+
+// GetActiveReviewerPoolMembers returns the members of the pool who may review right now
+func (m *Memory) GetActiveReviewerPoolMembers(ctx context.Context, poolName string) ([]domain.User, error) {
+	defer m.read(ctx)()
+
+	return m.data.mayReview(m.data.reviewerPool(poolName).Members), nil
+}
+
+func (m *Memory) GetTeamFallbacks(ctx context.Context, teamName string) ([]domain.ReviewerPool, error) {
+	defer m.read(ctx)()
+
+	return slices.Clone(m.data.fallbacks[teamName]), nil
+}
+
+// GetTeamsFallingBackTo returns the names of the teams falling back to the team itself or to a shared pool
+// with its members, ordered by name
+func (m *Memory) GetTeamsFallingBackTo(ctx context.Context, teamName string) ([]string, error) {
+	defer m.read(ctx)()
+
+	var teamNames []string
+	for name, fallbacks := range m.data.fallbacks {
+		if slices.ContainsFunc(fallbacks, func(fallback domain.ReviewerPool) bool {
+			return m.data.poolHasTeam(fallback, teamName)
+		}) {
+			teamNames = append(teamNames, name)
+		}
+	}
+	slices.Sort(teamNames)
+	return teamNames, nil
+}
+
+// poolHasTeam tells whether the pool is the team itself or a shared pool with its members
+func (s *state) poolHasTeam(pool domain.ReviewerPool, teamName string) bool {
+	if pool.Kind != domain.ReviewerPoolKindShared {
+		return pool.Name == teamName
+	}
+	return slices.ContainsFunc(s.reviewerPool(pool.Name).Members, func(user domain.User) bool {
+		return user.TeamName == teamName
+	})
+}
+
+func (m *Memory) SetTeamFallbacks(ctx context.Context, teamName string, fallbacks []domain.ReviewerPool) error {
+	defer m.write(ctx)()
+
+	if _, ok := m.data.teams[teamName]; !ok {
+		return fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotFound)
+	}
+	for _, fallback := range fallbacks {
+		if fallback.Kind == domain.ReviewerPoolKindShared {
+			if _, ok := m.data.pools[fallback.Name]; !ok {
+				return fmt.Errorf("reviewer pool %s: %w", fallback.Name, domain.ErrReviewerPoolNotFound)
+			}
+		} else if _, ok := m.data.teams[fallback.Name]; !ok {
+			return fmt.Errorf("team with name %s: %w", fallback.Name, domain.ErrTeamNotFound)
+		}
+	}
+	if len(fallbacks) == 0 {
+		delete(m.data.fallbacks, teamName)
+		return nil
+	}
+	m.data.fallbacks[teamName] = slices.Clone(fallbacks)
+	return nil
+}
+
+// replaceFallback replaces the pool in fallbacks of every team, an empty replacement drops it.
+// The lists are rebuilt, so that snapshots of the state don't share them
+func (s *state) replaceFallback(pool, replacement domain.ReviewerPool) {
+	for teamName, fallbacks := range s.fallbacks {
+		if !slices.Contains(fallbacks, pool) {
+			continue
+		}
+		updated := make([]domain.ReviewerPool, 0, len(fallbacks))
+		for _, fallback := range fallbacks {
+			switch {
+			case fallback != pool:
+				updated = append(updated, fallback)
+			case replacement != domain.ReviewerPool{}:
+				updated = append(updated, replacement)
+			}
+		}
+		if len(updated) == 0 {
+			delete(s.fallbacks, teamName)
+			continue
+		}
+		s.fallbacks[teamName] = updated
+	}
+}
+
+// LockReviewerCursor returns the ID of the member of the pool picked last in turn, empty if nobody was.
+// The transaction holds the whole storage, so the cursor needs no lock of its own
+func (m *Memory) LockReviewerCursor(ctx context.Context, pool domain.ReviewerPool) (string, error) {
+	defer m.read(ctx)()
+
+	return m.data.cursors[pool], nil
+}
+
+// SetReviewerCursor remembers the member of the pool picked last in turn
+func (m *Memory) SetReviewerCursor(ctx context.Context, pool domain.ReviewerPool, lastReviewerID string) error {
+	defer m.write(ctx)()
+
+	m.data.cursors[pool] = lastReviewerID
+	return nil
+}
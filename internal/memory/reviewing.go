@@ -0,0 +1,233 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+func (m *Memory) AssignReviewersToPR(
+	ctx context.Context,
+	prID string,
+	pool domain.ReviewerPool,
+	reviewerIDs []string,
+) error {
+	defer m.write(ctx)()
+
+	if _, ok := m.data.prs[prID]; !ok {
+		return fmt.Errorf("error assigning reviewers to PR: %w", domain.ErrPRNotFound)
+	}
+	assigned := m.data.reviewerIDs(prID)
+	for i, reviewerID := range reviewerIDs {
+		if _, ok := m.data.users[reviewerID]; !ok {
+			return fmt.Errorf("error assigning reviewers to PR: reviewer %s: %w", reviewerID, domain.ErrUserNotFound)
+		}
+		if slices.Contains(assigned, reviewerID) || slices.Contains(reviewerIDs[:i], reviewerID) {
+			return fmt.Errorf("error assigning reviewers to PR: reviewer %s is already assigned", reviewerID)
+		}
+	}
+
+	for _, reviewerID := range reviewerIDs {
+		m.data.reviewers[prID] = append(m.data.reviewers[prID],
+			assignment{ReviewerID: reviewerID, AssignedAt: now(), Pool: pool})
+	}
+	return nil
+}
+
+func (m *Memory) GetReviewersByPRID(ctx context.Context, prID string) ([]domain.User, error) {
+	defer m.read(ctx)()
+
+	var reviewers []domain.User
+	for _, reviewerID := range m.data.reviewerIDs(prID) {
+		reviewers = append(reviewers, m.data.users[reviewerID])
+	}
+	return reviewers, nil
+}
+
+func (m *Memory) GetReviewerPoolsByPRID(ctx context.Context, prID string) (map[string]domain.ReviewerPool, error) {
+	defer m.read(ctx)()
+
+	return m.data.reviewerPools(prID), nil
+}
+
+func (m *Memory) ReassignReviewer(
+	ctx context.Context,
+	prID string,
+	pool domain.ReviewerPool,
+	newReviewerID, oldReviewerID string,
+) error {
+	defer m.write(ctx)()
+
+	if _, ok := m.data.users[newReviewerID]; !ok {
+		return fmt.Errorf("reviewer %s: %w", newReviewerID, domain.ErrUserNotFound)
+	}
+	assigned := m.data.reviewerIDs(prID)
+	if !slices.Contains(assigned, oldReviewerID) {
+		return nil
+	}
+	if slices.Contains(assigned, newReviewerID) {
+		return fmt.Errorf("reviewer %s is already assigned to pull request %s", newReviewerID, prID)
+	}
+	m.data.replaceReviewer(prID, pool, newReviewerID, oldReviewerID)
+	return nil
+}
+
+// UnassignReviewer removes the reviewer from the pull request
+func (m *Memory) UnassignReviewer(ctx context.Context, prID, reviewerID string) error {
+	defer m.write(ctx)()
+
+	m.data.removeReviewer(prID, reviewerID)
+	return nil
+}
+
+// HandOverReviewers applies the replacements of reviewers on open pull requests. Reviewers without a replacement
+// are removed and their pull requests get NeedMoreReviewers. Replacements of reviewers that are no longer assigned
+// are skipped
+func (m *Memory) HandOverReviewers(ctx context.Context, handovers []domain.ReviewerHandover) error {
+	defer m.write(ctx)()
+
+	for _, h := range handovers {
+		if h.NewReviewerID == "" {
+			continue
+		}
+		if _, ok := m.data.users[h.NewReviewerID]; !ok {
+			return fmt.Errorf("reviewer %s: %w", h.NewReviewerID, domain.ErrUserNotFound)
+		}
+	}
+	for _, h := range handovers {
+		assigned := m.data.reviewerIDs(h.PullRequestID)
+		if !slices.Contains(assigned, h.OldReviewerID) {
+			continue
+		}
+		if h.NewReviewerID != "" {
+			if slices.Contains(assigned, h.NewReviewerID) {
+				return fmt.Errorf("reviewer %s is already assigned to pull request %s", h.NewReviewerID,
+					h.PullRequestID)
+			}
+			m.data.replaceReviewer(h.PullRequestID, h.Pool, h.NewReviewerID, h.OldReviewerID)
+			continue
+		}
+		m.data.removeReviewer(h.PullRequestID, h.OldReviewerID)
+		pr := m.data.prs[h.PullRequestID]
+		pr.NeedMoreReviewers = true
+		m.data.prs[h.PullRequestID] = pr
+	}
+	return nil
+}
+
+func (m *Memory) GetUsersReviewingPR(ctx context.Context, userID string) ([]domain.PullRequest, error) {
+	defer m.read(ctx)()
+
+	var pullRequests []domain.PullRequest
+	for prID, pr := range m.data.prs {
+		if pr.Status == domain.PRStatusOpen && slices.Contains(m.data.reviewerIDs(prID), userID) {
+			pullRequests = append(pullRequests, pr)
+		}
+	}
+	sortPullRequests(pullRequests)
+	return pullRequests, nil
+}
+
+func (m *Memory) IsReviewerAssignedToPR(ctx context.Context, prID, reviewerID string) (bool, error) {
+	defer m.read(ctx)()
+
+	return slices.Contains(m.data.reviewerIDs(prID), reviewerID), nil
+}
+
+// CountOpenReviews returns the number of unmerged pull requests each reviewer is assigned to.
+// Reviewers without open reviews are present in the result with zero.
+func (m *Memory) CountOpenReviews(ctx context.Context, reviewerIDs []string) (map[string]int, error) {
+	defer m.read(ctx)()
+
+	return m.data.countOpenReviews(reviewerIDs), nil
+}
+
+func (s *state) countOpenReviews(reviewerIDs []string) map[string]int {
+	load := make(map[string]int, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		load[id] = 0
+	}
+	for prID, assigned := range s.reviewers {
+		if s.prs[prID].Status != domain.PRStatusOpen {
+			continue
+		}
+		for _, a := range assigned {
+			if _, ok := load[a.ReviewerID]; ok {
+				load[a.ReviewerID]++
+			}
+		}
+	}
+	return load
+}
+
+func (s *state) reviewerIDs(prID string) []string {
+	assigned := s.reviewers[prID]
+	ids := make([]string, len(assigned))
+	for i, a := range assigned {
+		ids[i] = a.ReviewerID
+	}
+	return ids
+}
+
+func (s *state) reviewerPools(prID string) map[string]domain.ReviewerPool {
+	pools := make(map[string]domain.ReviewerPool, len(s.reviewers[prID]))
+	for _, a := range s.reviewers[prID] {
+		pools[a.ReviewerID] = a.Pool
+	}
+	return pools
+}
+
+func (s *state) replaceReviewer(prID string, pool domain.ReviewerPool, newReviewerID, oldReviewerID string) {
+	for i, a := range s.reviewers[prID] {
+		if a.ReviewerID == oldReviewerID {
+			s.reviewers[prID][i] = assignment{ReviewerID: newReviewerID, AssignedAt: now(), Pool: pool}
+			return
+		}
+	}
+}
+
+func (s *state) removeReviewer(prID, reviewerID string) {
+	s.reviewers[prID] = slices.DeleteFunc(s.reviewers[prID], func(a assignment) bool {
+		return a.ReviewerID == reviewerID
+	})
+}
+
+func (m *Memory) AddReview(ctx context.Context, review domain.Review) (domain.Review, error) {
+	defer m.write(ctx)()
+
+	if _, ok := m.data.prs[review.PullRequestID]; !ok {
+		return domain.Review{}, fmt.Errorf("error adding review: %w", domain.ErrPRNotFound)
+	}
+	if _, ok := m.data.users[review.ReviewerID]; !ok {
+		return domain.Review{}, fmt.Errorf("error adding review: reviewer %s: %w", review.ReviewerID, domain.ErrUserNotFound)
+	}
+	review.SubmittedAt = now()
+	m.data.reviews[review.PullRequestID] = append(m.data.reviews[review.PullRequestID], review)
+	return review, nil
+}
+
+func (m *Memory) GetReviewsByPRID(ctx context.Context, prID string) ([]domain.Review, error) {
+	defer m.read(ctx)()
+
+	// вердикты снятых с PR ревьюверов не считаются: они уже не могут их поменять
+	return slices.DeleteFunc(m.data.latestReviews(prID), func(r domain.Review) bool {
+		return !slices.ContainsFunc(m.data.reviewers[prID], func(a assignment) bool { return a.ReviewerID == r.ReviewerID })
+	}), nil
+}
+
+// latestReviews returns the last verdict of every reviewer of the pull request ordered by reviewer ID
+func (s *state) latestReviews(prID string) []domain.Review {
+	latest := make(map[string]domain.Review)
+	for _, r := range s.reviews[prID] {
+		latest[r.ReviewerID] = r
+	}
+
+	var reviews []domain.Review
+	for _, reviewerID := range slices.Sorted(maps.Keys(latest)) {
+		reviews = append(reviews, latest[reviewerID])
+	}
+	return reviews
+}
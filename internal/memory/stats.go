@@ -0,0 +1,273 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	stats_retriever "github.com/artmexbet/avito_test_task/internal/stats-retriever"
+)
+
+func (m *Memory) GetUserStats(ctx context.Context, filter stats_retriever.Filter) ([]stats_retriever.UsersStats, error) {
+	defer m.read(ctx)()
+
+	counts := make(map[bool]int)
+	for _, user := range m.data.users {
+		if filter.TeamName == "" || user.TeamName == filter.TeamName {
+			counts[user.IsActive]++
+		}
+	}
+
+	var userStats []stats_retriever.UsersStats
+	for _, isActive := range []bool{false, true} {
+		if total, ok := counts[isActive]; ok {
+			userStats = append(userStats, stats_retriever.UsersStats{IsActive: isActive, Total: total})
+		}
+	}
+	return userStats, nil
+}
+
+func (m *Memory) GetTeamStats(ctx context.Context, filter stats_retriever.Filter) ([]stats_retriever.TeamsStats, error) {
+	defer m.read(ctx)()
+
+	counts := make(map[string]int)
+	for _, assigned := range m.data.reviewers {
+		for _, a := range assigned {
+			teamName := m.data.users[a.ReviewerID].TeamName
+			if filter.TeamName == "" || teamName == filter.TeamName {
+				counts[teamName]++
+			}
+		}
+	}
+
+	var teamStats []stats_retriever.TeamsStats
+	for teamName, total := range counts {
+		teamStats = append(teamStats, stats_retriever.TeamsStats{TeamName: teamName, TotalPRs: total})
+	}
+	slices.SortFunc(teamStats, func(a, b stats_retriever.TeamsStats) int {
+		return strings.Compare(a.TeamName, b.TeamName)
+	})
+	return teamStats, nil
+}
+
+func (m *Memory) GetAssignmentStats(ctx context.Context, filter stats_retriever.Filter) ([]stats_retriever.AssignmentStats, error) {
+	defer m.read(ctx)()
+
+	counts := make(map[string]int)
+	for _, assigned := range m.data.reviewers {
+		for _, a := range assigned {
+			if m.data.matchesUser(a.ReviewerID, filter) {
+				counts[a.ReviewerID]++
+			}
+		}
+	}
+
+	var assignStats []stats_retriever.AssignmentStats
+	for reviewerID, total := range counts {
+		assignStats = append(assignStats, stats_retriever.AssignmentStats{
+			ReviewerID: reviewerID,
+			IsActive:   m.data.users[reviewerID].IsActive,
+			PRCount:    total,
+		})
+	}
+	slices.SortFunc(assignStats, func(a, b stats_retriever.AssignmentStats) int {
+		if a.PRCount != b.PRCount {
+			return a.PRCount - b.PRCount
+		}
+		return strings.Compare(a.ReviewerID, b.ReviewerID)
+	})
+	return assignStats, nil
+}
+
+func (m *Memory) GetTimeToMerge(ctx context.Context, filter stats_retriever.Filter) (stats_retriever.TimeToMerge, error) {
+	defer m.read(ctx)()
+
+	var durations []float64
+	for _, pr := range m.data.prs {
+		if pr.MergedAt.IsZero() || !inRange(pr.MergedAt, filter) || !m.data.matchesUser(pr.AuthorID, filter) {
+			continue
+		}
+		durations = append(durations, pr.MergedAt.Sub(pr.CreatedAt).Seconds())
+	}
+	slices.Sort(durations)
+
+	return stats_retriever.TimeToMerge{
+		MergedCount:   len(durations),
+		MedianSeconds: percentile(durations, 0.5),
+		P90Seconds:    percentile(durations, 0.9),
+	}, nil
+}
+
+func (m *Memory) GetPullRequestsByPeriod(
+	ctx context.Context,
+	filter stats_retriever.Filter,
+) ([]stats_retriever.PullRequestsPeriodStats, error) {
+	defer m.read(ctx)()
+
+	byPeriod := make(map[time.Time]*stats_retriever.PullRequestsPeriodStats)
+	period := func(t time.Time) *stats_retriever.PullRequestsPeriodStats {
+		start := truncate(t, filter.Period)
+		if _, ok := byPeriod[start]; !ok {
+			byPeriod[start] = &stats_retriever.PullRequestsPeriodStats{PeriodStart: start}
+		}
+		return byPeriod[start]
+	}
+	for _, pr := range m.data.prs {
+		if !m.data.matchesUser(pr.AuthorID, filter) {
+			continue
+		}
+		if inRange(pr.CreatedAt, filter) {
+			period(pr.CreatedAt).Opened++
+		}
+		if !pr.MergedAt.IsZero() && inRange(pr.MergedAt, filter) {
+			period(pr.MergedAt).Merged++
+		}
+	}
+
+	var prStats []stats_retriever.PullRequestsPeriodStats
+	for _, start := range slices.SortedFunc(maps.Keys(byPeriod), time.Time.Compare) {
+		prStats = append(prStats, *byPeriod[start])
+	}
+	return prStats, nil
+}
+
+func (m *Memory) GetAssignmentsByPeriod(
+	ctx context.Context,
+	filter stats_retriever.Filter,
+) ([]stats_retriever.AssignmentsPeriodStats, error) {
+	defer m.read(ctx)()
+
+	type key struct {
+		start      time.Time
+		reviewerID string
+	}
+	counts := make(map[key]int)
+	for _, assigned := range m.data.reviewers {
+		for _, a := range assigned {
+			if inRange(a.AssignedAt, filter) && m.data.matchesUser(a.ReviewerID, filter) {
+				counts[key{start: truncate(a.AssignedAt, filter.Period), reviewerID: a.ReviewerID}]++
+			}
+		}
+	}
+
+	var assignStats []stats_retriever.AssignmentsPeriodStats
+	for k, total := range counts {
+		assignStats = append(assignStats, stats_retriever.AssignmentsPeriodStats{
+			PeriodStart: k.start,
+			ReviewerID:  k.reviewerID,
+			Assignments: total,
+		})
+	}
+	slices.SortFunc(assignStats, func(a, b stats_retriever.AssignmentsPeriodStats) int {
+		if c := a.PeriodStart.Compare(b.PeriodStart); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ReviewerID, b.ReviewerID)
+	})
+	return assignStats, nil
+}
+
+func (m *Memory) GetTimeToFirstReview(
+	ctx context.Context,
+	filter stats_retriever.Filter,
+) (stats_retriever.TimeToFirstReview, error) {
+	defer m.read(ctx)()
+
+	var durations []float64
+	for prID, reviews := range m.data.reviews {
+		pr := m.data.prs[prID]
+		// Вердикты добавляются по времени, первый из них и есть первое ревью
+		if len(reviews) == 0 || !inRange(reviews[0].SubmittedAt, filter) || !m.data.matchesUser(pr.AuthorID, filter) {
+			continue
+		}
+		durations = append(durations, reviews[0].SubmittedAt.Sub(pr.CreatedAt).Seconds())
+	}
+	slices.Sort(durations)
+
+	return stats_retriever.TimeToFirstReview{
+		ReviewedCount: len(durations),
+		MedianSeconds: percentile(durations, 0.5),
+		P90Seconds:    percentile(durations, 0.9),
+	}, nil
+}
+
+func (m *Memory) GetReviewerApprovalStats(
+	ctx context.Context,
+	filter stats_retriever.Filter,
+) ([]stats_retriever.ReviewerApprovalStats, error) {
+	defer m.read(ctx)()
+
+	reviewed := make(map[string]int)
+	approved := make(map[string]int)
+	for prID := range m.data.reviews {
+		for _, r := range m.data.latestReviews(prID) {
+			if !inRange(r.SubmittedAt, filter) || !m.data.matchesUser(r.ReviewerID, filter) {
+				continue
+			}
+			reviewed[r.ReviewerID]++
+			if r.Verdict == domain.ReviewVerdictApproved {
+				approved[r.ReviewerID]++
+			}
+		}
+	}
+
+	var approvals []stats_retriever.ReviewerApprovalStats
+	for _, reviewerID := range slices.Sorted(maps.Keys(reviewed)) {
+		approvals = append(approvals,
+			stats_retriever.NewReviewerApprovalStats(reviewerID, reviewed[reviewerID], approved[reviewerID]))
+	}
+	return approvals, nil
+}
+
+// matchesUser reports whether the user passes the team and user filters
+func (s *state) matchesUser(userID string, filter stats_retriever.Filter) bool {
+	if filter.UserID != "" && userID != filter.UserID {
+		return false
+	}
+	return filter.TeamName == "" || s.users[userID].TeamName == filter.TeamName
+}
+
+func inRange(t time.Time, filter stats_retriever.Filter) bool {
+	return (filter.From.IsZero() || !t.Before(filter.From)) && (filter.To.IsZero() || t.Before(filter.To))
+}
+
+// truncate works like date_trunc in postgres: weeks start on Monday
+func truncate(t time.Time, period stats_retriever.Period) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case stats_retriever.PeriodWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case stats_retriever.PeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// percentile interpolates between the closest ranks like percentile_cont in postgres. values must be sorted.
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	pos := p * float64(len(values)-1)
+	lower := int(pos)
+	if lower == len(values)-1 {
+		return values[lower]
+	}
+	return values[lower] + (pos-float64(lower))*(values[lower+1]-values[lower])
+}
+
+func (m *Memory) CountTeams(ctx context.Context) (int, error) {
+	defer m.read(ctx)()
+
+	return len(m.data.teams), nil
+}
+
+// ReconcileStats does nothing: the in-memory storage computes stats from the data itself, so they cannot drift.
+func (m *Memory) ReconcileStats(context.Context) (stats_retriever.ReconcileResult, error) {
+	return stats_retriever.ReconcileResult{}, nil
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+func (m *Memory) GetTeamByName(ctx context.Context, teamName string) (domain.Team, error) {
+	defer m.read(ctx)()
+
+	team, ok := m.data.teams[teamName]
+	if !ok {
+		return domain.Team{}, fmt.Errorf("failed to get team by name: %w", domain.ErrTeamNotFound)
+	}
+	return team, nil
+}
+
+func (m *Memory) AddTeam(ctx context.Context, team domain.Team) (domain.Team, error) {
+	defer m.write(ctx)()
+
+	stored, ok := m.data.teams[team.Name]
+	if !ok {
+		stored = domain.Team{Name: team.Name, CreatedAt: now()}
+	}
+	stored.UpdatedAt = now()
+	m.data.teams[team.Name] = stored
+	return stored, nil
+}
+
+func (m *Memory) ExistsTeamByName(ctx context.Context, teamName string) (bool, error) {
+	defer m.read(ctx)()
+
+	_, ok := m.data.teams[teamName]
+	return ok, nil
+}
+
+// RenameTeam changes the name of the team, members and fallbacks follow it like ON UPDATE CASCADE in PostgreSQL.
+// Reviewers picked from the team and its round-robin cursor are relabeled too
+func (m *Memory) RenameTeam(ctx context.Context, teamName, newName string) (domain.Team, error) {
+	defer m.write(ctx)()
+
+	team, ok := m.data.teams[teamName]
+	if !ok {
+		return domain.Team{}, fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotFound)
+	}
+	if _, ok := m.data.teams[newName]; ok {
+		return domain.Team{}, fmt.Errorf("team with name %s: %w", newName, domain.ErrTeamAlreadyExists)
+	}
+
+	delete(m.data.teams, teamName)
+	team.Name = newName
+	team.UpdatedAt = now()
+	m.data.teams[newName] = team
+	for id, user := range m.data.users {
+		if user.TeamName == teamName {
+			user.TeamName = newName
+			m.data.users[id] = user
+		}
+	}
+	if fallbacks, ok := m.data.fallbacks[teamName]; ok {
+		delete(m.data.fallbacks, teamName)
+		m.data.fallbacks[newName] = fallbacks
+	}
+	m.data.replaceFallback(domain.TeamPool(teamName), domain.TeamPool(newName))
+	if cursor, ok := m.data.cursors[domain.TeamPool(teamName)]; ok {
+		delete(m.data.cursors, domain.TeamPool(teamName))
+		m.data.cursors[domain.TeamPool(newName)] = cursor
+	}
+	for _, assigned := range m.data.reviewers {
+		for i, a := range assigned {
+			if a.Pool == domain.TeamPool(teamName) {
+				assigned[i].Pool = domain.TeamPool(newName)
+			}
+		}
+	}
+	return team, nil
+}
+
+// DeleteTeam deletes the team without members. Members have to be moved to another team first
+func (m *Memory) DeleteTeam(ctx context.Context, teamName string) error {
+	defer m.write(ctx)()
+
+	if _, ok := m.data.teams[teamName]; !ok {
+		return fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotFound)
+	}
+	// удалённые пользователи тоже держат команду, как строки users в Postgres
+	for _, user := range m.data.users {
+		if user.TeamName == teamName {
+			return fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotEmpty)
+		}
+	}
+	delete(m.data.teams, teamName)
+	delete(m.data.fallbacks, teamName)
+	m.data.replaceFallback(domain.TeamPool(teamName), domain.ReviewerPool{})
+	return nil
+}
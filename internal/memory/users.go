@@ -0,0 +1,274 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// AddUsers inserts new users. An existing ID or username is reported as domain.ErrTeamMemberConflict
+func (m *Memory) AddUsers(ctx context.Context, users []domain.User) ([]domain.User, error) {
+	defer m.write(ctx)()
+
+	// Проверяем всё до записи, чтобы не оставить половину пользователей при ошибке
+	for i, user := range users {
+		if _, ok := m.data.teams[user.TeamName]; !ok {
+			return nil, fmt.Errorf("error adding users: team %s: %w", user.TeamName, domain.ErrTeamNotFound)
+		}
+		if _, ok := m.data.users[user.ID]; ok {
+			return nil, fmt.Errorf("error adding users: %w: user %s exists", domain.ErrTeamMemberConflict, user.ID)
+		}
+		taken := slices.ContainsFunc(users[:i], func(other domain.User) bool { return other.Username == user.Username })
+		for _, other := range m.data.users {
+			taken = taken || other.Username == user.Username
+		}
+		if taken {
+			return nil, fmt.Errorf("error adding users: %w: username %s is already taken",
+				domain.ErrTeamMemberConflict, user.Username)
+		}
+	}
+
+	addedUsers := make([]domain.User, len(users))
+	for i, user := range users {
+		stored := domain.User{
+			ID:             user.ID,
+			Username:       user.Username,
+			TeamName:       user.TeamName,
+			IsActive:       user.IsActive,
+			MaxOpenReviews: user.MaxOpenReviews,
+			CreatedAt:      now(),
+			UpdatedAt:      now(),
+		}
+		m.data.users[user.ID] = stored
+		addedUsers[i] = stored
+	}
+	return addedUsers, nil
+}
+
+func (m *Memory) ExistsUserByID(ctx context.Context, userID string) (bool, error) {
+	defer m.read(ctx)()
+
+	user, ok := m.data.users[userID]
+	return ok && !user.IsDeleted(), nil
+}
+
+func (m *Memory) GetUserByID(ctx context.Context, userID string) (domain.User, error) {
+	defer m.read(ctx)()
+
+	user, ok := m.data.users[userID]
+	if !ok {
+		return domain.User{}, fmt.Errorf("user with ID %s: %w", userID, domain.ErrUserNotFound)
+	}
+	return user, nil
+}
+
+// GetUsersByIDs returns the existing users among the given IDs ordered by ID
+func (m *Memory) GetUsersByIDs(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	defer m.read(ctx)()
+
+	users := make([]domain.User, 0, len(userIDs))
+	for _, userID := range uniqueStrings(userIDs) {
+		if user, ok := m.data.users[userID]; ok {
+			users = append(users, user)
+		}
+	}
+	slices.SortFunc(users, func(a, b domain.User) int { return strings.Compare(a.ID, b.ID) })
+	return users, nil
+}
+
+func (m *Memory) GetUsersByTeamName(ctx context.Context, teamName string) ([]domain.User, error) {
+	defer m.read(ctx)()
+
+	return m.data.usersOfTeam(teamName, false), nil
+}
+
+func (m *Memory) SetUserIsActive(ctx context.Context, userID string, isActive bool) (domain.User, error) {
+	defer m.write(ctx)()
+
+	user, ok := m.data.users[userID]
+	if !ok || user.IsDeleted() {
+		return domain.User{}, fmt.Errorf("error setting user %s is_active: %w", userID, domain.ErrUserNotFound)
+	}
+	user.IsActive = isActive
+	user.UpdatedAt = now()
+	m.data.users[userID] = user
+	return user, nil
+}
+
+// SetUserMaxOpenReviews sets the review limit of the user, 0 removes the limit
+func (m *Memory) SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error) {
+	defer m.write(ctx)()
+
+	user, ok := m.data.users[userID]
+	if !ok || user.IsDeleted() {
+		return domain.User{}, fmt.Errorf("user with ID %s: %w", userID, domain.ErrUserNotFound)
+	}
+	user.MaxOpenReviews = maxOpenReviews
+	user.UpdatedAt = now()
+	m.data.users[userID] = user
+	return user, nil
+}
+
+func (m *Memory) GetActiveUsersByTeamName(ctx context.Context, teamName string) ([]domain.User, error) {
+	defer m.read(ctx)()
+
+	return m.data.usersOfTeam(teamName, true), nil
+}
+
+// DeactivateTeamUsers deactivates users of the team (all of them if userIDs is empty) atomically.
+// Their open reviews are left to the caller
+func (m *Memory) DeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error) {
+	defer m.write(ctx)()
+
+	var deactivated []domain.User
+	for _, user := range m.data.usersOfTeam(teamName, false) {
+		if len(userIDs) == 0 || slices.Contains(userIDs, user.ID) {
+			deactivated = append(deactivated, user)
+		}
+	}
+	if len(userIDs) > 0 && len(deactivated) != len(uniqueStrings(userIDs)) {
+		return nil, fmt.Errorf("some users are not members of team %s: %w", teamName, domain.ErrUserNotFound)
+	}
+
+	for i := range deactivated {
+		deactivated[i].IsActive = false
+		deactivated[i].UpdatedAt = now()
+		m.data.users[deactivated[i].ID] = deactivated[i]
+	}
+	if deactivated == nil {
+		deactivated = []domain.User{}
+	}
+	return deactivated, nil
+}
+
+// MoveUsersToTeam moves the users to the team. Their open reviews are left to the caller
+func (m *Memory) MoveUsersToTeam(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error) {
+	defer m.write(ctx)()
+
+	if _, ok := m.data.teams[teamName]; !ok {
+		return nil, fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotFound)
+	}
+	ids := uniqueStrings(userIDs)
+	for _, id := range ids {
+		if _, ok := m.data.users[id]; !ok {
+			return nil, fmt.Errorf("some users to move to team %s: %w", teamName, domain.ErrUserNotFound)
+		}
+	}
+
+	moved := make([]domain.User, 0, len(ids))
+	for _, id := range ids {
+		user := m.data.users[id]
+		user.TeamName = teamName
+		user.UpdatedAt = now()
+		m.data.users[id] = user
+		moved = append(moved, user)
+	}
+	return moved, nil
+}
+
+// MoveDeletedUsersToTeam moves deleted users of the team to another team. Their reviews were handed over
+// on deletion, so nothing else changes
+func (m *Memory) MoveDeletedUsersToTeam(ctx context.Context, teamName, newTeamName string) ([]domain.User, error) {
+	defer m.write(ctx)()
+
+	var ids []string
+	for id, user := range m.data.users {
+		if user.TeamName == teamName && user.IsDeleted() {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return []domain.User{}, nil
+	}
+	if _, ok := m.data.teams[newTeamName]; !ok {
+		return nil, fmt.Errorf("team with name %s: %w", newTeamName, domain.ErrTeamNotFound)
+	}
+
+	slices.Sort(ids)
+	moved := make([]domain.User, 0, len(ids))
+	for _, id := range ids {
+		user := m.data.users[id]
+		user.TeamName = newTeamName
+		user.UpdatedAt = now()
+		m.data.users[id] = user
+		moved = append(moved, user)
+	}
+	return moved, nil
+}
+
+// ListUsers returns users matching the filter ordered by ID, filter.Limit of them at most
+func (m *Memory) ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	defer m.read(ctx)()
+
+	prefix := strings.ToLower(filter.UsernamePrefix)
+	users := make([]domain.User, 0)
+	for _, user := range m.data.users {
+		switch {
+		case user.IsDeleted(),
+			filter.TeamName != "" && user.TeamName != filter.TeamName,
+			filter.IsActive != nil && user.IsActive != *filter.IsActive,
+			!strings.HasPrefix(strings.ToLower(user.Username), prefix),
+			filter.AfterID != "" && user.ID <= filter.AfterID:
+			continue
+		}
+		users = append(users, user)
+	}
+	sortUsers(users)
+	if len(users) > filter.Limit {
+		users = users[:filter.Limit]
+	}
+	return users, nil
+}
+
+// SoftDeleteUser marks the user as deleted and inactive. The user stays, so pull requests and reviews keep them
+func (m *Memory) SoftDeleteUser(ctx context.Context, userID string) (domain.User, error) {
+	defer m.write(ctx)()
+
+	user, ok := m.data.users[userID]
+	if !ok || user.IsDeleted() {
+		return domain.User{}, fmt.Errorf("user with ID %s: %w", userID, domain.ErrUserNotFound)
+	}
+	user.IsActive = false
+	user.DeletedAt = now()
+	user.UpdatedAt = user.DeletedAt
+	m.data.users[userID] = user
+	return user, nil
+}
+
+// usersOfTeam returns members of the team sorted by ID, deleted users are not members. Only active members
+// are those who may review right now: active, not away and below their review limit
+func (s *state) usersOfTeam(teamName string, onlyActive bool) []domain.User {
+	users := make([]domain.User, 0)
+	for _, user := range s.users {
+		if user.TeamName == teamName && !user.IsDeleted() {
+			users = append(users, user)
+		}
+	}
+	if onlyActive {
+		users = s.mayReview(users)
+	}
+	sortUsers(users)
+	return users
+}
+
+// mayReview keeps the users who may review right now: active, not away and below their review limit
+func (s *state) mayReview(users []domain.User) []domain.User {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	load := s.countOpenReviews(ids)
+	at := now()
+	return slices.DeleteFunc(users, func(user domain.User) bool {
+		return !user.IsActive || s.isAbsent(user.ID, at) || !user.HasReviewCapacity(load[user.ID])
+	})
+}
+
+func uniqueStrings(values []string) []string {
+	res := slices.Clone(values)
+	slices.Sort(res)
+	return slices.Compact(res)
+}
@@ -0,0 +1,227 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// AddOutboxEvent stores the event in the outbox. Called inside a transaction, it is rolled back with the mutation
+func (m *Memory) AddOutboxEvent(ctx context.Context, event domain.OutboxEvent) (domain.OutboxEvent, error) {
+	defer m.write(ctx)()
+
+	return m.data.addOutboxEvent(event), nil
+}
+
+// AddOutboxEvents stores the events in the outbox in order
+func (m *Memory) AddOutboxEvents(ctx context.Context, events []domain.OutboxEvent) error {
+	defer m.write(ctx)()
+
+	for _, event := range events {
+		m.data.addOutboxEvent(event)
+	}
+	return nil
+}
+
+func (s *state) addOutboxEvent(event domain.OutboxEvent) domain.OutboxEvent {
+	event.ID = int64(len(s.outbox)) + 1
+	event.CreatedAt = now()
+	event.Payload = slices.Clone(event.Payload)
+	s.outbox = append(s.outbox, outboxEntry{event: event, fannedOut: false})
+	return event
+}
+
+func (m *Memory) AddWebhookSubscription(
+	ctx context.Context,
+	subscription domain.WebhookSubscription,
+) (domain.WebhookSubscription, error) {
+	defer m.write(ctx)()
+
+	m.data.lastSubscriptionID++
+	subscription.ID = m.data.lastSubscriptionID
+	subscription.EventTypes = slices.Clone(subscription.EventTypes)
+	subscription.CreatedAt = now()
+	m.data.subscriptions[subscription.ID] = subscription
+	return subscription, nil
+}
+
+func (m *Memory) ListWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	defer m.read(ctx)()
+
+	subscriptions := make([]domain.WebhookSubscription, 0, len(m.data.subscriptions))
+	for _, id := range slices.Sorted(maps.Keys(m.data.subscriptions)) {
+		subscriptions = append(subscriptions, m.data.subscriptions[id])
+	}
+	return subscriptions, nil
+}
+
+// DeleteWebhookSubscription removes the subscription along with its deliveries
+func (m *Memory) DeleteWebhookSubscription(ctx context.Context, id int64) error {
+	defer m.write(ctx)()
+
+	if _, ok := m.data.subscriptions[id]; !ok {
+		return domain.ErrWebhookSubscriptionNotFound
+	}
+	delete(m.data.subscriptions, id)
+	maps.DeleteFunc(m.data.deliveries, func(_ int64, delivery domain.WebhookDelivery) bool {
+		return delivery.SubscriptionID == id
+	})
+	return nil
+}
+
+// FanOutOutboxEvents creates deliveries of up to batchSize new outbox events to the subscribers of their types.
+// Returns the number of events processed
+func (m *Memory) FanOutOutboxEvents(ctx context.Context, batchSize int) (int, error) {
+	defer m.write(ctx)()
+
+	subscriptionIDs := slices.Sorted(maps.Keys(m.data.subscriptions))
+	fannedOut := 0
+	for i := range m.data.outbox {
+		if fannedOut == batchSize {
+			break
+		}
+		entry := &m.data.outbox[i]
+		if entry.fannedOut {
+			continue
+		}
+		for _, id := range subscriptionIDs {
+			if !slices.Contains(m.data.subscriptions[id].EventTypes, entry.event.Type) {
+				continue
+			}
+			m.data.lastDeliveryID++
+			m.data.deliveries[m.data.lastDeliveryID] = domain.WebhookDelivery{
+				ID:             m.data.lastDeliveryID,
+				SubscriptionID: id,
+				EventID:        entry.event.ID,
+				Status:         domain.DeliveryStatusPending,
+				Attempts:       0,
+				NextAttemptAt:  now(),
+				LastError:      "",
+				DeliveredAt:    time.Time{},
+				CreatedAt:      now(),
+			}
+		}
+		entry.fannedOut = true
+		fannedOut++
+	}
+	return fannedOut, nil
+}
+
+// ClaimWebhookDeliveries takes up to batchSize pending deliveries due at now and hides them from other
+// dispatchers until leaseUntil
+func (m *Memory) ClaimWebhookDeliveries(
+	ctx context.Context,
+	now, leaseUntil time.Time,
+	batchSize int,
+) ([]domain.ClaimedDelivery, error) {
+	defer m.write(ctx)()
+
+	due := make([]domain.WebhookDelivery, 0)
+	for _, delivery := range m.data.deliveries {
+		if delivery.Status == domain.DeliveryStatusPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	slices.SortFunc(due, func(a, b domain.WebhookDelivery) int {
+		return cmp.Or(a.NextAttemptAt.Compare(b.NextAttemptAt), cmp.Compare(a.ID, b.ID))
+	})
+	if len(due) > batchSize {
+		due = due[:batchSize]
+	}
+
+	claimed := make([]domain.ClaimedDelivery, 0, len(due))
+	for _, delivery := range due {
+		delivery.NextAttemptAt = leaseUntil
+		m.data.deliveries[delivery.ID] = delivery
+
+		subscription := m.data.subscriptions[delivery.SubscriptionID]
+		claimed = append(claimed, domain.ClaimedDelivery{
+			WebhookDelivery: delivery,
+			URL:             subscription.URL,
+			Secret:          subscription.Secret,
+			Event:           m.data.outbox[delivery.EventID-1].event,
+		})
+	}
+	return claimed, nil
+}
+
+func (m *Memory) CompleteWebhookDelivery(ctx context.Context, id int64) error {
+	defer m.write(ctx)()
+
+	delivery, ok := m.data.deliveries[id]
+	if !ok {
+		return nil
+	}
+	delivery.Status = domain.DeliveryStatusDelivered
+	delivery.Attempts++
+	delivery.LastError = ""
+	delivery.DeliveredAt = now()
+	m.data.deliveries[id] = delivery
+	return nil
+}
+
+// FailWebhookDelivery counts a failed attempt and moves the delivery to status, retried at nextAttemptAt if PENDING
+func (m *Memory) FailWebhookDelivery(
+	ctx context.Context,
+	id int64,
+	status domain.DeliveryStatus,
+	nextAttemptAt time.Time,
+	lastError string,
+) error {
+	defer m.write(ctx)()
+
+	delivery, ok := m.data.deliveries[id]
+	if !ok {
+		return nil
+	}
+	delivery.Status = status
+	delivery.Attempts++
+	delivery.NextAttemptAt = nextAttemptAt
+	delivery.LastError = lastError
+	m.data.deliveries[id] = delivery
+	return nil
+}
+
+// ListWebhookDeliveries returns at most filter.Limit deliveries matching the filter, newest first
+func (m *Memory) ListWebhookDeliveries(
+	ctx context.Context,
+	filter domain.WebhookDeliveryFilter,
+) ([]domain.WebhookDelivery, error) {
+	defer m.read(ctx)()
+
+	deliveries := make([]domain.WebhookDelivery, 0)
+	for _, id := range slices.Backward(slices.Sorted(maps.Keys(m.data.deliveries))) {
+		if len(deliveries) == filter.Limit {
+			break
+		}
+		delivery := m.data.deliveries[id]
+		switch {
+		case filter.AfterID != 0 && delivery.ID >= filter.AfterID,
+			filter.SubscriptionID != 0 && delivery.SubscriptionID != filter.SubscriptionID,
+			filter.Status != "" && delivery.Status != filter.Status:
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+// RetryWebhookDelivery puts a DEAD delivery back to the queue with a fresh attempt counter
+func (m *Memory) RetryWebhookDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	defer m.write(ctx)()
+
+	delivery, ok := m.data.deliveries[id]
+	if !ok || delivery.Status != domain.DeliveryStatusDead {
+		return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+	}
+	delivery.Status = domain.DeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now()
+	delivery.LastError = ""
+	m.data.deliveries[id] = delivery
+	return delivery, nil
+}
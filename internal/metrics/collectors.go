@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectTimeout bounds the storage queries made on each scrape
+const collectTimeout = 5 * time.Second
+
+type iEntityCounter interface {
+	CountTeams(ctx context.Context) (int, error)
+	CountUsers(ctx context.Context) (active, inactive int, err error)
+}
+
+// RegisterEntities exposes the number of teams and users, read from the storage on each scrape
+func (m *Metrics) RegisterEntities(counter iEntityCounter) {
+	m.registry.MustRegister(&entitiesCollector{
+		counter: counter,
+		teams: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "teams"),
+			"Number of teams.",
+			nil, nil,
+		),
+		users: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "users"),
+			"Number of users by activity.",
+			[]string{"active"}, nil,
+		),
+	})
+}
+
+type entitiesCollector struct {
+	counter iEntityCounter
+	teams   *prometheus.Desc
+	users   *prometheus.Desc
+}
+
+func (c *entitiesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.teams
+	ch <- c.users
+}
+
+func (c *entitiesCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	// Ошибку хранилища только логируем, чтобы остальные метрики продолжали отдаваться
+	teams, err := c.counter.CountTeams(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to count teams for metrics", "error", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(c.teams, prometheus.GaugeValue, float64(teams))
+	}
+
+	active, inactive, err := c.counter.CountUsers(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to count users for metrics", "error", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.users, prometheus.GaugeValue, float64(active), "true")
+	ch <- prometheus.MustNewConstMetric(c.users, prometheus.GaugeValue, float64(inactive), "false")
+}
+
+// RegisterPool exposes connection statistics of the PostgreSQL pool
+func (m *Metrics) RegisterPool(pool *pgxpool.Pool) {
+	m.registry.MustRegister(newPoolCollector(pool))
+}
+
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	newConnsCount        *prometheus.Desc
+}
+
+func newPoolCollector(pool *pgxpool.Pool) *poolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "pgxpool", name), help, nil, nil)
+	}
+	return &poolCollector{
+		pool:                 pool,
+		acquiredConns:        desc("acquired_conns", "Number of currently acquired connections."),
+		idleConns:            desc("idle_conns", "Number of currently idle connections."),
+		constructingConns:    desc("constructing_conns", "Number of connections being established."),
+		totalConns:           desc("total_conns", "Total number of connections in the pool."),
+		maxConns:             desc("max_conns", "Maximum size of the pool."),
+		acquireCount:         desc("acquire_total", "Number of successful acquires from the pool."),
+		acquireDuration:      desc("acquire_duration_seconds_total", "Total time spent on successful acquires."),
+		canceledAcquireCount: desc("canceled_acquire_total", "Number of acquires canceled by a context."),
+		emptyAcquireCount:    desc("empty_acquire_total", "Number of acquires that waited for a connection."),
+		newConnsCount:        desc("new_conns_total", "Number of new connections opened."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	gauge := func(desc *prometheus.Desc, value int32) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(value))
+	}
+	counter := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, value)
+	}
+
+	gauge(c.acquiredConns, stat.AcquiredConns())
+	gauge(c.idleConns, stat.IdleConns())
+	gauge(c.constructingConns, stat.ConstructingConns())
+	gauge(c.totalConns, stat.TotalConns())
+	gauge(c.maxConns, stat.MaxConns())
+	counter(c.acquireCount, float64(stat.AcquireCount()))
+	counter(c.acquireDuration, stat.AcquireDuration().Seconds())
+	counter(c.canceledAcquireCount, float64(stat.CanceledAcquireCount()))
+	counter(c.emptyAcquireCount, float64(stat.EmptyAcquireCount()))
+	counter(c.newConnsCount, float64(stat.NewConnsCount()))
+}
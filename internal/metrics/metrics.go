@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_reviewer"
+
+// Metrics holds the Prometheus registry of the service and all its metrics
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	prsCreated    prometheus.Counter
+	prsMerged     prometheus.Counter
+	reassignments prometheus.Counter
+	noCandidate   prometheus.Counter
+}
+
+// New creates metrics registered in a separate registry together with Go runtime and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{ //nolint:exhaustruct
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of handled HTTP requests.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{ //nolint:exhaustruct
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of handled HTTP requests.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		prsCreated: prometheus.NewCounter(prometheus.CounterOpts{ //nolint:exhaustruct
+			Namespace: namespace,
+			Name:      "pull_requests_created_total",
+			Help:      "Number of created pull requests.",
+		}),
+		prsMerged: prometheus.NewCounter(prometheus.CounterOpts{ //nolint:exhaustruct
+			Namespace: namespace,
+			Name:      "pull_requests_merged_total",
+			Help:      "Number of merged pull requests.",
+		}),
+		reassignments: prometheus.NewCounter(prometheus.CounterOpts{ //nolint:exhaustruct
+			Namespace: namespace,
+			Name:      "reviewer_reassignments_total",
+			Help:      "Number of successful reviewer reassignments.",
+		}),
+		noCandidate: prometheus.NewCounter(prometheus.CounterOpts{ //nolint:exhaustruct
+			Namespace: namespace,
+			Name:      "reviewer_reassignments_no_candidate_total",
+			Help:      "Number of times a pull request could not get enough reviewers.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}), //nolint:exhaustruct
+		m.httpRequests,
+		m.httpDuration,
+		m.prsCreated,
+		m.prsMerged,
+		m.reassignments,
+		m.noCandidate,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format
+func (m *Metrics) Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})) //nolint:exhaustruct
+}
+
+func (m *Metrics) PullRequestCreated() {
+	m.prsCreated.Inc()
+}
+
+func (m *Metrics) PullRequestMerged() {
+	m.prsMerged.Inc()
+}
+
+func (m *Metrics) ReviewerReassigned() {
+	m.reassignments.Inc()
+}
+
+func (m *Metrics) NoCandidate() {
+	m.noCandidate.Inc()
+}
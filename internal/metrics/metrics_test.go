@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+// MetricsTestSuite определяет test suite для метрик
+type MetricsTestSuite struct {
+	suite.Suite
+	metrics *Metrics
+	app     *fiber.App
+}
+
+// SetupTest создаёт приложение с middleware метрик и парой маршрутов
+func (s *MetricsTestSuite) SetupTest() {
+	s.metrics = New()
+	s.app = fiber.New()
+	s.app.Use(s.metrics.Middleware())
+	s.app.Get("/users/:id", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	s.app.Get("/fail", func(*fiber.Ctx) error {
+		return fiber.ErrBadRequest
+	})
+	s.app.Get("/metrics", s.metrics.Handler())
+}
+
+func (s *MetricsTestSuite) get(path string) string {
+	resp, err := s.app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+	return string(body)
+}
+
+// TestHTTPMetrics проверяет, что запросы считаются по шаблону маршрута и статусу ответа
+func (s *MetricsTestSuite) TestHTTPMetrics() {
+	s.get("/users/u1")
+	s.get("/users/u2")
+	s.get("/fail")
+
+	s.InDelta(2, testutil.ToFloat64(s.metrics.httpRequests.WithLabelValues("GET", "/users/:id", "200")), 0)
+	s.InDelta(1, testutil.ToFloat64(s.metrics.httpRequests.WithLabelValues("GET", "/fail", "400")), 0)
+	s.Equal(2, testutil.CollectAndCount(s.metrics.httpDuration))
+}
+
+// TestDomainMetrics проверяет доменные счётчики и их отдачу через /metrics
+func (s *MetricsTestSuite) TestDomainMetrics() {
+	s.metrics.PullRequestCreated()
+	s.metrics.PullRequestCreated()
+	s.metrics.PullRequestMerged()
+	s.metrics.ReviewerReassigned()
+	s.metrics.NoCandidate()
+
+	body := s.get("/metrics")
+	s.Contains(body, "pr_reviewer_pull_requests_created_total 2")
+	s.Contains(body, "pr_reviewer_pull_requests_merged_total 1")
+	s.Contains(body, "pr_reviewer_reviewer_reassignments_total 1")
+	s.Contains(body, "pr_reviewer_reviewer_reassignments_no_candidate_total 1")
+}
+
+type stubEntityCounter struct {
+	teams            int
+	active, inactive int
+	err              error
+}
+
+func (c stubEntityCounter) CountTeams(context.Context) (int, error) {
+	return c.teams, c.err
+}
+
+func (c stubEntityCounter) CountUsers(context.Context) (int, int, error) {
+	return c.active, c.inactive, c.err
+}
+
+// TestEntities проверяет gauge команд и пользователей
+func (s *MetricsTestSuite) TestEntities() {
+	s.metrics.RegisterEntities(stubEntityCounter{teams: 3, active: 5, inactive: 2})
+
+	expected := `
+# HELP pr_reviewer_teams Number of teams.
+# TYPE pr_reviewer_teams gauge
+pr_reviewer_teams 3
+# HELP pr_reviewer_users Number of users by activity.
+# TYPE pr_reviewer_users gauge
+pr_reviewer_users{active="false"} 2
+pr_reviewer_users{active="true"} 5
+`
+	err := testutil.GatherAndCompare(
+		s.metrics.registry, strings.NewReader(expected), "pr_reviewer_teams", "pr_reviewer_users",
+	)
+	s.Require().NoError(err)
+}
+
+// TestEntitiesStorageError проверяет, что ошибка хранилища не ломает отдачу остальных метрик
+func (s *MetricsTestSuite) TestEntitiesStorageError() {
+	s.metrics.RegisterEntities(stubEntityCounter{err: errors.New("db is down")})
+	s.metrics.PullRequestCreated()
+
+	body := s.get("/metrics")
+	s.Contains(body, "pr_reviewer_pull_requests_created_total 1")
+	s.NotContains(body, "pr_reviewer_teams")
+}
+
+// TestMetricsTestSuite запускает test suite
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
@@ -0,0 +1,95 @@
+package migrator
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/artmexbet/avito_test_task/migrations"
+)
+
+// MigratorTestSuite определяет test suite для разбора файлов миграций
+type MigratorTestSuite struct {
+	suite.Suite
+}
+
+// TestParse проверяет разбор имён и содержимого файлов миграций
+func (s *MigratorTestSuite) TestParse() {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []Migration
+		wantErr bool
+	}{
+		{
+			name: "sorted by version",
+			fsys: fstest.MapFS{
+				"10_second.up.sql":   {Data: []byte("up 10")},
+				"10_second.down.sql": {Data: []byte("down 10")},
+				"2_first.up.sql":     {Data: []byte("up 2")},
+				"README.md":          {Data: []byte("ignored")},
+			},
+			want: []Migration{
+				{Version: 2, Name: "first", Up: "up 2"},
+				{Version: 10, Name: "second", Up: "up 10", Down: "down 10"},
+			},
+		},
+		{
+			name:    "no direction",
+			fsys:    fstest.MapFS{"01_teams.sql": {Data: []byte("up")}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid version",
+			fsys:    fstest.MapFS{"first_teams.up.sql": {Data: []byte("up")}},
+			wantErr: true,
+		},
+		{
+			name:    "no name",
+			fsys:    fstest.MapFS{"01.up.sql": {Data: []byte("up")}},
+			wantErr: true,
+		},
+		{
+			name:    "only down script",
+			fsys:    fstest.MapFS{"01_teams.down.sql": {Data: []byte("down")}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"01_teams.up.sql": {Data: []byte("up")},
+				"01_users.up.sql": {Data: []byte("up")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			got, err := parse(tt.fsys)
+			if tt.wantErr {
+				s.Error(err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tt.want, got)
+		})
+	}
+}
+
+// TestEmbeddedMigrations проверяет, что вшитые миграции корректны и у каждой есть откат
+func (s *MigratorTestSuite) TestEmbeddedMigrations() {
+	got, err := parse(migrations.FS)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(got)
+	for i, migration := range got {
+		s.Equal(int64(i+1), migration.Version)
+		s.NotEmpty(migration.Down, "migration %d_%s has no down script", migration.Version, migration.Name)
+	}
+}
+
+// TestMigratorSuite запускает test suite
+func TestMigratorSuite(t *testing.T) {
+	suite.Run(t, new(MigratorTestSuite))
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/internal/postgres/queries"
+)
+
+func (p *Postgres) AddAbsence(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	created, err := p.q(ctx).CreateAbsence(ctx, queries.CreateAbsenceParams{
+		UserID:   absence.UserID,
+		StartsAt: absence.StartsAt,
+		EndsAt:   absence.EndsAt,
+		Reason:   absence.Reason,
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == sqlStateForeignKeyViolation {
+		return domain.Absence{}, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return domain.Absence{}, fmt.Errorf("error adding absence of user %s: %w", absence.UserID, err)
+	}
+	return created.ToDomain(), nil
+}
+
+func (p *Postgres) GetAbsence(ctx context.Context, id int64) (domain.Absence, error) {
+	absence, err := p.q(ctx).GetAbsence(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Absence{}, domain.ErrAbsenceNotFound
+	}
+	if err != nil {
+		return domain.Absence{}, fmt.Errorf("error getting absence %d: %w", id, err)
+	}
+	return absence.ToDomain(), nil
+}
+
+// UpdateAbsence changes the period and the reason of the absence. Its reviews are handed over anew
+// if the changed period is going on
+func (p *Postgres) UpdateAbsence(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	updated, err := p.q(ctx).UpdateAbsence(ctx, queries.UpdateAbsenceParams{
+		ID:       absence.ID,
+		StartsAt: absence.StartsAt,
+		EndsAt:   absence.EndsAt,
+		Reason:   absence.Reason,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Absence{}, domain.ErrAbsenceNotFound
+	}
+	if err != nil {
+		return domain.Absence{}, fmt.Errorf("error updating absence %d: %w", absence.ID, err)
+	}
+	return updated.ToDomain(), nil
+}
+
+func (p *Postgres) DeleteAbsence(ctx context.Context, id int64) error {
+	deleted, err := p.q(ctx).DeleteAbsence(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error deleting absence %d: %w", id, err)
+	}
+	if deleted == 0 {
+		return domain.ErrAbsenceNotFound
+	}
+	return nil
+}
+
+// ListAbsences returns the absences matching the filter ordered by start
+func (p *Postgres) ListAbsences(ctx context.Context, filter domain.AbsenceFilter) ([]domain.Absence, error) {
+	absences, err := p.q(ctx).ListAbsences(ctx, queries.ListAbsencesParams{
+		UserID:    optionalString(filter.UserID),
+		TeamName:  optionalString(filter.TeamName),
+		EndsAfter: optionalTime(filter.EndsAfter),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing absences: %w", err)
+	}
+	result := make([]domain.Absence, len(absences))
+	for i, absence := range absences {
+		result[i] = absence.ToDomain()
+	}
+	return result, nil
+}
+
+// ClaimStartedAbsences marks up to batchSize absences going on at now, whose reviews were not handed over yet,
+// as handed over at now and returns them. Concurrent callers get different absences
+func (p *Postgres) ClaimStartedAbsences(ctx context.Context, now time.Time, batchSize int) ([]domain.Absence, error) {
+	claimed, err := p.q(ctx).ClaimStartedAbsences(ctx, queries.ClaimStartedAbsencesParams{
+		Now:       now,
+		BatchSize: int32(batchSize), //nolint:gosec // Размер пачки задаётся конфигом
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error claiming started absences: %w", err)
+	}
+	result := make([]domain.Absence, len(claimed))
+	for i, absence := range claimed {
+		result[i] = absence.ToDomain()
+	}
+	return result, nil
+}
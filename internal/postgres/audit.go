@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/internal/postgres/queries"
+)
+
+// AddAuditEvent appends the event to the audit log. Called inside a transaction, it is committed with the mutation
+func (p *Postgres) AddAuditEvent(ctx context.Context, event domain.AuditEvent) (domain.AuditEvent, error) {
+	added, err := p.q(ctx).AddAuditEvent(ctx, queries.AddAuditEventParams{
+		Action:     string(event.Action),
+		EntityType: string(event.EntityType),
+		EntityID:   event.EntityID,
+		Actor:      event.Actor,
+		RequestID:  event.RequestID,
+		Before:     event.Before,
+		After:      event.After,
+	})
+	if err != nil {
+		return domain.AuditEvent{}, fmt.Errorf("error adding audit event: %w", err)
+	}
+	return added.ToDomain(), nil
+}
+
+// AddAuditEvents appends the events to the audit log with one round trip
+func (p *Postgres) AddAuditEvents(ctx context.Context, events []domain.AuditEvent) error {
+	params := make([]queries.AddAuditEventsParams, len(events))
+	for i, event := range events {
+		params[i] = queries.AddAuditEventsParams{
+			Action:     string(event.Action),
+			EntityType: string(event.EntityType),
+			EntityID:   event.EntityID,
+			Actor:      event.Actor,
+			RequestID:  event.RequestID,
+			Before:     event.Before,
+			After:      event.After,
+		}
+	}
+	br := p.q(ctx).AddAuditEvents(ctx, params)
+	defer br.Close() //nolint:errcheck
+
+	errs := make([]error, 0, len(events))
+	br.Exec(func(_ int, err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("error adding audit events: %w", err)
+	}
+	return nil
+}
+
+func (p *Postgres) ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	params := queries.ListAuditEventsParams{
+		Action:      optionalString(string(filter.Action)),
+		EntityType:  optionalString(string(filter.EntityType)),
+		EntityID:    optionalString(filter.EntityID),
+		Actor:       optionalString(filter.Actor),
+		RequestID:   optionalString(filter.RequestID),
+		CreatedFrom: optionalTime(filter.From),
+		CreatedTo:   optionalTime(filter.To),
+		PageSize:    int32(filter.Limit), //nolint:gosec // Размер страницы ограничен сервисом
+	}
+	if filter.AfterID != 0 {
+		params.AfterID = &filter.AfterID
+	}
+
+	events, err := p.q(ctx).ListAuditEvents(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error listing audit events: %w", err)
+	}
+	result := make([]domain.AuditEvent, len(events))
+	for i, event := range events {
+		result[i] = event.ToDomain()
+	}
+	return result, nil
+}
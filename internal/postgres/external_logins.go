@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/internal/postgres/queries"
+)
+
+// sqlStateForeignKeyViolation is returned when the mapped user does not exist
+const sqlStateForeignKeyViolation = "23503"
+
+// SetExternalLogin maps the login of the provider onto the user, replacing the previous mapping of the login
+func (p *Postgres) SetExternalLogin(ctx context.Context, login domain.ExternalLogin) (domain.ExternalLogin, error) {
+	stored, err := p.q(ctx).UpsertExternalLogin(ctx, queries.UpsertExternalLoginParams{
+		Provider: string(login.Provider),
+		Login:    login.Login,
+		UserID:   login.UserID,
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == sqlStateForeignKeyViolation {
+		return domain.ExternalLogin{}, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return domain.ExternalLogin{}, fmt.Errorf("error setting external login %s/%s: %w",
+			login.Provider, login.Login, err)
+	}
+	return stored.ToDomain(), nil
+}
+
+func (p *Postgres) GetExternalLogin(
+	ctx context.Context,
+	provider domain.ExternalProvider,
+	login string,
+) (domain.ExternalLogin, error) {
+	stored, err := p.q(ctx).GetExternalLogin(ctx, queries.GetExternalLoginParams{
+		Provider: string(provider),
+		Login:    login,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ExternalLogin{}, domain.ErrExternalLoginNotFound
+	}
+	if err != nil {
+		return domain.ExternalLogin{}, fmt.Errorf("error getting external login %s/%s: %w", provider, login, err)
+	}
+	return stored.ToDomain(), nil
+}
+
+// ListExternalLogins returns the mappings of the provider, or of all providers if it is empty
+func (p *Postgres) ListExternalLogins(
+	ctx context.Context,
+	provider domain.ExternalProvider,
+) ([]domain.ExternalLogin, error) {
+	logins, err := p.q(ctx).ListExternalLogins(ctx, optionalString(string(provider)))
+	if err != nil {
+		return nil, fmt.Errorf("error listing external logins: %w", err)
+	}
+	result := make([]domain.ExternalLogin, len(logins))
+	for i, login := range logins {
+		result[i] = login.ToDomain()
+	}
+	return result, nil
+}
+
+func (p *Postgres) DeleteExternalLogin(ctx context.Context, provider domain.ExternalProvider, login string) error {
+	deleted, err := p.q(ctx).DeleteExternalLogin(ctx, queries.DeleteExternalLoginParams{
+		Provider: string(provider),
+		Login:    login,
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting external login %s/%s: %w", provider, login, err)
+	}
+	if deleted == 0 {
+		return domain.ErrExternalLoginNotFound
+	}
+	return nil
+}
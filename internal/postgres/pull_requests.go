@@ -12,7 +12,7 @@ import (
 )
 
 func (p *Postgres) CreatePullRequest(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
-	tx, err := p.pool.Begin(ctx)
+	tx, err := p.begin(ctx)
 	if err != nil {
 		return domain.PullRequest{}, fmt.Errorf("error starting transaction: %w", err)
 	}
@@ -20,10 +20,17 @@ func (p *Postgres) CreatePullRequest(ctx context.Context, pr domain.PullRequest)
 
 	q := p.queries.WithTx(tx)
 
+	status := pr.Status
+	if status == "" {
+		status = domain.PRStatusOpen
+	}
 	createdPR, err := q.CreatePullRequest(ctx, queries.CreatePullRequestParams{
 		ID:       pr.ID,
 		Name:     pr.Name,
 		AuthorID: pr.AuthorID,
+		Status:   string(status),
+
+		NeedMoreReviewers: pr.NeedMoreReviewers,
 	})
 	if err != nil {
 		return domain.PullRequest{}, fmt.Errorf("error creating pull request: %w", err)
@@ -36,7 +43,7 @@ func (p *Postgres) CreatePullRequest(ctx context.Context, pr domain.PullRequest)
 }
 
 func (p *Postgres) GetPullRequestByID(ctx context.Context, prID string) (domain.PullRequest, error) {
-	pr, err := p.queries.GetPullRequestByID(ctx, prID)
+	pr, err := p.q(ctx).GetPullRequestByID(ctx, prID)
 	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		return domain.PullRequest{}, fmt.Errorf("error getting pull request by ID: %w", err)
 	} else if errors.Is(err, pgx.ErrNoRows) {
@@ -46,8 +53,25 @@ func (p *Postgres) GetPullRequestByID(ctx context.Context, prID string) (domain.
 	return pr.ToDomain(), nil
 }
 
+// LockPullRequest gets a pull request by ID and locks its row until the end of the transaction from ctx,
+// so that concurrent changes of the pull request are serialized.
+func (p *Postgres) LockPullRequest(ctx context.Context, prID string) (domain.PullRequest, error) {
+	if _, ok := txFromContext(ctx); !ok {
+		return domain.PullRequest{}, errors.New("locking pull request requires a transaction")
+	}
+
+	pr, err := p.q(ctx).LockPullRequestByID(ctx, prID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.PullRequest{}, domain.ErrPRNotFound
+	} else if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error locking pull request: %w", err)
+	}
+
+	return pr.ToDomain(), nil
+}
+
 func (p *Postgres) MergePullRequest(ctx context.Context, prID string) (domain.PullRequest, error) {
-	tx, err := p.pool.Begin(ctx)
+	tx, err := p.begin(ctx)
 	if err != nil {
 		return domain.PullRequest{}, fmt.Errorf("error starting transaction: %w", err)
 	}
@@ -55,10 +79,69 @@ func (p *Postgres) MergePullRequest(ctx context.Context, prID string) (domain.Pu
 
 	q := p.queries.WithTx(tx)
 
+	// Блокируем PR, чтобы открытые ревью закрылись в статистике ровно один раз
+	before, err := q.LockPullRequestByID(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error merging pull request: %w", err)
+	}
+
 	pr, err := q.MergePullRequest(ctx, prID)
 	if err != nil {
 		return domain.PullRequest{}, fmt.Errorf("error merging pull request: %w", err)
 	}
+	if before.Status == string(domain.PRStatusOpen) {
+		if err := q.CloseReviewerStatsForPullRequest(ctx, prID); err != nil {
+			return domain.PullRequest{}, fmt.Errorf("error updating reviewer stats: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return pr.ToDomain(), nil
+}
+
+// SetPullRequestStatus moves a pull request to the status. The transition is expected to be checked by the caller.
+// Reviewers of a pull request that stops being open are released, reopening brings them back.
+func (p *Postgres) SetPullRequestStatus(
+	ctx context.Context,
+	prID string,
+	status domain.PRStatus,
+) (domain.PullRequest, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := p.queries.WithTx(tx)
+
+	// Блокируем PR, чтобы открытые ревью в статистике менялись ровно один раз
+	before, err := q.LockPullRequestByID(ctx, prID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.PullRequest{}, domain.ErrPRNotFound
+	} else if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error locking pull request: %w", err)
+	}
+
+	pr, err := q.UpdatePullRequestStatus(ctx, queries.UpdatePullRequestStatusParams{
+		Status: string(status),
+		ID:     prID,
+	})
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error updating pull request status: %w", err)
+	}
+
+	wasOpen, isOpen := before.Status == string(domain.PRStatusOpen), status == domain.PRStatusOpen
+	switch {
+	case wasOpen && !isOpen:
+		err = q.CloseReviewerStatsForPullRequest(ctx, prID)
+	case !wasOpen && isOpen:
+		err = q.ReopenReviewerStatsForPullRequest(ctx, prID)
+	}
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error updating reviewer stats: %w", err)
+	}
 	if err := tx.Commit(ctx); err != nil {
 		return domain.PullRequest{}, fmt.Errorf("error committing transaction: %w", err)
 	}
@@ -67,9 +150,127 @@ func (p *Postgres) MergePullRequest(ctx context.Context, prID string) (domain.Pu
 }
 
 func (p *Postgres) ExistsPullRequest(ctx context.Context, prID string) (bool, error) {
-	exists, err := p.queries.ExistsPullRequestByID(ctx, prID)
+	exists, err := p.q(ctx).ExistsPullRequestByID(ctx, prID)
 	if err != nil {
 		return false, fmt.Errorf("error checking if pull request exists: %w", err)
 	}
 	return exists, nil
 }
+
+func (p *Postgres) SetPullRequestNeedMoreReviewers(ctx context.Context, prID string, needMore bool) error {
+	err := p.q(ctx).SetPullRequestNeedMoreReviewers(ctx, queries.SetPullRequestNeedMoreReviewersParams{
+		ID:                prID,
+		NeedMoreReviewers: needMore,
+	})
+	if err != nil {
+		return fmt.Errorf("error updating need more reviewers flag: %w", err)
+	}
+	return nil
+}
+
+// GetOpenPullRequestsNeedingReviewers returns open flagged pull requests authored by members of the team
+// along with their reviewers. The rows stay locked until the end of the transaction from ctx.
+func (p *Postgres) GetOpenPullRequestsNeedingReviewers(ctx context.Context, teamName string) ([]domain.PullRequest, error) {
+	q := p.q(ctx)
+	prs, err := q.GetOpenPullRequestsNeedingReviewersByTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pull requests needing reviewers: %w", err)
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return withReviewers(ctx, q, prs)
+}
+
+// GetOpenPullRequestsByReviewerIDs returns open pull requests any of the reviewers is assigned to, ordered by ID,
+// along with all their reviewers. With authorTeam set only pull requests authored by its members are returned.
+// The rows stay locked until the end of the transaction from ctx.
+func (p *Postgres) GetOpenPullRequestsByReviewerIDs(
+	ctx context.Context,
+	reviewerIDs []string,
+	authorTeam string,
+) ([]domain.PullRequest, error) {
+	q := p.q(ctx)
+	prs, err := q.GetOpenPullRequestsByReviewerIDs(ctx, queries.GetOpenPullRequestsByReviewerIDsParams{
+		ReviewerIds:    reviewerIDs,
+		AuthorTeamName: optionalString(authorTeam),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting open pull requests of reviewers: %w", err)
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return withReviewers(ctx, q, prs)
+}
+
+// ListPullRequests returns a page of pull requests matching the filter along with their reviewers,
+// at most filter.Limit of them
+func (p *Postgres) ListPullRequests(ctx context.Context, filter domain.PullRequestFilter) ([]domain.PullRequest, error) {
+	params := queries.ListPullRequestsParams{
+		Status:      optionalString(string(filter.Status)),
+		AuthorID:    optionalString(filter.AuthorID),
+		TeamName:    optionalString(filter.TeamName),
+		ReviewerID:  optionalString(filter.ReviewerID),
+		CreatedFrom: optionalTime(filter.CreatedFrom),
+		CreatedTo:   optionalTime(filter.CreatedTo),
+		MergedFrom:  optionalTime(filter.MergedFrom),
+		MergedTo:    optionalTime(filter.MergedTo),
+		PageSize:    int32(filter.Limit), //nolint:gosec // Размер страницы ограничен сервисом
+	}
+	if filter.After != nil {
+		params.AfterCreatedAt = &filter.After.CreatedAt
+		params.AfterID = &filter.After.ID
+	}
+
+	q := p.q(ctx)
+	prs, err := q.ListPullRequests(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pull requests: %w", err)
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return withReviewers(ctx, q, prs)
+}
+
+// withReviewers converts the pull requests to the domain ones with their reviewers and pools filled in
+func withReviewers(ctx context.Context, q *queries.Queries, prs []queries.PullRequest) ([]domain.PullRequest, error) {
+	prIDs := make([]string, len(prs))
+	for i, pr := range prs {
+		prIDs[i] = pr.ID
+	}
+	// Ревьюверов всех PR забираем одним запросом
+	rows, err := q.GetReviewerUsersByPullRequestIDs(ctx, prIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error getting reviewers of pull requests: %w", err)
+	}
+	reviewers := make(map[string][]domain.User, len(prs))
+	pools := make(map[string]map[string]domain.ReviewerPool, len(prs))
+	for _, r := range rows {
+		user := queries.User{
+			ID:             r.ID,
+			Username:       r.Username,
+			TeamName:       r.TeamName,
+			IsActive:       r.IsActive,
+			CreatedAt:      r.CreatedAt,
+			UpdatedAt:      r.UpdatedAt,
+			MaxOpenReviews: r.MaxOpenReviews,
+			DeletedAt:      r.DeletedAt,
+		}
+		reviewers[r.PullRequestID] = append(reviewers[r.PullRequestID], user.ToDomain())
+		if pools[r.PullRequestID] == nil {
+			pools[r.PullRequestID] = make(map[string]domain.ReviewerPool)
+		}
+		pools[r.PullRequestID][r.ID] = domain.ReviewerPool{Kind: domain.ReviewerPoolKind(r.PoolKind), Name: r.PoolName}
+	}
+
+	pullRequests := make([]domain.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		pullRequest := pr.ToDomain()
+		pullRequest.Reviewers = reviewers[pr.ID]
+		pullRequest.ReviewerPools = pools[pr.ID]
+		pullRequests = append(pullRequests, pullRequest)
+	}
+	return pullRequests, nil
+}
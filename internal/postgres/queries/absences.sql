@@ -0,0 +1,50 @@
+-- name: CreateAbsence :one
+INSERT INTO user_absences (user_id, starts_at, ends_at, reason)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetAbsence :one
+SELECT *
+FROM user_absences
+WHERE id = $1;
+
+-- name: UpdateAbsence :one
+-- Изменённый период заново проверяется фоновой задачей, поэтому отметка о передаче ревью сбрасывается
+UPDATE user_absences
+SET starts_at     = $2,
+    ends_at       = $3,
+    reason        = $4,
+    reassigned_at = NULL,
+    updated_at    = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteAbsence :execrows
+DELETE FROM user_absences
+WHERE id = $1;
+
+-- name: ListAbsences :many
+SELECT a.*
+FROM user_absences a
+JOIN users u ON u.id = a.user_id
+WHERE (sqlc.narg(user_id)::VARCHAR IS NULL OR a.user_id = sqlc.narg(user_id))
+  AND (sqlc.narg(team_name)::VARCHAR IS NULL OR u.team_name = sqlc.narg(team_name))
+  AND (sqlc.narg(ends_after)::TIMESTAMP IS NULL OR a.ends_at > sqlc.narg(ends_after))
+ORDER BY a.starts_at, a.id;
+
+-- name: ClaimStartedAbsences :many
+-- Забирает идущие периоды, по которым ревью ещё не передавались, и помечает их.
+-- SKIP LOCKED позволяет нескольким экземплярам сервиса запускать задачу параллельно
+WITH started AS (
+    SELECT id
+    FROM user_absences
+    WHERE reassigned_at IS NULL AND starts_at <= @now AND ends_at > @now
+    ORDER BY starts_at, id
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE user_absences a
+SET reassigned_at = @now
+FROM started
+WHERE a.id = started.id
+RETURNING a.*;
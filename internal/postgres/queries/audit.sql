@@ -0,0 +1,23 @@
+-- name: AddAuditEvent :one
+INSERT INTO audit_events (action, entity_type, entity_id, actor, request_id, before, after)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: AddAuditEvents :batchexec
+INSERT INTO audit_events (action, entity_type, entity_id, actor, request_id, before, after)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListAuditEvents :many
+-- События от новых к старым, страница начинается строго после события с ID курсора
+SELECT *
+FROM audit_events
+WHERE (sqlc.narg(action)::VARCHAR IS NULL OR action = sqlc.narg(action))
+  AND (sqlc.narg(entity_type)::VARCHAR IS NULL OR entity_type = sqlc.narg(entity_type))
+  AND (sqlc.narg(entity_id)::VARCHAR IS NULL OR entity_id = sqlc.narg(entity_id))
+  AND (sqlc.narg(actor)::VARCHAR IS NULL OR actor = sqlc.narg(actor))
+  AND (sqlc.narg(request_id)::VARCHAR IS NULL OR request_id = sqlc.narg(request_id))
+  AND (sqlc.narg(created_from)::TIMESTAMP IS NULL OR created_at >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::TIMESTAMP IS NULL OR created_at < sqlc.narg(created_to))
+  AND (sqlc.narg(after_id)::BIGINT IS NULL OR id < sqlc.narg(after_id))
+ORDER BY id DESC
+LIMIT @page_size;
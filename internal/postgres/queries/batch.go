@@ -16,14 +16,120 @@ var (
 	ErrBatchAlreadyClosed = errors.New("batch already closed")
 )
 
+const addAuditEvents = `-- name: AddAuditEvents :batchexec
+INSERT INTO audit_events (action, entity_type, entity_id, actor, request_id, before, after)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type AddAuditEventsBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type AddAuditEventsParams struct {
+	Action     string
+	EntityType string
+	EntityID   string
+	Actor      string
+	RequestID  string
+	Before     []byte
+	After      []byte
+}
+
+func (q *Queries) AddAuditEvents(ctx context.Context, arg []AddAuditEventsParams) *AddAuditEventsBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.Action,
+			a.EntityType,
+			a.EntityID,
+			a.Actor,
+			a.RequestID,
+			a.Before,
+			a.After,
+		}
+		batch.Queue(addAuditEvents, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &AddAuditEventsBatchResults{br, len(arg), false}
+}
+
+func (b *AddAuditEventsBatchResults) Exec(f func(int, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		if b.closed {
+			if f != nil {
+				f(t, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		_, err := b.br.Exec()
+		if f != nil {
+			f(t, err)
+		}
+	}
+}
+
+func (b *AddAuditEventsBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
+const addOutboxEvents = `-- name: AddOutboxEvents :batchexec
+INSERT INTO outbox_events (event_type, payload)
+VALUES ($1, $2)
+`
+
+type AddOutboxEventsBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type AddOutboxEventsParams struct {
+	EventType string
+	Payload   []byte
+}
+
+func (q *Queries) AddOutboxEvents(ctx context.Context, arg []AddOutboxEventsParams) *AddOutboxEventsBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.EventType,
+			a.Payload,
+		}
+		batch.Queue(addOutboxEvents, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &AddOutboxEventsBatchResults{br, len(arg), false}
+}
+
+func (b *AddOutboxEventsBatchResults) Exec(f func(int, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		if b.closed {
+			if f != nil {
+				f(t, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		_, err := b.br.Exec()
+		if f != nil {
+			f(t, err)
+		}
+	}
+}
+
+func (b *AddOutboxEventsBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
 const addUsers = `-- name: AddUsers :batchone
-INSERT INTO users (id, username, team_name, is_active)
-VALUES ($1, $2, $3, $4)
-ON CONFLICT (id) DO UPDATE SET username   = EXCLUDED.username,
-                               team_name  = EXCLUDED.team_name,
-                               is_active  = EXCLUDED.is_active,
-                               updated_at = CURRENT_TIMESTAMP
-RETURNING id, username, team_name, is_active, created_at, updated_at
+INSERT INTO users (id, username, team_name, is_active, max_open_reviews)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
 `
 
 type AddUsersBatchResults struct {
@@ -33,10 +139,11 @@ type AddUsersBatchResults struct {
 }
 
 type AddUsersParams struct {
-	ID       string
-	Username string
-	TeamName string
-	IsActive bool
+	ID             string
+	Username       string
+	TeamName       string
+	IsActive       bool
+	MaxOpenReviews *int32
 }
 
 func (q *Queries) AddUsers(ctx context.Context, arg []AddUsersParams) *AddUsersBatchResults {
@@ -47,6 +154,7 @@ func (q *Queries) AddUsers(ctx context.Context, arg []AddUsersParams) *AddUsersB
 			a.Username,
 			a.TeamName,
 			a.IsActive,
+			a.MaxOpenReviews,
 		}
 		batch.Queue(addUsers, vals...)
 	}
@@ -72,6 +180,8 @@ func (b *AddUsersBatchResults) QueryRow(f func(int, User, error)) {
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MaxOpenReviews,
+			&i.DeletedAt,
 		)
 		if f != nil {
 			f(t, i, err)
@@ -85,10 +195,10 @@ func (b *AddUsersBatchResults) Close() error {
 }
 
 const assignReviewerToPullRequest = `-- name: AssignReviewerToPullRequest :batchone
-INSERT INTO pull_requests_reviewers (pull_request_id, reviewer_id)
-VALUES ($1, $2)
+INSERT INTO pull_requests_reviewers (pull_request_id, reviewer_id, pool_kind, pool_name)
+VALUES ($1, $2, $3, $4)
 ON CONFLICT (pull_request_id, reviewer_id) DO NOTHING
-RETURNING pull_request_id, reviewer_id, assigned_at
+RETURNING pull_request_id, reviewer_id, assigned_at, pool_kind, pool_name
 `
 
 type AssignReviewerToPullRequestBatchResults struct {
@@ -100,6 +210,8 @@ type AssignReviewerToPullRequestBatchResults struct {
 type AssignReviewerToPullRequestParams struct {
 	PullRequestID string
 	ReviewerID    string
+	PoolKind      string
+	PoolName      string
 }
 
 func (q *Queries) AssignReviewerToPullRequest(ctx context.Context, arg []AssignReviewerToPullRequestParams) *AssignReviewerToPullRequestBatchResults {
@@ -108,6 +220,8 @@ func (q *Queries) AssignReviewerToPullRequest(ctx context.Context, arg []AssignR
 		vals := []interface{}{
 			a.PullRequestID,
 			a.ReviewerID,
+			a.PoolKind,
+			a.PoolName,
 		}
 		batch.Queue(assignReviewerToPullRequest, vals...)
 	}
@@ -126,7 +240,13 @@ func (b *AssignReviewerToPullRequestBatchResults) QueryRow(f func(int, PullReque
 			continue
 		}
 		row := b.br.QueryRow()
-		err := row.Scan(&i.PullRequestID, &i.ReviewerID, &i.AssignedAt)
+		err := row.Scan(
+			&i.PullRequestID,
+			&i.ReviewerID,
+			&i.AssignedAt,
+			&i.PoolKind,
+			&i.PoolName,
+		)
 		if f != nil {
 			f(t, i, err)
 		}
@@ -137,50 +257,3 @@ func (b *AssignReviewerToPullRequestBatchResults) Close() error {
 	b.closed = true
 	return b.br.Close()
 }
-
-const batchExistsUserByID = `-- name: BatchExistsUserByID :batchone
-SELECT EXISTS (SELECT 1
-               FROM users
-               WHERE id = $1) AS "exists"
-`
-
-type BatchExistsUserByIDBatchResults struct {
-	br     pgx.BatchResults
-	tot    int
-	closed bool
-}
-
-func (q *Queries) BatchExistsUserByID(ctx context.Context, id []string) *BatchExistsUserByIDBatchResults {
-	batch := &pgx.Batch{}
-	for _, a := range id {
-		vals := []interface{}{
-			a,
-		}
-		batch.Queue(batchExistsUserByID, vals...)
-	}
-	br := q.db.SendBatch(ctx, batch)
-	return &BatchExistsUserByIDBatchResults{br, len(id), false}
-}
-
-func (b *BatchExistsUserByIDBatchResults) QueryRow(f func(int, bool, error)) {
-	defer b.br.Close()
-	for t := 0; t < b.tot; t++ {
-		var exists bool
-		if b.closed {
-			if f != nil {
-				f(t, exists, ErrBatchAlreadyClosed)
-			}
-			continue
-		}
-		row := b.br.QueryRow()
-		err := row.Scan(&exists)
-		if f != nil {
-			f(t, exists, err)
-		}
-	}
-}
-
-func (b *BatchExistsUserByIDBatchResults) Close() error {
-	b.closed = true
-	return b.br.Close()
-}
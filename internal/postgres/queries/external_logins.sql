@@ -0,0 +1,20 @@
+-- name: UpsertExternalLogin :one
+INSERT INTO external_logins (provider, login, user_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (provider, login) DO UPDATE SET user_id = EXCLUDED.user_id
+RETURNING *;
+
+-- name: GetExternalLogin :one
+SELECT *
+FROM external_logins
+WHERE provider = $1 AND login = $2;
+
+-- name: ListExternalLogins :many
+SELECT *
+FROM external_logins
+WHERE (sqlc.narg(provider)::VARCHAR IS NULL OR provider = sqlc.narg(provider))
+ORDER BY provider, login;
+
+-- name: DeleteExternalLogin :execrows
+DELETE FROM external_logins
+WHERE provider = $1 AND login = $2;
@@ -8,18 +8,82 @@ import (
 	"time"
 )
 
-type PullRequest struct {
-	ID        string
-	Name      string
-	AuthorID  string
+type AuditEvent struct {
+	ID         int64
+	Action     string
+	EntityType string
+	EntityID   string
+	Actor      string
+	RequestID  string
+	Before     []byte
+	After      []byte
+	CreatedAt  time.Time
+}
+
+type ExternalLogin struct {
+	Provider  string
+	Login     string
+	UserID    string
 	CreatedAt time.Time
-	MergedAt  *time.Time
+}
+
+type OutboxEvent struct {
+	ID          int64
+	EventType   string
+	Payload     []byte
+	CreatedAt   time.Time
+	FannedOutAt *time.Time
+}
+
+type PullRequest struct {
+	ID                string
+	Name              string
+	AuthorID          string
+	CreatedAt         time.Time
+	MergedAt          *time.Time
+	NeedMoreReviewers bool
+	Status            string
+	ClosedAt          *time.Time
+}
+
+type PullRequestReview struct {
+	ID            int64
+	PullRequestID string
+	ReviewerID    string
+	Verdict       string
+	SubmittedAt   time.Time
 }
 
 type PullRequestsReviewer struct {
 	PullRequestID string
 	ReviewerID    string
 	AssignedAt    time.Time
+	PoolKind      string
+	PoolName      string
+}
+
+type ReviewerCursor struct {
+	PoolKind       string
+	PoolName       string
+	LastReviewerID string
+	UpdatedAt      time.Time
+}
+
+type ReviewerPool struct {
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ReviewerPoolMember struct {
+	PoolName string
+	UserID   string
+}
+
+type ReviewerStat struct {
+	ReviewerID      string
+	AssignedReviews int64
+	OpenReviews     int64
 }
 
 type Team struct {
@@ -28,11 +92,58 @@ type Team struct {
 	UpdatedAt *time.Time
 }
 
+type TeamFallbackPool struct {
+	TeamName         string
+	Position         int32
+	FallbackTeamName *string
+	PoolName         *string
+}
+
+type TeamStat struct {
+	TeamName        string
+	ActiveMembers   int64
+	InactiveMembers int64
+	AssignedReviews int64
+}
+
 type User struct {
-	ID        string
-	Username  string
-	TeamName  string
-	IsActive  bool
-	CreatedAt time.Time
-	UpdatedAt *time.Time
+	ID             string
+	Username       string
+	TeamName       string
+	IsActive       bool
+	CreatedAt      time.Time
+	UpdatedAt      *time.Time
+	MaxOpenReviews *int32
+	DeletedAt      *time.Time
+}
+
+type UserAbsence struct {
+	ID           int64
+	UserID       string
+	StartsAt     time.Time
+	EndsAt       time.Time
+	Reason       string
+	ReassignedAt *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventID        int64
+	Status         string
+	Attempts       int32
+	NextAttemptAt  time.Time
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+type WebhookSubscription struct {
+	ID         int64
+	Url        string
+	Secret     string
+	EventTypes []string
+	CreatedAt  time.Time
 }
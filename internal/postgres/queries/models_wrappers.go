@@ -12,34 +12,54 @@ func (m *User) ToDomain() domain.User {
 	if m.UpdatedAt != nil {
 		updatedAt = *m.UpdatedAt
 	}
+	var maxOpenReviews int
+	if m.MaxOpenReviews != nil {
+		maxOpenReviews = int(*m.MaxOpenReviews)
+	}
+	var deletedAt time.Time
+	if m.DeletedAt != nil {
+		deletedAt = *m.DeletedAt
+	}
 	return domain.User{
-		ID:        m.ID,
-		Username:  m.Username,
-		TeamName:  m.TeamName,
-		IsActive:  m.IsActive,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: updatedAt,
+		ID:             m.ID,
+		Username:       m.Username,
+		TeamName:       m.TeamName,
+		IsActive:       m.IsActive,
+		MaxOpenReviews: maxOpenReviews,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      updatedAt,
+		DeletedAt:      deletedAt,
 	}
 }
 
+// MaxOpenReviewsParam converts the review limit of the domain, where 0 means no limit, to the nullable column
+func MaxOpenReviewsParam(maxOpenReviews int) *int32 {
+	if maxOpenReviews == 0 {
+		return nil
+	}
+	limit := int32(maxOpenReviews) //nolint:gosec // Лимит проверен сервисом
+	return &limit
+}
+
 // ToDomain converts the PullRequest model to the domain PullRequest model.
 func (m *PullRequest) ToDomain() domain.PullRequest {
-	var status domain.PRStatus
-	var mergedAt time.Time
-	// Вынес отдельным енамом на случай, если в будущем появятся другие статусы
-	if m.MergedAt == nil {
-		status = domain.PRStatusOpen
-	} else {
-		status = domain.PRStatusMerged
+	var mergedAt, closedAt time.Time
+	if m.MergedAt != nil {
 		mergedAt = *m.MergedAt
 	}
+	if m.ClosedAt != nil {
+		closedAt = *m.ClosedAt
+	}
 	return domain.PullRequest{ //nolint:exhaustruct // Не все доменные поля можно заполнить отсюда
 		ID:        m.ID,
 		Name:      m.Name,
 		AuthorID:  m.AuthorID,
-		Status:    status,
+		Status:    domain.PRStatus(m.Status),
 		CreatedAt: m.CreatedAt,
 		MergedAt:  mergedAt,
+		ClosedAt:  closedAt,
+
+		NeedMoreReviewers: m.NeedMoreReviewers,
 	}
 }
 
@@ -55,3 +75,126 @@ func (m *Team) ToDomain() domain.Team {
 		UpdatedAt: updatedAt,
 	}
 }
+
+// ToDomain converts the PullRequestReview model to the domain Review model.
+func (m *PullRequestReview) ToDomain() domain.Review {
+	return domain.Review{
+		PullRequestID: m.PullRequestID,
+		ReviewerID:    m.ReviewerID,
+		Verdict:       domain.ReviewVerdict(m.Verdict),
+		SubmittedAt:   m.SubmittedAt,
+	}
+}
+
+// ToDomain converts the AuditEvent model to the domain AuditEvent model.
+func (m *AuditEvent) ToDomain() domain.AuditEvent {
+	return domain.AuditEvent{
+		ID:         m.ID,
+		Action:     domain.AuditAction(m.Action),
+		EntityType: domain.AuditEntityType(m.EntityType),
+		EntityID:   m.EntityID,
+		Actor:      m.Actor,
+		RequestID:  m.RequestID,
+		Before:     m.Before,
+		After:      m.After,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+// ToDomain converts the OutboxEvent model to the domain OutboxEvent model.
+func (m *OutboxEvent) ToDomain() domain.OutboxEvent {
+	return domain.OutboxEvent{
+		ID:        m.ID,
+		Type:      domain.EventType(m.EventType),
+		Payload:   m.Payload,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// ToDomain converts the WebhookSubscription model to the domain WebhookSubscription model.
+func (m *WebhookSubscription) ToDomain() domain.WebhookSubscription {
+	eventTypes := make([]domain.EventType, len(m.EventTypes))
+	for i, eventType := range m.EventTypes {
+		eventTypes[i] = domain.EventType(eventType)
+	}
+	return domain.WebhookSubscription{
+		ID:         m.ID,
+		URL:        m.Url,
+		Secret:     m.Secret,
+		EventTypes: eventTypes,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+// ToDomain converts the WebhookDelivery model to the domain WebhookDelivery model.
+func (m *WebhookDelivery) ToDomain() domain.WebhookDelivery {
+	var deliveredAt time.Time
+	if m.DeliveredAt != nil {
+		deliveredAt = *m.DeliveredAt
+	}
+	return domain.WebhookDelivery{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		EventID:        m.EventID,
+		Status:         domain.DeliveryStatus(m.Status),
+		Attempts:       int(m.Attempts),
+		NextAttemptAt:  m.NextAttemptAt,
+		LastError:      m.LastError,
+		DeliveredAt:    deliveredAt,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// ToDomain converts the claimed delivery row to the domain ClaimedDelivery model.
+func (m *ClaimWebhookDeliveriesRow) ToDomain() domain.ClaimedDelivery {
+	delivery := WebhookDelivery{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		EventID:        m.EventID,
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		NextAttemptAt:  m.NextAttemptAt,
+		LastError:      m.LastError,
+		DeliveredAt:    m.DeliveredAt,
+		CreatedAt:      m.CreatedAt,
+	}
+	return domain.ClaimedDelivery{
+		WebhookDelivery: delivery.ToDomain(),
+		URL:             m.Url,
+		Secret:          m.Secret,
+		Event: domain.OutboxEvent{
+			ID:        m.EventID,
+			Type:      domain.EventType(m.EventType),
+			Payload:   m.Payload,
+			CreatedAt: m.EventCreatedAt,
+		},
+	}
+}
+
+// ToDomain converts the ExternalLogin model to the domain ExternalLogin model.
+func (m *ExternalLogin) ToDomain() domain.ExternalLogin {
+	return domain.ExternalLogin{
+		Provider:  domain.ExternalProvider(m.Provider),
+		Login:     m.Login,
+		UserID:    m.UserID,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// ToDomain converts the UserAbsence model to the domain Absence model.
+func (m *UserAbsence) ToDomain() domain.Absence {
+	var reassignedAt time.Time
+	if m.ReassignedAt != nil {
+		reassignedAt = *m.ReassignedAt
+	}
+	return domain.Absence{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		StartsAt:     m.StartsAt,
+		EndsAt:       m.EndsAt,
+		Reason:       m.Reason,
+		ReassignedAt: reassignedAt,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
@@ -1,6 +1,6 @@
 -- name: CreatePullRequest :one
-INSERT INTO pull_requests (id, name, author_id)
-VALUES ($1, $2, $3)
+INSERT INTO pull_requests (id, name, author_id, need_more_reviewers, status)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: ExistsPullRequestByID :one
@@ -15,8 +15,80 @@ SELECT *
 FROM pull_requests
 WHERE id = $1;
 
+-- name: LockPullRequestByID :one
+SELECT *
+FROM pull_requests
+WHERE id = $1
+    FOR UPDATE;
+
 -- name: MergePullRequest :one
 UPDATE pull_requests
-SET merged_at = CURRENT_TIMESTAMP
+SET merged_at = CURRENT_TIMESTAMP,
+    status    = 'MERGED'
 WHERE id = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: UpdatePullRequestStatus :one
+-- closed_at хранит время последнего закрытия, при переоткрытии сбрасывается
+UPDATE pull_requests
+SET status              = @status,
+    closed_at           = CASE WHEN @status::VARCHAR = 'CLOSED' THEN CURRENT_TIMESTAMP END,
+    need_more_reviewers = need_more_reviewers AND @status::VARCHAR = 'OPEN'
+WHERE id = @id
+RETURNING *;
+
+-- name: SetPullRequestNeedMoreReviewers :exec
+UPDATE pull_requests
+SET need_more_reviewers = $2
+WHERE id = $1;
+
+-- name: MarkPullRequestsNeedMoreReviewers :exec
+UPDATE pull_requests
+SET need_more_reviewers = TRUE
+WHERE id = ANY (@ids::varchar[]);
+
+-- name: GetOpenPullRequestsNeedingReviewersByTeam :many
+SELECT pr.*
+FROM pull_requests pr
+         JOIN users u ON u.id = pr.author_id
+WHERE u.team_name = $1
+  AND pr.need_more_reviewers
+  AND pr.status = 'OPEN'
+ORDER BY pr.created_at, pr.id
+    FOR UPDATE OF pr;
+
+-- name: GetOpenPullRequestsByReviewerIDs :many
+-- author_team_name оставляет только PR, автор которых сейчас в этой команде
+SELECT pr.*
+FROM pull_requests pr
+         JOIN users a ON a.id = pr.author_id
+WHERE pr.status = 'OPEN'
+  AND EXISTS (SELECT 1
+              FROM pull_requests_reviewers prr
+              WHERE prr.pull_request_id = pr.id
+                AND prr.reviewer_id = ANY (@reviewer_ids::varchar[]))
+  AND (sqlc.narg(author_team_name)::VARCHAR IS NULL OR a.team_name = sqlc.narg(author_team_name))
+ORDER BY pr.id
+    FOR UPDATE OF pr;
+
+-- name: ListPullRequests :many
+-- Keyset-пагинация по (created_at, id) от новых к старым: страница начинается строго после курсора.
+-- Ревьювер - тот, кто назначен на PR сейчас, команда - команда автора
+SELECT pr.*
+FROM pull_requests pr
+         JOIN users u ON u.id = pr.author_id
+WHERE (sqlc.narg(status)::VARCHAR IS NULL OR pr.status = sqlc.narg(status))
+  AND (sqlc.narg(author_id)::VARCHAR IS NULL OR pr.author_id = sqlc.narg(author_id))
+  AND (sqlc.narg(team_name)::VARCHAR IS NULL OR u.team_name = sqlc.narg(team_name))
+  AND (sqlc.narg(reviewer_id)::VARCHAR IS NULL OR EXISTS (SELECT 1
+                                                         FROM pull_requests_reviewers prr
+                                                         WHERE prr.pull_request_id = pr.id
+                                                           AND prr.reviewer_id = sqlc.narg(reviewer_id)))
+  AND (sqlc.narg(created_from)::TIMESTAMP IS NULL OR pr.created_at >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::TIMESTAMP IS NULL OR pr.created_at < sqlc.narg(created_to))
+  AND (sqlc.narg(merged_from)::TIMESTAMP IS NULL OR pr.merged_at >= sqlc.narg(merged_from))
+  AND (sqlc.narg(merged_to)::TIMESTAMP IS NULL OR pr.merged_at < sqlc.narg(merged_to))
+  AND (sqlc.narg(after_created_at)::TIMESTAMP IS NULL
+    OR (pr.created_at, pr.id) < (sqlc.narg(after_created_at), sqlc.narg(after_id)::VARCHAR))
+ORDER BY pr.created_at DESC, pr.id DESC
+LIMIT @page_size;
\ No newline at end of file
@@ -7,22 +7,31 @@ package queries
 
 import (
 	"context"
+	"time"
 )
 
 const createPullRequest = `-- name: CreatePullRequest :one
-INSERT INTO pull_requests (id, name, author_id)
-VALUES ($1, $2, $3)
-RETURNING id, name, author_id, created_at, merged_at
+INSERT INTO pull_requests (id, name, author_id, need_more_reviewers, status)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, author_id, created_at, merged_at, need_more_reviewers, status, closed_at
 `
 
 type CreatePullRequestParams struct {
-	ID       string
-	Name     string
-	AuthorID string
+	ID                string
+	Name              string
+	AuthorID          string
+	NeedMoreReviewers bool
+	Status            string
 }
 
 func (q *Queries) CreatePullRequest(ctx context.Context, arg CreatePullRequestParams) (PullRequest, error) {
-	row := q.db.QueryRow(ctx, createPullRequest, arg.ID, arg.Name, arg.AuthorID)
+	row := q.db.QueryRow(ctx, createPullRequest,
+		arg.ID,
+		arg.Name,
+		arg.AuthorID,
+		arg.NeedMoreReviewers,
+		arg.Status,
+	)
 	var i PullRequest
 	err := row.Scan(
 		&i.ID,
@@ -30,6 +39,9 @@ func (q *Queries) CreatePullRequest(ctx context.Context, arg CreatePullRequestPa
 		&i.AuthorID,
 		&i.CreatedAt,
 		&i.MergedAt,
+		&i.NeedMoreReviewers,
+		&i.Status,
+		&i.ClosedAt,
 	)
 	return i, err
 }
@@ -49,8 +61,97 @@ func (q *Queries) ExistsPullRequestByID(ctx context.Context, id string) (bool, e
 	return exists, err
 }
 
+const getOpenPullRequestsByReviewerIDs = `-- name: GetOpenPullRequestsByReviewerIDs :many
+SELECT pr.id, pr.name, pr.author_id, pr.created_at, pr.merged_at, pr.need_more_reviewers, pr.status, pr.closed_at
+FROM pull_requests pr
+         JOIN users a ON a.id = pr.author_id
+WHERE pr.status = 'OPEN'
+  AND EXISTS (SELECT 1
+              FROM pull_requests_reviewers prr
+              WHERE prr.pull_request_id = pr.id
+                AND prr.reviewer_id = ANY ($1::varchar[]))
+  AND ($2::VARCHAR IS NULL OR a.team_name = $2)
+ORDER BY pr.id
+    FOR UPDATE OF pr
+`
+
+type GetOpenPullRequestsByReviewerIDsParams struct {
+	ReviewerIds    []string
+	AuthorTeamName *string
+}
+
+// author_team_name оставляет только PR, автор которых сейчас в этой команде
+func (q *Queries) GetOpenPullRequestsByReviewerIDs(ctx context.Context, arg GetOpenPullRequestsByReviewerIDsParams) ([]PullRequest, error) {
+	rows, err := q.db.Query(ctx, getOpenPullRequestsByReviewerIDs, arg.ReviewerIds, arg.AuthorTeamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PullRequest
+	for rows.Next() {
+		var i PullRequest
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AuthorID,
+			&i.CreatedAt,
+			&i.MergedAt,
+			&i.NeedMoreReviewers,
+			&i.Status,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOpenPullRequestsNeedingReviewersByTeam = `-- name: GetOpenPullRequestsNeedingReviewersByTeam :many
+SELECT pr.id, pr.name, pr.author_id, pr.created_at, pr.merged_at, pr.need_more_reviewers, pr.status, pr.closed_at
+FROM pull_requests pr
+         JOIN users u ON u.id = pr.author_id
+WHERE u.team_name = $1
+  AND pr.need_more_reviewers
+  AND pr.status = 'OPEN'
+ORDER BY pr.created_at, pr.id
+    FOR UPDATE OF pr
+`
+
+func (q *Queries) GetOpenPullRequestsNeedingReviewersByTeam(ctx context.Context, teamName string) ([]PullRequest, error) {
+	rows, err := q.db.Query(ctx, getOpenPullRequestsNeedingReviewersByTeam, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PullRequest
+	for rows.Next() {
+		var i PullRequest
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AuthorID,
+			&i.CreatedAt,
+			&i.MergedAt,
+			&i.NeedMoreReviewers,
+			&i.Status,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPullRequestByID = `-- name: GetPullRequestByID :one
-SELECT id, name, author_id, created_at, merged_at
+SELECT id, name, author_id, created_at, merged_at, need_more_reviewers, status, closed_at
 FROM pull_requests
 WHERE id = $1
 `
@@ -64,15 +165,131 @@ func (q *Queries) GetPullRequestByID(ctx context.Context, id string) (PullReques
 		&i.AuthorID,
 		&i.CreatedAt,
 		&i.MergedAt,
+		&i.NeedMoreReviewers,
+		&i.Status,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const listPullRequests = `-- name: ListPullRequests :many
+SELECT pr.id, pr.name, pr.author_id, pr.created_at, pr.merged_at, pr.need_more_reviewers, pr.status, pr.closed_at
+FROM pull_requests pr
+         JOIN users u ON u.id = pr.author_id
+WHERE ($1::VARCHAR IS NULL OR pr.status = $1)
+  AND ($2::VARCHAR IS NULL OR pr.author_id = $2)
+  AND ($3::VARCHAR IS NULL OR u.team_name = $3)
+  AND ($4::VARCHAR IS NULL OR EXISTS (SELECT 1
+                                                         FROM pull_requests_reviewers prr
+                                                         WHERE prr.pull_request_id = pr.id
+                                                           AND prr.reviewer_id = $4))
+  AND ($5::TIMESTAMP IS NULL OR pr.created_at >= $5)
+  AND ($6::TIMESTAMP IS NULL OR pr.created_at < $6)
+  AND ($7::TIMESTAMP IS NULL OR pr.merged_at >= $7)
+  AND ($8::TIMESTAMP IS NULL OR pr.merged_at < $8)
+  AND ($9::TIMESTAMP IS NULL
+    OR (pr.created_at, pr.id) < ($9, $10::VARCHAR))
+ORDER BY pr.created_at DESC, pr.id DESC
+LIMIT $11
+`
+
+type ListPullRequestsParams struct {
+	Status         *string
+	AuthorID       *string
+	TeamName       *string
+	ReviewerID     *string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	MergedFrom     *time.Time
+	MergedTo       *time.Time
+	AfterCreatedAt *time.Time
+	AfterID        *string
+	PageSize       int32
+}
+
+// Keyset-пагинация по (created_at, id) от новых к старым: страница начинается строго после курсора.
+// Ревьювер - тот, кто назначен на PR сейчас, команда - команда автора
+func (q *Queries) ListPullRequests(ctx context.Context, arg ListPullRequestsParams) ([]PullRequest, error) {
+	rows, err := q.db.Query(ctx, listPullRequests,
+		arg.Status,
+		arg.AuthorID,
+		arg.TeamName,
+		arg.ReviewerID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.MergedFrom,
+		arg.MergedTo,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PullRequest
+	for rows.Next() {
+		var i PullRequest
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AuthorID,
+			&i.CreatedAt,
+			&i.MergedAt,
+			&i.NeedMoreReviewers,
+			&i.Status,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockPullRequestByID = `-- name: LockPullRequestByID :one
+SELECT id, name, author_id, created_at, merged_at, need_more_reviewers, status, closed_at
+FROM pull_requests
+WHERE id = $1
+    FOR UPDATE
+`
+
+func (q *Queries) LockPullRequestByID(ctx context.Context, id string) (PullRequest, error) {
+	row := q.db.QueryRow(ctx, lockPullRequestByID, id)
+	var i PullRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AuthorID,
+		&i.CreatedAt,
+		&i.MergedAt,
+		&i.NeedMoreReviewers,
+		&i.Status,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
+const markPullRequestsNeedMoreReviewers = `-- name: MarkPullRequestsNeedMoreReviewers :exec
+UPDATE pull_requests
+SET need_more_reviewers = TRUE
+WHERE id = ANY ($1::varchar[])
+`
+
+func (q *Queries) MarkPullRequestsNeedMoreReviewers(ctx context.Context, ids []string) error {
+	_, err := q.db.Exec(ctx, markPullRequestsNeedMoreReviewers, ids)
+	return err
+}
+
 const mergePullRequest = `-- name: MergePullRequest :one
 UPDATE pull_requests
-SET merged_at = CURRENT_TIMESTAMP
+SET merged_at = CURRENT_TIMESTAMP,
+    status    = 'MERGED'
 WHERE id = $1
-RETURNING id, name, author_id, created_at, merged_at
+RETURNING id, name, author_id, created_at, merged_at, need_more_reviewers, status, closed_at
 `
 
 func (q *Queries) MergePullRequest(ctx context.Context, id string) (PullRequest, error) {
@@ -84,6 +301,56 @@ func (q *Queries) MergePullRequest(ctx context.Context, id string) (PullRequest,
 		&i.AuthorID,
 		&i.CreatedAt,
 		&i.MergedAt,
+		&i.NeedMoreReviewers,
+		&i.Status,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const setPullRequestNeedMoreReviewers = `-- name: SetPullRequestNeedMoreReviewers :exec
+UPDATE pull_requests
+SET need_more_reviewers = $2
+WHERE id = $1
+`
+
+type SetPullRequestNeedMoreReviewersParams struct {
+	ID                string
+	NeedMoreReviewers bool
+}
+
+func (q *Queries) SetPullRequestNeedMoreReviewers(ctx context.Context, arg SetPullRequestNeedMoreReviewersParams) error {
+	_, err := q.db.Exec(ctx, setPullRequestNeedMoreReviewers, arg.ID, arg.NeedMoreReviewers)
+	return err
+}
+
+const updatePullRequestStatus = `-- name: UpdatePullRequestStatus :one
+UPDATE pull_requests
+SET status              = $1,
+    closed_at           = CASE WHEN $1::VARCHAR = 'CLOSED' THEN CURRENT_TIMESTAMP END,
+    need_more_reviewers = need_more_reviewers AND $1::VARCHAR = 'OPEN'
+WHERE id = $2
+RETURNING id, name, author_id, created_at, merged_at, need_more_reviewers, status, closed_at
+`
+
+type UpdatePullRequestStatusParams struct {
+	Status string
+	ID     string
+}
+
+// closed_at хранит время последнего закрытия, при переоткрытии сбрасывается
+func (q *Queries) UpdatePullRequestStatus(ctx context.Context, arg UpdatePullRequestStatusParams) (PullRequest, error) {
+	row := q.db.QueryRow(ctx, updatePullRequestStatus, arg.Status, arg.ID)
+	var i PullRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AuthorID,
+		&i.CreatedAt,
+		&i.MergedAt,
+		&i.NeedMoreReviewers,
+		&i.Status,
+		&i.ClosedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,94 @@
+-- name: UpsertReviewerPool :one
+INSERT INTO reviewer_pools (name)
+VALUES ($1)
+ON CONFLICT (name) DO UPDATE SET updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetReviewerPoolByName :one
+SELECT *
+FROM reviewer_pools
+WHERE name = $1;
+
+-- name: DeleteReviewerPool :execrows
+-- Команды, ссылавшиеся на пул, теряют его каскадно
+DELETE
+FROM reviewer_pools
+WHERE name = $1;
+
+-- name: DeleteReviewerPoolMembers :exec
+DELETE
+FROM reviewer_pool_members
+WHERE pool_name = $1;
+
+-- name: AddReviewerPoolMembers :exec
+INSERT INTO reviewer_pool_members (pool_name, user_id)
+SELECT @pool_name::varchar, unnest(@user_ids::varchar[]);
+
+-- name: GetReviewerPoolMembers :many
+-- Удалённые пользователи в пуле не числятся
+SELECT u.*
+FROM reviewer_pool_members m
+         JOIN users u ON u.id = m.user_id
+WHERE m.pool_name = $1
+  AND u.deleted_at IS NULL
+ORDER BY u.id;
+
+-- name: GetActiveReviewerPoolMembers :many
+-- Участники пула, которых можно назначить ревьюверами, условия те же, что в GetActiveUsersByTeamName
+SELECT u.*
+FROM reviewer_pool_members m
+         JOIN users u ON u.id = m.user_id
+WHERE m.pool_name = $1
+  AND u.is_active = TRUE
+  AND NOT EXISTS (SELECT 1
+                  FROM user_absences a
+                  WHERE a.user_id = u.id
+                    AND a.starts_at <= CURRENT_TIMESTAMP
+                    AND a.ends_at > CURRENT_TIMESTAMP)
+  AND (u.max_open_reviews IS NULL OR
+       (SELECT COUNT(*)
+        FROM pull_requests_reviewers prr
+                 JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+        WHERE prr.reviewer_id = u.id) < u.max_open_reviews);
+
+-- name: GetTeamFallbackPools :many
+SELECT *
+FROM team_fallback_pools
+WHERE team_name = $1
+ORDER BY position;
+
+-- name: GetTeamsFallingBackTo :many
+-- Команды, которые подстраховывает команда: напрямую или через общий пул, где есть её участники
+SELECT DISTINCT f.team_name
+FROM team_fallback_pools f
+WHERE f.fallback_team_name = @team_name
+   OR f.pool_name IN (SELECT m.pool_name
+                      FROM reviewer_pool_members m
+                               JOIN users u ON u.id = m.user_id
+                      WHERE u.team_name = @team_name
+                        AND u.deleted_at IS NULL)
+ORDER BY f.team_name;
+
+-- name: DeleteTeamFallbackPools :exec
+DELETE
+FROM team_fallback_pools
+WHERE team_name = $1;
+
+-- name: AddTeamFallbackPool :exec
+INSERT INTO team_fallback_pools (team_name, position, fallback_team_name, pool_name)
+VALUES (@team_name, @position, sqlc.narg(fallback_team_name), sqlc.narg(pool_name));
+
+-- name: LockReviewerCursor :one
+-- Пустой курсор создаётся при первом обращении. DO UPDATE нужен, чтобы вернуть уже существующий курсор
+-- и заблокировать его строку до конца транзакции: конкурирующий выбор из того же пула ждёт
+INSERT INTO reviewer_cursors (pool_kind, pool_name, last_reviewer_id)
+VALUES (@pool_kind, @pool_name, '')
+ON CONFLICT (pool_kind, pool_name) DO UPDATE SET pool_kind = EXCLUDED.pool_kind
+RETURNING last_reviewer_id;
+
+-- name: SetReviewerCursor :exec
+UPDATE reviewer_cursors
+SET last_reviewer_id = @last_reviewer_id,
+    updated_at       = CURRENT_TIMESTAMP
+WHERE pool_kind = @pool_kind
+  AND pool_name = @pool_name;
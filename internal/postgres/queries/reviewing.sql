@@ -1,6 +1,6 @@
 -- name: AssignReviewerToPullRequest :batchone
-INSERT INTO pull_requests_reviewers (pull_request_id, reviewer_id)
-VALUES ($1, $2)
+INSERT INTO pull_requests_reviewers (pull_request_id, reviewer_id, pool_kind, pool_name)
+VALUES ($1, $2, $3, $4)
 ON CONFLICT (pull_request_id, reviewer_id) DO NOTHING
 RETURNING *;
 
@@ -10,20 +10,83 @@ FROM pull_requests_reviewers prr
          JOIN users u ON u.id = prr.reviewer_id
 WHERE prr.pull_request_id = $1;
 
+-- name: GetReviewerPoolsByPullRequestID :many
+SELECT reviewer_id, pool_kind, pool_name
+FROM pull_requests_reviewers
+WHERE pull_request_id = $1;
+
 -- name: IsUserReviewerForPullRequest :one
 SELECT EXISTS (SELECT 1
                FROM pull_requests_reviewers
                WHERE pull_request_id = $1
                  AND reviewer_id = $2) AS "exists";
 
--- name: ReassignReviewerForPullRequest :exec
+-- name: ReassignReviewerForPullRequest :execrows
 UPDATE pull_requests_reviewers
-SET reviewer_id = $2
+SET reviewer_id = $2,
+    pool_kind   = $4,
+    pool_name   = $5,
+    assigned_at = CURRENT_TIMESTAMP
 WHERE pull_request_id = $1
   AND reviewer_id = $3;
 
+-- name: ReassignReviewersForPullRequests :many
+-- Замены передаются параллельными массивами, возвращаются только состоявшиеся
+UPDATE pull_requests_reviewers prr
+SET reviewer_id = h.new_reviewer_id,
+    pool_kind   = h.pool_kind,
+    pool_name   = h.pool_name,
+    assigned_at = CURRENT_TIMESTAMP
+FROM UNNEST(@pull_request_ids::varchar[], @old_reviewer_ids::varchar[], @new_reviewer_ids::varchar[],
+            @pool_kinds::varchar[], @pool_names::varchar[])
+         AS h(pull_request_id, old_reviewer_id, new_reviewer_id, pool_kind, pool_name)
+WHERE prr.pull_request_id = h.pull_request_id
+  AND prr.reviewer_id = h.old_reviewer_id
+RETURNING prr.pull_request_id, h.old_reviewer_id::VARCHAR AS old_reviewer_id, prr.reviewer_id AS new_reviewer_id;
+
 -- name: GetUsersReviewingPullRequest :many
 SELECT pr.*
 FROM pull_requests_reviewers prr
-         JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.merged_at IS NULL
-WHERE prr.reviewer_id = $1;
\ No newline at end of file
+         JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+WHERE prr.reviewer_id = $1;
+
+-- name: CountOpenReviewsByReviewerIDs :many
+SELECT prr.reviewer_id, COUNT(*) AS open_reviews
+FROM pull_requests_reviewers prr
+         JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+WHERE prr.reviewer_id = ANY (@reviewer_ids::varchar[])
+GROUP BY prr.reviewer_id;
+
+-- name: GetReviewerUsersByPullRequestIDs :many
+SELECT prr.pull_request_id, prr.pool_kind, prr.pool_name, u.*
+FROM pull_requests_reviewers prr
+         JOIN users u ON u.id = prr.reviewer_id
+WHERE prr.pull_request_id = ANY (@pull_request_ids::varchar[])
+ORDER BY prr.pull_request_id, u.id;
+
+-- name: RemoveReviewerFromPullRequest :execrows
+DELETE
+FROM pull_requests_reviewers
+WHERE pull_request_id = $1
+  AND reviewer_id = $2;
+
+-- name: RemoveReviewersFromPullRequests :many
+DELETE
+FROM pull_requests_reviewers prr
+    USING UNNEST(@pull_request_ids::varchar[], @reviewer_ids::varchar[]) AS r(pull_request_id, reviewer_id)
+WHERE prr.pull_request_id = r.pull_request_id
+  AND prr.reviewer_id = r.reviewer_id
+RETURNING prr.pull_request_id, prr.reviewer_id;
+
+-- name: AddPullRequestReview :one
+INSERT INTO pull_request_reviews (pull_request_id, reviewer_id, verdict)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetLatestReviewsByPullRequestID :many
+-- Вердикты снятых с PR ревьюверов не считаются: они уже не могут их поменять
+SELECT DISTINCT ON (r.reviewer_id) r.*
+FROM pull_request_reviews r
+         JOIN pull_requests_reviewers prr ON prr.pull_request_id = r.pull_request_id AND prr.reviewer_id = r.reviewer_id
+WHERE r.pull_request_id = $1
+ORDER BY r.reviewer_id, r.submitted_at DESC, r.id DESC;
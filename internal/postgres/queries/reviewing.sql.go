@@ -7,10 +7,190 @@ package queries
 
 import (
 	"context"
+	"time"
 )
 
+const addPullRequestReview = `-- name: AddPullRequestReview :one
+INSERT INTO pull_request_reviews (pull_request_id, reviewer_id, verdict)
+VALUES ($1, $2, $3)
+RETURNING id, pull_request_id, reviewer_id, verdict, submitted_at
+`
+
+type AddPullRequestReviewParams struct {
+	PullRequestID string
+	ReviewerID    string
+	Verdict       string
+}
+
+func (q *Queries) AddPullRequestReview(ctx context.Context, arg AddPullRequestReviewParams) (PullRequestReview, error) {
+	row := q.db.QueryRow(ctx, addPullRequestReview, arg.PullRequestID, arg.ReviewerID, arg.Verdict)
+	var i PullRequestReview
+	err := row.Scan(
+		&i.ID,
+		&i.PullRequestID,
+		&i.ReviewerID,
+		&i.Verdict,
+		&i.SubmittedAt,
+	)
+	return i, err
+}
+
+const countOpenReviewsByReviewerIDs = `-- name: CountOpenReviewsByReviewerIDs :many
+SELECT prr.reviewer_id, COUNT(*) AS open_reviews
+FROM pull_requests_reviewers prr
+         JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+WHERE prr.reviewer_id = ANY ($1::varchar[])
+GROUP BY prr.reviewer_id
+`
+
+type CountOpenReviewsByReviewerIDsRow struct {
+	ReviewerID  string
+	OpenReviews int64
+}
+
+func (q *Queries) CountOpenReviewsByReviewerIDs(ctx context.Context, reviewerIds []string) ([]CountOpenReviewsByReviewerIDsRow, error) {
+	rows, err := q.db.Query(ctx, countOpenReviewsByReviewerIDs, reviewerIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountOpenReviewsByReviewerIDsRow
+	for rows.Next() {
+		var i CountOpenReviewsByReviewerIDsRow
+		if err := rows.Scan(&i.ReviewerID, &i.OpenReviews); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestReviewsByPullRequestID = `-- name: GetLatestReviewsByPullRequestID :many
+SELECT DISTINCT ON (r.reviewer_id) r.id, r.pull_request_id, r.reviewer_id, r.verdict, r.submitted_at
+FROM pull_request_reviews r
+         JOIN pull_requests_reviewers prr ON prr.pull_request_id = r.pull_request_id AND prr.reviewer_id = r.reviewer_id
+WHERE r.pull_request_id = $1
+ORDER BY r.reviewer_id, r.submitted_at DESC, r.id DESC
+`
+
+// Вердикты снятых с PR ревьюверов не считаются: они уже не могут их поменять
+func (q *Queries) GetLatestReviewsByPullRequestID(ctx context.Context, pullRequestID string) ([]PullRequestReview, error) {
+	rows, err := q.db.Query(ctx, getLatestReviewsByPullRequestID, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PullRequestReview
+	for rows.Next() {
+		var i PullRequestReview
+		if err := rows.Scan(
+			&i.ID,
+			&i.PullRequestID,
+			&i.ReviewerID,
+			&i.Verdict,
+			&i.SubmittedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewerPoolsByPullRequestID = `-- name: GetReviewerPoolsByPullRequestID :many
+SELECT reviewer_id, pool_kind, pool_name
+FROM pull_requests_reviewers
+WHERE pull_request_id = $1
+`
+
+type GetReviewerPoolsByPullRequestIDRow struct {
+	ReviewerID string
+	PoolKind   string
+	PoolName   string
+}
+
+func (q *Queries) GetReviewerPoolsByPullRequestID(ctx context.Context, pullRequestID string) ([]GetReviewerPoolsByPullRequestIDRow, error) {
+	rows, err := q.db.Query(ctx, getReviewerPoolsByPullRequestID, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReviewerPoolsByPullRequestIDRow
+	for rows.Next() {
+		var i GetReviewerPoolsByPullRequestIDRow
+		if err := rows.Scan(&i.ReviewerID, &i.PoolKind, &i.PoolName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewerUsersByPullRequestIDs = `-- name: GetReviewerUsersByPullRequestIDs :many
+SELECT prr.pull_request_id, prr.pool_kind, prr.pool_name, u.id, u.username, u.team_name, u.is_active, u.created_at, u.updated_at, u.max_open_reviews, u.deleted_at
+FROM pull_requests_reviewers prr
+         JOIN users u ON u.id = prr.reviewer_id
+WHERE prr.pull_request_id = ANY ($1::varchar[])
+ORDER BY prr.pull_request_id, u.id
+`
+
+type GetReviewerUsersByPullRequestIDsRow struct {
+	PullRequestID  string
+	PoolKind       string
+	PoolName       string
+	ID             string
+	Username       string
+	TeamName       string
+	IsActive       bool
+	CreatedAt      time.Time
+	UpdatedAt      *time.Time
+	MaxOpenReviews *int32
+	DeletedAt      *time.Time
+}
+
+func (q *Queries) GetReviewerUsersByPullRequestIDs(ctx context.Context, pullRequestIds []string) ([]GetReviewerUsersByPullRequestIDsRow, error) {
+	rows, err := q.db.Query(ctx, getReviewerUsersByPullRequestIDs, pullRequestIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReviewerUsersByPullRequestIDsRow
+	for rows.Next() {
+		var i GetReviewerUsersByPullRequestIDsRow
+		if err := rows.Scan(
+			&i.PullRequestID,
+			&i.PoolKind,
+			&i.PoolName,
+			&i.ID,
+			&i.Username,
+			&i.TeamName,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MaxOpenReviews,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getReviewersByPullRequestID = `-- name: GetReviewersByPullRequestID :many
-SELECT u.id, u.username, u.team_name, u.is_active, u.created_at, u.updated_at
+SELECT u.id, u.username, u.team_name, u.is_active, u.created_at, u.updated_at, u.max_open_reviews, u.deleted_at
 FROM pull_requests_reviewers prr
          JOIN users u ON u.id = prr.reviewer_id
 WHERE prr.pull_request_id = $1
@@ -32,6 +212,8 @@ func (q *Queries) GetReviewersByPullRequestID(ctx context.Context, pullRequestID
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MaxOpenReviews,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -44,9 +226,9 @@ func (q *Queries) GetReviewersByPullRequestID(ctx context.Context, pullRequestID
 }
 
 const getUsersReviewingPullRequest = `-- name: GetUsersReviewingPullRequest :many
-SELECT pr.id, pr.name, pr.author_id, pr.created_at, pr.merged_at
+SELECT pr.id, pr.name, pr.author_id, pr.created_at, pr.merged_at, pr.need_more_reviewers, pr.status, pr.closed_at
 FROM pull_requests_reviewers prr
-         JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.merged_at IS NULL
+         JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
 WHERE prr.reviewer_id = $1
 `
 
@@ -65,6 +247,9 @@ func (q *Queries) GetUsersReviewingPullRequest(ctx context.Context, reviewerID s
 			&i.AuthorID,
 			&i.CreatedAt,
 			&i.MergedAt,
+			&i.NeedMoreReviewers,
+			&i.Status,
+			&i.ClosedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -95,9 +280,12 @@ func (q *Queries) IsUserReviewerForPullRequest(ctx context.Context, arg IsUserRe
 	return exists, err
 }
 
-const reassignReviewerForPullRequest = `-- name: ReassignReviewerForPullRequest :exec
+const reassignReviewerForPullRequest = `-- name: ReassignReviewerForPullRequest :execrows
 UPDATE pull_requests_reviewers
-SET reviewer_id = $2
+SET reviewer_id = $2,
+    pool_kind   = $4,
+    pool_name   = $5,
+    assigned_at = CURRENT_TIMESTAMP
 WHERE pull_request_id = $1
   AND reviewer_id = $3
 `
@@ -106,9 +294,134 @@ type ReassignReviewerForPullRequestParams struct {
 	PullRequestID string
 	ReviewerID    string
 	ReviewerID_2  string
+	PoolKind      string
+	PoolName      string
+}
+
+func (q *Queries) ReassignReviewerForPullRequest(ctx context.Context, arg ReassignReviewerForPullRequestParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reassignReviewerForPullRequest,
+		arg.PullRequestID,
+		arg.ReviewerID,
+		arg.ReviewerID_2,
+		arg.PoolKind,
+		arg.PoolName,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reassignReviewersForPullRequests = `-- name: ReassignReviewersForPullRequests :many
+UPDATE pull_requests_reviewers prr
+SET reviewer_id = h.new_reviewer_id,
+    pool_kind   = h.pool_kind,
+    pool_name   = h.pool_name,
+    assigned_at = CURRENT_TIMESTAMP
+FROM UNNEST($1::varchar[], $2::varchar[], $3::varchar[],
+            $4::varchar[], $5::varchar[])
+         AS h(pull_request_id, old_reviewer_id, new_reviewer_id, pool_kind, pool_name)
+WHERE prr.pull_request_id = h.pull_request_id
+  AND prr.reviewer_id = h.old_reviewer_id
+RETURNING prr.pull_request_id, h.old_reviewer_id::VARCHAR AS old_reviewer_id, prr.reviewer_id AS new_reviewer_id
+`
+
+type ReassignReviewersForPullRequestsParams struct {
+	PullRequestIds []string
+	OldReviewerIds []string
+	NewReviewerIds []string
+	PoolKinds      []string
+	PoolNames      []string
+}
+
+type ReassignReviewersForPullRequestsRow struct {
+	PullRequestID string
+	OldReviewerID string
+	NewReviewerID string
+}
+
+// Замены передаются параллельными массивами, возвращаются только состоявшиеся
+func (q *Queries) ReassignReviewersForPullRequests(ctx context.Context, arg ReassignReviewersForPullRequestsParams) ([]ReassignReviewersForPullRequestsRow, error) {
+	rows, err := q.db.Query(ctx, reassignReviewersForPullRequests,
+		arg.PullRequestIds,
+		arg.OldReviewerIds,
+		arg.NewReviewerIds,
+		arg.PoolKinds,
+		arg.PoolNames,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReassignReviewersForPullRequestsRow
+	for rows.Next() {
+		var i ReassignReviewersForPullRequestsRow
+		if err := rows.Scan(&i.PullRequestID, &i.OldReviewerID, &i.NewReviewerID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeReviewerFromPullRequest = `-- name: RemoveReviewerFromPullRequest :execrows
+DELETE
+FROM pull_requests_reviewers
+WHERE pull_request_id = $1
+  AND reviewer_id = $2
+`
+
+type RemoveReviewerFromPullRequestParams struct {
+	PullRequestID string
+	ReviewerID    string
+}
+
+func (q *Queries) RemoveReviewerFromPullRequest(ctx context.Context, arg RemoveReviewerFromPullRequestParams) (int64, error) {
+	result, err := q.db.Exec(ctx, removeReviewerFromPullRequest, arg.PullRequestID, arg.ReviewerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-func (q *Queries) ReassignReviewerForPullRequest(ctx context.Context, arg ReassignReviewerForPullRequestParams) error {
-	_, err := q.db.Exec(ctx, reassignReviewerForPullRequest, arg.PullRequestID, arg.ReviewerID, arg.ReviewerID_2)
-	return err
+const removeReviewersFromPullRequests = `-- name: RemoveReviewersFromPullRequests :many
+DELETE
+FROM pull_requests_reviewers prr
+    USING UNNEST($1::varchar[], $2::varchar[]) AS r(pull_request_id, reviewer_id)
+WHERE prr.pull_request_id = r.pull_request_id
+  AND prr.reviewer_id = r.reviewer_id
+RETURNING prr.pull_request_id, prr.reviewer_id
+`
+
+type RemoveReviewersFromPullRequestsParams struct {
+	PullRequestIds []string
+	ReviewerIds    []string
+}
+
+type RemoveReviewersFromPullRequestsRow struct {
+	PullRequestID string
+	ReviewerID    string
+}
+
+func (q *Queries) RemoveReviewersFromPullRequests(ctx context.Context, arg RemoveReviewersFromPullRequestsParams) ([]RemoveReviewersFromPullRequestsRow, error) {
+	rows, err := q.db.Query(ctx, removeReviewersFromPullRequests, arg.PullRequestIds, arg.ReviewerIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RemoveReviewersFromPullRequestsRow
+	for rows.Next() {
+		var i RemoveReviewersFromPullRequestsRow
+		if err := rows.Scan(&i.PullRequestID, &i.ReviewerID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
@@ -1,20 +1,207 @@
+-- name: CountTeams :one
+SELECT COUNT(*)
+FROM teams;
+
 -- name: GetAssignmentStats :many
-SELECT prr.reviewer_id,
+SELECT rs.reviewer_id,
        u.is_active,
-       COUNT(prr.pull_request_id) AS assigned_pull_requests
-FROM pull_requests_reviewers prr
-         JOIN users u ON prr.reviewer_id = u.id
-GROUP BY prr.reviewer_id, u.id
+       rs.assigned_reviews AS assigned_pull_requests
+FROM reviewer_stats rs
+         JOIN users u ON rs.reviewer_id = u.id
+WHERE rs.assigned_reviews > 0
+  AND (sqlc.narg(team_name)::VARCHAR IS NULL OR u.team_name = sqlc.narg(team_name))
+  AND (sqlc.narg(reviewer_id)::VARCHAR IS NULL OR rs.reviewer_id = sqlc.narg(reviewer_id))
 ORDER BY assigned_pull_requests;
 
 -- name: GetTeamsCount :many
-SELECT t.name, COUNT(*) AS pr_count
+SELECT ts.team_name AS name, ts.assigned_reviews AS pr_count
+FROM team_stats ts
+WHERE ts.assigned_reviews > 0
+  AND (sqlc.narg(team_name)::VARCHAR IS NULL OR ts.team_name = sqlc.narg(team_name));
+
+-- name: GetUsersCount :many
+SELECT s.is_active, s.user_count::BIGINT AS user_count
+FROM (SELECT FALSE AS is_active, COALESCE(SUM(inactive_members), 0) AS user_count
+      FROM team_stats
+      WHERE sqlc.narg(team_name)::VARCHAR IS NULL OR team_name = sqlc.narg(team_name)
+      UNION ALL
+      SELECT TRUE AS is_active, COALESCE(SUM(active_members), 0) AS user_count
+      FROM team_stats
+      WHERE sqlc.narg(team_name)::VARCHAR IS NULL OR team_name = sqlc.narg(team_name)) s
+WHERE s.user_count > 0;
+
+-- name: GetTimeToMerge :one
+-- Время до мерджа считаем по PR, смердженным в заданном интервале.
+-- Команду определяем по автору PR
+SELECT COUNT(*) AS merged_count,
+       COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)),
+                0)::FLOAT8 AS median_seconds,
+       COALESCE(PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)),
+                0)::FLOAT8 AS p90_seconds
+FROM pull_requests pr
+         JOIN users u ON u.id = pr.author_id
+WHERE pr.merged_at IS NOT NULL
+  AND (sqlc.narg(from_time)::TIMESTAMP IS NULL OR pr.merged_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::TIMESTAMP IS NULL OR pr.merged_at < sqlc.narg(to_time))
+  AND (sqlc.narg(team_name)::VARCHAR IS NULL OR u.team_name = sqlc.narg(team_name))
+  AND (sqlc.narg(author_id)::VARCHAR IS NULL OR pr.author_id = sqlc.narg(author_id));
+
+-- name: GetPullRequestsByPeriod :many
+-- Открытые PR считаем по created_at, смердженные - по merged_at, поэтому периоды склеиваем FULL JOIN'ом
+WITH filtered AS (SELECT pr.created_at, pr.merged_at
+                  FROM pull_requests pr
+                           JOIN users u ON u.id = pr.author_id
+                  WHERE (sqlc.narg(team_name)::VARCHAR IS NULL OR u.team_name = sqlc.narg(team_name))
+                    AND (sqlc.narg(author_id)::VARCHAR IS NULL OR pr.author_id = sqlc.narg(author_id))),
+     opened AS (SELECT DATE_TRUNC(@period::TEXT, f.created_at) AS period_start, COUNT(*) AS cnt
+                FROM filtered f
+                WHERE (sqlc.narg(from_time)::TIMESTAMP IS NULL OR f.created_at >= sqlc.narg(from_time))
+                  AND (sqlc.narg(to_time)::TIMESTAMP IS NULL OR f.created_at < sqlc.narg(to_time))
+                GROUP BY 1),
+     merged AS (SELECT DATE_TRUNC(@period::TEXT, f.merged_at) AS period_start, COUNT(*) AS cnt
+                FROM filtered f
+                WHERE f.merged_at IS NOT NULL
+                  AND (sqlc.narg(from_time)::TIMESTAMP IS NULL OR f.merged_at >= sqlc.narg(from_time))
+                  AND (sqlc.narg(to_time)::TIMESTAMP IS NULL OR f.merged_at < sqlc.narg(to_time))
+                GROUP BY 1)
+SELECT COALESCE(o.period_start, m.period_start)::TIMESTAMP AS period_start,
+       COALESCE(o.cnt, 0)::BIGINT                          AS opened,
+       COALESCE(m.cnt, 0)::BIGINT                          AS merged
+FROM opened o
+         FULL JOIN merged m ON m.period_start = o.period_start
+ORDER BY 1;
+
+-- name: GetAssignmentsByPeriod :many
+-- Переназначение обновляет assigned_at, так что ревью учитывается за тем, кто его получил последним
+SELECT DATE_TRUNC(@period::TEXT, prr.assigned_at)::TIMESTAMP AS period_start,
+       prr.reviewer_id,
+       COUNT(*)                                               AS assignments
 FROM pull_requests_reviewers prr
          JOIN users u ON u.id = prr.reviewer_id
-         JOIN teams t ON u.team_name = t.name
+WHERE (sqlc.narg(from_time)::TIMESTAMP IS NULL OR prr.assigned_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::TIMESTAMP IS NULL OR prr.assigned_at < sqlc.narg(to_time))
+  AND (sqlc.narg(team_name)::VARCHAR IS NULL OR u.team_name = sqlc.narg(team_name))
+  AND (sqlc.narg(reviewer_id)::VARCHAR IS NULL OR prr.reviewer_id = sqlc.narg(reviewer_id))
+GROUP BY 1, prr.reviewer_id
+ORDER BY 1, prr.reviewer_id;
+
+-- name: AddTeamStats :exec
+INSERT INTO team_stats (team_name, active_members, inactive_members, assigned_reviews)
+VALUES (@team_name, @active_delta::BIGINT, @inactive_delta::BIGINT, @assigned_delta::BIGINT)
+ON CONFLICT (team_name) DO UPDATE
+    SET active_members   = team_stats.active_members + EXCLUDED.active_members,
+        inactive_members = team_stats.inactive_members + EXCLUDED.inactive_members,
+        assigned_reviews = team_stats.assigned_reviews + EXCLUDED.assigned_reviews;
+
+-- name: AddReviewersStats :many
+-- Строки обновляются в порядке ID ревьюверов, чтобы параллельные транзакции не взаимоблокировались
+INSERT INTO reviewer_stats (reviewer_id, assigned_reviews, open_reviews)
+SELECT d.reviewer_id, d.assigned_delta, d.open_delta
+FROM UNNEST(@reviewer_ids::varchar[], @assigned_deltas::bigint[], @open_deltas::bigint[])
+         AS d(reviewer_id, assigned_delta, open_delta)
+ORDER BY d.reviewer_id
+ON CONFLICT (reviewer_id) DO UPDATE
+    SET assigned_reviews = reviewer_stats.assigned_reviews + EXCLUDED.assigned_reviews,
+        open_reviews     = reviewer_stats.open_reviews + EXCLUDED.open_reviews
+RETURNING reviewer_id, (SELECT u.team_name
+                        FROM users u
+                        WHERE u.id = reviewer_stats.reviewer_id)::VARCHAR AS team_name;
+
+-- name: AddTeamsAssignedStats :exec
+INSERT INTO team_stats (team_name, active_members, inactive_members, assigned_reviews)
+SELECT d.team_name, 0, 0, d.assigned_delta
+FROM UNNEST(@team_names::varchar[], @assigned_deltas::bigint[]) AS d(team_name, assigned_delta)
+ORDER BY d.team_name
+ON CONFLICT (team_name) DO UPDATE
+    SET assigned_reviews = team_stats.assigned_reviews + EXCLUDED.assigned_reviews;
+
+-- name: GetReviewerAssignedReviews :one
+SELECT COALESCE((SELECT assigned_reviews
+                 FROM reviewer_stats
+                 WHERE reviewer_id = $1), 0)::BIGINT AS assigned_reviews;
+
+-- name: CloseReviewerStatsForPullRequest :exec
+UPDATE reviewer_stats rs
+SET open_reviews = rs.open_reviews - 1
+FROM pull_requests_reviewers prr
+WHERE prr.pull_request_id = $1
+  AND prr.reviewer_id = rs.reviewer_id;
+
+-- name: ReopenReviewerStatsForPullRequest :exec
+UPDATE reviewer_stats rs
+SET open_reviews = rs.open_reviews + 1
+FROM pull_requests_reviewers prr
+WHERE prr.pull_request_id = $1
+  AND prr.reviewer_id = rs.reviewer_id;
+
+-- name: LockStatsTables :exec
+LOCK TABLE reviewer_stats, team_stats IN SHARE ROW EXCLUSIVE MODE;
+
+-- name: GetAllReviewerStats :many
+SELECT *
+FROM reviewer_stats
+ORDER BY reviewer_id;
+
+-- name: GetAllTeamStats :many
+SELECT *
+FROM team_stats
+ORDER BY team_name;
+
+-- name: DeleteAllStats :exec
+WITH deleted_reviewers AS (DELETE FROM reviewer_stats)
+DELETE
+FROM team_stats;
+
+-- name: RebuildReviewerStats :exec
+INSERT INTO reviewer_stats (reviewer_id, assigned_reviews, open_reviews)
+SELECT prr.reviewer_id, COUNT(*), COUNT(*) FILTER (WHERE pr.status = 'OPEN')
+FROM pull_requests_reviewers prr
+         JOIN pull_requests pr ON pr.id = prr.pull_request_id
+GROUP BY prr.reviewer_id;
+
+-- name: RebuildTeamStats :exec
+INSERT INTO team_stats (team_name, active_members, inactive_members, assigned_reviews)
+SELECT t.name,
+       COUNT(u.id) FILTER (WHERE u.is_active),
+       COUNT(u.id) FILTER (WHERE NOT u.is_active),
+       COALESCE(SUM(rs.assigned_reviews), 0)::BIGINT
+FROM teams t
+         LEFT JOIN users u ON u.team_name = t.name
+         LEFT JOIN reviewer_stats rs ON rs.reviewer_id = u.id
 GROUP BY t.name;
 
--- name: GetUsersCount :many
-SELECT u.is_active, COUNT(*) AS user_count
-FROM users u
-GROUP BY u.is_active;
\ No newline at end of file
+-- name: GetTimeToFirstReview :one
+-- Время до первого ревью считаем по PR, получившим первый вердикт в заданном интервале.
+-- Команду определяем по автору PR
+WITH first_reviews AS (SELECT r.pull_request_id, MIN(r.submitted_at) AS submitted_at
+                       FROM pull_request_reviews r
+                       GROUP BY r.pull_request_id)
+SELECT COUNT(*) AS reviewed_count,
+       COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM fr.submitted_at - pr.created_at)),
+                0)::FLOAT8 AS median_seconds,
+       COALESCE(PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM fr.submitted_at - pr.created_at)),
+                0)::FLOAT8 AS p90_seconds
+FROM first_reviews fr
+         JOIN pull_requests pr ON pr.id = fr.pull_request_id
+         JOIN users u ON u.id = pr.author_id
+WHERE (sqlc.narg(from_time)::TIMESTAMP IS NULL OR fr.submitted_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::TIMESTAMP IS NULL OR fr.submitted_at < sqlc.narg(to_time))
+  AND (sqlc.narg(team_name)::VARCHAR IS NULL OR u.team_name = sqlc.narg(team_name))
+  AND (sqlc.narg(author_id)::VARCHAR IS NULL OR pr.author_id = sqlc.narg(author_id));
+
+-- name: GetReviewerApprovalStats :many
+-- Для каждого PR берём последний вердикт ревьювера, интервал применяем к его времени
+WITH latest AS (SELECT DISTINCT ON (r.pull_request_id, r.reviewer_id) r.reviewer_id, r.verdict, r.submitted_at
+                FROM pull_request_reviews r
+                ORDER BY r.pull_request_id, r.reviewer_id, r.submitted_at DESC, r.id DESC)
+SELECT l.reviewer_id,
+       COUNT(*)                                       AS reviewed,
+       COUNT(*) FILTER (WHERE l.verdict = 'APPROVED') AS approved
+FROM latest l
+         JOIN users u ON u.id = l.reviewer_id
+WHERE (sqlc.narg(from_time)::TIMESTAMP IS NULL OR l.submitted_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::TIMESTAMP IS NULL OR l.submitted_at < sqlc.narg(to_time))
+  AND (sqlc.narg(team_name)::VARCHAR IS NULL OR u.team_name = sqlc.narg(team_name))
+  AND (sqlc.narg(reviewer_id)::VARCHAR IS NULL OR l.reviewer_id = sqlc.narg(reviewer_id))
+GROUP BY l.reviewer_id
+ORDER BY l.reviewer_id;
@@ -7,26 +7,218 @@ package queries
 
 import (
 	"context"
+	"time"
 )
 
+const addReviewersStats = `-- name: AddReviewersStats :many
+INSERT INTO reviewer_stats (reviewer_id, assigned_reviews, open_reviews)
+SELECT d.reviewer_id, d.assigned_delta, d.open_delta
+FROM UNNEST($1::varchar[], $2::bigint[], $3::bigint[])
+         AS d(reviewer_id, assigned_delta, open_delta)
+ORDER BY d.reviewer_id
+ON CONFLICT (reviewer_id) DO UPDATE
+    SET assigned_reviews = reviewer_stats.assigned_reviews + EXCLUDED.assigned_reviews,
+        open_reviews     = reviewer_stats.open_reviews + EXCLUDED.open_reviews
+RETURNING reviewer_id, (SELECT u.team_name
+                        FROM users u
+                        WHERE u.id = reviewer_stats.reviewer_id)::VARCHAR AS team_name
+`
+
+type AddReviewersStatsParams struct {
+	ReviewerIds    []string
+	AssignedDeltas []int64
+	OpenDeltas     []int64
+}
+
+type AddReviewersStatsRow struct {
+	ReviewerID string
+	TeamName   string
+}
+
+// Строки обновляются в порядке ID ревьюверов, чтобы параллельные транзакции не взаимоблокировались
+func (q *Queries) AddReviewersStats(ctx context.Context, arg AddReviewersStatsParams) ([]AddReviewersStatsRow, error) {
+	rows, err := q.db.Query(ctx, addReviewersStats, arg.ReviewerIds, arg.AssignedDeltas, arg.OpenDeltas)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AddReviewersStatsRow
+	for rows.Next() {
+		var i AddReviewersStatsRow
+		if err := rows.Scan(&i.ReviewerID, &i.TeamName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const addTeamStats = `-- name: AddTeamStats :exec
+INSERT INTO team_stats (team_name, active_members, inactive_members, assigned_reviews)
+VALUES ($1, $2::BIGINT, $3::BIGINT, $4::BIGINT)
+ON CONFLICT (team_name) DO UPDATE
+    SET active_members   = team_stats.active_members + EXCLUDED.active_members,
+        inactive_members = team_stats.inactive_members + EXCLUDED.inactive_members,
+        assigned_reviews = team_stats.assigned_reviews + EXCLUDED.assigned_reviews
+`
+
+type AddTeamStatsParams struct {
+	TeamName      string
+	ActiveDelta   int64
+	InactiveDelta int64
+	AssignedDelta int64
+}
+
+func (q *Queries) AddTeamStats(ctx context.Context, arg AddTeamStatsParams) error {
+	_, err := q.db.Exec(ctx, addTeamStats,
+		arg.TeamName,
+		arg.ActiveDelta,
+		arg.InactiveDelta,
+		arg.AssignedDelta,
+	)
+	return err
+}
+
+const addTeamsAssignedStats = `-- name: AddTeamsAssignedStats :exec
+INSERT INTO team_stats (team_name, active_members, inactive_members, assigned_reviews)
+SELECT d.team_name, 0, 0, d.assigned_delta
+FROM UNNEST($1::varchar[], $2::bigint[]) AS d(team_name, assigned_delta)
+ORDER BY d.team_name
+ON CONFLICT (team_name) DO UPDATE
+    SET assigned_reviews = team_stats.assigned_reviews + EXCLUDED.assigned_reviews
+`
+
+type AddTeamsAssignedStatsParams struct {
+	TeamNames      []string
+	AssignedDeltas []int64
+}
+
+func (q *Queries) AddTeamsAssignedStats(ctx context.Context, arg AddTeamsAssignedStatsParams) error {
+	_, err := q.db.Exec(ctx, addTeamsAssignedStats, arg.TeamNames, arg.AssignedDeltas)
+	return err
+}
+
+const closeReviewerStatsForPullRequest = `-- name: CloseReviewerStatsForPullRequest :exec
+UPDATE reviewer_stats rs
+SET open_reviews = rs.open_reviews - 1
+FROM pull_requests_reviewers prr
+WHERE prr.pull_request_id = $1
+  AND prr.reviewer_id = rs.reviewer_id
+`
+
+func (q *Queries) CloseReviewerStatsForPullRequest(ctx context.Context, pullRequestID string) error {
+	_, err := q.db.Exec(ctx, closeReviewerStatsForPullRequest, pullRequestID)
+	return err
+}
+
+const countTeams = `-- name: CountTeams :one
+SELECT COUNT(*)
+FROM teams
+`
+
+func (q *Queries) CountTeams(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countTeams)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteAllStats = `-- name: DeleteAllStats :exec
+WITH deleted_reviewers AS (DELETE FROM reviewer_stats)
+DELETE
+FROM team_stats
+`
+
+func (q *Queries) DeleteAllStats(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteAllStats)
+	return err
+}
+
+const getAllReviewerStats = `-- name: GetAllReviewerStats :many
+SELECT *
+FROM reviewer_stats
+ORDER BY reviewer_id
+`
+
+func (q *Queries) GetAllReviewerStats(ctx context.Context) ([]ReviewerStat, error) {
+	rows, err := q.db.Query(ctx, getAllReviewerStats)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReviewerStat
+	for rows.Next() {
+		var i ReviewerStat
+		if err := rows.Scan(&i.ReviewerID, &i.AssignedReviews, &i.OpenReviews); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllTeamStats = `-- name: GetAllTeamStats :many
+SELECT *
+FROM team_stats
+ORDER BY team_name
+`
+
+func (q *Queries) GetAllTeamStats(ctx context.Context) ([]TeamStat, error) {
+	rows, err := q.db.Query(ctx, getAllTeamStats)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TeamStat
+	for rows.Next() {
+		var i TeamStat
+		if err := rows.Scan(
+			&i.TeamName,
+			&i.ActiveMembers,
+			&i.InactiveMembers,
+			&i.AssignedReviews,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAssignmentStats = `-- name: GetAssignmentStats :many
-SELECT prr.reviewer_id,
+SELECT rs.reviewer_id,
        u.is_active,
-       COUNT(prr.pull_request_id) AS assigned_pull_requests
-FROM pull_requests_reviewers prr
-         JOIN users u ON prr.reviewer_id = u.id
-GROUP BY prr.reviewer_id, u.id
+       rs.assigned_reviews AS assigned_pull_requests
+FROM reviewer_stats rs
+         JOIN users u ON rs.reviewer_id = u.id
+WHERE rs.assigned_reviews > 0
+  AND ($1::VARCHAR IS NULL OR u.team_name = $1)
+  AND ($2::VARCHAR IS NULL OR rs.reviewer_id = $2)
 ORDER BY assigned_pull_requests
 `
 
+type GetAssignmentStatsParams struct {
+	TeamName   *string
+	ReviewerID *string
+}
+
 type GetAssignmentStatsRow struct {
 	ReviewerID           string
 	IsActive             bool
 	AssignedPullRequests int64
 }
 
-func (q *Queries) GetAssignmentStats(ctx context.Context) ([]GetAssignmentStatsRow, error) {
-	rows, err := q.db.Query(ctx, getAssignmentStats)
+func (q *Queries) GetAssignmentStats(ctx context.Context, arg GetAssignmentStatsParams) ([]GetAssignmentStatsRow, error) {
+	rows, err := q.db.Query(ctx, getAssignmentStats, arg.TeamName, arg.ReviewerID)
 	if err != nil {
 		return nil, err
 	}
@@ -45,12 +237,201 @@ func (q *Queries) GetAssignmentStats(ctx context.Context) ([]GetAssignmentStatsR
 	return items, nil
 }
 
-const getTeamsCount = `-- name: GetTeamsCount :many
-SELECT t.name, COUNT(*) AS pr_count
+const getAssignmentsByPeriod = `-- name: GetAssignmentsByPeriod :many
+SELECT DATE_TRUNC($1::TEXT, prr.assigned_at)::TIMESTAMP AS period_start,
+       prr.reviewer_id,
+       COUNT(*)                                               AS assignments
 FROM pull_requests_reviewers prr
          JOIN users u ON u.id = prr.reviewer_id
-         JOIN teams t ON u.team_name = t.name
-GROUP BY t.name
+WHERE ($2::TIMESTAMP IS NULL OR prr.assigned_at >= $2)
+  AND ($3::TIMESTAMP IS NULL OR prr.assigned_at < $3)
+  AND ($4::VARCHAR IS NULL OR u.team_name = $4)
+  AND ($5::VARCHAR IS NULL OR prr.reviewer_id = $5)
+GROUP BY 1, prr.reviewer_id
+ORDER BY 1, prr.reviewer_id
+`
+
+type GetAssignmentsByPeriodParams struct {
+	Period     string
+	FromTime   *time.Time
+	ToTime     *time.Time
+	TeamName   *string
+	ReviewerID *string
+}
+
+type GetAssignmentsByPeriodRow struct {
+	PeriodStart time.Time
+	ReviewerID  string
+	Assignments int64
+}
+
+// Переназначение обновляет assigned_at, так что ревью учитывается за тем, кто его получил последним
+func (q *Queries) GetAssignmentsByPeriod(ctx context.Context, arg GetAssignmentsByPeriodParams) ([]GetAssignmentsByPeriodRow, error) {
+	rows, err := q.db.Query(ctx, getAssignmentsByPeriod,
+		arg.Period,
+		arg.FromTime,
+		arg.ToTime,
+		arg.TeamName,
+		arg.ReviewerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAssignmentsByPeriodRow
+	for rows.Next() {
+		var i GetAssignmentsByPeriodRow
+		if err := rows.Scan(&i.PeriodStart, &i.ReviewerID, &i.Assignments); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPullRequestsByPeriod = `-- name: GetPullRequestsByPeriod :many
+WITH filtered AS (SELECT pr.created_at, pr.merged_at
+                  FROM pull_requests pr
+                           JOIN users u ON u.id = pr.author_id
+                  WHERE ($1::VARCHAR IS NULL OR u.team_name = $1)
+                    AND ($2::VARCHAR IS NULL OR pr.author_id = $2)),
+     opened AS (SELECT DATE_TRUNC($3::TEXT, f.created_at) AS period_start, COUNT(*) AS cnt
+                FROM filtered f
+                WHERE ($4::TIMESTAMP IS NULL OR f.created_at >= $4)
+                  AND ($5::TIMESTAMP IS NULL OR f.created_at < $5)
+                GROUP BY 1),
+     merged AS (SELECT DATE_TRUNC($3::TEXT, f.merged_at) AS period_start, COUNT(*) AS cnt
+                FROM filtered f
+                WHERE f.merged_at IS NOT NULL
+                  AND ($4::TIMESTAMP IS NULL OR f.merged_at >= $4)
+                  AND ($5::TIMESTAMP IS NULL OR f.merged_at < $5)
+                GROUP BY 1)
+SELECT COALESCE(o.period_start, m.period_start)::TIMESTAMP AS period_start,
+       COALESCE(o.cnt, 0)::BIGINT                          AS opened,
+       COALESCE(m.cnt, 0)::BIGINT                          AS merged
+FROM opened o
+         FULL JOIN merged m ON m.period_start = o.period_start
+ORDER BY 1
+`
+
+type GetPullRequestsByPeriodParams struct {
+	TeamName *string
+	AuthorID *string
+	Period   string
+	FromTime *time.Time
+	ToTime   *time.Time
+}
+
+type GetPullRequestsByPeriodRow struct {
+	PeriodStart time.Time
+	Opened      int64
+	Merged      int64
+}
+
+// Открытые PR считаем по created_at, смердженные - по merged_at, поэтому периоды склеиваем FULL JOIN'ом
+func (q *Queries) GetPullRequestsByPeriod(ctx context.Context, arg GetPullRequestsByPeriodParams) ([]GetPullRequestsByPeriodRow, error) {
+	rows, err := q.db.Query(ctx, getPullRequestsByPeriod,
+		arg.TeamName,
+		arg.AuthorID,
+		arg.Period,
+		arg.FromTime,
+		arg.ToTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPullRequestsByPeriodRow
+	for rows.Next() {
+		var i GetPullRequestsByPeriodRow
+		if err := rows.Scan(&i.PeriodStart, &i.Opened, &i.Merged); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewerApprovalStats = `-- name: GetReviewerApprovalStats :many
+WITH latest AS (SELECT DISTINCT ON (r.pull_request_id, r.reviewer_id) r.reviewer_id, r.verdict, r.submitted_at
+                FROM pull_request_reviews r
+                ORDER BY r.pull_request_id, r.reviewer_id, r.submitted_at DESC, r.id DESC)
+SELECT l.reviewer_id,
+       COUNT(*)                                       AS reviewed,
+       COUNT(*) FILTER (WHERE l.verdict = 'APPROVED') AS approved
+FROM latest l
+         JOIN users u ON u.id = l.reviewer_id
+WHERE ($1::TIMESTAMP IS NULL OR l.submitted_at >= $1)
+  AND ($2::TIMESTAMP IS NULL OR l.submitted_at < $2)
+  AND ($3::VARCHAR IS NULL OR u.team_name = $3)
+  AND ($4::VARCHAR IS NULL OR l.reviewer_id = $4)
+GROUP BY l.reviewer_id
+ORDER BY l.reviewer_id
+`
+
+type GetReviewerApprovalStatsParams struct {
+	FromTime   *time.Time
+	ToTime     *time.Time
+	TeamName   *string
+	ReviewerID *string
+}
+
+type GetReviewerApprovalStatsRow struct {
+	ReviewerID string
+	Reviewed   int64
+	Approved   int64
+}
+
+// Для каждого PR берём последний вердикт ревьювера, интервал применяем к его времени
+func (q *Queries) GetReviewerApprovalStats(ctx context.Context, arg GetReviewerApprovalStatsParams) ([]GetReviewerApprovalStatsRow, error) {
+	rows, err := q.db.Query(ctx, getReviewerApprovalStats,
+		arg.FromTime,
+		arg.ToTime,
+		arg.TeamName,
+		arg.ReviewerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReviewerApprovalStatsRow
+	for rows.Next() {
+		var i GetReviewerApprovalStatsRow
+		if err := rows.Scan(&i.ReviewerID, &i.Reviewed, &i.Approved); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewerAssignedReviews = `-- name: GetReviewerAssignedReviews :one
+SELECT COALESCE((SELECT assigned_reviews
+                 FROM reviewer_stats
+                 WHERE reviewer_id = $1), 0)::BIGINT AS assigned_reviews
+`
+
+func (q *Queries) GetReviewerAssignedReviews(ctx context.Context, reviewerID string) (int64, error) {
+	row := q.db.QueryRow(ctx, getReviewerAssignedReviews, reviewerID)
+	var assigned_reviews int64
+	err := row.Scan(&assigned_reviews)
+	return assigned_reviews, err
+}
+
+const getTeamsCount = `-- name: GetTeamsCount :many
+SELECT ts.team_name AS name, ts.assigned_reviews AS pr_count
+FROM team_stats ts
+WHERE ts.assigned_reviews > 0
+  AND ($1::VARCHAR IS NULL OR ts.team_name = $1)
 `
 
 type GetTeamsCountRow struct {
@@ -58,8 +439,8 @@ type GetTeamsCountRow struct {
 	PrCount int64
 }
 
-func (q *Queries) GetTeamsCount(ctx context.Context) ([]GetTeamsCountRow, error) {
-	rows, err := q.db.Query(ctx, getTeamsCount)
+func (q *Queries) GetTeamsCount(ctx context.Context, teamName *string) ([]GetTeamsCountRow, error) {
+	rows, err := q.db.Query(ctx, getTeamsCount, teamName)
 	if err != nil {
 		return nil, err
 	}
@@ -78,10 +459,103 @@ func (q *Queries) GetTeamsCount(ctx context.Context) ([]GetTeamsCountRow, error)
 	return items, nil
 }
 
+const getTimeToFirstReview = `-- name: GetTimeToFirstReview :one
+WITH first_reviews AS (SELECT r.pull_request_id, MIN(r.submitted_at) AS submitted_at
+                       FROM pull_request_reviews r
+                       GROUP BY r.pull_request_id)
+SELECT COUNT(*) AS reviewed_count,
+       COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM fr.submitted_at - pr.created_at)),
+                0)::FLOAT8 AS median_seconds,
+       COALESCE(PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM fr.submitted_at - pr.created_at)),
+                0)::FLOAT8 AS p90_seconds
+FROM first_reviews fr
+         JOIN pull_requests pr ON pr.id = fr.pull_request_id
+         JOIN users u ON u.id = pr.author_id
+WHERE ($1::TIMESTAMP IS NULL OR fr.submitted_at >= $1)
+  AND ($2::TIMESTAMP IS NULL OR fr.submitted_at < $2)
+  AND ($3::VARCHAR IS NULL OR u.team_name = $3)
+  AND ($4::VARCHAR IS NULL OR pr.author_id = $4)
+`
+
+type GetTimeToFirstReviewParams struct {
+	FromTime *time.Time
+	ToTime   *time.Time
+	TeamName *string
+	AuthorID *string
+}
+
+type GetTimeToFirstReviewRow struct {
+	ReviewedCount int64
+	MedianSeconds float64
+	P90Seconds    float64
+}
+
+// Время до первого ревью считаем по PR, получившим первый вердикт в заданном интервале.
+// Команду определяем по автору PR
+func (q *Queries) GetTimeToFirstReview(ctx context.Context, arg GetTimeToFirstReviewParams) (GetTimeToFirstReviewRow, error) {
+	row := q.db.QueryRow(ctx, getTimeToFirstReview,
+		arg.FromTime,
+		arg.ToTime,
+		arg.TeamName,
+		arg.AuthorID,
+	)
+	var i GetTimeToFirstReviewRow
+	err := row.Scan(&i.ReviewedCount, &i.MedianSeconds, &i.P90Seconds)
+	return i, err
+}
+
+const getTimeToMerge = `-- name: GetTimeToMerge :one
+SELECT COUNT(*) AS merged_count,
+       COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)),
+                0)::FLOAT8 AS median_seconds,
+       COALESCE(PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)),
+                0)::FLOAT8 AS p90_seconds
+FROM pull_requests pr
+         JOIN users u ON u.id = pr.author_id
+WHERE pr.merged_at IS NOT NULL
+  AND ($1::TIMESTAMP IS NULL OR pr.merged_at >= $1)
+  AND ($2::TIMESTAMP IS NULL OR pr.merged_at < $2)
+  AND ($3::VARCHAR IS NULL OR u.team_name = $3)
+  AND ($4::VARCHAR IS NULL OR pr.author_id = $4)
+`
+
+type GetTimeToMergeParams struct {
+	FromTime *time.Time
+	ToTime   *time.Time
+	TeamName *string
+	AuthorID *string
+}
+
+type GetTimeToMergeRow struct {
+	MergedCount   int64
+	MedianSeconds float64
+	P90Seconds    float64
+}
+
+// Время до мерджа считаем по PR, смердженным в заданном интервале.
+// Команду определяем по автору PR
+func (q *Queries) GetTimeToMerge(ctx context.Context, arg GetTimeToMergeParams) (GetTimeToMergeRow, error) {
+	row := q.db.QueryRow(ctx, getTimeToMerge,
+		arg.FromTime,
+		arg.ToTime,
+		arg.TeamName,
+		arg.AuthorID,
+	)
+	var i GetTimeToMergeRow
+	err := row.Scan(&i.MergedCount, &i.MedianSeconds, &i.P90Seconds)
+	return i, err
+}
+
 const getUsersCount = `-- name: GetUsersCount :many
-SELECT u.is_active, COUNT(*) AS user_count
-FROM users u
-GROUP BY u.is_active
+SELECT s.is_active, s.user_count::BIGINT AS user_count
+FROM (SELECT FALSE AS is_active, COALESCE(SUM(inactive_members), 0) AS user_count
+      FROM team_stats
+      WHERE $1::VARCHAR IS NULL OR team_name = $1
+      UNION ALL
+      SELECT TRUE AS is_active, COALESCE(SUM(active_members), 0) AS user_count
+      FROM team_stats
+      WHERE $1::VARCHAR IS NULL OR team_name = $1) s
+WHERE s.user_count > 0
 `
 
 type GetUsersCountRow struct {
@@ -89,8 +563,8 @@ type GetUsersCountRow struct {
 	UserCount int64
 }
 
-func (q *Queries) GetUsersCount(ctx context.Context) ([]GetUsersCountRow, error) {
-	rows, err := q.db.Query(ctx, getUsersCount)
+func (q *Queries) GetUsersCount(ctx context.Context, teamName *string) ([]GetUsersCountRow, error) {
+	rows, err := q.db.Query(ctx, getUsersCount, teamName)
 	if err != nil {
 		return nil, err
 	}
@@ -108,3 +582,55 @@ func (q *Queries) GetUsersCount(ctx context.Context) ([]GetUsersCountRow, error)
 	}
 	return items, nil
 }
+
+const lockStatsTables = `-- name: LockStatsTables :exec
+LOCK TABLE reviewer_stats, team_stats IN SHARE ROW EXCLUSIVE MODE
+`
+
+func (q *Queries) LockStatsTables(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockStatsTables)
+	return err
+}
+
+const rebuildReviewerStats = `-- name: RebuildReviewerStats :exec
+INSERT INTO reviewer_stats (reviewer_id, assigned_reviews, open_reviews)
+SELECT prr.reviewer_id, COUNT(*), COUNT(*) FILTER (WHERE pr.status = 'OPEN')
+FROM pull_requests_reviewers prr
+         JOIN pull_requests pr ON pr.id = prr.pull_request_id
+GROUP BY prr.reviewer_id
+`
+
+func (q *Queries) RebuildReviewerStats(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, rebuildReviewerStats)
+	return err
+}
+
+const rebuildTeamStats = `-- name: RebuildTeamStats :exec
+INSERT INTO team_stats (team_name, active_members, inactive_members, assigned_reviews)
+SELECT t.name,
+       COUNT(u.id) FILTER (WHERE u.is_active),
+       COUNT(u.id) FILTER (WHERE NOT u.is_active),
+       COALESCE(SUM(rs.assigned_reviews), 0)::BIGINT
+FROM teams t
+         LEFT JOIN users u ON u.team_name = t.name
+         LEFT JOIN reviewer_stats rs ON rs.reviewer_id = u.id
+GROUP BY t.name
+`
+
+func (q *Queries) RebuildTeamStats(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, rebuildTeamStats)
+	return err
+}
+
+const reopenReviewerStatsForPullRequest = `-- name: ReopenReviewerStatsForPullRequest :exec
+UPDATE reviewer_stats rs
+SET open_reviews = rs.open_reviews + 1
+FROM pull_requests_reviewers prr
+WHERE prr.pull_request_id = $1
+  AND prr.reviewer_id = rs.reviewer_id
+`
+
+func (q *Queries) ReopenReviewerStatsForPullRequest(ctx context.Context, pullRequestID string) error {
+	_, err := q.db.Exec(ctx, reopenReviewerStatsForPullRequest, pullRequestID)
+	return err
+}
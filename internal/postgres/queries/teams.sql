@@ -16,3 +16,34 @@ SELECT EXISTS (
     FROM teams
     WHERE name = $1
 ) AS exists;
+
+-- name: RenameTeam :one
+-- Участники и счётчики команды переезжают за ней по ON UPDATE CASCADE
+UPDATE teams
+SET name       = @new_name,
+    updated_at = CURRENT_TIMESTAMP
+WHERE name = @name
+RETURNING *;
+
+-- name: RenameTeamReviewerPools :exec
+-- Назначения помнят пул по имени команды, ключа на него нет, поэтому переименовываем сами
+UPDATE pull_requests_reviewers
+SET pool_name = @new_name
+WHERE pool_kind = 'team'
+  AND pool_name = @name;
+
+-- name: RenameTeamReviewerCursor :exec
+-- Курсор очереди тоже помнит команду по имени
+UPDATE reviewer_cursors
+SET pool_name = @new_name
+WHERE pool_kind = 'team'
+  AND pool_name = @name;
+
+-- name: DeleteEmptyTeam :execrows
+-- Участники удалились бы каскадно, поэтому команду с участниками не трогаем
+DELETE
+FROM teams t
+WHERE t.name = $1
+  AND NOT EXISTS (SELECT 1
+                  FROM users u
+                  WHERE u.team_name = t.name);
@@ -24,6 +24,24 @@ func (q *Queries) AddTeam(ctx context.Context, name string) (Team, error) {
 	return i, err
 }
 
+const deleteEmptyTeam = `-- name: DeleteEmptyTeam :execrows
+DELETE
+FROM teams t
+WHERE t.name = $1
+  AND NOT EXISTS (SELECT 1
+                  FROM users u
+                  WHERE u.team_name = t.name)
+`
+
+// Участники удалились бы каскадно, поэтому команду с участниками не трогаем
+func (q *Queries) DeleteEmptyTeam(ctx context.Context, name string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteEmptyTeam, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const existsTeamByName = `-- name: ExistsTeamByName :one
 SELECT EXISTS (
     SELECT 1
@@ -51,3 +69,60 @@ func (q *Queries) GetTeamByName(ctx context.Context, name string) (Team, error)
 	err := row.Scan(&i.Name, &i.CreatedAt, &i.UpdatedAt)
 	return i, err
 }
+
+const renameTeam = `-- name: RenameTeam :one
+UPDATE teams
+SET name       = $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE name = $2
+RETURNING name, created_at, updated_at
+`
+
+type RenameTeamParams struct {
+	NewName string
+	Name    string
+}
+
+// Участники и счётчики команды переезжают за ней по ON UPDATE CASCADE
+func (q *Queries) RenameTeam(ctx context.Context, arg RenameTeamParams) (Team, error) {
+	row := q.db.QueryRow(ctx, renameTeam, arg.NewName, arg.Name)
+	var i Team
+	err := row.Scan(&i.Name, &i.CreatedAt, &i.UpdatedAt)
+	return i, err
+}
+
+const renameTeamReviewerCursor = `-- name: RenameTeamReviewerCursor :exec
+UPDATE reviewer_cursors
+SET pool_name = $1
+WHERE pool_kind = 'team'
+  AND pool_name = $2
+`
+
+type RenameTeamReviewerCursorParams struct {
+	NewName string
+	Name    string
+}
+
+// Курсор очереди тоже помнит команду по имени
+func (q *Queries) RenameTeamReviewerCursor(ctx context.Context, arg RenameTeamReviewerCursorParams) error {
+	_, err := q.db.Exec(ctx, renameTeamReviewerCursor, arg.NewName, arg.Name)
+	return err
+}
+
+const renameTeamReviewerPools = `-- name: RenameTeamReviewerPools :exec
+UPDATE pull_requests_reviewers
+SET pool_name = $1
+WHERE pool_kind = 'team'
+  AND pool_name = $2
+`
+
+type RenameTeamReviewerPoolsParams struct {
+	NewName string
+	Name    string
+}
+
+// Назначения помнят пул по имени команды, ключа на него нет, поэтому переименовываем сами
+func (q *Queries) RenameTeamReviewerPools(ctx context.Context, arg RenameTeamReviewerPoolsParams) error {
+	_, err := q.db.Exec(ctx, renameTeamReviewerPools, arg.NewName, arg.Name)
+	return err
+}
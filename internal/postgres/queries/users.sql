@@ -1,41 +1,114 @@
 -- name: AddUsers :batchone
-INSERT INTO users (id, username, team_name, is_active)
-VALUES ($1, $2, $3, $4)
-ON CONFLICT (id) DO UPDATE SET username   = EXCLUDED.username,
-                               team_name  = EXCLUDED.team_name,
-                               is_active  = EXCLUDED.is_active,
-                               updated_at = CURRENT_TIMESTAMP
+INSERT INTO users (id, username, team_name, is_active, max_open_reviews)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
--- name: BatchExistsUserByID :batchone
-SELECT EXISTS (SELECT 1
-               FROM users
-               WHERE id = $1) AS "exists";
-
 -- name: ExistsUserByID :one
 SELECT EXISTS (SELECT 1
                FROM users
-               WHERE id = $1) AS "exists";
+               WHERE id = $1
+                 AND deleted_at IS NULL) AS "exists";
 
 -- name: GetUserByID :one
 SELECT *
 FROM users
 WHERE id = $1;
 
+-- name: GetUsersByIDs :many
+SELECT *
+FROM users
+WHERE id = ANY (@ids::varchar[])
+ORDER BY id;
+
 -- name: GetUsersByTeamName :many
 SELECT *
 FROM users
-WHERE team_name = $1;
+WHERE team_name = $1
+  AND deleted_at IS NULL;
 
 -- name: SetUserIsActiveByID :one
 UPDATE users
 SET is_active  = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
+  AND deleted_at IS NULL
 RETURNING *;
 
 -- name: GetActiveUsersByTeamName :many
+-- Отсутствующие сейчас пользователи и те, у кого открытых ревью уже под лимит,
+-- в ревьюверы не берутся, даже если активны
+SELECT *
+FROM users u
+WHERE u.team_name = $1
+  AND u.is_active = TRUE
+  AND NOT EXISTS (SELECT 1
+                  FROM user_absences a
+                  WHERE a.user_id = u.id
+                    AND a.starts_at <= CURRENT_TIMESTAMP
+                    AND a.ends_at > CURRENT_TIMESTAMP)
+  AND (u.max_open_reviews IS NULL OR
+       (SELECT COUNT(*)
+        FROM pull_requests_reviewers prr
+                 JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+        WHERE prr.reviewer_id = u.id) < u.max_open_reviews);
+
+-- name: SetUserMaxOpenReviewsByID :one
+UPDATE users
+SET max_open_reviews = $2,
+    updated_at       = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeactivateTeamUsers :many
+UPDATE users
+SET is_active  = FALSE,
+    updated_at = CURRENT_TIMESTAMP
+WHERE team_name = @team_name
+  AND deleted_at IS NULL
+  AND (cardinality(@user_ids::varchar[]) = 0 OR id = ANY (@user_ids::varchar[]))
+RETURNING *;
+
+-- name: LockUsersByIDs :many
+SELECT *
+FROM users
+WHERE id = ANY (@ids::varchar[])
+ORDER BY id
+    FOR UPDATE;
+
+-- name: LockUsersByTeamName :many
 SELECT *
 FROM users
 WHERE team_name = $1
-  AND is_active = TRUE;
+ORDER BY id
+    FOR UPDATE;
+
+-- name: MoveUsersToTeam :many
+UPDATE users
+SET team_name  = @team_name,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ANY (@ids::varchar[])
+RETURNING *;
+
+-- name: ListUsers :many
+-- Удалённые пользователи в справочник не попадают, страница начинается строго после пользователя с ID курсора
+SELECT *
+FROM users
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(team_name)::VARCHAR IS NULL OR team_name = sqlc.narg(team_name))
+  AND (sqlc.narg(is_active)::BOOLEAN IS NULL OR is_active = sqlc.narg(is_active))
+  AND (sqlc.narg(username_prefix)::VARCHAR IS NULL
+    OR starts_with(lower(username), lower(sqlc.narg(username_prefix))))
+  AND (sqlc.narg(after_id)::VARCHAR IS NULL OR id > sqlc.narg(after_id))
+ORDER BY id
+LIMIT @page_size;
+
+-- name: SoftDeleteUser :one
+-- Строка остаётся, чтобы не терять историю pull request'ов, удалённый пользователь считается неактивным
+UPDATE users
+SET is_active  = FALSE,
+    deleted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING *;
@@ -9,10 +9,55 @@ import (
 	"context"
 )
 
+const deactivateTeamUsers = `-- name: DeactivateTeamUsers :many
+UPDATE users
+SET is_active  = FALSE,
+    updated_at = CURRENT_TIMESTAMP
+WHERE team_name = $1
+  AND deleted_at IS NULL
+  AND (cardinality($2::varchar[]) = 0 OR id = ANY ($2::varchar[]))
+RETURNING id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
+`
+
+type DeactivateTeamUsersParams struct {
+	TeamName string
+	UserIds  []string
+}
+
+func (q *Queries) DeactivateTeamUsers(ctx context.Context, arg DeactivateTeamUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, deactivateTeamUsers, arg.TeamName, arg.UserIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.TeamName,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MaxOpenReviews,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const existsUserByID = `-- name: ExistsUserByID :one
 SELECT EXISTS (SELECT 1
                FROM users
-               WHERE id = $1) AS "exists"
+               WHERE id = $1
+                 AND deleted_at IS NULL) AS "exists"
 `
 
 func (q *Queries) ExistsUserByID(ctx context.Context, id string) (bool, error) {
@@ -23,12 +68,24 @@ func (q *Queries) ExistsUserByID(ctx context.Context, id string) (bool, error) {
 }
 
 const getActiveUsersByTeamName = `-- name: GetActiveUsersByTeamName :many
-SELECT id, username, team_name, is_active, created_at, updated_at
-FROM users
-WHERE team_name = $1
-  AND is_active = TRUE
+SELECT id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
+FROM users u
+WHERE u.team_name = $1
+  AND u.is_active = TRUE
+  AND NOT EXISTS (SELECT 1
+                  FROM user_absences a
+                  WHERE a.user_id = u.id
+                    AND a.starts_at <= CURRENT_TIMESTAMP
+                    AND a.ends_at > CURRENT_TIMESTAMP)
+  AND (u.max_open_reviews IS NULL OR
+       (SELECT COUNT(*)
+        FROM pull_requests_reviewers prr
+                 JOIN pull_requests pr ON pr.id = prr.pull_request_id AND pr.status = 'OPEN'
+        WHERE prr.reviewer_id = u.id) < u.max_open_reviews)
 `
 
+// Отсутствующие сейчас пользователи и те, у кого открытых ревью уже под лимит,
+// в ревьюверы не берутся, даже если активны
 func (q *Queries) GetActiveUsersByTeamName(ctx context.Context, teamName string) ([]User, error) {
 	rows, err := q.db.Query(ctx, getActiveUsersByTeamName, teamName)
 	if err != nil {
@@ -45,6 +102,8 @@ func (q *Queries) GetActiveUsersByTeamName(ctx context.Context, teamName string)
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MaxOpenReviews,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -57,7 +116,7 @@ func (q *Queries) GetActiveUsersByTeamName(ctx context.Context, teamName string)
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, username, team_name, is_active, created_at, updated_at
+SELECT id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
 FROM users
 WHERE id = $1
 `
@@ -72,14 +131,53 @@ func (q *Queries) GetUserByID(ctx context.Context, id string) (User, error) {
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxOpenReviews,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
+const getUsersByIDs = `-- name: GetUsersByIDs :many
+SELECT id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
+FROM users
+WHERE id = ANY ($1::varchar[])
+ORDER BY id
+`
+
+func (q *Queries) GetUsersByIDs(ctx context.Context, ids []string) ([]User, error) {
+	rows, err := q.db.Query(ctx, getUsersByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.TeamName,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MaxOpenReviews,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUsersByTeamName = `-- name: GetUsersByTeamName :many
-SELECT id, username, team_name, is_active, created_at, updated_at
+SELECT id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
 FROM users
 WHERE team_name = $1
+  AND deleted_at IS NULL
 `
 
 func (q *Queries) GetUsersByTeamName(ctx context.Context, teamName string) ([]User, error) {
@@ -98,6 +196,181 @@ func (q *Queries) GetUsersByTeamName(ctx context.Context, teamName string) ([]Us
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MaxOpenReviews,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
+FROM users
+WHERE deleted_at IS NULL
+  AND ($1::VARCHAR IS NULL OR team_name = $1)
+  AND ($2::BOOLEAN IS NULL OR is_active = $2)
+  AND ($3::VARCHAR IS NULL
+    OR starts_with(lower(username), lower($3)))
+  AND ($4::VARCHAR IS NULL OR id > $4)
+ORDER BY id
+LIMIT $5
+`
+
+type ListUsersParams struct {
+	TeamName       *string
+	IsActive       *bool
+	UsernamePrefix *string
+	AfterID        *string
+	PageSize       int32
+}
+
+// Удалённые пользователи в справочник не попадают, страница начинается строго после пользователя с ID курсора
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsers,
+		arg.TeamName,
+		arg.IsActive,
+		arg.UsernamePrefix,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.TeamName,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MaxOpenReviews,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockUsersByIDs = `-- name: LockUsersByIDs :many
+SELECT id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
+FROM users
+WHERE id = ANY ($1::varchar[])
+ORDER BY id
+    FOR UPDATE
+`
+
+func (q *Queries) LockUsersByIDs(ctx context.Context, ids []string) ([]User, error) {
+	rows, err := q.db.Query(ctx, lockUsersByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.TeamName,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MaxOpenReviews,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockUsersByTeamName = `-- name: LockUsersByTeamName :many
+SELECT id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
+FROM users
+WHERE team_name = $1
+ORDER BY id
+    FOR UPDATE
+`
+
+func (q *Queries) LockUsersByTeamName(ctx context.Context, teamName string) ([]User, error) {
+	rows, err := q.db.Query(ctx, lockUsersByTeamName, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.TeamName,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MaxOpenReviews,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveUsersToTeam = `-- name: MoveUsersToTeam :many
+UPDATE users
+SET team_name  = $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ANY ($2::varchar[])
+RETURNING id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
+`
+
+type MoveUsersToTeamParams struct {
+	TeamName string
+	Ids      []string
+}
+
+func (q *Queries) MoveUsersToTeam(ctx context.Context, arg MoveUsersToTeamParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, moveUsersToTeam, arg.TeamName, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.TeamName,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MaxOpenReviews,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -114,7 +387,8 @@ UPDATE users
 SET is_active  = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, username, team_name, is_active, created_at, updated_at
+  AND deleted_at IS NULL
+RETURNING id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
 `
 
 type SetUserIsActiveByIDParams struct {
@@ -132,6 +406,65 @@ func (q *Queries) SetUserIsActiveByID(ctx context.Context, arg SetUserIsActiveBy
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxOpenReviews,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const setUserMaxOpenReviewsByID = `-- name: SetUserMaxOpenReviewsByID :one
+UPDATE users
+SET max_open_reviews = $2,
+    updated_at       = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
+`
+
+type SetUserMaxOpenReviewsByIDParams struct {
+	ID             string
+	MaxOpenReviews *int32
+}
+
+func (q *Queries) SetUserMaxOpenReviewsByID(ctx context.Context, arg SetUserMaxOpenReviewsByIDParams) (User, error) {
+	row := q.db.QueryRow(ctx, setUserMaxOpenReviewsByID, arg.ID, arg.MaxOpenReviews)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.TeamName,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MaxOpenReviews,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const softDeleteUser = `-- name: SoftDeleteUser :one
+UPDATE users
+SET is_active  = FALSE,
+    deleted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING id, username, team_name, is_active, created_at, updated_at, max_open_reviews, deleted_at
+`
+
+// Строка остаётся, чтобы не терять историю pull request'ов, удалённый пользователь считается неактивным
+func (q *Queries) SoftDeleteUser(ctx context.Context, id string) (User, error) {
+	row := q.db.QueryRow(ctx, softDeleteUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.TeamName,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MaxOpenReviews,
+		&i.DeletedAt,
 	)
 	return i, err
 }
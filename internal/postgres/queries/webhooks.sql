@@ -0,0 +1,87 @@
+-- name: AddOutboxEvent :one
+INSERT INTO outbox_events (event_type, payload)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: AddOutboxEvents :batchexec
+INSERT INTO outbox_events (event_type, payload)
+VALUES ($1, $2);
+
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, secret, event_types)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListWebhookSubscriptions :many
+SELECT *
+FROM webhook_subscriptions
+ORDER BY id;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: FanOutOutboxEvents :execrows
+-- Создаёт доставки новых событий всем подписчикам на их тип и помечает события разложенными.
+-- SKIP LOCKED позволяет нескольким экземплярам сервиса раскладывать события параллельно
+WITH pending AS (
+    SELECT id, event_type
+    FROM outbox_events
+    WHERE fanned_out_at IS NULL
+    ORDER BY id
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+), deliveries AS (
+    INSERT INTO webhook_deliveries (subscription_id, event_id)
+    SELECT s.id, p.id
+    FROM pending p
+    JOIN webhook_subscriptions s ON p.event_type = ANY (s.event_types)
+    ON CONFLICT (subscription_id, event_id) DO NOTHING
+)
+UPDATE outbox_events
+SET fanned_out_at = CURRENT_TIMESTAMP
+WHERE id IN (SELECT id FROM pending);
+
+-- name: ClaimWebhookDeliveries :many
+-- Забирает доставки, время которых пришло, и сдвигает next_attempt_at на время аренды:
+-- пока диспетчер отправляет запросы вне транзакции, другие экземпляры эти доставки не возьмут
+WITH due AS (
+    SELECT id
+    FROM webhook_deliveries
+    WHERE status = 'PENDING' AND next_attempt_at <= @now
+    ORDER BY next_attempt_at, id
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE webhook_deliveries d
+SET next_attempt_at = @lease_until
+FROM due, webhook_subscriptions s, outbox_events e
+WHERE d.id = due.id AND s.id = d.subscription_id AND e.id = d.event_id
+RETURNING d.*, s.url, s.secret, e.event_type, e.payload, e.created_at AS event_created_at;
+
+-- name: CompleteWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = 'DELIVERED', attempts = attempts + 1, last_error = '', delivered_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: FailWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = @status, attempts = attempts + 1, next_attempt_at = @next_attempt_at, last_error = @last_error
+WHERE id = @id;
+
+-- name: ListWebhookDeliveries :many
+-- Доставки от новых к старым, страница начинается строго после доставки с ID курсора
+SELECT *
+FROM webhook_deliveries
+WHERE (sqlc.narg(subscription_id)::BIGINT IS NULL OR subscription_id = sqlc.narg(subscription_id))
+  AND (sqlc.narg(status)::VARCHAR IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(after_id)::BIGINT IS NULL OR id < sqlc.narg(after_id))
+ORDER BY id DESC
+LIMIT @page_size;
+
+-- name: RetryWebhookDelivery :one
+-- Возвращает мёртвую доставку в очередь с чистым счётчиком попыток
+UPDATE webhook_deliveries
+SET status = 'PENDING', attempts = 0, next_attempt_at = CURRENT_TIMESTAMP, last_error = ''
+WHERE id = $1 AND status = 'DEAD'
+RETURNING *;
@@ -0,0 +1,216 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/internal/postgres/queries"
+)
+
+// fallbackPoolConstraint is the foreign key a fallback onto an unknown shared pool breaks
+const fallbackPoolConstraint = "team_fallback_pools_pool_name_fkey"
+
+// SetReviewerPool creates the shared reviewer pool or replaces the members of the existing one
+func (p *Postgres) SetReviewerPool(
+	ctx context.Context,
+	poolName string,
+	userIDs []string,
+) (domain.SharedReviewerPool, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return domain.SharedReviewerPool{}, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck  // safe to call even after commit
+
+	q := p.queries.WithTx(tx)
+
+	pool, err := q.UpsertReviewerPool(ctx, poolName)
+	if err != nil {
+		return domain.SharedReviewerPool{}, fmt.Errorf("error upserting reviewer pool %s: %w", poolName, err)
+	}
+	if err := q.DeleteReviewerPoolMembers(ctx, poolName); err != nil {
+		return domain.SharedReviewerPool{}, fmt.Errorf("error clearing reviewer pool %s: %w", poolName, err)
+	}
+	err = q.AddReviewerPoolMembers(ctx, queries.AddReviewerPoolMembersParams{PoolName: poolName, UserIds: userIDs})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == sqlStateForeignKeyViolation {
+		return domain.SharedReviewerPool{}, fmt.Errorf("members of reviewer pool %s: %w", poolName,
+			domain.ErrUserNotFound)
+	}
+	if err != nil {
+		return domain.SharedReviewerPool{}, fmt.Errorf("error adding members to reviewer pool %s: %w", poolName, err)
+	}
+
+	result, err := reviewerPoolWithMembers(ctx, q, pool)
+	if err != nil {
+		return domain.SharedReviewerPool{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.SharedReviewerPool{}, fmt.Errorf("error committing transaction: %w", err)
+	}
+	return result, nil
+}
+
+// GetReviewerPool returns the shared reviewer pool with its members ordered by ID
+func (p *Postgres) GetReviewerPool(ctx context.Context, poolName string) (domain.SharedReviewerPool, error) {
+	q := p.q(ctx)
+	pool, err := q.GetReviewerPoolByName(ctx, poolName)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.SharedReviewerPool{}, fmt.Errorf("reviewer pool %s: %w", poolName, domain.ErrReviewerPoolNotFound)
+	}
+	if err != nil {
+		return domain.SharedReviewerPool{}, fmt.Errorf("error getting reviewer pool %s: %w", poolName, err)
+	}
+	return reviewerPoolWithMembers(ctx, q, pool)
+}
+
+func reviewerPoolWithMembers(
+	ctx context.Context,
+	q *queries.Queries,
+	pool queries.ReviewerPool,
+) (domain.SharedReviewerPool, error) {
+	members, err := q.GetReviewerPoolMembers(ctx, pool.Name)
+	if err != nil {
+		return domain.SharedReviewerPool{}, fmt.Errorf("error getting members of reviewer pool %s: %w", pool.Name, err)
+	}
+	result := domain.SharedReviewerPool{
+		Name:      pool.Name,
+		Members:   make([]domain.User, len(members)),
+		CreatedAt: pool.CreatedAt,
+		UpdatedAt: pool.UpdatedAt,
+	}
+	for i, member := range members {
+		result.Members[i] = member.ToDomain()
+	}
+	return result, nil
+}
+
+// DeleteReviewerPool deletes the shared reviewer pool, teams falling back to it lose it by cascade
+func (p *Postgres) DeleteReviewerPool(ctx context.Context, poolName string) error {
+	deleted, err := p.q(ctx).DeleteReviewerPool(ctx, poolName)
+	if err != nil {
+		return fmt.Errorf("error deleting reviewer pool %s: %w", poolName, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("reviewer pool %s: %w", poolName, domain.ErrReviewerPoolNotFound)
+	}
+	return nil
+}
+
+// GetActiveReviewerPoolMembers returns the members of the shared pool who may review right now
+func (p *Postgres) GetActiveReviewerPoolMembers(ctx context.Context, poolName string) ([]domain.User, error) {
+	users, err := p.q(ctx).GetActiveReviewerPoolMembers(ctx, poolName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting active members of reviewer pool %s: %w", poolName, err)
+	}
+	domainUsers := make([]domain.User, len(users))
+	for i, user := range users {
+		domainUsers[i] = user.ToDomain()
+	}
+	return domainUsers, nil
+}
+
+// GetTeamFallbacks returns the fallback pools of the team in order
+func (p *Postgres) GetTeamFallbacks(ctx context.Context, teamName string) ([]domain.ReviewerPool, error) {
+	rows, err := p.q(ctx).GetTeamFallbackPools(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fallback pools of team %s: %w", teamName, err)
+	}
+	fallbacks := make([]domain.ReviewerPool, len(rows))
+	for i, r := range rows {
+		if r.PoolName != nil {
+			fallbacks[i] = domain.SharedPool(*r.PoolName)
+		} else if r.FallbackTeamName != nil {
+			fallbacks[i] = domain.TeamPool(*r.FallbackTeamName)
+		}
+	}
+	return fallbacks, nil
+}
+
+// GetTeamsFallingBackTo returns the names of the teams falling back to the team itself or to a shared pool
+// with its members
+func (p *Postgres) GetTeamsFallingBackTo(ctx context.Context, teamName string) ([]string, error) {
+	teamNames, err := p.q(ctx).GetTeamsFallingBackTo(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting teams falling back to team %s: %w", teamName, err)
+	}
+	return teamNames, nil
+}
+
+// SetTeamFallbacks replaces the fallback pools of the team, the order of fallbacks is kept
+func (p *Postgres) SetTeamFallbacks(ctx context.Context, teamName string, fallbacks []domain.ReviewerPool) error {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck  // safe to call even after commit
+
+	q := p.queries.WithTx(tx)
+
+	if err := q.DeleteTeamFallbackPools(ctx, teamName); err != nil {
+		return fmt.Errorf("error clearing fallback pools of team %s: %w", teamName, err)
+	}
+	for i, fallback := range fallbacks {
+		params := queries.AddTeamFallbackPoolParams{
+			TeamName:         teamName,
+			Position:         int32(i), //nolint:gosec // Число запасных пулов ограничено сервисом
+			FallbackTeamName: nil,
+			PoolName:         nil,
+		}
+		if fallback.Kind == domain.ReviewerPoolKindShared {
+			params.PoolName = &fallback.Name
+		} else {
+			params.FallbackTeamName = &fallback.Name
+		}
+
+		err := q.AddTeamFallbackPool(ctx, params)
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.As(err, &pgErr) && pgErr.Code == sqlStateForeignKeyViolation &&
+			pgErr.ConstraintName == fallbackPoolConstraint:
+			return fmt.Errorf("reviewer pool %s: %w", fallback.Name, domain.ErrReviewerPoolNotFound)
+		case errors.As(err, &pgErr) && pgErr.Code == sqlStateForeignKeyViolation:
+			return fmt.Errorf("team with name %s or %s: %w", teamName, fallback.Name, domain.ErrTeamNotFound)
+		case err != nil:
+			return fmt.Errorf("error adding fallback pool of team %s: %w", teamName, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+// LockReviewerCursor returns the ID of the member of the pool picked last in turn, empty if nobody was.
+// The cursor stays locked till the end of the transaction from ctx, so concurrent picks from the pool wait
+func (p *Postgres) LockReviewerCursor(ctx context.Context, pool domain.ReviewerPool) (string, error) {
+	lastReviewerID, err := p.q(ctx).LockReviewerCursor(ctx, queries.LockReviewerCursorParams{
+		PoolKind: string(pool.Kind),
+		PoolName: pool.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error locking cursor of %s pool %s: %w", pool.Kind, pool.Name, err)
+	}
+	return lastReviewerID, nil
+}
+
+// SetReviewerCursor remembers the member of the pool picked last in turn. The cursor must be locked
+// with LockReviewerCursor first
+func (p *Postgres) SetReviewerCursor(ctx context.Context, pool domain.ReviewerPool, lastReviewerID string) error {
+	err := p.q(ctx).SetReviewerCursor(ctx, queries.SetReviewerCursorParams{
+		LastReviewerID: lastReviewerID,
+		PoolKind:       string(pool.Kind),
+		PoolName:       pool.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("error setting cursor of %s pool %s: %w", pool.Kind, pool.Name, err)
+	}
+	return nil
+}
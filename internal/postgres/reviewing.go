@@ -9,8 +9,14 @@ import (
 	"github.com/artmexbet/avito_test_task/internal/postgres/queries"
 )
 
-func (p *Postgres) AssignReviewersToPR(ctx context.Context, prID string, reviewerIDs []string) error {
-	tx, err := p.pool.Begin(ctx)
+// AssignReviewersToPR assigns the reviewers picked from the pool to the pull request
+func (p *Postgres) AssignReviewersToPR(
+	ctx context.Context,
+	prID string,
+	pool domain.ReviewerPool,
+	reviewerIDs []string,
+) error {
+	tx, err := p.begin(ctx)
 	if err != nil {
 		return fmt.Errorf("error starting transaction: %w", err)
 	}
@@ -23,6 +29,8 @@ func (p *Postgres) AssignReviewersToPR(ctx context.Context, prID string, reviewe
 		params[i] = queries.AssignReviewerToPullRequestParams{
 			PullRequestID: prID,
 			ReviewerID:    reviewerID,
+			PoolKind:      string(pool.Kind),
+			PoolName:      pool.Name,
 		}
 	}
 	br := q.AssignReviewerToPullRequest(ctx, params)
@@ -39,6 +47,15 @@ func (p *Postgres) AssignReviewersToPR(ctx context.Context, prID string, reviewe
 		return fmt.Errorf("error assigning reviewers to PR: %w", err)
 	}
 
+	// Ревьюверов назначаем только на открытые PR
+	delta := make(reviewerStatsDelta, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		delta.add(reviewerID, 1, true)
+	}
+	if err := delta.apply(ctx, q); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("error committing transaction: %w", err)
 	}
@@ -46,7 +63,7 @@ func (p *Postgres) AssignReviewersToPR(ctx context.Context, prID string, reviewe
 }
 
 func (p *Postgres) GetReviewersByPRID(ctx context.Context, prID string) ([]domain.User, error) {
-	users, err := p.queries.GetReviewersByPullRequestID(ctx, prID)
+	users, err := p.q(ctx).GetReviewersByPullRequestID(ctx, prID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting reviewers by PR ID: %w", err)
 	}
@@ -58,16 +75,179 @@ func (p *Postgres) GetReviewersByPRID(ctx context.Context, prID string) ([]domai
 	return reviewers, nil
 }
 
-func (p *Postgres) ReassignReviewer(ctx context.Context, prID, newReviewerID, oldReviewerID string) error {
-	return p.queries.ReassignReviewerForPullRequest(ctx, queries.ReassignReviewerForPullRequestParams{
+// GetReviewerPoolsByPRID returns the pool each reviewer of the pull request was picked from by reviewer ID
+func (p *Postgres) GetReviewerPoolsByPRID(ctx context.Context, prID string) (map[string]domain.ReviewerPool, error) {
+	rows, err := p.q(ctx).GetReviewerPoolsByPullRequestID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting reviewer pools by PR ID: %w", err)
+	}
+
+	pools := make(map[string]domain.ReviewerPool, len(rows))
+	for _, r := range rows {
+		pools[r.ReviewerID] = domain.ReviewerPool{Kind: domain.ReviewerPoolKind(r.PoolKind), Name: r.PoolName}
+	}
+	return pools, nil
+}
+
+// ReassignReviewer replaces oldReviewerID on the pull request with newReviewerID picked from the pool
+func (p *Postgres) ReassignReviewer(
+	ctx context.Context,
+	prID string,
+	pool domain.ReviewerPool,
+	newReviewerID, oldReviewerID string,
+) error {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := p.queries.WithTx(tx)
+
+	reassigned, err := q.ReassignReviewerForPullRequest(ctx, queries.ReassignReviewerForPullRequestParams{
 		PullRequestID: prID,
 		ReviewerID:    newReviewerID,
 		ReviewerID_2:  oldReviewerID,
+		PoolKind:      string(pool.Kind),
+		PoolName:      pool.Name,
 	})
+	if err != nil {
+		return fmt.Errorf("error reassigning reviewer: %w", err)
+	}
+
+	if reassigned > 0 {
+		pr, err := q.GetPullRequestByID(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("error getting pull request: %w", err)
+		}
+		open := pr.Status == string(domain.PRStatusOpen)
+		delta := make(reviewerStatsDelta, 2)
+		delta.add(oldReviewerID, -1, open)
+		delta.add(newReviewerID, 1, open)
+		if err := delta.apply(ctx, q); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+// UnassignReviewer removes the reviewer from the pull request
+func (p *Postgres) UnassignReviewer(ctx context.Context, prID, reviewerID string) error {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := p.queries.WithTx(tx)
+
+	removed, err := q.RemoveReviewerFromPullRequest(ctx, queries.RemoveReviewerFromPullRequestParams{
+		PullRequestID: prID,
+		ReviewerID:    reviewerID,
+	})
+	if err != nil {
+		return fmt.Errorf("error removing reviewer: %w", err)
+	}
+
+	if removed > 0 {
+		pr, err := q.GetPullRequestByID(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("error getting pull request: %w", err)
+		}
+		delta := make(reviewerStatsDelta, 1)
+		delta.add(reviewerID, -1, pr.Status == string(domain.PRStatusOpen))
+		if err := delta.apply(ctx, q); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+// HandOverReviewers applies the replacements of reviewers on open pull requests with a constant number
+// of statements whatever their number. Reviewers without a replacement are removed and their pull requests
+// get NeedMoreReviewers. Replacements of reviewers that are no longer assigned are skipped
+func (p *Postgres) HandOverReviewers(ctx context.Context, handovers []domain.ReviewerHandover) error {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := p.queries.WithTx(tx)
+
+	reassign, remove := handoverParams(handovers)
+
+	// Передаются ревью только открытых PR, так что открытые ревью меняются вместе с назначенными
+	delta := make(reviewerStatsDelta, 2*len(handovers))
+	if len(reassign.PullRequestIds) > 0 {
+		reassigned, err := q.ReassignReviewersForPullRequests(ctx, reassign)
+		if err != nil {
+			return fmt.Errorf("error reassigning reviewers: %w", err)
+		}
+		for _, r := range reassigned {
+			delta.add(r.OldReviewerID, -1, true)
+			delta.add(r.NewReviewerID, 1, true)
+		}
+	}
+	if len(remove.PullRequestIds) > 0 {
+		removed, err := q.RemoveReviewersFromPullRequests(ctx, remove)
+		if err != nil {
+			return fmt.Errorf("error removing reviewers: %w", err)
+		}
+		prIDs := make([]string, 0, len(removed))
+		for _, r := range removed {
+			delta.add(r.ReviewerID, -1, true)
+			prIDs = append(prIDs, r.PullRequestID)
+		}
+		// PR доберут ревьюверов, когда появятся доступные
+		if err := q.MarkPullRequestsNeedMoreReviewers(ctx, prIDs); err != nil {
+			return fmt.Errorf("error updating pull requests: %w", err)
+		}
+	}
+	if err := delta.apply(ctx, q); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+// handoverParams splits the handovers into replacements and removals of reviewers
+func handoverParams(handovers []domain.ReviewerHandover) (
+	queries.ReassignReviewersForPullRequestsParams,
+	queries.RemoveReviewersFromPullRequestsParams,
+) {
+	var (
+		reassign queries.ReassignReviewersForPullRequestsParams
+		remove   queries.RemoveReviewersFromPullRequestsParams
+	)
+	for _, h := range handovers {
+		if h.NewReviewerID == "" {
+			remove.PullRequestIds = append(remove.PullRequestIds, h.PullRequestID)
+			remove.ReviewerIds = append(remove.ReviewerIds, h.OldReviewerID)
+			continue
+		}
+		reassign.PullRequestIds = append(reassign.PullRequestIds, h.PullRequestID)
+		reassign.OldReviewerIds = append(reassign.OldReviewerIds, h.OldReviewerID)
+		reassign.NewReviewerIds = append(reassign.NewReviewerIds, h.NewReviewerID)
+		reassign.PoolKinds = append(reassign.PoolKinds, string(h.Pool.Kind))
+		reassign.PoolNames = append(reassign.PoolNames, h.Pool.Name)
+	}
+	return reassign, remove
 }
 
 func (p *Postgres) GetUsersReviewingPR(ctx context.Context, userID string) ([]domain.PullRequest, error) {
-	prs, err := p.queries.GetUsersReviewingPullRequest(ctx, userID)
+	prs, err := p.q(ctx).GetUsersReviewingPullRequest(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting PRs being reviewed by user: %w", err)
 	}
@@ -80,7 +260,7 @@ func (p *Postgres) GetUsersReviewingPR(ctx context.Context, userID string) ([]do
 }
 
 func (p *Postgres) IsReviewerAssignedToPR(ctx context.Context, prID, reviewerID string) (bool, error) {
-	assigned, err := p.queries.IsUserReviewerForPullRequest(ctx, queries.IsUserReviewerForPullRequestParams{
+	assigned, err := p.q(ctx).IsUserReviewerForPullRequest(ctx, queries.IsUserReviewerForPullRequestParams{
 		PullRequestID: prID,
 		ReviewerID:    reviewerID,
 	})
@@ -90,3 +270,48 @@ func (p *Postgres) IsReviewerAssignedToPR(ctx context.Context, prID, reviewerID
 
 	return assigned, nil
 }
+
+// CountOpenReviews returns the number of unmerged pull requests each reviewer is assigned to.
+// Reviewers without open reviews are present in the result with zero.
+func (p *Postgres) CountOpenReviews(ctx context.Context, reviewerIDs []string) (map[string]int, error) {
+	rows, err := p.q(ctx).CountOpenReviewsByReviewerIDs(ctx, reviewerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error counting open reviews: %w", err)
+	}
+
+	load := make(map[string]int, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		load[id] = 0
+	}
+	for _, r := range rows {
+		load[r.ReviewerID] = int(r.OpenReviews)
+	}
+	return load, nil
+}
+
+// AddReview stores the verdict of a reviewer and returns it with the submission time set
+func (p *Postgres) AddReview(ctx context.Context, review domain.Review) (domain.Review, error) {
+	r, err := p.q(ctx).AddPullRequestReview(ctx, queries.AddPullRequestReviewParams{
+		PullRequestID: review.PullRequestID,
+		ReviewerID:    review.ReviewerID,
+		Verdict:       string(review.Verdict),
+	})
+	if err != nil {
+		return domain.Review{}, fmt.Errorf("error adding review: %w", err)
+	}
+	return r.ToDomain(), nil
+}
+
+// GetReviewsByPRID returns the latest verdict of every reviewer currently assigned to the pull request
+func (p *Postgres) GetReviewsByPRID(ctx context.Context, prID string) ([]domain.Review, error) {
+	rows, err := p.q(ctx).GetLatestReviewsByPullRequestID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting reviews by PR ID: %w", err)
+	}
+
+	var reviews []domain.Review
+	for _, r := range rows {
+		reviews = append(reviews, r.ToDomain())
+	}
+	return reviews, nil
+}
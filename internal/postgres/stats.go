@@ -3,12 +3,16 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
+	"github.com/artmexbet/avito_test_task/internal/postgres/queries"
 	stats_retriever "github.com/artmexbet/avito_test_task/internal/stats-retriever"
 )
 
-func (p *Postgres) GetUserStats(ctx context.Context) ([]stats_retriever.UsersStats, error) {
-	res, err := p.queries.GetUsersCount(ctx)
+func (p *Postgres) GetUserStats(ctx context.Context, filter stats_retriever.Filter) ([]stats_retriever.UsersStats, error) {
+	res, err := p.q(ctx).GetUsersCount(ctx, optionalString(filter.TeamName))
 	if err != nil {
 		return nil, fmt.Errorf("GetUserStats: %w", err)
 	}
@@ -23,8 +27,8 @@ func (p *Postgres) GetUserStats(ctx context.Context) ([]stats_retriever.UsersSta
 	return userStats, nil
 }
 
-func (p *Postgres) GetTeamStats(ctx context.Context) ([]stats_retriever.TeamsStats, error) {
-	res, err := p.queries.GetTeamsCount(ctx)
+func (p *Postgres) GetTeamStats(ctx context.Context, filter stats_retriever.Filter) ([]stats_retriever.TeamsStats, error) {
+	res, err := p.q(ctx).GetTeamsCount(ctx, optionalString(filter.TeamName))
 	if err != nil {
 		return nil, fmt.Errorf("GetTeamStats: %w", err)
 	}
@@ -39,8 +43,11 @@ func (p *Postgres) GetTeamStats(ctx context.Context) ([]stats_retriever.TeamsSta
 	return teamStats, nil
 }
 
-func (p *Postgres) GetAssignmentStats(ctx context.Context) ([]stats_retriever.AssignmentStats, error) {
-	res, err := p.queries.GetAssignmentStats(ctx)
+func (p *Postgres) GetAssignmentStats(ctx context.Context, filter stats_retriever.Filter) ([]stats_retriever.AssignmentStats, error) {
+	res, err := p.q(ctx).GetAssignmentStats(ctx, queries.GetAssignmentStatsParams{
+		TeamName:   optionalString(filter.TeamName),
+		ReviewerID: optionalString(filter.UserID),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("GetAssignmentStats: %w", err)
 	}
@@ -55,3 +62,318 @@ func (p *Postgres) GetAssignmentStats(ctx context.Context) ([]stats_retriever.As
 	}
 	return assignStats, nil
 }
+
+func (p *Postgres) GetTimeToMerge(ctx context.Context, filter stats_retriever.Filter) (stats_retriever.TimeToMerge, error) {
+	res, err := p.q(ctx).GetTimeToMerge(ctx, queries.GetTimeToMergeParams{
+		FromTime: optionalTime(filter.From),
+		ToTime:   optionalTime(filter.To),
+		TeamName: optionalString(filter.TeamName),
+		AuthorID: optionalString(filter.UserID),
+	})
+	if err != nil {
+		return stats_retriever.TimeToMerge{}, fmt.Errorf("GetTimeToMerge: %w", err)
+	}
+
+	return stats_retriever.TimeToMerge{
+		MergedCount:   int(res.MergedCount),
+		MedianSeconds: res.MedianSeconds,
+		P90Seconds:    res.P90Seconds,
+	}, nil
+}
+
+func (p *Postgres) GetPullRequestsByPeriod(
+	ctx context.Context,
+	filter stats_retriever.Filter,
+) ([]stats_retriever.PullRequestsPeriodStats, error) {
+	res, err := p.q(ctx).GetPullRequestsByPeriod(ctx, queries.GetPullRequestsByPeriodParams{
+		TeamName: optionalString(filter.TeamName),
+		AuthorID: optionalString(filter.UserID),
+		Period:   string(filter.Period),
+		FromTime: optionalTime(filter.From),
+		ToTime:   optionalTime(filter.To),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetPullRequestsByPeriod: %w", err)
+	}
+
+	var prStats []stats_retriever.PullRequestsPeriodStats
+	for _, r := range res {
+		prStats = append(prStats, stats_retriever.PullRequestsPeriodStats{
+			PeriodStart: r.PeriodStart,
+			Opened:      int(r.Opened),
+			Merged:      int(r.Merged),
+		})
+	}
+	return prStats, nil
+}
+
+func (p *Postgres) GetAssignmentsByPeriod(
+	ctx context.Context,
+	filter stats_retriever.Filter,
+) ([]stats_retriever.AssignmentsPeriodStats, error) {
+	res, err := p.q(ctx).GetAssignmentsByPeriod(ctx, queries.GetAssignmentsByPeriodParams{
+		Period:     string(filter.Period),
+		FromTime:   optionalTime(filter.From),
+		ToTime:     optionalTime(filter.To),
+		TeamName:   optionalString(filter.TeamName),
+		ReviewerID: optionalString(filter.UserID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetAssignmentsByPeriod: %w", err)
+	}
+
+	var assignStats []stats_retriever.AssignmentsPeriodStats
+	for _, r := range res {
+		assignStats = append(assignStats, stats_retriever.AssignmentsPeriodStats{
+			PeriodStart: r.PeriodStart,
+			ReviewerID:  r.ReviewerID,
+			Assignments: int(r.Assignments),
+		})
+	}
+	return assignStats, nil
+}
+
+func (p *Postgres) GetTimeToFirstReview(
+	ctx context.Context,
+	filter stats_retriever.Filter,
+) (stats_retriever.TimeToFirstReview, error) {
+	res, err := p.q(ctx).GetTimeToFirstReview(ctx, queries.GetTimeToFirstReviewParams{
+		FromTime: optionalTime(filter.From),
+		ToTime:   optionalTime(filter.To),
+		TeamName: optionalString(filter.TeamName),
+		AuthorID: optionalString(filter.UserID),
+	})
+	if err != nil {
+		return stats_retriever.TimeToFirstReview{}, fmt.Errorf("GetTimeToFirstReview: %w", err)
+	}
+
+	return stats_retriever.TimeToFirstReview{
+		ReviewedCount: int(res.ReviewedCount),
+		MedianSeconds: res.MedianSeconds,
+		P90Seconds:    res.P90Seconds,
+	}, nil
+}
+
+func (p *Postgres) GetReviewerApprovalStats(
+	ctx context.Context,
+	filter stats_retriever.Filter,
+) ([]stats_retriever.ReviewerApprovalStats, error) {
+	res, err := p.q(ctx).GetReviewerApprovalStats(ctx, queries.GetReviewerApprovalStatsParams{
+		FromTime:   optionalTime(filter.From),
+		ToTime:     optionalTime(filter.To),
+		TeamName:   optionalString(filter.TeamName),
+		ReviewerID: optionalString(filter.UserID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetReviewerApprovalStats: %w", err)
+	}
+
+	var approvals []stats_retriever.ReviewerApprovalStats
+	for _, r := range res {
+		approvals = append(approvals, stats_retriever.NewReviewerApprovalStats(r.ReviewerID, int(r.Reviewed), int(r.Approved)))
+	}
+	return approvals, nil
+}
+
+// optionalString maps an empty filter value to NULL, which the queries treat as "no filter"
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// optionalTime maps a zero filter value to NULL, which the queries treat as "no filter"
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func (p *Postgres) CountTeams(ctx context.Context) (int, error) {
+	count, err := p.q(ctx).CountTeams(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("CountTeams: %w", err)
+	}
+	return int(count), nil
+}
+
+// reviewerStatsDelta accumulates changes of reviewer counters: assigned and open reviews by reviewer ID.
+// Rows are updated in a fixed order, reviewers first and their teams then, so concurrent
+// transactions wait for each other instead of deadlocking.
+type reviewerStatsDelta map[string][2]int64
+
+// add changes the number of reviews assigned to the reviewer by delta.
+// The open reviews counter is changed too if the pull request is open.
+func (d reviewerStatsDelta) add(reviewerID string, delta int64, open bool) {
+	v := d[reviewerID]
+	v[0] += delta
+	if open {
+		v[1] += delta
+	}
+	d[reviewerID] = v
+}
+
+// apply updates the counters of all the reviewers with one statement and the counters of their teams with another
+func (d reviewerStatsDelta) apply(ctx context.Context, q *queries.Queries) error {
+	var reviewers queries.AddReviewersStatsParams
+	for _, reviewerID := range slices.Sorted(maps.Keys(d)) {
+		v := d[reviewerID]
+		if v == [2]int64{} {
+			continue
+		}
+		reviewers.ReviewerIds = append(reviewers.ReviewerIds, reviewerID)
+		reviewers.AssignedDeltas = append(reviewers.AssignedDeltas, v[0])
+		reviewers.OpenDeltas = append(reviewers.OpenDeltas, v[1])
+	}
+	if len(reviewers.ReviewerIds) == 0 {
+		return nil
+	}
+	rows, err := q.AddReviewersStats(ctx, reviewers)
+	if err != nil {
+		return fmt.Errorf("error updating stats of reviewers: %w", err)
+	}
+
+	deltas := make(map[string]int64)
+	for _, r := range rows {
+		deltas[r.TeamName] += d[r.ReviewerID][0]
+	}
+	var teams queries.AddTeamsAssignedStatsParams
+	for _, teamName := range slices.Sorted(maps.Keys(deltas)) {
+		if deltas[teamName] == 0 {
+			continue
+		}
+		teams.TeamNames = append(teams.TeamNames, teamName)
+		teams.AssignedDeltas = append(teams.AssignedDeltas, deltas[teamName])
+	}
+	if len(teams.TeamNames) == 0 {
+		return nil
+	}
+	if err := q.AddTeamsAssignedStats(ctx, teams); err != nil {
+		return fmt.Errorf("error updating stats of teams: %w", err)
+	}
+	return nil
+}
+
+// shiftMemberStats keeps team counters in line with a created or updated user.
+// before is nil for a new user. When the user moves to another team, its assigned reviews move too.
+func shiftMemberStats(ctx context.Context, q *queries.Queries, before *queries.User, after queries.User) error {
+	if before != nil && before.TeamName == after.TeamName && before.IsActive == after.IsActive {
+		return nil
+	}
+
+	var assigned int64
+	if before != nil {
+		if before.TeamName != after.TeamName {
+			var err error
+			assigned, err = q.GetReviewerAssignedReviews(ctx, after.ID)
+			if err != nil {
+				return fmt.Errorf("error getting stats of user %s: %w", after.ID, err)
+			}
+		}
+		if err := addTeamStats(ctx, q, before.TeamName, before.IsActive, -1, -assigned); err != nil {
+			return err
+		}
+	}
+	return addTeamStats(ctx, q, after.TeamName, after.IsActive, 1, assigned)
+}
+
+// addTeamStats changes the number of active or inactive members of the team and its assigned reviews
+func addTeamStats(ctx context.Context, q *queries.Queries, teamName string, isActive bool, members, assigned int64) error {
+	params := queries.AddTeamStatsParams{
+		TeamName:      teamName,
+		AssignedDelta: assigned,
+	}
+	if isActive {
+		params.ActiveDelta = members
+	} else {
+		params.InactiveDelta = members
+	}
+	if err := q.AddTeamStats(ctx, params); err != nil {
+		return fmt.Errorf("error updating stats of team %s: %w", teamName, err)
+	}
+	return nil
+}
+
+// ReconcileStats rebuilds the stats counters from the source tables and reports how many of them had drifted.
+// Counters are locked for writing until the end, so concurrent assignments wait instead of being lost.
+func (p *Postgres) ReconcileStats(ctx context.Context) (stats_retriever.ReconcileResult, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return stats_retriever.ReconcileResult{}, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+	q := p.queries.WithTx(tx)
+
+	if err := q.LockStatsTables(ctx); err != nil {
+		return stats_retriever.ReconcileResult{}, fmt.Errorf("error locking stats: %w", err)
+	}
+	reviewersBefore, err := q.GetAllReviewerStats(ctx)
+	if err != nil {
+		return stats_retriever.ReconcileResult{}, fmt.Errorf("error getting reviewer stats: %w", err)
+	}
+	teamsBefore, err := q.GetAllTeamStats(ctx)
+	if err != nil {
+		return stats_retriever.ReconcileResult{}, fmt.Errorf("error getting team stats: %w", err)
+	}
+
+	if err := q.DeleteAllStats(ctx); err != nil {
+		return stats_retriever.ReconcileResult{}, fmt.Errorf("error deleting stats: %w", err)
+	}
+	// Команды считаются по уже пересобранным счётчикам ревьюверов
+	if err := q.RebuildReviewerStats(ctx); err != nil {
+		return stats_retriever.ReconcileResult{}, fmt.Errorf("error rebuilding reviewer stats: %w", err)
+	}
+	if err := q.RebuildTeamStats(ctx); err != nil {
+		return stats_retriever.ReconcileResult{}, fmt.Errorf("error rebuilding team stats: %w", err)
+	}
+
+	reviewersAfter, err := q.GetAllReviewerStats(ctx)
+	if err != nil {
+		return stats_retriever.ReconcileResult{}, fmt.Errorf("error getting reviewer stats: %w", err)
+	}
+	teamsAfter, err := q.GetAllTeamStats(ctx)
+	if err != nil {
+		return stats_retriever.ReconcileResult{}, fmt.Errorf("error getting team stats: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return stats_retriever.ReconcileResult{}, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return stats_retriever.ReconcileResult{
+		ReviewersFixed: countDrifted(reviewersBefore, reviewersAfter, func(s queries.ReviewerStat) (string, statCounters) {
+			return s.ReviewerID, statCounters{s.AssignedReviews, s.OpenReviews}
+		}),
+		TeamsFixed: countDrifted(teamsBefore, teamsAfter, func(s queries.TeamStat) (string, statCounters) {
+			return s.TeamName, statCounters{s.ActiveMembers, s.InactiveMembers, s.AssignedReviews}
+		}),
+	}, nil
+}
+
+type statCounters [3]int64
+
+// countDrifted returns the number of rows whose counters differ. A missing row equals a row of zeros.
+func countDrifted[T any](before, after []T, counters func(T) (string, statCounters)) int {
+	values := make(map[string]statCounters, len(before))
+	for _, s := range before {
+		key, v := counters(s)
+		values[key] = v
+	}
+
+	drifted := 0
+	for _, s := range after {
+		key, v := counters(s)
+		if values[key] != v {
+			drifted++
+		}
+		delete(values, key)
+	}
+	for _, v := range values {
+		if v != (statCounters{}) {
+			drifted++
+		}
+	}
+	return drifted
+}
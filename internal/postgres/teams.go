@@ -2,13 +2,21 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
 	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/internal/postgres/queries"
 )
 
+// sqlStateUniqueViolation is returned when the new name of the team is taken
+const sqlStateUniqueViolation = "23505"
+
 func (p *Postgres) GetTeamByName(ctx context.Context, teamName string) (domain.Team, error) {
-	team, err := p.queries.GetTeamByName(ctx, teamName)
+	team, err := p.q(ctx).GetTeamByName(ctx, teamName)
 	if err != nil {
 		return domain.Team{}, fmt.Errorf("failed to get team by name: %w", err)
 	}
@@ -16,7 +24,7 @@ func (p *Postgres) GetTeamByName(ctx context.Context, teamName string) (domain.T
 }
 
 func (p *Postgres) AddTeam(ctx context.Context, team domain.Team) (domain.Team, error) {
-	tx, err := p.pool.Begin(ctx)
+	tx, err := p.begin(ctx)
 	if err != nil {
 		return domain.Team{}, fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -37,9 +45,67 @@ func (p *Postgres) AddTeam(ctx context.Context, team domain.Team) (domain.Team,
 }
 
 func (p *Postgres) ExistsTeamByName(ctx context.Context, teamName string) (bool, error) {
-	exists, err := p.queries.ExistsTeamByName(ctx, teamName)
+	exists, err := p.q(ctx).ExistsTeamByName(ctx, teamName)
 	if err != nil {
 		return false, fmt.Errorf("failed to check if team exists by name: %w", err)
 	}
 	return exists, nil
 }
+
+// RenameTeam changes the name of the team. Members, stats and fallbacks of the team follow it by ON UPDATE CASCADE,
+// reviewers picked from the team and its round-robin cursor are relabeled explicitly
+func (p *Postgres) RenameTeam(ctx context.Context, teamName, newName string) (domain.Team, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return domain.Team{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck  // safe to call even after commit
+
+	q := p.queries.WithTx(tx)
+
+	team, err := q.RenameTeam(ctx, queries.RenameTeamParams{NewName: newName, Name: teamName})
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return domain.Team{}, fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotFound)
+	case errors.As(err, &pgErr) && pgErr.Code == sqlStateUniqueViolation:
+		return domain.Team{}, fmt.Errorf("team with name %s: %w", newName, domain.ErrTeamAlreadyExists)
+	case err != nil:
+		return domain.Team{}, fmt.Errorf("failed to rename team %s: %w", teamName, err)
+	}
+
+	err = q.RenameTeamReviewerPools(ctx, queries.RenameTeamReviewerPoolsParams{NewName: newName, Name: teamName})
+	if err != nil {
+		return domain.Team{}, fmt.Errorf("failed to relabel reviewers picked from team %s: %w", teamName, err)
+	}
+	err = q.RenameTeamReviewerCursor(ctx, queries.RenameTeamReviewerCursorParams{NewName: newName, Name: teamName})
+	if err != nil {
+		return domain.Team{}, fmt.Errorf("failed to relabel reviewer cursor of team %s: %w", teamName, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Team{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return team.ToDomain(), nil
+}
+
+// DeleteTeam deletes the team without members. Members would be deleted by cascade,
+// so they have to be moved to another team first
+func (p *Postgres) DeleteTeam(ctx context.Context, teamName string) error {
+	deleted, err := p.q(ctx).DeleteEmptyTeam(ctx, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to delete team %s: %w", teamName, err)
+	}
+	if deleted > 0 {
+		return nil
+	}
+
+	exists, err := p.q(ctx).ExistsTeamByName(ctx, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to check if team exists by name: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotFound)
+	}
+	return fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotEmpty)
+}
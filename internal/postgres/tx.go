@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/artmexbet/avito_test_task/internal/postgres/queries"
+)
+
+type txKey struct{}
+
+const (
+	// txMaxAttempts limits how many times a transaction is run when it fails with a serialization error
+	txMaxAttempts = 5
+	// txBaseBackoff is the delay before the first retry, it doubles with every next attempt
+	txBaseBackoff = 5 * time.Millisecond
+
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// WithinTransaction runs fn in a single transaction.
+// Every Postgres method called with the context passed to fn works inside this transaction,
+// so the whole group is committed or rolled back at once. Nested calls join the outer transaction.
+// Serialization failures and deadlocks are retried with exponential backoff, so fn must be safe to rerun.
+func (p *Postgres) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	return retryTransaction(ctx, txBaseBackoff, func() error {
+		return p.runTransaction(ctx, fn)
+	})
+}
+
+// retryTransaction runs the transaction until it succeeds, fails with an error that is not worth retrying
+// or txMaxAttempts runs are made. The delay between runs starts at backoff and doubles every time
+func retryTransaction(ctx context.Context, backoff time.Duration, run func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := run()
+		if err == nil || attempt == txMaxAttempts || !isSerializationFailure(err) {
+			return err
+		}
+
+		// Джиттер, чтобы конкурирующие транзакции не повторялись синхронно
+		delay := backoff + rand.N(backoff)
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+		backoff *= 2
+	}
+}
+
+func (p *Postgres) runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck  // safe to call even after commit
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+// isSerializationFailure reports whether the transaction failed because of a conflict with a concurrent one
+// and may succeed if run again.
+func isSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == sqlStateSerializationFailure || pgErr.Code == sqlStateDeadlockDetected
+}
+
+func txFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// begin starts a new transaction or a savepoint inside the transaction from ctx.
+func (p *Postgres) begin(ctx context.Context) (pgx.Tx, error) {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.Begin(ctx)
+	}
+	return p.pool.Begin(ctx)
+}
+
+// q returns queries bound to the transaction from ctx, if there is one.
+func (p *Postgres) q(ctx context.Context) *queries.Queries {
+	if tx, ok := txFromContext(ctx); ok {
+		return p.queries.WithTx(tx)
+	}
+	return p.queries
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/suite"
+)
+
+// TxTestSuite определяет test suite для повторов транзакций
+type TxTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+// SetupTest выполняется перед каждым тестом
+func (s *TxTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+// TestRetryTransaction проверяет, какие ошибки транзакции повторяются
+func (s *TxTestSuite) TestRetryTransaction() {
+	serializationFailure := fmt.Errorf("error updating pull request: %w",
+		&pgconn.PgError{Code: sqlStateSerializationFailure})
+	deadlock := &pgconn.PgError{Code: sqlStateDeadlockDetected}
+	otherErr := errors.New("database error")
+
+	tests := []struct {
+		name         string
+		errs         []error // ошибки запусков по порядку, после них запуск успешен
+		wantAttempts int
+		wantErr      error
+	}{
+		{
+			name:         "serialization failure retried",
+			errs:         []error{serializationFailure, serializationFailure},
+			wantAttempts: 3,
+		},
+		{
+			name:         "deadlock retried",
+			errs:         []error{deadlock},
+			wantAttempts: 2,
+		},
+		{
+			name:         "other error not retried",
+			errs:         []error{otherErr},
+			wantAttempts: 1,
+			wantErr:      otherErr,
+		},
+		{
+			name: "attempts exhausted",
+			errs: []error{
+				serializationFailure, serializationFailure, serializationFailure, serializationFailure,
+				serializationFailure,
+			},
+			wantAttempts: txMaxAttempts,
+			wantErr:      serializationFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			attempts := 0
+			err := retryTransaction(s.ctx, time.Microsecond, func() error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+				return nil
+			})
+
+			s.Equal(tt.wantAttempts, attempts)
+			if tt.wantErr != nil {
+				s.ErrorIs(err, tt.wantErr)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+// TestRetryTransactionCanceled проверяет, что отменённый контекст прерывает повторы
+func (s *TxTestSuite) TestRetryTransactionCanceled() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	cancel()
+
+	attempts := 0
+	err := retryTransaction(ctx, time.Hour, func() error {
+		attempts++
+		return &pgconn.PgError{Code: sqlStateSerializationFailure}
+	})
+
+	s.Equal(1, attempts)
+	s.ErrorIs(err, context.Canceled)
+}
+
+// TestTxSuite запускает test suite
+func TestTxSuite(t *testing.T) {
+	suite.Run(t, new(TxTestSuite))
+}
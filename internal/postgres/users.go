@@ -4,13 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/artmexbet/avito_test_task/internal/domain"
 	"github.com/artmexbet/avito_test_task/internal/postgres/queries"
 )
 
+// AddUsers inserts new users. An existing ID or username is reported as domain.ErrTeamMemberConflict
 func (p *Postgres) AddUsers(ctx context.Context, users []domain.User) ([]domain.User, error) {
-	tx, err := p.pool.Begin(ctx)
+	tx, err := p.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error starting transaction: %w", err)
 	}
@@ -20,60 +25,76 @@ func (p *Postgres) AddUsers(ctx context.Context, users []domain.User) ([]domain.
 	params := make([]queries.AddUsersParams, len(users))
 	for i, user := range users {
 		params[i] = queries.AddUsersParams{
-			ID:       user.ID,
-			Username: user.Username,
-			TeamName: user.TeamName,
-			IsActive: user.IsActive,
+			ID:             user.ID,
+			Username:       user.Username,
+			TeamName:       user.TeamName,
+			IsActive:       user.IsActive,
+			MaxOpenReviews: queries.MaxOpenReviewsParam(user.MaxOpenReviews),
 		}
 	}
 	br := q.AddUsers(ctx, params)
 	defer br.Close() //nolint:errcheck
 	addedUsers := make([]domain.User, len(users))
 	errs := make([]error, 0, len(users))
+	inserted := make([]queries.User, len(users))
 	br.QueryRow(func(i int, user queries.User, err error) {
+		inserted[i] = user
 		addedUsers[i] = user.ToDomain()
 		if err != nil {
 			errs = append(errs, err)
 		}
 	})
 	err = errors.Join(errs...)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == sqlStateUniqueViolation {
+		return nil, fmt.Errorf("error adding users: %w: %s", domain.ErrTeamMemberConflict, pgErr.ConstraintName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error adding users: %w", err)
 	}
+
+	for _, user := range inserted {
+		if err := shiftMemberStats(ctx, q, nil, user); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("error committing transaction: %w", err)
 	}
 	return addedUsers, nil
 }
 
-func (p *Postgres) BatchExistsUserByID(ctx context.Context, users []domain.User) map[domain.User]bool {
-	params := make([]string, len(users))
-	for i, user := range users {
-		params[i] = user.ID
-	}
-	br := p.queries.BatchExistsUserByID(ctx, params)
-	defer br.Close() //nolint:errcheck
-	existsMap := make(map[domain.User]bool, len(users))
-	br.QueryRow(func(i int, exists bool, _ error) {
-		existsMap[users[i]] = exists
-	})
-	return existsMap
-}
-
 func (p *Postgres) ExistsUserByID(ctx context.Context, userID string) (bool, error) {
-	return p.queries.ExistsUserByID(ctx, userID)
+	return p.q(ctx).ExistsUserByID(ctx, userID)
 }
 
 func (p *Postgres) GetUserByID(ctx context.Context, userID string) (domain.User, error) {
-	user, err := p.queries.GetUserByID(ctx, userID)
+	user, err := p.q(ctx).GetUserByID(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.User{}, fmt.Errorf("user with ID %s: %w", userID, domain.ErrUserNotFound)
+	}
 	if err != nil {
 		return domain.User{}, err
 	}
 	return user.ToDomain(), nil
 }
 
+// GetUsersByIDs returns the existing users among the given IDs ordered by ID
+func (p *Postgres) GetUsersByIDs(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	users, err := p.q(ctx).GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	domainUsers := make([]domain.User, len(users))
+	for i, user := range users {
+		domainUsers[i] = user.ToDomain()
+	}
+	return domainUsers, nil
+}
+
 func (p *Postgres) GetUsersByTeamName(ctx context.Context, teamName string) ([]domain.User, error) {
-	users, err := p.queries.GetUsersByTeamName(ctx, teamName)
+	users, err := p.q(ctx).GetUsersByTeamName(ctx, teamName)
 	if err != nil {
 		return nil, err
 	}
@@ -85,13 +106,18 @@ func (p *Postgres) GetUsersByTeamName(ctx context.Context, teamName string) ([]d
 }
 
 func (p *Postgres) SetUserIsActive(ctx context.Context, userID string, isActive bool) (domain.User, error) {
-	tx, err := p.pool.Begin(ctx)
+	tx, err := p.begin(ctx)
 	if err != nil {
 		return domain.User{}, fmt.Errorf("error starting transaction: %w", err)
 	}
 	defer tx.Rollback(ctx) //nolint:errcheck  // safe to call even after commit
 	q := p.queries.WithTx(tx)
 
+	locked, err := q.LockUsersByIDs(ctx, []string{userID})
+	if err != nil {
+		return domain.User{}, fmt.Errorf("error locking user %s: %w", userID, err)
+	}
+
 	user, err := q.SetUserIsActiveByID(ctx, queries.SetUserIsActiveByIDParams{
 		ID:       userID,
 		IsActive: isActive,
@@ -99,6 +125,11 @@ func (p *Postgres) SetUserIsActive(ctx context.Context, userID string, isActive
 	if err != nil {
 		return domain.User{}, fmt.Errorf("error setting user %s is_active: %w", userID, err)
 	}
+	if len(locked) > 0 {
+		if err := shiftMemberStats(ctx, q, &locked[0], user); err != nil {
+			return domain.User{}, err
+		}
+	}
 
 	if err := tx.Commit(ctx); err != nil {
 		return domain.User{}, fmt.Errorf("error committing transaction: %w", err)
@@ -106,8 +137,23 @@ func (p *Postgres) SetUserIsActive(ctx context.Context, userID string, isActive
 	return user.ToDomain(), nil
 }
 
+// SetUserMaxOpenReviews sets the review limit of the user, 0 removes the limit
+func (p *Postgres) SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error) {
+	user, err := p.q(ctx).SetUserMaxOpenReviewsByID(ctx, queries.SetUserMaxOpenReviewsByIDParams{
+		ID:             userID,
+		MaxOpenReviews: queries.MaxOpenReviewsParam(maxOpenReviews),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.User{}, fmt.Errorf("user with ID %s: %w", userID, domain.ErrUserNotFound)
+	}
+	if err != nil {
+		return domain.User{}, fmt.Errorf("error setting max open reviews of user %s: %w", userID, err)
+	}
+	return user.ToDomain(), nil
+}
+
 func (p *Postgres) GetActiveUsersByTeamName(ctx context.Context, teamName string) ([]domain.User, error) {
-	users, err := p.queries.GetActiveUsersByTeamName(ctx, teamName)
+	users, err := p.q(ctx).GetActiveUsersByTeamName(ctx, teamName)
 	if err != nil {
 		return nil, err
 	}
@@ -117,3 +163,221 @@ func (p *Postgres) GetActiveUsersByTeamName(ctx context.Context, teamName string
 	}
 	return domainUsers, nil
 }
+
+// DeactivateTeamUsers deactivates users of the team (all of them if userIDs is empty) in a single transaction.
+// Only the users and the team counters are changed here, with the same three queries whatever the number of users.
+// Their open reviews are left to the caller, it hands them over with HandOverReviewers
+func (p *Postgres) DeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck  // safe to call even after commit
+	q := p.queries.WithTx(tx)
+
+	if userIDs == nil {
+		userIDs = []string{}
+	}
+	members, err := q.LockUsersByTeamName(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("error locking users of team %s: %w", teamName, err)
+	}
+	wasActive := make(map[string]bool, len(members))
+	for _, u := range members {
+		wasActive[u.ID] = u.IsActive
+	}
+
+	dbUsers, err := q.DeactivateTeamUsers(ctx, queries.DeactivateTeamUsersParams{
+		TeamName: teamName,
+		UserIds:  userIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error deactivating users of team %s: %w", teamName, err)
+	}
+	if len(userIDs) > 0 && len(dbUsers) != len(uniqueStrings(userIDs)) {
+		return nil, fmt.Errorf("some users are not members of team %s: %w", teamName, domain.ErrUserNotFound)
+	}
+
+	deactivated := make([]domain.User, len(dbUsers))
+	var newlyInactive int64
+	for i, u := range dbUsers {
+		deactivated[i] = u.ToDomain()
+		if wasActive[u.ID] {
+			newlyInactive++
+		}
+	}
+	if newlyInactive > 0 {
+		err := q.AddTeamStats(ctx, queries.AddTeamStatsParams{
+			TeamName:      teamName,
+			ActiveDelta:   -newlyInactive,
+			InactiveDelta: newlyInactive,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error updating stats of team %s: %w", teamName, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+	return deactivated, nil
+}
+
+// MoveUsersToTeam moves the users to the team. Their open reviews are left to the caller
+func (p *Postgres) MoveUsersToTeam(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck  // safe to call even after commit
+	q := p.queries.WithTx(tx)
+
+	ids := uniqueStrings(userIDs)
+	locked, err := q.LockUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error locking users: %w", err)
+	}
+	if len(locked) != len(ids) {
+		return nil, fmt.Errorf("some users to move to team %s: %w", teamName, domain.ErrUserNotFound)
+	}
+
+	dbUsers, err := q.MoveUsersToTeam(ctx, queries.MoveUsersToTeamParams{TeamName: teamName, Ids: ids})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == sqlStateForeignKeyViolation {
+		return nil, fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error moving users to team %s: %w", teamName, err)
+	}
+	after := make(map[string]queries.User, len(dbUsers))
+	for _, user := range dbUsers {
+		after[user.ID] = user
+	}
+
+	// счётчики сдвигаем в порядке блокировки
+	moved := make([]domain.User, 0, len(locked))
+	for _, before := range locked {
+		user := after[before.ID]
+		if err := shiftMemberStats(ctx, q, &before, user); err != nil {
+			return nil, err
+		}
+		moved = append(moved, user.ToDomain())
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+	return moved, nil
+}
+
+// MoveDeletedUsersToTeam moves deleted users of the team to another team. Their reviews were handed over
+// on deletion, so only the stats counters follow them
+func (p *Postgres) MoveDeletedUsersToTeam(ctx context.Context, teamName, newTeamName string) ([]domain.User, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck  // safe to call even after commit
+	q := p.queries.WithTx(tx)
+
+	members, err := q.LockUsersByTeamName(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("error locking users of team %s: %w", teamName, err)
+	}
+	var deleted []queries.User
+	for _, user := range members {
+		if user.DeletedAt != nil {
+			deleted = append(deleted, user)
+		}
+	}
+	if len(deleted) == 0 {
+		return []domain.User{}, nil
+	}
+
+	ids := make([]string, len(deleted))
+	for i, user := range deleted {
+		ids[i] = user.ID
+	}
+	dbUsers, err := q.MoveUsersToTeam(ctx, queries.MoveUsersToTeamParams{TeamName: newTeamName, Ids: ids})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == sqlStateForeignKeyViolation {
+		return nil, fmt.Errorf("team with name %s: %w", newTeamName, domain.ErrTeamNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error moving deleted users to team %s: %w", newTeamName, err)
+	}
+	after := make(map[string]queries.User, len(dbUsers))
+	for _, user := range dbUsers {
+		after[user.ID] = user
+	}
+
+	moved := make([]domain.User, 0, len(deleted))
+	for _, before := range deleted {
+		user := after[before.ID]
+		if err := shiftMemberStats(ctx, q, &before, user); err != nil {
+			return nil, err
+		}
+		moved = append(moved, user.ToDomain())
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+	return moved, nil
+}
+
+// ListUsers returns users matching the filter ordered by ID, filter.Limit of them at most
+func (p *Postgres) ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	users, err := p.q(ctx).ListUsers(ctx, queries.ListUsersParams{
+		TeamName:       optionalString(filter.TeamName),
+		IsActive:       filter.IsActive,
+		UsernamePrefix: optionalString(filter.UsernamePrefix),
+		AfterID:        optionalString(filter.AfterID),
+		PageSize:       int32(filter.Limit), //nolint:gosec // Размер страницы ограничен сервисом
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing users: %w", err)
+	}
+	domainUsers := make([]domain.User, len(users))
+	for i, user := range users {
+		domainUsers[i] = user.ToDomain()
+	}
+	return domainUsers, nil
+}
+
+// SoftDeleteUser marks the user as deleted and inactive. The row stays, so pull requests and reviews keep it
+func (p *Postgres) SoftDeleteUser(ctx context.Context, userID string) (domain.User, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck  // safe to call even after commit
+	q := p.queries.WithTx(tx)
+
+	locked, err := q.LockUsersByIDs(ctx, []string{userID})
+	if err != nil {
+		return domain.User{}, fmt.Errorf("error locking user %s: %w", userID, err)
+	}
+	user, err := q.SoftDeleteUser(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.User{}, fmt.Errorf("user with ID %s: %w", userID, domain.ErrUserNotFound)
+	}
+	if err != nil {
+		return domain.User{}, fmt.Errorf("error deleting user %s: %w", userID, err)
+	}
+	// удалённый считается неактивным, в счётчиках команды он переезжает в неактивные
+	if err := shiftMemberStats(ctx, q, &locked[0], user); err != nil {
+		return domain.User{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.User{}, fmt.Errorf("error committing transaction: %w", err)
+	}
+	return user.ToDomain(), nil
+}
+
+func uniqueStrings(values []string) []string {
+	res := slices.Clone(values)
+	slices.Sort(res)
+	return slices.Compact(res)
+}
@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/internal/postgres/queries"
+)
+
+// AddOutboxEvent stores the event in the outbox. Called inside a transaction, it is committed with the mutation
+func (p *Postgres) AddOutboxEvent(ctx context.Context, event domain.OutboxEvent) (domain.OutboxEvent, error) {
+	added, err := p.q(ctx).AddOutboxEvent(ctx, queries.AddOutboxEventParams{
+		EventType: string(event.Type),
+		Payload:   event.Payload,
+	})
+	if err != nil {
+		return domain.OutboxEvent{}, fmt.Errorf("error adding outbox event: %w", err)
+	}
+	return added.ToDomain(), nil
+}
+
+// AddOutboxEvents stores the events in the outbox with one round trip
+func (p *Postgres) AddOutboxEvents(ctx context.Context, events []domain.OutboxEvent) error {
+	params := make([]queries.AddOutboxEventsParams, len(events))
+	for i, event := range events {
+		params[i] = queries.AddOutboxEventsParams{
+			EventType: string(event.Type),
+			Payload:   event.Payload,
+		}
+	}
+	br := p.q(ctx).AddOutboxEvents(ctx, params)
+	defer br.Close() //nolint:errcheck
+
+	errs := make([]error, 0, len(events))
+	br.Exec(func(_ int, err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("error adding outbox events: %w", err)
+	}
+	return nil
+}
+
+func (p *Postgres) AddWebhookSubscription(
+	ctx context.Context,
+	subscription domain.WebhookSubscription,
+) (domain.WebhookSubscription, error) {
+	eventTypes := make([]string, len(subscription.EventTypes))
+	for i, eventType := range subscription.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+	created, err := p.q(ctx).CreateWebhookSubscription(ctx, queries.CreateWebhookSubscriptionParams{
+		Url:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("error creating webhook subscription: %w", err)
+	}
+	return created.ToDomain(), nil
+}
+
+func (p *Postgres) ListWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	subscriptions, err := p.q(ctx).ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing webhook subscriptions: %w", err)
+	}
+	result := make([]domain.WebhookSubscription, len(subscriptions))
+	for i, subscription := range subscriptions {
+		result[i] = subscription.ToDomain()
+	}
+	return result, nil
+}
+
+// DeleteWebhookSubscription removes the subscription along with its deliveries
+func (p *Postgres) DeleteWebhookSubscription(ctx context.Context, id int64) error {
+	deleted, err := p.q(ctx).DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error deleting webhook subscription %d: %w", id, err)
+	}
+	if deleted == 0 {
+		return domain.ErrWebhookSubscriptionNotFound
+	}
+	return nil
+}
+
+// FanOutOutboxEvents creates deliveries of up to batchSize new outbox events to the subscribers of their types.
+// Returns the number of events processed
+func (p *Postgres) FanOutOutboxEvents(ctx context.Context, batchSize int) (int, error) {
+	//nolint:gosec // Размер пачки задаётся конфигом
+	fannedOut, err := p.q(ctx).FanOutOutboxEvents(ctx, int32(batchSize))
+	if err != nil {
+		return 0, fmt.Errorf("error fanning out outbox events: %w", err)
+	}
+	return int(fannedOut), nil
+}
+
+// ClaimWebhookDeliveries takes up to batchSize pending deliveries due at now and hides them from other
+// dispatchers until leaseUntil
+func (p *Postgres) ClaimWebhookDeliveries(
+	ctx context.Context,
+	now, leaseUntil time.Time,
+	batchSize int,
+) ([]domain.ClaimedDelivery, error) {
+	claimed, err := p.q(ctx).ClaimWebhookDeliveries(ctx, queries.ClaimWebhookDeliveriesParams{
+		Now:        now,
+		BatchSize:  int32(batchSize), //nolint:gosec // Размер пачки задаётся конфигом
+		LeaseUntil: leaseUntil,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error claiming webhook deliveries: %w", err)
+	}
+	result := make([]domain.ClaimedDelivery, len(claimed))
+	for i, delivery := range claimed {
+		result[i] = delivery.ToDomain()
+	}
+	return result, nil
+}
+
+func (p *Postgres) CompleteWebhookDelivery(ctx context.Context, id int64) error {
+	if err := p.q(ctx).CompleteWebhookDelivery(ctx, id); err != nil {
+		return fmt.Errorf("error completing webhook delivery %d: %w", id, err)
+	}
+	return nil
+}
+
+// FailWebhookDelivery counts a failed attempt and moves the delivery to status, retried at nextAttemptAt if PENDING
+func (p *Postgres) FailWebhookDelivery(
+	ctx context.Context,
+	id int64,
+	status domain.DeliveryStatus,
+	nextAttemptAt time.Time,
+	lastError string,
+) error {
+	err := p.q(ctx).FailWebhookDelivery(ctx, queries.FailWebhookDeliveryParams{
+		Status:        string(status),
+		NextAttemptAt: nextAttemptAt,
+		LastError:     lastError,
+		ID:            id,
+	})
+	if err != nil {
+		return fmt.Errorf("error failing webhook delivery %d: %w", id, err)
+	}
+	return nil
+}
+
+func (p *Postgres) ListWebhookDeliveries(
+	ctx context.Context,
+	filter domain.WebhookDeliveryFilter,
+) ([]domain.WebhookDelivery, error) {
+	params := queries.ListWebhookDeliveriesParams{
+		SubscriptionID: nil,
+		Status:         optionalString(string(filter.Status)),
+		AfterID:        nil,
+		PageSize:       int32(filter.Limit), //nolint:gosec // Размер страницы ограничен сервисом
+	}
+	if filter.SubscriptionID != 0 {
+		params.SubscriptionID = &filter.SubscriptionID
+	}
+	if filter.AfterID != 0 {
+		params.AfterID = &filter.AfterID
+	}
+
+	deliveries, err := p.q(ctx).ListWebhookDeliveries(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error listing webhook deliveries: %w", err)
+	}
+	result := make([]domain.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		result[i] = delivery.ToDomain()
+	}
+	return result, nil
+}
+
+// RetryWebhookDelivery puts a DEAD delivery back to the queue with a fresh attempt counter
+func (p *Postgres) RetryWebhookDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	delivery, err := p.q(ctx).RetryWebhookDelivery(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return domain.WebhookDelivery{}, fmt.Errorf("error retrying webhook delivery %d: %w", id, err)
+	}
+	return delivery.ToDomain(), nil
+}
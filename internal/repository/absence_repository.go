@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+type iAbsencePostgres interface {
+	AddAbsence(ctx context.Context, absence domain.Absence) (domain.Absence, error)
+	GetAbsence(ctx context.Context, id int64) (domain.Absence, error)
+	UpdateAbsence(ctx context.Context, absence domain.Absence) (domain.Absence, error)
+	DeleteAbsence(ctx context.Context, id int64) error
+	ListAbsences(ctx context.Context, filter domain.AbsenceFilter) ([]domain.Absence, error)
+	ClaimStartedAbsences(ctx context.Context, now time.Time, batchSize int) ([]domain.Absence, error)
+}
+
+// AbsenceRepository struct for store interactions related to absences of users
+type AbsenceRepository struct {
+	postgres iAbsencePostgres
+}
+
+func NewAbsenceRepository(postgres iAbsencePostgres) *AbsenceRepository {
+	return &AbsenceRepository{postgres: postgres}
+}
+
+// Add stores a new absence of the user
+func (r *AbsenceRepository) Add(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	return r.postgres.AddAbsence(ctx, absence)
+}
+
+// Get retrieves the absence by its ID
+func (r *AbsenceRepository) Get(ctx context.Context, id int64) (domain.Absence, error) {
+	return r.postgres.GetAbsence(ctx, id)
+}
+
+// Update changes the period and the reason of the absence
+func (r *AbsenceRepository) Update(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	return r.postgres.UpdateAbsence(ctx, absence)
+}
+
+// Delete removes the absence
+func (r *AbsenceRepository) Delete(ctx context.Context, id int64) error {
+	return r.postgres.DeleteAbsence(ctx, id)
+}
+
+// List retrieves the absences matching the filter
+func (r *AbsenceRepository) List(ctx context.Context, filter domain.AbsenceFilter) ([]domain.Absence, error) {
+	return r.postgres.ListAbsences(ctx, filter)
+}
+
+// ClaimStarted marks up to batchSize absences going on at now as handed over and returns them
+func (r *AbsenceRepository) ClaimStarted(ctx context.Context, now time.Time, batchSize int) ([]domain.Absence, error) {
+	return r.postgres.ClaimStartedAbsences(ctx, now, batchSize)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+type iAuditPostgres interface {
+	AddAuditEvent(ctx context.Context, event domain.AuditEvent) (domain.AuditEvent, error)
+	AddAuditEvents(ctx context.Context, events []domain.AuditEvent) error
+	ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error)
+}
+
+// AuditRepository struct for store interactions related to the audit log
+type AuditRepository struct {
+	postgres iAuditPostgres
+}
+
+func NewAuditRepository(postgres iAuditPostgres) *AuditRepository {
+	return &AuditRepository{postgres: postgres}
+}
+
+// Add appends an event to the audit log
+func (r *AuditRepository) Add(ctx context.Context, event domain.AuditEvent) (domain.AuditEvent, error) {
+	return r.postgres.AddAuditEvent(ctx, event)
+}
+
+// AddBatch appends events to the audit log at once
+func (r *AuditRepository) AddBatch(ctx context.Context, events []domain.AuditEvent) error {
+	return r.postgres.AddAuditEvents(ctx, events)
+}
+
+// List retrieves a page of audit events matching the filter, newest first
+func (r *AuditRepository) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	return r.postgres.ListAuditEvents(ctx, filter)
+}
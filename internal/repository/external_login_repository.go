@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+type iExternalLoginPostgres interface {
+	SetExternalLogin(ctx context.Context, login domain.ExternalLogin) (domain.ExternalLogin, error)
+	GetExternalLogin(ctx context.Context, provider domain.ExternalProvider, login string) (domain.ExternalLogin, error)
+	ListExternalLogins(ctx context.Context, provider domain.ExternalProvider) ([]domain.ExternalLogin, error)
+	DeleteExternalLogin(ctx context.Context, provider domain.ExternalProvider, login string) error
+}
+
+// ExternalLoginRepository struct for store interactions related to the mapping of provider logins onto users
+type ExternalLoginRepository struct {
+	postgres iExternalLoginPostgres
+}
+
+func NewExternalLoginRepository(postgres iExternalLoginPostgres) *ExternalLoginRepository {
+	return &ExternalLoginRepository{postgres: postgres}
+}
+
+// Set maps the login onto the user, replacing the previous mapping of the login
+func (r *ExternalLoginRepository) Set(ctx context.Context, login domain.ExternalLogin) (domain.ExternalLogin, error) {
+	return r.postgres.SetExternalLogin(ctx, login)
+}
+
+// Get retrieves the mapping of the login
+func (r *ExternalLoginRepository) Get(
+	ctx context.Context,
+	provider domain.ExternalProvider,
+	login string,
+) (domain.ExternalLogin, error) {
+	return r.postgres.GetExternalLogin(ctx, provider, login)
+}
+
+// List returns the mappings of the provider, or of all providers if it is empty
+func (r *ExternalLoginRepository) List(
+	ctx context.Context,
+	provider domain.ExternalProvider,
+) ([]domain.ExternalLogin, error) {
+	return r.postgres.ListExternalLogins(ctx, provider)
+}
+
+// Delete removes the mapping of the login
+func (r *ExternalLoginRepository) Delete(ctx context.Context, provider domain.ExternalProvider, login string) error {
+	return r.postgres.DeleteExternalLogin(ctx, provider, login)
+}
@@ -10,9 +10,20 @@ import (
 type iPRPostgres interface {
 	CreatePullRequest(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error)
 	GetPullRequestByID(ctx context.Context, prID string) (domain.PullRequest, error)
+	LockPullRequest(ctx context.Context, prID string) (domain.PullRequest, error)
 	MergePullRequest(ctx context.Context, prID string) (domain.PullRequest, error)
+	SetPullRequestStatus(ctx context.Context, prID string, status domain.PRStatus) (domain.PullRequest, error)
 	ExistsPullRequest(ctx context.Context, prID string) (bool, error)
 	GetReviewersByPRID(ctx context.Context, prID string) ([]domain.User, error)
+	GetReviewerPoolsByPRID(ctx context.Context, prID string) (map[string]domain.ReviewerPool, error)
+	SetPullRequestNeedMoreReviewers(ctx context.Context, prID string, needMore bool) error
+	GetOpenPullRequestsNeedingReviewers(ctx context.Context, teamName string) ([]domain.PullRequest, error)
+	GetOpenPullRequestsByReviewerIDs(
+		ctx context.Context,
+		reviewerIDs []string,
+		authorTeam string,
+	) ([]domain.PullRequest, error)
+	ListPullRequests(ctx context.Context, filter domain.PullRequestFilter) ([]domain.PullRequest, error)
 }
 
 // PRRepository struct for store interactions related to pull requests
@@ -35,9 +46,21 @@ func (r *PRRepository) GetByID(ctx context.Context, prID string) (domain.PullReq
 	if err != nil {
 		return domain.PullRequest{}, fmt.Errorf("error getting pull request by ID: %w", err)
 	}
-	pr.Reviewers, err = r.postgres.GetReviewersByPRID(ctx, prID)
+	if err := r.withReviewers(ctx, &pr); err != nil {
+		return domain.PullRequest{}, err
+	}
+	return pr, nil
+}
+
+// GetByIDForUpdate retrieves a pull request by its ID and locks it until the end of the transaction.
+// Must be called inside Transactor.WithinTransaction
+func (r *PRRepository) GetByIDForUpdate(ctx context.Context, prID string) (domain.PullRequest, error) {
+	pr, err := r.postgres.LockPullRequest(ctx, prID)
 	if err != nil {
-		return domain.PullRequest{}, fmt.Errorf("error getting pull request reviewers: %w", err)
+		return domain.PullRequest{}, fmt.Errorf("error locking pull request: %w", err)
+	}
+	if err := r.withReviewers(ctx, &pr); err != nil {
+		return domain.PullRequest{}, err
 	}
 	return pr, nil
 }
@@ -47,7 +70,52 @@ func (r *PRRepository) Merge(ctx context.Context, prID string) (domain.PullReque
 	return r.postgres.MergePullRequest(ctx, prID)
 }
 
+// SetStatus moves a pull request to the status, releasing its reviewers if it is no longer open
+func (r *PRRepository) SetStatus(ctx context.Context, prID string, status domain.PRStatus) (domain.PullRequest, error) {
+	return r.postgres.SetPullRequestStatus(ctx, prID, status)
+}
+
 // Exists checks if a pull request with the given ID exists
 func (r *PRRepository) Exists(ctx context.Context, prID string) (bool, error) {
 	return r.postgres.ExistsPullRequest(ctx, prID)
 }
+
+// SetNeedMoreReviewers updates the flag telling that the pull request lacks reviewers
+func (r *PRRepository) SetNeedMoreReviewers(ctx context.Context, prID string, needMore bool) error {
+	return r.postgres.SetPullRequestNeedMoreReviewers(ctx, prID, needMore)
+}
+
+// GetNeedingReviewers retrieves open pull requests of the team that lack reviewers, along with their reviewers
+func (r *PRRepository) GetNeedingReviewers(ctx context.Context, teamName string) ([]domain.PullRequest, error) {
+	return r.postgres.GetOpenPullRequestsNeedingReviewers(ctx, teamName)
+}
+
+// GetOpenByReviewerIDs retrieves open pull requests any of the reviewers is assigned to, along with their reviewers.
+// With authorTeam set only pull requests of its members are returned. The pull requests stay locked
+// until the end of the transaction
+func (r *PRRepository) GetOpenByReviewerIDs(
+	ctx context.Context,
+	reviewerIDs []string,
+	authorTeam string,
+) ([]domain.PullRequest, error) {
+	return r.postgres.GetOpenPullRequestsByReviewerIDs(ctx, reviewerIDs, authorTeam)
+}
+
+// withReviewers fills the reviewers of the pull request and the pools they were picked from
+func (r *PRRepository) withReviewers(ctx context.Context, pr *domain.PullRequest) error {
+	var err error
+	pr.Reviewers, err = r.postgres.GetReviewersByPRID(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("error getting pull request reviewers: %w", err)
+	}
+	pr.ReviewerPools, err = r.postgres.GetReviewerPoolsByPRID(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("error getting pull request reviewer pools: %w", err)
+	}
+	return nil
+}
+
+// List retrieves pull requests matching the filter along with their reviewers, at most filter.Limit of them
+func (r *PRRepository) List(ctx context.Context, filter domain.PullRequestFilter) ([]domain.PullRequest, error) {
+	return r.postgres.ListPullRequests(ctx, filter)
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+type iReviewerPoolPostgres interface {
+	SetReviewerPool(ctx context.Context, poolName string, userIDs []string) (domain.SharedReviewerPool, error)
+	GetReviewerPool(ctx context.Context, poolName string) (domain.SharedReviewerPool, error)
+	DeleteReviewerPool(ctx context.Context, poolName string) error
+	GetActiveReviewerPoolMembers(ctx context.Context, poolName string) ([]domain.User, error)
+	GetTeamFallbacks(ctx context.Context, teamName string) ([]domain.ReviewerPool, error)
+	SetTeamFallbacks(ctx context.Context, teamName string, fallbacks []domain.ReviewerPool) error
+	GetTeamsFallingBackTo(ctx context.Context, teamName string) ([]string, error)
+	LockReviewerCursor(ctx context.Context, pool domain.ReviewerPool) (string, error)
+	SetReviewerCursor(ctx context.Context, pool domain.ReviewerPool, lastReviewerID string) error
+}
+
+// ReviewerPoolRepository struct for store interactions related to shared reviewer pools and team fallbacks
+type ReviewerPoolRepository struct {
+	postgres iReviewerPoolPostgres
+}
+
+func NewReviewerPoolRepository(postgres iReviewerPoolPostgres) *ReviewerPoolRepository {
+	return &ReviewerPoolRepository{postgres: postgres}
+}
+
+// Set creates the shared reviewer pool or replaces the members of the existing one
+func (r *ReviewerPoolRepository) Set(
+	ctx context.Context,
+	poolName string,
+	userIDs []string,
+) (domain.SharedReviewerPool, error) {
+	return r.postgres.SetReviewerPool(ctx, poolName, userIDs)
+}
+
+// Get retrieves the shared reviewer pool with its members
+func (r *ReviewerPoolRepository) Get(ctx context.Context, poolName string) (domain.SharedReviewerPool, error) {
+	return r.postgres.GetReviewerPool(ctx, poolName)
+}
+
+// Delete deletes the shared reviewer pool, teams falling back to it lose it
+func (r *ReviewerPoolRepository) Delete(ctx context.Context, poolName string) error {
+	return r.postgres.DeleteReviewerPool(ctx, poolName)
+}
+
+// GetActiveMembers retrieves the members of the shared pool who may review right now
+func (r *ReviewerPoolRepository) GetActiveMembers(ctx context.Context, poolName string) ([]domain.User, error) {
+	return r.postgres.GetActiveReviewerPoolMembers(ctx, poolName)
+}
+
+// GetTeamFallbacks retrieves the fallback pools of the team in order
+func (r *ReviewerPoolRepository) GetTeamFallbacks(ctx context.Context, teamName string) ([]domain.ReviewerPool, error) {
+	return r.postgres.GetTeamFallbacks(ctx, teamName)
+}
+
+// GetTeamsFallingBackTo retrieves the names of the teams whose fallbacks include the team
+// or a shared pool with its members
+func (r *ReviewerPoolRepository) GetTeamsFallingBackTo(ctx context.Context, teamName string) ([]string, error) {
+	return r.postgres.GetTeamsFallingBackTo(ctx, teamName)
+}
+
+// SetTeamFallbacks replaces the fallback pools of the team
+func (r *ReviewerPoolRepository) SetTeamFallbacks(
+	ctx context.Context,
+	teamName string,
+	fallbacks []domain.ReviewerPool,
+) error {
+	return r.postgres.SetTeamFallbacks(ctx, teamName, fallbacks)
+}
+
+// LockCursor retrieves the ID of the member of the pool picked last in turn, locked till the end of the transaction
+func (r *ReviewerPoolRepository) LockCursor(ctx context.Context, pool domain.ReviewerPool) (string, error) {
+	return r.postgres.LockReviewerCursor(ctx, pool)
+}
+
+// SetCursor remembers the member of the pool picked last in turn
+func (r *ReviewerPoolRepository) SetCursor(ctx context.Context, pool domain.ReviewerPool, lastReviewerID string) error {
+	return r.postgres.SetReviewerCursor(ctx, pool, lastReviewerID)
+}
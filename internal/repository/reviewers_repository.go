@@ -7,11 +7,17 @@ import (
 )
 
 type iReviewersPostgres interface {
-	AssignReviewersToPR(ctx context.Context, prID string, reviewerIDs []string) error
+	AssignReviewersToPR(ctx context.Context, prID string, pool domain.ReviewerPool, reviewerIDs []string) error
 	GetReviewersByPRID(ctx context.Context, prID string) ([]domain.User, error)
-	ReassignReviewer(ctx context.Context, prID, newReviewerID, oldReviewerID string) error
+	GetReviewerPoolsByPRID(ctx context.Context, prID string) (map[string]domain.ReviewerPool, error)
+	ReassignReviewer(ctx context.Context, prID string, pool domain.ReviewerPool, newReviewerID, oldReviewerID string) error
+	UnassignReviewer(ctx context.Context, prID, reviewerID string) error
+	HandOverReviewers(ctx context.Context, handovers []domain.ReviewerHandover) error
 	GetUsersReviewingPR(ctx context.Context, userID string) ([]domain.PullRequest, error)
 	IsReviewerAssignedToPR(ctx context.Context, prID, reviewerID string) (bool, error)
+	CountOpenReviews(ctx context.Context, reviewerIDs []string) (map[string]int, error)
+	AddReview(ctx context.Context, review domain.Review) (domain.Review, error)
+	GetReviewsByPRID(ctx context.Context, prID string) ([]domain.Review, error)
 }
 
 // ReviewersRepository struct for store interactions related to reviewers
@@ -23,9 +29,14 @@ func NewReviewersRepository(postgres iReviewersPostgres) *ReviewersRepository {
 	return &ReviewersRepository{postgres: postgres}
 }
 
-// AssignToPR assigns reviewers with reviewerIDs to a pull request with the given prID
-func (r *ReviewersRepository) AssignToPR(ctx context.Context, prID string, reviewerIDs []string) error {
-	return r.postgres.AssignReviewersToPR(ctx, prID, reviewerIDs)
+// AssignToPR assigns reviewers with reviewerIDs picked from the pool to a pull request with the given prID
+func (r *ReviewersRepository) AssignToPR(
+	ctx context.Context,
+	prID string,
+	pool domain.ReviewerPool,
+	reviewerIDs []string,
+) error {
+	return r.postgres.AssignReviewersToPR(ctx, prID, pool, reviewerIDs)
 }
 
 // GetByPRID retrieves the list of reviewers assigned to a pull request with the given prID
@@ -33,9 +44,29 @@ func (r *ReviewersRepository) GetByPRID(ctx context.Context, prID string) ([]dom
 	return r.postgres.GetReviewersByPRID(ctx, prID)
 }
 
-// Reassign changes the reviewer of a pull request from oldReviewerID to newReviewerID
-func (r *ReviewersRepository) Reassign(ctx context.Context, prID, newReviewerID, oldReviewerID string) error {
-	return r.postgres.ReassignReviewer(ctx, prID, newReviewerID, oldReviewerID)
+// GetPoolsByPRID retrieves the pool each reviewer of a pull request was picked from by reviewer ID
+func (r *ReviewersRepository) GetPoolsByPRID(ctx context.Context, prID string) (map[string]domain.ReviewerPool, error) {
+	return r.postgres.GetReviewerPoolsByPRID(ctx, prID)
+}
+
+// Reassign changes the reviewer of a pull request from oldReviewerID to newReviewerID picked from the pool
+func (r *ReviewersRepository) Reassign(
+	ctx context.Context,
+	prID string,
+	pool domain.ReviewerPool,
+	newReviewerID, oldReviewerID string,
+) error {
+	return r.postgres.ReassignReviewer(ctx, prID, pool, newReviewerID, oldReviewerID)
+}
+
+// Unassign removes the reviewer with reviewerID from a pull request with the given prID
+func (r *ReviewersRepository) Unassign(ctx context.Context, prID, reviewerID string) error {
+	return r.postgres.UnassignReviewer(ctx, prID, reviewerID)
+}
+
+// HandOver applies reviewer replacements on open pull requests at once, reviewers without a replacement are removed
+func (r *ReviewersRepository) HandOver(ctx context.Context, handovers []domain.ReviewerHandover) error {
+	return r.postgres.HandOverReviewers(ctx, handovers)
 }
 
 // GetReviewingPR retrieves the list of pull requests that the user with userID is reviewing
@@ -47,3 +78,19 @@ func (r *ReviewersRepository) GetReviewingPR(ctx context.Context, userID string)
 func (r *ReviewersRepository) IsReviewerAssignedToPR(ctx context.Context, prID, reviewerID string) (bool, error) {
 	return r.postgres.IsReviewerAssignedToPR(ctx, prID, reviewerID)
 }
+
+// CountOpenReviews returns the number of open pull requests each of the users is reviewing
+func (r *ReviewersRepository) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	return r.postgres.CountOpenReviews(ctx, userIDs)
+}
+
+// AddReview stores the verdict a reviewer submitted on a pull request
+func (r *ReviewersRepository) AddReview(ctx context.Context, review domain.Review) (domain.Review, error) {
+	return r.postgres.AddReview(ctx, review)
+}
+
+// GetReviewsByPRID retrieves the latest verdict of every reviewer currently assigned to a pull request with the
+// given prID
+func (r *ReviewersRepository) GetReviewsByPRID(ctx context.Context, prID string) ([]domain.Review, error) {
+	return r.postgres.GetReviewsByPRID(ctx, prID)
+}
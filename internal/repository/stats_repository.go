@@ -8,9 +8,16 @@ import (
 )
 
 type iStatsPostgres interface {
-	GetUserStats(ctx context.Context) ([]stats_retriever.UsersStats, error)
-	GetTeamStats(ctx context.Context) ([]stats_retriever.TeamsStats, error)
-	GetAssignmentStats(ctx context.Context) ([]stats_retriever.AssignmentStats, error)
+	GetUserStats(ctx context.Context, filter stats_retriever.Filter) ([]stats_retriever.UsersStats, error)
+	GetTeamStats(ctx context.Context, filter stats_retriever.Filter) ([]stats_retriever.TeamsStats, error)
+	GetAssignmentStats(ctx context.Context, filter stats_retriever.Filter) ([]stats_retriever.AssignmentStats, error)
+	GetTimeToMerge(ctx context.Context, filter stats_retriever.Filter) (stats_retriever.TimeToMerge, error)
+	GetPullRequestsByPeriod(ctx context.Context, filter stats_retriever.Filter) ([]stats_retriever.PullRequestsPeriodStats, error)
+	GetAssignmentsByPeriod(ctx context.Context, filter stats_retriever.Filter) ([]stats_retriever.AssignmentsPeriodStats, error)
+	GetTimeToFirstReview(ctx context.Context, filter stats_retriever.Filter) (stats_retriever.TimeToFirstReview, error)
+	GetReviewerApprovalStats(ctx context.Context, filter stats_retriever.Filter) ([]stats_retriever.ReviewerApprovalStats, error)
+	CountTeams(ctx context.Context) (int, error)
+	ReconcileStats(ctx context.Context) (stats_retriever.ReconcileResult, error)
 }
 
 type StatsRepository struct {
@@ -23,25 +30,84 @@ func NewStatsRepository(postgres iStatsPostgres) *StatsRepository {
 	}
 }
 
-func (r *StatsRepository) Get(ctx context.Context) ([]stats_retriever.Stats, error) {
-	var statsList stats_retriever.Stats
-	userStats, err := r.postgres.GetUserStats(ctx)
+func (r *StatsRepository) Get(ctx context.Context, filter stats_retriever.Filter) (stats_retriever.Stats, error) {
+	var stats stats_retriever.Stats
+	userStats, err := r.postgres.GetUserStats(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("get stats: %w", err)
+		return stats_retriever.Stats{}, fmt.Errorf("get stats: %w", err)
 	}
-	statsList.UserStats = userStats
+	stats.UserStats = userStats
 
-	teamStats, err := r.postgres.GetTeamStats(ctx)
+	teamStats, err := r.postgres.GetTeamStats(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("get stats: %w", err)
+		return stats_retriever.Stats{}, fmt.Errorf("get stats: %w", err)
 	}
-	statsList.TeamStats = teamStats
+	stats.TeamStats = teamStats
 
-	assignStats, err := r.postgres.GetAssignmentStats(ctx)
+	assignStats, err := r.postgres.GetAssignmentStats(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("get stats: %w", err)
+		return stats_retriever.Stats{}, fmt.Errorf("get stats: %w", err)
 	}
-	statsList.AssignStats = assignStats
+	stats.AssignStats = assignStats
 
-	return []stats_retriever.Stats{statsList}, nil
+	timeToMerge, err := r.postgres.GetTimeToMerge(ctx, filter)
+	if err != nil {
+		return stats_retriever.Stats{}, fmt.Errorf("get stats: %w", err)
+	}
+	stats.TimeToMerge = timeToMerge
+
+	prStats, err := r.postgres.GetPullRequestsByPeriod(ctx, filter)
+	if err != nil {
+		return stats_retriever.Stats{}, fmt.Errorf("get stats: %w", err)
+	}
+	stats.PullRequests = prStats
+
+	assignByPeriod, err := r.postgres.GetAssignmentsByPeriod(ctx, filter)
+	if err != nil {
+		return stats_retriever.Stats{}, fmt.Errorf("get stats: %w", err)
+	}
+	stats.Assignments = assignByPeriod
+
+	timeToFirstReview, err := r.postgres.GetTimeToFirstReview(ctx, filter)
+	if err != nil {
+		return stats_retriever.Stats{}, fmt.Errorf("get stats: %w", err)
+	}
+	stats.TimeToFirstReview = timeToFirstReview
+
+	approvals, err := r.postgres.GetReviewerApprovalStats(ctx, filter)
+	if err != nil {
+		return stats_retriever.Stats{}, fmt.Errorf("get stats: %w", err)
+	}
+	stats.Approvals = approvals
+
+	return stats, nil
+}
+
+// Reconcile rebuilds the stats counters from the source data
+func (r *StatsRepository) Reconcile(ctx context.Context) (stats_retriever.ReconcileResult, error) {
+	res, err := r.postgres.ReconcileStats(ctx)
+	if err != nil {
+		return stats_retriever.ReconcileResult{}, fmt.Errorf("reconcile stats: %w", err)
+	}
+	return res, nil
+}
+
+func (r *StatsRepository) CountTeams(ctx context.Context) (int, error) {
+	return r.postgres.CountTeams(ctx)
+}
+
+// CountUsers returns the number of active and inactive users
+func (r *StatsRepository) CountUsers(ctx context.Context) (active, inactive int, err error) {
+	userStats, err := r.postgres.GetUserStats(ctx, stats_retriever.Filter{})
+	if err != nil {
+		return 0, 0, fmt.Errorf("count users: %w", err)
+	}
+	for _, s := range userStats {
+		if s.IsActive {
+			active += s.Total
+		} else {
+			inactive += s.Total
+		}
+	}
+	return active, inactive, nil
 }
@@ -0,0 +1,17 @@
+package repository
+
+// Storage is everything repositories need from a storage backend.
+// It is implemented by postgres.Postgres and memory.Memory.
+type Storage interface {
+	iUserPostgres
+	iTeamPostgres
+	iPRPostgres
+	iReviewersPostgres
+	iStatsPostgres
+	iAuditPostgres
+	iWebhookPostgres
+	iExternalLoginPostgres
+	iAbsencePostgres
+	iReviewerPoolPostgres
+	iTxPostgres
+}
@@ -10,6 +10,8 @@ type iTeamPostgres interface {
 	GetTeamByName(ctx context.Context, teamName string) (domain.Team, error)
 	AddTeam(ctx context.Context, team domain.Team) (domain.Team, error)
 	ExistsTeamByName(ctx context.Context, teamName string) (bool, error)
+	RenameTeam(ctx context.Context, teamName, newName string) (domain.Team, error)
+	DeleteTeam(ctx context.Context, teamName string) error
 }
 
 type TeamRepository struct {
@@ -31,3 +33,12 @@ func (r *TeamRepository) Add(ctx context.Context, team domain.Team) (domain.Team
 func (r *TeamRepository) Exists(ctx context.Context, teamName string) (bool, error) {
 	return r.postgres.ExistsTeamByName(ctx, teamName)
 }
+
+func (r *TeamRepository) Rename(ctx context.Context, teamName, newName string) (domain.Team, error) {
+	return r.postgres.RenameTeam(ctx, teamName, newName)
+}
+
+// Delete deletes the team, it must have no members
+func (r *TeamRepository) Delete(ctx context.Context, teamName string) error {
+	return r.postgres.DeleteTeam(ctx, teamName)
+}
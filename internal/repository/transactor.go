@@ -0,0 +1,57 @@
+package repository
+
+import "context"
+
+type iTxPostgres interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type commitHooksKey struct{}
+
+// commitHooks are the functions to call once the transaction commits
+type commitHooks struct {
+	fns []func()
+}
+
+// Transactor lets services run several repository calls as a single unit of work
+type Transactor struct {
+	postgres iTxPostgres
+}
+
+func NewTransactor(postgres iTxPostgres) *Transactor {
+	return &Transactor{postgres: postgres}
+}
+
+// WithinTransaction runs fn atomically: repository calls made with the context passed to fn
+// are committed together if fn returns nil and rolled back otherwise
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// вложенный вызов присоединяется к внешней транзакции, функции после коммита вызовет она
+	if _, ok := ctx.Value(commitHooksKey{}).(*commitHooks); ok {
+		return t.postgres.WithinTransaction(ctx, fn)
+	}
+
+	hooks := &commitHooks{}
+	err := t.postgres.WithinTransaction(ctx, func(ctx context.Context) error {
+		// повтор транзакции начинается заново, функции прошлых запусков не вызываются
+		hooks.fns = nil
+		return fn(context.WithValue(ctx, commitHooksKey{}, hooks))
+	})
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks.fns {
+		hook()
+	}
+	return nil
+}
+
+// AfterCommit calls fn once the outermost transaction of ctx commits, fn is dropped if it is rolled back.
+// Outside of a transaction fn is called at once
+func (t *Transactor) AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(commitHooksKey{}).(*commitHooks)
+	if !ok {
+		fn()
+		return
+	}
+	hooks.fns = append(hooks.fns, fn)
+}
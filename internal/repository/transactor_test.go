@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// stubTx runs fn as a transaction, the first failures runs are retried like serialization failures
+type stubTx struct {
+	failures int
+	runs     int
+}
+
+func (t *stubTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	for {
+		t.runs++
+		err := fn(ctx)
+		if err != nil || t.failures == 0 {
+			return err
+		}
+		t.failures--
+	}
+}
+
+// TransactorTestSuite определяет test suite для транзакций и функций после коммита
+type TransactorTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+// SetupTest выполняется перед каждым тестом
+func (s *TransactorTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+// TestAfterCommit проверяет, когда вызываются функции после коммита
+func (s *TransactorTestSuite) TestAfterCommit() {
+	s.Run("called after commit", func() {
+		transactor := NewTransactor(&stubTx{})
+		calls := 0
+
+		err := transactor.WithinTransaction(s.ctx, func(ctx context.Context) error {
+			transactor.AfterCommit(ctx, func() { calls++ })
+			s.Zero(calls)
+			return nil
+		})
+
+		s.Require().NoError(err)
+		s.Equal(1, calls)
+	})
+
+	s.Run("dropped on rollback", func() {
+		transactor := NewTransactor(&stubTx{})
+		txErr := errors.New("database error")
+		calls := 0
+
+		err := transactor.WithinTransaction(s.ctx, func(ctx context.Context) error {
+			transactor.AfterCommit(ctx, func() { calls++ })
+			return txErr
+		})
+
+		s.ErrorIs(err, txErr)
+		s.Zero(calls)
+	})
+
+	s.Run("nested transaction waits for the outer one", func() {
+		transactor := NewTransactor(&stubTx{})
+		calls := 0
+
+		err := transactor.WithinTransaction(s.ctx, func(ctx context.Context) error {
+			if err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				transactor.AfterCommit(ctx, func() { calls++ })
+				return nil
+			}); err != nil {
+				return err
+			}
+			s.Zero(calls)
+			return nil
+		})
+
+		s.Require().NoError(err)
+		s.Equal(1, calls)
+	})
+
+	s.Run("retried run starts over", func() {
+		tx := &stubTx{failures: 2}
+		transactor := NewTransactor(tx)
+		calls := 0
+
+		err := transactor.WithinTransaction(s.ctx, func(ctx context.Context) error {
+			transactor.AfterCommit(ctx, func() { calls++ })
+			return nil
+		})
+
+		s.Require().NoError(err)
+		s.Equal(3, tx.runs)
+		s.Equal(1, calls)
+	})
+
+	s.Run("called at once outside of a transaction", func() {
+		transactor := NewTransactor(&stubTx{})
+		calls := 0
+
+		transactor.AfterCommit(s.ctx, func() { calls++ })
+
+		s.Equal(1, calls)
+	})
+}
+
+// TestTransactorSuite запускает test suite
+func TestTransactorSuite(t *testing.T) {
+	suite.Run(t, new(TransactorTestSuite))
+}
@@ -8,12 +8,18 @@ import (
 
 type iUserPostgres interface {
 	AddUsers(ctx context.Context, users []domain.User) ([]domain.User, error)
-	BatchExistsUserByID(ctx context.Context, users []domain.User) map[domain.User]bool
 	ExistsUserByID(ctx context.Context, userID string) (bool, error)
 	GetUserByID(ctx context.Context, userID string) (domain.User, error)
+	GetUsersByIDs(ctx context.Context, userIDs []string) ([]domain.User, error)
 	GetUsersByTeamName(ctx context.Context, teamName string) ([]domain.User, error)
 	SetUserIsActive(ctx context.Context, userID string, isActive bool) (domain.User, error)
+	SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error)
 	GetActiveUsersByTeamName(ctx context.Context, teamName string) ([]domain.User, error)
+	DeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error)
+	MoveUsersToTeam(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error)
+	MoveDeletedUsersToTeam(ctx context.Context, teamName, newTeamName string) ([]domain.User, error)
+	ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error)
+	SoftDeleteUser(ctx context.Context, userID string) (domain.User, error)
 }
 
 // UserRepository struct for store interactions related to users
@@ -25,14 +31,14 @@ func NewUserRepository(postgres iUserPostgres) *UserRepository {
 	return &UserRepository{postgres: postgres}
 }
 
-// Add adds new users to the repository
+// Add inserts new users, an existing ID or username is reported as domain.ErrTeamMemberConflict
 func (r *UserRepository) Add(ctx context.Context, users []domain.User) ([]domain.User, error) {
 	return r.postgres.AddUsers(ctx, users)
 }
 
-// BatchExistsByID checks the existence of multiple users by their IDs
-func (r *UserRepository) BatchExistsByID(ctx context.Context, users []domain.User) map[domain.User]bool {
-	return r.postgres.BatchExistsUserByID(ctx, users)
+// GetByIDs retrieves the existing users among the given IDs
+func (r *UserRepository) GetByIDs(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	return r.postgres.GetUsersByIDs(ctx, userIDs)
 }
 
 // ExistsByID checks if a user exists by their ID
@@ -55,7 +61,45 @@ func (r *UserRepository) SetIsActive(ctx context.Context, userID string, isActiv
 	return r.postgres.SetUserIsActive(ctx, userID, isActive)
 }
 
+// SetMaxOpenReviews sets the number of open reviews the user may have at once, 0 removes the limit
+func (r *UserRepository) SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error) {
+	return r.postgres.SetUserMaxOpenReviews(ctx, userID, maxOpenReviews)
+}
+
 // GetActiveByTeamName retrieves active users by their team name
 func (r *UserRepository) GetActiveByTeamName(ctx context.Context, teamName string) ([]domain.User, error) {
 	return r.postgres.GetActiveUsersByTeamName(ctx, teamName)
 }
+
+// DeactivateTeamUsers deactivates users of the team, all of them if userIDs is empty
+func (r *UserRepository) DeactivateTeamUsers(
+	ctx context.Context,
+	teamName string,
+	userIDs []string,
+) ([]domain.User, error) {
+	return r.postgres.DeactivateTeamUsers(ctx, teamName, userIDs)
+}
+
+// MoveUsersToTeam moves users to the team
+func (r *UserRepository) MoveUsersToTeam(
+	ctx context.Context,
+	teamName string,
+	userIDs []string,
+) ([]domain.User, error) {
+	return r.postgres.MoveUsersToTeam(ctx, teamName, userIDs)
+}
+
+// MoveDeletedUsers moves deleted users of the team to another team, they hold no open reviews
+func (r *UserRepository) MoveDeletedUsers(ctx context.Context, teamName, newTeamName string) ([]domain.User, error) {
+	return r.postgres.MoveDeletedUsersToTeam(ctx, teamName, newTeamName)
+}
+
+// List retrieves users matching the filter ordered by ID, deleted users are skipped
+func (r *UserRepository) List(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	return r.postgres.ListUsers(ctx, filter)
+}
+
+// SoftDelete marks the user as deleted and inactive, the row stays for the history
+func (r *UserRepository) SoftDelete(ctx context.Context, userID string) (domain.User, error) {
+	return r.postgres.SoftDeleteUser(ctx, userID)
+}
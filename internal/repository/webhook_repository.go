@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+type iWebhookPostgres interface {
+	AddOutboxEvent(ctx context.Context, event domain.OutboxEvent) (domain.OutboxEvent, error)
+	AddOutboxEvents(ctx context.Context, events []domain.OutboxEvent) error
+	AddWebhookSubscription(
+		ctx context.Context,
+		subscription domain.WebhookSubscription,
+	) (domain.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, id int64) error
+	FanOutOutboxEvents(ctx context.Context, batchSize int) (int, error)
+	ClaimWebhookDeliveries(
+		ctx context.Context,
+		now, leaseUntil time.Time,
+		batchSize int,
+	) ([]domain.ClaimedDelivery, error)
+	CompleteWebhookDelivery(ctx context.Context, id int64) error
+	FailWebhookDelivery(
+		ctx context.Context,
+		id int64,
+		status domain.DeliveryStatus,
+		nextAttemptAt time.Time,
+		lastError string,
+	) error
+	ListWebhookDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)
+	RetryWebhookDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error)
+}
+
+// WebhookRepository struct for store interactions related to the event outbox and webhook deliveries
+type WebhookRepository struct {
+	postgres iWebhookPostgres
+}
+
+func NewWebhookRepository(postgres iWebhookPostgres) *WebhookRepository {
+	return &WebhookRepository{postgres: postgres}
+}
+
+// AddEvent stores a domain event in the outbox
+func (r *WebhookRepository) AddEvent(ctx context.Context, event domain.OutboxEvent) (domain.OutboxEvent, error) {
+	return r.postgres.AddOutboxEvent(ctx, event)
+}
+
+// AddEvents stores domain events in the outbox at once
+func (r *WebhookRepository) AddEvents(ctx context.Context, events []domain.OutboxEvent) error {
+	return r.postgres.AddOutboxEvents(ctx, events)
+}
+
+// Subscribe stores a new subscription
+func (r *WebhookRepository) Subscribe(
+	ctx context.Context,
+	subscription domain.WebhookSubscription,
+) (domain.WebhookSubscription, error) {
+	return r.postgres.AddWebhookSubscription(ctx, subscription)
+}
+
+// ListSubscriptions retrieves all subscriptions
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return r.postgres.ListWebhookSubscriptions(ctx)
+}
+
+// Unsubscribe removes a subscription and its deliveries
+func (r *WebhookRepository) Unsubscribe(ctx context.Context, id int64) error {
+	return r.postgres.DeleteWebhookSubscription(ctx, id)
+}
+
+// FanOutEvents creates deliveries of new outbox events to their subscribers
+func (r *WebhookRepository) FanOutEvents(ctx context.Context, batchSize int) (int, error) {
+	return r.postgres.FanOutOutboxEvents(ctx, batchSize)
+}
+
+// ClaimDeliveries takes pending deliveries that are due and leases them to the caller
+func (r *WebhookRepository) ClaimDeliveries(
+	ctx context.Context,
+	now, leaseUntil time.Time,
+	batchSize int,
+) ([]domain.ClaimedDelivery, error) {
+	return r.postgres.ClaimWebhookDeliveries(ctx, now, leaseUntil, batchSize)
+}
+
+// CompleteDelivery marks a delivery as delivered
+func (r *WebhookRepository) CompleteDelivery(ctx context.Context, id int64) error {
+	return r.postgres.CompleteWebhookDelivery(ctx, id)
+}
+
+// FailDelivery records a failed attempt of a delivery
+func (r *WebhookRepository) FailDelivery(
+	ctx context.Context,
+	id int64,
+	status domain.DeliveryStatus,
+	nextAttemptAt time.Time,
+	lastError string,
+) error {
+	return r.postgres.FailWebhookDelivery(ctx, id, status, nextAttemptAt, lastError)
+}
+
+// ListDeliveries retrieves a page of deliveries matching the filter, newest first
+func (r *WebhookRepository) ListDeliveries(
+	ctx context.Context,
+	filter domain.WebhookDeliveryFilter,
+) ([]domain.WebhookDelivery, error) {
+	return r.postgres.ListWebhookDeliveries(ctx, filter)
+}
+
+// RetryDelivery puts a dead delivery back to the queue
+func (r *WebhookRepository) RetryDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	return r.postgres.RetryWebhookDelivery(ctx, id)
+}
@@ -0,0 +1,163 @@
+package router
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+func (r *Router) addAbsence(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req addAbsenceRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse add absence request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for add absence request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+	if principal, _ := domain.PrincipalFromContext(uCtx); !canActFor(principal, req.UserID) {
+		slog.WarnContext(uCtx, "member added absence of another user", "user_id", req.UserID,
+			"subject", principal.Subject)
+		return ctx.Status(fiber.StatusForbidden).JSON(errorResponseForbidden)
+	}
+
+	absence, err := r.absenceService.Add(uCtx, req.ToDomain())
+	switch {
+	case errors.Is(err, domain.ErrInvalidAbsence):
+		slog.WarnContext(uCtx, "invalid absence", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case errors.Is(err, domain.ErrUserNotFound):
+		slog.WarnContext(uCtx, "user not found", "user_id", req.UserID)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to add absence", "error", err)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"absence": fromDomainAbsence(absence)})
+}
+
+// listAbsences returns current and upcoming absences, past ones only with include_past=true.
+// Members see only their own absences
+func (r *Router) listAbsences(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	filter := domain.AbsenceFilter{
+		UserID:    ctx.Query("user_id"),
+		TeamName:  ctx.Query("team_name"),
+		EndsAfter: time.Now().UTC(),
+	}
+	if ctx.QueryBool("include_past") {
+		filter.EndsAfter = time.Time{}
+	}
+	principal, _ := domain.PrincipalFromContext(uCtx)
+	if principal.Role == domain.RoleMember {
+		if filter.UserID != "" && filter.UserID != principal.Subject {
+			slog.WarnContext(uCtx, "member listed absences of another user", "user_id", filter.UserID,
+				"subject", principal.Subject)
+			return ctx.Status(fiber.StatusForbidden).JSON(errorResponseForbidden)
+		}
+		filter.UserID = principal.Subject
+	}
+
+	absences, err := r.absenceService.List(uCtx, filter)
+	if err != nil {
+		slog.ErrorContext(uCtx, "failed to list absences", "error", err)
+		return fiber.ErrInternalServerError
+	}
+
+	resp := make([]absenceResponse, 0, len(absences))
+	for _, absence := range absences {
+		resp = append(resp, fromDomainAbsence(absence))
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"absences": resp})
+}
+
+func (r *Router) updateAbsence(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req updateAbsenceRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse update absence request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for update absence request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+	absence, allowed, err := r.ownAbsence(ctx, req.AbsenceID)
+	if !allowed {
+		return err
+	}
+
+	absence.StartsAt, absence.EndsAt, absence.Reason = req.StartsAt, req.EndsAt, req.Reason
+	absence, err = r.absenceService.Update(uCtx, absence)
+	switch {
+	case errors.Is(err, domain.ErrInvalidAbsence):
+		slog.WarnContext(uCtx, "invalid absence", "absence_id", req.AbsenceID, "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case errors.Is(err, domain.ErrAbsenceNotFound):
+		slog.WarnContext(uCtx, "absence not found", "absence_id", req.AbsenceID)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to update absence", "absence_id", req.AbsenceID, "error", err)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"absence": fromDomainAbsence(absence)})
+}
+
+func (r *Router) deleteAbsence(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req deleteAbsenceRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse delete absence request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for delete absence request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+	if _, allowed, err := r.ownAbsence(ctx, req.AbsenceID); !allowed {
+		return err
+	}
+
+	err := r.absenceService.Delete(uCtx, req.AbsenceID)
+	switch {
+	case errors.Is(err, domain.ErrAbsenceNotFound):
+		slog.WarnContext(uCtx, "absence not found", "absence_id", req.AbsenceID)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to delete absence", "absence_id", req.AbsenceID, "error", err)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.SendStatus(fiber.StatusOK)
+}
+
+// ownAbsence loads the absence and checks that the caller may change it.
+// If not, the response is already written and its error is returned
+func (r *Router) ownAbsence(ctx *fiber.Ctx, id int64) (domain.Absence, bool, error) {
+	uCtx := ctx.UserContext()
+
+	absence, err := r.absenceService.Get(uCtx, id)
+	switch {
+	case errors.Is(err, domain.ErrAbsenceNotFound):
+		slog.WarnContext(uCtx, "absence not found", "absence_id", id)
+		return domain.Absence{}, false, ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to get absence", "absence_id", id, "error", err)
+		return domain.Absence{}, false, fiber.ErrInternalServerError
+	}
+	if principal, _ := domain.PrincipalFromContext(uCtx); !canActFor(principal, absence.UserID) {
+		slog.WarnContext(uCtx, "member changed absence of another user", "absence_id", id,
+			"subject", principal.Subject)
+		return domain.Absence{}, false, ctx.Status(fiber.StatusForbidden).JSON(errorResponseForbidden)
+	}
+	return absence, true, nil
+}
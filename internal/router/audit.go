@@ -0,0 +1,90 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// auditContext passes the request ID to services, so that the audit events they write refer to it.
+// The actor is added by authentication. It has to run after the requestid middleware.
+// Header values are copied, because Fiber reuses their memory after the request while events may outlive it
+func auditContext(ctx *fiber.Ctx) error {
+	uCtx := domain.WithRequestID(ctx.UserContext(), strings.Clone(ctx.GetRespHeader(fiber.HeaderXRequestID)))
+	ctx.SetUserContext(uCtx)
+	return ctx.Next()
+}
+
+func (r *Router) listAuditEvents(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	filter, err := parseAuditFilter(ctx)
+	if err != nil {
+		slog.WarnContext(uCtx, "invalid audit list query params", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	}
+
+	page, err := r.auditService.List(uCtx, filter)
+	switch {
+	case errors.Is(err, domain.ErrInvalidAuditFilter):
+		slog.WarnContext(uCtx, "invalid audit list filter", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to list audit events", "error", err)
+		return fiber.ErrInternalServerError
+	}
+
+	resp := auditListResponse{Events: make([]auditEventResponse, 0, len(page.Events))}
+	for _, event := range page.Events {
+		resp.Events = append(resp.Events, fromDomainAuditEvent(event))
+	}
+	if page.NextAfterID != 0 {
+		resp.NextCursor = strconv.FormatInt(page.NextAfterID, 10)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(resp)
+}
+
+func parseAuditFilter(ctx *fiber.Ctx) (domain.AuditFilter, error) {
+	filter := domain.AuditFilter{ //nolint:exhaustruct // Даты и курсор разбираются ниже
+		Action:     domain.AuditAction(ctx.Query("action")),
+		EntityType: domain.AuditEntityType(ctx.Query("entity_type")),
+		EntityID:   ctx.Query("entity_id"),
+		Actor:      ctx.Query("actor"),
+		RequestID:  ctx.Query("request_id"),
+	}
+
+	for param, dst := range map[string]*time.Time{
+		"from": &filter.From,
+		"to":   &filter.To,
+	} {
+		t, err := parseQueryTime(ctx.Query(param))
+		if err != nil {
+			return domain.AuditFilter{}, fmt.Errorf("%s: %w", param, err)
+		}
+		*dst = t
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return domain.AuditFilter{}, fmt.Errorf("limit: expected integer, got %q", limit)
+		}
+		filter.Limit = n
+	}
+	// Курсор - ID последнего события предыдущей страницы, события идут по убыванию ID
+	if cursor := ctx.Query("cursor"); cursor != "" {
+		id, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return domain.AuditFilter{}, fmt.Errorf("cursor: malformed cursor %q", cursor)
+		}
+		filter.AfterID = id
+	}
+	return filter, nil
+}
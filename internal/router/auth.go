@@ -0,0 +1,99 @@
+package router
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// iAuthenticator checks bearer tokens. It is optional, without it every request is served as an anonymous admin
+type iAuthenticator interface {
+	Authenticate(token string) (domain.Principal, error)
+}
+
+// anonymousAdmin is the principal of every request when authentication is disabled
+var anonymousAdmin = domain.Principal{Subject: domain.AnonymousActor, Role: domain.RoleAdmin, TeamName: ""}
+
+// protected wraps the handler of a route with authentication and a role check. Without roles any role is allowed
+func (r *Router) protected(handler fiber.Handler, roles ...domain.Role) []fiber.Handler {
+	return []fiber.Handler{r.authenticate, authorize(roles...), handler}
+}
+
+// authenticate puts the caller named by the Authorization header into the context of the request
+func (r *Router) authenticate(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+	if r.authenticator == nil {
+		ctx.SetUserContext(domain.WithPrincipal(uCtx, anonymousAdmin))
+		return ctx.Next()
+	}
+
+	header := ctx.Get(fiber.HeaderAuthorization)
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok && header != "" {
+		slog.WarnContext(uCtx, "unsupported authorization scheme", "path", ctx.Path())
+		return ctx.Status(fiber.StatusUnauthorized).
+			JSON(newErrorResponse("bearer token is required", errorCodeUnauthorized))
+	}
+	principal, err := r.authenticator.Authenticate(strings.TrimSpace(token))
+	if err != nil {
+		slog.WarnContext(uCtx, "authentication failed", "path", ctx.Path(), "error", err)
+		return ctx.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err.Error(), errorCodeUnauthorized))
+	}
+	ctx.SetUserContext(domain.WithPrincipal(uCtx, principal))
+	return ctx.Next()
+}
+
+// authorize lets the request through if the caller has one of the roles. It has to run after authenticate
+func authorize(roles ...domain.Role) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		principal, _ := domain.PrincipalFromContext(ctx.UserContext())
+		if len(roles) > 0 && !principal.HasRole(roles...) {
+			slog.WarnContext(ctx.UserContext(), "caller role is not allowed", "path", ctx.Path(),
+				"subject", principal.Subject, "role", principal.Role)
+			return ctx.Status(fiber.StatusForbidden).JSON(errorResponseForbidden)
+		}
+		return ctx.Next()
+	}
+}
+
+// canActFor reports whether the caller may read or change data of the user. Members may only touch their own
+func canActFor(principal domain.Principal, userID string) bool {
+	return principal.Role != domain.RoleMember || principal.Subject == userID
+}
+
+// authorizeTeam answers 403 if a team lead calls for another team or for members of another team. An empty team name
+// is not checked, users that don't exist are skipped and left to the handler. done reports that the response is written
+func (r *Router) authorizeTeam(ctx *fiber.Ctx, teamName string, userIDs ...string) (done bool, err error) {
+	uCtx := ctx.UserContext()
+	principal, _ := domain.PrincipalFromContext(uCtx)
+	if principal.Role != domain.RoleTeamLead {
+		return false, nil
+	}
+
+	allowed := teamName == "" || principal.CanManageTeam(teamName)
+	for _, userID := range userIDs {
+		if !allowed {
+			break
+		}
+		user, err := r.userService.Get(uCtx, userID)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			slog.ErrorContext(uCtx, "failed to get user to check the team", "user_id", userID, "error", err)
+			return true, fiber.ErrInternalServerError
+		}
+		allowed = principal.CanManageTeam(user.TeamName)
+	}
+	if allowed {
+		return false, nil
+	}
+
+	slog.WarnContext(uCtx, "team lead may not manage another team", "path", ctx.Path(),
+		"subject", principal.Subject, "lead_team", principal.TeamName, "team_name", teamName, "user_ids", userIDs)
+	return true, ctx.Status(fiber.StatusForbidden).JSON(errorResponseForbidden)
+}
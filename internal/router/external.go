@@ -0,0 +1,128 @@
+package router
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/internal/ingest"
+)
+
+func (r *Router) handleGitHubWebhook(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+	result, err := r.externalService.HandleGitHub(uCtx,
+		ctx.Get(ingest.HeaderGitHubEvent), ctx.Get(ingest.HeaderGitHubSignature), ctx.Body())
+	return r.respondExternalEvent(ctx, domain.ExternalProviderGitHub, result, err)
+}
+
+func (r *Router) handleGitLabWebhook(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+	result, err := r.externalService.HandleGitLab(uCtx,
+		ctx.Get(ingest.HeaderGitLabEvent), ctx.Get(ingest.HeaderGitLabToken), ctx.Body())
+	return r.respondExternalEvent(ctx, domain.ExternalProviderGitLab, result, err)
+}
+
+func (r *Router) respondExternalEvent(
+	ctx *fiber.Ctx,
+	provider domain.ExternalProvider,
+	result domain.ExternalEventResult,
+	err error,
+) error {
+	uCtx := ctx.UserContext()
+	switch {
+	case errors.Is(err, domain.ErrProviderNotConfigured):
+		slog.WarnContext(uCtx, "webhook of a provider that is not configured", "provider", provider)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case errors.Is(err, domain.ErrInvalidSignature):
+		slog.WarnContext(uCtx, "webhook signature check failed", "provider", provider, "error", err)
+		return ctx.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err.Error(), errorCodeUnauthorized))
+	case errors.Is(err, domain.ErrInvalidExternalEvent):
+		slog.WarnContext(uCtx, "invalid webhook payload", "provider", provider, "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case errors.Is(err, domain.ErrExternalLoginNotMapped), errors.Is(err, domain.ErrUserNotFound):
+		slog.WarnContext(uCtx, "webhook author is not mapped", "provider", provider, "error", err)
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(newErrorResponse(err.Error(), errorCodeUnknownLogin))
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to handle webhook", "provider", provider, "error", err)
+		return fiber.ErrInternalServerError
+	}
+
+	slog.InfoContext(uCtx, "webhook handled", "provider", provider, "action", result.Event.Action,
+		"pull_request_id", result.Event.PullRequestID, "applied", result.Applied, "reason", result.Reason)
+	return ctx.Status(fiber.StatusOK).JSON(fromDomainExternalEventResult(result))
+}
+
+func (r *Router) setExternalLogin(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req setExternalLoginRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse set external login request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for set external login request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	login, err := r.externalService.SetLogin(uCtx, req.ToDomain())
+	switch {
+	case errors.Is(err, domain.ErrInvalidExternalLogin):
+		slog.WarnContext(uCtx, "invalid external login", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case errors.Is(err, domain.ErrUserNotFound):
+		slog.WarnContext(uCtx, "user not found", "user_id", req.UserID)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to set external login", "error", err)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"external_login": fromDomainExternalLogin(login)})
+}
+
+func (r *Router) listExternalLogins(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	logins, err := r.externalService.ListLogins(uCtx, domain.ExternalProvider(ctx.Query("provider")))
+	switch {
+	case errors.Is(err, domain.ErrInvalidExternalLogin):
+		slog.WarnContext(uCtx, "invalid external logins filter", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to list external logins", "error", err)
+		return fiber.ErrInternalServerError
+	}
+
+	resp := make([]externalLoginResponse, 0, len(logins))
+	for _, login := range logins {
+		resp = append(resp, fromDomainExternalLogin(login))
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"external_logins": resp})
+}
+
+func (r *Router) deleteExternalLogin(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req deleteExternalLoginRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse delete external login request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for delete external login request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	err := r.externalService.DeleteLogin(uCtx, req.Provider, req.Login)
+	switch {
+	case errors.Is(err, domain.ErrExternalLoginNotFound):
+		slog.WarnContext(uCtx, "external login not found", "provider", req.Provider, "login", req.Login)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to delete external login", "error", err)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.SendStatus(fiber.StatusOK)
+}
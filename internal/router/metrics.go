@@ -0,0 +1,18 @@
+package router
+
+import "github.com/gofiber/fiber/v2"
+
+// nopMetrics is used when the router is created without metrics
+type nopMetrics struct{}
+
+func (nopMetrics) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Next()
+	}
+}
+
+func (nopMetrics) Handler() fiber.Handler {
+	return func(*fiber.Ctx) error {
+		return fiber.ErrNotFound
+	}
+}
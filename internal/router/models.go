@@ -1,6 +1,7 @@
 package router
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/artmexbet/avito_test_task/internal/domain"
@@ -13,12 +14,23 @@ type ErrorCode string
 
 // Possible values for ErrorCode
 const (
-	errorCodeTeamExist ErrorCode = "TEAM_EXISTS"
-	errorCodeNotFound  ErrorCode = "NOT_FOUND"
+	errorCodeTeamExist    ErrorCode = "TEAM_EXISTS"
+	errorCodeTeamNotEmpty ErrorCode = "TEAM_NOT_EMPTY"
+	errorCodeTeamConflict ErrorCode = "TEAM_MEMBER_CONFLICT"
+	errorCodeNotFound     ErrorCode = "NOT_FOUND"
+	errorCodeBadRequest   ErrorCode = "BAD_REQUEST"
 	// PullRequest specific error codes
 	errorCodePRExists    ErrorCode = "PR_EXISTS"
+	errorCodePRMerged    ErrorCode = "PR_MERGED"
 	errorCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
 	errorCodeNoCandidate ErrorCode = "NO_CANDIDATE"
+	errorCodeMergePolicy ErrorCode = "MERGE_POLICY_NOT_MET"
+	errorCodePRNotOpen   ErrorCode = "PR_NOT_OPEN"
+	errorCodeTransition  ErrorCode = "INVALID_TRANSITION"
+	// Authentication and provider webhook specific error codes
+	errorCodeUnauthorized ErrorCode = "UNAUTHORIZED"
+	errorCodeForbidden    ErrorCode = "FORBIDDEN"
+	errorCodeUnknownLogin ErrorCode = "UNKNOWN_LOGIN"
 )
 
 // Error defines the type for error codes.
@@ -43,7 +55,14 @@ var (
 	errorBadRequest = errorResponse{
 		Error: Error{
 			Message: "bad request",
-			Code:    "BAD_REQUEST",
+			Code:    errorCodeBadRequest,
+		},
+	}
+
+	errorResponseForbidden = errorResponse{
+		Error: Error{
+			Message: "not allowed for your role",
+			Code:    errorCodeForbidden,
 		},
 	}
 )
@@ -58,12 +77,24 @@ func newErrorResponse(message string, code ErrorCode) errorResponse {
 }
 
 type pullRequestResponse struct {
-	ID        string          `json:"pull_request_id"`
-	Name      string          `json:"pull_request_name"`
-	AuthorID  string          `json:"author_id"`
-	Reviewers []string        `json:"assigned_reviewers,omitempty"`
-	Status    domain.PRStatus `json:"status"`
-	MergedAt  time.Time       `json:"merged_at"`
+	ID                string           `json:"pull_request_id"`
+	Name              string           `json:"pull_request_name"`
+	AuthorID          string           `json:"author_id"`
+	Reviewers         []string         `json:"assigned_reviewers,omitempty"`
+	Reviews           []reviewResponse `json:"reviews,omitempty"`
+	Status            domain.PRStatus  `json:"status"`
+	MergedAt          time.Time        `json:"merged_at"`
+	ClosedAt          time.Time        `json:"closed_at"`
+	NeedMoreReviewers bool             `json:"need_more_reviewers"`
+	// ReviewerPools tell where each assigned reviewer was picked from, in the order of assigned_reviewers
+	ReviewerPools []reviewerPoolAssignmentResponse `json:"reviewer_pools,omitempty"`
+}
+
+// reviewerPoolAssignmentResponse is the pool an assigned reviewer was picked from
+type reviewerPoolAssignmentResponse struct {
+	ReviewerID string                  `json:"reviewer_id"`
+	PoolType   domain.ReviewerPoolKind `json:"pool_type"`
+	PoolName   string                  `json:"pool_name"`
 }
 
 // pullRequestShortResponse represents a shortened response structure for a pull request.
@@ -83,35 +114,72 @@ func fromDomainPR(pr domain.PullRequest) pullRequestResponse {
 		Reviewers: make([]string, 0, len(pr.Reviewers)),
 		Status:    pr.Status,
 		MergedAt:  pr.MergedAt,
+		ClosedAt:  pr.ClosedAt,
+
+		NeedMoreReviewers: pr.NeedMoreReviewers,
 	}
 	if len(pr.Reviewers) > 0 {
 		resp.Reviewers = make([]string, 0, len(pr.Reviewers))
 		for _, r := range pr.Reviewers {
 			resp.Reviewers = append(resp.Reviewers, r.ID)
+			if pool, ok := pr.ReviewerPools[r.ID]; ok {
+				resp.ReviewerPools = append(resp.ReviewerPools, reviewerPoolAssignmentResponse{
+					ReviewerID: r.ID,
+					PoolType:   pool.Kind,
+					PoolName:   pool.Name,
+				})
+			}
 		}
 	}
+	for _, r := range pr.Reviews {
+		resp.Reviews = append(resp.Reviews, reviewResponse{
+			ReviewerID:  r.ReviewerID,
+			Verdict:     r.Verdict,
+			SubmittedAt: r.SubmittedAt,
+		})
+	}
 	return resp
 }
 
+// reviewResponse is the latest verdict of a reviewer on a pull request
+type reviewResponse struct {
+	ReviewerID  string               `json:"reviewer_id"`
+	Verdict     domain.ReviewVerdict `json:"verdict"`
+	SubmittedAt time.Time            `json:"submitted_at"`
+}
+
 type member struct {
 	UserID   string `json:"user_id" validate:"required"`
 	Username string `json:"username" validate:"required"`
 	IsActive bool   `json:"is_active" validate:"-"`
+	// MaxOpenReviews limits open reviews of the user, 0 means no limit
+	MaxOpenReviews int `json:"max_open_reviews,omitempty" validate:"gte=0,lte=1000"`
 }
 
 type addTeamRequest struct {
 	TeamName string   `json:"team_name" validate:"required"`
 	Members  []member `json:"members" validate:"required,dive"`
+	// Mode is create_only by default
+	Mode domain.TeamAddMode `json:"mode" validate:"omitempty,oneof=create_only merge replace"`
+}
+
+// mode returns the requested mode or create_only if it is omitted
+func (r *addTeamRequest) mode() domain.TeamAddMode {
+	if r.Mode == "" {
+		return domain.TeamAddModeCreateOnly
+	}
+	return r.Mode
 }
 
 func (r *addTeamRequest) ToDomain() domain.Team {
 	var members []domain.User
 	for _, m := range r.Members {
 		members = append(members, domain.User{ //nolint:exhaustruct
-			ID:       m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
-			TeamName: r.TeamName,
+			ID:             m.UserID,
+			Username:       m.Username,
+			IsActive:       m.IsActive,
+			TeamName:       r.TeamName,
+			MaxOpenReviews: m.MaxOpenReviews,
 		})
 	}
 	return domain.Team{ //nolint:exhaustruct
@@ -120,9 +188,33 @@ func (r *addTeamRequest) ToDomain() domain.Team {
 	}
 }
 
+// memberIDs returns the IDs of the listed members
+func (r *addTeamRequest) memberIDs() []string {
+	ids := make([]string, 0, len(r.Members))
+	for _, m := range r.Members {
+		ids = append(ids, m.UserID)
+	}
+	return ids
+}
+
 type getTeamResponse struct {
-	TeamName string   `json:"team_name"`
-	Members  []member `json:"members"`
+	TeamName  string                 `json:"team_name"`
+	Members   []member               `json:"members"`
+	Fallbacks []reviewerPoolResponse `json:"fallbacks,omitempty"`
+}
+
+// reviewerPoolResponse is a team or a shared pool reviewers are picked from
+type reviewerPoolResponse struct {
+	PoolType domain.ReviewerPoolKind `json:"pool_type" validate:"required,oneof=team shared"`
+	PoolName string                  `json:"pool_name" validate:"required,max=100"`
+}
+
+func fromDomainReviewerPools(pools []domain.ReviewerPool) []reviewerPoolResponse {
+	resp := make([]reviewerPoolResponse, 0, len(pools))
+	for _, pool := range pools {
+		resp = append(resp, reviewerPoolResponse{PoolType: pool.Kind, PoolName: pool.Name})
+	}
+	return resp
 }
 
 // fromDomainTeam converts domain.Team to getTeamResponse
@@ -130,14 +222,182 @@ func fromDomainTeam(team domain.Team) getTeamResponse {
 	var members []member
 	for _, m := range team.Members {
 		members = append(members, member{
-			UserID:   m.ID,
-			Username: m.Username,
-			IsActive: m.IsActive,
+			UserID:         m.ID,
+			Username:       m.Username,
+			IsActive:       m.IsActive,
+			MaxOpenReviews: m.MaxOpenReviews,
 		})
 	}
-	return getTeamResponse{
-		TeamName: team.Name,
-		Members:  members,
+	resp := getTeamResponse{
+		TeamName:  team.Name,
+		Members:   members,
+		Fallbacks: nil,
+	}
+	if len(team.Fallbacks) > 0 {
+		resp.Fallbacks = fromDomainReviewerPools(team.Fallbacks)
+	}
+	return resp
+}
+
+type addTeamResponse struct {
+	Team          getTeamResponse               `json:"team"`
+	Created       []string                      `json:"created_user_ids"`
+	Moved         []string                      `json:"moved_user_ids"`
+	Untouched     []string                      `json:"untouched_user_ids"`
+	Activated     []string                      `json:"activated_user_ids"`
+	Deactivated   []string                      `json:"deactivated_user_ids"`
+	Reassignments []reviewerReplacementResponse `json:"reassignments"`
+}
+
+// fromDomainTeamAdd converts domain.TeamAddResult to addTeamResponse
+func fromDomainTeamAdd(result domain.TeamAddResult) addTeamResponse {
+	return addTeamResponse{
+		Team:          fromDomainTeam(result.Team),
+		Created:       result.Created,
+		Moved:         result.Moved,
+		Untouched:     result.Untouched,
+		Activated:     result.Activated,
+		Deactivated:   result.Deactivated,
+		Reassignments: fromDomainReplacements(result.Replacements),
+	}
+}
+
+type teamMemberConflictResponse struct {
+	UserID string                    `json:"user_id"`
+	Reason domain.TeamConflictReason `json:"reason"`
+	// CurrentTeam is the team of the stored user, empty for a new one
+	CurrentTeam string `json:"current_team,omitempty"`
+}
+
+// teamConflictResponse is errorResponse listing the members that were not applied
+type teamConflictResponse struct {
+	Error     Error                        `json:"error"`
+	Conflicts []teamMemberConflictResponse `json:"conflicts"`
+}
+
+func newTeamConflictResponse(conflicts []domain.TeamMemberConflict) teamConflictResponse {
+	resp := teamConflictResponse{
+		Error: Error{
+			Message: "team members conflict with existing users, nothing was changed",
+			Code:    errorCodeTeamConflict,
+		},
+		Conflicts: make([]teamMemberConflictResponse, 0, len(conflicts)),
+	}
+	for _, c := range conflicts {
+		resp.Conflicts = append(resp.Conflicts, teamMemberConflictResponse{
+			UserID:      c.UserID,
+			Reason:      c.Reason,
+			CurrentTeam: c.CurrentTeam,
+		})
+	}
+	return resp
+}
+
+type deactivateTeamUsersRequest struct {
+	TeamName string   `json:"team_name" validate:"required"`
+	UserIDs  []string `json:"user_ids" validate:"omitempty,dive,required"`
+}
+
+type reviewerReplacementResponse struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	// NewReviewerID is empty if no replacement was found and the reviewer was removed
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+}
+
+type deactivateTeamUsersResponse struct {
+	TeamName      string                        `json:"team_name"`
+	Deactivated   []string                      `json:"deactivated_user_ids"`
+	Reassignments []reviewerReplacementResponse `json:"reassignments"`
+}
+
+// fromDomainDeactivation converts deactivated users and replacements to deactivateTeamUsersResponse
+func fromDomainDeactivation(
+	teamName string,
+	users []domain.User,
+	replacements []domain.ReviewerReplacement,
+) deactivateTeamUsersResponse {
+	return deactivateTeamUsersResponse{
+		TeamName:      teamName,
+		Deactivated:   userIDsOf(users),
+		Reassignments: fromDomainReplacements(replacements),
+	}
+}
+
+func userIDsOf(users []domain.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
+
+func fromDomainReplacements(replacements []domain.ReviewerReplacement) []reviewerReplacementResponse {
+	resp := make([]reviewerReplacementResponse, 0, len(replacements))
+	for _, r := range replacements {
+		resp = append(resp, reviewerReplacementResponse{
+			PullRequestID: r.PullRequestID,
+			OldReviewerID: r.OldReviewerID,
+			NewReviewerID: r.NewReviewerID,
+		})
+	}
+	return resp
+}
+
+type renameTeamRequest struct {
+	TeamName    string `json:"team_name" validate:"required"`
+	NewTeamName string `json:"new_team_name" validate:"required,max=100"`
+}
+
+type deleteTeamRequest struct {
+	TeamName string `json:"team_name" validate:"required"`
+	// MoveMembersTo is the team members are moved to, required if the team has members
+	MoveMembersTo string `json:"move_members_to"`
+}
+
+// setTeamFallbacksRequest replaces the fallback pools of the team, an empty list removes them
+type setTeamFallbacksRequest struct {
+	TeamName  string                 `json:"team_name" validate:"required"`
+	Fallbacks []reviewerPoolResponse `json:"fallbacks" validate:"max=10,dive"`
+}
+
+func (r *setTeamFallbacksRequest) ToDomain() []domain.ReviewerPool {
+	fallbacks := make([]domain.ReviewerPool, 0, len(r.Fallbacks))
+	for _, fallback := range r.Fallbacks {
+		fallbacks = append(fallbacks, domain.ReviewerPool{Kind: fallback.PoolType, Name: fallback.PoolName})
+	}
+	return fallbacks
+}
+
+type moveTeamUsersRequest struct {
+	TeamName string   `json:"team_name" validate:"required"`
+	UserIDs  []string `json:"user_ids" validate:"required,min=1,dive,required"`
+}
+
+type moveTeamUsersResponse struct {
+	TeamName      string                        `json:"team_name"`
+	Moved         []string                      `json:"moved_user_ids"`
+	Reassignments []reviewerReplacementResponse `json:"reassignments"`
+}
+
+type deleteTeamResponse struct {
+	TeamName string `json:"team_name"`
+	// MovedTo is empty if the team had no members
+	MovedTo       string                        `json:"moved_to,omitempty"`
+	Moved         []string                      `json:"moved_user_ids"`
+	Reassignments []reviewerReplacementResponse `json:"reassignments"`
+}
+
+// fromDomainMove converts moved users and replacements to moveTeamUsersResponse
+func fromDomainMove(
+	teamName string,
+	users []domain.User,
+	replacements []domain.ReviewerReplacement,
+) moveTeamUsersResponse {
+	return moveTeamUsersResponse{
+		TeamName:      teamName,
+		Moved:         userIDsOf(users),
+		Reassignments: fromDomainReplacements(replacements),
 	}
 }
 
@@ -146,9 +406,29 @@ type setUserIsActiveRequest struct {
 	IsActive bool   `json:"is_active"`
 }
 
+type setUserMaxOpenReviewsRequest struct {
+	UserID         string `json:"user_id" validate:"required"`
+	MaxOpenReviews int    `json:"max_open_reviews" validate:"gte=0"`
+}
+
 type reviewPRsResponse struct {
 	UserID       string                     `json:"user_id"`
 	PullRequests []pullRequestShortResponse `json:"pull_requests"`
+	// OpenReviews is the current load of the user: reviews of OPEN pull requests
+	OpenReviews    int `json:"open_reviews"`
+	MaxOpenReviews int `json:"max_open_reviews,omitempty"`
+}
+
+// userListResponse is a page of /users/list.
+// NextCursor is passed as the cursor query param to get the next page, it is empty on the last page
+type userListResponse struct {
+	Users      []UserResponse `json:"users"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+type deleteUserResponse struct {
+	User          UserResponse                  `json:"user"`
+	Reassignments []reviewerReplacementResponse `json:"reassignments"`
 }
 
 // PullRequests requests/responses
@@ -157,19 +437,27 @@ type createPRRequest struct {
 	PullRequestID   string `json:"pull_request_id" validate:"required"`
 	PullRequestName string `json:"pull_request_name" validate:"required"`
 	AuthorID        string `json:"author_id" validate:"required"`
+	// Draft creates the PR as DRAFT, reviewers are assigned once it is marked ready
+	Draft bool `json:"draft"`
 }
 
 func (r createPRRequest) ToDomain() domain.PullRequest {
+	status := domain.PRStatusOpen
+	if r.Draft {
+		status = domain.PRStatusDraft
+	}
 	return domain.PullRequest{ //nolint:exhaustruct
 		ID:       r.PullRequestID,
 		Name:     r.PullRequestName,
 		AuthorID: r.AuthorID,
-		Status:   domain.PRStatusOpen,
+		Status:   status,
 	}
 }
 
 type mergePRRequest struct {
 	PullRequestID string `json:"pull_request_id" validate:"required"`
+	// Force merges the PR even if it doesn't meet the merge policy
+	Force bool `json:"force"`
 }
 
 type reassignReviewerRequest struct {
@@ -177,23 +465,293 @@ type reassignReviewerRequest struct {
 	OldUserID     string `json:"old_user_id" validate:"required"`
 }
 
+// pullRequestListResponse is a page of /pullRequest/list.
+// NextCursor is passed as the cursor query param to get the next page, it is empty on the last page
+type pullRequestListResponse struct {
+	PullRequests []pullRequestResponse `json:"pull_requests"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
+// prStatusRequest is the body of the requests that move a PR between statuses: ready, close and reopen
+type prStatusRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required"`
+}
+
+type reviewPRRequest struct {
+	PullRequestID string               `json:"pull_request_id" validate:"required"`
+	ReviewerID    string               `json:"reviewer_id" validate:"required"`
+	Verdict       domain.ReviewVerdict `json:"verdict" validate:"required,oneof=APPROVED CHANGES_REQUESTED COMMENTED"`
+}
+
 type reassignReviewerResponse struct {
 	PR         pullRequestResponse `json:"pr"`
 	ReplacedBy string              `json:"replaced_by"`
 }
 
 type UserResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	TeamName       string `json:"team_name"`
+	IsActive       bool   `json:"is_active"`
+	MaxOpenReviews int    `json:"max_open_reviews,omitempty"`
 }
 
 func fromDomainUser(user domain.User) UserResponse {
 	return UserResponse{
-		UserID:   user.ID,
-		Username: user.Username,
-		TeamName: user.TeamName,
-		IsActive: user.IsActive,
+		UserID:         user.ID,
+		Username:       user.Username,
+		TeamName:       user.TeamName,
+		IsActive:       user.IsActive,
+		MaxOpenReviews: user.MaxOpenReviews,
+	}
+}
+
+// auditEventResponse is an entry of the audit log. Before and After hold the entity snapshots as they were stored,
+// missing snapshots are encoded as null
+type auditEventResponse struct {
+	ID         int64                  `json:"id"`
+	Action     domain.AuditAction     `json:"action"`
+	EntityType domain.AuditEntityType `json:"entity_type"`
+	EntityID   string                 `json:"entity_id"`
+	Actor      string                 `json:"actor"`
+	RequestID  string                 `json:"request_id"`
+	Before     json.RawMessage        `json:"before"`
+	After      json.RawMessage        `json:"after"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+func fromDomainAuditEvent(event domain.AuditEvent) auditEventResponse {
+	return auditEventResponse{
+		ID:         event.ID,
+		Action:     event.Action,
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		Actor:      event.Actor,
+		RequestID:  event.RequestID,
+		Before:     event.Before,
+		After:      event.After,
+		CreatedAt:  event.CreatedAt,
 	}
 }
+
+// auditListResponse is a page of /audit/list.
+// NextCursor is passed as the cursor query param to get the next page, it is empty on the last page
+type auditListResponse struct {
+	Events     []auditEventResponse `json:"events"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+type subscribeWebhookRequest struct {
+	URL string `json:"url" validate:"required,url"`
+	// Secret signs the deliveries, a random one is generated if it is empty
+	Secret string             `json:"secret" validate:"omitempty,min=16,max=200"`
+	Events []domain.EventType `json:"events" validate:"required,min=1,dive,required"`
+}
+
+func (r *subscribeWebhookRequest) ToDomain() domain.WebhookSubscription {
+	return domain.WebhookSubscription{ //nolint:exhaustruct // ID и время выставляет хранилище
+		URL:        r.URL,
+		Secret:     r.Secret,
+		EventTypes: r.Events,
+	}
+}
+
+// webhookSubscriptionResponse is a webhook subscription. The secret is shown only once, when it is created
+type webhookSubscriptionResponse struct {
+	ID        int64              `json:"id"`
+	URL       string             `json:"url"`
+	Events    []domain.EventType `json:"events"`
+	Secret    string             `json:"secret,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+func fromDomainWebhookSubscription(subscription domain.WebhookSubscription) webhookSubscriptionResponse {
+	return webhookSubscriptionResponse{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		Events:    subscription.EventTypes,
+		Secret:    "",
+		CreatedAt: subscription.CreatedAt,
+	}
+}
+
+type unsubscribeWebhookRequest struct {
+	ID int64 `json:"id" validate:"required,gt=0"`
+}
+
+type redeliverWebhookRequest struct {
+	DeliveryID int64 `json:"delivery_id" validate:"required,gt=0"`
+}
+
+type webhookDeliveryResponse struct {
+	ID             int64                 `json:"id"`
+	SubscriptionID int64                 `json:"subscription_id"`
+	EventID        int64                 `json:"event_id"`
+	Status         domain.DeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	LastError      string                `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+func fromDomainWebhookDelivery(delivery domain.WebhookDelivery) webhookDeliveryResponse {
+	resp := webhookDeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastError:      delivery.LastError,
+		DeliveredAt:    nil,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if !delivery.DeliveredAt.IsZero() {
+		resp.DeliveredAt = &delivery.DeliveredAt
+	}
+	return resp
+}
+
+// webhookDeliveryListResponse is a page of /webhooks/deliveries.
+// NextCursor is passed as the cursor query param to get the next page, it is empty on the last page
+type webhookDeliveryListResponse struct {
+	Deliveries []webhookDeliveryResponse `json:"deliveries"`
+	NextCursor string                    `json:"next_cursor,omitempty"`
+}
+
+// externalEventResponse tells what was done with a webhook of a provider
+type externalEventResponse struct {
+	Applied       bool                    `json:"applied"`
+	Action        domain.ExternalPRAction `json:"action,omitempty"`
+	PullRequestID string                  `json:"pull_request_id,omitempty"`
+	Reason        string                  `json:"reason,omitempty"`
+}
+
+func fromDomainExternalEventResult(result domain.ExternalEventResult) externalEventResponse {
+	return externalEventResponse{
+		Applied:       result.Applied,
+		Action:        result.Event.Action,
+		PullRequestID: result.Event.PullRequestID,
+		Reason:        result.Reason,
+	}
+}
+
+type setExternalLoginRequest struct {
+	Provider domain.ExternalProvider `json:"provider" validate:"required,oneof=github gitlab"`
+	Login    string                  `json:"login" validate:"required,max=255"`
+	UserID   string                  `json:"user_id" validate:"required,max=50"`
+}
+
+func (r *setExternalLoginRequest) ToDomain() domain.ExternalLogin {
+	return domain.ExternalLogin{ //nolint:exhaustruct // Время выставляет хранилище
+		Provider: r.Provider,
+		Login:    r.Login,
+		UserID:   r.UserID,
+	}
+}
+
+type deleteExternalLoginRequest struct {
+	Provider domain.ExternalProvider `json:"provider" validate:"required,oneof=github gitlab"`
+	Login    string                  `json:"login" validate:"required"`
+}
+
+type externalLoginResponse struct {
+	Provider  domain.ExternalProvider `json:"provider"`
+	Login     string                  `json:"login"`
+	UserID    string                  `json:"user_id"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+func fromDomainExternalLogin(login domain.ExternalLogin) externalLoginResponse {
+	return externalLoginResponse{
+		Provider:  login.Provider,
+		Login:     login.Login,
+		UserID:    login.UserID,
+		CreatedAt: login.CreatedAt,
+	}
+}
+
+type setReviewerPoolRequest struct {
+	PoolName string   `json:"pool_name" validate:"required,max=100"`
+	UserIDs  []string `json:"user_ids" validate:"required,min=1,dive,required"`
+}
+
+type deleteReviewerPoolRequest struct {
+	PoolName string `json:"pool_name" validate:"required"`
+}
+
+type sharedReviewerPoolResponse struct {
+	PoolName  string         `json:"pool_name"`
+	Members   []UserResponse `json:"members"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+func fromDomainSharedReviewerPool(pool domain.SharedReviewerPool) sharedReviewerPoolResponse {
+	members := make([]UserResponse, 0, len(pool.Members))
+	for _, user := range pool.Members {
+		members = append(members, fromDomainUser(user))
+	}
+	return sharedReviewerPoolResponse{
+		PoolName:  pool.Name,
+		Members:   members,
+		CreatedAt: pool.CreatedAt,
+		UpdatedAt: pool.UpdatedAt,
+	}
+}
+
+type addAbsenceRequest struct {
+	UserID   string    `json:"user_id" validate:"required,max=50"`
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+	EndsAt   time.Time `json:"ends_at" validate:"required"`
+	Reason   string    `json:"reason" validate:"max=255"`
+}
+
+func (r *addAbsenceRequest) ToDomain() domain.Absence {
+	return domain.Absence{ //nolint:exhaustruct // ID и время выставляет хранилище
+		UserID:   r.UserID,
+		StartsAt: r.StartsAt,
+		EndsAt:   r.EndsAt,
+		Reason:   r.Reason,
+	}
+}
+
+// updateAbsenceRequest replaces the period and the reason of the absence, the user can't be changed
+type updateAbsenceRequest struct {
+	AbsenceID int64     `json:"absence_id" validate:"required,gt=0"`
+	StartsAt  time.Time `json:"starts_at" validate:"required"`
+	EndsAt    time.Time `json:"ends_at" validate:"required"`
+	Reason    string    `json:"reason" validate:"max=255"`
+}
+
+type deleteAbsenceRequest struct {
+	AbsenceID int64 `json:"absence_id" validate:"required,gt=0"`
+}
+
+type absenceResponse struct {
+	ID       int64     `json:"absence_id"`
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+	// ReassignedAt is when open reviews of the user were handed over to teammates
+	ReassignedAt *time.Time `json:"reassigned_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func fromDomainAbsence(absence domain.Absence) absenceResponse {
+	resp := absenceResponse{
+		ID:           absence.ID,
+		UserID:       absence.UserID,
+		StartsAt:     absence.StartsAt,
+		EndsAt:       absence.EndsAt,
+		Reason:       absence.Reason,
+		ReassignedAt: nil,
+		CreatedAt:    absence.CreatedAt,
+	}
+	if !absence.ReassignedAt.IsZero() {
+		resp.ReassignedAt = &absence.ReassignedAt
+	}
+	return resp
+}
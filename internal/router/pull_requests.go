@@ -1,9 +1,14 @@
 package router
 
 import (
+	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 
@@ -45,6 +50,123 @@ func (r *Router) createPullRequest(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"pr": resp})
 }
 
+func (r *Router) getPullRequest(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	prID := ctx.Query("pull_request_id")
+	if prID == "" {
+		slog.WarnContext(uCtx, "pull_request_id query param is required")
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	pr, err := r.pullRequestService.Get(uCtx, prID)
+	switch {
+	case errors.Is(err, domain.ErrPRNotFound):
+		slog.WarnContext(uCtx, "pull request not found", "pr_id", prID)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to get PR", "error", err, "pr_id", prID)
+		return fiber.ErrInternalServerError
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"pr": fromDomainPR(pr)})
+}
+
+func (r *Router) listPullRequests(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	filter, err := parsePRFilter(ctx)
+	if err != nil {
+		slog.WarnContext(uCtx, "invalid PR list query params", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	}
+	// очередь ревью участники смотрят только свою, как в /users/getReview
+	if principal, _ := domain.PrincipalFromContext(uCtx); filter.ReviewerID != "" &&
+		!canActFor(principal, filter.ReviewerID) {
+		slog.WarnContext(uCtx, "member listed reviews of another user", "reviewer_id", filter.ReviewerID,
+			"subject", principal.Subject)
+		return ctx.Status(fiber.StatusForbidden).JSON(errorResponseForbidden)
+	}
+
+	page, err := r.pullRequestService.List(uCtx, filter)
+	switch {
+	case errors.Is(err, domain.ErrInvalidPRFilter):
+		slog.WarnContext(uCtx, "invalid PR list filter", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to list PRs", "error", err)
+		return fiber.ErrInternalServerError
+	}
+
+	resp := pullRequestListResponse{PullRequests: make([]pullRequestResponse, 0, len(page.PullRequests))}
+	for _, pr := range page.PullRequests {
+		resp.PullRequests = append(resp.PullRequests, fromDomainPR(pr))
+	}
+	if page.Next != nil {
+		resp.NextCursor = encodeCursor(*page.Next)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(resp)
+}
+
+func parsePRFilter(ctx *fiber.Ctx) (domain.PullRequestFilter, error) {
+	filter := domain.PullRequestFilter{ //nolint:exhaustruct // Даты и курсор разбираются ниже
+		Status:     domain.PRStatus(ctx.Query("status")),
+		AuthorID:   ctx.Query("author_id"),
+		TeamName:   ctx.Query("team_name"),
+		ReviewerID: ctx.Query("reviewer_id"),
+	}
+
+	for param, dst := range map[string]*time.Time{
+		"created_from": &filter.CreatedFrom,
+		"created_to":   &filter.CreatedTo,
+		"merged_from":  &filter.MergedFrom,
+		"merged_to":    &filter.MergedTo,
+	} {
+		t, err := parseQueryTime(ctx.Query(param))
+		if err != nil {
+			return domain.PullRequestFilter{}, fmt.Errorf("%s: %w", param, err)
+		}
+		*dst = t
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return domain.PullRequestFilter{}, fmt.Errorf("limit: expected integer, got %q", limit)
+		}
+		filter.Limit = n
+	}
+	if cursor := ctx.Query("cursor"); cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			return domain.PullRequestFilter{}, fmt.Errorf("cursor: %w", err)
+		}
+		filter.After = &after
+	}
+	return filter, nil
+}
+
+// encodeCursor makes an opaque cursor out of the position in the PR list
+func encodeCursor(c domain.PullRequestCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID))
+}
+
+func decodeCursor(cursor string) (domain.PullRequestCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return domain.PullRequestCursor{}, errors.New("malformed cursor")
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return domain.PullRequestCursor{}, errors.New("malformed cursor")
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return domain.PullRequestCursor{}, errors.New("malformed cursor")
+	}
+	return domain.PullRequestCursor{CreatedAt: t, ID: id}, nil
+}
+
 func (r *Router) mergePullRequest(ctx *fiber.Ctx) error {
 	uCtx := ctx.UserContext()
 
@@ -57,8 +179,13 @@ func (r *Router) mergePullRequest(ctx *fiber.Ctx) error {
 		slog.WarnContext(uCtx, "validation failed for merge PR request", "error", err)
 		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
 	}
+	if principal, _ := domain.PrincipalFromContext(uCtx); req.Force && !principal.HasRole(domain.RoleAdmin) {
+		slog.WarnContext(uCtx, "force merge by a non-admin", "pr_id", req.PullRequestID, "subject", principal.Subject)
+		return ctx.Status(fiber.StatusForbidden).JSON(newErrorResponse("only admins can force merge", errorCodeForbidden))
+	}
 
-	pr, err := r.pullRequestService.Merge(uCtx, req.PullRequestID)
+	pr, err := r.pullRequestService.Merge(uCtx, req.PullRequestID, req.Force)
+	var policyErr *domain.MergePolicyError
 	switch {
 	case errors.Is(err, domain.ErrPRNotFound):
 		slog.WarnContext(uCtx, "pull request not found on merge", "pr_id", req.PullRequestID)
@@ -66,6 +193,12 @@ func (r *Router) mergePullRequest(ctx *fiber.Ctx) error {
 	case errors.Is(err, domain.ErrPRAlreadyMerged):
 		slog.WarnContext(uCtx, "pull request already merged", "pr_id", req.PullRequestID)
 		return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"pr": fromDomainPR(pr)})
+	case errors.Is(err, domain.ErrInvalidPRTransition):
+		slog.WarnContext(uCtx, "pull request cannot be merged in its status", "pr_id", req.PullRequestID)
+		return ctx.Status(fiber.StatusConflict).JSON(newErrorResponse("only open PR can be merged", errorCodeTransition))
+	case errors.As(err, &policyErr):
+		slog.WarnContext(uCtx, "merge policy not met", "pr_id", req.PullRequestID, "violations", policyErr.Violations)
+		return ctx.Status(fiber.StatusConflict).JSON(newErrorResponse(policyErr.Error(), errorCodeMergePolicy))
 	case err != nil:
 		slog.ErrorContext(uCtx, "failed to merge PR", "error", err)
 		return fiber.ErrInternalServerError
@@ -113,6 +246,16 @@ func (r *Router) reassignReviewer(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusConflict).JSON(
 			newErrorResponse("no active replacement candidate in team", errorCodeNoCandidate),
 		)
+	case errors.Is(err, domain.ErrPRAlreadyMerged):
+		slog.WarnContext(uCtx, "reassign on merged PR", "pr_id", req.PullRequestID)
+		return ctx.Status(fiber.StatusConflict).JSON(
+			newErrorResponse("cannot reassign on merged PR", errorCodePRMerged),
+		)
+	case errors.Is(err, domain.ErrPRNotOpen):
+		slog.WarnContext(uCtx, "reassign on PR that is not open", "pr_id", req.PullRequestID)
+		return ctx.Status(fiber.StatusConflict).JSON(
+			newErrorResponse("cannot reassign on PR that is not open", errorCodePRNotOpen),
+		)
 	case err != nil:
 		slog.ErrorContext(uCtx, "failed to reassign reviewer", "error", err)
 		return fiber.ErrInternalServerError
@@ -121,3 +264,87 @@ func (r *Router) reassignReviewer(ctx *fiber.Ctx) error {
 	resp := reassignReviewerResponse{PR: fromDomainPR(*pr), ReplacedBy: newID}
 	return ctx.Status(fiber.StatusOK).JSON(resp)
 }
+
+func (r *Router) reviewPullRequest(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req reviewPRRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse review request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for review request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+	if principal, _ := domain.PrincipalFromContext(uCtx); !canActFor(principal, req.ReviewerID) {
+		slog.WarnContext(uCtx, "member reviewed on behalf of another user", "pr_id", req.PullRequestID,
+			"reviewer_id", req.ReviewerID, "subject", principal.Subject)
+		return ctx.Status(fiber.StatusForbidden).JSON(errorResponseForbidden)
+	}
+
+	pr, err := r.pullRequestService.Review(uCtx, req.PullRequestID, req.ReviewerID, req.Verdict)
+	switch {
+	case errors.Is(err, domain.ErrPRNotFound) || errors.Is(err, domain.ErrUserNotFound):
+		slog.WarnContext(uCtx, "pr or user not found on review", "pr_id", req.PullRequestID, "reviewer_id", req.ReviewerID)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case errors.Is(err, domain.ErrReviewerNotAssigned):
+		slog.WarnContext(uCtx, "reviewer not assigned to PR", "pr_id", req.PullRequestID, "reviewer_id", req.ReviewerID)
+		return ctx.Status(fiber.StatusConflict).JSON(
+			newErrorResponse("reviewer is not assigned to this PR", errorCodeNotAssigned),
+		)
+	case errors.Is(err, domain.ErrPRAlreadyMerged):
+		slog.WarnContext(uCtx, "review submitted on merged PR", "pr_id", req.PullRequestID)
+		return ctx.Status(fiber.StatusConflict).JSON(
+			newErrorResponse("cannot review merged PR", errorCodePRMerged),
+		)
+	case errors.Is(err, domain.ErrPRNotOpen):
+		slog.WarnContext(uCtx, "review submitted on PR that is not open", "pr_id", req.PullRequestID)
+		return ctx.Status(fiber.StatusConflict).JSON(
+			newErrorResponse("cannot review PR that is not open", errorCodePRNotOpen),
+		)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to review PR", "error", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"pr": fromDomainPR(pr)})
+}
+
+// changePullRequestStatus returns a handler that moves a PR to another status with change.
+// action names the transition in logs and error messages
+func (r *Router) changePullRequestStatus(
+	action string,
+	change func(ctx context.Context, prID string) (domain.PullRequest, error),
+) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		uCtx := ctx.UserContext()
+
+		var req prStatusRequest
+		if err := ctx.BodyParser(&req); err != nil {
+			slog.ErrorContext(uCtx, "failed to parse PR status request", "action", action, "error", err)
+			return fiber.ErrBadRequest
+		}
+		if err := r.validator.StructCtx(uCtx, req); err != nil {
+			slog.WarnContext(uCtx, "validation failed for PR status request", "action", action, "error", err)
+			return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+		}
+
+		pr, err := change(uCtx, req.PullRequestID)
+		switch {
+		case errors.Is(err, domain.ErrPRNotFound):
+			slog.WarnContext(uCtx, "pull request not found", "action", action, "pr_id", req.PullRequestID)
+			return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+		case errors.Is(err, domain.ErrInvalidPRTransition) || errors.Is(err, domain.ErrPRAlreadyMerged):
+			slog.WarnContext(uCtx, "invalid PR status transition", "action", action, "pr_id", req.PullRequestID)
+			return ctx.Status(fiber.StatusConflict).JSON(
+				newErrorResponse(fmt.Sprintf("cannot %s PR in its current status", action), errorCodeTransition),
+			)
+		case err != nil:
+			slog.ErrorContext(uCtx, "failed to change PR status", "action", action, "error", err)
+			return fiber.ErrInternalServerError
+		}
+
+		return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"pr": fromDomainPR(pr)})
+	}
+}
@@ -0,0 +1,85 @@
+package router
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// setReviewerPool creates the shared reviewer pool or replaces its members
+func (r *Router) setReviewerPool(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req setReviewerPoolRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse set reviewer pool request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for set reviewer pool request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	pool, err := r.reviewerPoolService.Set(uCtx, req.PoolName, req.UserIDs)
+	switch {
+	case errors.Is(err, domain.ErrInvalidReviewerPool):
+		slog.WarnContext(uCtx, "invalid reviewer pool", "pool_name", req.PoolName, "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case errors.Is(err, domain.ErrUserNotFound):
+		slog.WarnContext(uCtx, "reviewer pool members not found", "pool_name", req.PoolName, "user_ids", req.UserIDs)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to set reviewer pool", "error", err, "pool_name", req.PoolName)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"pool": fromDomainSharedReviewerPool(pool)})
+}
+
+func (r *Router) getReviewerPool(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+	poolName := ctx.Query("pool_name")
+	if poolName == "" {
+		slog.WarnContext(uCtx, "pool_name query param is required")
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	pool, err := r.reviewerPoolService.Get(uCtx, poolName)
+	switch {
+	case errors.Is(err, domain.ErrReviewerPoolNotFound):
+		slog.WarnContext(uCtx, "reviewer pool not found", "pool_name", poolName)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to get reviewer pool", "error", err, "pool_name", poolName)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"pool": fromDomainSharedReviewerPool(pool)})
+}
+
+// deleteReviewerPool deletes the shared reviewer pool, teams falling back to it lose it
+func (r *Router) deleteReviewerPool(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req deleteReviewerPoolRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse delete reviewer pool request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for delete reviewer pool request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	err := r.reviewerPoolService.Delete(uCtx, req.PoolName)
+	switch {
+	case errors.Is(err, domain.ErrReviewerPoolNotFound):
+		slog.WarnContext(uCtx, "reviewer pool not found", "pool_name", req.PoolName)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to delete reviewer pool", "error", err, "pool_name", req.PoolName)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.SendStatus(fiber.StatusOK)
+}
@@ -19,23 +19,86 @@ import (
 )
 
 type iUserService interface {
+	Get(ctx context.Context, userID string) (domain.User, error)
+	List(ctx context.Context, filter domain.UserFilter) (domain.UserPage, error)
+	Delete(ctx context.Context, userID string) (domain.User, []domain.ReviewerReplacement, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) (domain.User, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error)
 }
 
 type iPullRequestService interface {
 	GetReviewingPRs(ctx context.Context, userID string) ([]domain.PullRequest, error)
 	Create(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error)
-	Merge(ctx context.Context, prID string) (domain.PullRequest, error)
+	Get(ctx context.Context, prID string) (domain.PullRequest, error)
+	List(ctx context.Context, filter domain.PullRequestFilter) (domain.PullRequestPage, error)
+	Merge(ctx context.Context, prID string, force bool) (domain.PullRequest, error)
 	ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error)
+	Review(ctx context.Context, prID, reviewerID string, verdict domain.ReviewVerdict) (domain.PullRequest, error)
+	MarkReady(ctx context.Context, prID string) (domain.PullRequest, error)
+	Close(ctx context.Context, prID string) (domain.PullRequest, error)
+	Reopen(ctx context.Context, prID string) (domain.PullRequest, error)
 }
 
 type iTeamService interface {
-	Add(ctx context.Context, team domain.Team) (domain.Team, error)
+	Add(ctx context.Context, team domain.Team, mode domain.TeamAddMode) (domain.TeamAddResult, error)
 	Get(ctx context.Context, teamName string) (domain.Team, error)
+	DeactivateUsers(
+		ctx context.Context,
+		teamName string,
+		userIDs []string,
+	) ([]domain.User, []domain.ReviewerReplacement, error)
+	Rename(ctx context.Context, teamName, newName string) (domain.Team, error)
+	Delete(ctx context.Context, teamName, moveTo string) ([]domain.User, []domain.ReviewerReplacement, error)
+	MoveUsers(
+		ctx context.Context,
+		teamName string,
+		userIDs []string,
+	) ([]domain.User, []domain.ReviewerReplacement, error)
+	SetFallbacks(ctx context.Context, teamName string, fallbacks []domain.ReviewerPool) (domain.Team, error)
+}
+
+type iReviewerPoolService interface {
+	Set(ctx context.Context, poolName string, userIDs []string) (domain.SharedReviewerPool, error)
+	Get(ctx context.Context, poolName string) (domain.SharedReviewerPool, error)
+	Delete(ctx context.Context, poolName string) error
+}
+
+type iAuditService interface {
+	List(ctx context.Context, filter domain.AuditFilter) (domain.AuditPage, error)
+}
+
+type iWebhookService interface {
+	Subscribe(ctx context.Context, subscription domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	Unsubscribe(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) (domain.WebhookDeliveryPage, error)
+	Redeliver(ctx context.Context, id int64) (domain.WebhookDelivery, error)
+}
+
+type iExternalEventService interface {
+	HandleGitHub(ctx context.Context, event, signature string, body []byte) (domain.ExternalEventResult, error)
+	HandleGitLab(ctx context.Context, event, token string, body []byte) (domain.ExternalEventResult, error)
+	SetLogin(ctx context.Context, login domain.ExternalLogin) (domain.ExternalLogin, error)
+	ListLogins(ctx context.Context, provider domain.ExternalProvider) ([]domain.ExternalLogin, error)
+	DeleteLogin(ctx context.Context, provider domain.ExternalProvider, login string) error
+}
+
+type iAbsenceService interface {
+	Add(ctx context.Context, absence domain.Absence) (domain.Absence, error)
+	Get(ctx context.Context, id int64) (domain.Absence, error)
+	List(ctx context.Context, filter domain.AbsenceFilter) ([]domain.Absence, error)
+	Update(ctx context.Context, absence domain.Absence) (domain.Absence, error)
+	Delete(ctx context.Context, id int64) error
 }
 
 type iStatsRetriever interface {
-	RetrieveStats(ctx context.Context) ([]stats_retriever.Stats, error)
+	RetrieveStats(ctx context.Context, filter stats_retriever.Filter) (stats_retriever.Stats, error)
+}
+
+// iMetrics records HTTP metrics and serves all of them. It is optional, without it /metrics responds with 404
+type iMetrics interface {
+	Middleware() fiber.Handler
+	Handler() fiber.Handler
 }
 
 type Config struct {
@@ -49,10 +112,17 @@ type Router struct {
 	router    *fiber.App
 	validator *validator.Validate
 
-	userService        iUserService
-	pullRequestService iPullRequestService
-	teamService        iTeamService
-	statsRetriever     iStatsRetriever
+	userService         iUserService
+	pullRequestService  iPullRequestService
+	teamService         iTeamService
+	reviewerPoolService iReviewerPoolService
+	auditService        iAuditService
+	webhookService      iWebhookService
+	externalService     iExternalEventService
+	absenceService      iAbsenceService
+	statsRetriever      iStatsRetriever
+	metrics             iMetrics
+	authenticator       iAuthenticator
 }
 
 func New(
@@ -60,18 +130,35 @@ func New(
 	userService iUserService,
 	pullRequestService iPullRequestService,
 	teamService iTeamService,
+	reviewerPoolService iReviewerPoolService,
+	auditService iAuditService,
+	webhookService iWebhookService,
+	externalService iExternalEventService,
+	absenceService iAbsenceService,
 	statsRetriever iStatsRetriever,
+	metrics iMetrics,
+	authenticator iAuthenticator,
 ) *Router {
 	app := fiber.New()
+	if metrics == nil {
+		metrics = nopMetrics{}
+	}
 
 	router := &Router{
-		config:             config,
-		router:             app,
-		userService:        userService,
-		pullRequestService: pullRequestService,
-		teamService:        teamService,
-		statsRetriever:     statsRetriever,
-		validator:          validator.New(validator.WithRequiredStructEnabled()),
+		config:              config,
+		router:              app,
+		userService:         userService,
+		pullRequestService:  pullRequestService,
+		teamService:         teamService,
+		reviewerPoolService: reviewerPoolService,
+		auditService:        auditService,
+		webhookService:      webhookService,
+		externalService:     externalService,
+		absenceService:      absenceService,
+		statsRetriever:      statsRetriever,
+		metrics:             metrics,
+		authenticator:       authenticator,
+		validator:           validator.New(validator.WithRequiredStructEnabled()),
 	}
 	router.initMiddlewares()
 	router.initRoutes()
@@ -80,10 +167,12 @@ func New(
 }
 
 func (r *Router) initMiddlewares() {
+	r.router.Use(r.metrics.Middleware())
 	r.router.Use(slogfiber.New(slog.Default()))
 	r.router.Use(_recover.New())
 	r.router.Use(healthcheck.New())
 	r.router.Use(requestid.New())
+	r.router.Use(auditContext)
 	r.router.Use(
 		swagger.New(swagger.Config{ //nolint:exhaustruct
 			BasePath: "/",
@@ -94,31 +183,74 @@ func (r *Router) initMiddlewares() {
 	)
 }
 
+// initRoutes registers the routes with the roles allowed to call them.
+// Provider webhooks check their own signatures, metrics are scraped without a token
 func (r *Router) initRoutes() {
+	leads := []domain.Role{domain.RoleAdmin, domain.RoleTeamLead}
+
 	teams := r.router.Group("/team")
-	teams.Post("/add", r.addTeam)
-	teams.Get("/get", r.getTeam)
+	teams.Post("/add", r.protected(r.addTeam, leads...)...)
+	teams.Get("/get", r.protected(r.getTeam)...)
+	teams.Post("/deactivateUsers", r.protected(r.deactivateTeamUsers, leads...)...)
+	teams.Post("/moveUsers", r.protected(r.moveTeamUsers, leads...)...)
+	teams.Post("/rename", r.protected(r.renameTeam, domain.RoleAdmin)...)
+	teams.Post("/delete", r.protected(r.deleteTeam, domain.RoleAdmin)...)
+	teams.Post("/setFallbacks", r.protected(r.setTeamFallbacks, domain.RoleAdmin)...)
+
+	pools := r.router.Group("/reviewerPools")
+	pools.Post("/set", r.protected(r.setReviewerPool, domain.RoleAdmin)...)
+	pools.Get("/get", r.protected(r.getReviewerPool)...)
+	pools.Post("/delete", r.protected(r.deleteReviewerPool, domain.RoleAdmin)...)
 
 	users := r.router.Group("/users")
-	users.Post("/setIsActive", r.setUserIsActive)
-	users.Get("/getReview", r.getUserReview)
+	users.Post("/setIsActive", r.protected(r.setUserIsActive, leads...)...)
+	users.Post("/setMaxOpenReviews", r.protected(r.setUserMaxOpenReviews, leads...)...)
+	users.Get("/getReview", r.protected(r.getUserReview)...)
+	users.Get("/get", r.protected(r.getUser)...)
+	users.Get("/list", r.protected(r.listUsers)...)
+	users.Delete("/delete", r.protected(r.deleteUser, domain.RoleAdmin)...)
 
 	prs := r.router.Group("/pullRequest")
-	prs.Post("/create", r.createPullRequest)
-	prs.Post("/merge", r.mergePullRequest)
-	prs.Post("/reassign", r.reassignReviewer)
+	prs.Post("/create", r.protected(r.createPullRequest)...)
+	prs.Get("/get", r.protected(r.getPullRequest)...)
+	prs.Get("/list", r.protected(r.listPullRequests)...)
+	prs.Post("/merge", r.protected(r.mergePullRequest)...)
+	prs.Post("/reassign", r.protected(r.reassignReviewer)...)
+	prs.Post("/review", r.protected(r.reviewPullRequest)...)
+	prs.Post("/ready", r.protected(r.changePullRequestStatus("ready", r.pullRequestService.MarkReady))...)
+	prs.Post("/close", r.protected(r.changePullRequestStatus("close", r.pullRequestService.Close))...)
+	prs.Post("/reopen", r.protected(r.changePullRequestStatus("reopen", r.pullRequestService.Reopen))...)
+
+	r.router.Get("/audit/list", r.protected(r.listAuditEvents, domain.RoleAdmin)...)
+
+	webhooks := r.router.Group("/webhooks")
+	webhooks.Post("/subscribe", r.protected(r.subscribeWebhook, domain.RoleAdmin)...)
+	webhooks.Get("/list", r.protected(r.listWebhooks, domain.RoleAdmin)...)
+	webhooks.Post("/unsubscribe", r.protected(r.unsubscribeWebhook, domain.RoleAdmin)...)
+	webhooks.Get("/deliveries", r.protected(r.listWebhookDeliveries, domain.RoleAdmin)...)
+	webhooks.Post("/redeliver", r.protected(r.redeliverWebhook, domain.RoleAdmin)...)
+	webhooks.Post("/github", r.handleGitHubWebhook)
+	webhooks.Post("/gitlab", r.handleGitLabWebhook)
+
+	externalLogins := r.router.Group("/externalLogins")
+	externalLogins.Post("/set", r.protected(r.setExternalLogin, domain.RoleAdmin)...)
+	externalLogins.Get("/list", r.protected(r.listExternalLogins, domain.RoleAdmin)...)
+	externalLogins.Post("/delete", r.protected(r.deleteExternalLogin, domain.RoleAdmin)...)
+
+	// участники планируют только свои отсутствия, это проверяют обработчики
+	absences := r.router.Group("/absences")
+	absences.Post("/add", r.protected(r.addAbsence)...)
+	absences.Get("/list", r.protected(r.listAbsences)...)
+	absences.Post("/update", r.protected(r.updateAbsence)...)
+	absences.Post("/delete", r.protected(r.deleteAbsence)...)
+
+	r.router.Get("/metrics", r.metrics.Handler())
 
 	if r.statsRetriever == nil {
 		return
 	}
 
-	r.router.Get("/stats/get", func(c *fiber.Ctx) error {
-		s, err := r.statsRetriever.RetrieveStats(c.UserContext())
-		if err != nil {
-			return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("failed to retrieve stats: %v", err))
-		}
-		return c.JSON(s)
-	})
+	r.router.Get("/stats/get", r.protected(r.getStats)...)
 }
 
 func (r *Router) Run() error {
@@ -0,0 +1,68 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	stats_retriever "github.com/artmexbet/avito_test_task/internal/stats-retriever"
+)
+
+// queryDateLayout is accepted in time query params along with RFC 3339
+const queryDateLayout = time.DateOnly
+
+func (r *Router) getStats(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	filter, err := parseStatsFilter(ctx)
+	if err != nil {
+		slog.WarnContext(uCtx, "invalid stats query params", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	}
+
+	s, err := r.statsRetriever.RetrieveStats(uCtx, filter)
+	if errors.Is(err, stats_retriever.ErrInvalidFilter) {
+		slog.WarnContext(uCtx, "invalid stats filter", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	} else if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("failed to retrieve stats: %v", err))
+	}
+	return ctx.JSON(s)
+}
+
+func parseStatsFilter(ctx *fiber.Ctx) (stats_retriever.Filter, error) {
+	from, err := parseQueryTime(ctx.Query("from"))
+	if err != nil {
+		return stats_retriever.Filter{}, fmt.Errorf("from: %w", err)
+	}
+	to, err := parseQueryTime(ctx.Query("to"))
+	if err != nil {
+		return stats_retriever.Filter{}, fmt.Errorf("to: %w", err)
+	}
+
+	return stats_retriever.Filter{
+		From:     from,
+		To:       to,
+		TeamName: ctx.Query("team_name"),
+		UserID:   ctx.Query("user_id"),
+		Period:   stats_retriever.Period(ctx.Query("period")),
+	}, nil
+}
+
+// parseQueryTime parses RFC 3339 timestamps and plain dates, which are taken as midnight UTC
+func parseQueryTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(queryDateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC 3339 timestamp or %s date, got %q", queryDateLayout, value)
+	}
+	return t, nil
+}
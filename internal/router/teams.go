@@ -10,6 +10,8 @@ import (
 	"github.com/artmexbet/avito_test_task/internal/domain"
 )
 
+// addTeam adds the team in the requested mode, conflicting members fail the whole request.
+// The replace mode deactivates unlisted members and reports them as deactivated
 func (r *Router) addTeam(ctx *fiber.Ctx) error {
 	uCtx := ctx.UserContext()
 
@@ -24,12 +26,14 @@ func (r *Router) addTeam(ctx *fiber.Ctx) error {
 		slog.ErrorContext(uCtx, "validation failed for add team request", "error", err)
 		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
 	}
-
-	team, err := r.teamService.Add(uCtx, req.ToDomain())
-	if err != nil && !errors.Is(err, domain.ErrTeamAlreadyExists) {
-		slog.ErrorContext(uCtx, "failed to add team", "error", err)
+	if done, err := r.authorizeTeam(ctx, req.TeamName, req.memberIDs()...); done {
 		return err
-	} else if errors.Is(err, domain.ErrTeamAlreadyExists) {
+	}
+
+	result, err := r.teamService.Add(uCtx, req.ToDomain(), req.mode())
+	var conflictErr *domain.TeamConflictError
+	switch {
+	case errors.Is(err, domain.ErrTeamAlreadyExists):
 		slog.ErrorContext(uCtx, "team already exists", "team_name", req.TeamName)
 		return ctx.Status(fiber.StatusBadRequest).
 			JSON(
@@ -38,12 +42,23 @@ func (r *Router) addTeam(ctx *fiber.Ctx) error {
 					errorCodeTeamExist,
 				),
 			)
+	case errors.As(err, &conflictErr):
+		slog.WarnContext(uCtx, "team members conflict", "team_name", req.TeamName, "error", err)
+		return ctx.Status(fiber.StatusConflict).JSON(newTeamConflictResponse(conflictErr.Conflicts))
+	case errors.Is(err, domain.ErrTeamMemberConflict):
+		// Пользователя успели создать параллельным запросом
+		slog.WarnContext(uCtx, "team members conflict", "team_name", req.TeamName, "error", err)
+		return ctx.Status(fiber.StatusConflict).JSON(newErrorResponse(err.Error(), errorCodeTeamConflict))
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to add team", "error", err)
+		return err
 	}
 
-	resp := fromDomainTeam(team)
-
-	// Не стал делать отдельную структуру ответа, нет нужды здесь
-	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"team": resp})
+	status := fiber.StatusOK
+	if result.TeamCreated {
+		status = fiber.StatusCreated
+	}
+	return ctx.Status(status).JSON(fromDomainTeamAdd(result))
 }
 
 func (r *Router) getTeam(ctx *fiber.Ctx) error {
@@ -64,3 +79,174 @@ func (r *Router) getTeam(ctx *fiber.Ctx) error {
 
 	return ctx.Status(fiber.StatusOK).JSON(resp)
 }
+
+func (r *Router) deactivateTeamUsers(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req deactivateTeamUsersRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse deactivate team users request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for deactivate team users request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+	if done, err := r.authorizeTeam(ctx, req.TeamName); done {
+		return err
+	}
+
+	users, replacements, err := r.teamService.DeactivateUsers(uCtx, req.TeamName, req.UserIDs)
+	switch {
+	case errors.Is(err, domain.ErrTeamNotFound) || errors.Is(err, domain.ErrUserNotFound):
+		slog.WarnContext(uCtx, "team or users not found on deactivation",
+			"team_name", req.TeamName,
+			"user_ids", req.UserIDs)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to deactivate team users", "error", err, "team_name", req.TeamName)
+		return fiber.ErrInternalServerError
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fromDomainDeactivation(req.TeamName, users, replacements))
+}
+
+func (r *Router) renameTeam(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req renameTeamRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse rename team request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for rename team request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	team, err := r.teamService.Rename(uCtx, req.TeamName, req.NewTeamName)
+	switch {
+	case errors.Is(err, domain.ErrInvalidTeamChange):
+		slog.WarnContext(uCtx, "invalid team rename", "team_name", req.TeamName, "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case errors.Is(err, domain.ErrTeamAlreadyExists):
+		slog.WarnContext(uCtx, "team already exists", "team_name", req.NewTeamName)
+		return ctx.Status(fiber.StatusBadRequest).
+			JSON(newErrorResponse(fmt.Sprintf("%s already exists", req.NewTeamName), errorCodeTeamExist))
+	case errors.Is(err, domain.ErrTeamNotFound):
+		slog.WarnContext(uCtx, "team not found on rename", "team_name", req.TeamName)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to rename team", "error", err, "team_name", req.TeamName)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"team": fromDomainTeam(team)})
+}
+
+// deleteTeam deletes the team, its members are moved to move_members_to beforehand
+func (r *Router) deleteTeam(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req deleteTeamRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse delete team request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for delete team request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	users, replacements, err := r.teamService.Delete(uCtx, req.TeamName, req.MoveMembersTo)
+	switch {
+	case errors.Is(err, domain.ErrInvalidTeamChange):
+		slog.WarnContext(uCtx, "invalid team deletion", "team_name", req.TeamName, "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case errors.Is(err, domain.ErrTeamNotEmpty):
+		slog.WarnContext(uCtx, "team with members deleted without move_members_to", "team_name", req.TeamName)
+		return ctx.Status(fiber.StatusConflict).
+			JSON(newErrorResponse("team has members, set move_members_to", errorCodeTeamNotEmpty))
+	case errors.Is(err, domain.ErrTeamNotFound):
+		slog.WarnContext(uCtx, "team not found on deletion",
+			"team_name", req.TeamName,
+			"move_members_to", req.MoveMembersTo)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to delete team", "error", err, "team_name", req.TeamName)
+		return fiber.ErrInternalServerError
+	}
+
+	resp := deleteTeamResponse{
+		TeamName:      req.TeamName,
+		MovedTo:       "",
+		Moved:         userIDsOf(users),
+		Reassignments: fromDomainReplacements(replacements),
+	}
+	if len(users) > 0 {
+		resp.MovedTo = req.MoveMembersTo
+	}
+	return ctx.Status(fiber.StatusOK).JSON(resp)
+}
+
+// moveTeamUsers moves users to the team given in team_name. A team lead may move only members of their own team
+func (r *Router) moveTeamUsers(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req moveTeamUsersRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse move team users request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for move team users request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+	if done, err := r.authorizeTeam(ctx, "", req.UserIDs...); done {
+		return err
+	}
+
+	users, replacements, err := r.teamService.MoveUsers(uCtx, req.TeamName, req.UserIDs)
+	switch {
+	case errors.Is(err, domain.ErrInvalidTeamChange):
+		slog.WarnContext(uCtx, "invalid move of users", "team_name", req.TeamName, "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case errors.Is(err, domain.ErrTeamNotFound) || errors.Is(err, domain.ErrUserNotFound):
+		slog.WarnContext(uCtx, "team or users not found on move",
+			"team_name", req.TeamName,
+			"user_ids", req.UserIDs)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to move users", "error", err, "team_name", req.TeamName)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fromDomainMove(req.TeamName, users, replacements))
+}
+
+// setTeamFallbacks replaces the pools reviewers are picked from when the team can't supply enough of them
+func (r *Router) setTeamFallbacks(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req setTeamFallbacksRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse set team fallbacks request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for set team fallbacks request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	team, err := r.teamService.SetFallbacks(uCtx, req.TeamName, req.ToDomain())
+	switch {
+	case errors.Is(err, domain.ErrInvalidTeamChange):
+		slog.WarnContext(uCtx, "invalid team fallbacks", "team_name", req.TeamName, "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case errors.Is(err, domain.ErrTeamNotFound) || errors.Is(err, domain.ErrReviewerPoolNotFound):
+		slog.WarnContext(uCtx, "team or fallback pool not found", "team_name", req.TeamName, "error", err)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to set team fallbacks", "error", err, "team_name", req.TeamName)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"team": fromDomainTeam(team)})
+}
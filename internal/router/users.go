@@ -2,7 +2,9 @@ package router
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 
@@ -23,6 +25,9 @@ func (r *Router) setUserIsActive(ctx *fiber.Ctx) error {
 		slog.WarnContext(uCtx, "validation failed for set user is active request", "request", req)
 		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
 	}
+	if done, err := r.authorizeTeam(ctx, "", req.UserID); done {
+		return err
+	}
 
 	user, err := r.userService.SetIsActive(uCtx, req.UserID, req.IsActive)
 	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
@@ -41,6 +46,38 @@ func (r *Router) setUserIsActive(ctx *fiber.Ctx) error {
 	return ctx.JSON(fiber.Map{"user": fromDomainUser(user)})
 }
 
+// setUserMaxOpenReviews sets how many open reviews the user may have, 0 removes the limit
+func (r *Router) setUserMaxOpenReviews(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req setUserMaxOpenReviewsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse set user max open reviews request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for set user max open reviews request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+	if done, err := r.authorizeTeam(ctx, "", req.UserID); done {
+		return err
+	}
+
+	user, err := r.userService.SetMaxOpenReviews(uCtx, req.UserID, req.MaxOpenReviews)
+	switch {
+	case errors.Is(err, domain.ErrInvalidReviewLimit):
+		slog.WarnContext(uCtx, "invalid review limit", "user_id", req.UserID, "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case errors.Is(err, domain.ErrUserNotFound):
+		slog.WarnContext(uCtx, "user not found when setting max open reviews", "user_id", req.UserID)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to set user max open reviews", "user_id", req.UserID, "error", err)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.JSON(fiber.Map{"user": fromDomainUser(user)})
+}
+
 func (r *Router) getUserReview(ctx *fiber.Ctx) error {
 	uCtx := ctx.UserContext()
 
@@ -49,6 +86,13 @@ func (r *Router) getUserReview(ctx *fiber.Ctx) error {
 		slog.WarnContext(uCtx, "user_id query param is required")
 		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
 	}
+	// участники видят только свои ревью
+	principal, _ := domain.PrincipalFromContext(uCtx)
+	if !canActFor(principal, userID) {
+		slog.WarnContext(uCtx, "member requested reviews of another user", "user_id", userID,
+			"subject", principal.Subject)
+		return ctx.Status(fiber.StatusForbidden).JSON(errorResponseForbidden)
+	}
 
 	prs, err := r.pullRequestService.GetReviewingPRs(uCtx, userID)
 	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
@@ -59,12 +103,23 @@ func (r *Router) getUserReview(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
 	}
 
+	user, err := r.userService.Get(uCtx, userID)
+	if err != nil {
+		slog.ErrorContext(uCtx, "failed to get user", "error", err, "user_id", userID)
+		return fiber.ErrInternalServerError
+	}
+
 	resp := reviewPRsResponse{
-		UserID:       userID,
-		PullRequests: make([]pullRequestShortResponse, 0, len(prs)),
+		UserID:         userID,
+		PullRequests:   make([]pullRequestShortResponse, 0, len(prs)),
+		OpenReviews:    0,
+		MaxOpenReviews: user.MaxOpenReviews,
 	}
 
 	for _, pr := range prs {
+		if pr.Status == domain.PRStatusOpen {
+			resp.OpenReviews++
+		}
 		resp.PullRequests = append(resp.PullRequests, pullRequestShortResponse{
 			ID:       pr.ID,
 			Name:     pr.Name,
@@ -75,3 +130,102 @@ func (r *Router) getUserReview(ctx *fiber.Ctx) error {
 
 	return ctx.Status(fiber.StatusOK).JSON(resp)
 }
+
+// getUser returns the user, deleted users are not found
+func (r *Router) getUser(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	userID := ctx.Query("user_id")
+	if userID == "" {
+		slog.WarnContext(uCtx, "user_id query param is required")
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	user, err := r.userService.Get(uCtx, userID)
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		slog.WarnContext(uCtx, "user not found", "user_id", userID)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to get user", "error", err, "user_id", userID)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.JSON(fiber.Map{"user": fromDomainUser(user)})
+}
+
+// listUsers returns a page of the user directory ordered by user ID
+func (r *Router) listUsers(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	filter, err := parseUserFilter(ctx)
+	if err != nil {
+		slog.WarnContext(uCtx, "invalid user list query params", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	}
+
+	page, err := r.userService.List(uCtx, filter)
+	switch {
+	case errors.Is(err, domain.ErrInvalidUserFilter):
+		slog.WarnContext(uCtx, "invalid user list filter", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to list users", "error", err)
+		return fiber.ErrInternalServerError
+	}
+
+	resp := userListResponse{Users: make([]UserResponse, 0, len(page.Users)), NextCursor: page.NextAfterID}
+	for _, user := range page.Users {
+		resp.Users = append(resp.Users, fromDomainUser(user))
+	}
+	return ctx.JSON(resp)
+}
+
+func parseUserFilter(ctx *fiber.Ctx) (domain.UserFilter, error) {
+	filter := domain.UserFilter{ //nolint:exhaustruct // Статус, лимит и курсор разбираются ниже
+		TeamName:       ctx.Query("team_name"),
+		UsernamePrefix: ctx.Query("username_prefix"),
+	}
+
+	if isActive := ctx.Query("is_active"); isActive != "" {
+		v, err := strconv.ParseBool(isActive)
+		if err != nil {
+			return domain.UserFilter{}, fmt.Errorf("is_active: expected boolean, got %q", isActive)
+		}
+		filter.IsActive = &v
+	}
+	if limit := ctx.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return domain.UserFilter{}, fmt.Errorf("limit: expected integer, got %q", limit)
+		}
+		filter.Limit = n
+	}
+	// Курсор - ID последнего пользователя предыдущей страницы, пользователи идут по возрастанию ID
+	filter.AfterID = ctx.Query("cursor")
+	return filter, nil
+}
+
+// deleteUser deletes the user softly and hands their open reviews over to teammates
+func (r *Router) deleteUser(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	userID := ctx.Query("user_id")
+	if userID == "" {
+		slog.WarnContext(uCtx, "user_id query param is required")
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	user, replacements, err := r.userService.Delete(uCtx, userID)
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		slog.WarnContext(uCtx, "user not found when deleting", "user_id", userID)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to delete user", "error", err, "user_id", userID)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.JSON(deleteUserResponse{
+		User:          fromDomainUser(user),
+		Reassignments: fromDomainReplacements(replacements),
+	})
+}
@@ -0,0 +1,162 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+func (r *Router) subscribeWebhook(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req subscribeWebhookRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse subscribe webhook request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for subscribe webhook request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	subscription, err := r.webhookService.Subscribe(uCtx, req.ToDomain())
+	switch {
+	case errors.Is(err, domain.ErrInvalidWebhook):
+		slog.WarnContext(uCtx, "invalid webhook subscription", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to subscribe webhook", "error", err)
+		return fiber.ErrInternalServerError
+	}
+
+	resp := fromDomainWebhookSubscription(subscription)
+	resp.Secret = subscription.Secret
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"subscription": resp})
+}
+
+func (r *Router) listWebhooks(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	subscriptions, err := r.webhookService.ListSubscriptions(uCtx)
+	if err != nil {
+		slog.ErrorContext(uCtx, "failed to list webhook subscriptions", "error", err)
+		return fiber.ErrInternalServerError
+	}
+
+	resp := make([]webhookSubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		resp = append(resp, fromDomainWebhookSubscription(subscription))
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"subscriptions": resp})
+}
+
+func (r *Router) unsubscribeWebhook(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req unsubscribeWebhookRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse unsubscribe webhook request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for unsubscribe webhook request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	err := r.webhookService.Unsubscribe(uCtx, req.ID)
+	switch {
+	case errors.Is(err, domain.ErrWebhookSubscriptionNotFound):
+		slog.WarnContext(uCtx, "webhook subscription not found", "id", req.ID)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to unsubscribe webhook", "error", err)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.SendStatus(fiber.StatusOK)
+}
+
+func (r *Router) listWebhookDeliveries(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	filter, err := parseWebhookDeliveryFilter(ctx)
+	if err != nil {
+		slog.WarnContext(uCtx, "invalid webhook deliveries query params", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	}
+
+	page, err := r.webhookService.ListDeliveries(uCtx, filter)
+	switch {
+	case errors.Is(err, domain.ErrInvalidWebhook):
+		slog.WarnContext(uCtx, "invalid webhook deliveries filter", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err.Error(), errorCodeBadRequest))
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to list webhook deliveries", "error", err)
+		return fiber.ErrInternalServerError
+	}
+
+	resp := webhookDeliveryListResponse{Deliveries: make([]webhookDeliveryResponse, 0, len(page.Deliveries))}
+	for _, delivery := range page.Deliveries {
+		resp.Deliveries = append(resp.Deliveries, fromDomainWebhookDelivery(delivery))
+	}
+	if page.NextAfterID != 0 {
+		resp.NextCursor = strconv.FormatInt(page.NextAfterID, 10)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(resp)
+}
+
+func parseWebhookDeliveryFilter(ctx *fiber.Ctx) (domain.WebhookDeliveryFilter, error) {
+	filter := domain.WebhookDeliveryFilter{ //nolint:exhaustruct // Числовые параметры разбираются ниже
+		Status: domain.DeliveryStatus(ctx.Query("status")),
+	}
+	for param, dst := range map[string]*int64{
+		"subscription_id": &filter.SubscriptionID,
+		// Курсор - ID последней доставки предыдущей страницы, доставки идут по убыванию ID
+		"cursor": &filter.AfterID,
+	} {
+		if value := ctx.Query(param); value != "" {
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return domain.WebhookDeliveryFilter{}, fmt.Errorf("%s: expected integer, got %q", param, value)
+			}
+			*dst = n
+		}
+	}
+	if limit := ctx.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return domain.WebhookDeliveryFilter{}, fmt.Errorf("limit: expected integer, got %q", limit)
+		}
+		filter.Limit = n
+	}
+	return filter, nil
+}
+
+func (r *Router) redeliverWebhook(ctx *fiber.Ctx) error {
+	uCtx := ctx.UserContext()
+
+	var req redeliverWebhookRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		slog.ErrorContext(uCtx, "failed to parse redeliver webhook request", "error", err)
+		return fiber.ErrBadRequest
+	}
+	if err := r.validator.StructCtx(uCtx, req); err != nil {
+		slog.WarnContext(uCtx, "validation failed for redeliver webhook request", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(errorBadRequest)
+	}
+
+	delivery, err := r.webhookService.Redeliver(uCtx, req.DeliveryID)
+	switch {
+	case errors.Is(err, domain.ErrWebhookDeliveryNotFound):
+		slog.WarnContext(uCtx, "dead webhook delivery not found", "delivery_id", req.DeliveryID)
+		return ctx.Status(fiber.StatusNotFound).JSON(errorResponseNotFound)
+	case err != nil:
+		slog.ErrorContext(uCtx, "failed to redeliver webhook", "error", err)
+		return fiber.ErrInternalServerError
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"delivery": fromDomainWebhookDelivery(delivery)})
+}
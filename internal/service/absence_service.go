@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/pkg/config"
+)
+
+type iAbsenceRepository interface {
+	Add(ctx context.Context, absence domain.Absence) (domain.Absence, error)
+	Get(ctx context.Context, id int64) (domain.Absence, error)
+	Update(ctx context.Context, absence domain.Absence) (domain.Absence, error)
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, filter domain.AbsenceFilter) ([]domain.Absence, error)
+	ClaimStarted(ctx context.Context, now time.Time, batchSize int) ([]domain.Absence, error)
+}
+
+type iAbsenceUserRepository interface {
+	GetByID(ctx context.Context, userID string) (domain.User, error)
+}
+
+// AbsenceService manages periods when users are away. Absent users are not picked as reviewers,
+// and the background job hands their open reviews over to teammates when the absence starts
+type AbsenceService struct {
+	absences         iAbsenceRepository
+	users            iAbsenceUserRepository
+	reviewerAssigner iReviewerAssigner
+	auditRecorder    iAuditRecorder
+	transactor       iTransactor
+	cfg              config.AbsencesConfig
+	now              func() time.Time
+}
+
+func NewAbsenceService(
+	absences iAbsenceRepository,
+	users iAbsenceUserRepository,
+	reviewerAssigner iReviewerAssigner,
+	auditRecorder iAuditRecorder,
+	transactor iTransactor,
+	cfg config.AbsencesConfig,
+) *AbsenceService {
+	return &AbsenceService{
+		absences:         absences,
+		users:            users,
+		reviewerAssigner: reviewerAssigner,
+		auditRecorder:    auditRecorder,
+		transactor:       transactor,
+		cfg:              cfg,
+		now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// normalizeAbsence checks the period and brings its bounds to UTC with the precision of the storage
+func normalizeAbsence(absence domain.Absence) (domain.Absence, error) {
+	if absence.StartsAt.IsZero() || absence.EndsAt.IsZero() {
+		return domain.Absence{}, fmt.Errorf("%w: starts_at and ends_at are required", domain.ErrInvalidAbsence)
+	}
+	absence.StartsAt = absence.StartsAt.UTC().Truncate(time.Microsecond)
+	absence.EndsAt = absence.EndsAt.UTC().Truncate(time.Microsecond)
+	if !absence.EndsAt.After(absence.StartsAt) {
+		return domain.Absence{}, fmt.Errorf("%w: ends_at must be after starts_at", domain.ErrInvalidAbsence)
+	}
+	if len(absence.Reason) > domain.MaxAbsenceReasonLength {
+		return domain.Absence{}, fmt.Errorf("%w: reason is longer than %d bytes", domain.ErrInvalidAbsence,
+			domain.MaxAbsenceReasonLength)
+	}
+	return absence, nil
+}
+
+// Add schedules an absence of the user. From its start the user gets no new reviews
+func (s *AbsenceService) Add(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	absence, err := normalizeAbsence(absence)
+	if err != nil {
+		return domain.Absence{}, err
+	}
+
+	var added domain.Absence
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		added, err = s.absences.Add(ctx, absence)
+		if err != nil {
+			return fmt.Errorf("error adding absence of user %s: %w", absence.UserID, err)
+		}
+		return recordAudit(ctx, s.auditRecorder, domain.AuditActionAbsenceAdd, domain.AuditEntityAbsence,
+			strconv.FormatInt(added.ID, 10), nil, absenceSnapshot(added))
+	})
+	if err != nil {
+		return domain.Absence{}, err
+	}
+	return added, nil
+}
+
+func (s *AbsenceService) Get(ctx context.Context, id int64) (domain.Absence, error) {
+	absence, err := s.absences.Get(ctx, id)
+	if err != nil {
+		return domain.Absence{}, fmt.Errorf("error getting absence %d: %w", id, err)
+	}
+	return absence, nil
+}
+
+// List returns the absences matching the filter ordered by start
+func (s *AbsenceService) List(ctx context.Context, filter domain.AbsenceFilter) ([]domain.Absence, error) {
+	absences, err := s.absences.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error listing absences: %w", err)
+	}
+	return absences, nil
+}
+
+// Update changes the period and the reason of the absence. If that brings the user back,
+// pull requests of the team that lack reviewers may get them
+func (s *AbsenceService) Update(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	absence, err := normalizeAbsence(absence)
+	if err != nil {
+		return domain.Absence{}, err
+	}
+
+	var updated domain.Absence
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.absences.Get(ctx, absence.ID)
+		if err != nil {
+			return fmt.Errorf("error getting absence %d: %w", absence.ID, err)
+		}
+		updated, err = s.absences.Update(ctx, absence)
+		if err != nil {
+			return fmt.Errorf("error updating absence %d: %w", absence.ID, err)
+		}
+		if err := recordAudit(ctx, s.auditRecorder, domain.AuditActionAbsenceUpdate, domain.AuditEntityAbsence,
+			strconv.FormatInt(absence.ID, 10), absenceSnapshot(before), absenceSnapshot(updated)); err != nil {
+			return err
+		}
+		now := s.now()
+		if before.CoversAt(now) && !updated.CoversAt(now) {
+			return s.topUpTeamOf(ctx, updated.UserID)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Absence{}, err
+	}
+	return updated, nil
+}
+
+// Delete removes the absence. If the user is back because of that,
+// pull requests of the team that lack reviewers may get them
+func (s *AbsenceService) Delete(ctx context.Context, id int64) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.absences.Get(ctx, id)
+		if err != nil {
+			return fmt.Errorf("error getting absence %d: %w", id, err)
+		}
+		if err := s.absences.Delete(ctx, id); err != nil {
+			return fmt.Errorf("error deleting absence %d: %w", id, err)
+		}
+		if err := recordAudit(ctx, s.auditRecorder, domain.AuditActionAbsenceDelete, domain.AuditEntityAbsence,
+			strconv.FormatInt(id, 10), absenceSnapshot(before), nil); err != nil {
+			return err
+		}
+		if before.CoversAt(s.now()) {
+			return s.topUpTeamOf(ctx, before.UserID)
+		}
+		return nil
+	})
+}
+
+func (s *AbsenceService) topUpTeamOf(ctx context.Context, userID string) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error getting user %s: %w", userID, err)
+	}
+	if _, err := s.reviewerAssigner.TopUpReviewers(ctx, user.TeamName); err != nil {
+		return fmt.Errorf("error topping up reviewers of team %s: %w", user.TeamName, err)
+	}
+	return nil
+}
+
+// ReassignStarted hands open reviews of users whose absence is going on over to their teammates.
+// Every absence is handled once, an updated one is handled again
+func (s *AbsenceService) ReassignStarted(ctx context.Context) ([]domain.AbsenceReassignment, error) {
+	var reassignments []domain.AbsenceReassignment
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		reassignments = nil
+		started, err := s.absences.ClaimStarted(ctx, s.now(), s.cfg.BatchSize)
+		if err != nil {
+			return fmt.Errorf("error claiming started absences: %w", err)
+		}
+		for _, absence := range started {
+			replacements, err := s.reviewerAssigner.HandOverReviews(ctx, []string{absence.UserID}, "")
+			if err != nil {
+				return fmt.Errorf("error reassigning reviews of absent user %s: %w", absence.UserID, err)
+			}
+			reassignments = append(reassignments, domain.AbsenceReassignment{
+				Absence:      absence,
+				Replacements: replacements,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reassignments, nil
+}
+
+// RunReassignment hands over reviews of absent users every cfg.ReassignInterval until ctx is done
+func (s *AbsenceService) RunReassignment(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.ReassignInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reassignments, err := s.ReassignStarted(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to reassign reviews of absent users", "error", err)
+				continue
+			}
+			for _, reassignment := range reassignments {
+				slog.InfoContext(ctx, "reviews of absent user reassigned",
+					"user_id", reassignment.Absence.UserID,
+					"absence_id", reassignment.Absence.ID,
+					"reviews", len(reassignment.Replacements))
+			}
+		}
+	}
+}
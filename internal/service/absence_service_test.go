@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/pkg/config"
+)
+
+// AbsenceServiceTestSuite определяет test suite для AbsenceService
+type AbsenceServiceTestSuite struct {
+	suite.Suite
+	ctx   context.Context
+	clock time.Time
+
+	absences  *mockiAbsenceRepository
+	users     *mockiAbsenceUserRepository
+	assigner  *mockiReviewerAssigner
+	service   *AbsenceService
+	auditLogs []domain.AuditEvent
+}
+
+// SetupTest выполняется перед каждым тестом
+func (s *AbsenceServiceTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.clock = time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	s.absences = newMockiAbsenceRepository(s.T())
+	s.users = newMockiAbsenceUserRepository(s.T())
+	s.assigner = newMockiReviewerAssigner(s.T())
+	s.auditLogs = nil
+
+	recorder := newMockiAuditRecorder(s.T())
+	recorder.EXPECT().
+		Add(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, event domain.AuditEvent) (domain.AuditEvent, error) {
+			s.auditLogs = append(s.auditLogs, event)
+			return event, nil
+		}).Maybe()
+
+	s.service = NewAbsenceService(s.absences, s.users, s.assigner, recorder, newPassthroughTransactor(s.T()),
+		config.AbsencesConfig{ReassignInterval: time.Minute, BatchSize: 10})
+	s.service.now = func() time.Time { return s.clock }
+}
+
+// vacation возвращает отпуск user-1 на неделю вокруг текущего времени
+func (s *AbsenceServiceTestSuite) vacation() domain.Absence {
+	return domain.Absence{
+		ID:       7,
+		UserID:   "user-1",
+		StartsAt: s.clock.Add(-24 * time.Hour),
+		EndsAt:   s.clock.Add(6 * 24 * time.Hour),
+		Reason:   "vacation",
+	}
+}
+
+// TestAddValidation проверяет отказ в негодных периодах, до хранилища они не доходят
+func (s *AbsenceServiceTestSuite) TestAddValidation() {
+	long := make([]byte, domain.MaxAbsenceReasonLength+1)
+	tests := map[string]domain.Absence{
+		"no start":     {UserID: "user-1", EndsAt: s.clock},
+		"no end":       {UserID: "user-1", StartsAt: s.clock},
+		"empty period": {UserID: "user-1", StartsAt: s.clock, EndsAt: s.clock},
+		"reversed":     {UserID: "user-1", StartsAt: s.clock, EndsAt: s.clock.Add(-time.Hour)},
+		"long reason":  {UserID: "user-1", StartsAt: s.clock, EndsAt: s.clock.Add(time.Hour), Reason: string(long)},
+		"same instant": {UserID: "user-1", StartsAt: s.clock, EndsAt: s.clock.In(time.FixedZone("MSK", 3*3600))},
+	}
+	for name, absence := range tests {
+		_, err := s.service.Add(s.ctx, absence)
+		s.ErrorIs(err, domain.ErrInvalidAbsence, name)
+	}
+}
+
+// TestAdd проверяет приведение границ к UTC и запись в аудит
+func (s *AbsenceServiceTestSuite) TestAdd() {
+	msk := time.FixedZone("MSK", 3*3600)
+	s.absences.EXPECT().
+		Add(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, absence domain.Absence) (domain.Absence, error) {
+			s.Equal(time.UTC, absence.StartsAt.Location())
+			s.Equal(s.clock, absence.StartsAt)
+			absence.ID = 1
+			return absence, nil
+		}).Once()
+
+	added, err := s.service.Add(s.ctx, domain.Absence{
+		UserID:   "user-1",
+		StartsAt: s.clock.In(msk),
+		EndsAt:   s.clock.Add(time.Hour).In(msk),
+	})
+
+	s.Require().NoError(err)
+	s.Equal(int64(1), added.ID)
+	s.Require().Len(s.auditLogs, 1)
+	s.Equal(domain.AuditActionAbsenceAdd, s.auditLogs[0].Action)
+	s.Equal(domain.AuditEntityAbsence, s.auditLogs[0].EntityType)
+	s.Equal("1", s.auditLogs[0].EntityID)
+}
+
+// TestDelete проверяет, что после отмены идущего отсутствия команда добирает ревьюверов
+func (s *AbsenceServiceTestSuite) TestDelete() {
+	s.Run("ongoing absence", func() {
+		s.SetupTest()
+		s.absences.EXPECT().Get(mock.Anything, int64(7)).Return(s.vacation(), nil).Once()
+		s.absences.EXPECT().Delete(mock.Anything, int64(7)).Return(nil).Once()
+		s.users.EXPECT().GetByID(mock.Anything, "user-1").Return(domain.User{ID: "user-1", TeamName: "backend"}, nil)
+		s.assigner.EXPECT().TopUpReviewers(mock.Anything, "backend").Return(nil, nil).Once()
+
+		s.Require().NoError(s.service.Delete(s.ctx, 7))
+		s.Require().Len(s.auditLogs, 1)
+		s.Equal(domain.AuditActionAbsenceDelete, s.auditLogs[0].Action)
+	})
+
+	s.Run("future absence", func() {
+		s.SetupTest()
+		future := s.vacation()
+		future.StartsAt = s.clock.Add(24 * time.Hour)
+		s.absences.EXPECT().Get(mock.Anything, int64(7)).Return(future, nil).Once()
+		s.absences.EXPECT().Delete(mock.Anything, int64(7)).Return(nil).Once()
+
+		s.Require().NoError(s.service.Delete(s.ctx, 7))
+	})
+
+	s.Run("not found", func() {
+		s.SetupTest()
+		s.absences.EXPECT().Get(mock.Anything, int64(7)).Return(domain.Absence{}, domain.ErrAbsenceNotFound).Once()
+
+		s.ErrorIs(s.service.Delete(s.ctx, 7), domain.ErrAbsenceNotFound)
+	})
+}
+
+// TestUpdate проверяет, что досрочное возвращение тоже добирает ревьюверов
+func (s *AbsenceServiceTestSuite) TestUpdate() {
+	shortened := s.vacation()
+	shortened.EndsAt = s.clock.Add(-time.Hour)
+	s.absences.EXPECT().Get(mock.Anything, int64(7)).Return(s.vacation(), nil).Once()
+	s.absences.EXPECT().Update(mock.Anything, shortened).Return(shortened, nil).Once()
+	s.users.EXPECT().GetByID(mock.Anything, "user-1").Return(domain.User{ID: "user-1", TeamName: "backend"}, nil)
+	s.assigner.EXPECT().TopUpReviewers(mock.Anything, "backend").Return(nil, nil).Once()
+
+	updated, err := s.service.Update(s.ctx, shortened)
+
+	s.Require().NoError(err)
+	s.Equal(shortened, updated)
+	s.Require().Len(s.auditLogs, 1)
+	s.Equal(domain.AuditActionAbsenceUpdate, s.auditLogs[0].Action)
+	s.NotNil(s.auditLogs[0].Before)
+}
+
+// TestReassignStarted проверяет передачу ревью начавшихся отсутствий
+func (s *AbsenceServiceTestSuite) TestReassignStarted() {
+	first, second := s.vacation(), s.vacation()
+	second.ID, second.UserID = 8, "user-2"
+	s.absences.EXPECT().ClaimStarted(mock.Anything, s.clock, 10).Return([]domain.Absence{first, second}, nil).Once()
+	s.assigner.EXPECT().HandOverReviews(mock.Anything, []string{"user-1"}, "").Return([]domain.ReviewerReplacement{
+		{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-3"},
+		{PullRequestID: "pr-2", OldReviewerID: "user-1"},
+	}, nil).Once()
+	s.assigner.EXPECT().HandOverReviews(mock.Anything, []string{"user-2"}, "").
+		Return([]domain.ReviewerReplacement{}, nil).Once()
+
+	reassignments, err := s.service.ReassignStarted(s.ctx)
+
+	s.Require().NoError(err)
+	s.Require().Len(reassignments, 2)
+	s.Len(reassignments[0].Replacements, 2)
+	s.Empty(reassignments[1].Replacements)
+}
+
+// TestAbsenceServiceSuite запускает test suite
+func TestAbsenceServiceSuite(t *testing.T) {
+	suite.Run(t, new(AbsenceServiceTestSuite))
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// iAuditRecorder appends events to the audit log
+type iAuditRecorder interface {
+	Add(ctx context.Context, event domain.AuditEvent) (domain.AuditEvent, error)
+	AddBatch(ctx context.Context, events []domain.AuditEvent) error
+}
+
+type iAuditRepository interface {
+	List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error)
+}
+
+// AuditService reads the audit log. Events are written by the services that make the mutations
+type AuditService struct {
+	repository iAuditRepository
+}
+
+func NewAuditService(repository iAuditRepository) *AuditService {
+	return &AuditService{repository: repository}
+}
+
+// List returns a page of audit events matching the filter, newest first.
+// The page holds filter.Limit events at most, DefaultAuditPageSize if the limit is not set
+func (s *AuditService) List(ctx context.Context, filter domain.AuditFilter) (domain.AuditPage, error) {
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultAuditPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > domain.MaxAuditPageSize {
+		return domain.AuditPage{}, fmt.Errorf("%w: limit must be between 1 and %d",
+			domain.ErrInvalidAuditFilter, domain.MaxAuditPageSize)
+	}
+	if filter.AfterID < 0 {
+		return domain.AuditPage{}, fmt.Errorf("%w: cursor must be positive", domain.ErrInvalidAuditFilter)
+	}
+
+	// timestamps are stored in UTC without time zone
+	filter.From, filter.To = filter.From.UTC(), filter.To.UTC()
+
+	// one extra event tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	events, err := s.repository.List(ctx, filter)
+	if err != nil {
+		return domain.AuditPage{}, fmt.Errorf("error listing audit events: %w", err)
+	}
+
+	page := domain.AuditPage{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.NextAfterID = page.Events[limit-1].ID
+	}
+	return page, nil
+}
+
+// recordAudit appends an event about the entity to the audit log on behalf of the actor from ctx.
+// before and after are stored as JSON, nil is stored as no payload.
+// It has to be called with the context of the mutation transaction, so that the event is committed with it
+func recordAudit(
+	ctx context.Context,
+	recorder iAuditRecorder,
+	action domain.AuditAction,
+	entityType domain.AuditEntityType,
+	entityID string,
+	before, after any,
+) error {
+	event, err := newAuditEvent(ctx, action, entityType, entityID, before, after)
+	if err != nil {
+		return err
+	}
+	if _, err := recorder.Add(ctx, event); err != nil {
+		return fmt.Errorf("error recording audit event %s: %w", action, err)
+	}
+	return nil
+}
+
+// recordAudits appends the events made by newAuditEvent to the audit log at once, like recordAudit does
+// with one of them
+func recordAudits(ctx context.Context, recorder iAuditRecorder, events []domain.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := recorder.AddBatch(ctx, events); err != nil {
+		return fmt.Errorf("error recording audit events: %w", err)
+	}
+	return nil
+}
+
+// newAuditEvent makes the event recordAudit appends to the audit log
+func newAuditEvent(
+	ctx context.Context,
+	action domain.AuditAction,
+	entityType domain.AuditEntityType,
+	entityID string,
+	before, after any,
+) (domain.AuditEvent, error) {
+	event := domain.AuditEvent{ //nolint:exhaustruct // ID и время выставляет хранилище
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Actor:      domain.ActorFromContext(ctx),
+		RequestID:  domain.RequestIDFromContext(ctx),
+	}
+	var err error
+	if event.Before, err = auditPayload(before); err != nil {
+		return domain.AuditEvent{}, fmt.Errorf("error encoding audit payload of %s %s: %w", entityType, entityID, err)
+	}
+	if event.After, err = auditPayload(after); err != nil {
+		return domain.AuditEvent{}, fmt.Errorf("error encoding audit payload of %s %s: %w", entityType, entityID, err)
+	}
+	return event, nil
+}
+
+func auditPayload(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// Snapshots of entities stored in the audit log. Field names follow the API
+type (
+	auditUser struct {
+		UserID         string `json:"user_id"`
+		Username       string `json:"username"`
+		TeamName       string `json:"team_name"`
+		IsActive       bool   `json:"is_active"`
+		MaxOpenReviews int    `json:"max_open_reviews,omitempty"`
+	}
+
+	auditTeam struct {
+		TeamName string      `json:"team_name"`
+		Members  []auditUser `json:"members"`
+	}
+
+	auditReviewerPool struct {
+		PoolType string `json:"pool_type"`
+		PoolName string `json:"pool_name"`
+	}
+
+	auditTeamFallbacks struct {
+		TeamName  string              `json:"team_name"`
+		Fallbacks []auditReviewerPool `json:"fallbacks"`
+	}
+
+	auditSharedPool struct {
+		PoolName string   `json:"pool_name"`
+		Members  []string `json:"members"`
+	}
+
+	auditPullRequest struct {
+		PullRequestID     string     `json:"pull_request_id"`
+		PullRequestName   string     `json:"pull_request_name"`
+		AuthorID          string     `json:"author_id"`
+		Status            string     `json:"status"`
+		AssignedReviewers []string   `json:"assigned_reviewers"`
+		NeedMoreReviewers bool       `json:"need_more_reviewers"`
+		MergedAt          *time.Time `json:"merged_at,omitempty"`
+	}
+
+	auditAbsence struct {
+		AbsenceID int64     `json:"absence_id"`
+		UserID    string    `json:"user_id"`
+		StartsAt  time.Time `json:"starts_at"`
+		EndsAt    time.Time `json:"ends_at"`
+		Reason    string    `json:"reason"`
+	}
+
+	auditReview struct {
+		PullRequestID string `json:"pull_request_id"`
+		ReviewerID    string `json:"reviewer_id"`
+		Verdict       string `json:"verdict"`
+	}
+
+	// auditForceMerge is the state of a pull request merged in spite of the merge policy
+	auditForceMerge struct {
+		auditPullRequest
+		BypassedViolations []string `json:"bypassed_violations"`
+	}
+)
+
+func userSnapshot(user domain.User) auditUser {
+	return auditUser{
+		UserID:         user.ID,
+		Username:       user.Username,
+		TeamName:       user.TeamName,
+		IsActive:       user.IsActive,
+		MaxOpenReviews: user.MaxOpenReviews,
+	}
+}
+
+func teamSnapshot(team domain.Team) auditTeam {
+	members := make([]auditUser, 0, len(team.Members))
+	for _, member := range team.Members {
+		members = append(members, userSnapshot(member))
+	}
+	return auditTeam{TeamName: team.Name, Members: members}
+}
+
+func teamFallbacksSnapshot(team domain.Team) auditTeamFallbacks {
+	fallbacks := make([]auditReviewerPool, 0, len(team.Fallbacks))
+	for _, fallback := range team.Fallbacks {
+		fallbacks = append(fallbacks, auditReviewerPool{PoolType: string(fallback.Kind), PoolName: fallback.Name})
+	}
+	return auditTeamFallbacks{TeamName: team.Name, Fallbacks: fallbacks}
+}
+
+func sharedPoolSnapshot(pool domain.SharedReviewerPool) auditSharedPool {
+	members := make([]string, 0, len(pool.Members))
+	for _, member := range pool.Members {
+		members = append(members, member.ID)
+	}
+	return auditSharedPool{PoolName: pool.Name, Members: members}
+}
+
+func pullRequestSnapshot(pr domain.PullRequest) auditPullRequest {
+	reviewers := make([]string, 0, len(pr.Reviewers))
+	for _, reviewer := range pr.Reviewers {
+		reviewers = append(reviewers, reviewer.ID)
+	}
+	snapshot := auditPullRequest{
+		PullRequestID:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorID:          pr.AuthorID,
+		Status:            string(pr.Status),
+		AssignedReviewers: reviewers,
+		NeedMoreReviewers: pr.NeedMoreReviewers,
+		MergedAt:          nil,
+	}
+	if !pr.MergedAt.IsZero() {
+		snapshot.MergedAt = &pr.MergedAt
+	}
+	return snapshot
+}
+
+func absenceSnapshot(absence domain.Absence) auditAbsence {
+	return auditAbsence{
+		AbsenceID: absence.ID,
+		UserID:    absence.UserID,
+		StartsAt:  absence.StartsAt,
+		EndsAt:    absence.EndsAt,
+		Reason:    absence.Reason,
+	}
+}
+
+func reviewSnapshot(review domain.Review) auditReview {
+	return auditReview{
+		PullRequestID: review.PullRequestID,
+		ReviewerID:    review.ReviewerID,
+		Verdict:       string(review.Verdict),
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// AuditServiceTestSuite определяет test suite для AuditService и записи событий аудита
+type AuditServiceTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+// SetupTest выполняется перед каждым тестом
+func (s *AuditServiceTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+// TestList проверяет значения по умолчанию, проверку лимита и курсор следующей страницы
+func (s *AuditServiceTestSuite) TestList() {
+	events := []domain.AuditEvent{{ID: 5}, {ID: 4}, {ID: 3}}
+
+	tests := []struct {
+		name        string
+		filter      domain.AuditFilter
+		arrangeFunc func(ctx context.Context, mockRepo *mockiAuditRepository)
+		wantErr     bool
+		wantErrIs   error
+		wantIDs     []int64
+		wantNext    int64
+	}{
+		{
+			name:   "default limit",
+			filter: domain.AuditFilter{Actor: "admin"},
+			arrangeFunc: func(ctx context.Context, mockRepo *mockiAuditRepository) {
+				mockRepo.EXPECT().
+					List(ctx, domain.AuditFilter{Actor: "admin", Limit: domain.DefaultAuditPageSize + 1}).
+					Return(events, nil).Once()
+			},
+			wantIDs: []int64{5, 4, 3},
+		},
+		{
+			name:   "next page",
+			filter: domain.AuditFilter{Limit: 2, AfterID: 6},
+			arrangeFunc: func(ctx context.Context, mockRepo *mockiAuditRepository) {
+				mockRepo.EXPECT().List(ctx, domain.AuditFilter{Limit: 3, AfterID: 6}).Return(events, nil).Once()
+			},
+			wantIDs:  []int64{5, 4},
+			wantNext: 4,
+		},
+		{
+			name:      "limit too big",
+			filter:    domain.AuditFilter{Limit: domain.MaxAuditPageSize + 1},
+			wantErr:   true,
+			wantErrIs: domain.ErrInvalidAuditFilter,
+		},
+		{
+			name:      "negative cursor",
+			filter:    domain.AuditFilter{AfterID: -1},
+			wantErr:   true,
+			wantErrIs: domain.ErrInvalidAuditFilter,
+		},
+		{
+			name: "repository error",
+			arrangeFunc: func(ctx context.Context, mockRepo *mockiAuditRepository) {
+				mockRepo.EXPECT().List(ctx, mock.Anything).Return(nil, errors.New("database error")).Once()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Arrange
+			mockRepo := newMockiAuditRepository(s.T())
+			service := NewAuditService(mockRepo)
+			if tt.arrangeFunc != nil {
+				tt.arrangeFunc(s.ctx, mockRepo)
+			}
+
+			// Act
+			page, err := service.List(s.ctx, tt.filter)
+
+			// Assert
+			if tt.wantErr {
+				s.Error(err)
+				if tt.wantErrIs != nil {
+					s.ErrorIs(err, tt.wantErrIs)
+				}
+				return
+			}
+			s.NoError(err)
+			ids := make([]int64, 0, len(page.Events))
+			for _, event := range page.Events {
+				ids = append(ids, event.ID)
+			}
+			s.Equal(tt.wantIDs, ids)
+			s.Equal(tt.wantNext, page.NextAfterID)
+		})
+	}
+}
+
+// TestRecordAudit проверяет, что событие получает автора и ID запроса из контекста, а снимки кодируются в JSON
+func (s *AuditServiceTestSuite) TestRecordAudit() {
+	ctx := domain.WithRequestID(domain.WithActor(s.ctx, "alice"), "req-1")
+	mergedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	pr := domain.PullRequest{
+		ID:        "pr-1",
+		Name:      "Add feature",
+		AuthorID:  "author-1",
+		Status:    domain.PRStatusMerged,
+		Reviewers: []domain.User{{ID: "user-1"}},
+		MergedAt:  mergedAt,
+	}
+
+	var recorded domain.AuditEvent
+	mockRecorder := newMockiAuditRecorder(s.T())
+	mockRecorder.EXPECT().
+		Add(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, event domain.AuditEvent) (domain.AuditEvent, error) {
+			recorded = event
+			return event, nil
+		}).Once()
+
+	err := recordAudit(ctx, mockRecorder, domain.AuditActionPRCreate, domain.AuditEntityPullRequest, pr.ID,
+		nil, pullRequestSnapshot(pr))
+
+	s.Require().NoError(err)
+	s.Equal(domain.AuditActionPRCreate, recorded.Action)
+	s.Equal(domain.AuditEntityPullRequest, recorded.EntityType)
+	s.Equal("pr-1", recorded.EntityID)
+	s.Equal("alice", recorded.Actor)
+	s.Equal("req-1", recorded.RequestID)
+	s.Nil(recorded.Before)
+
+	var after map[string]any
+	s.Require().NoError(json.Unmarshal(recorded.After, &after))
+	s.Equal("pr-1", after["pull_request_id"])
+	s.Equal("MERGED", after["status"])
+	s.Equal([]any{"user-1"}, after["assigned_reviewers"])
+	s.Equal(mergedAt.Format(time.RFC3339), after["merged_at"])
+}
+
+// TestRecordAuditAnonymous проверяет автора по умолчанию и ошибку записи события
+func (s *AuditServiceTestSuite) TestRecordAuditAnonymous() {
+	mockRecorder := newMockiAuditRecorder(s.T())
+	mockRecorder.EXPECT().
+		Add(s.ctx, mock.MatchedBy(func(event domain.AuditEvent) bool {
+			return event.Actor == domain.AnonymousActor && event.RequestID == ""
+		})).
+		Return(domain.AuditEvent{}, errors.New("database error")).Once()
+
+	err := recordAudit(s.ctx, mockRecorder, domain.AuditActionUserSetIsActive, domain.AuditEntityUser, "user-1",
+		userSnapshot(domain.User{ID: "user-1"}), userSnapshot(domain.User{ID: "user-1", IsActive: true}))
+
+	s.Error(err)
+}
+
+// TestAuditServiceSuite запускает test suite
+func TestAuditServiceSuite(t *testing.T) {
+	suite.Run(t, new(AuditServiceTestSuite))
+}
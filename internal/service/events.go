@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// iEventOutbox stores domain events for delivery to webhook subscribers
+type iEventOutbox interface {
+	AddEvent(ctx context.Context, event domain.OutboxEvent) (domain.OutboxEvent, error)
+	AddEvents(ctx context.Context, events []domain.OutboxEvent) error
+}
+
+// emitEvent stores the event in the outbox with payload encoded as JSON.
+// It has to be called with the context of the mutation transaction, so that the event is committed with it
+func emitEvent(ctx context.Context, outbox iEventOutbox, eventType domain.EventType, payload any) error {
+	event, err := newOutboxEvent(eventType, payload)
+	if err != nil {
+		return err
+	}
+	if _, err := outbox.AddEvent(ctx, event); err != nil {
+		return fmt.Errorf("error emitting event %s: %w", eventType, err)
+	}
+	return nil
+}
+
+// emitEvents stores the events of the same type in the outbox at once, like emitEvent does with one of them
+func emitEvents[T any](ctx context.Context, outbox iEventOutbox, eventType domain.EventType, payloads []T) error {
+	if len(payloads) == 0 {
+		return nil
+	}
+	events := make([]domain.OutboxEvent, len(payloads))
+	for i, payload := range payloads {
+		var err error
+		if events[i], err = newOutboxEvent(eventType, payload); err != nil {
+			return err
+		}
+	}
+	if err := outbox.AddEvents(ctx, events); err != nil {
+		return fmt.Errorf("error emitting events %s: %w", eventType, err)
+	}
+	return nil
+}
+
+func newOutboxEvent(eventType domain.EventType, payload any) (domain.OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return domain.OutboxEvent{}, fmt.Errorf("error encoding event %s: %w", eventType, err)
+	}
+	return domain.OutboxEvent{ //nolint:exhaustruct // ID и время выставляет хранилище
+		Type:    eventType,
+		Payload: data,
+	}, nil
+}
+
+// Payloads of the events delivered to webhook subscribers. Field names follow the API
+type (
+	reviewersAssignedEvent struct {
+		PullRequestID string   `json:"pull_request_id"`
+		ReviewerIDs   []string `json:"reviewer_ids"`
+	}
+
+	// reviewerReassignedEvent has no new reviewer when nobody could replace the old one
+	reviewerReassignedEvent struct {
+		PullRequestID string `json:"pull_request_id"`
+		OldReviewerID string `json:"old_reviewer_id"`
+		NewReviewerID string `json:"new_reviewer_id,omitempty"`
+	}
+
+	pullRequestMergedEvent struct {
+		PullRequestID string    `json:"pull_request_id"`
+		AuthorID      string    `json:"author_id"`
+		MergedAt      time.Time `json:"merged_at"`
+		// Forced is set when the merge bypassed the merge policy
+		Forced bool `json:"forced"`
+	}
+
+	pullRequestStatusChangedEvent struct {
+		PullRequestID string          `json:"pull_request_id"`
+		AuthorID      string          `json:"author_id"`
+		OldStatus     domain.PRStatus `json:"old_status"`
+		NewStatus     domain.PRStatus `json:"new_status"`
+	}
+)
+
+func emitReviewersAssigned(ctx context.Context, outbox iEventOutbox, prID string, reviewerIDs []string) error {
+	return emitEvent(ctx, outbox, domain.EventReviewersAssigned, reviewersAssignedEvent{
+		PullRequestID: prID,
+		ReviewerIDs:   reviewerIDs,
+	})
+}
+
+func emitReviewerReassigned(ctx context.Context, outbox iEventOutbox, replacement domain.ReviewerReplacement) error {
+	return emitEvent(ctx, outbox, domain.EventReviewerReassigned, reviewerReassignedEvent{
+		PullRequestID: replacement.PullRequestID,
+		OldReviewerID: replacement.OldReviewerID,
+		NewReviewerID: replacement.NewReviewerID,
+	})
+}
+
+func emitReviewersReassigned(
+	ctx context.Context,
+	outbox iEventOutbox,
+	replacements []domain.ReviewerReplacement,
+) error {
+	payloads := make([]reviewerReassignedEvent, len(replacements))
+	for i, replacement := range replacements {
+		payloads[i] = reviewerReassignedEvent{
+			PullRequestID: replacement.PullRequestID,
+			OldReviewerID: replacement.OldReviewerID,
+			NewReviewerID: replacement.NewReviewerID,
+		}
+	}
+	return emitEvents(ctx, outbox, domain.EventReviewerReassigned, payloads)
+}
+
+func emitPullRequestStatusChanged(ctx context.Context, outbox iEventOutbox, pr domain.PullRequest,
+	oldStatus domain.PRStatus) error {
+	return emitEvent(ctx, outbox, domain.EventPullRequestStatusChanged, pullRequestStatusChangedEvent{
+		PullRequestID: pr.ID,
+		AuthorID:      pr.AuthorID,
+		OldStatus:     oldStatus,
+		NewStatus:     pr.Status,
+	})
+}
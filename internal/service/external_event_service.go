@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/internal/ingest"
+	"github.com/artmexbet/avito_test_task/pkg/config"
+)
+
+type iExternalLoginRepository interface {
+	Set(ctx context.Context, login domain.ExternalLogin) (domain.ExternalLogin, error)
+	Get(ctx context.Context, provider domain.ExternalProvider, login string) (domain.ExternalLogin, error)
+	List(ctx context.Context, provider domain.ExternalProvider) ([]domain.ExternalLogin, error)
+	Delete(ctx context.Context, provider domain.ExternalProvider, login string) error
+}
+
+type iExternalPRService interface {
+	Create(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error)
+	Merge(ctx context.Context, prID string, force bool) (domain.PullRequest, error)
+	MarkReady(ctx context.Context, prID string) (domain.PullRequest, error)
+	Close(ctx context.Context, prID string) (domain.PullRequest, error)
+	Reopen(ctx context.Context, prID string) (domain.PullRequest, error)
+}
+
+// ExternalEventService applies pull request events sent by code hostings, so that CI scripts
+// don't have to call the API by hand. Logins of the providers are mapped onto users through a mapping table
+type ExternalEventService struct {
+	logins       iExternalLoginRepository
+	pullRequests iExternalPRService
+	cfg          config.IngestConfig
+}
+
+func NewExternalEventService(
+	logins iExternalLoginRepository,
+	pullRequests iExternalPRService,
+	cfg config.IngestConfig,
+) *ExternalEventService {
+	return &ExternalEventService{
+		logins:       logins,
+		pullRequests: pullRequests,
+		cfg:          cfg,
+	}
+}
+
+// HandleGitHub verifies the signature of a GitHub webhook and applies it if it is about a pull request
+func (s *ExternalEventService) HandleGitHub(
+	ctx context.Context,
+	event, signature string,
+	body []byte,
+) (domain.ExternalEventResult, error) {
+	if s.cfg.GitHubSecret == "" {
+		return domain.ExternalEventResult{}, fmt.Errorf("%s: %w", domain.ExternalProviderGitHub,
+			domain.ErrProviderNotConfigured)
+	}
+	if err := ingest.VerifyGitHub(s.cfg.GitHubSecret, signature, body); err != nil {
+		return domain.ExternalEventResult{}, err
+	}
+	prEvent, ok, err := ingest.ParseGitHub(event, body)
+	if err != nil {
+		return domain.ExternalEventResult{}, err
+	}
+	if !ok {
+		return ignoredEvent(event), nil
+	}
+	return s.apply(ctx, prEvent)
+}
+
+// HandleGitLab verifies the token of a GitLab webhook and applies it if it is about a merge request
+func (s *ExternalEventService) HandleGitLab(
+	ctx context.Context,
+	event, token string,
+	body []byte,
+) (domain.ExternalEventResult, error) {
+	if s.cfg.GitLabToken == "" {
+		return domain.ExternalEventResult{}, fmt.Errorf("%s: %w", domain.ExternalProviderGitLab,
+			domain.ErrProviderNotConfigured)
+	}
+	if err := ingest.VerifyGitLab(s.cfg.GitLabToken, token); err != nil {
+		return domain.ExternalEventResult{}, err
+	}
+	prEvent, ok, err := ingest.ParseGitLab(event, body)
+	if err != nil {
+		return domain.ExternalEventResult{}, err
+	}
+	if !ok {
+		return ignoredEvent(event), nil
+	}
+	return s.apply(ctx, prEvent)
+}
+
+func ignoredEvent(event string) domain.ExternalEventResult {
+	return domain.ExternalEventResult{ //nolint:exhaustruct // Событие не про PR
+		Reason: fmt.Sprintf("event %q is not handled", event),
+	}
+}
+
+// apply maps the event onto the pull request service. Providers redeliver webhooks and the pull request
+// may be changed through the API as well, so events that don't fit the current state are skipped
+func (s *ExternalEventService) apply(
+	ctx context.Context,
+	event domain.ExternalPREvent,
+) (domain.ExternalEventResult, error) {
+	actor := string(event.Provider)
+	if event.Sender != "" {
+		actor += ":" + event.Sender
+	}
+	ctx = domain.WithActor(ctx, actor)
+
+	result := domain.ExternalEventResult{Event: event, Applied: false, Reason: ""}
+	err := s.dispatch(ctx, event)
+	switch {
+	case errors.Is(err, domain.ErrPRAlreadyExists), errors.Is(err, domain.ErrPRAlreadyMerged),
+		errors.Is(err, domain.ErrPRNotFound), errors.Is(err, domain.ErrInvalidPRTransition):
+		result.Reason = err.Error()
+		return result, nil
+	case err != nil:
+		return result, fmt.Errorf("error applying %s %s event to pull request %s: %w",
+			event.Provider, event.Action, event.PullRequestID, err)
+	}
+	result.Applied = true
+	return result, nil
+}
+
+func (s *ExternalEventService) dispatch(ctx context.Context, event domain.ExternalPREvent) error {
+	var err error
+	switch event.Action {
+	case domain.ExternalPROpened:
+		err = s.create(ctx, event)
+	case domain.ExternalPRReady:
+		_, err = s.pullRequests.MarkReady(ctx, event.PullRequestID)
+	case domain.ExternalPRMerged:
+		// PR уже смерджен у провайдера, политика мерджа тут ничего не изменит
+		_, err = s.pullRequests.Merge(ctx, event.PullRequestID, true)
+	case domain.ExternalPRClosed:
+		_, err = s.pullRequests.Close(ctx, event.PullRequestID)
+	case domain.ExternalPRReopened:
+		_, err = s.pullRequests.Reopen(ctx, event.PullRequestID)
+	}
+	return err
+}
+
+func (s *ExternalEventService) create(ctx context.Context, event domain.ExternalPREvent) error {
+	author, err := s.resolve(ctx, event.Provider, event.AuthorLogin)
+	if err != nil {
+		return err
+	}
+	status := domain.PRStatusOpen
+	if event.Draft {
+		status = domain.PRStatusDraft
+	}
+	//nolint:exhaustruct // Ревьюверов и время выставляет сервис PR
+	_, err = s.pullRequests.Create(ctx, domain.PullRequest{
+		ID:       event.PullRequestID,
+		Name:     event.Name,
+		AuthorID: author.UserID,
+		Status:   status,
+	})
+	return err
+}
+
+// resolve finds the user the login of the provider is mapped onto
+func (s *ExternalEventService) resolve(
+	ctx context.Context,
+	provider domain.ExternalProvider,
+	login string,
+) (domain.ExternalLogin, error) {
+	mapping, err := s.logins.Get(ctx, provider, login)
+	if errors.Is(err, domain.ErrExternalLoginNotFound) {
+		return domain.ExternalLogin{}, fmt.Errorf("%s login %q: %w", provider, login, domain.ErrExternalLoginNotMapped)
+	}
+	if err != nil {
+		return domain.ExternalLogin{}, fmt.Errorf("error resolving %s login %q: %w", provider, login, err)
+	}
+	return mapping, nil
+}
+
+// SetLogin maps the login of the provider onto the user, replacing the previous mapping of the login
+func (s *ExternalEventService) SetLogin(ctx context.Context, login domain.ExternalLogin) (domain.ExternalLogin, error) {
+	if !domain.IsValidExternalProvider(login.Provider) {
+		return domain.ExternalLogin{}, fmt.Errorf("%w: unknown provider %q", domain.ErrInvalidExternalLogin,
+			login.Provider)
+	}
+	if login.Login == "" || login.UserID == "" {
+		return domain.ExternalLogin{}, fmt.Errorf("%w: login and user_id are required", domain.ErrInvalidExternalLogin)
+	}
+
+	stored, err := s.logins.Set(ctx, login)
+	if err != nil {
+		return domain.ExternalLogin{}, fmt.Errorf("error setting %s login %q: %w", login.Provider, login.Login, err)
+	}
+	return stored, nil
+}
+
+// ListLogins returns the mappings of the provider, or of all providers if it is empty
+func (s *ExternalEventService) ListLogins(
+	ctx context.Context,
+	provider domain.ExternalProvider,
+) ([]domain.ExternalLogin, error) {
+	if provider != "" && !domain.IsValidExternalProvider(provider) {
+		return nil, fmt.Errorf("%w: unknown provider %q", domain.ErrInvalidExternalLogin, provider)
+	}
+	logins, err := s.logins.List(ctx, provider)
+	if err != nil {
+		return nil, fmt.Errorf("error listing external logins: %w", err)
+	}
+	return logins, nil
+}
+
+// DeleteLogin removes the mapping of the login. Events of the login are rejected until it is mapped again
+func (s *ExternalEventService) DeleteLogin(ctx context.Context, provider domain.ExternalProvider, login string) error {
+	if err := s.logins.Delete(ctx, provider, login); err != nil {
+		return fmt.Errorf("error deleting %s login %q: %w", provider, login, err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/pkg/config"
+)
+
+const (
+	testGitHubSecret = "github-secret"
+	testGitLabToken  = "gitlab-token"
+	gitlabMRHook     = "Merge Request Hook"
+)
+
+// ExternalEventServiceTestSuite определяет test suite для ExternalEventService
+type ExternalEventServiceTestSuite struct {
+	suite.Suite
+	ctx        context.Context
+	mockLogins *mockiExternalLoginRepository
+	mockPRs    *mockiExternalPRService
+	service    *ExternalEventService
+}
+
+// SetupTest выполняется перед каждым тестом
+func (s *ExternalEventServiceTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.mockLogins = newMockiExternalLoginRepository(s.T())
+	s.mockPRs = newMockiExternalPRService(s.T())
+	s.service = NewExternalEventService(s.mockLogins, s.mockPRs, config.IngestConfig{
+		GitHubSecret: testGitHubSecret,
+		GitLabToken:  testGitLabToken,
+	})
+}
+
+// fixture читает полезную нагрузку вебхука из internal/ingest/testdata
+func (s *ExternalEventServiceTestSuite) fixture(name string) []byte {
+	payload, err := os.ReadFile(filepath.Join("..", "ingest", "testdata", name))
+	s.Require().NoError(err)
+	return payload
+}
+
+func githubSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// TestCredentials проверяет отключённых провайдеров и проверку подписи до разбора тела
+func (s *ExternalEventServiceTestSuite) TestCredentials() {
+	body := s.fixture("github_pull_request_opened.json")
+
+	disabled := NewExternalEventService(s.mockLogins, s.mockPRs, config.IngestConfig{})
+	_, err := disabled.HandleGitHub(s.ctx, "pull_request", githubSignature("", body), body)
+	s.ErrorIs(err, domain.ErrProviderNotConfigured)
+	_, err = disabled.HandleGitLab(s.ctx, gitlabMRHook, "", s.fixture("gitlab_merge_request_open.json"))
+	s.ErrorIs(err, domain.ErrProviderNotConfigured)
+
+	_, err = s.service.HandleGitHub(s.ctx, "pull_request", githubSignature("wrong", body), body)
+	s.ErrorIs(err, domain.ErrInvalidSignature)
+	_, err = s.service.HandleGitLab(s.ctx, gitlabMRHook, "wrong", s.fixture("gitlab_merge_request_open.json"))
+	s.ErrorIs(err, domain.ErrInvalidSignature)
+
+	// подписанное, но битое тело
+	_, err = s.service.HandleGitHub(s.ctx, "pull_request", githubSignature(testGitHubSecret, []byte("{")), []byte("{"))
+	s.ErrorIs(err, domain.ErrInvalidExternalEvent)
+}
+
+// TestOpened проверяет создание PR от имени сопоставленного пользователя
+func (s *ExternalEventServiceTestSuite) TestOpened() {
+	body := s.fixture("github_pull_request_opened_draft.json")
+	s.mockLogins.EXPECT().
+		Get(mock.Anything, domain.ExternalProviderGitHub, "octocat").
+		Return(domain.ExternalLogin{Provider: domain.ExternalProviderGitHub, Login: "octocat", UserID: "user-1"}, nil)
+	s.mockPRs.EXPECT().
+		Create(mock.Anything, domain.PullRequest{
+			ID:       "github-1296269-1347",
+			Name:     "Amazing new feature",
+			AuthorID: "user-1",
+			Status:   domain.PRStatusDraft,
+		}).
+		RunAndReturn(func(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
+			s.Equal("github:octocat", domain.ActorFromContext(ctx))
+			return pr, nil
+		})
+
+	result, err := s.service.HandleGitHub(s.ctx, "pull_request", githubSignature(testGitHubSecret, body), body)
+
+	s.Require().NoError(err)
+	s.True(result.Applied)
+	s.Equal(domain.ExternalPROpened, result.Event.Action)
+}
+
+// TestUnmappedAuthor проверяет, что PR не создаётся, если логин автора не сопоставлен
+func (s *ExternalEventServiceTestSuite) TestUnmappedAuthor() {
+	s.mockLogins.EXPECT().
+		Get(mock.Anything, domain.ExternalProviderGitLab, "root").
+		Return(domain.ExternalLogin{}, domain.ErrExternalLoginNotFound)
+
+	_, err := s.service.HandleGitLab(s.ctx, gitlabMRHook, testGitLabToken, s.fixture("gitlab_merge_request_open.json"))
+
+	s.ErrorIs(err, domain.ErrExternalLoginNotMapped)
+}
+
+// TestMerged проверяет мердж в обход политики и повторную доставку
+func (s *ExternalEventServiceTestSuite) TestMerged() {
+	body := s.fixture("gitlab_merge_request_merge.json")
+	s.mockPRs.EXPECT().
+		Merge(mock.Anything, "gitlab-15-7", true).
+		Return(domain.PullRequest{ID: "gitlab-15-7", Status: domain.PRStatusMerged}, nil).
+		Once()
+
+	result, err := s.service.HandleGitLab(s.ctx, gitlabMRHook, testGitLabToken, body)
+	s.Require().NoError(err)
+	s.True(result.Applied)
+
+	// повторная доставка того же события ничего не меняет и не считается ошибкой
+	s.mockPRs.EXPECT().
+		Merge(mock.Anything, "gitlab-15-7", true).
+		Return(domain.PullRequest{}, fmt.Errorf("pull request with ID gitlab-15-7: %w", domain.ErrPRAlreadyMerged)).
+		Once()
+
+	result, err = s.service.HandleGitLab(s.ctx, gitlabMRHook, testGitLabToken, body)
+	s.Require().NoError(err)
+	s.False(result.Applied)
+	s.Contains(result.Reason, domain.ErrPRAlreadyMerged.Error())
+}
+
+// TestSkippedAndFailed проверяет пропуск событий, не подходящих к состоянию PR, и проброс остальных ошибок
+func (s *ExternalEventServiceTestSuite) TestSkippedAndFailed() {
+	closed := s.fixture("github_pull_request_closed.json")
+	signature := githubSignature(testGitHubSecret, closed)
+
+	s.mockPRs.EXPECT().Close(mock.Anything, "github-1296269-1347").
+		Return(domain.PullRequest{}, domain.ErrPRNotFound).Once()
+	result, err := s.service.HandleGitHub(s.ctx, "pull_request", signature, closed)
+	s.Require().NoError(err)
+	s.False(result.Applied)
+
+	dbErr := errors.New("connection refused")
+	s.mockPRs.EXPECT().Close(mock.Anything, "github-1296269-1347").
+		Return(domain.PullRequest{}, dbErr).Once()
+	_, err = s.service.HandleGitHub(s.ctx, "pull_request", signature, closed)
+	s.ErrorIs(err, dbErr)
+
+	// события не про PR подтверждаются без изменений
+	ping := s.fixture("github_ping.json")
+	result, err = s.service.HandleGitHub(s.ctx, "ping", githubSignature(testGitHubSecret, ping), ping)
+	s.Require().NoError(err)
+	s.False(result.Applied)
+	s.Empty(result.Event.PullRequestID)
+}
+
+// TestSetLogin проверяет валидацию соответствия логинов
+func (s *ExternalEventServiceTestSuite) TestSetLogin() {
+	for _, login := range []domain.ExternalLogin{
+		{Provider: "bitbucket", Login: "octocat", UserID: "user-1"},
+		{Provider: domain.ExternalProviderGitHub, UserID: "user-1"},
+		{Provider: domain.ExternalProviderGitHub, Login: "octocat"},
+	} {
+		_, err := s.service.SetLogin(s.ctx, login)
+		s.ErrorIs(err, domain.ErrInvalidExternalLogin, login)
+	}
+
+	login := domain.ExternalLogin{Provider: domain.ExternalProviderGitHub, Login: "octocat", UserID: "user-404"}
+	s.mockLogins.EXPECT().Set(s.ctx, login).Return(domain.ExternalLogin{}, domain.ErrUserNotFound)
+	_, err := s.service.SetLogin(s.ctx, login)
+	s.ErrorIs(err, domain.ErrUserNotFound)
+
+	_, err = s.service.ListLogins(s.ctx, "bitbucket")
+	s.ErrorIs(err, domain.ErrInvalidExternalLogin)
+}
+
+// TestExternalEventServiceSuite запускает test suite
+func TestExternalEventServiceSuite(t *testing.T) {
+	suite.Run(t, new(ExternalEventServiceTestSuite))
+}
@@ -2,10 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
 	"slices"
-	"time"
 
 	"github.com/artmexbet/avito_test_task/internal/domain"
 )
@@ -13,42 +12,113 @@ import (
 type iPullRequestRepository interface {
 	Create(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error)
 	GetByID(ctx context.Context, prID string) (domain.PullRequest, error)
+	GetByIDForUpdate(ctx context.Context, prID string) (domain.PullRequest, error)
 	Merge(ctx context.Context, prID string) (domain.PullRequest, error)
+	SetStatus(ctx context.Context, prID string, status domain.PRStatus) (domain.PullRequest, error)
 	Exists(ctx context.Context, prID string) (bool, error)
+	SetNeedMoreReviewers(ctx context.Context, prID string, needMore bool) error
+	GetNeedingReviewers(ctx context.Context, teamName string) ([]domain.PullRequest, error)
+	GetOpenByReviewerIDs(ctx context.Context, reviewerIDs []string, authorTeam string) ([]domain.PullRequest, error)
+	List(ctx context.Context, filter domain.PullRequestFilter) ([]domain.PullRequest, error)
 }
 
 type iReviewRepository interface {
-	AssignToPR(ctx context.Context, prID string, reviewerIDs []string) error
-	Reassign(ctx context.Context, prID, newReviewerID, oldReviewerID string) error
+	AssignToPR(ctx context.Context, prID string, pool domain.ReviewerPool, reviewerIDs []string) error
+	Reassign(ctx context.Context, prID string, pool domain.ReviewerPool, newReviewerID, oldReviewerID string) error
+	Unassign(ctx context.Context, prID, reviewerID string) error
+	HandOver(ctx context.Context, handovers []domain.ReviewerHandover) error
+	CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error)
 	GetByPRID(ctx context.Context, prID string) ([]domain.User, error)
+	GetPoolsByPRID(ctx context.Context, prID string) (map[string]domain.ReviewerPool, error)
 	GetReviewingPR(ctx context.Context, userID string) ([]domain.PullRequest, error)
+	AddReview(ctx context.Context, review domain.Review) (domain.Review, error)
+	GetReviewsByPRID(ctx context.Context, prID string) ([]domain.Review, error)
+}
+
+type iTransactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	AfterCommit(ctx context.Context, fn func())
 }
 
 type iPRUserRepository interface {
 	GetByID(ctx context.Context, userID string) (domain.User, error)
+	GetByIDs(ctx context.Context, userIDs []string) ([]domain.User, error)
 	ExistsByID(ctx context.Context, userID string) (bool, error)
 	GetActiveByTeamName(ctx context.Context, teamName string) ([]domain.User, error)
 }
 
+type iReviewerPoolSource interface {
+	GetTeamFallbacks(ctx context.Context, teamName string) ([]domain.ReviewerPool, error)
+	GetActiveMembers(ctx context.Context, poolName string) ([]domain.User, error)
+	GetTeamsFallingBackTo(ctx context.Context, teamName string) ([]string, error)
+}
+
+// iPullRequestMetrics counts pull requests and reviewer changes. Changes made in a transaction are counted
+// once it commits
+type iPullRequestMetrics interface {
+	PullRequestCreated()
+	PullRequestMerged()
+	ReviewerReassigned()
+	NoCandidate()
+}
+
+// maxReviewersPerPR is the number of reviewers assigned to a new pull request
+const maxReviewersPerPR = 2
+
 type PullRequestService struct {
 	pullRequestRepo iPullRequestRepository
 	reviewRepo      iReviewRepository
 	userRepo        iPRUserRepository
+	poolRepo        iReviewerPoolSource
+	selector        ReviewerSelector
+	mergePolicy     domain.MergePolicy
+	auditRecorder   iAuditRecorder
+	outbox          iEventOutbox
+	transactor      iTransactor
+	metrics         iPullRequestMetrics
 }
 
 func NewPullRequestService(
 	pullRequestRepo iPullRequestRepository,
 	reviewRepo iReviewRepository,
 	userRepo iPRUserRepository,
+	poolRepo iReviewerPoolSource,
+	selector ReviewerSelector,
+	mergePolicy domain.MergePolicy,
+	auditRecorder iAuditRecorder,
+	outbox iEventOutbox,
+	transactor iTransactor,
+	metrics iPullRequestMetrics,
 ) *PullRequestService {
+	if metrics == nil {
+		metrics = nopPullRequestMetrics{}
+	}
 	return &PullRequestService{
 		pullRequestRepo: pullRequestRepo,
 		reviewRepo:      reviewRepo,
 		userRepo:        userRepo,
+		poolRepo:        poolRepo,
+		selector:        selector,
+		mergePolicy:     mergePolicy,
+		auditRecorder:   auditRecorder,
+		outbox:          outbox,
+		transactor:      transactor,
+		metrics:         metrics,
 	}
 }
 
+// Create creates a pull request and assigns reviewers to it atomically
 func (p *PullRequestService) Create(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
+	var created domain.PullRequest
+	err := p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = p.create(ctx, pr)
+		return err
+	})
+	return created, err
+}
+
+func (p *PullRequestService) create(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
 	// check if PR with same ID exists
 	exists, err := p.pullRequestRepo.Exists(ctx, pr.ID)
 	if err != nil {
@@ -63,62 +133,178 @@ func (p *PullRequestService) Create(ctx context.Context, pr domain.PullRequest)
 	if err != nil {
 		return domain.PullRequest{}, fmt.Errorf("error finding author: %w", err)
 	}
+	if author.IsDeleted() {
+		return domain.PullRequest{}, fmt.Errorf("author with ID %s: %w", pr.AuthorID, domain.ErrUserNotFound)
+	}
+
+	// drafts get reviewers only when they are marked ready
+	var picks []reviewerPick
+	if pr.Status == domain.PRStatusDraft {
+		pr.NeedMoreReviewers = false
+	} else {
+		picks, err = p.newReviewerPicker().pickReviewers(ctx, pr, author.TeamName)
+		if err != nil {
+			return domain.PullRequest{}, err
+		}
+		pr.NeedMoreReviewers = pickedCount(picks) < maxReviewersPerPR
+		if pr.NeedMoreReviewers {
+			p.transactor.AfterCommit(ctx, p.metrics.NoCandidate)
+		}
+	}
 
 	newPR, err := p.pullRequestRepo.Create(ctx, pr)
 	if err != nil {
 		return domain.PullRequest{}, fmt.Errorf("error creating pull request: %w", err)
 	}
 
-	// assign reviewers
-	activeUsers, err := p.userRepo.GetActiveByTeamName(ctx, author.TeamName)
-	if err != nil {
-		return domain.PullRequest{}, fmt.Errorf("error getting active users by team name: %w", err)
+	if err := p.assign(ctx, newPR.ID, picks); err != nil {
+		return domain.PullRequest{}, err
 	}
-	if len(activeUsers) == 0 {
-		return domain.PullRequest{},
-			fmt.Errorf("no active users in team %s: %w", author.TeamName, domain.ErrNoAvailableReviewers)
+
+	if err := p.loadReviewers(ctx, newPR.ID, &newPR); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	if err := recordAudit(ctx, p.auditRecorder, domain.AuditActionPRCreate, domain.AuditEntityPullRequest, newPR.ID,
+		nil, pullRequestSnapshot(newPR)); err != nil {
+		return domain.PullRequest{}, err
 	}
 
-	// exclude author from reviewers
-	var reviewerIDs []string
-	for _, user := range activeUsers {
-		if user.ID != author.ID {
-			reviewerIDs = append(reviewerIDs, user.ID)
+	p.transactor.AfterCommit(ctx, p.metrics.PullRequestCreated)
+	return newPR, nil
+}
+
+// reviewerPick is a group of reviewers picked from the same pool
+type reviewerPick struct {
+	pool  domain.ReviewerPool
+	users []domain.User
+}
+
+func pickedCount(picks []reviewerPick) int {
+	count := 0
+	for _, pick := range picks {
+		count += len(pick.users)
+	}
+	return count
+}
+
+// assign assigns the picked users to the pull request as reviewers and emits the event about it
+func (p *PullRequestService) assign(ctx context.Context, prID string, picks []reviewerPick) error {
+	reviewerIDs := make([]string, 0, pickedCount(picks))
+	for _, pick := range picks {
+		ids := make([]string, 0, len(pick.users))
+		for _, user := range pick.users {
+			ids = append(ids, user.ID)
 		}
+		if err := p.reviewRepo.AssignToPR(ctx, prID, pick.pool, ids); err != nil {
+			return fmt.Errorf("error assigning reviewers to pull request %s: %w", prID, err)
+		}
+		reviewerIDs = append(reviewerIDs, ids...)
 	}
 	if len(reviewerIDs) == 0 {
-		return domain.PullRequest{}, fmt.Errorf("no available users to assign: %w", domain.ErrNoAvailableReviewers)
+		return nil
 	}
-	if len(reviewerIDs) > 2 {
-		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-		rng.Shuffle(len(reviewerIDs), func(i, j int) {
-			reviewerIDs[i], reviewerIDs[j] = reviewerIDs[j], reviewerIDs[i]
-		})
-		reviewerIDs = reviewerIDs[:2]
+	return emitReviewersAssigned(ctx, p.outbox, prID, reviewerIDs)
+}
+
+// loadReviewers reads the reviewers of the pull request and the pools they were picked from
+func (p *PullRequestService) loadReviewers(ctx context.Context, prID string, pr *domain.PullRequest) error {
+	var err error
+	pr.Reviewers, err = p.reviewRepo.GetByPRID(ctx, prID)
+	if err != nil {
+		return fmt.Errorf("error getting reviewers for pull request: %w", err)
 	}
+	pr.ReviewerPools, err = p.reviewRepo.GetPoolsByPRID(ctx, prID)
+	if err != nil {
+		return fmt.Errorf("error getting reviewer pools for pull request: %w", err)
+	}
+	return nil
+}
 
-	if err := p.reviewRepo.AssignToPR(ctx, newPR.ID, reviewerIDs); err != nil {
-		return domain.PullRequest{}, fmt.Errorf("error assigning reviewers to pull request: %w", err)
+// Get returns a pull request with its reviewers and their latest verdicts
+func (p *PullRequestService) Get(ctx context.Context, prID string) (domain.PullRequest, error) {
+	pr, err := p.pullRequestRepo.GetByID(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error getting pull request: %w", err)
+	}
+	pr.Reviews, err = p.reviewRepo.GetReviewsByPRID(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error getting reviews for pull request: %w", err)
+	}
+	return pr, nil
+}
+
+// List returns a page of pull requests matching the filter, newest first.
+// The page holds filter.Limit pull requests at most, DefaultPullRequestPageSize if the limit is not set
+func (p *PullRequestService) List(ctx context.Context, filter domain.PullRequestFilter) (domain.PullRequestPage, error) {
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultPullRequestPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > domain.MaxPullRequestPageSize {
+		return domain.PullRequestPage{}, fmt.Errorf("%w: limit must be between 1 and %d",
+			domain.ErrInvalidPRFilter, domain.MaxPullRequestPageSize)
+	}
+	if filter.Status != "" && !domain.IsValidPRStatus(filter.Status) {
+		return domain.PullRequestPage{}, fmt.Errorf("%w: unknown status %q", domain.ErrInvalidPRFilter, filter.Status)
 	}
 
-	newPR.Reviewers, err = p.reviewRepo.GetByPRID(ctx, newPR.ID)
+	// timestamps are stored in UTC without time zone
+	filter.CreatedFrom, filter.CreatedTo = filter.CreatedFrom.UTC(), filter.CreatedTo.UTC()
+	filter.MergedFrom, filter.MergedTo = filter.MergedFrom.UTC(), filter.MergedTo.UTC()
+
+	// one extra pull request tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	prs, err := p.pullRequestRepo.List(ctx, filter)
 	if err != nil {
-		return domain.PullRequest{}, fmt.Errorf("error getting author by ID: %w", err)
+		return domain.PullRequestPage{}, fmt.Errorf("error listing pull requests: %w", err)
 	}
 
-	return newPR, nil
+	page := domain.PullRequestPage{PullRequests: prs}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+		last := page.PullRequests[limit-1]
+		page.Next = &domain.PullRequestCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return page, nil
 }
 
-func (p *PullRequestService) Merge(ctx context.Context, prID string) (domain.PullRequest, error) {
-	// check if PR exists
-	pr, err := p.pullRequestRepo.GetByID(ctx, prID)
+// Merge marks a pull request as merged. If it is already merged, the PR is returned with ErrPRAlreadyMerged.
+// The PR has to meet the merge policy, otherwise *domain.MergePolicyError is returned.
+// force bypasses the policy, such merges are recorded in the audit log as AuditActionPRForceMerge
+func (p *PullRequestService) Merge(ctx context.Context, prID string, force bool) (domain.PullRequest, error) {
+	var merged domain.PullRequest
+	err := p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		merged, err = p.merge(ctx, prID, force)
+		return err
+	})
+	return merged, err
+}
+
+func (p *PullRequestService) merge(ctx context.Context, prID string, force bool) (domain.PullRequest, error) {
+	// lock the PR, so that no verdict changes between the policy check and the merge
+	pr, err := p.pullRequestRepo.GetByIDForUpdate(ctx, prID)
 	if err != nil {
 		return domain.PullRequest{}, fmt.Errorf("error checking existing pull request: %w", err)
 	}
 
-	// check if PR is already merged
-	if pr.Status == domain.PRStatusMerged {
-		return pr, fmt.Errorf("pull request with ID %s: %w", prID, domain.ErrPRAlreadyMerged)
+	// only open PRs can be merged, merging a merged PR returns ErrPRAlreadyMerged
+	if err := domain.CheckPRTransition(pr.Status, domain.PRStatusMerged); err != nil {
+		return pr, fmt.Errorf("pull request with ID %s: %w", prID, err)
+	}
+
+	reviews, err := p.reviewRepo.GetReviewsByPRID(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error getting reviews for pull request: %w", err)
+	}
+	violations, err := p.checkMergePolicy(ctx, pr, reviews)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	if len(violations) > 0 && !force {
+		return domain.PullRequest{}, fmt.Errorf("pull request with ID %s: %w", prID,
+			&domain.MergePolicyError{Violations: violations})
 	}
 
 	mergedPR, err := p.pullRequestRepo.Merge(ctx, prID)
@@ -126,15 +312,259 @@ func (p *PullRequestService) Merge(ctx context.Context, prID string) (domain.Pul
 		return domain.PullRequest{}, fmt.Errorf("error merging pull request: %w", err)
 	}
 
-	// retrieve reviewers
-	mergedPR.Reviewers, err = p.reviewRepo.GetByPRID(ctx, prID)
-	if err != nil {
-		return domain.PullRequest{}, fmt.Errorf("error getting reviewers for pull request: %w", err)
+	if err := p.loadReviewers(ctx, prID, &mergedPR); err != nil {
+		return domain.PullRequest{}, err
+	}
+	mergedPR.Reviews = reviews
+
+	action, after := domain.AuditActionPRMerge, any(pullRequestSnapshot(mergedPR))
+	if len(violations) > 0 {
+		action = domain.AuditActionPRForceMerge
+		after = auditForceMerge{auditPullRequest: pullRequestSnapshot(mergedPR), BypassedViolations: violations}
+	}
+	if err := recordAudit(ctx, p.auditRecorder, action, domain.AuditEntityPullRequest, prID,
+		pullRequestSnapshot(pr), after); err != nil {
+		return domain.PullRequest{}, err
+	}
+	if err := emitEvent(ctx, p.outbox, domain.EventPullRequestMerged, pullRequestMergedEvent{
+		PullRequestID: prID,
+		AuthorID:      mergedPR.AuthorID,
+		MergedAt:      mergedPR.MergedAt,
+		Forced:        len(violations) > 0,
+	}); err != nil {
+		return domain.PullRequest{}, err
 	}
 
+	p.transactor.AfterCommit(ctx, p.metrics.PullRequestMerged)
 	return mergedPR, nil
 }
 
+// checkMergePolicy returns the merge policy rules the pull request breaks
+func (p *PullRequestService) checkMergePolicy(
+	ctx context.Context,
+	pr domain.PullRequest,
+	reviews []domain.Review,
+) ([]string, error) {
+	var (
+		authorTeam    string
+		reviewerTeams map[string]string
+	)
+	// teams are needed only to look for an approval from outside the author's team
+	if p.mergePolicy.RequireOutsideApproval {
+		author, err := p.userRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return nil, fmt.Errorf("error finding author: %w", err)
+		}
+		authorTeam = author.TeamName
+
+		reviewerTeams = make(map[string]string, len(reviews))
+		for _, r := range reviews {
+			if r.Verdict != domain.ReviewVerdictApproved {
+				continue
+			}
+			reviewer, err := p.userRepo.GetByID(ctx, r.ReviewerID)
+			if err != nil {
+				return nil, fmt.Errorf("error finding reviewer: %w", err)
+			}
+			reviewerTeams[r.ReviewerID] = reviewer.TeamName
+		}
+	}
+	return p.mergePolicy.Violations(reviews, authorTeam, reviewerTeams), nil
+}
+
+// checkOpen returns ErrPRAlreadyMerged for merged pull requests and ErrPRNotOpen for drafts and closed ones
+func checkOpen(pr domain.PullRequest) error {
+	switch pr.Status {
+	case domain.PRStatusOpen:
+		return nil
+	case domain.PRStatusMerged:
+		return fmt.Errorf("pull request with ID %s: %w", pr.ID, domain.ErrPRAlreadyMerged)
+	default:
+		return fmt.Errorf("pull request with ID %s is %s: %w", pr.ID, pr.Status, domain.ErrPRNotOpen)
+	}
+}
+
+// MarkReady moves a draft pull request to OPEN and assigns reviewers to it
+func (p *PullRequestService) MarkReady(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return p.transition(ctx, prID, domain.PRStatusDraft, domain.PRStatusOpen)
+}
+
+// Close abandons an open or draft pull request without merging. Its reviewers are released
+func (p *PullRequestService) Close(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return p.transition(ctx, prID, "", domain.PRStatusClosed)
+}
+
+// Reopen moves a closed pull request back to OPEN. The previous reviewers get the review back,
+// missing ones are assigned as on creation
+func (p *PullRequestService) Reopen(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return p.transition(ctx, prID, domain.PRStatusClosed, domain.PRStatusOpen)
+}
+
+// transition moves a pull request to the status atomically. If from is set, the pull request must be in it.
+// The change is recorded in the audit log and emitted as EventPullRequestStatusChanged
+func (p *PullRequestService) transition(
+	ctx context.Context,
+	prID string,
+	from, to domain.PRStatus,
+) (domain.PullRequest, error) {
+	var updated domain.PullRequest
+	err := p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err := p.pullRequestRepo.GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("error checking existing pull request: %w", err)
+		}
+		if from != "" && pr.Status != from {
+			return fmt.Errorf("pull request with ID %s: %w: %s is not %s", prID, domain.ErrInvalidPRTransition,
+				pr.Status, from)
+		}
+		if err := domain.CheckPRTransition(pr.Status, to); err != nil {
+			return fmt.Errorf("pull request with ID %s: %w", prID, err)
+		}
+
+		updated, err = p.pullRequestRepo.SetStatus(ctx, prID, to)
+		if err != nil {
+			return fmt.Errorf("error updating pull request status: %w", err)
+		}
+		if to == domain.PRStatusOpen {
+			updated.NeedMoreReviewers, err = p.fillReviewers(ctx, pr)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := p.loadReviewers(ctx, prID, &updated); err != nil {
+			return err
+		}
+		updated.Reviews, err = p.reviewRepo.GetReviewsByPRID(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("error getting reviews for pull request: %w", err)
+		}
+
+		if err := recordAudit(ctx, p.auditRecorder, domain.AuditActionPRSetStatus, domain.AuditEntityPullRequest,
+			prID, pullRequestSnapshot(pr), pullRequestSnapshot(updated)); err != nil {
+			return err
+		}
+		return emitPullRequestStatusChanged(ctx, p.outbox, updated, pr.Status)
+	})
+	return updated, err
+}
+
+// fillReviewers assigns missing reviewers to a pull request that became open.
+// It returns the updated NeedMoreReviewers flag of the pull request
+func (p *PullRequestService) fillReviewers(ctx context.Context, pr domain.PullRequest) (bool, error) {
+	author, err := p.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return false, fmt.Errorf("error finding author: %w", err)
+	}
+	picks, err := p.newReviewerPicker().pickReviewers(ctx, pr, author.TeamName)
+	if err != nil {
+		return false, err
+	}
+	if err := p.assign(ctx, pr.ID, picks); err != nil {
+		return false, err
+	}
+
+	needMore := len(pr.Reviewers)+pickedCount(picks) < maxReviewersPerPR
+	if needMore {
+		p.transactor.AfterCommit(ctx, p.metrics.NoCandidate)
+	}
+	if err := p.pullRequestRepo.SetNeedMoreReviewers(ctx, pr.ID, needMore); err != nil {
+		return false, fmt.Errorf("error updating pull request %s: %w", pr.ID, err)
+	}
+	return needMore, nil
+}
+
+// TopUpReviewers assigns missing reviewers to open pull requests flagged with NeedMoreReviewers, authored by members
+// of the team or of the teams falling back to it. It is called when the team gets new active members
+// and returns the pull requests that got reviewers.
+func (p *PullRequestService) TopUpReviewers(ctx context.Context, teamName string) ([]domain.PullRequest, error) {
+	var updated []domain.PullRequest
+	err := p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = p.topUpReviewers(ctx, teamName)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (p *PullRequestService) topUpReviewers(ctx context.Context, teamName string) ([]domain.PullRequest, error) {
+	// новые участники команды могут ревьюить и PR команд, для которых она или её общий пул - запасной
+	dependents, err := p.poolRepo.GetTeamsFallingBackTo(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting teams falling back to team %s: %w", teamName, err)
+	}
+	teamNames := []string{teamName}
+	for _, dependent := range dependents {
+		if !slices.Contains(teamNames, dependent) {
+			teamNames = append(teamNames, dependent)
+		}
+	}
+
+	var updated []domain.PullRequest
+	picker := p.newReviewerPicker()
+	for _, authorTeam := range teamNames {
+		topped, err := p.topUpTeam(ctx, picker, authorTeam)
+		if err != nil {
+			return nil, err
+		}
+		updated = append(updated, topped...)
+	}
+	return updated, nil
+}
+
+// topUpTeam assigns missing reviewers to the flagged pull requests authored by members of the team
+func (p *PullRequestService) topUpTeam(
+	ctx context.Context,
+	picker *reviewerPicker,
+	teamName string,
+) ([]domain.PullRequest, error) {
+	prs, err := p.pullRequestRepo.GetNeedingReviewers(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pull requests needing reviewers: %w", err)
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+
+	var updated []domain.PullRequest
+	for _, pr := range prs {
+		picks, err := picker.pickReviewers(ctx, pr, teamName)
+		if err != nil {
+			return nil, err
+		}
+		if len(pr.Reviewers)+pickedCount(picks) < maxReviewersPerPR {
+			p.transactor.AfterCommit(ctx, p.metrics.NoCandidate)
+		}
+		if len(picks) == 0 && len(pr.Reviewers) < maxReviewersPerPR {
+			continue
+		}
+		if err := p.assign(ctx, pr.ID, picks); err != nil {
+			return nil, err
+		}
+
+		for _, pick := range picks {
+			if pr.ReviewerPools == nil {
+				pr.ReviewerPools = make(map[string]domain.ReviewerPool, maxReviewersPerPR)
+			}
+			for _, user := range pick.users {
+				pr.Reviewers = append(pr.Reviewers, user)
+				pr.ReviewerPools[user.ID] = pick.pool
+			}
+		}
+		pr.NeedMoreReviewers = len(pr.Reviewers) < maxReviewersPerPR
+		if !pr.NeedMoreReviewers {
+			if err := p.pullRequestRepo.SetNeedMoreReviewers(ctx, pr.ID, false); err != nil {
+				return nil, fmt.Errorf("error updating pull request %s: %w", pr.ID, err)
+			}
+		}
+		updated = append(updated, pr)
+	}
+	return updated, nil
+}
+
 func (p *PullRequestService) GetReviewingPRs(ctx context.Context, userID string) ([]domain.PullRequest, error) {
 	// check user
 	exists, err := p.userRepo.ExistsByID(ctx, userID)
@@ -152,18 +582,42 @@ func (p *PullRequestService) GetReviewingPRs(ctx context.Context, userID string)
 	return prs, nil
 }
 
+// ReassignReviewer replaces oldReviewerID on the pull request with another active member of the team atomically
 func (p *PullRequestService) ReassignReviewer(
 	ctx context.Context,
 	prID, oldReviewerID string,
 ) (*domain.PullRequest, string, error) {
-	// check if PR exists
-	pr, err := p.pullRequestRepo.GetByID(ctx, prID)
+	var (
+		pr            *domain.PullRequest
+		newReviewerID string
+	)
+	err := p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, newReviewerID, err = p.reassignReviewer(ctx, prID, oldReviewerID)
+		return err
+	})
+	if errors.Is(err, domain.ErrNoAvailableReviewers) {
+		// транзакция откатилась, но неудачная попытка всё равно считается
+		p.metrics.NoCandidate()
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return pr, newReviewerID, nil
+}
+
+func (p *PullRequestService) reassignReviewer(
+	ctx context.Context,
+	prID, oldReviewerID string,
+) (*domain.PullRequest, string, error) {
+	// lock the PR, so that concurrent reassignments wait for us and see the reviewers we leave
+	pr, err := p.pullRequestRepo.GetByIDForUpdate(ctx, prID)
 	if err != nil {
 		return nil, "", fmt.Errorf("error checking existing pull request: %w", err)
 	}
 
-	if pr.Status == domain.PRStatusMerged {
-		return nil, "", fmt.Errorf("pull request with ID %s: %w", prID, domain.ErrPRAlreadyMerged)
+	if err := checkOpen(pr); err != nil {
+		return nil, "", err
 	}
 
 	// check old reviewer
@@ -175,11 +629,8 @@ func (p *PullRequestService) ReassignReviewer(
 		return nil, "", fmt.Errorf("user with ID %s: %w", oldReviewerID, domain.ErrUserNotFound)
 	}
 
-	// check if old reviewer is assigned to the PR
-	assignedReviewers, err := p.reviewRepo.GetByPRID(ctx, prID)
-	if err != nil {
-		return nil, "", fmt.Errorf("error getting assigned reviewers: %w", err)
-	}
+	// check if old reviewer is still assigned to the PR, reviewers are read after the lock is taken
+	assignedReviewers := pr.Reviewers
 	mapAssignedReviewers := make(map[string]struct{}, len(assignedReviewers))
 	for _, reviewer := range assignedReviewers {
 		mapAssignedReviewers[reviewer.ID] = struct{}{}
@@ -188,26 +639,221 @@ func (p *PullRequestService) ReassignReviewer(
 		return nil, "", fmt.Errorf("old reviewer with ID %s: %w", oldReviewerID, domain.ErrReviewerNotAssigned)
 	}
 
-	activeUsers, err := p.userRepo.GetActiveByTeamName(ctx, assignedReviewers[0].TeamName)
+	newReviewer, pool, err := p.newReviewerPicker().pickReplacement(ctx, pr, oldReviewerID)
+	if err != nil {
+		return nil, "", err
+	}
+	newReviewerID := newReviewer.ID
+	before := pullRequestSnapshot(pr)
+
+	if err := p.reviewRepo.Reassign(ctx, prID, pool, newReviewerID, oldReviewerID); err != nil {
+		return nil, "", fmt.Errorf("error reassigning reviewer: %w", err)
+	}
+
+	if err := p.loadReviewers(ctx, prID, &pr); err != nil {
+		return nil, "", err
+	}
+	pr.Reviews, err = p.reviewRepo.GetReviewsByPRID(ctx, prID)
+	if err != nil {
+		return nil, "", fmt.Errorf("error getting reviews of pull request by ID: %w", err)
+	}
+
+	if err := recordAudit(ctx, p.auditRecorder, domain.AuditActionPRReassign, domain.AuditEntityPullRequest, prID,
+		before, pullRequestSnapshot(pr)); err != nil {
+		return nil, "", err
+	}
+	if err := emitReviewerReassigned(ctx, p.outbox, domain.ReviewerReplacement{
+		PullRequestID: prID,
+		OldReviewerID: oldReviewerID,
+		NewReviewerID: newReviewerID,
+	}); err != nil {
+		return nil, "", err
+	}
+	p.transactor.AfterCommit(ctx, p.metrics.ReviewerReassigned)
+	return &pr, newReviewerID, nil
+}
+
+// HandOverReviews replaces the reviewers in every open pull request they review atomically. Replacements are
+// picked as in ReassignReviewer. With authorTeam set only pull requests authored by its members are touched.
+// A reviewer nobody can replace is just removed, such replacements have an empty NewReviewerID
+// and the pull request gets NeedMoreReviewers
+func (p *PullRequestService) HandOverReviews(
+	ctx context.Context,
+	reviewerIDs []string,
+	authorTeam string,
+) ([]domain.ReviewerReplacement, error) {
+	var replacements []domain.ReviewerReplacement
+	err := p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		replacements, err = p.handOverReviews(ctx, reviewerIDs, authorTeam)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return replacements, nil
+}
+
+func (p *PullRequestService) handOverReviews(
+	ctx context.Context,
+	reviewerIDs []string,
+	authorTeam string,
+) ([]domain.ReviewerReplacement, error) {
+	prs, err := p.pullRequestRepo.GetOpenByReviewerIDs(ctx, reviewerIDs, authorTeam)
 	if err != nil {
-		return nil, "", fmt.Errorf("error getting active users by team name: %w", err)
+		return nil, fmt.Errorf("error getting open pull requests of reviewers: %w", err)
+	}
+	replacements := make([]domain.ReviewerReplacement, 0)
+	if len(prs) == 0 {
+		return replacements, nil
+	}
+
+	// Кандидатов и их нагрузку читаем один раз на пул, замены пишем пачкой
+	picker := p.newReviewerPicker()
+	authorIDs := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		authorIDs = append(authorIDs, pr.AuthorID)
+	}
+	if err := picker.loadAuthors(ctx, authorIDs); err != nil {
+		return nil, err
+	}
+	var (
+		handovers []domain.ReviewerHandover
+		events    []domain.AuditEvent
+	)
+	for _, pr := range prs {
+		for _, reviewer := range slices.Clone(pr.Reviewers) {
+			if !slices.Contains(reviewerIDs, reviewer.ID) {
+				continue
+			}
+			before := pullRequestSnapshot(pr)
+			handover, err := p.handOverReview(ctx, picker, &pr, reviewer.ID)
+			if err != nil {
+				return nil, err
+			}
+			event, err := newAuditEvent(ctx, domain.AuditActionPRReassign, domain.AuditEntityPullRequest, pr.ID,
+				before, pullRequestSnapshot(pr))
+			if err != nil {
+				return nil, err
+			}
+			handovers = append(handovers, handover)
+			events = append(events, event)
+			replacements = append(replacements, handover.ReviewerReplacement)
+		}
 	}
-	r := slices.DeleteFunc(activeUsers, func(user domain.User) bool {
-		_, isAssigned := mapAssignedReviewers[user.ID]
-		return isAssigned || user.ID == oldReviewerID || user.ID == pr.AuthorID
+
+	if err := p.reviewRepo.HandOver(ctx, handovers); err != nil {
+		return nil, fmt.Errorf("error handing over reviews: %w", err)
+	}
+	if err := recordAudits(ctx, p.auditRecorder, events); err != nil {
+		return nil, err
+	}
+	if err := emitReviewersReassigned(ctx, p.outbox, replacements); err != nil {
+		return nil, err
+	}
+	return replacements, nil
+}
+
+// handOverReview picks the replacement of the reviewer on the pull request, nobody is picked if nobody may take
+// the review. The reviewers of pr are updated accordingly, the change itself is left to the caller
+func (p *PullRequestService) handOverReview(
+	ctx context.Context,
+	picker *reviewerPicker,
+	pr *domain.PullRequest,
+	reviewerID string,
+) (domain.ReviewerHandover, error) {
+	handover := domain.ReviewerHandover{ //nolint:exhaustruct // Замену и пул заполняем, если нашёлся ревьювер
+		ReviewerReplacement: domain.ReviewerReplacement{PullRequestID: pr.ID, OldReviewerID: reviewerID},
+	}
+	newReviewer, pool, err := picker.pickReplacement(ctx, *pr, reviewerID)
+	switch {
+	case errors.Is(err, domain.ErrNoAvailableReviewers):
+		// PR доберёт ревьюверов, когда в команде появятся активные
+		pr.NeedMoreReviewers = true
+		p.transactor.AfterCommit(ctx, p.metrics.NoCandidate)
+	case err != nil:
+		return domain.ReviewerHandover{}, err
+	default:
+		p.transactor.AfterCommit(ctx, p.metrics.ReviewerReassigned)
+		handover.NewReviewerID = newReviewer.ID
+		handover.Pool = pool
+		if pr.ReviewerPools == nil {
+			pr.ReviewerPools = make(map[string]domain.ReviewerPool, maxReviewersPerPR)
+		}
+		pr.Reviewers = append(pr.Reviewers, newReviewer)
+		pr.ReviewerPools[newReviewer.ID] = pool
+	}
+	pr.Reviewers = slices.DeleteFunc(pr.Reviewers, func(user domain.User) bool { return user.ID == reviewerID })
+	delete(pr.ReviewerPools, reviewerID)
+	return handover, nil
+}
+
+// Review records the verdict of an assigned reviewer on an open pull request, the verdict goes to the audit log.
+// The reviewer may submit several verdicts, the pull request holds the latest one of each reviewer
+func (p *PullRequestService) Review(
+	ctx context.Context,
+	prID, reviewerID string,
+	verdict domain.ReviewVerdict,
+) (domain.PullRequest, error) {
+	var reviewed domain.PullRequest
+	err := p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		reviewed, err = p.review(ctx, prID, reviewerID, verdict)
+		return err
 	})
+	return reviewed, err
+}
 
-	if len(r) == 0 {
-		return nil, "", fmt.Errorf("no available active users to reassign as reviewer: %w", domain.ErrNoAvailableReviewers)
+func (p *PullRequestService) review(
+	ctx context.Context,
+	prID, reviewerID string,
+	verdict domain.ReviewVerdict,
+) (domain.PullRequest, error) {
+	// lock the PR, so that the verdict can't sneak in while the PR is being merged
+	pr, err := p.pullRequestRepo.GetByIDForUpdate(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error checking existing pull request: %w", err)
+	}
+	if err := checkOpen(pr); err != nil {
+		return domain.PullRequest{}, err
 	}
 
-	if err := p.reviewRepo.Reassign(ctx, prID, r[0].ID, oldReviewerID); err != nil {
-		return nil, "", fmt.Errorf("error reassigning reviewer: %w", err)
+	exists, err := p.userRepo.ExistsByID(ctx, reviewerID)
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error checking existing user: %w", err)
+	}
+	if !exists {
+		return domain.PullRequest{}, fmt.Errorf("user with ID %s: %w", reviewerID, domain.ErrUserNotFound)
+	}
+	if !slices.ContainsFunc(pr.Reviewers, func(r domain.User) bool { return r.ID == reviewerID }) {
+		return domain.PullRequest{}, fmt.Errorf("reviewer with ID %s: %w", reviewerID, domain.ErrReviewerNotAssigned)
 	}
 
-	pr.Reviewers, err = p.reviewRepo.GetByPRID(ctx, prID)
+	added, err := p.reviewRepo.AddReview(ctx, domain.Review{
+		PullRequestID: prID,
+		ReviewerID:    reviewerID,
+		Verdict:       verdict,
+	})
 	if err != nil {
-		return nil, "", fmt.Errorf("error getting reviewers of pull request by ID: %w", err)
+		return domain.PullRequest{}, fmt.Errorf("error adding review: %w", err)
 	}
-	return &pr, r[0].ID, nil
+	// вердикты только добавляются, поэтому состояния до изменения нет
+	if err := recordAudit(ctx, p.auditRecorder, domain.AuditActionPRReview, domain.AuditEntityPullRequest, prID,
+		nil, reviewSnapshot(added)); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	pr.Reviews, err = p.reviewRepo.GetReviewsByPRID(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("error getting reviews of pull request by ID: %w", err)
+	}
+	return pr, nil
 }
+
+// nopPullRequestMetrics is used when the service is created without metrics
+type nopPullRequestMetrics struct{}
+
+func (nopPullRequestMetrics) PullRequestCreated() {}
+func (nopPullRequestMetrics) PullRequestMerged()  {}
+func (nopPullRequestMetrics) ReviewerReassigned() {}
+func (nopPullRequestMetrics) NoCandidate()        {}
@@ -3,8 +3,12 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/artmexbet/avito_test_task/internal/domain"
@@ -21,6 +25,55 @@ func (s *PullRequestServiceTestSuite) SetupTest() {
 	s.ctx = context.Background()
 }
 
+// newPassthroughTransactor возвращает мок транзакций, который просто вызывает переданную функцию,
+// а функции после коммита вызывает сразу
+func newPassthroughTransactor(t *testing.T) *mockiTransactor {
+	transactor := newMockiTransactor(t)
+	transactor.EXPECT().
+		WithinTransaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).Maybe()
+	transactor.EXPECT().
+		AfterCommit(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, fn func()) {
+			fn()
+		}).Maybe()
+	return transactor
+}
+
+// newAcceptingAuditRecorder возвращает мок журнала аудита, который принимает любые события
+func newAcceptingAuditRecorder(t *testing.T) *mockiAuditRecorder {
+	recorder := newMockiAuditRecorder(t)
+	recorder.EXPECT().
+		Add(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, event domain.AuditEvent) (domain.AuditEvent, error) {
+			return event, nil
+		}).Maybe()
+	recorder.EXPECT().AddBatch(mock.Anything, mock.Anything).Return(nil).Maybe()
+	return recorder
+}
+
+// newNoFallbacks возвращает мок источника пулов для команд без запасных пулов
+func newNoFallbacks(t *testing.T) *mockiReviewerPoolSource {
+	pools := newMockiReviewerPoolSource(t)
+	pools.EXPECT().GetTeamFallbacks(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	pools.EXPECT().GetTeamsFallingBackTo(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return pools
+}
+
+// newAcceptingOutbox возвращает мок outbox, который принимает любые события
+func newAcceptingOutbox(t *testing.T) *mockiEventOutbox {
+	outbox := newMockiEventOutbox(t)
+	outbox.EXPECT().
+		AddEvent(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, event domain.OutboxEvent) (domain.OutboxEvent, error) {
+			return event, nil
+		}).Maybe()
+	outbox.EXPECT().AddEvents(mock.Anything, mock.Anything).Return(nil).Maybe()
+	return outbox
+}
+
 // TestCreate проверяет метод Create
 func (s *PullRequestServiceTestSuite) TestCreate() {
 	tests := []struct {
@@ -69,6 +122,10 @@ func (s *PullRequestServiceTestSuite) TestCreate() {
 					GetByID(ctx, "author-1").
 					Return(author, nil).Once()
 
+				mockUserRepo.EXPECT().
+					GetActiveByTeamName(ctx, "backend-team").
+					Return(activeUsers, nil).Once()
+
 				mockPRRepo.EXPECT().
 					Create(ctx, domain.PullRequest{
 						ID:       "pr-1",
@@ -78,17 +135,14 @@ func (s *PullRequestServiceTestSuite) TestCreate() {
 					}).
 					Return(createdPR, nil).Once()
 
-				mockUserRepo.EXPECT().
-					GetActiveByTeamName(ctx, "backend-team").
-					Return(activeUsers, nil).Once()
-
 				mockReviewRepo.EXPECT().
-					AssignToPR(ctx, "pr-1", []string{"user-2", "user-3"}).
+					AssignToPR(ctx, "pr-1", domain.TeamPool("backend-team"), []string{"user-2", "user-3"}).
 					Return(nil).Once()
 
 				mockReviewRepo.EXPECT().
 					GetByPRID(ctx, "pr-1").
 					Return(reviewers, nil).Once()
+				mockReviewRepo.EXPECT().GetPoolsByPRID(ctx, "pr-1").Return(nil, nil).Once()
 			},
 			wantErr: false,
 			checkResult: func(result domain.PullRequest) {
@@ -96,6 +150,28 @@ func (s *PullRequestServiceTestSuite) TestCreate() {
 				s.Len(result.Reviewers, 2)
 			},
 		},
+		{
+			name: "success - draft gets no reviewers",
+			pr: domain.PullRequest{
+				ID:       "pr-1",
+				AuthorID: "author-1",
+				Status:   domain.PRStatusDraft,
+			},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				draft := domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.PRStatusDraft}
+
+				mockPRRepo.EXPECT().Exists(ctx, "pr-1").Return(false, nil).Once()
+				mockUserRepo.EXPECT().GetByID(ctx, "author-1").Return(domain.User{ID: "author-1", TeamName: "backend-team"}, nil).Once()
+				mockPRRepo.EXPECT().Create(ctx, draft).Return(draft, nil).Once()
+				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return(nil, nil).Once()
+				mockReviewRepo.EXPECT().GetPoolsByPRID(ctx, "pr-1").Return(nil, nil).Once()
+			},
+			checkResult: func(result domain.PullRequest) {
+				s.Equal(domain.PRStatusDraft, result.Status)
+				s.Empty(result.Reviewers)
+				s.False(result.NeedMoreReviewers)
+			},
+		},
 		{
 			name: "success - one reviewer",
 			pr: domain.PullRequest{
@@ -112,7 +188,7 @@ func (s *PullRequestServiceTestSuite) TestCreate() {
 					{ID: "author-1", TeamName: "small-team", IsActive: true},
 					{ID: "user-2", Username: "bob", TeamName: "small-team", IsActive: true},
 				}
-				createdPR := domain.PullRequest{ID: "pr-1"}
+				createdPR := domain.PullRequest{ID: "pr-1", NeedMoreReviewers: true}
 				reviewers := []domain.User{
 					{ID: "user-2", Username: "bob"},
 				}
@@ -125,29 +201,73 @@ func (s *PullRequestServiceTestSuite) TestCreate() {
 					GetByID(ctx, "author-1").
 					Return(author, nil).Once()
 
+				mockUserRepo.EXPECT().
+					GetActiveByTeamName(ctx, "small-team").
+					Return(activeUsers, nil).Once()
+
 				mockPRRepo.EXPECT().
 					Create(ctx, domain.PullRequest{
-						ID:       "pr-1",
-						AuthorID: "author-1",
+						ID:                "pr-1",
+						AuthorID:          "author-1",
+						NeedMoreReviewers: true,
 					}).
 					Return(createdPR, nil).Once()
 
-				mockUserRepo.EXPECT().
-					GetActiveByTeamName(ctx, "small-team").
-					Return(activeUsers, nil).Once()
-
 				mockReviewRepo.EXPECT().
-					AssignToPR(ctx, "pr-1", []string{"user-2"}).
+					AssignToPR(ctx, "pr-1", domain.TeamPool("small-team"), []string{"user-2"}).
 					Return(nil).Once()
 
 				mockReviewRepo.EXPECT().
 					GetByPRID(ctx, "pr-1").
 					Return(reviewers, nil).Once()
+				mockReviewRepo.EXPECT().GetPoolsByPRID(ctx, "pr-1").Return(nil, nil).Once()
 			},
 			wantErr: false,
 			checkResult: func(result domain.PullRequest) {
 				s.Equal("pr-1", result.ID)
 				s.Len(result.Reviewers, 1)
+				s.True(result.NeedMoreReviewers)
+			},
+		},
+		{
+			name: "success - no reviewers in solo team",
+			pr: domain.PullRequest{
+				ID:       "pr-1",
+				AuthorID: "author-1",
+			},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				author := domain.User{ID: "author-1", TeamName: "solo-team", IsActive: true}
+
+				mockPRRepo.EXPECT().
+					Exists(ctx, "pr-1").
+					Return(false, nil).Once()
+
+				mockUserRepo.EXPECT().
+					GetByID(ctx, "author-1").
+					Return(author, nil).Once()
+
+				mockUserRepo.EXPECT().
+					GetActiveByTeamName(ctx, "solo-team").
+					Return([]domain.User{author}, nil).Once()
+
+				mockPRRepo.EXPECT().
+					Create(ctx, domain.PullRequest{
+						ID:                "pr-1",
+						AuthorID:          "author-1",
+						NeedMoreReviewers: true,
+					}).
+					Return(domain.PullRequest{ID: "pr-1", NeedMoreReviewers: true}, nil).Once()
+
+				mockReviewRepo.EXPECT().
+					GetByPRID(ctx, "pr-1").
+					Return(nil, nil).Once()
+				mockReviewRepo.EXPECT().GetPoolsByPRID(ctx, "pr-1").Return(nil, nil).Once()
+			},
+			wantErr: false,
+			checkResult: func(result domain.PullRequest) {
+				s.Equal("pr-1", result.ID)
+				s.Empty(result.Reviewers)
+				s.True(result.NeedMoreReviewers)
 			},
 		},
 		{
@@ -207,10 +327,15 @@ func (s *PullRequestServiceTestSuite) TestCreate() {
 					GetByID(ctx, "author-1").
 					Return(author, nil).Once()
 
+				mockUserRepo.EXPECT().
+					GetActiveByTeamName(ctx, "team-1").
+					Return([]domain.User{author}, nil).Once()
+
 				mockPRRepo.EXPECT().
 					Create(ctx, domain.PullRequest{
-						ID:       "pr-1",
-						AuthorID: "author-1",
+						ID:                "pr-1",
+						AuthorID:          "author-1",
+						NeedMoreReviewers: true,
 					}).
 					Return(domain.PullRequest{}, errors.New("insert error")).Once()
 			},
@@ -224,7 +349,10 @@ func (s *PullRequestServiceTestSuite) TestCreate() {
 			mockPRRepo := newMockiPullRequestRepository(s.T())
 			mockReviewRepo := newMockiReviewRepository(s.T())
 			mockUserRepo := newMockiPRUserRepository(s.T())
-			service := NewPullRequestService(mockPRRepo, mockReviewRepo, mockUserRepo)
+			service := NewPullRequestService(
+				mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+				newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+			)
 
 			tt.arrangeFunc(s.ctx, mockPRRepo, mockReviewRepo, mockUserRepo)
 
@@ -270,9 +398,11 @@ func (s *PullRequestServiceTestSuite) TestMerge() {
 					{ID: "user-1", Username: "alice"},
 					{ID: "user-2", Username: "bob"},
 				}
-				mockPRRepo.EXPECT().GetByID(ctx, "pr-1").Return(domain.PullRequest{}, nil).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(domain.PullRequest{Status: domain.PRStatusOpen}, nil).Once()
 				mockPRRepo.EXPECT().Merge(ctx, "pr-1").Return(mergedPR, nil).Once()
 				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return(reviewers, nil).Once()
+				mockReviewRepo.EXPECT().GetPoolsByPRID(ctx, "pr-1").Return(nil, nil).Once()
+				mockReviewRepo.EXPECT().GetReviewsByPRID(ctx, "pr-1").Return(nil, nil).Once()
 			},
 			wantErr: false,
 			checkResult: func(result domain.PullRequest) {
@@ -284,7 +414,7 @@ func (s *PullRequestServiceTestSuite) TestMerge() {
 			name: "PR not found",
 			prID: "non-existent-pr",
 			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository) {
-				mockPRRepo.EXPECT().GetByID(ctx, "non-existent-pr").Return(domain.PullRequest{}, domain.ErrPRNotFound).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "non-existent-pr").Return(domain.PullRequest{}, domain.ErrPRNotFound).Once()
 			},
 			wantErr:   true,
 			wantErrIs: domain.ErrPRNotFound,
@@ -293,7 +423,7 @@ func (s *PullRequestServiceTestSuite) TestMerge() {
 			name: "exists check error",
 			prID: "pr-1",
 			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository) {
-				mockPRRepo.EXPECT().GetByID(ctx, "pr-1").Return(domain.PullRequest{}, domain.ErrPRNotFound).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(domain.PullRequest{}, domain.ErrPRNotFound).Once()
 			},
 			wantErr:   true,
 			wantErrIs: domain.ErrPRNotFound,
@@ -302,7 +432,8 @@ func (s *PullRequestServiceTestSuite) TestMerge() {
 			name: "merge error",
 			prID: "pr-1",
 			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository) {
-				mockPRRepo.EXPECT().GetByID(ctx, "pr-1").Return(domain.PullRequest{}, nil).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(domain.PullRequest{Status: domain.PRStatusOpen}, nil).Once()
+				mockReviewRepo.EXPECT().GetReviewsByPRID(ctx, "pr-1").Return(nil, nil).Once()
 				mockPRRepo.EXPECT().Merge(ctx, "pr-1").Return(domain.PullRequest{}, errors.New("merge failed")).Once()
 			},
 			wantErr: true,
@@ -312,7 +443,8 @@ func (s *PullRequestServiceTestSuite) TestMerge() {
 			prID: "pr-1",
 			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository) {
 				mergedPR := domain.PullRequest{ID: "pr-1", Status: domain.PRStatusMerged}
-				mockPRRepo.EXPECT().GetByID(ctx, "pr-1").Return(domain.PullRequest{}, nil).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(domain.PullRequest{Status: domain.PRStatusOpen}, nil).Once()
+				mockReviewRepo.EXPECT().GetReviewsByPRID(ctx, "pr-1").Return(nil, nil).Once()
 				mockPRRepo.EXPECT().Merge(ctx, "pr-1").Return(mergedPR, nil).Once()
 				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return([]domain.User{}, errors.New("failed to get reviewers")).Once()
 			},
@@ -322,7 +454,7 @@ func (s *PullRequestServiceTestSuite) TestMerge() {
 			name: "pr already merged",
 			prID: "pr-1",
 			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository) {
-				mockPRRepo.EXPECT().GetByID(ctx, "pr-1").Return(domain.PullRequest{
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(domain.PullRequest{
 					Status: domain.PRStatusMerged,
 				}, nil).Once()
 			},
@@ -337,12 +469,15 @@ func (s *PullRequestServiceTestSuite) TestMerge() {
 			mockPRRepo := newMockiPullRequestRepository(s.T())
 			mockReviewRepo := newMockiReviewRepository(s.T())
 			mockUserRepo := newMockiPRUserRepository(s.T())
-			service := NewPullRequestService(mockPRRepo, mockReviewRepo, mockUserRepo)
+			service := NewPullRequestService(
+				mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+				newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+			)
 
 			tt.arrangeFunc(s.ctx, mockPRRepo, mockReviewRepo)
 
 			// Act
-			result, err := service.Merge(s.ctx, tt.prID)
+			result, err := service.Merge(s.ctx, tt.prID, false)
 
 			// Assert
 			if tt.wantErr {
@@ -360,6 +495,116 @@ func (s *PullRequestServiceTestSuite) TestMerge() {
 	}
 }
 
+// TestMergePolicy проверяет, что Merge соблюдает политику мерджа, а force её обходит и попадает в журнал аудита
+func (s *PullRequestServiceTestSuite) TestMergePolicy() {
+	policy := domain.MergePolicy{MinApprovals: 1, BlockOnChangesRequested: true, RequireOutsideApproval: true}
+	openPR := domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.PRStatusOpen}
+
+	tests := []struct {
+		name        string
+		reviews     []domain.Review
+		force       bool
+		arrangeFunc func(ctx context.Context, mockUserRepo *mockiPRUserRepository)
+		wantErrIs   error
+		// wantAction - действие, с которым мердж записывается в журнал аудита
+		wantAction domain.AuditAction
+	}{
+		{
+			name: "approved from outside the team",
+			reviews: []domain.Review{
+				{ReviewerID: "user-1", Verdict: domain.ReviewVerdictApproved},
+				{ReviewerID: "user-2", Verdict: domain.ReviewVerdictCommented},
+			},
+			arrangeFunc: func(ctx context.Context, mockUserRepo *mockiPRUserRepository) {
+				mockUserRepo.EXPECT().GetByID(ctx, "author-1").Return(domain.User{ID: "author-1", TeamName: "backend"}, nil).Once()
+				mockUserRepo.EXPECT().GetByID(ctx, "user-1").Return(domain.User{ID: "user-1", TeamName: "frontend"}, nil).Once()
+			},
+			wantAction: domain.AuditActionPRMerge,
+		},
+		{
+			name:    "no approvals",
+			reviews: []domain.Review{{ReviewerID: "user-2", Verdict: domain.ReviewVerdictCommented}},
+			arrangeFunc: func(ctx context.Context, mockUserRepo *mockiPRUserRepository) {
+				mockUserRepo.EXPECT().GetByID(ctx, "author-1").Return(domain.User{ID: "author-1", TeamName: "backend"}, nil).Once()
+			},
+			wantErrIs: domain.ErrMergePolicyNotMet,
+		},
+		{
+			name: "changes requested",
+			reviews: []domain.Review{
+				{ReviewerID: "user-1", Verdict: domain.ReviewVerdictApproved},
+				{ReviewerID: "user-2", Verdict: domain.ReviewVerdictChangesRequested},
+			},
+			arrangeFunc: func(ctx context.Context, mockUserRepo *mockiPRUserRepository) {
+				mockUserRepo.EXPECT().GetByID(ctx, "author-1").Return(domain.User{ID: "author-1", TeamName: "backend"}, nil).Once()
+				mockUserRepo.EXPECT().GetByID(ctx, "user-1").Return(domain.User{ID: "user-1", TeamName: "frontend"}, nil).Once()
+			},
+			wantErrIs: domain.ErrMergePolicyNotMet,
+		},
+		{
+			name:    "approved only by teammates",
+			reviews: []domain.Review{{ReviewerID: "user-1", Verdict: domain.ReviewVerdictApproved}},
+			arrangeFunc: func(ctx context.Context, mockUserRepo *mockiPRUserRepository) {
+				mockUserRepo.EXPECT().GetByID(ctx, "author-1").Return(domain.User{ID: "author-1", TeamName: "backend"}, nil).Once()
+				mockUserRepo.EXPECT().GetByID(ctx, "user-1").Return(domain.User{ID: "user-1", TeamName: "backend"}, nil).Once()
+			},
+			wantErrIs: domain.ErrMergePolicyNotMet,
+		},
+		{
+			name:    "force bypasses the policy",
+			reviews: nil,
+			force:   true,
+			arrangeFunc: func(ctx context.Context, mockUserRepo *mockiPRUserRepository) {
+				mockUserRepo.EXPECT().GetByID(ctx, "author-1").Return(domain.User{ID: "author-1", TeamName: "backend"}, nil).Once()
+			},
+			wantAction: domain.AuditActionPRForceMerge,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Arrange
+			mockPRRepo := newMockiPullRequestRepository(s.T())
+			mockReviewRepo := newMockiReviewRepository(s.T())
+			mockUserRepo := newMockiPRUserRepository(s.T())
+			mockRecorder := newMockiAuditRecorder(s.T())
+			service := NewPullRequestService(
+				mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), policy,
+				mockRecorder, newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+			)
+
+			mockPRRepo.EXPECT().GetByIDForUpdate(s.ctx, "pr-1").Return(openPR, nil).Once()
+			mockReviewRepo.EXPECT().GetReviewsByPRID(s.ctx, "pr-1").Return(tt.reviews, nil).Once()
+			tt.arrangeFunc(s.ctx, mockUserRepo)
+			if tt.wantErrIs == nil {
+				mockPRRepo.EXPECT().Merge(s.ctx, "pr-1").Return(domain.PullRequest{ID: "pr-1", Status: domain.PRStatusMerged}, nil).Once()
+				mockReviewRepo.EXPECT().GetByPRID(s.ctx, "pr-1").Return(nil, nil).Once()
+				mockReviewRepo.EXPECT().GetPoolsByPRID(s.ctx, "pr-1").Return(nil, nil).Once()
+				mockRecorder.EXPECT().
+					Add(s.ctx, mock.MatchedBy(func(event domain.AuditEvent) bool {
+						return event.Action == tt.wantAction && event.EntityID == "pr-1" && len(event.Before) > 0
+					})).
+					Return(domain.AuditEvent{}, nil).Once()
+			}
+
+			// Act
+			result, err := service.Merge(s.ctx, "pr-1", tt.force)
+
+			// Assert
+			if tt.wantErrIs != nil {
+				s.ErrorIs(err, tt.wantErrIs)
+				var policyErr *domain.MergePolicyError
+				s.Require().ErrorAs(err, &policyErr)
+				s.NotEmpty(policyErr.Violations)
+			} else {
+				s.NoError(err)
+				s.Equal(domain.PRStatusMerged, result.Status)
+				s.Equal(tt.reviews, result.Reviews)
+			}
+		})
+	}
+}
+
 // TestGetReviewingPRs проверяет метод GetReviewingPRs
 func (s *PullRequestServiceTestSuite) TestGetReviewingPRs() {
 	tests := []struct {
@@ -423,7 +668,10 @@ func (s *PullRequestServiceTestSuite) TestGetReviewingPRs() {
 			mockPRRepo := newMockiPullRequestRepository(s.T())
 			mockReviewRepo := newMockiReviewRepository(s.T())
 			mockUserRepo := newMockiPRUserRepository(s.T())
-			service := NewPullRequestService(mockPRRepo, mockReviewRepo, mockUserRepo)
+			service := NewPullRequestService(
+				mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+				newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+			)
 
 			tt.arrangeFunc(s.ctx, mockUserRepo, mockReviewRepo)
 
@@ -484,12 +732,14 @@ func (s *PullRequestServiceTestSuite) TestReassignReviewer() {
 					AuthorID:  "user-3",
 				}
 
-				mockPRRepo.EXPECT().GetByID(ctx, "pr-1").Return(pr, nil).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(pr, nil).Once()
 				mockUserRepo.EXPECT().ExistsByID(ctx, "user-1").Return(true, nil).Once()
-				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return(assignedReviewers, nil).Once()
+				mockUserRepo.EXPECT().GetByID(ctx, "user-3").Return(domain.User{ID: "user-3", TeamName: "backend-team"}, nil).Once()
 				mockUserRepo.EXPECT().GetActiveByTeamName(ctx, "backend-team").Return(activeUsers, nil).Once()
-				mockReviewRepo.EXPECT().Reassign(ctx, "pr-1", "user-4", "user-1").Return(nil).Once()
+				mockReviewRepo.EXPECT().Reassign(ctx, "pr-1", domain.TeamPool("backend-team"), "user-4", "user-1").Return(nil).Once()
 				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return(updatedReviewers, nil).Once()
+				mockReviewRepo.EXPECT().GetPoolsByPRID(ctx, "pr-1").Return(nil, nil).Once()
+				mockReviewRepo.EXPECT().GetReviewsByPRID(ctx, "pr-1").Return(nil, nil).Once()
 			},
 			wantErr: false,
 			checkResult: func(result *domain.PullRequest, newID string) {
@@ -498,12 +748,49 @@ func (s *PullRequestServiceTestSuite) TestReassignReviewer() {
 				s.Equal("user-4", newID)
 			},
 		},
+		{
+			name:          "reviewer moved teams after assignment",
+			prID:          "pr-1",
+			oldReviewerID: "user-1",
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				// пул назначения не сохранён, а user-1 уже в другой команде: замену ищем в команде автора, а не в mobile-team
+				assignedReviewers := []domain.User{
+					{ID: "user-1", Username: "alice", TeamName: "mobile-team"},
+					{ID: "user-2", Username: "bob", TeamName: "backend-team"},
+				}
+				activeUsers := []domain.User{
+					{ID: "user-2", Username: "bob", TeamName: "backend-team", IsActive: true},
+					{ID: "user-3", Username: "charlie", TeamName: "backend-team", IsActive: true},
+					{ID: "user-4", Username: "tony", TeamName: "backend-team", IsActive: true},
+				}
+				pr := domain.PullRequest{
+					ID:        "pr-1",
+					Status:    domain.PRStatusOpen,
+					Reviewers: assignedReviewers,
+					AuthorID:  "user-3",
+				}
+
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(pr, nil).Once()
+				mockUserRepo.EXPECT().ExistsByID(ctx, "user-1").Return(true, nil).Once()
+				mockUserRepo.EXPECT().GetByID(ctx, "user-3").Return(domain.User{ID: "user-3", TeamName: "backend-team"}, nil).Once()
+				mockUserRepo.EXPECT().GetActiveByTeamName(ctx, "backend-team").Return(activeUsers, nil).Once()
+				mockReviewRepo.EXPECT().Reassign(ctx, "pr-1", domain.TeamPool("backend-team"), "user-4", "user-1").Return(nil).Once()
+				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return([]domain.User{{ID: "user-2"}, {ID: "user-4"}}, nil).Once()
+				mockReviewRepo.EXPECT().GetPoolsByPRID(ctx, "pr-1").
+					Return(map[string]domain.ReviewerPool{"user-4": domain.TeamPool("backend-team")}, nil).Once()
+				mockReviewRepo.EXPECT().GetReviewsByPRID(ctx, "pr-1").Return(nil, nil).Once()
+			},
+			checkResult: func(result *domain.PullRequest, newID string) {
+				s.Equal("user-4", newID)
+				s.Equal(domain.TeamPool("backend-team"), result.ReviewerPools["user-4"])
+			},
+		},
 		{
 			name:          "PR not found",
 			prID:          "non-existent-pr",
 			oldReviewerID: "user-1",
 			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
-				mockPRRepo.EXPECT().GetByID(ctx, "non-existent-pr").Return(domain.PullRequest{}, domain.ErrPRNotFound).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "non-existent-pr").Return(domain.PullRequest{}, domain.ErrPRNotFound).Once()
 			},
 			wantErr:   true,
 			wantErrIs: domain.ErrPRNotFound,
@@ -513,7 +800,7 @@ func (s *PullRequestServiceTestSuite) TestReassignReviewer() {
 			prID:          "pr-1",
 			oldReviewerID: "non-existent-user",
 			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
-				mockPRRepo.EXPECT().GetByID(ctx, "pr-1").Return(domain.PullRequest{}, nil).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(domain.PullRequest{Status: domain.PRStatusOpen}, nil).Once()
 				mockUserRepo.EXPECT().ExistsByID(ctx, "non-existent-user").Return(false, nil).Once()
 			},
 			wantErr:   true,
@@ -528,9 +815,8 @@ func (s *PullRequestServiceTestSuite) TestReassignReviewer() {
 					{ID: "user-1", Username: "alice", TeamName: "backend-team"},
 					{ID: "user-2", Username: "bob", TeamName: "backend-team"},
 				}
-				mockPRRepo.EXPECT().GetByID(ctx, "pr-1").Return(domain.PullRequest{}, nil).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(domain.PullRequest{Status: domain.PRStatusOpen, Reviewers: assignedReviewers}, nil).Once()
 				mockUserRepo.EXPECT().ExistsByID(ctx, "user-3").Return(true, nil).Once()
-				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return(assignedReviewers, nil).Once()
 			},
 			wantErr:   true,
 			wantErrIs: domain.ErrReviewerNotAssigned,
@@ -548,10 +834,10 @@ func (s *PullRequestServiceTestSuite) TestReassignReviewer() {
 					{ID: "user-1", Username: "alice", TeamName: "small-team", IsActive: true},
 					{ID: "user-2", Username: "bob", TeamName: "small-team", IsActive: true},
 				}
-				mockPRRepo.EXPECT().GetByID(ctx, "pr-1").Return(domain.PullRequest{AuthorID: "user-3"}, nil).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(domain.PullRequest{Status: domain.PRStatusOpen, AuthorID: "user-3", Reviewers: assignedReviewers}, nil).Once()
 				mockUserRepo.EXPECT().ExistsByID(ctx, "user-1").Return(true, nil).Once()
-				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return(assignedReviewers, nil).Once()
 				mockUserRepo.EXPECT().GetActiveByTeamName(ctx, "small-team").Return(activeUsers, nil).Once()
+				mockUserRepo.EXPECT().GetByID(ctx, "user-3").Return(domain.User{ID: "user-3", TeamName: "small-team"}, nil).Once()
 			},
 			wantErr:   true,
 			wantErrIs: domain.ErrNoAvailableReviewers,
@@ -570,11 +856,11 @@ func (s *PullRequestServiceTestSuite) TestReassignReviewer() {
 					{ID: "user-2", Username: "bob", TeamName: "backend-team", IsActive: true},
 					{ID: "user-3", Username: "charlie", TeamName: "backend-team", IsActive: true},
 				}
-				mockPRRepo.EXPECT().GetByID(ctx, "pr-1").Return(domain.PullRequest{AuthorID: "user-4"}, nil).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(domain.PullRequest{Status: domain.PRStatusOpen, AuthorID: "user-4", Reviewers: assignedReviewers}, nil).Once()
 				mockUserRepo.EXPECT().ExistsByID(ctx, "user-1").Return(true, nil).Once()
-				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return(assignedReviewers, nil).Once()
+				mockUserRepo.EXPECT().GetByID(ctx, "user-4").Return(domain.User{ID: "user-4", TeamName: "backend-team"}, nil).Once()
 				mockUserRepo.EXPECT().GetActiveByTeamName(ctx, "backend-team").Return(activeUsers, nil).Once()
-				mockReviewRepo.EXPECT().Reassign(ctx, "pr-1", "user-3", "user-1").Return(errors.New("reassign failed")).Once()
+				mockReviewRepo.EXPECT().Reassign(ctx, "pr-1", domain.TeamPool("backend-team"), "user-3", "user-1").Return(errors.New("reassign failed")).Once()
 			},
 			wantErr: true,
 		},
@@ -592,11 +878,11 @@ func (s *PullRequestServiceTestSuite) TestReassignReviewer() {
 					{ID: "user-2", Username: "bob", TeamName: "backend-team", IsActive: true},
 					{ID: "user-3", Username: "charlie", TeamName: "backend-team", IsActive: true},
 				}
-				mockPRRepo.EXPECT().GetByID(ctx, "pr-1").Return(domain.PullRequest{AuthorID: "user-4"}, nil).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(domain.PullRequest{Status: domain.PRStatusOpen, AuthorID: "user-4", Reviewers: assignedReviewers}, nil).Once()
 				mockUserRepo.EXPECT().ExistsByID(ctx, "user-1").Return(true, nil).Once()
-				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return(assignedReviewers, nil).Once()
+				mockUserRepo.EXPECT().GetByID(ctx, "user-4").Return(domain.User{ID: "user-4", TeamName: "backend-team"}, nil).Once()
 				mockUserRepo.EXPECT().GetActiveByTeamName(ctx, "backend-team").Return(activeUsers, nil).Once()
-				mockReviewRepo.EXPECT().Reassign(ctx, "pr-1", "user-3", "user-1").Return(nil).Once()
+				mockReviewRepo.EXPECT().Reassign(ctx, "pr-1", domain.TeamPool("backend-team"), "user-3", "user-1").Return(nil).Once()
 				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return([]domain.User{}, errors.New("failed to get reviewers")).Once()
 			},
 			wantErr: true,
@@ -612,10 +898,10 @@ func (s *PullRequestServiceTestSuite) TestReassignReviewer() {
 				activeUsers := []domain.User{
 					{ID: "user-1", Username: "alice", TeamName: "solo-team", IsActive: true},
 				}
-				mockPRRepo.EXPECT().GetByID(ctx, "pr-1").Return(domain.PullRequest{AuthorID: "user-2"}, nil).Once()
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(domain.PullRequest{Status: domain.PRStatusOpen, AuthorID: "user-2", Reviewers: assignedReviewers}, nil).Once()
 				mockUserRepo.EXPECT().ExistsByID(ctx, "user-1").Return(true, nil).Once()
-				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return(assignedReviewers, nil).Once()
 				mockUserRepo.EXPECT().GetActiveByTeamName(ctx, "solo-team").Return(activeUsers, nil).Once()
+				mockUserRepo.EXPECT().GetByID(ctx, "user-2").Return(domain.User{ID: "user-2", TeamName: "solo-team"}, nil).Once()
 			},
 			wantErr:   true,
 			wantErrIs: domain.ErrNoAvailableReviewers,
@@ -628,7 +914,10 @@ func (s *PullRequestServiceTestSuite) TestReassignReviewer() {
 			mockPRRepo := newMockiPullRequestRepository(s.T())
 			mockReviewRepo := newMockiReviewRepository(s.T())
 			mockUserRepo := newMockiPRUserRepository(s.T())
-			service := NewPullRequestService(mockPRRepo, mockReviewRepo, mockUserRepo)
+			service := NewPullRequestService(
+				mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+				newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+			)
 
 			tt.arrangeFunc(s.ctx, mockPRRepo, mockReviewRepo, mockUserRepo)
 
@@ -653,6 +942,966 @@ func (s *PullRequestServiceTestSuite) TestReassignReviewer() {
 	}
 }
 
+// TestReview проверяет метод Review
+func (s *PullRequestServiceTestSuite) TestReview() {
+	openPR := domain.PullRequest{
+		ID:        "pr-1",
+		AuthorID:  "user-3",
+		Status:    domain.PRStatusOpen,
+		Reviewers: []domain.User{{ID: "user-1"}, {ID: "user-2"}},
+	}
+
+	tests := []struct {
+		name        string
+		reviewerID  string
+		arrangeFunc func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository)
+		wantErrIs   error
+		checkResult func(result domain.PullRequest)
+	}{
+		{
+			name:       "success",
+			reviewerID: "user-1",
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				review := domain.Review{PullRequestID: "pr-1", ReviewerID: "user-1", Verdict: domain.ReviewVerdictApproved}
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(openPR, nil).Once()
+				mockUserRepo.EXPECT().ExistsByID(ctx, "user-1").Return(true, nil).Once()
+				mockReviewRepo.EXPECT().AddReview(ctx, review).Return(review, nil).Once()
+				mockReviewRepo.EXPECT().GetReviewsByPRID(ctx, "pr-1").Return([]domain.Review{review}, nil).Once()
+			},
+			checkResult: func(result domain.PullRequest) {
+				s.Equal("pr-1", result.ID)
+				s.Require().Len(result.Reviews, 1)
+				s.Equal(domain.ReviewVerdictApproved, result.Reviews[0].Verdict)
+			},
+		},
+		{
+			name:       "PR not found",
+			reviewerID: "user-1",
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(domain.PullRequest{}, domain.ErrPRNotFound).Once()
+			},
+			wantErrIs: domain.ErrPRNotFound,
+		},
+		{
+			name:       "PR already merged",
+			reviewerID: "user-1",
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				merged := openPR
+				merged.Status = domain.PRStatusMerged
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(merged, nil).Once()
+			},
+			wantErrIs: domain.ErrPRAlreadyMerged,
+		},
+		{
+			name:       "reviewer not found",
+			reviewerID: "ghost",
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(openPR, nil).Once()
+				mockUserRepo.EXPECT().ExistsByID(ctx, "ghost").Return(false, nil).Once()
+			},
+			wantErrIs: domain.ErrUserNotFound,
+		},
+		{
+			name:       "reviewer not assigned",
+			reviewerID: "user-3",
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(openPR, nil).Once()
+				mockUserRepo.EXPECT().ExistsByID(ctx, "user-3").Return(true, nil).Once()
+			},
+			wantErrIs: domain.ErrReviewerNotAssigned,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Arrange
+			mockPRRepo := newMockiPullRequestRepository(s.T())
+			mockReviewRepo := newMockiReviewRepository(s.T())
+			mockUserRepo := newMockiPRUserRepository(s.T())
+			service := NewPullRequestService(
+				mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+				newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+			)
+
+			tt.arrangeFunc(s.ctx, mockPRRepo, mockReviewRepo, mockUserRepo)
+
+			// Act
+			result, err := service.Review(s.ctx, "pr-1", tt.reviewerID, domain.ReviewVerdictApproved)
+
+			// Assert
+			if tt.wantErrIs != nil {
+				s.ErrorIs(err, tt.wantErrIs)
+			} else {
+				s.NoError(err)
+				tt.checkResult(result)
+			}
+		})
+	}
+}
+
+// TestList проверяет постраничное получение PR
+func (s *PullRequestServiceTestSuite) TestList() {
+	now := time.Now().UTC()
+	prs := []domain.PullRequest{
+		{ID: "pr-3", CreatedAt: now},
+		{ID: "pr-2", CreatedAt: now.Add(-time.Minute)},
+		{ID: "pr-1", CreatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	tests := []struct {
+		name        string
+		filter      domain.PullRequestFilter
+		arrangeFunc func(ctx context.Context, mockPRRepo *mockiPullRequestRepository)
+		wantErrIs   error
+		checkResult func(page domain.PullRequestPage)
+	}{
+		{
+			name:   "next page exists",
+			filter: domain.PullRequestFilter{Limit: 2, Status: domain.PRStatusOpen},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository) {
+				mockPRRepo.EXPECT().
+					List(ctx, domain.PullRequestFilter{Limit: 3, Status: domain.PRStatusOpen}).
+					Return(prs, nil).Once()
+			},
+			checkResult: func(page domain.PullRequestPage) {
+				s.Len(page.PullRequests, 2)
+				s.Require().NotNil(page.Next)
+				s.Equal(domain.PullRequestCursor{CreatedAt: prs[1].CreatedAt, ID: "pr-2"}, *page.Next)
+			},
+		},
+		{
+			name:   "last page with default limit",
+			filter: domain.PullRequestFilter{},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository) {
+				mockPRRepo.EXPECT().
+					List(ctx, domain.PullRequestFilter{Limit: domain.DefaultPullRequestPageSize + 1}).
+					Return(prs, nil).Once()
+			},
+			checkResult: func(page domain.PullRequestPage) {
+				s.Len(page.PullRequests, 3)
+				s.Nil(page.Next)
+			},
+		},
+		{
+			name:        "limit too big",
+			filter:      domain.PullRequestFilter{Limit: domain.MaxPullRequestPageSize + 1},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository) {},
+			wantErrIs:   domain.ErrInvalidPRFilter,
+		},
+		{
+			name:        "unknown status",
+			filter:      domain.PullRequestFilter{Status: "REVIEWED"},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository) {},
+			wantErrIs:   domain.ErrInvalidPRFilter,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Arrange
+			mockPRRepo := newMockiPullRequestRepository(s.T())
+			service := NewPullRequestService(
+				mockPRRepo, newMockiReviewRepository(s.T()), newMockiPRUserRepository(s.T()), newNoFallbacks(s.T()),
+				NewRandomSelector(), domain.MergePolicy{}, newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+			)
+
+			tt.arrangeFunc(s.ctx, mockPRRepo)
+
+			// Act
+			page, err := service.List(s.ctx, tt.filter)
+
+			// Assert
+			if tt.wantErrIs != nil {
+				s.ErrorIs(err, tt.wantErrIs)
+			} else {
+				s.NoError(err)
+				tt.checkResult(page)
+			}
+		})
+	}
+}
+
+// TestLifecycle проверяет переходы PR между статусами: готовность черновика, закрытие и переоткрытие
+func (s *PullRequestServiceTestSuite) TestLifecycle() {
+	author := domain.User{ID: "author-1", TeamName: "backend-team", IsActive: true}
+	activeUsers := []domain.User{author, {ID: "user-2", TeamName: "backend-team", IsActive: true}}
+
+	tests := []struct {
+		name        string
+		act         func(service *PullRequestService) (domain.PullRequest, error)
+		arrangeFunc func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository)
+		wantErrIs   error
+		checkResult func(result domain.PullRequest)
+	}{
+		{
+			name: "ready draft gets reviewers",
+			act: func(service *PullRequestService) (domain.PullRequest, error) {
+				return service.MarkReady(s.ctx, "pr-1")
+			},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				draft := domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.PRStatusDraft}
+				opened := draft
+				opened.Status = domain.PRStatusOpen
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(draft, nil).Once()
+				mockPRRepo.EXPECT().SetStatus(ctx, "pr-1", domain.PRStatusOpen).Return(opened, nil).Once()
+				mockUserRepo.EXPECT().GetByID(ctx, "author-1").Return(author, nil).Once()
+				mockUserRepo.EXPECT().GetActiveByTeamName(ctx, "backend-team").Return(activeUsers, nil).Once()
+				mockReviewRepo.EXPECT().AssignToPR(ctx, "pr-1", domain.TeamPool("backend-team"), []string{"user-2"}).Return(nil).Once()
+				mockPRRepo.EXPECT().SetNeedMoreReviewers(ctx, "pr-1", true).Return(nil).Once()
+				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return([]domain.User{{ID: "user-2"}}, nil).Once()
+				mockReviewRepo.EXPECT().GetPoolsByPRID(ctx, "pr-1").Return(nil, nil).Once()
+				mockReviewRepo.EXPECT().GetReviewsByPRID(ctx, "pr-1").Return(nil, nil).Once()
+			},
+			checkResult: func(result domain.PullRequest) {
+				s.Equal(domain.PRStatusOpen, result.Status)
+				s.Len(result.Reviewers, 1)
+				s.True(result.NeedMoreReviewers)
+			},
+		},
+		{
+			name: "close open PR",
+			act: func(service *PullRequestService) (domain.PullRequest, error) {
+				return service.Close(s.ctx, "pr-1")
+			},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				open := domain.PullRequest{ID: "pr-1", Status: domain.PRStatusOpen}
+				closed := domain.PullRequest{ID: "pr-1", Status: domain.PRStatusClosed}
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(open, nil).Once()
+				mockPRRepo.EXPECT().SetStatus(ctx, "pr-1", domain.PRStatusClosed).Return(closed, nil).Once()
+				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return([]domain.User{{ID: "user-2"}}, nil).Once()
+				mockReviewRepo.EXPECT().GetPoolsByPRID(ctx, "pr-1").Return(nil, nil).Once()
+				mockReviewRepo.EXPECT().GetReviewsByPRID(ctx, "pr-1").Return(nil, nil).Once()
+			},
+			checkResult: func(result domain.PullRequest) {
+				s.Equal(domain.PRStatusClosed, result.Status)
+			},
+		},
+		{
+			name: "reopen keeps previous reviewers",
+			act: func(service *PullRequestService) (domain.PullRequest, error) {
+				return service.Reopen(s.ctx, "pr-1")
+			},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				reviewers := []domain.User{{ID: "user-2"}, {ID: "user-3"}}
+				closed := domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.PRStatusClosed, Reviewers: reviewers}
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").Return(closed, nil).Once()
+				mockPRRepo.EXPECT().SetStatus(ctx, "pr-1", domain.PRStatusOpen).
+					Return(domain.PullRequest{ID: "pr-1", Status: domain.PRStatusOpen}, nil).Once()
+				mockUserRepo.EXPECT().GetByID(ctx, "author-1").Return(author, nil).Once()
+				mockPRRepo.EXPECT().SetNeedMoreReviewers(ctx, "pr-1", false).Return(nil).Once()
+				mockReviewRepo.EXPECT().GetByPRID(ctx, "pr-1").Return(reviewers, nil).Once()
+				mockReviewRepo.EXPECT().GetPoolsByPRID(ctx, "pr-1").Return(nil, nil).Once()
+				mockReviewRepo.EXPECT().GetReviewsByPRID(ctx, "pr-1").Return(nil, nil).Once()
+			},
+			checkResult: func(result domain.PullRequest) {
+				s.Equal(domain.PRStatusOpen, result.Status)
+				s.Len(result.Reviewers, 2)
+				s.False(result.NeedMoreReviewers)
+			},
+		},
+		{
+			name: "cannot close merged PR",
+			act: func(service *PullRequestService) (domain.PullRequest, error) {
+				return service.Close(s.ctx, "pr-1")
+			},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").
+					Return(domain.PullRequest{ID: "pr-1", Status: domain.PRStatusMerged}, nil).Once()
+			},
+			wantErrIs: domain.ErrInvalidPRTransition,
+		},
+		{
+			name: "cannot reopen draft",
+			act: func(service *PullRequestService) (domain.PullRequest, error) {
+				return service.Reopen(s.ctx, "pr-1")
+			},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").
+					Return(domain.PullRequest{ID: "pr-1", Status: domain.PRStatusDraft}, nil).Once()
+			},
+			wantErrIs: domain.ErrInvalidPRTransition,
+		},
+		{
+			name: "cannot merge closed PR",
+			act: func(service *PullRequestService) (domain.PullRequest, error) {
+				return service.Merge(s.ctx, "pr-1", true)
+			},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").
+					Return(domain.PullRequest{ID: "pr-1", Status: domain.PRStatusClosed}, nil).Once()
+			},
+			wantErrIs: domain.ErrInvalidPRTransition,
+		},
+		{
+			name: "cannot review closed PR",
+			act: func(service *PullRequestService) (domain.PullRequest, error) {
+				return service.Review(s.ctx, "pr-1", "user-2", domain.ReviewVerdictApproved)
+			},
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				mockPRRepo.EXPECT().GetByIDForUpdate(ctx, "pr-1").
+					Return(domain.PullRequest{ID: "pr-1", Status: domain.PRStatusClosed}, nil).Once()
+			},
+			wantErrIs: domain.ErrPRNotOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Arrange
+			mockPRRepo := newMockiPullRequestRepository(s.T())
+			mockReviewRepo := newMockiReviewRepository(s.T())
+			mockUserRepo := newMockiPRUserRepository(s.T())
+			service := NewPullRequestService(
+				mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+				newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+			)
+
+			tt.arrangeFunc(s.ctx, mockPRRepo, mockReviewRepo, mockUserRepo)
+
+			// Act
+			result, err := tt.act(service)
+
+			// Assert
+			if tt.wantErrIs != nil {
+				s.ErrorIs(err, tt.wantErrIs)
+			} else {
+				s.NoError(err)
+				tt.checkResult(result)
+			}
+		})
+	}
+}
+
+// TestLifecycleRecorded проверяет, что смена статуса и вердикты попадают в журнал аудита, а смена статуса - в outbox
+func (s *PullRequestServiceTestSuite) TestLifecycleRecorded() {
+	s.Run("close", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		mockReviewRepo := newMockiReviewRepository(s.T())
+		mockRecorder := newMockiAuditRecorder(s.T())
+		mockOutbox := newMockiEventOutbox(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, mockReviewRepo, newMockiPRUserRepository(s.T()), newNoFallbacks(s.T()), NewRandomSelector(),
+			domain.MergePolicy{}, mockRecorder, mockOutbox, newPassthroughTransactor(s.T()), nil,
+		)
+
+		mockPRRepo.EXPECT().GetByIDForUpdate(s.ctx, "pr-1").
+			Return(domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.PRStatusOpen}, nil).Once()
+		mockPRRepo.EXPECT().SetStatus(s.ctx, "pr-1", domain.PRStatusClosed).
+			Return(domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.PRStatusClosed}, nil).Once()
+		mockReviewRepo.EXPECT().GetByPRID(s.ctx, "pr-1").Return(nil, nil).Once()
+		mockReviewRepo.EXPECT().GetPoolsByPRID(s.ctx, "pr-1").Return(nil, nil).Once()
+		mockReviewRepo.EXPECT().GetReviewsByPRID(s.ctx, "pr-1").Return(nil, nil).Once()
+		mockRecorder.EXPECT().Add(s.ctx, mock.MatchedBy(func(event domain.AuditEvent) bool {
+			return event.Action == domain.AuditActionPRSetStatus && event.EntityID == "pr-1" &&
+				strings.Contains(string(event.Before), `"status":"OPEN"`) &&
+				strings.Contains(string(event.After), `"status":"CLOSED"`)
+		})).Return(domain.AuditEvent{}, nil).Once()
+		mockOutbox.EXPECT().AddEvent(s.ctx, mock.MatchedBy(func(event domain.OutboxEvent) bool {
+			return event.Type == domain.EventPullRequestStatusChanged && string(event.Payload) ==
+				`{"pull_request_id":"pr-1","author_id":"author-1","old_status":"OPEN","new_status":"CLOSED"}`
+		})).Return(domain.OutboxEvent{}, nil).Once()
+
+		_, err := service.Close(s.ctx, "pr-1")
+
+		s.Require().NoError(err)
+	})
+
+	s.Run("review", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		mockReviewRepo := newMockiReviewRepository(s.T())
+		mockUserRepo := newMockiPRUserRepository(s.T())
+		mockRecorder := newMockiAuditRecorder(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+			mockRecorder, newMockiEventOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+		)
+		review := domain.Review{PullRequestID: "pr-1", ReviewerID: "user-1", Verdict: domain.ReviewVerdictChangesRequested}
+
+		mockPRRepo.EXPECT().GetByIDForUpdate(s.ctx, "pr-1").Return(domain.PullRequest{
+			ID: "pr-1", Status: domain.PRStatusOpen, Reviewers: []domain.User{{ID: "user-1"}},
+		}, nil).Once()
+		mockUserRepo.EXPECT().ExistsByID(s.ctx, "user-1").Return(true, nil).Once()
+		mockReviewRepo.EXPECT().AddReview(s.ctx, review).Return(review, nil).Once()
+		mockRecorder.EXPECT().Add(s.ctx, mock.MatchedBy(func(event domain.AuditEvent) bool {
+			return event.Action == domain.AuditActionPRReview && event.EntityID == "pr-1" && event.Before == nil &&
+				string(event.After) == `{"pull_request_id":"pr-1","reviewer_id":"user-1","verdict":"CHANGES_REQUESTED"}`
+		})).Return(domain.AuditEvent{}, nil).Once()
+		mockReviewRepo.EXPECT().GetReviewsByPRID(s.ctx, "pr-1").Return([]domain.Review{review}, nil).Once()
+
+		_, err := service.Review(s.ctx, "pr-1", "user-1", domain.ReviewVerdictChangesRequested)
+
+		s.Require().NoError(err)
+	})
+}
+
+// TestTopUpReviewers проверяет добор ревьюверов на PR, где их не хватает
+func (s *PullRequestServiceTestSuite) TestTopUpReviewers() {
+	activeUsers := []domain.User{
+		{ID: "author-1", TeamName: "backend-team", IsActive: true},
+		{ID: "user-2", TeamName: "backend-team", IsActive: true},
+		{ID: "user-3", TeamName: "backend-team", IsActive: true},
+	}
+
+	tests := []struct {
+		name        string
+		arrangeFunc func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository)
+		wantErr     bool
+		checkResult func(result []domain.PullRequest)
+	}{
+		{
+			name: "success - PRs topped up",
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				mockPRRepo.EXPECT().
+					GetNeedingReviewers(ctx, "backend-team").
+					Return([]domain.PullRequest{
+						{ID: "pr-1", AuthorID: "author-1", NeedMoreReviewers: true},
+						{ID: "pr-2", AuthorID: "author-1", NeedMoreReviewers: true, Reviewers: []domain.User{{ID: "user-2"}}},
+					}, nil).Once()
+
+				// кандидаты читаются один раз на все PR
+				mockUserRepo.EXPECT().
+					GetActiveByTeamName(ctx, "backend-team").
+					Return(slices.Clone(activeUsers), nil).Once()
+
+				mockReviewRepo.EXPECT().
+					AssignToPR(ctx, "pr-1", domain.TeamPool("backend-team"), mock.MatchedBy(func(ids []string) bool {
+						return len(ids) == 2 && !slices.Contains(ids, "author-1")
+					})).
+					Return(nil).Once()
+				mockPRRepo.EXPECT().SetNeedMoreReviewers(ctx, "pr-1", false).Return(nil).Once()
+
+				mockReviewRepo.EXPECT().
+					AssignToPR(ctx, "pr-2", domain.TeamPool("backend-team"), []string{"user-3"}).
+					Return(nil).Once()
+				mockPRRepo.EXPECT().SetNeedMoreReviewers(ctx, "pr-2", false).Return(nil).Once()
+			},
+			checkResult: func(result []domain.PullRequest) {
+				s.Require().Len(result, 2)
+				for _, pr := range result {
+					s.Len(pr.Reviewers, 2)
+					s.False(pr.NeedMoreReviewers)
+				}
+			},
+		},
+		{
+			name: "reviewer reaches the limit on the first PR",
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				mockPRRepo.EXPECT().
+					GetNeedingReviewers(ctx, "backend-team").
+					Return([]domain.PullRequest{
+						{ID: "pr-1", AuthorID: "author-1", NeedMoreReviewers: true, Reviewers: []domain.User{{ID: "user-2"}}},
+						{ID: "pr-2", AuthorID: "author-1", NeedMoreReviewers: true, Reviewers: []domain.User{{ID: "user-2"}}},
+					}, nil).Once()
+
+				// у user-3 лимит в одно открытое ревью, после pr-1 он его набирает
+				limited := slices.Clone(activeUsers)
+				limited[2].MaxOpenReviews = 1
+				mockUserRepo.EXPECT().
+					GetActiveByTeamName(ctx, "backend-team").
+					Return(limited, nil).Once()
+				mockReviewRepo.EXPECT().
+					CountOpenReviews(ctx, []string{"user-3"}).
+					Return(map[string]int{"user-3": 0}, nil).Once()
+
+				mockReviewRepo.EXPECT().
+					AssignToPR(ctx, "pr-1", domain.TeamPool("backend-team"), []string{"user-3"}).
+					Return(nil).Once()
+				mockPRRepo.EXPECT().SetNeedMoreReviewers(ctx, "pr-1", false).Return(nil).Once()
+			},
+			checkResult: func(result []domain.PullRequest) {
+				s.Require().Len(result, 1)
+				s.Equal("pr-1", result[0].ID)
+			},
+		},
+		{
+			name: "still not enough reviewers",
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				mockPRRepo.EXPECT().
+					GetNeedingReviewers(ctx, "backend-team").
+					Return([]domain.PullRequest{
+						{ID: "pr-1", AuthorID: "author-1", NeedMoreReviewers: true, Reviewers: []domain.User{{ID: "user-2"}}},
+					}, nil).Once()
+
+				mockUserRepo.EXPECT().
+					GetActiveByTeamName(ctx, "backend-team").
+					Return(slices.Clone(activeUsers[:2]), nil).Once()
+			},
+			checkResult: func(result []domain.PullRequest) {
+				s.Empty(result)
+			},
+		},
+		{
+			name: "no flagged PRs",
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				mockPRRepo.EXPECT().
+					GetNeedingReviewers(ctx, "backend-team").
+					Return(nil, nil).Once()
+			},
+			checkResult: func(result []domain.PullRequest) {
+				s.Empty(result)
+			},
+		},
+		{
+			name: "assign error",
+			arrangeFunc: func(ctx context.Context, mockPRRepo *mockiPullRequestRepository, mockReviewRepo *mockiReviewRepository, mockUserRepo *mockiPRUserRepository) {
+				mockPRRepo.EXPECT().
+					GetNeedingReviewers(ctx, "backend-team").
+					Return([]domain.PullRequest{{ID: "pr-1", AuthorID: "author-1", NeedMoreReviewers: true}}, nil).Once()
+
+				mockUserRepo.EXPECT().
+					GetActiveByTeamName(ctx, "backend-team").
+					Return(slices.Clone(activeUsers), nil).Once()
+
+				mockReviewRepo.EXPECT().
+					AssignToPR(ctx, "pr-1", domain.TeamPool("backend-team"), mock.Anything).
+					Return(errors.New("insert error")).Once()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Arrange
+			mockPRRepo := newMockiPullRequestRepository(s.T())
+			mockReviewRepo := newMockiReviewRepository(s.T())
+			mockUserRepo := newMockiPRUserRepository(s.T())
+			service := NewPullRequestService(
+				mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+				newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+			)
+
+			tt.arrangeFunc(s.ctx, mockPRRepo, mockReviewRepo, mockUserRepo)
+
+			// Act
+			result, err := service.TopUpReviewers(s.ctx, "backend-team")
+
+			// Assert
+			if tt.wantErr {
+				s.Error(err)
+				s.Nil(result)
+			} else {
+				s.NoError(err)
+				tt.checkResult(result)
+			}
+		})
+	}
+}
+
+// TestFallbackPools проверяет выбор ревьюверов из запасных пулов, когда своей команды не хватает
+func (s *PullRequestServiceTestSuite) TestFallbackPools() {
+	platform := domain.TeamPool("platform")
+	guild := domain.SharedPool("guild")
+
+	s.Run("create picks from fallbacks in order", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		mockReviewRepo := newMockiReviewRepository(s.T())
+		mockUserRepo := newMockiPRUserRepository(s.T())
+		mockPools := newMockiReviewerPoolSource(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, mockReviewRepo, mockUserRepo, mockPools, NewRandomSelector(), domain.MergePolicy{},
+			newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+		)
+		pr := domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.PRStatusOpen}
+
+		mockPRRepo.EXPECT().Exists(s.ctx, "pr-1").Return(false, nil).Once()
+		mockUserRepo.EXPECT().GetByID(s.ctx, "author-1").
+			Return(domain.User{ID: "author-1", TeamName: "solo", IsActive: true}, nil).Once()
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "solo").
+			Return([]domain.User{{ID: "author-1", TeamName: "solo", IsActive: true}}, nil).Once()
+		mockPools.EXPECT().GetTeamFallbacks(s.ctx, "solo").Return([]domain.ReviewerPool{platform, guild}, nil).Once()
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "platform").
+			Return([]domain.User{{ID: "user-p", TeamName: "platform", IsActive: true}}, nil).Once()
+		// участник пула, уже выбранный из команды, второй раз не назначается
+		mockPools.EXPECT().GetActiveMembers(s.ctx, "guild").Return([]domain.User{
+			{ID: "user-p", TeamName: "platform", IsActive: true},
+			{ID: "user-g", TeamName: "mobile", IsActive: true},
+		}, nil).Once()
+		mockPRRepo.EXPECT().Create(s.ctx, pr).Return(pr, nil).Once()
+		mockReviewRepo.EXPECT().AssignToPR(s.ctx, "pr-1", platform, []string{"user-p"}).Return(nil).Once()
+		mockReviewRepo.EXPECT().AssignToPR(s.ctx, "pr-1", guild, []string{"user-g"}).Return(nil).Once()
+		mockReviewRepo.EXPECT().GetByPRID(s.ctx, "pr-1").
+			Return([]domain.User{{ID: "user-g"}, {ID: "user-p"}}, nil).Once()
+		mockReviewRepo.EXPECT().GetPoolsByPRID(s.ctx, "pr-1").
+			Return(map[string]domain.ReviewerPool{"user-g": guild, "user-p": platform}, nil).Once()
+
+		result, err := service.Create(s.ctx, pr)
+
+		s.Require().NoError(err)
+		s.False(result.NeedMoreReviewers)
+		s.Equal(guild, result.ReviewerPools["user-g"])
+	})
+
+	s.Run("reassign falls back from an exhausted pool", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		mockReviewRepo := newMockiReviewRepository(s.T())
+		mockUserRepo := newMockiPRUserRepository(s.T())
+		mockPools := newMockiReviewerPoolSource(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, mockReviewRepo, mockUserRepo, mockPools, NewRandomSelector(), domain.MergePolicy{},
+			newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+		)
+		pr := domain.PullRequest{
+			ID:            "pr-1",
+			AuthorID:      "author-1",
+			Status:        domain.PRStatusOpen,
+			Reviewers:     []domain.User{{ID: "user-g", TeamName: "mobile"}},
+			ReviewerPools: map[string]domain.ReviewerPool{"user-g": guild},
+		}
+
+		mockPRRepo.EXPECT().GetByIDForUpdate(s.ctx, "pr-1").Return(pr, nil).Once()
+		mockUserRepo.EXPECT().ExistsByID(s.ctx, "user-g").Return(true, nil).Once()
+		mockPools.EXPECT().GetActiveMembers(s.ctx, "guild").
+			Return([]domain.User{{ID: "user-g", TeamName: "mobile", IsActive: true}}, nil).Once()
+		mockUserRepo.EXPECT().GetByID(s.ctx, "author-1").
+			Return(domain.User{ID: "author-1", TeamName: "solo", IsActive: true}, nil).Once()
+		// пул старого ревьювера уже проверен и второй раз не читается
+		mockPools.EXPECT().GetTeamFallbacks(s.ctx, "solo").Return([]domain.ReviewerPool{guild, platform}, nil).Once()
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "solo").
+			Return([]domain.User{{ID: "author-1", TeamName: "solo", IsActive: true}}, nil).Once()
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "platform").
+			Return([]domain.User{{ID: "user-p", TeamName: "platform", IsActive: true}}, nil).Once()
+		mockReviewRepo.EXPECT().Reassign(s.ctx, "pr-1", platform, "user-p", "user-g").Return(nil).Once()
+		mockReviewRepo.EXPECT().GetByPRID(s.ctx, "pr-1").Return([]domain.User{{ID: "user-p"}}, nil).Once()
+		mockReviewRepo.EXPECT().GetPoolsByPRID(s.ctx, "pr-1").
+			Return(map[string]domain.ReviewerPool{"user-p": platform}, nil).Once()
+		mockReviewRepo.EXPECT().GetReviewsByPRID(s.ctx, "pr-1").Return(nil, nil).Once()
+
+		result, newID, err := service.ReassignReviewer(s.ctx, "pr-1", "user-g")
+
+		s.Require().NoError(err)
+		s.Equal("user-p", newID)
+		s.Equal(platform, result.ReviewerPools["user-p"])
+	})
+
+	s.Run("top-up reaches teams falling back to the team", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		mockReviewRepo := newMockiReviewRepository(s.T())
+		mockUserRepo := newMockiPRUserRepository(s.T())
+		mockPools := newMockiReviewerPoolSource(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, mockReviewRepo, mockUserRepo, mockPools, NewRandomSelector(), domain.MergePolicy{},
+			newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+		)
+
+		// в platform снова активен участник, а PR команды solo ждёт ревьюверов из её запасного пула platform
+		mockPools.EXPECT().GetTeamsFallingBackTo(s.ctx, "platform").Return([]string{"solo"}, nil).Once()
+		mockPRRepo.EXPECT().GetNeedingReviewers(s.ctx, "platform").Return(nil, nil).Once()
+		mockPRRepo.EXPECT().GetNeedingReviewers(s.ctx, "solo").Return([]domain.PullRequest{{
+			ID:                "pr-1",
+			AuthorID:          "author-1",
+			Status:            domain.PRStatusOpen,
+			NeedMoreReviewers: true,
+			Reviewers:         []domain.User{{ID: "user-p", TeamName: "platform"}},
+			ReviewerPools:     map[string]domain.ReviewerPool{"user-p": platform},
+		}}, nil).Once()
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "solo").
+			Return([]domain.User{{ID: "author-1", TeamName: "solo", IsActive: true}}, nil).Once()
+		mockPools.EXPECT().GetTeamFallbacks(s.ctx, "solo").Return([]domain.ReviewerPool{platform}, nil).Once()
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "platform").Return([]domain.User{
+			{ID: "user-p", TeamName: "platform", IsActive: true},
+			{ID: "user-r", TeamName: "platform", IsActive: true},
+		}, nil).Once()
+		mockReviewRepo.EXPECT().AssignToPR(s.ctx, "pr-1", platform, []string{"user-r"}).Return(nil).Once()
+		mockPRRepo.EXPECT().SetNeedMoreReviewers(s.ctx, "pr-1", false).Return(nil).Once()
+
+		result, err := service.TopUpReviewers(s.ctx, "platform")
+
+		s.Require().NoError(err)
+		s.Require().Len(result, 1)
+		s.False(result[0].NeedMoreReviewers)
+		s.Equal(platform, result[0].ReviewerPools["user-r"])
+	})
+}
+
+// TestHandOverReviews проверяет передачу открытых ревью другим ревьюверам
+func (s *PullRequestServiceTestSuite) TestHandOverReviews() {
+	backend := domain.TeamPool("backend-team")
+	openPRs := func() []domain.PullRequest {
+		return []domain.PullRequest{{
+			ID:            "pr-1",
+			AuthorID:      "author-1",
+			Status:        domain.PRStatusOpen,
+			Reviewers:     []domain.User{{ID: "user-2", TeamName: "backend-team"}, {ID: "user-3", TeamName: "backend-team"}},
+			ReviewerPools: map[string]domain.ReviewerPool{"user-2": backend, "user-3": backend},
+		}}
+	}
+
+	s.Run("reviewer replaced from its pool", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		mockReviewRepo := newMockiReviewRepository(s.T())
+		mockUserRepo := newMockiPRUserRepository(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+			newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+		)
+
+		mockPRRepo.EXPECT().GetOpenByReviewerIDs(s.ctx, []string{"user-2"}, "").Return(openPRs(), nil).Once()
+		// автор и оставшийся ревьювер замену не получают
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"author-1"}).
+			Return([]domain.User{{ID: "author-1", TeamName: "backend-team", IsActive: true}}, nil).Once()
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "backend-team").Return([]domain.User{
+			{ID: "author-1", TeamName: "backend-team", IsActive: true},
+			{ID: "user-3", TeamName: "backend-team", IsActive: true},
+			{ID: "user-4", TeamName: "backend-team", IsActive: true},
+		}, nil).Once()
+		mockReviewRepo.EXPECT().HandOver(s.ctx, []domain.ReviewerHandover{{
+			ReviewerReplacement: domain.ReviewerReplacement{
+				PullRequestID: "pr-1", OldReviewerID: "user-2", NewReviewerID: "user-4",
+			},
+			Pool: backend,
+		}}).Return(nil).Once()
+
+		result, err := service.HandOverReviews(s.ctx, []string{"user-2"}, "")
+
+		s.Require().NoError(err)
+		s.Equal([]domain.ReviewerReplacement{
+			{PullRequestID: "pr-1", OldReviewerID: "user-2", NewReviewerID: "user-4"},
+		}, result)
+	})
+
+	s.Run("reviewer removed when nobody is available", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		mockReviewRepo := newMockiReviewRepository(s.T())
+		mockUserRepo := newMockiPRUserRepository(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+			newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+		)
+
+		mockPRRepo.EXPECT().GetOpenByReviewerIDs(s.ctx, []string{"user-2"}, "backend-team").
+			Return(openPRs(), nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"author-1"}).
+			Return([]domain.User{{ID: "author-1", TeamName: "backend-team", IsActive: true}}, nil).Once()
+		// команда автора - тот же пул, что и у снятого ревьювера, она читается один раз
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "backend-team").Return([]domain.User{
+			{ID: "author-1", TeamName: "backend-team", IsActive: true},
+			{ID: "user-3", TeamName: "backend-team", IsActive: true},
+		}, nil).Once()
+		mockReviewRepo.EXPECT().HandOver(s.ctx, []domain.ReviewerHandover{{
+			ReviewerReplacement: domain.ReviewerReplacement{PullRequestID: "pr-1", OldReviewerID: "user-2"},
+		}}).Return(nil).Once()
+
+		result, err := service.HandOverReviews(s.ctx, []string{"user-2"}, "backend-team")
+
+		s.Require().NoError(err)
+		s.Equal([]domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-2"}}, result)
+	})
+
+	s.Run("pool read once for many pull requests", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		mockReviewRepo := newMockiReviewRepository(s.T())
+		mockUserRepo := newMockiPRUserRepository(s.T())
+		mockRecorder := newMockiAuditRecorder(s.T())
+		mockOutbox := newMockiEventOutbox(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+			mockRecorder, mockOutbox, newPassthroughTransactor(s.T()), nil,
+		)
+
+		second := openPRs()[0]
+		second.ID = "pr-2"
+		mockPRRepo.EXPECT().GetOpenByReviewerIDs(s.ctx, []string{"user-2"}, "").
+			Return(append(openPRs(), second), nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"author-1", "author-1"}).
+			Return([]domain.User{{ID: "author-1", TeamName: "backend-team", IsActive: true}}, nil).Once()
+		// у user-4 лимит в одно ревью: первый PR достаётся ему, со второго ревьювер просто снимается
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "backend-team").Return([]domain.User{
+			{ID: "user-4", TeamName: "backend-team", IsActive: true, MaxOpenReviews: 1},
+		}, nil).Once()
+		mockReviewRepo.EXPECT().CountOpenReviews(s.ctx, []string{"user-4"}).
+			Return(map[string]int{"user-4": 0}, nil).Once()
+		mockReviewRepo.EXPECT().HandOver(s.ctx, []domain.ReviewerHandover{
+			{
+				ReviewerReplacement: domain.ReviewerReplacement{
+					PullRequestID: "pr-1", OldReviewerID: "user-2", NewReviewerID: "user-4",
+				},
+				Pool: backend,
+			},
+			{ReviewerReplacement: domain.ReviewerReplacement{PullRequestID: "pr-2", OldReviewerID: "user-2"}},
+		}).Return(nil).Once()
+		mockRecorder.EXPECT().AddBatch(s.ctx, mock.MatchedBy(func(events []domain.AuditEvent) bool {
+			return len(events) == 2 && events[0].Action == domain.AuditActionPRReassign
+		})).Return(nil).Once()
+		mockOutbox.EXPECT().AddEvents(s.ctx, mock.MatchedBy(func(events []domain.OutboxEvent) bool {
+			return len(events) == 2 && events[0].Type == domain.EventReviewerReassigned
+		})).Return(nil).Once()
+
+		result, err := service.HandOverReviews(s.ctx, []string{"user-2"}, "")
+
+		s.Require().NoError(err)
+		s.Len(result, 2)
+	})
+
+	s.Run("repository error", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, newMockiReviewRepository(s.T()), newMockiPRUserRepository(s.T()), newNoFallbacks(s.T()),
+			NewRandomSelector(), domain.MergePolicy{},
+			newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), nil,
+		)
+
+		mockPRRepo.EXPECT().GetOpenByReviewerIDs(s.ctx, []string{"user-2"}, "").
+			Return(nil, errors.New("select error")).Once()
+
+		result, err := service.HandOverReviews(s.ctx, []string{"user-2"}, "")
+
+		s.Error(err)
+		s.Nil(result)
+	})
+}
+
+// TestMetrics проверяет, что сервис сам считает PR, замены ревьюверов и NO_CANDIDATE по всем путям
+func (s *PullRequestServiceTestSuite) TestMetrics() {
+	backend := domain.TeamPool("backend-team")
+
+	s.Run("create short of reviewers", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		mockReviewRepo := newMockiReviewRepository(s.T())
+		mockUserRepo := newMockiPRUserRepository(s.T())
+		mockMetrics := newMockiPullRequestMetrics(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+			newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), mockMetrics,
+		)
+		pr := domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.PRStatusOpen}
+		created := pr
+		created.NeedMoreReviewers = true
+
+		mockPRRepo.EXPECT().Exists(s.ctx, "pr-1").Return(false, nil).Once()
+		mockUserRepo.EXPECT().GetByID(s.ctx, "author-1").
+			Return(domain.User{ID: "author-1", TeamName: "solo", IsActive: true}, nil).Once()
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "solo").
+			Return([]domain.User{{ID: "author-1", TeamName: "solo", IsActive: true}}, nil).Once()
+		mockPRRepo.EXPECT().Create(s.ctx, created).Return(created, nil).Once()
+		mockReviewRepo.EXPECT().GetByPRID(s.ctx, "pr-1").Return(nil, nil).Once()
+		mockReviewRepo.EXPECT().GetPoolsByPRID(s.ctx, "pr-1").Return(nil, nil).Once()
+		mockMetrics.EXPECT().NoCandidate().Return().Once()
+		mockMetrics.EXPECT().PullRequestCreated().Return().Once()
+
+		_, err := service.Create(s.ctx, pr)
+
+		s.Require().NoError(err)
+	})
+
+	s.Run("handover replaces one reviewer and removes another", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		mockReviewRepo := newMockiReviewRepository(s.T())
+		mockUserRepo := newMockiPRUserRepository(s.T())
+		mockMetrics := newMockiPullRequestMetrics(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, mockReviewRepo, mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(), domain.MergePolicy{},
+			newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()), newPassthroughTransactor(s.T()), mockMetrics,
+		)
+
+		mockPRRepo.EXPECT().GetOpenByReviewerIDs(s.ctx, []string{"user-2", "user-3"}, "").
+			Return([]domain.PullRequest{{
+				ID:            "pr-1",
+				AuthorID:      "author-1",
+				Status:        domain.PRStatusOpen,
+				Reviewers:     []domain.User{{ID: "user-2", TeamName: "backend-team"}, {ID: "user-3", TeamName: "backend-team"}},
+				ReviewerPools: map[string]domain.ReviewerPool{"user-2": backend, "user-3": backend},
+			}}, nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"author-1"}).
+			Return([]domain.User{{ID: "author-1", TeamName: "backend-team", IsActive: true}}, nil).Once()
+		// замену получает только один из двух ревьюверов
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "backend-team").Return([]domain.User{
+			{ID: "author-1", TeamName: "backend-team", IsActive: true},
+			{ID: "user-4", TeamName: "backend-team", IsActive: true},
+		}, nil).Once()
+		mockReviewRepo.EXPECT().HandOver(s.ctx, mock.Anything).Return(nil).Once()
+		mockMetrics.EXPECT().ReviewerReassigned().Return().Once()
+		mockMetrics.EXPECT().NoCandidate().Return().Once()
+
+		_, err := service.HandOverReviews(s.ctx, []string{"user-2", "user-3"}, "")
+
+		s.Require().NoError(err)
+	})
+
+	s.Run("top-up leaves a pull request short", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		mockUserRepo := newMockiPRUserRepository(s.T())
+		mockMetrics := newMockiPullRequestMetrics(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, newMockiReviewRepository(s.T()), mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(),
+			domain.MergePolicy{}, newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()),
+			newPassthroughTransactor(s.T()), mockMetrics,
+		)
+
+		mockPRRepo.EXPECT().GetNeedingReviewers(s.ctx, "backend-team").Return([]domain.PullRequest{{
+			ID: "pr-1", AuthorID: "author-1", NeedMoreReviewers: true, Reviewers: []domain.User{{ID: "user-2"}},
+		}}, nil).Once()
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "backend-team").Return([]domain.User{
+			{ID: "author-1", TeamName: "backend-team", IsActive: true},
+			{ID: "user-2", TeamName: "backend-team", IsActive: true},
+		}, nil).Once()
+		mockMetrics.EXPECT().NoCandidate().Return().Once()
+
+		result, err := service.TopUpReviewers(s.ctx, "backend-team")
+
+		s.Require().NoError(err)
+		s.Empty(result)
+	})
+
+	s.Run("failed reassignment counted as NO_CANDIDATE", func() {
+		mockPRRepo := newMockiPullRequestRepository(s.T())
+		mockUserRepo := newMockiPRUserRepository(s.T())
+		mockMetrics := newMockiPullRequestMetrics(s.T())
+		service := NewPullRequestService(
+			mockPRRepo, newMockiReviewRepository(s.T()), mockUserRepo, newNoFallbacks(s.T()), NewRandomSelector(),
+			domain.MergePolicy{}, newAcceptingAuditRecorder(s.T()), newAcceptingOutbox(s.T()),
+			newPassthroughTransactor(s.T()), mockMetrics,
+		)
+		reviewers := []domain.User{{ID: "user-1", TeamName: "small-team"}, {ID: "user-2", TeamName: "small-team"}}
+
+		mockPRRepo.EXPECT().GetByIDForUpdate(s.ctx, "pr-1").Return(domain.PullRequest{
+			ID: "pr-1", Status: domain.PRStatusOpen, AuthorID: "user-3", Reviewers: reviewers,
+		}, nil).Once()
+		mockUserRepo.EXPECT().ExistsByID(s.ctx, "user-1").Return(true, nil).Once()
+		mockUserRepo.EXPECT().GetByID(s.ctx, "user-3").Return(domain.User{ID: "user-3", TeamName: "small-team"}, nil).Once()
+		mockUserRepo.EXPECT().GetActiveByTeamName(s.ctx, "small-team").Return([]domain.User{
+			{ID: "user-1", TeamName: "small-team", IsActive: true},
+			{ID: "user-2", TeamName: "small-team", IsActive: true},
+		}, nil).Once()
+		mockMetrics.EXPECT().NoCandidate().Return().Once()
+
+		_, _, err := service.ReassignReviewer(s.ctx, "pr-1", "user-1")
+
+		s.Require().ErrorIs(err, domain.ErrNoAvailableReviewers)
+	})
+}
+
+// TestTransactionError проверяет, что ошибка транзакции возвращается из методов сервиса
+func (s *PullRequestServiceTestSuite) TestTransactionError() {
+	txErr := errors.New("error starting transaction")
+	transactor := newMockiTransactor(s.T())
+	transactor.EXPECT().WithinTransaction(s.ctx, mock.Anything).Return(txErr).Times(3)
+
+	service := NewPullRequestService(
+		newMockiPullRequestRepository(s.T()),
+		newMockiReviewRepository(s.T()),
+		newMockiPRUserRepository(s.T()),
+		newMockiReviewerPoolSource(s.T()),
+		NewRandomSelector(),
+		domain.MergePolicy{},
+		newMockiAuditRecorder(s.T()),
+		newMockiEventOutbox(s.T()),
+		transactor,
+		nil,
+	)
+
+	_, err := service.Create(s.ctx, domain.PullRequest{ID: "pr-1", AuthorID: "user-1"})
+	s.ErrorIs(err, txErr)
+
+	_, err = service.Merge(s.ctx, "pr-1", false)
+	s.ErrorIs(err, txErr)
+
+	pr, newID, err := service.ReassignReviewer(s.ctx, "pr-1", "user-2")
+	s.ErrorIs(err, txErr)
+	s.Nil(pr)
+	s.Empty(newID)
+}
+
 // TestPullRequestServiceSuite запускает test suite
 func TestPullRequestServiceSuite(t *testing.T) {
 	suite.Run(t, new(PullRequestServiceTestSuite))
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// reviewerPicker picks reviewers for pull requests. What it reads is kept for its lifetime: the candidates
+// of every pool, their open reviews and the pools of the authors' teams, so picking reviewers for many pull
+// requests at once costs a few queries per pool. Reviewers it picks are counted as loaded with one more review,
+// so a reviewer reaching the limit is not picked again.
+// A picker is used within one transaction only
+type reviewerPicker struct {
+	service    *PullRequestService
+	candidates map[domain.ReviewerPool][]domain.User
+	// load holds open reviews of the candidates that have a limit or are picked by load
+	load        map[string]int
+	authorTeams map[string]string
+	fallbacks   map[string][]domain.ReviewerPool
+}
+
+func (p *PullRequestService) newReviewerPicker() *reviewerPicker {
+	return &reviewerPicker{
+		service:     p,
+		candidates:  make(map[domain.ReviewerPool][]domain.User),
+		load:        make(map[string]int),
+		authorTeams: make(map[string]string),
+		fallbacks:   make(map[string][]domain.ReviewerPool),
+	}
+}
+
+// pickReviewers selects the missing reviewers of a pull request authored by a member of the team.
+// Active members of the team go first, then members of its fallback pools in order. The author and
+// the assigned reviewers are skipped, nobody is picked twice. If there are fewer candidates than needed,
+// as many as possible are picked
+func (r *reviewerPicker) pickReviewers(
+	ctx context.Context,
+	pr domain.PullRequest,
+	teamName string,
+) ([]reviewerPick, error) {
+	missing := maxReviewersPerPR - len(pr.Reviewers)
+	if missing <= 0 {
+		return nil, nil
+	}
+	taken := takenReviewers(pr)
+
+	var picks []reviewerPick
+	pools := []domain.ReviewerPool{domain.TeamPool(teamName)}
+	for i := 0; i < len(pools) && missing > 0; i++ {
+		selected, err := r.pickFromPool(ctx, pools[i], taken, missing)
+		if err != nil {
+			return nil, err
+		}
+		if len(selected) > 0 {
+			picks = append(picks, reviewerPick{pool: pools[i], users: selected})
+			missing -= len(selected)
+		}
+		// запасные пулы читаем, только когда своей команды не хватило
+		if i == 0 && missing > 0 {
+			fallbacks, err := r.teamFallbacks(ctx, teamName)
+			if err != nil {
+				return nil, err
+			}
+			pools = append(pools, fallbacks...)
+		}
+	}
+	return picks, nil
+}
+
+// pickReplacement selects an active user to replace oldReviewerID on the pull request. The pool the old reviewer
+// was picked from goes first, then the author's team and its fallback pools in order
+func (r *reviewerPicker) pickReplacement(
+	ctx context.Context,
+	pr domain.PullRequest,
+	oldReviewerID string,
+) (domain.User, domain.ReviewerPool, error) {
+	taken := takenReviewers(pr)
+
+	var pools []domain.ReviewerPool
+	pool, known := pr.ReviewerPools[oldReviewerID]
+	if known {
+		pools = append(pools, pool)
+	} else {
+		// пул не известен, а текущая команда ревьювера могла смениться после назначения - берём пулы автора
+		authorPools, err := r.authorPools(ctx, pr.AuthorID)
+		if err != nil {
+			return domain.User{}, domain.ReviewerPool{}, err
+		}
+		pools = authorPools
+	}
+
+	for i := 0; i < len(pools); i++ {
+		selected, err := r.pickFromPool(ctx, pools[i], taken, 1)
+		if err != nil {
+			return domain.User{}, domain.ReviewerPool{}, err
+		}
+		if len(selected) > 0 {
+			// у снятого ревьювера одним открытым ревью меньше
+			if _, counted := r.load[oldReviewerID]; counted {
+				r.load[oldReviewerID]--
+			}
+			return selected[0], pools[i], nil
+		}
+		// команду автора и её запасные пулы читаем, только когда исходный пул пуст
+		if i == 0 && known {
+			authorPools, err := r.authorPools(ctx, pr.AuthorID)
+			if err != nil {
+				return domain.User{}, domain.ReviewerPool{}, err
+			}
+			for _, authorPool := range authorPools {
+				if !slices.Contains(pools, authorPool) {
+					pools = append(pools, authorPool)
+				}
+			}
+		}
+	}
+	return domain.User{}, domain.ReviewerPool{}, fmt.Errorf("no available active users to reassign as reviewer: %w",
+		domain.ErrNoAvailableReviewers)
+}
+
+// takenReviewers returns the IDs of the users who can't be picked as reviewers of the pull request
+func takenReviewers(pr domain.PullRequest) map[string]struct{} {
+	taken := make(map[string]struct{}, len(pr.Reviewers)+1)
+	taken[pr.AuthorID] = struct{}{}
+	for _, reviewer := range pr.Reviewers {
+		taken[reviewer.ID] = struct{}{}
+	}
+	return taken
+}
+
+// pickFromPool selects up to count members of the pool who may review right now and are not taken yet.
+// The selected users become taken
+func (r *reviewerPicker) pickFromPool(
+	ctx context.Context,
+	pool domain.ReviewerPool,
+	taken map[string]struct{},
+	count int,
+) ([]domain.User, error) {
+	members, err := r.poolCandidates(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	candidates := slices.DeleteFunc(slices.Clone(members), func(user domain.User) bool {
+		_, isTaken := taken[user.ID]
+		return isTaken || !user.HasReviewCapacity(r.load[user.ID])
+	})
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	var selected []domain.User
+	if selector, ok := r.service.selector.(loadAwareSelector); ok {
+		selected = selector.SelectByLoad(candidates, r.load, count)
+	} else {
+		selected, err = r.service.selector.Select(ctx, pool, candidates, count)
+		if err != nil {
+			return nil, fmt.Errorf("error selecting reviewers: %w", err)
+		}
+	}
+	for _, user := range selected {
+		taken[user.ID] = struct{}{}
+		r.load[user.ID]++
+	}
+	return selected, nil
+}
+
+// poolCandidates returns the members of the pool who could review when the pool was read first
+func (r *reviewerPicker) poolCandidates(ctx context.Context, pool domain.ReviewerPool) ([]domain.User, error) {
+	if candidates, ok := r.candidates[pool]; ok {
+		return candidates, nil
+	}
+
+	var (
+		candidates []domain.User
+		err        error
+	)
+	if pool.Kind == domain.ReviewerPoolKindShared {
+		candidates, err = r.service.poolRepo.GetActiveMembers(ctx, pool.Name)
+	} else {
+		candidates, err = r.service.userRepo.GetActiveByTeamName(ctx, pool.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting active users of %s pool %s: %w", pool.Kind, pool.Name, err)
+	}
+
+	// нагрузку читаем, только когда по ней выбирают или у кандидата есть лимит
+	_, byLoad := r.service.selector.(loadAwareSelector)
+	var uncounted []string
+	for _, user := range candidates {
+		if _, counted := r.load[user.ID]; !counted && (byLoad || user.MaxOpenReviews > 0) {
+			uncounted = append(uncounted, user.ID)
+		}
+	}
+	if len(uncounted) > 0 {
+		load, err := r.service.reviewRepo.CountOpenReviews(ctx, uncounted)
+		if err != nil {
+			return nil, fmt.Errorf("error counting open reviews: %w", err)
+		}
+		maps.Copy(r.load, load)
+	}
+
+	r.candidates[pool] = candidates
+	return candidates, nil
+}
+
+// loadAuthors reads the teams of the authors at once, so that authorPools doesn't read them one by one
+func (r *reviewerPicker) loadAuthors(ctx context.Context, authorIDs []string) error {
+	authors, err := r.service.userRepo.GetByIDs(ctx, authorIDs)
+	if err != nil {
+		return fmt.Errorf("error getting authors by IDs: %w", err)
+	}
+	for _, author := range authors {
+		r.authorTeams[author.ID] = author.TeamName
+	}
+	return nil
+}
+
+// authorPools returns the team of the author followed by its fallback pools
+func (r *reviewerPicker) authorPools(ctx context.Context, authorID string) ([]domain.ReviewerPool, error) {
+	teamName, ok := r.authorTeams[authorID]
+	if !ok {
+		author, err := r.service.userRepo.GetByID(ctx, authorID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting author by ID: %w", err)
+		}
+		teamName = author.TeamName
+		r.authorTeams[authorID] = teamName
+	}
+	fallbacks, err := r.teamFallbacks(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	return append([]domain.ReviewerPool{domain.TeamPool(teamName)}, fallbacks...), nil
+}
+
+func (r *reviewerPicker) teamFallbacks(ctx context.Context, teamName string) ([]domain.ReviewerPool, error) {
+	if fallbacks, ok := r.fallbacks[teamName]; ok {
+		return fallbacks, nil
+	}
+	fallbacks, err := r.service.poolRepo.GetTeamFallbacks(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fallback pools of team %s: %w", teamName, err)
+	}
+	r.fallbacks[teamName] = fallbacks
+	return fallbacks, nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+type iReviewerPoolRepository interface {
+	Set(ctx context.Context, poolName string, userIDs []string) (domain.SharedReviewerPool, error)
+	Get(ctx context.Context, poolName string) (domain.SharedReviewerPool, error)
+	Delete(ctx context.Context, poolName string) error
+}
+
+type iPoolUserRepository interface {
+	GetByIDs(ctx context.Context, userIDs []string) ([]domain.User, error)
+}
+
+// ReviewerPoolService manages shared reviewer pools teams may fall back to
+type ReviewerPoolService struct {
+	repository     iReviewerPoolRepository
+	userRepository iPoolUserRepository
+	auditRecorder  iAuditRecorder
+	transactor     iTransactor
+}
+
+func NewReviewerPoolService(
+	repository iReviewerPoolRepository,
+	userRepository iPoolUserRepository,
+	auditRecorder iAuditRecorder,
+	transactor iTransactor,
+) *ReviewerPoolService {
+	return &ReviewerPoolService{
+		repository:     repository,
+		userRepository: userRepository,
+		auditRecorder:  auditRecorder,
+		transactor:     transactor,
+	}
+}
+
+// Set creates the shared reviewer pool or replaces its members. Members stay in their own teams,
+// deleted users can't join a pool
+func (s *ReviewerPoolService) Set(
+	ctx context.Context,
+	poolName string,
+	userIDs []string,
+) (domain.SharedReviewerPool, error) {
+	if poolName == "" {
+		return domain.SharedReviewerPool{}, fmt.Errorf("%w: pool name is empty", domain.ErrInvalidReviewerPool)
+	}
+	for i, userID := range userIDs {
+		if slices.Contains(userIDs[:i], userID) {
+			return domain.SharedReviewerPool{}, fmt.Errorf("%w: user %s is listed twice",
+				domain.ErrInvalidReviewerPool, userID)
+		}
+	}
+
+	var pool domain.SharedReviewerPool
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkMembers(ctx, userIDs); err != nil {
+			return err
+		}
+
+		var before any
+		stored, err := s.repository.Get(ctx, poolName)
+		switch {
+		case err == nil:
+			before = sharedPoolSnapshot(stored)
+		case !errors.Is(err, domain.ErrReviewerPoolNotFound):
+			return fmt.Errorf("error getting reviewer pool %s: %w", poolName, err)
+		}
+
+		pool, err = s.repository.Set(ctx, poolName, userIDs)
+		if err != nil {
+			return fmt.Errorf("error setting reviewer pool %s: %w", poolName, err)
+		}
+		return recordAudit(ctx, s.auditRecorder, domain.AuditActionPoolSet, domain.AuditEntityPool, poolName,
+			before, sharedPoolSnapshot(pool))
+	})
+	if err != nil {
+		return domain.SharedReviewerPool{}, err
+	}
+	return pool, nil
+}
+
+// checkMembers checks that every user exists and is not deleted
+func (s *ReviewerPoolService) checkMembers(ctx context.Context, userIDs []string) error {
+	users, err := s.userRepository.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return fmt.Errorf("error getting pool members: %w", err)
+	}
+	for _, userID := range userIDs {
+		i := slices.IndexFunc(users, func(user domain.User) bool { return user.ID == userID })
+		if i < 0 || users[i].IsDeleted() {
+			return fmt.Errorf("user with ID %s: %w", userID, domain.ErrUserNotFound)
+		}
+	}
+	return nil
+}
+
+// Get returns the shared reviewer pool with its members
+func (s *ReviewerPoolService) Get(ctx context.Context, poolName string) (domain.SharedReviewerPool, error) {
+	pool, err := s.repository.Get(ctx, poolName)
+	if err != nil {
+		return domain.SharedReviewerPool{}, fmt.Errorf("error getting reviewer pool %s: %w", poolName, err)
+	}
+	return pool, nil
+}
+
+// Delete deletes the shared reviewer pool, teams falling back to it lose it.
+// Reviewers already picked from the pool stay assigned
+func (s *ReviewerPoolService) Delete(ctx context.Context, poolName string) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		pool, err := s.repository.Get(ctx, poolName)
+		if err != nil {
+			return fmt.Errorf("error getting reviewer pool %s: %w", poolName, err)
+		}
+		if err := s.repository.Delete(ctx, poolName); err != nil {
+			return fmt.Errorf("error deleting reviewer pool %s: %w", poolName, err)
+		}
+		return recordAudit(ctx, s.auditRecorder, domain.AuditActionPoolDelete, domain.AuditEntityPool, poolName,
+			sharedPoolSnapshot(pool), nil)
+	})
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// ReviewerPoolServiceTestSuite определяет test suite для ReviewerPoolService
+type ReviewerPoolServiceTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+// SetupTest выполняется перед каждым тестом
+func (s *ReviewerPoolServiceTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+// newPoolServiceMocks создаёт сервис пулов на моках, транзакции просто выполняются
+func (s *ReviewerPoolServiceTestSuite) newPoolServiceMocks() (
+	*ReviewerPoolService,
+	*mockiReviewerPoolRepository,
+	*mockiPoolUserRepository,
+	*mockiAuditRecorder,
+) {
+	mockRepo := newMockiReviewerPoolRepository(s.T())
+	mockUserRepo := newMockiPoolUserRepository(s.T())
+	mockRecorder := newMockiAuditRecorder(s.T())
+	service := NewReviewerPoolService(mockRepo, mockUserRepo, mockRecorder, newPassthroughTransactor(s.T()))
+	return service, mockRepo, mockUserRepo, mockRecorder
+}
+
+// TestSet проверяет создание пула и замену его участников
+func (s *ReviewerPoolServiceTestSuite) TestSet() {
+	users := []domain.User{{ID: "user-1", TeamName: "backend"}, {ID: "user-2", TeamName: "frontend"}}
+
+	s.Run("new pool", func() {
+		service, mockRepo, mockUserRepo, mockRecorder := s.newPoolServiceMocks()
+		pool := domain.SharedReviewerPool{Name: "guild", Members: users}
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"user-1", "user-2"}).Return(users, nil).Once()
+		mockRepo.EXPECT().Get(s.ctx, "guild").Return(domain.SharedReviewerPool{}, domain.ErrReviewerPoolNotFound).Once()
+		mockRepo.EXPECT().Set(s.ctx, "guild", []string{"user-1", "user-2"}).Return(pool, nil).Once()
+		// у нового пула нет состояния до изменения
+		mockRecorder.EXPECT().Add(s.ctx, mock.MatchedBy(func(event domain.AuditEvent) bool {
+			return event.Action == domain.AuditActionPoolSet && event.EntityType == domain.AuditEntityPool &&
+				event.Before == nil && string(event.After) == `{"pool_name":"guild","members":["user-1","user-2"]}`
+		})).Return(domain.AuditEvent{}, nil).Once()
+
+		result, err := service.Set(s.ctx, "guild", []string{"user-1", "user-2"})
+
+		s.Require().NoError(err)
+		s.Equal(pool, result)
+	})
+
+	s.Run("deleted member", func() {
+		service, _, mockUserRepo, _ := s.newPoolServiceMocks()
+		deleted := []domain.User{users[0], {ID: "user-2", DeletedAt: time.Now()}}
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"user-1", "user-2"}).Return(deleted, nil).Once()
+
+		_, err := service.Set(s.ctx, "guild", []string{"user-1", "user-2"})
+		s.ErrorIs(err, domain.ErrUserNotFound)
+	})
+
+	s.Run("unknown member", func() {
+		service, _, mockUserRepo, _ := s.newPoolServiceMocks()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"user-1", "ghost"}).Return(users[:1], nil).Once()
+
+		_, err := service.Set(s.ctx, "guild", []string{"user-1", "ghost"})
+		s.ErrorIs(err, domain.ErrUserNotFound)
+	})
+
+	s.Run("duplicate member", func() {
+		service, _, _, _ := s.newPoolServiceMocks()
+		_, err := service.Set(s.ctx, "guild", []string{"user-1", "user-1"})
+		s.ErrorIs(err, domain.ErrInvalidReviewerPool)
+	})
+}
+
+// TestDelete проверяет удаление пула
+func (s *ReviewerPoolServiceTestSuite) TestDelete() {
+	s.Run("success", func() {
+		service, mockRepo, _, mockRecorder := s.newPoolServiceMocks()
+		mockRepo.EXPECT().Get(s.ctx, "guild").Return(domain.SharedReviewerPool{Name: "guild"}, nil).Once()
+		mockRepo.EXPECT().Delete(s.ctx, "guild").Return(nil).Once()
+		mockRecorder.EXPECT().Add(s.ctx, mock.MatchedBy(func(event domain.AuditEvent) bool {
+			return event.Action == domain.AuditActionPoolDelete && len(event.Before) > 0 && event.After == nil
+		})).Return(domain.AuditEvent{}, nil).Once()
+
+		s.Require().NoError(service.Delete(s.ctx, "guild"))
+	})
+
+	s.Run("pool not found", func() {
+		service, mockRepo, _, _ := s.newPoolServiceMocks()
+		mockRepo.EXPECT().Get(s.ctx, "ghost").Return(domain.SharedReviewerPool{}, domain.ErrReviewerPoolNotFound).Once()
+
+		s.ErrorIs(service.Delete(s.ctx, "ghost"), domain.ErrReviewerPoolNotFound)
+	})
+}
+
+// TestReviewerPoolServiceSuite запускает test suite
+func TestReviewerPoolServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReviewerPoolServiceTestSuite))
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/pkg/config"
+)
+
+// ReviewerSelector picks up to count reviewers out of candidates, the members of the pool.
+// Candidates are already filtered (active, not the author, not assigned yet).
+type ReviewerSelector interface {
+	Select(ctx context.Context, pool domain.ReviewerPool, candidates []domain.User, count int) ([]domain.User, error)
+}
+
+// loadAwareSelector is a ReviewerSelector that picks by the open reviews of the candidates.
+// The caller passes the load it already knows, so the selector doesn't read it
+type loadAwareSelector interface {
+	SelectByLoad(candidates []domain.User, load map[string]int, count int) []domain.User
+}
+
+type iReviewLoadRepository interface {
+	CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error)
+}
+
+// iReviewerCursorRepository stores the member of every pool picked last in turn
+type iReviewerCursorRepository interface {
+	LockCursor(ctx context.Context, pool domain.ReviewerPool) (string, error)
+	SetCursor(ctx context.Context, pool domain.ReviewerPool, lastReviewerID string) error
+}
+
+// NewReviewerSelector creates the selector configured for the deployment.
+func NewReviewerSelector(
+	cfg config.ReviewersConfig,
+	loadRepo iReviewLoadRepository,
+	cursorRepo iReviewerCursorRepository,
+) (ReviewerSelector, error) {
+	switch cfg.Strategy {
+	case config.ReviewerStrategyRandom, "":
+		return NewRandomSelector(), nil
+	case config.ReviewerStrategyRoundRobin:
+		return NewRoundRobinSelector(cursorRepo), nil
+	case config.ReviewerStrategyLeastLoaded:
+		return NewLeastLoadedSelector(loadRepo), nil
+	case config.ReviewerStrategyWeighted:
+		return NewWeightedSelector(cfg.Weights), nil
+	}
+	return nil, fmt.Errorf("unknown reviewer selection strategy %q", cfg.Strategy)
+}
+
+// RandomSelector picks reviewers uniformly at random.
+type RandomSelector struct{}
+
+func NewRandomSelector() *RandomSelector {
+	return &RandomSelector{}
+}
+
+func (s *RandomSelector) Select(
+	_ context.Context,
+	_ domain.ReviewerPool,
+	candidates []domain.User,
+	count int,
+) ([]domain.User, error) {
+	if len(candidates) <= count {
+		return candidates, nil
+	}
+
+	selected := slices.Clone(candidates)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	rng.Shuffle(len(selected), func(i, j int) {
+		selected[i], selected[j] = selected[j], selected[i]
+	})
+	return selected[:count], nil
+}
+
+// RoundRobinSelector walks over the pool members in the order of their IDs, so that everyone gets reviews in turn.
+// The cursor of a pool is stored and moves in the transaction that assigns the picked reviewers: a rolled back
+// or retried assignment leaves the queue where it was, and all instances share one queue.
+type RoundRobinSelector struct {
+	cursorRepo iReviewerCursorRepository
+}
+
+func NewRoundRobinSelector(cursorRepo iReviewerCursorRepository) *RoundRobinSelector {
+	return &RoundRobinSelector{cursorRepo: cursorRepo}
+}
+
+func (s *RoundRobinSelector) Select(
+	ctx context.Context,
+	pool domain.ReviewerPool,
+	candidates []domain.User,
+	count int,
+) ([]domain.User, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	count = min(count, len(candidates))
+
+	ordered := slices.Clone(candidates)
+	slices.SortFunc(ordered, func(a, b domain.User) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	// курсор заблокирован до конца транзакции, так что параллельный выбор из пула не возьмёт тех же
+	cursor, err := s.cursorRepo.LockCursor(ctx, pool)
+	if err != nil {
+		return nil, fmt.Errorf("error getting cursor of %s pool %s: %w", pool.Kind, pool.Name, err)
+	}
+
+	// Очередь идёт по ID всех участников пула, а не по позиции в списке кандидатов: он каждый раз свой,
+	// потому что без автора и недоступных. Начинаем со следующего после последнего выбранного, недоступных пропускаем
+	start, found := slices.BinarySearchFunc(ordered, cursor, func(user domain.User, id string) int {
+		return strings.Compare(user.ID, id)
+	})
+	if found {
+		start++
+	}
+
+	selected := make([]domain.User, 0, count)
+	for i := range count {
+		selected = append(selected, ordered[(start+i)%len(ordered)])
+	}
+	if err := s.cursorRepo.SetCursor(ctx, pool, selected[count-1].ID); err != nil {
+		return nil, fmt.Errorf("error moving cursor of %s pool %s: %w", pool.Kind, pool.Name, err)
+	}
+	return selected, nil
+}
+
+// LeastLoadedSelector prefers reviewers with the smallest number of open reviews.
+// Reviewers with equal load are picked at random.
+type LeastLoadedSelector struct {
+	loadRepo iReviewLoadRepository
+}
+
+func NewLeastLoadedSelector(loadRepo iReviewLoadRepository) *LeastLoadedSelector {
+	return &LeastLoadedSelector{loadRepo: loadRepo}
+}
+
+func (s *LeastLoadedSelector) Select(
+	ctx context.Context,
+	_ domain.ReviewerPool,
+	candidates []domain.User,
+	count int,
+) ([]domain.User, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.ID
+	}
+	load, err := s.loadRepo.CountOpenReviews(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error counting open reviews: %w", err)
+	}
+	return s.SelectByLoad(candidates, load, count), nil
+}
+
+// SelectByLoad picks up to count candidates with the smallest load, the number of open reviews by user ID
+func (s *LeastLoadedSelector) SelectByLoad(candidates []domain.User, load map[string]int, count int) []domain.User {
+	// Перемешиваем перед стабильной сортировкой, чтобы при равной нагрузке не выбирать всегда одних и тех же
+	ordered := slices.Clone(candidates)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	rng.Shuffle(len(ordered), func(i, j int) {
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	})
+	slices.SortStableFunc(ordered, func(a, b domain.User) int {
+		return load[a.ID] - load[b.ID]
+	})
+	return ordered[:min(count, len(ordered))]
+}
+
+// WeightedSelector picks reviewers at random proportionally to their weights.
+type WeightedSelector struct {
+	weights map[string]int
+}
+
+func NewWeightedSelector(weights map[string]int) *WeightedSelector {
+	return &WeightedSelector{weights: weights}
+}
+
+func (s *WeightedSelector) Select(
+	_ context.Context,
+	_ domain.ReviewerPool,
+	candidates []domain.User,
+	count int,
+) ([]domain.User, error) {
+	if len(candidates) <= count {
+		return candidates, nil
+	}
+
+	// Взвешенная выборка без повторений (Efraimidis–Spirakis): key = u^(1/w), берём count наибольших.
+	// Пользователи с нулевым весом получают ключ -1 и выбираются только если больше некого.
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	keys := make(map[string]float64, len(candidates))
+	for _, c := range candidates {
+		w, ok := s.weights[c.ID]
+		if !ok {
+			w = 1
+		}
+		if w <= 0 {
+			keys[c.ID] = -1
+			continue
+		}
+		keys[c.ID] = math.Pow(rng.Float64(), 1/float64(w))
+	}
+
+	ordered := slices.Clone(candidates)
+	slices.SortFunc(ordered, func(a, b domain.User) int {
+		switch {
+		case keys[a.ID] > keys[b.ID]:
+			return -1
+		case keys[a.ID] < keys[b.ID]:
+			return 1
+		}
+		return 0
+	})
+	return ordered[:count], nil
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/pkg/config"
+)
+
+// ReviewerSelectorTestSuite определяет test suite для стратегий выбора ревьюверов
+type ReviewerSelectorTestSuite struct {
+	suite.Suite
+	ctx        context.Context
+	pool       domain.ReviewerPool
+	candidates []domain.User
+}
+
+// SetupTest выполняется перед каждым тестом
+func (s *ReviewerSelectorTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.pool = domain.TeamPool("backend-team")
+	s.candidates = []domain.User{
+		{ID: "user-3", TeamName: "backend-team", IsActive: true},
+		{ID: "user-1", TeamName: "backend-team", IsActive: true},
+		{ID: "user-2", TeamName: "backend-team", IsActive: true},
+		{ID: "user-4", TeamName: "backend-team", IsActive: true},
+	}
+}
+
+func ids(users []domain.User) []string {
+	res := make([]string, 0, len(users))
+	for _, u := range users {
+		res = append(res, u.ID)
+	}
+	return res
+}
+
+// TestNewReviewerSelector проверяет выбор стратегии по конфигу
+func (s *ReviewerSelectorTestSuite) TestNewReviewerSelector() {
+	tests := []struct {
+		name     string
+		strategy config.ReviewerStrategy
+		want     ReviewerSelector
+		wantErr  bool
+	}{
+		{name: "default", strategy: "", want: &RandomSelector{}},
+		{name: "random", strategy: config.ReviewerStrategyRandom, want: &RandomSelector{}},
+		{name: "round robin", strategy: config.ReviewerStrategyRoundRobin, want: &RoundRobinSelector{}},
+		{name: "least loaded", strategy: config.ReviewerStrategyLeastLoaded, want: &LeastLoadedSelector{}},
+		{name: "weighted", strategy: config.ReviewerStrategyWeighted, want: &WeightedSelector{}},
+		{name: "unknown", strategy: "by_mood", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			selector, err := NewReviewerSelector(config.ReviewersConfig{Strategy: tt.strategy}, nil, nil)
+			if tt.wantErr {
+				s.Error(err)
+				return
+			}
+			s.NoError(err)
+			s.IsType(tt.want, selector)
+		})
+	}
+}
+
+// TestRandomSelector проверяет случайную стратегию
+func (s *ReviewerSelectorTestSuite) TestRandomSelector() {
+	selector := NewRandomSelector()
+
+	selected, err := selector.Select(s.ctx, s.pool, s.candidates, 2)
+	s.Require().NoError(err)
+	s.Len(selected, 2)
+	s.NotEqual(selected[0].ID, selected[1].ID)
+	s.Subset(ids(s.candidates), ids(selected))
+
+	// Кандидатов меньше, чем нужно - возвращаем всех как есть
+	selected, err = selector.Select(s.ctx, s.pool, s.candidates[:1], 2)
+	s.Require().NoError(err)
+	s.Equal([]string{"user-3"}, ids(selected))
+}
+
+// TestRoundRobinSelector проверяет, что ревьюверы назначаются по кругу
+func (s *ReviewerSelectorTestSuite) TestRoundRobinSelector() {
+	selector := NewRoundRobinSelector(s.newCursorStore())
+
+	var got [][]string
+	for range 3 {
+		selected, err := selector.Select(s.ctx, s.pool, s.candidates, 2)
+		s.Require().NoError(err)
+		got = append(got, ids(selected))
+	}
+
+	s.Equal([][]string{
+		{"user-1", "user-2"},
+		{"user-3", "user-4"},
+		{"user-1", "user-2"},
+	}, got)
+
+	// Курсор другого пула независим
+	other, err := selector.Select(s.ctx, domain.TeamPool("frontend-team"),
+		[]domain.User{{ID: "user-9", TeamName: "frontend-team"}}, 1)
+	s.Require().NoError(err)
+	s.Equal([]string{"user-9"}, ids(other))
+
+	empty, err := selector.Select(s.ctx, s.pool, nil, 2)
+	s.Require().NoError(err)
+	s.Empty(empty)
+}
+
+// TestRoundRobinSelectorSkipsCandidates проверяет, что очередь не сбивается, когда кандидаты каждый раз разные
+func (s *ReviewerSelectorTestSuite) TestRoundRobinSelectorSkipsCandidates() {
+	selector := NewRoundRobinSelector(s.newCursorStore())
+	without := func(userID string) []domain.User {
+		return slices.DeleteFunc(slices.Clone(s.candidates), func(user domain.User) bool { return user.ID == userID })
+	}
+
+	// автор user-1 пропускается, очередь доходит до user-3
+	selected, err := selector.Select(s.ctx, s.pool, without("user-1"), 2)
+	s.Require().NoError(err)
+	s.Equal([]string{"user-2", "user-3"}, ids(selected))
+
+	// следующий по очереди user-4 - автор, поэтому после него идут user-1 и user-2, user-3 не выбирается дважды подряд
+	selected, err = selector.Select(s.ctx, s.pool, without("user-4"), 2)
+	s.Require().NoError(err)
+	s.Equal([]string{"user-1", "user-2"}, ids(selected))
+
+	// выбывший из кандидатов последний выбранный не сбрасывает очередь
+	selected, err = selector.Select(s.ctx, s.pool, without("user-2"), 1)
+	s.Require().NoError(err)
+	s.Equal([]string{"user-3"}, ids(selected))
+
+	// участники общего пула из разных команд идут в одной очереди пула
+	shared := domain.SharedPool("guild")
+	members := []domain.User{{ID: "user-7", TeamName: "mobile"}, {ID: "user-5", TeamName: "backend-team"}}
+	for _, want := range []string{"user-5", "user-7", "user-5"} {
+		selected, err = selector.Select(s.ctx, shared, members, 1)
+		s.Require().NoError(err)
+		s.Equal([]string{want}, ids(selected))
+	}
+}
+
+// TestRoundRobinSelectorCursorErrors проверяет, что без сохранённого курсора никого не выбирают
+func (s *ReviewerSelectorTestSuite) TestRoundRobinSelectorCursorErrors() {
+	s.Run("lock error", func() {
+		cursorRepo := newMockiReviewerCursorRepository(s.T())
+		cursorRepo.EXPECT().LockCursor(s.ctx, s.pool).Return("", errors.New("database error")).Once()
+
+		selected, err := NewRoundRobinSelector(cursorRepo).Select(s.ctx, s.pool, s.candidates, 2)
+		s.Error(err)
+		s.Nil(selected)
+	})
+
+	s.Run("set error", func() {
+		cursorRepo := newMockiReviewerCursorRepository(s.T())
+		cursorRepo.EXPECT().LockCursor(s.ctx, s.pool).Return("user-1", nil).Once()
+		cursorRepo.EXPECT().SetCursor(s.ctx, s.pool, "user-3").Return(errors.New("database error")).Once()
+
+		selected, err := NewRoundRobinSelector(cursorRepo).Select(s.ctx, s.pool, s.candidates, 2)
+		s.Error(err)
+		s.Nil(selected)
+	})
+}
+
+// newCursorStore создаёт хранилище курсоров на моке, которое помнит записанное
+func (s *ReviewerSelectorTestSuite) newCursorStore() *mockiReviewerCursorRepository {
+	cursors := make(map[domain.ReviewerPool]string)
+	cursorRepo := newMockiReviewerCursorRepository(s.T())
+	cursorRepo.EXPECT().LockCursor(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, pool domain.ReviewerPool) (string, error) {
+			return cursors[pool], nil
+		}).Maybe()
+	cursorRepo.EXPECT().SetCursor(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, pool domain.ReviewerPool, lastReviewerID string) error {
+			cursors[pool] = lastReviewerID
+			return nil
+		}).Maybe()
+	return cursorRepo
+}
+
+// TestLeastLoadedSelector проверяет выбор наименее загруженных ревьюверов
+func (s *ReviewerSelectorTestSuite) TestLeastLoadedSelector() {
+	s.Run("success", func() {
+		loadRepo := newMockiReviewLoadRepository(s.T())
+		loadRepo.EXPECT().
+			CountOpenReviews(s.ctx, []string{"user-3", "user-1", "user-2", "user-4"}).
+			Return(map[string]int{"user-3": 5, "user-1": 2, "user-2": 0, "user-4": 1}, nil).Once()
+
+		selected, err := NewLeastLoadedSelector(loadRepo).Select(s.ctx, s.pool, s.candidates, 2)
+		s.Require().NoError(err)
+		s.Equal([]string{"user-2", "user-4"}, ids(selected))
+	})
+
+	s.Run("ties are broken randomly", func() {
+		loadRepo := newMockiReviewLoadRepository(s.T())
+		loadRepo.EXPECT().
+			CountOpenReviews(s.ctx, mock.Anything).
+			Return(map[string]int{"user-3": 1, "user-1": 1, "user-2": 1, "user-4": 7}, nil)
+
+		seen := make(map[string]struct{})
+		for range 50 {
+			selected, err := NewLeastLoadedSelector(loadRepo).Select(s.ctx, s.pool, s.candidates, 1)
+			s.Require().NoError(err)
+			s.NotEqual("user-4", selected[0].ID)
+			seen[selected[0].ID] = struct{}{}
+		}
+		s.Greater(len(seen), 1)
+	})
+
+	s.Run("repository error", func() {
+		loadRepo := newMockiReviewLoadRepository(s.T())
+		loadRepo.EXPECT().
+			CountOpenReviews(s.ctx, mock.Anything).
+			Return(nil, errors.New("database error")).Once()
+
+		_, err := NewLeastLoadedSelector(loadRepo).Select(s.ctx, s.pool, s.candidates, 2)
+		s.Error(err)
+	})
+}
+
+// TestWeightedSelector проверяет взвешенную стратегию
+func (s *ReviewerSelectorTestSuite) TestWeightedSelector() {
+	// Пользователи с нулевым весом не выбираются, пока есть другие кандидаты
+	selector := NewWeightedSelector(map[string]int{"user-1": 0, "user-2": 0, "user-3": 10})
+
+	for range 20 {
+		selected, err := selector.Select(s.ctx, s.pool, s.candidates, 2)
+		s.Require().NoError(err)
+		s.ElementsMatch([]string{"user-3", "user-4"}, ids(selected))
+	}
+}
+
+// TestReviewerSelectorSuite запускает test suite
+func TestReviewerSelectorSuite(t *testing.T) {
+	suite.Run(t, new(ReviewerSelectorTestSuite))
+}
@@ -6,18 +6,19 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/artmexbet/avito_test_task/internal/domain"
 	mock "github.com/stretchr/testify/mock"
 )
 
-// newMockiPullRequestRepository creates a new instance of mockiPullRequestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// newMockiAbsenceRepository creates a new instance of mockiAbsenceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func newMockiPullRequestRepository(t interface {
+func newMockiAbsenceRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *mockiPullRequestRepository {
-	mock := &mockiPullRequestRepository{}
+}) *mockiAbsenceRepository {
+	mock := &mockiAbsenceRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -25,66 +26,66 @@ func newMockiPullRequestRepository(t interface {
 	return mock
 }
 
-// mockiPullRequestRepository is an autogenerated mock type for the iPullRequestRepository type
-type mockiPullRequestRepository struct {
+// mockiAbsenceRepository is an autogenerated mock type for the iAbsenceRepository type
+type mockiAbsenceRepository struct {
 	mock.Mock
 }
 
-type mockiPullRequestRepository_Expecter struct {
+type mockiAbsenceRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *mockiPullRequestRepository) EXPECT() *mockiPullRequestRepository_Expecter {
-	return &mockiPullRequestRepository_Expecter{mock: &_m.Mock}
+func (_m *mockiAbsenceRepository) EXPECT() *mockiAbsenceRepository_Expecter {
+	return &mockiAbsenceRepository_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function for the type mockiPullRequestRepository
-func (_mock *mockiPullRequestRepository) Create(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
-	ret := _mock.Called(ctx, pr)
+// Add provides a mock function for the type mockiAbsenceRepository
+func (_mock *mockiAbsenceRepository) Add(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	ret := _mock.Called(ctx, absence)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for Add")
 	}
 
-	var r0 domain.PullRequest
+	var r0 domain.Absence
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PullRequest) (domain.PullRequest, error)); ok {
-		return returnFunc(ctx, pr)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Absence) (domain.Absence, error)); ok {
+		return returnFunc(ctx, absence)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PullRequest) domain.PullRequest); ok {
-		r0 = returnFunc(ctx, pr)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Absence) domain.Absence); ok {
+		r0 = returnFunc(ctx, absence)
 	} else {
-		r0 = ret.Get(0).(domain.PullRequest)
+		r0 = ret.Get(0).(domain.Absence)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.PullRequest) error); ok {
-		r1 = returnFunc(ctx, pr)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Absence) error); ok {
+		r1 = returnFunc(ctx, absence)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// mockiPullRequestRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type mockiPullRequestRepository_Create_Call struct {
+// mockiAbsenceRepository_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type mockiAbsenceRepository_Add_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// Add is a helper method to define mock.On call
 //   - ctx context.Context
-//   - pr domain.PullRequest
-func (_e *mockiPullRequestRepository_Expecter) Create(ctx interface{}, pr interface{}) *mockiPullRequestRepository_Create_Call {
-	return &mockiPullRequestRepository_Create_Call{Call: _e.mock.On("Create", ctx, pr)}
+//   - absence domain.Absence
+func (_e *mockiAbsenceRepository_Expecter) Add(ctx interface{}, absence interface{}) *mockiAbsenceRepository_Add_Call {
+	return &mockiAbsenceRepository_Add_Call{Call: _e.mock.On("Add", ctx, absence)}
 }
 
-func (_c *mockiPullRequestRepository_Create_Call) Run(run func(ctx context.Context, pr domain.PullRequest)) *mockiPullRequestRepository_Create_Call {
+func (_c *mockiAbsenceRepository_Add_Call) Run(run func(ctx context.Context, absence domain.Absence)) *mockiAbsenceRepository_Add_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 domain.PullRequest
+		var arg1 domain.Absence
 		if args[1] != nil {
-			arg1 = args[1].(domain.PullRequest)
+			arg1 = args[1].(domain.Absence)
 		}
 		run(
 			arg0,
@@ -94,129 +95,128 @@ func (_c *mockiPullRequestRepository_Create_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *mockiPullRequestRepository_Create_Call) Return(pullRequest domain.PullRequest, err error) *mockiPullRequestRepository_Create_Call {
-	_c.Call.Return(pullRequest, err)
+func (_c *mockiAbsenceRepository_Add_Call) Return(absence1 domain.Absence, err error) *mockiAbsenceRepository_Add_Call {
+	_c.Call.Return(absence1, err)
 	return _c
 }
 
-func (_c *mockiPullRequestRepository_Create_Call) RunAndReturn(run func(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error)) *mockiPullRequestRepository_Create_Call {
+func (_c *mockiAbsenceRepository_Add_Call) RunAndReturn(run func(ctx context.Context, absence domain.Absence) (domain.Absence, error)) *mockiAbsenceRepository_Add_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Exists provides a mock function for the type mockiPullRequestRepository
-func (_mock *mockiPullRequestRepository) Exists(ctx context.Context, prID string) (bool, error) {
-	ret := _mock.Called(ctx, prID)
+// ClaimStarted provides a mock function for the type mockiAbsenceRepository
+func (_mock *mockiAbsenceRepository) ClaimStarted(ctx context.Context, now time.Time, batchSize int) ([]domain.Absence, error) {
+	ret := _mock.Called(ctx, now, batchSize)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Exists")
+		panic("no return value specified for ClaimStarted")
 	}
 
-	var r0 bool
+	var r0 []domain.Absence
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
-		return returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]domain.Absence, error)); ok {
+		return returnFunc(ctx, now, batchSize)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) []domain.Absence); ok {
+		r0 = returnFunc(ctx, now, batchSize)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Absence)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, now, batchSize)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// mockiPullRequestRepository_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
-type mockiPullRequestRepository_Exists_Call struct {
+// mockiAbsenceRepository_ClaimStarted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimStarted'
+type mockiAbsenceRepository_ClaimStarted_Call struct {
 	*mock.Call
 }
 
-// Exists is a helper method to define mock.On call
+// ClaimStarted is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-func (_e *mockiPullRequestRepository_Expecter) Exists(ctx interface{}, prID interface{}) *mockiPullRequestRepository_Exists_Call {
-	return &mockiPullRequestRepository_Exists_Call{Call: _e.mock.On("Exists", ctx, prID)}
+//   - now time.Time
+//   - batchSize int
+func (_e *mockiAbsenceRepository_Expecter) ClaimStarted(ctx interface{}, now interface{}, batchSize interface{}) *mockiAbsenceRepository_ClaimStarted_Call {
+	return &mockiAbsenceRepository_ClaimStarted_Call{Call: _e.mock.On("ClaimStarted", ctx, now, batchSize)}
 }
 
-func (_c *mockiPullRequestRepository_Exists_Call) Run(run func(ctx context.Context, prID string)) *mockiPullRequestRepository_Exists_Call {
+func (_c *mockiAbsenceRepository_ClaimStarted_Call) Run(run func(ctx context.Context, now time.Time, batchSize int)) *mockiAbsenceRepository_ClaimStarted_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *mockiPullRequestRepository_Exists_Call) Return(b bool, err error) *mockiPullRequestRepository_Exists_Call {
-	_c.Call.Return(b, err)
+func (_c *mockiAbsenceRepository_ClaimStarted_Call) Return(absences []domain.Absence, err error) *mockiAbsenceRepository_ClaimStarted_Call {
+	_c.Call.Return(absences, err)
 	return _c
 }
 
-func (_c *mockiPullRequestRepository_Exists_Call) RunAndReturn(run func(ctx context.Context, prID string) (bool, error)) *mockiPullRequestRepository_Exists_Call {
+func (_c *mockiAbsenceRepository_ClaimStarted_Call) RunAndReturn(run func(ctx context.Context, now time.Time, batchSize int) ([]domain.Absence, error)) *mockiAbsenceRepository_ClaimStarted_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function for the type mockiPullRequestRepository
-func (_mock *mockiPullRequestRepository) GetByID(ctx context.Context, prID string) (domain.PullRequest, error) {
-	ret := _mock.Called(ctx, prID)
+// Delete provides a mock function for the type mockiAbsenceRepository
+func (_mock *mockiAbsenceRepository) Delete(ctx context.Context, id int64) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for Delete")
 	}
 
-	var r0 domain.PullRequest
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.PullRequest, error)); ok {
-		return returnFunc(ctx, prID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.PullRequest); ok {
-		r0 = returnFunc(ctx, prID)
-	} else {
-		r0 = ret.Get(0).(domain.PullRequest)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, prID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// mockiPullRequestRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type mockiPullRequestRepository_GetByID_Call struct {
+// mockiAbsenceRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type mockiAbsenceRepository_Delete_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
+// Delete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-func (_e *mockiPullRequestRepository_Expecter) GetByID(ctx interface{}, prID interface{}) *mockiPullRequestRepository_GetByID_Call {
-	return &mockiPullRequestRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, prID)}
+//   - id int64
+func (_e *mockiAbsenceRepository_Expecter) Delete(ctx interface{}, id interface{}) *mockiAbsenceRepository_Delete_Call {
+	return &mockiAbsenceRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
 }
 
-func (_c *mockiPullRequestRepository_GetByID_Call) Run(run func(ctx context.Context, prID string)) *mockiPullRequestRepository_GetByID_Call {
+func (_c *mockiAbsenceRepository_Delete_Call) Run(run func(ctx context.Context, id int64)) *mockiAbsenceRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int64)
 		}
 		run(
 			arg0,
@@ -226,63 +226,63 @@ func (_c *mockiPullRequestRepository_GetByID_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *mockiPullRequestRepository_GetByID_Call) Return(pullRequest domain.PullRequest, err error) *mockiPullRequestRepository_GetByID_Call {
-	_c.Call.Return(pullRequest, err)
+func (_c *mockiAbsenceRepository_Delete_Call) Return(err error) *mockiAbsenceRepository_Delete_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *mockiPullRequestRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, prID string) (domain.PullRequest, error)) *mockiPullRequestRepository_GetByID_Call {
+func (_c *mockiAbsenceRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id int64) error) *mockiAbsenceRepository_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Merge provides a mock function for the type mockiPullRequestRepository
-func (_mock *mockiPullRequestRepository) Merge(ctx context.Context, prID string) (domain.PullRequest, error) {
-	ret := _mock.Called(ctx, prID)
+// Get provides a mock function for the type mockiAbsenceRepository
+func (_mock *mockiAbsenceRepository) Get(ctx context.Context, id int64) (domain.Absence, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Merge")
+		panic("no return value specified for Get")
 	}
 
-	var r0 domain.PullRequest
+	var r0 domain.Absence
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.PullRequest, error)); ok {
-		return returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (domain.Absence, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.PullRequest); ok {
-		r0 = returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) domain.Absence); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Get(0).(domain.PullRequest)
+		r0 = ret.Get(0).(domain.Absence)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// mockiPullRequestRepository_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
-type mockiPullRequestRepository_Merge_Call struct {
+// mockiAbsenceRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type mockiAbsenceRepository_Get_Call struct {
 	*mock.Call
 }
 
-// Merge is a helper method to define mock.On call
+// Get is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-func (_e *mockiPullRequestRepository_Expecter) Merge(ctx interface{}, prID interface{}) *mockiPullRequestRepository_Merge_Call {
-	return &mockiPullRequestRepository_Merge_Call{Call: _e.mock.On("Merge", ctx, prID)}
+//   - id int64
+func (_e *mockiAbsenceRepository_Expecter) Get(ctx interface{}, id interface{}) *mockiAbsenceRepository_Get_Call {
+	return &mockiAbsenceRepository_Get_Call{Call: _e.mock.On("Get", ctx, id)}
 }
 
-func (_c *mockiPullRequestRepository_Merge_Call) Run(run func(ctx context.Context, prID string)) *mockiPullRequestRepository_Merge_Call {
+func (_c *mockiAbsenceRepository_Get_Call) Run(run func(ctx context.Context, id int64)) *mockiAbsenceRepository_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int64)
 		}
 		run(
 			arg0,
@@ -292,155 +292,131 @@ func (_c *mockiPullRequestRepository_Merge_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *mockiPullRequestRepository_Merge_Call) Return(pullRequest domain.PullRequest, err error) *mockiPullRequestRepository_Merge_Call {
-	_c.Call.Return(pullRequest, err)
+func (_c *mockiAbsenceRepository_Get_Call) Return(absence domain.Absence, err error) *mockiAbsenceRepository_Get_Call {
+	_c.Call.Return(absence, err)
 	return _c
 }
 
-func (_c *mockiPullRequestRepository_Merge_Call) RunAndReturn(run func(ctx context.Context, prID string) (domain.PullRequest, error)) *mockiPullRequestRepository_Merge_Call {
+func (_c *mockiAbsenceRepository_Get_Call) RunAndReturn(run func(ctx context.Context, id int64) (domain.Absence, error)) *mockiAbsenceRepository_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// newMockiReviewRepository creates a new instance of mockiReviewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func newMockiReviewRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *mockiReviewRepository {
-	mock := &mockiReviewRepository{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// mockiReviewRepository is an autogenerated mock type for the iReviewRepository type
-type mockiReviewRepository struct {
-	mock.Mock
-}
-
-type mockiReviewRepository_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *mockiReviewRepository) EXPECT() *mockiReviewRepository_Expecter {
-	return &mockiReviewRepository_Expecter{mock: &_m.Mock}
-}
-
-// AssignToPR provides a mock function for the type mockiReviewRepository
-func (_mock *mockiReviewRepository) AssignToPR(ctx context.Context, prID string, reviewerIDs []string) error {
-	ret := _mock.Called(ctx, prID, reviewerIDs)
+// List provides a mock function for the type mockiAbsenceRepository
+func (_mock *mockiAbsenceRepository) List(ctx context.Context, filter domain.AbsenceFilter) ([]domain.Absence, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AssignToPR")
+		panic("no return value specified for List")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
-		r0 = returnFunc(ctx, prID, reviewerIDs)
+	var r0 []domain.Absence
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.AbsenceFilter) ([]domain.Absence, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.AbsenceFilter) []domain.Absence); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Absence)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.AbsenceFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// mockiReviewRepository_AssignToPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignToPR'
-type mockiReviewRepository_AssignToPR_Call struct {
+// mockiAbsenceRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type mockiAbsenceRepository_List_Call struct {
 	*mock.Call
 }
 
-// AssignToPR is a helper method to define mock.On call
+// List is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-//   - reviewerIDs []string
-func (_e *mockiReviewRepository_Expecter) AssignToPR(ctx interface{}, prID interface{}, reviewerIDs interface{}) *mockiReviewRepository_AssignToPR_Call {
-	return &mockiReviewRepository_AssignToPR_Call{Call: _e.mock.On("AssignToPR", ctx, prID, reviewerIDs)}
+//   - filter domain.AbsenceFilter
+func (_e *mockiAbsenceRepository_Expecter) List(ctx interface{}, filter interface{}) *mockiAbsenceRepository_List_Call {
+	return &mockiAbsenceRepository_List_Call{Call: _e.mock.On("List", ctx, filter)}
 }
 
-func (_c *mockiReviewRepository_AssignToPR_Call) Run(run func(ctx context.Context, prID string, reviewerIDs []string)) *mockiReviewRepository_AssignToPR_Call {
+func (_c *mockiAbsenceRepository_List_Call) Run(run func(ctx context.Context, filter domain.AbsenceFilter)) *mockiAbsenceRepository_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 domain.AbsenceFilter
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 []string
-		if args[2] != nil {
-			arg2 = args[2].([]string)
+			arg1 = args[1].(domain.AbsenceFilter)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *mockiReviewRepository_AssignToPR_Call) Return(err error) *mockiReviewRepository_AssignToPR_Call {
-	_c.Call.Return(err)
+func (_c *mockiAbsenceRepository_List_Call) Return(absences []domain.Absence, err error) *mockiAbsenceRepository_List_Call {
+	_c.Call.Return(absences, err)
 	return _c
 }
 
-func (_c *mockiReviewRepository_AssignToPR_Call) RunAndReturn(run func(ctx context.Context, prID string, reviewerIDs []string) error) *mockiReviewRepository_AssignToPR_Call {
+func (_c *mockiAbsenceRepository_List_Call) RunAndReturn(run func(ctx context.Context, filter domain.AbsenceFilter) ([]domain.Absence, error)) *mockiAbsenceRepository_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByPRID provides a mock function for the type mockiReviewRepository
-func (_mock *mockiReviewRepository) GetByPRID(ctx context.Context, prID string) ([]domain.User, error) {
-	ret := _mock.Called(ctx, prID)
+// Update provides a mock function for the type mockiAbsenceRepository
+func (_mock *mockiAbsenceRepository) Update(ctx context.Context, absence domain.Absence) (domain.Absence, error) {
+	ret := _mock.Called(ctx, absence)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByPRID")
+		panic("no return value specified for Update")
 	}
 
-	var r0 []domain.User
+	var r0 domain.Absence
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.User, error)); ok {
-		return returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Absence) (domain.Absence, error)); ok {
+		return returnFunc(ctx, absence)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.User); ok {
-		r0 = returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Absence) domain.Absence); ok {
+		r0 = returnFunc(ctx, absence)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.User)
-		}
+		r0 = ret.Get(0).(domain.Absence)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Absence) error); ok {
+		r1 = returnFunc(ctx, absence)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// mockiReviewRepository_GetByPRID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByPRID'
-type mockiReviewRepository_GetByPRID_Call struct {
+// mockiAbsenceRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type mockiAbsenceRepository_Update_Call struct {
 	*mock.Call
 }
 
-// GetByPRID is a helper method to define mock.On call
+// Update is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-func (_e *mockiReviewRepository_Expecter) GetByPRID(ctx interface{}, prID interface{}) *mockiReviewRepository_GetByPRID_Call {
-	return &mockiReviewRepository_GetByPRID_Call{Call: _e.mock.On("GetByPRID", ctx, prID)}
+//   - absence domain.Absence
+func (_e *mockiAbsenceRepository_Expecter) Update(ctx interface{}, absence interface{}) *mockiAbsenceRepository_Update_Call {
+	return &mockiAbsenceRepository_Update_Call{Call: _e.mock.On("Update", ctx, absence)}
 }
 
-func (_c *mockiReviewRepository_GetByPRID_Call) Run(run func(ctx context.Context, prID string)) *mockiReviewRepository_GetByPRID_Call {
+func (_c *mockiAbsenceRepository_Update_Call) Run(run func(ctx context.Context, absence domain.Absence)) *mockiAbsenceRepository_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 domain.Absence
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(domain.Absence)
 		}
 		run(
 			arg0,
@@ -450,35 +426,60 @@ func (_c *mockiReviewRepository_GetByPRID_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *mockiReviewRepository_GetByPRID_Call) Return(users []domain.User, err error) *mockiReviewRepository_GetByPRID_Call {
-	_c.Call.Return(users, err)
+func (_c *mockiAbsenceRepository_Update_Call) Return(absence1 domain.Absence, err error) *mockiAbsenceRepository_Update_Call {
+	_c.Call.Return(absence1, err)
 	return _c
 }
 
-func (_c *mockiReviewRepository_GetByPRID_Call) RunAndReturn(run func(ctx context.Context, prID string) ([]domain.User, error)) *mockiReviewRepository_GetByPRID_Call {
+func (_c *mockiAbsenceRepository_Update_Call) RunAndReturn(run func(ctx context.Context, absence domain.Absence) (domain.Absence, error)) *mockiAbsenceRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetReviewingPR provides a mock function for the type mockiReviewRepository
-func (_mock *mockiReviewRepository) GetReviewingPR(ctx context.Context, userID string) ([]domain.PullRequest, error) {
+// newMockiAbsenceUserRepository creates a new instance of mockiAbsenceUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiAbsenceUserRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiAbsenceUserRepository {
+	mock := &mockiAbsenceUserRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiAbsenceUserRepository is an autogenerated mock type for the iAbsenceUserRepository type
+type mockiAbsenceUserRepository struct {
+	mock.Mock
+}
+
+type mockiAbsenceUserRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiAbsenceUserRepository) EXPECT() *mockiAbsenceUserRepository_Expecter {
+	return &mockiAbsenceUserRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetByID provides a mock function for the type mockiAbsenceUserRepository
+func (_mock *mockiAbsenceUserRepository) GetByID(ctx context.Context, userID string) (domain.User, error) {
 	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReviewingPR")
+		panic("no return value specified for GetByID")
 	}
 
-	var r0 []domain.PullRequest
+	var r0 domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.PullRequest, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
 		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.PullRequest); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
 		r0 = returnFunc(ctx, userID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.PullRequest)
-		}
+		r0 = ret.Get(0).(domain.User)
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = returnFunc(ctx, userID)
@@ -488,19 +489,19 @@ func (_mock *mockiReviewRepository) GetReviewingPR(ctx context.Context, userID s
 	return r0, r1
 }
 
-// mockiReviewRepository_GetReviewingPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewingPR'
-type mockiReviewRepository_GetReviewingPR_Call struct {
+// mockiAbsenceUserRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type mockiAbsenceUserRepository_GetByID_Call struct {
 	*mock.Call
 }
 
-// GetReviewingPR is a helper method to define mock.On call
+// GetByID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-func (_e *mockiReviewRepository_Expecter) GetReviewingPR(ctx interface{}, userID interface{}) *mockiReviewRepository_GetReviewingPR_Call {
-	return &mockiReviewRepository_GetReviewingPR_Call{Call: _e.mock.On("GetReviewingPR", ctx, userID)}
+func (_e *mockiAbsenceUserRepository_Expecter) GetByID(ctx interface{}, userID interface{}) *mockiAbsenceUserRepository_GetByID_Call {
+	return &mockiAbsenceUserRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, userID)}
 }
 
-func (_c *mockiReviewRepository_GetReviewingPR_Call) Run(run func(ctx context.Context, userID string)) *mockiReviewRepository_GetReviewingPR_Call {
+func (_c *mockiAbsenceUserRepository_GetByID_Call) Run(run func(ctx context.Context, userID string)) *mockiAbsenceUserRepository_GetByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -518,48 +519,5223 @@ func (_c *mockiReviewRepository_GetReviewingPR_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *mockiReviewRepository_GetReviewingPR_Call) Return(pullRequests []domain.PullRequest, err error) *mockiReviewRepository_GetReviewingPR_Call {
-	_c.Call.Return(pullRequests, err)
+func (_c *mockiAbsenceUserRepository_GetByID_Call) Return(user domain.User, err error) *mockiAbsenceUserRepository_GetByID_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *mockiReviewRepository_GetReviewingPR_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]domain.PullRequest, error)) *mockiReviewRepository_GetReviewingPR_Call {
+func (_c *mockiAbsenceUserRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, userID string) (domain.User, error)) *mockiAbsenceUserRepository_GetByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Reassign provides a mock function for the type mockiReviewRepository
-func (_mock *mockiReviewRepository) Reassign(ctx context.Context, prID string, newReviewerID string, oldReviewerID string) error {
-	ret := _mock.Called(ctx, prID, newReviewerID, oldReviewerID)
+// newMockiAuditRecorder creates a new instance of mockiAuditRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiAuditRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiAuditRecorder {
+	mock := &mockiAuditRecorder{}
+	mock.Mock.Test(t)
 
-	if len(ret) == 0 {
-		panic("no return value specified for Reassign")
-	}
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiAuditRecorder is an autogenerated mock type for the iAuditRecorder type
+type mockiAuditRecorder struct {
+	mock.Mock
+}
+
+type mockiAuditRecorder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiAuditRecorder) EXPECT() *mockiAuditRecorder_Expecter {
+	return &mockiAuditRecorder_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function for the type mockiAuditRecorder
+func (_mock *mockiAuditRecorder) Add(ctx context.Context, event domain.AuditEvent) (domain.AuditEvent, error) {
+	ret := _mock.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 domain.AuditEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.AuditEvent) (domain.AuditEvent, error)); ok {
+		return returnFunc(ctx, event)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.AuditEvent) domain.AuditEvent); ok {
+		r0 = returnFunc(ctx, event)
+	} else {
+		r0 = ret.Get(0).(domain.AuditEvent)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.AuditEvent) error); ok {
+		r1 = returnFunc(ctx, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiAuditRecorder_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type mockiAuditRecorder_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event domain.AuditEvent
+func (_e *mockiAuditRecorder_Expecter) Add(ctx interface{}, event interface{}) *mockiAuditRecorder_Add_Call {
+	return &mockiAuditRecorder_Add_Call{Call: _e.mock.On("Add", ctx, event)}
+}
+
+func (_c *mockiAuditRecorder_Add_Call) Run(run func(ctx context.Context, event domain.AuditEvent)) *mockiAuditRecorder_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.AuditEvent
+		if args[1] != nil {
+			arg1 = args[1].(domain.AuditEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiAuditRecorder_Add_Call) Return(auditEvent domain.AuditEvent, err error) *mockiAuditRecorder_Add_Call {
+	_c.Call.Return(auditEvent, err)
+	return _c
+}
+
+func (_c *mockiAuditRecorder_Add_Call) RunAndReturn(run func(ctx context.Context, event domain.AuditEvent) (domain.AuditEvent, error)) *mockiAuditRecorder_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddBatch provides a mock function for the type mockiAuditRecorder
+func (_mock *mockiAuditRecorder) AddBatch(ctx context.Context, events []domain.AuditEvent) error {
+	ret := _mock.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBatch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.AuditEvent) error); ok {
+		r0 = returnFunc(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockiAuditRecorder_AddBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddBatch'
+type mockiAuditRecorder_AddBatch_Call struct {
+	*mock.Call
+}
+
+// AddBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []domain.AuditEvent
+func (_e *mockiAuditRecorder_Expecter) AddBatch(ctx interface{}, events interface{}) *mockiAuditRecorder_AddBatch_Call {
+	return &mockiAuditRecorder_AddBatch_Call{Call: _e.mock.On("AddBatch", ctx, events)}
+}
+
+func (_c *mockiAuditRecorder_AddBatch_Call) Run(run func(ctx context.Context, events []domain.AuditEvent)) *mockiAuditRecorder_AddBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []domain.AuditEvent
+		if args[1] != nil {
+			arg1 = args[1].([]domain.AuditEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiAuditRecorder_AddBatch_Call) Return(err error) *mockiAuditRecorder_AddBatch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiAuditRecorder_AddBatch_Call) RunAndReturn(run func(ctx context.Context, events []domain.AuditEvent) error) *mockiAuditRecorder_AddBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiAuditRepository creates a new instance of mockiAuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiAuditRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiAuditRepository {
+	mock := &mockiAuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiAuditRepository is an autogenerated mock type for the iAuditRepository type
+type mockiAuditRepository struct {
+	mock.Mock
+}
+
+type mockiAuditRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiAuditRepository) EXPECT() *mockiAuditRepository_Expecter {
+	return &mockiAuditRepository_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function for the type mockiAuditRepository
+func (_mock *mockiAuditRepository) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.AuditEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.AuditFilter) ([]domain.AuditEvent, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.AuditFilter) []domain.AuditEvent); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.AuditFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiAuditRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type mockiAuditRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.AuditFilter
+func (_e *mockiAuditRepository_Expecter) List(ctx interface{}, filter interface{}) *mockiAuditRepository_List_Call {
+	return &mockiAuditRepository_List_Call{Call: _e.mock.On("List", ctx, filter)}
+}
+
+func (_c *mockiAuditRepository_List_Call) Run(run func(ctx context.Context, filter domain.AuditFilter)) *mockiAuditRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.AuditFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.AuditFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiAuditRepository_List_Call) Return(auditEvents []domain.AuditEvent, err error) *mockiAuditRepository_List_Call {
+	_c.Call.Return(auditEvents, err)
+	return _c
+}
+
+func (_c *mockiAuditRepository_List_Call) RunAndReturn(run func(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error)) *mockiAuditRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiEventOutbox creates a new instance of mockiEventOutbox. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiEventOutbox(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiEventOutbox {
+	mock := &mockiEventOutbox{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiEventOutbox is an autogenerated mock type for the iEventOutbox type
+type mockiEventOutbox struct {
+	mock.Mock
+}
+
+type mockiEventOutbox_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiEventOutbox) EXPECT() *mockiEventOutbox_Expecter {
+	return &mockiEventOutbox_Expecter{mock: &_m.Mock}
+}
+
+// AddEvent provides a mock function for the type mockiEventOutbox
+func (_mock *mockiEventOutbox) AddEvent(ctx context.Context, event domain.OutboxEvent) (domain.OutboxEvent, error) {
+	ret := _mock.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEvent")
+	}
+
+	var r0 domain.OutboxEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.OutboxEvent) (domain.OutboxEvent, error)); ok {
+		return returnFunc(ctx, event)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.OutboxEvent) domain.OutboxEvent); ok {
+		r0 = returnFunc(ctx, event)
+	} else {
+		r0 = ret.Get(0).(domain.OutboxEvent)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.OutboxEvent) error); ok {
+		r1 = returnFunc(ctx, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiEventOutbox_AddEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddEvent'
+type mockiEventOutbox_AddEvent_Call struct {
+	*mock.Call
+}
+
+// AddEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event domain.OutboxEvent
+func (_e *mockiEventOutbox_Expecter) AddEvent(ctx interface{}, event interface{}) *mockiEventOutbox_AddEvent_Call {
+	return &mockiEventOutbox_AddEvent_Call{Call: _e.mock.On("AddEvent", ctx, event)}
+}
+
+func (_c *mockiEventOutbox_AddEvent_Call) Run(run func(ctx context.Context, event domain.OutboxEvent)) *mockiEventOutbox_AddEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.OutboxEvent
+		if args[1] != nil {
+			arg1 = args[1].(domain.OutboxEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiEventOutbox_AddEvent_Call) Return(outboxEvent domain.OutboxEvent, err error) *mockiEventOutbox_AddEvent_Call {
+	_c.Call.Return(outboxEvent, err)
+	return _c
+}
+
+func (_c *mockiEventOutbox_AddEvent_Call) RunAndReturn(run func(ctx context.Context, event domain.OutboxEvent) (domain.OutboxEvent, error)) *mockiEventOutbox_AddEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddEvents provides a mock function for the type mockiEventOutbox
+func (_mock *mockiEventOutbox) AddEvents(ctx context.Context, events []domain.OutboxEvent) error {
+	ret := _mock.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEvents")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.OutboxEvent) error); ok {
+		r0 = returnFunc(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockiEventOutbox_AddEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddEvents'
+type mockiEventOutbox_AddEvents_Call struct {
+	*mock.Call
+}
+
+// AddEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []domain.OutboxEvent
+func (_e *mockiEventOutbox_Expecter) AddEvents(ctx interface{}, events interface{}) *mockiEventOutbox_AddEvents_Call {
+	return &mockiEventOutbox_AddEvents_Call{Call: _e.mock.On("AddEvents", ctx, events)}
+}
+
+func (_c *mockiEventOutbox_AddEvents_Call) Run(run func(ctx context.Context, events []domain.OutboxEvent)) *mockiEventOutbox_AddEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []domain.OutboxEvent
+		if args[1] != nil {
+			arg1 = args[1].([]domain.OutboxEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiEventOutbox_AddEvents_Call) Return(err error) *mockiEventOutbox_AddEvents_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiEventOutbox_AddEvents_Call) RunAndReturn(run func(ctx context.Context, events []domain.OutboxEvent) error) *mockiEventOutbox_AddEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiExternalLoginRepository creates a new instance of mockiExternalLoginRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiExternalLoginRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiExternalLoginRepository {
+	mock := &mockiExternalLoginRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiExternalLoginRepository is an autogenerated mock type for the iExternalLoginRepository type
+type mockiExternalLoginRepository struct {
+	mock.Mock
+}
+
+type mockiExternalLoginRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiExternalLoginRepository) EXPECT() *mockiExternalLoginRepository_Expecter {
+	return &mockiExternalLoginRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type mockiExternalLoginRepository
+func (_mock *mockiExternalLoginRepository) Delete(ctx context.Context, provider domain.ExternalProvider, login string) error {
+	ret := _mock.Called(ctx, provider, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExternalProvider, string) error); ok {
+		r0 = returnFunc(ctx, provider, login)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockiExternalLoginRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type mockiExternalLoginRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider domain.ExternalProvider
+//   - login string
+func (_e *mockiExternalLoginRepository_Expecter) Delete(ctx interface{}, provider interface{}, login interface{}) *mockiExternalLoginRepository_Delete_Call {
+	return &mockiExternalLoginRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, provider, login)}
+}
+
+func (_c *mockiExternalLoginRepository_Delete_Call) Run(run func(ctx context.Context, provider domain.ExternalProvider, login string)) *mockiExternalLoginRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ExternalProvider
+		if args[1] != nil {
+			arg1 = args[1].(domain.ExternalProvider)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiExternalLoginRepository_Delete_Call) Return(err error) *mockiExternalLoginRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiExternalLoginRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, provider domain.ExternalProvider, login string) error) *mockiExternalLoginRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type mockiExternalLoginRepository
+func (_mock *mockiExternalLoginRepository) Get(ctx context.Context, provider domain.ExternalProvider, login string) (domain.ExternalLogin, error) {
+	ret := _mock.Called(ctx, provider, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 domain.ExternalLogin
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExternalProvider, string) (domain.ExternalLogin, error)); ok {
+		return returnFunc(ctx, provider, login)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExternalProvider, string) domain.ExternalLogin); ok {
+		r0 = returnFunc(ctx, provider, login)
+	} else {
+		r0 = ret.Get(0).(domain.ExternalLogin)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ExternalProvider, string) error); ok {
+		r1 = returnFunc(ctx, provider, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiExternalLoginRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type mockiExternalLoginRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider domain.ExternalProvider
+//   - login string
+func (_e *mockiExternalLoginRepository_Expecter) Get(ctx interface{}, provider interface{}, login interface{}) *mockiExternalLoginRepository_Get_Call {
+	return &mockiExternalLoginRepository_Get_Call{Call: _e.mock.On("Get", ctx, provider, login)}
+}
+
+func (_c *mockiExternalLoginRepository_Get_Call) Run(run func(ctx context.Context, provider domain.ExternalProvider, login string)) *mockiExternalLoginRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ExternalProvider
+		if args[1] != nil {
+			arg1 = args[1].(domain.ExternalProvider)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiExternalLoginRepository_Get_Call) Return(externalLogin domain.ExternalLogin, err error) *mockiExternalLoginRepository_Get_Call {
+	_c.Call.Return(externalLogin, err)
+	return _c
+}
+
+func (_c *mockiExternalLoginRepository_Get_Call) RunAndReturn(run func(ctx context.Context, provider domain.ExternalProvider, login string) (domain.ExternalLogin, error)) *mockiExternalLoginRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type mockiExternalLoginRepository
+func (_mock *mockiExternalLoginRepository) List(ctx context.Context, provider domain.ExternalProvider) ([]domain.ExternalLogin, error) {
+	ret := _mock.Called(ctx, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.ExternalLogin
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExternalProvider) ([]domain.ExternalLogin, error)); ok {
+		return returnFunc(ctx, provider)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExternalProvider) []domain.ExternalLogin); ok {
+		r0 = returnFunc(ctx, provider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ExternalLogin)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ExternalProvider) error); ok {
+		r1 = returnFunc(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiExternalLoginRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type mockiExternalLoginRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider domain.ExternalProvider
+func (_e *mockiExternalLoginRepository_Expecter) List(ctx interface{}, provider interface{}) *mockiExternalLoginRepository_List_Call {
+	return &mockiExternalLoginRepository_List_Call{Call: _e.mock.On("List", ctx, provider)}
+}
+
+func (_c *mockiExternalLoginRepository_List_Call) Run(run func(ctx context.Context, provider domain.ExternalProvider)) *mockiExternalLoginRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ExternalProvider
+		if args[1] != nil {
+			arg1 = args[1].(domain.ExternalProvider)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiExternalLoginRepository_List_Call) Return(externalLogins []domain.ExternalLogin, err error) *mockiExternalLoginRepository_List_Call {
+	_c.Call.Return(externalLogins, err)
+	return _c
+}
+
+func (_c *mockiExternalLoginRepository_List_Call) RunAndReturn(run func(ctx context.Context, provider domain.ExternalProvider) ([]domain.ExternalLogin, error)) *mockiExternalLoginRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function for the type mockiExternalLoginRepository
+func (_mock *mockiExternalLoginRepository) Set(ctx context.Context, login domain.ExternalLogin) (domain.ExternalLogin, error) {
+	ret := _mock.Called(ctx, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 domain.ExternalLogin
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExternalLogin) (domain.ExternalLogin, error)); ok {
+		return returnFunc(ctx, login)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExternalLogin) domain.ExternalLogin); ok {
+		r0 = returnFunc(ctx, login)
+	} else {
+		r0 = ret.Get(0).(domain.ExternalLogin)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ExternalLogin) error); ok {
+		r1 = returnFunc(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiExternalLoginRepository_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type mockiExternalLoginRepository_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login domain.ExternalLogin
+func (_e *mockiExternalLoginRepository_Expecter) Set(ctx interface{}, login interface{}) *mockiExternalLoginRepository_Set_Call {
+	return &mockiExternalLoginRepository_Set_Call{Call: _e.mock.On("Set", ctx, login)}
+}
+
+func (_c *mockiExternalLoginRepository_Set_Call) Run(run func(ctx context.Context, login domain.ExternalLogin)) *mockiExternalLoginRepository_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ExternalLogin
+		if args[1] != nil {
+			arg1 = args[1].(domain.ExternalLogin)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiExternalLoginRepository_Set_Call) Return(externalLogin domain.ExternalLogin, err error) *mockiExternalLoginRepository_Set_Call {
+	_c.Call.Return(externalLogin, err)
+	return _c
+}
+
+func (_c *mockiExternalLoginRepository_Set_Call) RunAndReturn(run func(ctx context.Context, login domain.ExternalLogin) (domain.ExternalLogin, error)) *mockiExternalLoginRepository_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiExternalPRService creates a new instance of mockiExternalPRService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiExternalPRService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiExternalPRService {
+	mock := &mockiExternalPRService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiExternalPRService is an autogenerated mock type for the iExternalPRService type
+type mockiExternalPRService struct {
+	mock.Mock
+}
+
+type mockiExternalPRService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiExternalPRService) EXPECT() *mockiExternalPRService_Expecter {
+	return &mockiExternalPRService_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function for the type mockiExternalPRService
+func (_mock *mockiExternalPRService) Close(ctx context.Context, prID string) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiExternalPRService_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type mockiExternalPRService_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *mockiExternalPRService_Expecter) Close(ctx interface{}, prID interface{}) *mockiExternalPRService_Close_Call {
+	return &mockiExternalPRService_Close_Call{Call: _e.mock.On("Close", ctx, prID)}
+}
+
+func (_c *mockiExternalPRService_Close_Call) Run(run func(ctx context.Context, prID string)) *mockiExternalPRService_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiExternalPRService_Close_Call) Return(pullRequest domain.PullRequest, err error) *mockiExternalPRService_Close_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *mockiExternalPRService_Close_Call) RunAndReturn(run func(ctx context.Context, prID string) (domain.PullRequest, error)) *mockiExternalPRService_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type mockiExternalPRService
+func (_mock *mockiExternalPRService) Create(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, pr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PullRequest) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, pr)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PullRequest) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, pr)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.PullRequest) error); ok {
+		r1 = returnFunc(ctx, pr)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiExternalPRService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type mockiExternalPRService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr domain.PullRequest
+func (_e *mockiExternalPRService_Expecter) Create(ctx interface{}, pr interface{}) *mockiExternalPRService_Create_Call {
+	return &mockiExternalPRService_Create_Call{Call: _e.mock.On("Create", ctx, pr)}
+}
+
+func (_c *mockiExternalPRService_Create_Call) Run(run func(ctx context.Context, pr domain.PullRequest)) *mockiExternalPRService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.PullRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.PullRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiExternalPRService_Create_Call) Return(pullRequest domain.PullRequest, err error) *mockiExternalPRService_Create_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *mockiExternalPRService_Create_Call) RunAndReturn(run func(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error)) *mockiExternalPRService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkReady provides a mock function for the type mockiExternalPRService
+func (_mock *mockiExternalPRService) MarkReady(ctx context.Context, prID string) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkReady")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiExternalPRService_MarkReady_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkReady'
+type mockiExternalPRService_MarkReady_Call struct {
+	*mock.Call
+}
+
+// MarkReady is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *mockiExternalPRService_Expecter) MarkReady(ctx interface{}, prID interface{}) *mockiExternalPRService_MarkReady_Call {
+	return &mockiExternalPRService_MarkReady_Call{Call: _e.mock.On("MarkReady", ctx, prID)}
+}
+
+func (_c *mockiExternalPRService_MarkReady_Call) Run(run func(ctx context.Context, prID string)) *mockiExternalPRService_MarkReady_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiExternalPRService_MarkReady_Call) Return(pullRequest domain.PullRequest, err error) *mockiExternalPRService_MarkReady_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *mockiExternalPRService_MarkReady_Call) RunAndReturn(run func(ctx context.Context, prID string) (domain.PullRequest, error)) *mockiExternalPRService_MarkReady_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function for the type mockiExternalPRService
+func (_mock *mockiExternalPRService) Merge(ctx context.Context, prID string, force bool) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID, force)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID, force)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID, force)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = returnFunc(ctx, prID, force)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiExternalPRService_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type mockiExternalPRService_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - force bool
+func (_e *mockiExternalPRService_Expecter) Merge(ctx interface{}, prID interface{}, force interface{}) *mockiExternalPRService_Merge_Call {
+	return &mockiExternalPRService_Merge_Call{Call: _e.mock.On("Merge", ctx, prID, force)}
+}
+
+func (_c *mockiExternalPRService_Merge_Call) Run(run func(ctx context.Context, prID string, force bool)) *mockiExternalPRService_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiExternalPRService_Merge_Call) Return(pullRequest domain.PullRequest, err error) *mockiExternalPRService_Merge_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *mockiExternalPRService_Merge_Call) RunAndReturn(run func(ctx context.Context, prID string, force bool) (domain.PullRequest, error)) *mockiExternalPRService_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reopen provides a mock function for the type mockiExternalPRService
+func (_mock *mockiExternalPRService) Reopen(ctx context.Context, prID string) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reopen")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiExternalPRService_Reopen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reopen'
+type mockiExternalPRService_Reopen_Call struct {
+	*mock.Call
+}
+
+// Reopen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *mockiExternalPRService_Expecter) Reopen(ctx interface{}, prID interface{}) *mockiExternalPRService_Reopen_Call {
+	return &mockiExternalPRService_Reopen_Call{Call: _e.mock.On("Reopen", ctx, prID)}
+}
+
+func (_c *mockiExternalPRService_Reopen_Call) Run(run func(ctx context.Context, prID string)) *mockiExternalPRService_Reopen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiExternalPRService_Reopen_Call) Return(pullRequest domain.PullRequest, err error) *mockiExternalPRService_Reopen_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *mockiExternalPRService_Reopen_Call) RunAndReturn(run func(ctx context.Context, prID string) (domain.PullRequest, error)) *mockiExternalPRService_Reopen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiPullRequestRepository creates a new instance of mockiPullRequestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiPullRequestRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiPullRequestRepository {
+	mock := &mockiPullRequestRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiPullRequestRepository is an autogenerated mock type for the iPullRequestRepository type
+type mockiPullRequestRepository struct {
+	mock.Mock
+}
+
+type mockiPullRequestRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiPullRequestRepository) EXPECT() *mockiPullRequestRepository_Expecter {
+	return &mockiPullRequestRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type mockiPullRequestRepository
+func (_mock *mockiPullRequestRepository) Create(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, pr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PullRequest) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, pr)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PullRequest) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, pr)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.PullRequest) error); ok {
+		r1 = returnFunc(ctx, pr)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPullRequestRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type mockiPullRequestRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr domain.PullRequest
+func (_e *mockiPullRequestRepository_Expecter) Create(ctx interface{}, pr interface{}) *mockiPullRequestRepository_Create_Call {
+	return &mockiPullRequestRepository_Create_Call{Call: _e.mock.On("Create", ctx, pr)}
+}
+
+func (_c *mockiPullRequestRepository_Create_Call) Run(run func(ctx context.Context, pr domain.PullRequest)) *mockiPullRequestRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.PullRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.PullRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_Create_Call) Return(pullRequest domain.PullRequest, err error) *mockiPullRequestRepository_Create_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_Create_Call) RunAndReturn(run func(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error)) *mockiPullRequestRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exists provides a mock function for the type mockiPullRequestRepository
+func (_mock *mockiPullRequestRepository) Exists(ctx context.Context, prID string) (bool, error) {
+	ret := _mock.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, prID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPullRequestRepository_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type mockiPullRequestRepository_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *mockiPullRequestRepository_Expecter) Exists(ctx interface{}, prID interface{}) *mockiPullRequestRepository_Exists_Call {
+	return &mockiPullRequestRepository_Exists_Call{Call: _e.mock.On("Exists", ctx, prID)}
+}
+
+func (_c *mockiPullRequestRepository_Exists_Call) Run(run func(ctx context.Context, prID string)) *mockiPullRequestRepository_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_Exists_Call) Return(b bool, err error) *mockiPullRequestRepository_Exists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_Exists_Call) RunAndReturn(run func(ctx context.Context, prID string) (bool, error)) *mockiPullRequestRepository_Exists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type mockiPullRequestRepository
+func (_mock *mockiPullRequestRepository) GetByID(ctx context.Context, prID string) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPullRequestRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type mockiPullRequestRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *mockiPullRequestRepository_Expecter) GetByID(ctx interface{}, prID interface{}) *mockiPullRequestRepository_GetByID_Call {
+	return &mockiPullRequestRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, prID)}
+}
+
+func (_c *mockiPullRequestRepository_GetByID_Call) Run(run func(ctx context.Context, prID string)) *mockiPullRequestRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_GetByID_Call) Return(pullRequest domain.PullRequest, err error) *mockiPullRequestRepository_GetByID_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, prID string) (domain.PullRequest, error)) *mockiPullRequestRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDForUpdate provides a mock function for the type mockiPullRequestRepository
+func (_mock *mockiPullRequestRepository) GetByIDForUpdate(ctx context.Context, prID string) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDForUpdate")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPullRequestRepository_GetByIDForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDForUpdate'
+type mockiPullRequestRepository_GetByIDForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetByIDForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *mockiPullRequestRepository_Expecter) GetByIDForUpdate(ctx interface{}, prID interface{}) *mockiPullRequestRepository_GetByIDForUpdate_Call {
+	return &mockiPullRequestRepository_GetByIDForUpdate_Call{Call: _e.mock.On("GetByIDForUpdate", ctx, prID)}
+}
+
+func (_c *mockiPullRequestRepository_GetByIDForUpdate_Call) Run(run func(ctx context.Context, prID string)) *mockiPullRequestRepository_GetByIDForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_GetByIDForUpdate_Call) Return(pullRequest domain.PullRequest, err error) *mockiPullRequestRepository_GetByIDForUpdate_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_GetByIDForUpdate_Call) RunAndReturn(run func(ctx context.Context, prID string) (domain.PullRequest, error)) *mockiPullRequestRepository_GetByIDForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNeedingReviewers provides a mock function for the type mockiPullRequestRepository
+func (_mock *mockiPullRequestRepository) GetNeedingReviewers(ctx context.Context, teamName string) ([]domain.PullRequest, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNeedingReviewers")
+	}
+
+	var r0 []domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.PullRequest, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.PullRequest); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PullRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPullRequestRepository_GetNeedingReviewers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNeedingReviewers'
+type mockiPullRequestRepository_GetNeedingReviewers_Call struct {
+	*mock.Call
+}
+
+// GetNeedingReviewers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *mockiPullRequestRepository_Expecter) GetNeedingReviewers(ctx interface{}, teamName interface{}) *mockiPullRequestRepository_GetNeedingReviewers_Call {
+	return &mockiPullRequestRepository_GetNeedingReviewers_Call{Call: _e.mock.On("GetNeedingReviewers", ctx, teamName)}
+}
+
+func (_c *mockiPullRequestRepository_GetNeedingReviewers_Call) Run(run func(ctx context.Context, teamName string)) *mockiPullRequestRepository_GetNeedingReviewers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_GetNeedingReviewers_Call) Return(pullRequests []domain.PullRequest, err error) *mockiPullRequestRepository_GetNeedingReviewers_Call {
+	_c.Call.Return(pullRequests, err)
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_GetNeedingReviewers_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]domain.PullRequest, error)) *mockiPullRequestRepository_GetNeedingReviewers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenByReviewerIDs provides a mock function for the type mockiPullRequestRepository
+func (_mock *mockiPullRequestRepository) GetOpenByReviewerIDs(ctx context.Context, reviewerIDs []string, authorTeam string) ([]domain.PullRequest, error) {
+	ret := _mock.Called(ctx, reviewerIDs, authorTeam)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenByReviewerIDs")
+	}
+
+	var r0 []domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string) ([]domain.PullRequest, error)); ok {
+		return returnFunc(ctx, reviewerIDs, authorTeam)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string) []domain.PullRequest); ok {
+		r0 = returnFunc(ctx, reviewerIDs, authorTeam)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PullRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = returnFunc(ctx, reviewerIDs, authorTeam)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPullRequestRepository_GetOpenByReviewerIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenByReviewerIDs'
+type mockiPullRequestRepository_GetOpenByReviewerIDs_Call struct {
+	*mock.Call
+}
+
+// GetOpenByReviewerIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reviewerIDs []string
+//   - authorTeam string
+func (_e *mockiPullRequestRepository_Expecter) GetOpenByReviewerIDs(ctx interface{}, reviewerIDs interface{}, authorTeam interface{}) *mockiPullRequestRepository_GetOpenByReviewerIDs_Call {
+	return &mockiPullRequestRepository_GetOpenByReviewerIDs_Call{Call: _e.mock.On("GetOpenByReviewerIDs", ctx, reviewerIDs, authorTeam)}
+}
+
+func (_c *mockiPullRequestRepository_GetOpenByReviewerIDs_Call) Run(run func(ctx context.Context, reviewerIDs []string, authorTeam string)) *mockiPullRequestRepository_GetOpenByReviewerIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_GetOpenByReviewerIDs_Call) Return(pullRequests []domain.PullRequest, err error) *mockiPullRequestRepository_GetOpenByReviewerIDs_Call {
+	_c.Call.Return(pullRequests, err)
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_GetOpenByReviewerIDs_Call) RunAndReturn(run func(ctx context.Context, reviewerIDs []string, authorTeam string) ([]domain.PullRequest, error)) *mockiPullRequestRepository_GetOpenByReviewerIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type mockiPullRequestRepository
+func (_mock *mockiPullRequestRepository) List(ctx context.Context, filter domain.PullRequestFilter) ([]domain.PullRequest, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PullRequestFilter) ([]domain.PullRequest, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PullRequestFilter) []domain.PullRequest); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PullRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.PullRequestFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPullRequestRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type mockiPullRequestRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.PullRequestFilter
+func (_e *mockiPullRequestRepository_Expecter) List(ctx interface{}, filter interface{}) *mockiPullRequestRepository_List_Call {
+	return &mockiPullRequestRepository_List_Call{Call: _e.mock.On("List", ctx, filter)}
+}
+
+func (_c *mockiPullRequestRepository_List_Call) Run(run func(ctx context.Context, filter domain.PullRequestFilter)) *mockiPullRequestRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.PullRequestFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.PullRequestFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_List_Call) Return(pullRequests []domain.PullRequest, err error) *mockiPullRequestRepository_List_Call {
+	_c.Call.Return(pullRequests, err)
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_List_Call) RunAndReturn(run func(ctx context.Context, filter domain.PullRequestFilter) ([]domain.PullRequest, error)) *mockiPullRequestRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function for the type mockiPullRequestRepository
+func (_mock *mockiPullRequestRepository) Merge(ctx context.Context, prID string) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPullRequestRepository_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type mockiPullRequestRepository_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *mockiPullRequestRepository_Expecter) Merge(ctx interface{}, prID interface{}) *mockiPullRequestRepository_Merge_Call {
+	return &mockiPullRequestRepository_Merge_Call{Call: _e.mock.On("Merge", ctx, prID)}
+}
+
+func (_c *mockiPullRequestRepository_Merge_Call) Run(run func(ctx context.Context, prID string)) *mockiPullRequestRepository_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_Merge_Call) Return(pullRequest domain.PullRequest, err error) *mockiPullRequestRepository_Merge_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_Merge_Call) RunAndReturn(run func(ctx context.Context, prID string) (domain.PullRequest, error)) *mockiPullRequestRepository_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNeedMoreReviewers provides a mock function for the type mockiPullRequestRepository
+func (_mock *mockiPullRequestRepository) SetNeedMoreReviewers(ctx context.Context, prID string, needMore bool) error {
+	ret := _mock.Called(ctx, prID, needMore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNeedMoreReviewers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = returnFunc(ctx, prID, needMore)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockiPullRequestRepository_SetNeedMoreReviewers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNeedMoreReviewers'
+type mockiPullRequestRepository_SetNeedMoreReviewers_Call struct {
+	*mock.Call
+}
+
+// SetNeedMoreReviewers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - needMore bool
+func (_e *mockiPullRequestRepository_Expecter) SetNeedMoreReviewers(ctx interface{}, prID interface{}, needMore interface{}) *mockiPullRequestRepository_SetNeedMoreReviewers_Call {
+	return &mockiPullRequestRepository_SetNeedMoreReviewers_Call{Call: _e.mock.On("SetNeedMoreReviewers", ctx, prID, needMore)}
+}
+
+func (_c *mockiPullRequestRepository_SetNeedMoreReviewers_Call) Run(run func(ctx context.Context, prID string, needMore bool)) *mockiPullRequestRepository_SetNeedMoreReviewers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_SetNeedMoreReviewers_Call) Return(err error) *mockiPullRequestRepository_SetNeedMoreReviewers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_SetNeedMoreReviewers_Call) RunAndReturn(run func(ctx context.Context, prID string, needMore bool) error) *mockiPullRequestRepository_SetNeedMoreReviewers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetStatus provides a mock function for the type mockiPullRequestRepository
+func (_mock *mockiPullRequestRepository) SetStatus(ctx context.Context, prID string, status domain.PRStatus) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatus")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.PRStatus) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID, status)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.PRStatus) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID, status)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.PRStatus) error); ok {
+		r1 = returnFunc(ctx, prID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPullRequestRepository_SetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStatus'
+type mockiPullRequestRepository_SetStatus_Call struct {
+	*mock.Call
+}
+
+// SetStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - status domain.PRStatus
+func (_e *mockiPullRequestRepository_Expecter) SetStatus(ctx interface{}, prID interface{}, status interface{}) *mockiPullRequestRepository_SetStatus_Call {
+	return &mockiPullRequestRepository_SetStatus_Call{Call: _e.mock.On("SetStatus", ctx, prID, status)}
+}
+
+func (_c *mockiPullRequestRepository_SetStatus_Call) Run(run func(ctx context.Context, prID string, status domain.PRStatus)) *mockiPullRequestRepository_SetStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.PRStatus
+		if args[2] != nil {
+			arg2 = args[2].(domain.PRStatus)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_SetStatus_Call) Return(pullRequest domain.PullRequest, err error) *mockiPullRequestRepository_SetStatus_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *mockiPullRequestRepository_SetStatus_Call) RunAndReturn(run func(ctx context.Context, prID string, status domain.PRStatus) (domain.PullRequest, error)) *mockiPullRequestRepository_SetStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiReviewRepository creates a new instance of mockiReviewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiReviewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiReviewRepository {
+	mock := &mockiReviewRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiReviewRepository is an autogenerated mock type for the iReviewRepository type
+type mockiReviewRepository struct {
+	mock.Mock
+}
+
+type mockiReviewRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiReviewRepository) EXPECT() *mockiReviewRepository_Expecter {
+	return &mockiReviewRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddReview provides a mock function for the type mockiReviewRepository
+func (_mock *mockiReviewRepository) AddReview(ctx context.Context, review domain.Review) (domain.Review, error) {
+	ret := _mock.Called(ctx, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReview")
+	}
+
+	var r0 domain.Review
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Review) (domain.Review, error)); ok {
+		return returnFunc(ctx, review)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Review) domain.Review); ok {
+		r0 = returnFunc(ctx, review)
+	} else {
+		r0 = ret.Get(0).(domain.Review)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Review) error); ok {
+		r1 = returnFunc(ctx, review)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewRepository_AddReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReview'
+type mockiReviewRepository_AddReview_Call struct {
+	*mock.Call
+}
+
+// AddReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - review domain.Review
+func (_e *mockiReviewRepository_Expecter) AddReview(ctx interface{}, review interface{}) *mockiReviewRepository_AddReview_Call {
+	return &mockiReviewRepository_AddReview_Call{Call: _e.mock.On("AddReview", ctx, review)}
+}
+
+func (_c *mockiReviewRepository_AddReview_Call) Run(run func(ctx context.Context, review domain.Review)) *mockiReviewRepository_AddReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Review
+		if args[1] != nil {
+			arg1 = args[1].(domain.Review)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewRepository_AddReview_Call) Return(review1 domain.Review, err error) *mockiReviewRepository_AddReview_Call {
+	_c.Call.Return(review1, err)
+	return _c
+}
+
+func (_c *mockiReviewRepository_AddReview_Call) RunAndReturn(run func(ctx context.Context, review domain.Review) (domain.Review, error)) *mockiReviewRepository_AddReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AssignToPR provides a mock function for the type mockiReviewRepository
+func (_mock *mockiReviewRepository) AssignToPR(ctx context.Context, prID string, pool domain.ReviewerPool, reviewerIDs []string) error {
+	ret := _mock.Called(ctx, prID, pool, reviewerIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignToPR")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.ReviewerPool, []string) error); ok {
+		r0 = returnFunc(ctx, prID, pool, reviewerIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockiReviewRepository_AssignToPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignToPR'
+type mockiReviewRepository_AssignToPR_Call struct {
+	*mock.Call
+}
+
+// AssignToPR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - pool domain.ReviewerPool
+//   - reviewerIDs []string
+func (_e *mockiReviewRepository_Expecter) AssignToPR(ctx interface{}, prID interface{}, pool interface{}, reviewerIDs interface{}) *mockiReviewRepository_AssignToPR_Call {
+	return &mockiReviewRepository_AssignToPR_Call{Call: _e.mock.On("AssignToPR", ctx, prID, pool, reviewerIDs)}
+}
+
+func (_c *mockiReviewRepository_AssignToPR_Call) Run(run func(ctx context.Context, prID string, pool domain.ReviewerPool, reviewerIDs []string)) *mockiReviewRepository_AssignToPR_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.ReviewerPool
+		if args[2] != nil {
+			arg2 = args[2].(domain.ReviewerPool)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewRepository_AssignToPR_Call) Return(err error) *mockiReviewRepository_AssignToPR_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiReviewRepository_AssignToPR_Call) RunAndReturn(run func(ctx context.Context, prID string, pool domain.ReviewerPool, reviewerIDs []string) error) *mockiReviewRepository_AssignToPR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountOpenReviews provides a mock function for the type mockiReviewRepository
+func (_mock *mockiReviewRepository) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	ret := _mock.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOpenReviews")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (map[string]int, error)); ok {
+		return returnFunc(ctx, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) map[string]int); ok {
+		r0 = returnFunc(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewRepository_CountOpenReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOpenReviews'
+type mockiReviewRepository_CountOpenReviews_Call struct {
+	*mock.Call
+}
+
+// CountOpenReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *mockiReviewRepository_Expecter) CountOpenReviews(ctx interface{}, userIDs interface{}) *mockiReviewRepository_CountOpenReviews_Call {
+	return &mockiReviewRepository_CountOpenReviews_Call{Call: _e.mock.On("CountOpenReviews", ctx, userIDs)}
+}
+
+func (_c *mockiReviewRepository_CountOpenReviews_Call) Run(run func(ctx context.Context, userIDs []string)) *mockiReviewRepository_CountOpenReviews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewRepository_CountOpenReviews_Call) Return(sToInt map[string]int, err error) *mockiReviewRepository_CountOpenReviews_Call {
+	_c.Call.Return(sToInt, err)
+	return _c
+}
+
+func (_c *mockiReviewRepository_CountOpenReviews_Call) RunAndReturn(run func(ctx context.Context, userIDs []string) (map[string]int, error)) *mockiReviewRepository_CountOpenReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByPRID provides a mock function for the type mockiReviewRepository
+func (_mock *mockiReviewRepository) GetByPRID(ctx context.Context, prID string) ([]domain.User, error) {
+	ret := _mock.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByPRID")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.User, error)); ok {
+		return returnFunc(ctx, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.User); ok {
+		r0 = returnFunc(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewRepository_GetByPRID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByPRID'
+type mockiReviewRepository_GetByPRID_Call struct {
+	*mock.Call
+}
+
+// GetByPRID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *mockiReviewRepository_Expecter) GetByPRID(ctx interface{}, prID interface{}) *mockiReviewRepository_GetByPRID_Call {
+	return &mockiReviewRepository_GetByPRID_Call{Call: _e.mock.On("GetByPRID", ctx, prID)}
+}
+
+func (_c *mockiReviewRepository_GetByPRID_Call) Run(run func(ctx context.Context, prID string)) *mockiReviewRepository_GetByPRID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewRepository_GetByPRID_Call) Return(users []domain.User, err error) *mockiReviewRepository_GetByPRID_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *mockiReviewRepository_GetByPRID_Call) RunAndReturn(run func(ctx context.Context, prID string) ([]domain.User, error)) *mockiReviewRepository_GetByPRID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPoolsByPRID provides a mock function for the type mockiReviewRepository
+func (_mock *mockiReviewRepository) GetPoolsByPRID(ctx context.Context, prID string) (map[string]domain.ReviewerPool, error) {
+	ret := _mock.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPoolsByPRID")
+	}
+
+	var r0 map[string]domain.ReviewerPool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (map[string]domain.ReviewerPool, error)); ok {
+		return returnFunc(ctx, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) map[string]domain.ReviewerPool); ok {
+		r0 = returnFunc(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.ReviewerPool)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewRepository_GetPoolsByPRID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPoolsByPRID'
+type mockiReviewRepository_GetPoolsByPRID_Call struct {
+	*mock.Call
+}
+
+// GetPoolsByPRID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *mockiReviewRepository_Expecter) GetPoolsByPRID(ctx interface{}, prID interface{}) *mockiReviewRepository_GetPoolsByPRID_Call {
+	return &mockiReviewRepository_GetPoolsByPRID_Call{Call: _e.mock.On("GetPoolsByPRID", ctx, prID)}
+}
+
+func (_c *mockiReviewRepository_GetPoolsByPRID_Call) Run(run func(ctx context.Context, prID string)) *mockiReviewRepository_GetPoolsByPRID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewRepository_GetPoolsByPRID_Call) Return(sToReviewerPool map[string]domain.ReviewerPool, err error) *mockiReviewRepository_GetPoolsByPRID_Call {
+	_c.Call.Return(sToReviewerPool, err)
+	return _c
+}
+
+func (_c *mockiReviewRepository_GetPoolsByPRID_Call) RunAndReturn(run func(ctx context.Context, prID string) (map[string]domain.ReviewerPool, error)) *mockiReviewRepository_GetPoolsByPRID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReviewingPR provides a mock function for the type mockiReviewRepository
+func (_mock *mockiReviewRepository) GetReviewingPR(ctx context.Context, userID string) ([]domain.PullRequest, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewingPR")
+	}
+
+	var r0 []domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.PullRequest, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.PullRequest); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PullRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewRepository_GetReviewingPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewingPR'
+type mockiReviewRepository_GetReviewingPR_Call struct {
+	*mock.Call
+}
+
+// GetReviewingPR is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *mockiReviewRepository_Expecter) GetReviewingPR(ctx interface{}, userID interface{}) *mockiReviewRepository_GetReviewingPR_Call {
+	return &mockiReviewRepository_GetReviewingPR_Call{Call: _e.mock.On("GetReviewingPR", ctx, userID)}
+}
+
+func (_c *mockiReviewRepository_GetReviewingPR_Call) Run(run func(ctx context.Context, userID string)) *mockiReviewRepository_GetReviewingPR_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewRepository_GetReviewingPR_Call) Return(pullRequests []domain.PullRequest, err error) *mockiReviewRepository_GetReviewingPR_Call {
+	_c.Call.Return(pullRequests, err)
+	return _c
+}
+
+func (_c *mockiReviewRepository_GetReviewingPR_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]domain.PullRequest, error)) *mockiReviewRepository_GetReviewingPR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReviewsByPRID provides a mock function for the type mockiReviewRepository
+func (_mock *mockiReviewRepository) GetReviewsByPRID(ctx context.Context, prID string) ([]domain.Review, error) {
+	ret := _mock.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewsByPRID")
+	}
+
+	var r0 []domain.Review
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.Review, error)); ok {
+		return returnFunc(ctx, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.Review); ok {
+		r0 = returnFunc(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Review)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewRepository_GetReviewsByPRID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewsByPRID'
+type mockiReviewRepository_GetReviewsByPRID_Call struct {
+	*mock.Call
+}
+
+// GetReviewsByPRID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *mockiReviewRepository_Expecter) GetReviewsByPRID(ctx interface{}, prID interface{}) *mockiReviewRepository_GetReviewsByPRID_Call {
+	return &mockiReviewRepository_GetReviewsByPRID_Call{Call: _e.mock.On("GetReviewsByPRID", ctx, prID)}
+}
+
+func (_c *mockiReviewRepository_GetReviewsByPRID_Call) Run(run func(ctx context.Context, prID string)) *mockiReviewRepository_GetReviewsByPRID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewRepository_GetReviewsByPRID_Call) Return(reviews []domain.Review, err error) *mockiReviewRepository_GetReviewsByPRID_Call {
+	_c.Call.Return(reviews, err)
+	return _c
+}
+
+func (_c *mockiReviewRepository_GetReviewsByPRID_Call) RunAndReturn(run func(ctx context.Context, prID string) ([]domain.Review, error)) *mockiReviewRepository_GetReviewsByPRID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandOver provides a mock function for the type mockiReviewRepository
+func (_mock *mockiReviewRepository) HandOver(ctx context.Context, handovers []domain.ReviewerHandover) error {
+	ret := _mock.Called(ctx, handovers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandOver")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.ReviewerHandover) error); ok {
+		r0 = returnFunc(ctx, handovers)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockiReviewRepository_HandOver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandOver'
+type mockiReviewRepository_HandOver_Call struct {
+	*mock.Call
+}
+
+// HandOver is a helper method to define mock.On call
+//   - ctx context.Context
+//   - handovers []domain.ReviewerHandover
+func (_e *mockiReviewRepository_Expecter) HandOver(ctx interface{}, handovers interface{}) *mockiReviewRepository_HandOver_Call {
+	return &mockiReviewRepository_HandOver_Call{Call: _e.mock.On("HandOver", ctx, handovers)}
+}
+
+func (_c *mockiReviewRepository_HandOver_Call) Run(run func(ctx context.Context, handovers []domain.ReviewerHandover)) *mockiReviewRepository_HandOver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []domain.ReviewerHandover
+		if args[1] != nil {
+			arg1 = args[1].([]domain.ReviewerHandover)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewRepository_HandOver_Call) Return(err error) *mockiReviewRepository_HandOver_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiReviewRepository_HandOver_Call) RunAndReturn(run func(ctx context.Context, handovers []domain.ReviewerHandover) error) *mockiReviewRepository_HandOver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reassign provides a mock function for the type mockiReviewRepository
+func (_mock *mockiReviewRepository) Reassign(ctx context.Context, prID string, pool domain.ReviewerPool, newReviewerID string, oldReviewerID string) error {
+	ret := _mock.Called(ctx, prID, pool, newReviewerID, oldReviewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reassign")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.ReviewerPool, string, string) error); ok {
+		r0 = returnFunc(ctx, prID, pool, newReviewerID, oldReviewerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockiReviewRepository_Reassign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reassign'
+type mockiReviewRepository_Reassign_Call struct {
+	*mock.Call
+}
+
+// Reassign is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - pool domain.ReviewerPool
+//   - newReviewerID string
+//   - oldReviewerID string
+func (_e *mockiReviewRepository_Expecter) Reassign(ctx interface{}, prID interface{}, pool interface{}, newReviewerID interface{}, oldReviewerID interface{}) *mockiReviewRepository_Reassign_Call {
+	return &mockiReviewRepository_Reassign_Call{Call: _e.mock.On("Reassign", ctx, prID, pool, newReviewerID, oldReviewerID)}
+}
+
+func (_c *mockiReviewRepository_Reassign_Call) Run(run func(ctx context.Context, prID string, pool domain.ReviewerPool, newReviewerID string, oldReviewerID string)) *mockiReviewRepository_Reassign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.ReviewerPool
+		if args[2] != nil {
+			arg2 = args[2].(domain.ReviewerPool)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewRepository_Reassign_Call) Return(err error) *mockiReviewRepository_Reassign_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiReviewRepository_Reassign_Call) RunAndReturn(run func(ctx context.Context, prID string, pool domain.ReviewerPool, newReviewerID string, oldReviewerID string) error) *mockiReviewRepository_Reassign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unassign provides a mock function for the type mockiReviewRepository
+func (_mock *mockiReviewRepository) Unassign(ctx context.Context, prID string, reviewerID string) error {
+	ret := _mock.Called(ctx, prID, reviewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unassign")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, prID, reviewerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockiReviewRepository_Unassign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unassign'
+type mockiReviewRepository_Unassign_Call struct {
+	*mock.Call
+}
+
+// Unassign is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - reviewerID string
+func (_e *mockiReviewRepository_Expecter) Unassign(ctx interface{}, prID interface{}, reviewerID interface{}) *mockiReviewRepository_Unassign_Call {
+	return &mockiReviewRepository_Unassign_Call{Call: _e.mock.On("Unassign", ctx, prID, reviewerID)}
+}
+
+func (_c *mockiReviewRepository_Unassign_Call) Run(run func(ctx context.Context, prID string, reviewerID string)) *mockiReviewRepository_Unassign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewRepository_Unassign_Call) Return(err error) *mockiReviewRepository_Unassign_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiReviewRepository_Unassign_Call) RunAndReturn(run func(ctx context.Context, prID string, reviewerID string) error) *mockiReviewRepository_Unassign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiTransactor creates a new instance of mockiTransactor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiTransactor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiTransactor {
+	mock := &mockiTransactor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiTransactor is an autogenerated mock type for the iTransactor type
+type mockiTransactor struct {
+	mock.Mock
+}
+
+type mockiTransactor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiTransactor) EXPECT() *mockiTransactor_Expecter {
+	return &mockiTransactor_Expecter{mock: &_m.Mock}
+}
+
+// AfterCommit provides a mock function for the type mockiTransactor
+func (_mock *mockiTransactor) AfterCommit(ctx context.Context, fn func()) {
+	_mock.Called(ctx, fn)
+	return
+}
+
+// mockiTransactor_AfterCommit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AfterCommit'
+type mockiTransactor_AfterCommit_Call struct {
+	*mock.Call
+}
+
+// AfterCommit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func()
+func (_e *mockiTransactor_Expecter) AfterCommit(ctx interface{}, fn interface{}) *mockiTransactor_AfterCommit_Call {
+	return &mockiTransactor_AfterCommit_Call{Call: _e.mock.On("AfterCommit", ctx, fn)}
+}
+
+func (_c *mockiTransactor_AfterCommit_Call) Run(run func(ctx context.Context, fn func())) *mockiTransactor_AfterCommit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func()
+		if args[1] != nil {
+			arg1 = args[1].(func())
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTransactor_AfterCommit_Call) Return() *mockiTransactor_AfterCommit_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockiTransactor_AfterCommit_Call) RunAndReturn(run func(ctx context.Context, fn func())) *mockiTransactor_AfterCommit_Call {
+	_c.Run(run)
+	return _c
+}
+
+// WithinTransaction provides a mock function for the type mockiTransactor
+func (_mock *mockiTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithinTransaction")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(ctx context.Context) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockiTransactor_WithinTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithinTransaction'
+type mockiTransactor_WithinTransaction_Call struct {
+	*mock.Call
+}
+
+// WithinTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(ctx context.Context) error
+func (_e *mockiTransactor_Expecter) WithinTransaction(ctx interface{}, fn interface{}) *mockiTransactor_WithinTransaction_Call {
+	return &mockiTransactor_WithinTransaction_Call{Call: _e.mock.On("WithinTransaction", ctx, fn)}
+}
+
+func (_c *mockiTransactor_WithinTransaction_Call) Run(run func(ctx context.Context, fn func(ctx context.Context) error)) *mockiTransactor_WithinTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(ctx context.Context) error
+		if args[1] != nil {
+			arg1 = args[1].(func(ctx context.Context) error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTransactor_WithinTransaction_Call) Return(err error) *mockiTransactor_WithinTransaction_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiTransactor_WithinTransaction_Call) RunAndReturn(run func(ctx context.Context, fn func(ctx context.Context) error) error) *mockiTransactor_WithinTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiPRUserRepository creates a new instance of mockiPRUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiPRUserRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiPRUserRepository {
+	mock := &mockiPRUserRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiPRUserRepository is an autogenerated mock type for the iPRUserRepository type
+type mockiPRUserRepository struct {
+	mock.Mock
+}
+
+type mockiPRUserRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiPRUserRepository) EXPECT() *mockiPRUserRepository_Expecter {
+	return &mockiPRUserRepository_Expecter{mock: &_m.Mock}
+}
+
+// ExistsByID provides a mock function for the type mockiPRUserRepository
+func (_mock *mockiPRUserRepository) ExistsByID(ctx context.Context, userID string) (bool, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExistsByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPRUserRepository_ExistsByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistsByID'
+type mockiPRUserRepository_ExistsByID_Call struct {
+	*mock.Call
+}
+
+// ExistsByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *mockiPRUserRepository_Expecter) ExistsByID(ctx interface{}, userID interface{}) *mockiPRUserRepository_ExistsByID_Call {
+	return &mockiPRUserRepository_ExistsByID_Call{Call: _e.mock.On("ExistsByID", ctx, userID)}
+}
+
+func (_c *mockiPRUserRepository_ExistsByID_Call) Run(run func(ctx context.Context, userID string)) *mockiPRUserRepository_ExistsByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPRUserRepository_ExistsByID_Call) Return(b bool, err error) *mockiPRUserRepository_ExistsByID_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *mockiPRUserRepository_ExistsByID_Call) RunAndReturn(run func(ctx context.Context, userID string) (bool, error)) *mockiPRUserRepository_ExistsByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveByTeamName provides a mock function for the type mockiPRUserRepository
+func (_mock *mockiPRUserRepository) GetActiveByTeamName(ctx context.Context, teamName string) ([]domain.User, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveByTeamName")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.User, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.User); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPRUserRepository_GetActiveByTeamName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveByTeamName'
+type mockiPRUserRepository_GetActiveByTeamName_Call struct {
+	*mock.Call
+}
+
+// GetActiveByTeamName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *mockiPRUserRepository_Expecter) GetActiveByTeamName(ctx interface{}, teamName interface{}) *mockiPRUserRepository_GetActiveByTeamName_Call {
+	return &mockiPRUserRepository_GetActiveByTeamName_Call{Call: _e.mock.On("GetActiveByTeamName", ctx, teamName)}
+}
+
+func (_c *mockiPRUserRepository_GetActiveByTeamName_Call) Run(run func(ctx context.Context, teamName string)) *mockiPRUserRepository_GetActiveByTeamName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPRUserRepository_GetActiveByTeamName_Call) Return(users []domain.User, err error) *mockiPRUserRepository_GetActiveByTeamName_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *mockiPRUserRepository_GetActiveByTeamName_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]domain.User, error)) *mockiPRUserRepository_GetActiveByTeamName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type mockiPRUserRepository
+func (_mock *mockiPRUserRepository) GetByID(ctx context.Context, userID string) (domain.User, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPRUserRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type mockiPRUserRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *mockiPRUserRepository_Expecter) GetByID(ctx interface{}, userID interface{}) *mockiPRUserRepository_GetByID_Call {
+	return &mockiPRUserRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, userID)}
+}
+
+func (_c *mockiPRUserRepository_GetByID_Call) Run(run func(ctx context.Context, userID string)) *mockiPRUserRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPRUserRepository_GetByID_Call) Return(user domain.User, err error) *mockiPRUserRepository_GetByID_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *mockiPRUserRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, userID string) (domain.User, error)) *mockiPRUserRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDs provides a mock function for the type mockiPRUserRepository
+func (_mock *mockiPRUserRepository) GetByIDs(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	ret := _mock.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]domain.User, error)); ok {
+		return returnFunc(ctx, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []domain.User); ok {
+		r0 = returnFunc(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPRUserRepository_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type mockiPRUserRepository_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *mockiPRUserRepository_Expecter) GetByIDs(ctx interface{}, userIDs interface{}) *mockiPRUserRepository_GetByIDs_Call {
+	return &mockiPRUserRepository_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, userIDs)}
+}
+
+func (_c *mockiPRUserRepository_GetByIDs_Call) Run(run func(ctx context.Context, userIDs []string)) *mockiPRUserRepository_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPRUserRepository_GetByIDs_Call) Return(users []domain.User, err error) *mockiPRUserRepository_GetByIDs_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *mockiPRUserRepository_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, userIDs []string) ([]domain.User, error)) *mockiPRUserRepository_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiReviewerPoolSource creates a new instance of mockiReviewerPoolSource. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiReviewerPoolSource(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiReviewerPoolSource {
+	mock := &mockiReviewerPoolSource{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiReviewerPoolSource is an autogenerated mock type for the iReviewerPoolSource type
+type mockiReviewerPoolSource struct {
+	mock.Mock
+}
+
+type mockiReviewerPoolSource_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiReviewerPoolSource) EXPECT() *mockiReviewerPoolSource_Expecter {
+	return &mockiReviewerPoolSource_Expecter{mock: &_m.Mock}
+}
+
+// GetActiveMembers provides a mock function for the type mockiReviewerPoolSource
+func (_mock *mockiReviewerPoolSource) GetActiveMembers(ctx context.Context, poolName string) ([]domain.User, error) {
+	ret := _mock.Called(ctx, poolName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveMembers")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.User, error)); ok {
+		return returnFunc(ctx, poolName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.User); ok {
+		r0 = returnFunc(ctx, poolName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, poolName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewerPoolSource_GetActiveMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveMembers'
+type mockiReviewerPoolSource_GetActiveMembers_Call struct {
+	*mock.Call
+}
+
+// GetActiveMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - poolName string
+func (_e *mockiReviewerPoolSource_Expecter) GetActiveMembers(ctx interface{}, poolName interface{}) *mockiReviewerPoolSource_GetActiveMembers_Call {
+	return &mockiReviewerPoolSource_GetActiveMembers_Call{Call: _e.mock.On("GetActiveMembers", ctx, poolName)}
+}
+
+func (_c *mockiReviewerPoolSource_GetActiveMembers_Call) Run(run func(ctx context.Context, poolName string)) *mockiReviewerPoolSource_GetActiveMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewerPoolSource_GetActiveMembers_Call) Return(users []domain.User, err error) *mockiReviewerPoolSource_GetActiveMembers_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *mockiReviewerPoolSource_GetActiveMembers_Call) RunAndReturn(run func(ctx context.Context, poolName string) ([]domain.User, error)) *mockiReviewerPoolSource_GetActiveMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamFallbacks provides a mock function for the type mockiReviewerPoolSource
+func (_mock *mockiReviewerPoolSource) GetTeamFallbacks(ctx context.Context, teamName string) ([]domain.ReviewerPool, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamFallbacks")
+	}
+
+	var r0 []domain.ReviewerPool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.ReviewerPool, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.ReviewerPool); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ReviewerPool)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewerPoolSource_GetTeamFallbacks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamFallbacks'
+type mockiReviewerPoolSource_GetTeamFallbacks_Call struct {
+	*mock.Call
+}
+
+// GetTeamFallbacks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *mockiReviewerPoolSource_Expecter) GetTeamFallbacks(ctx interface{}, teamName interface{}) *mockiReviewerPoolSource_GetTeamFallbacks_Call {
+	return &mockiReviewerPoolSource_GetTeamFallbacks_Call{Call: _e.mock.On("GetTeamFallbacks", ctx, teamName)}
+}
+
+func (_c *mockiReviewerPoolSource_GetTeamFallbacks_Call) Run(run func(ctx context.Context, teamName string)) *mockiReviewerPoolSource_GetTeamFallbacks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewerPoolSource_GetTeamFallbacks_Call) Return(reviewerPools []domain.ReviewerPool, err error) *mockiReviewerPoolSource_GetTeamFallbacks_Call {
+	_c.Call.Return(reviewerPools, err)
+	return _c
+}
+
+func (_c *mockiReviewerPoolSource_GetTeamFallbacks_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]domain.ReviewerPool, error)) *mockiReviewerPoolSource_GetTeamFallbacks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamsFallingBackTo provides a mock function for the type mockiReviewerPoolSource
+func (_mock *mockiReviewerPoolSource) GetTeamsFallingBackTo(ctx context.Context, teamName string) ([]string, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamsFallingBackTo")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewerPoolSource_GetTeamsFallingBackTo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamsFallingBackTo'
+type mockiReviewerPoolSource_GetTeamsFallingBackTo_Call struct {
+	*mock.Call
+}
+
+// GetTeamsFallingBackTo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *mockiReviewerPoolSource_Expecter) GetTeamsFallingBackTo(ctx interface{}, teamName interface{}) *mockiReviewerPoolSource_GetTeamsFallingBackTo_Call {
+	return &mockiReviewerPoolSource_GetTeamsFallingBackTo_Call{Call: _e.mock.On("GetTeamsFallingBackTo", ctx, teamName)}
+}
+
+func (_c *mockiReviewerPoolSource_GetTeamsFallingBackTo_Call) Run(run func(ctx context.Context, teamName string)) *mockiReviewerPoolSource_GetTeamsFallingBackTo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewerPoolSource_GetTeamsFallingBackTo_Call) Return(strings []string, err error) *mockiReviewerPoolSource_GetTeamsFallingBackTo_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *mockiReviewerPoolSource_GetTeamsFallingBackTo_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]string, error)) *mockiReviewerPoolSource_GetTeamsFallingBackTo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiPullRequestMetrics creates a new instance of mockiPullRequestMetrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiPullRequestMetrics(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiPullRequestMetrics {
+	mock := &mockiPullRequestMetrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiPullRequestMetrics is an autogenerated mock type for the iPullRequestMetrics type
+type mockiPullRequestMetrics struct {
+	mock.Mock
+}
+
+type mockiPullRequestMetrics_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiPullRequestMetrics) EXPECT() *mockiPullRequestMetrics_Expecter {
+	return &mockiPullRequestMetrics_Expecter{mock: &_m.Mock}
+}
+
+// NoCandidate provides a mock function for the type mockiPullRequestMetrics
+func (_mock *mockiPullRequestMetrics) NoCandidate() {
+	_mock.Called()
+	return
+}
+
+// mockiPullRequestMetrics_NoCandidate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NoCandidate'
+type mockiPullRequestMetrics_NoCandidate_Call struct {
+	*mock.Call
+}
+
+// NoCandidate is a helper method to define mock.On call
+func (_e *mockiPullRequestMetrics_Expecter) NoCandidate() *mockiPullRequestMetrics_NoCandidate_Call {
+	return &mockiPullRequestMetrics_NoCandidate_Call{Call: _e.mock.On("NoCandidate")}
+}
+
+func (_c *mockiPullRequestMetrics_NoCandidate_Call) Run(run func()) *mockiPullRequestMetrics_NoCandidate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestMetrics_NoCandidate_Call) Return() *mockiPullRequestMetrics_NoCandidate_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockiPullRequestMetrics_NoCandidate_Call) RunAndReturn(run func()) *mockiPullRequestMetrics_NoCandidate_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PullRequestCreated provides a mock function for the type mockiPullRequestMetrics
+func (_mock *mockiPullRequestMetrics) PullRequestCreated() {
+	_mock.Called()
+	return
+}
+
+// mockiPullRequestMetrics_PullRequestCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PullRequestCreated'
+type mockiPullRequestMetrics_PullRequestCreated_Call struct {
+	*mock.Call
+}
+
+// PullRequestCreated is a helper method to define mock.On call
+func (_e *mockiPullRequestMetrics_Expecter) PullRequestCreated() *mockiPullRequestMetrics_PullRequestCreated_Call {
+	return &mockiPullRequestMetrics_PullRequestCreated_Call{Call: _e.mock.On("PullRequestCreated")}
+}
+
+func (_c *mockiPullRequestMetrics_PullRequestCreated_Call) Run(run func()) *mockiPullRequestMetrics_PullRequestCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestMetrics_PullRequestCreated_Call) Return() *mockiPullRequestMetrics_PullRequestCreated_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockiPullRequestMetrics_PullRequestCreated_Call) RunAndReturn(run func()) *mockiPullRequestMetrics_PullRequestCreated_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PullRequestMerged provides a mock function for the type mockiPullRequestMetrics
+func (_mock *mockiPullRequestMetrics) PullRequestMerged() {
+	_mock.Called()
+	return
+}
+
+// mockiPullRequestMetrics_PullRequestMerged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PullRequestMerged'
+type mockiPullRequestMetrics_PullRequestMerged_Call struct {
+	*mock.Call
+}
+
+// PullRequestMerged is a helper method to define mock.On call
+func (_e *mockiPullRequestMetrics_Expecter) PullRequestMerged() *mockiPullRequestMetrics_PullRequestMerged_Call {
+	return &mockiPullRequestMetrics_PullRequestMerged_Call{Call: _e.mock.On("PullRequestMerged")}
+}
+
+func (_c *mockiPullRequestMetrics_PullRequestMerged_Call) Run(run func()) *mockiPullRequestMetrics_PullRequestMerged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestMetrics_PullRequestMerged_Call) Return() *mockiPullRequestMetrics_PullRequestMerged_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockiPullRequestMetrics_PullRequestMerged_Call) RunAndReturn(run func()) *mockiPullRequestMetrics_PullRequestMerged_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ReviewerReassigned provides a mock function for the type mockiPullRequestMetrics
+func (_mock *mockiPullRequestMetrics) ReviewerReassigned() {
+	_mock.Called()
+	return
+}
+
+// mockiPullRequestMetrics_ReviewerReassigned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReviewerReassigned'
+type mockiPullRequestMetrics_ReviewerReassigned_Call struct {
+	*mock.Call
+}
+
+// ReviewerReassigned is a helper method to define mock.On call
+func (_e *mockiPullRequestMetrics_Expecter) ReviewerReassigned() *mockiPullRequestMetrics_ReviewerReassigned_Call {
+	return &mockiPullRequestMetrics_ReviewerReassigned_Call{Call: _e.mock.On("ReviewerReassigned")}
+}
+
+func (_c *mockiPullRequestMetrics_ReviewerReassigned_Call) Run(run func()) *mockiPullRequestMetrics_ReviewerReassigned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockiPullRequestMetrics_ReviewerReassigned_Call) Return() *mockiPullRequestMetrics_ReviewerReassigned_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockiPullRequestMetrics_ReviewerReassigned_Call) RunAndReturn(run func()) *mockiPullRequestMetrics_ReviewerReassigned_Call {
+	_c.Run(run)
+	return _c
+}
+
+// newMockiReviewerPoolRepository creates a new instance of mockiReviewerPoolRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiReviewerPoolRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiReviewerPoolRepository {
+	mock := &mockiReviewerPoolRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiReviewerPoolRepository is an autogenerated mock type for the iReviewerPoolRepository type
+type mockiReviewerPoolRepository struct {
+	mock.Mock
+}
+
+type mockiReviewerPoolRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiReviewerPoolRepository) EXPECT() *mockiReviewerPoolRepository_Expecter {
+	return &mockiReviewerPoolRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type mockiReviewerPoolRepository
+func (_mock *mockiReviewerPoolRepository) Delete(ctx context.Context, poolName string) error {
+	ret := _mock.Called(ctx, poolName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, poolName)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockiReviewerPoolRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type mockiReviewerPoolRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - poolName string
+func (_e *mockiReviewerPoolRepository_Expecter) Delete(ctx interface{}, poolName interface{}) *mockiReviewerPoolRepository_Delete_Call {
+	return &mockiReviewerPoolRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, poolName)}
+}
+
+func (_c *mockiReviewerPoolRepository_Delete_Call) Run(run func(ctx context.Context, poolName string)) *mockiReviewerPoolRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewerPoolRepository_Delete_Call) Return(err error) *mockiReviewerPoolRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiReviewerPoolRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, poolName string) error) *mockiReviewerPoolRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type mockiReviewerPoolRepository
+func (_mock *mockiReviewerPoolRepository) Get(ctx context.Context, poolName string) (domain.SharedReviewerPool, error) {
+	ret := _mock.Called(ctx, poolName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 domain.SharedReviewerPool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.SharedReviewerPool, error)); ok {
+		return returnFunc(ctx, poolName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.SharedReviewerPool); ok {
+		r0 = returnFunc(ctx, poolName)
+	} else {
+		r0 = ret.Get(0).(domain.SharedReviewerPool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, poolName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewerPoolRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type mockiReviewerPoolRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - poolName string
+func (_e *mockiReviewerPoolRepository_Expecter) Get(ctx interface{}, poolName interface{}) *mockiReviewerPoolRepository_Get_Call {
+	return &mockiReviewerPoolRepository_Get_Call{Call: _e.mock.On("Get", ctx, poolName)}
+}
+
+func (_c *mockiReviewerPoolRepository_Get_Call) Run(run func(ctx context.Context, poolName string)) *mockiReviewerPoolRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewerPoolRepository_Get_Call) Return(sharedReviewerPool domain.SharedReviewerPool, err error) *mockiReviewerPoolRepository_Get_Call {
+	_c.Call.Return(sharedReviewerPool, err)
+	return _c
+}
+
+func (_c *mockiReviewerPoolRepository_Get_Call) RunAndReturn(run func(ctx context.Context, poolName string) (domain.SharedReviewerPool, error)) *mockiReviewerPoolRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function for the type mockiReviewerPoolRepository
+func (_mock *mockiReviewerPoolRepository) Set(ctx context.Context, poolName string, userIDs []string) (domain.SharedReviewerPool, error) {
+	ret := _mock.Called(ctx, poolName, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 domain.SharedReviewerPool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) (domain.SharedReviewerPool, error)); ok {
+		return returnFunc(ctx, poolName, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) domain.SharedReviewerPool); ok {
+		r0 = returnFunc(ctx, poolName, userIDs)
+	} else {
+		r0 = ret.Get(0).(domain.SharedReviewerPool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, poolName, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewerPoolRepository_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type mockiReviewerPoolRepository_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - ctx context.Context
+//   - poolName string
+//   - userIDs []string
+func (_e *mockiReviewerPoolRepository_Expecter) Set(ctx interface{}, poolName interface{}, userIDs interface{}) *mockiReviewerPoolRepository_Set_Call {
+	return &mockiReviewerPoolRepository_Set_Call{Call: _e.mock.On("Set", ctx, poolName, userIDs)}
+}
+
+func (_c *mockiReviewerPoolRepository_Set_Call) Run(run func(ctx context.Context, poolName string, userIDs []string)) *mockiReviewerPoolRepository_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewerPoolRepository_Set_Call) Return(sharedReviewerPool domain.SharedReviewerPool, err error) *mockiReviewerPoolRepository_Set_Call {
+	_c.Call.Return(sharedReviewerPool, err)
+	return _c
+}
+
+func (_c *mockiReviewerPoolRepository_Set_Call) RunAndReturn(run func(ctx context.Context, poolName string, userIDs []string) (domain.SharedReviewerPool, error)) *mockiReviewerPoolRepository_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiPoolUserRepository creates a new instance of mockiPoolUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiPoolUserRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiPoolUserRepository {
+	mock := &mockiPoolUserRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiPoolUserRepository is an autogenerated mock type for the iPoolUserRepository type
+type mockiPoolUserRepository struct {
+	mock.Mock
+}
+
+type mockiPoolUserRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiPoolUserRepository) EXPECT() *mockiPoolUserRepository_Expecter {
+	return &mockiPoolUserRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetByIDs provides a mock function for the type mockiPoolUserRepository
+func (_mock *mockiPoolUserRepository) GetByIDs(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	ret := _mock.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]domain.User, error)); ok {
+		return returnFunc(ctx, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []domain.User); ok {
+		r0 = returnFunc(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiPoolUserRepository_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type mockiPoolUserRepository_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *mockiPoolUserRepository_Expecter) GetByIDs(ctx interface{}, userIDs interface{}) *mockiPoolUserRepository_GetByIDs_Call {
+	return &mockiPoolUserRepository_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, userIDs)}
+}
+
+func (_c *mockiPoolUserRepository_GetByIDs_Call) Run(run func(ctx context.Context, userIDs []string)) *mockiPoolUserRepository_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiPoolUserRepository_GetByIDs_Call) Return(users []domain.User, err error) *mockiPoolUserRepository_GetByIDs_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *mockiPoolUserRepository_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, userIDs []string) ([]domain.User, error)) *mockiPoolUserRepository_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReviewerSelector creates a new instance of MockReviewerSelector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReviewerSelector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReviewerSelector {
+	mock := &MockReviewerSelector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockReviewerSelector is an autogenerated mock type for the ReviewerSelector type
+type MockReviewerSelector struct {
+	mock.Mock
+}
+
+type MockReviewerSelector_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReviewerSelector) EXPECT() *MockReviewerSelector_Expecter {
+	return &MockReviewerSelector_Expecter{mock: &_m.Mock}
+}
+
+// Select provides a mock function for the type MockReviewerSelector
+func (_mock *MockReviewerSelector) Select(ctx context.Context, pool domain.ReviewerPool, candidates []domain.User, count int) ([]domain.User, error) {
+	ret := _mock.Called(ctx, pool, candidates, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Select")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ReviewerPool, []domain.User, int) ([]domain.User, error)); ok {
+		return returnFunc(ctx, pool, candidates, count)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ReviewerPool, []domain.User, int) []domain.User); ok {
+		r0 = returnFunc(ctx, pool, candidates, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ReviewerPool, []domain.User, int) error); ok {
+		r1 = returnFunc(ctx, pool, candidates, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockReviewerSelector_Select_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Select'
+type MockReviewerSelector_Select_Call struct {
+	*mock.Call
+}
+
+// Select is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pool domain.ReviewerPool
+//   - candidates []domain.User
+//   - count int
+func (_e *MockReviewerSelector_Expecter) Select(ctx interface{}, pool interface{}, candidates interface{}, count interface{}) *MockReviewerSelector_Select_Call {
+	return &MockReviewerSelector_Select_Call{Call: _e.mock.On("Select", ctx, pool, candidates, count)}
+}
+
+func (_c *MockReviewerSelector_Select_Call) Run(run func(ctx context.Context, pool domain.ReviewerPool, candidates []domain.User, count int)) *MockReviewerSelector_Select_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ReviewerPool
+		if args[1] != nil {
+			arg1 = args[1].(domain.ReviewerPool)
+		}
+		var arg2 []domain.User
+		if args[2] != nil {
+			arg2 = args[2].([]domain.User)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReviewerSelector_Select_Call) Return(users []domain.User, err error) *MockReviewerSelector_Select_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockReviewerSelector_Select_Call) RunAndReturn(run func(ctx context.Context, pool domain.ReviewerPool, candidates []domain.User, count int) ([]domain.User, error)) *MockReviewerSelector_Select_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockloadAwareSelector creates a new instance of mockloadAwareSelector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockloadAwareSelector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockloadAwareSelector {
+	mock := &mockloadAwareSelector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockloadAwareSelector is an autogenerated mock type for the loadAwareSelector type
+type mockloadAwareSelector struct {
+	mock.Mock
+}
+
+type mockloadAwareSelector_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockloadAwareSelector) EXPECT() *mockloadAwareSelector_Expecter {
+	return &mockloadAwareSelector_Expecter{mock: &_m.Mock}
+}
+
+// SelectByLoad provides a mock function for the type mockloadAwareSelector
+func (_mock *mockloadAwareSelector) SelectByLoad(candidates []domain.User, load map[string]int, count int) []domain.User {
+	ret := _mock.Called(candidates, load, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectByLoad")
+	}
+
+	var r0 []domain.User
+	if returnFunc, ok := ret.Get(0).(func([]domain.User, map[string]int, int) []domain.User); ok {
+		r0 = returnFunc(candidates, load, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	return r0
+}
+
+// mockloadAwareSelector_SelectByLoad_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectByLoad'
+type mockloadAwareSelector_SelectByLoad_Call struct {
+	*mock.Call
+}
+
+// SelectByLoad is a helper method to define mock.On call
+//   - candidates []domain.User
+//   - load map[string]int
+//   - count int
+func (_e *mockloadAwareSelector_Expecter) SelectByLoad(candidates interface{}, load interface{}, count interface{}) *mockloadAwareSelector_SelectByLoad_Call {
+	return &mockloadAwareSelector_SelectByLoad_Call{Call: _e.mock.On("SelectByLoad", candidates, load, count)}
+}
+
+func (_c *mockloadAwareSelector_SelectByLoad_Call) Run(run func(candidates []domain.User, load map[string]int, count int)) *mockloadAwareSelector_SelectByLoad_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []domain.User
+		if args[0] != nil {
+			arg0 = args[0].([]domain.User)
+		}
+		var arg1 map[string]int
+		if args[1] != nil {
+			arg1 = args[1].(map[string]int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockloadAwareSelector_SelectByLoad_Call) Return(users []domain.User) *mockloadAwareSelector_SelectByLoad_Call {
+	_c.Call.Return(users)
+	return _c
+}
+
+func (_c *mockloadAwareSelector_SelectByLoad_Call) RunAndReturn(run func(candidates []domain.User, load map[string]int, count int) []domain.User) *mockloadAwareSelector_SelectByLoad_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiReviewLoadRepository creates a new instance of mockiReviewLoadRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiReviewLoadRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiReviewLoadRepository {
+	mock := &mockiReviewLoadRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiReviewLoadRepository is an autogenerated mock type for the iReviewLoadRepository type
+type mockiReviewLoadRepository struct {
+	mock.Mock
+}
+
+type mockiReviewLoadRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiReviewLoadRepository) EXPECT() *mockiReviewLoadRepository_Expecter {
+	return &mockiReviewLoadRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountOpenReviews provides a mock function for the type mockiReviewLoadRepository
+func (_mock *mockiReviewLoadRepository) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	ret := _mock.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOpenReviews")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (map[string]int, error)); ok {
+		return returnFunc(ctx, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) map[string]int); ok {
+		r0 = returnFunc(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewLoadRepository_CountOpenReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOpenReviews'
+type mockiReviewLoadRepository_CountOpenReviews_Call struct {
+	*mock.Call
+}
+
+// CountOpenReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *mockiReviewLoadRepository_Expecter) CountOpenReviews(ctx interface{}, userIDs interface{}) *mockiReviewLoadRepository_CountOpenReviews_Call {
+	return &mockiReviewLoadRepository_CountOpenReviews_Call{Call: _e.mock.On("CountOpenReviews", ctx, userIDs)}
+}
+
+func (_c *mockiReviewLoadRepository_CountOpenReviews_Call) Run(run func(ctx context.Context, userIDs []string)) *mockiReviewLoadRepository_CountOpenReviews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewLoadRepository_CountOpenReviews_Call) Return(sToInt map[string]int, err error) *mockiReviewLoadRepository_CountOpenReviews_Call {
+	_c.Call.Return(sToInt, err)
+	return _c
+}
+
+func (_c *mockiReviewLoadRepository_CountOpenReviews_Call) RunAndReturn(run func(ctx context.Context, userIDs []string) (map[string]int, error)) *mockiReviewLoadRepository_CountOpenReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiReviewerCursorRepository creates a new instance of mockiReviewerCursorRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiReviewerCursorRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiReviewerCursorRepository {
+	mock := &mockiReviewerCursorRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiReviewerCursorRepository is an autogenerated mock type for the iReviewerCursorRepository type
+type mockiReviewerCursorRepository struct {
+	mock.Mock
+}
+
+type mockiReviewerCursorRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiReviewerCursorRepository) EXPECT() *mockiReviewerCursorRepository_Expecter {
+	return &mockiReviewerCursorRepository_Expecter{mock: &_m.Mock}
+}
+
+// LockCursor provides a mock function for the type mockiReviewerCursorRepository
+func (_mock *mockiReviewerCursorRepository) LockCursor(ctx context.Context, pool domain.ReviewerPool) (string, error) {
+	ret := _mock.Called(ctx, pool)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockCursor")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ReviewerPool) (string, error)); ok {
+		return returnFunc(ctx, pool)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ReviewerPool) string); ok {
+		r0 = returnFunc(ctx, pool)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ReviewerPool) error); ok {
+		r1 = returnFunc(ctx, pool)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiReviewerCursorRepository_LockCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockCursor'
+type mockiReviewerCursorRepository_LockCursor_Call struct {
+	*mock.Call
+}
+
+// LockCursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pool domain.ReviewerPool
+func (_e *mockiReviewerCursorRepository_Expecter) LockCursor(ctx interface{}, pool interface{}) *mockiReviewerCursorRepository_LockCursor_Call {
+	return &mockiReviewerCursorRepository_LockCursor_Call{Call: _e.mock.On("LockCursor", ctx, pool)}
+}
+
+func (_c *mockiReviewerCursorRepository_LockCursor_Call) Run(run func(ctx context.Context, pool domain.ReviewerPool)) *mockiReviewerCursorRepository_LockCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ReviewerPool
+		if args[1] != nil {
+			arg1 = args[1].(domain.ReviewerPool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewerCursorRepository_LockCursor_Call) Return(s string, err error) *mockiReviewerCursorRepository_LockCursor_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *mockiReviewerCursorRepository_LockCursor_Call) RunAndReturn(run func(ctx context.Context, pool domain.ReviewerPool) (string, error)) *mockiReviewerCursorRepository_LockCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCursor provides a mock function for the type mockiReviewerCursorRepository
+func (_mock *mockiReviewerCursorRepository) SetCursor(ctx context.Context, pool domain.ReviewerPool, lastReviewerID string) error {
+	ret := _mock.Called(ctx, pool, lastReviewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCursor")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ReviewerPool, string) error); ok {
+		r0 = returnFunc(ctx, pool, lastReviewerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockiReviewerCursorRepository_SetCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCursor'
+type mockiReviewerCursorRepository_SetCursor_Call struct {
+	*mock.Call
+}
+
+// SetCursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pool domain.ReviewerPool
+//   - lastReviewerID string
+func (_e *mockiReviewerCursorRepository_Expecter) SetCursor(ctx interface{}, pool interface{}, lastReviewerID interface{}) *mockiReviewerCursorRepository_SetCursor_Call {
+	return &mockiReviewerCursorRepository_SetCursor_Call{Call: _e.mock.On("SetCursor", ctx, pool, lastReviewerID)}
+}
+
+func (_c *mockiReviewerCursorRepository_SetCursor_Call) Run(run func(ctx context.Context, pool domain.ReviewerPool, lastReviewerID string)) *mockiReviewerCursorRepository_SetCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ReviewerPool
+		if args[1] != nil {
+			arg1 = args[1].(domain.ReviewerPool)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiReviewerCursorRepository_SetCursor_Call) Return(err error) *mockiReviewerCursorRepository_SetCursor_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiReviewerCursorRepository_SetCursor_Call) RunAndReturn(run func(ctx context.Context, pool domain.ReviewerPool, lastReviewerID string) error) *mockiReviewerCursorRepository_SetCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiTeamRepository creates a new instance of mockiTeamRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiTeamRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiTeamRepository {
+	mock := &mockiTeamRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiTeamRepository is an autogenerated mock type for the iTeamRepository type
+type mockiTeamRepository struct {
+	mock.Mock
+}
+
+type mockiTeamRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiTeamRepository) EXPECT() *mockiTeamRepository_Expecter {
+	return &mockiTeamRepository_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function for the type mockiTeamRepository
+func (_mock *mockiTeamRepository) Add(ctx context.Context, team domain.Team) (domain.Team, error) {
+	ret := _mock.Called(ctx, team)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 domain.Team
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Team) (domain.Team, error)); ok {
+		return returnFunc(ctx, team)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Team) domain.Team); ok {
+		r0 = returnFunc(ctx, team)
+	} else {
+		r0 = ret.Get(0).(domain.Team)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Team) error); ok {
+		r1 = returnFunc(ctx, team)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamRepository_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type mockiTeamRepository_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - team domain.Team
+func (_e *mockiTeamRepository_Expecter) Add(ctx interface{}, team interface{}) *mockiTeamRepository_Add_Call {
+	return &mockiTeamRepository_Add_Call{Call: _e.mock.On("Add", ctx, team)}
+}
+
+func (_c *mockiTeamRepository_Add_Call) Run(run func(ctx context.Context, team domain.Team)) *mockiTeamRepository_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Team
+		if args[1] != nil {
+			arg1 = args[1].(domain.Team)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamRepository_Add_Call) Return(team1 domain.Team, err error) *mockiTeamRepository_Add_Call {
+	_c.Call.Return(team1, err)
+	return _c
+}
+
+func (_c *mockiTeamRepository_Add_Call) RunAndReturn(run func(ctx context.Context, team domain.Team) (domain.Team, error)) *mockiTeamRepository_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type mockiTeamRepository
+func (_mock *mockiTeamRepository) Delete(ctx context.Context, teamName string) error {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockiTeamRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type mockiTeamRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *mockiTeamRepository_Expecter) Delete(ctx interface{}, teamName interface{}) *mockiTeamRepository_Delete_Call {
+	return &mockiTeamRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, teamName)}
+}
+
+func (_c *mockiTeamRepository_Delete_Call) Run(run func(ctx context.Context, teamName string)) *mockiTeamRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamRepository_Delete_Call) Return(err error) *mockiTeamRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiTeamRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, teamName string) error) *mockiTeamRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exists provides a mock function for the type mockiTeamRepository
+func (_mock *mockiTeamRepository) Exists(ctx context.Context, teamName string) (bool, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamRepository_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type mockiTeamRepository_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *mockiTeamRepository_Expecter) Exists(ctx interface{}, teamName interface{}) *mockiTeamRepository_Exists_Call {
+	return &mockiTeamRepository_Exists_Call{Call: _e.mock.On("Exists", ctx, teamName)}
+}
+
+func (_c *mockiTeamRepository_Exists_Call) Run(run func(ctx context.Context, teamName string)) *mockiTeamRepository_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamRepository_Exists_Call) Return(b bool, err error) *mockiTeamRepository_Exists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *mockiTeamRepository_Exists_Call) RunAndReturn(run func(ctx context.Context, teamName string) (bool, error)) *mockiTeamRepository_Exists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type mockiTeamRepository
+func (_mock *mockiTeamRepository) Get(ctx context.Context, teamName string) (domain.Team, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 domain.Team
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.Team, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.Team); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		r0 = ret.Get(0).(domain.Team)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type mockiTeamRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *mockiTeamRepository_Expecter) Get(ctx interface{}, teamName interface{}) *mockiTeamRepository_Get_Call {
+	return &mockiTeamRepository_Get_Call{Call: _e.mock.On("Get", ctx, teamName)}
+}
+
+func (_c *mockiTeamRepository_Get_Call) Run(run func(ctx context.Context, teamName string)) *mockiTeamRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamRepository_Get_Call) Return(team domain.Team, err error) *mockiTeamRepository_Get_Call {
+	_c.Call.Return(team, err)
+	return _c
+}
+
+func (_c *mockiTeamRepository_Get_Call) RunAndReturn(run func(ctx context.Context, teamName string) (domain.Team, error)) *mockiTeamRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rename provides a mock function for the type mockiTeamRepository
+func (_mock *mockiTeamRepository) Rename(ctx context.Context, teamName string, newName string) (domain.Team, error) {
+	ret := _mock.Called(ctx, teamName, newName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 domain.Team
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (domain.Team, error)); ok {
+		return returnFunc(ctx, teamName, newName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) domain.Team); ok {
+		r0 = returnFunc(ctx, teamName, newName)
+	} else {
+		r0 = ret.Get(0).(domain.Team)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, teamName, newName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamRepository_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type mockiTeamRepository_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - newName string
+func (_e *mockiTeamRepository_Expecter) Rename(ctx interface{}, teamName interface{}, newName interface{}) *mockiTeamRepository_Rename_Call {
+	return &mockiTeamRepository_Rename_Call{Call: _e.mock.On("Rename", ctx, teamName, newName)}
+}
+
+func (_c *mockiTeamRepository_Rename_Call) Run(run func(ctx context.Context, teamName string, newName string)) *mockiTeamRepository_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamRepository_Rename_Call) Return(team domain.Team, err error) *mockiTeamRepository_Rename_Call {
+	_c.Call.Return(team, err)
+	return _c
+}
+
+func (_c *mockiTeamRepository_Rename_Call) RunAndReturn(run func(ctx context.Context, teamName string, newName string) (domain.Team, error)) *mockiTeamRepository_Rename_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiTeamUserRepository creates a new instance of mockiTeamUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiTeamUserRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiTeamUserRepository {
+	mock := &mockiTeamUserRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiTeamUserRepository is an autogenerated mock type for the iTeamUserRepository type
+type mockiTeamUserRepository struct {
+	mock.Mock
+}
+
+type mockiTeamUserRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiTeamUserRepository) EXPECT() *mockiTeamUserRepository_Expecter {
+	return &mockiTeamUserRepository_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function for the type mockiTeamUserRepository
+func (_mock *mockiTeamUserRepository) Add(ctx context.Context, users []domain.User) ([]domain.User, error) {
+	ret := _mock.Called(ctx, users)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.User) ([]domain.User, error)); ok {
+		return returnFunc(ctx, users)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.User) []domain.User); ok {
+		r0 = returnFunc(ctx, users)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []domain.User) error); ok {
+		r1 = returnFunc(ctx, users)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamUserRepository_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type mockiTeamUserRepository_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - users []domain.User
+func (_e *mockiTeamUserRepository_Expecter) Add(ctx interface{}, users interface{}) *mockiTeamUserRepository_Add_Call {
+	return &mockiTeamUserRepository_Add_Call{Call: _e.mock.On("Add", ctx, users)}
+}
+
+func (_c *mockiTeamUserRepository_Add_Call) Run(run func(ctx context.Context, users []domain.User)) *mockiTeamUserRepository_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []domain.User
+		if args[1] != nil {
+			arg1 = args[1].([]domain.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_Add_Call) Return(users1 []domain.User, err error) *mockiTeamUserRepository_Add_Call {
+	_c.Call.Return(users1, err)
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_Add_Call) RunAndReturn(run func(ctx context.Context, users []domain.User) ([]domain.User, error)) *mockiTeamUserRepository_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeactivateTeamUsers provides a mock function for the type mockiTeamUserRepository
+func (_mock *mockiTeamUserRepository) DeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error) {
+	ret := _mock.Called(ctx, teamName, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateTeamUsers")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]domain.User, error)); ok {
+		return returnFunc(ctx, teamName, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []domain.User); ok {
+		r0 = returnFunc(ctx, teamName, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, teamName, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamUserRepository_DeactivateTeamUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeactivateTeamUsers'
+type mockiTeamUserRepository_DeactivateTeamUsers_Call struct {
+	*mock.Call
+}
+
+// DeactivateTeamUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - userIDs []string
+func (_e *mockiTeamUserRepository_Expecter) DeactivateTeamUsers(ctx interface{}, teamName interface{}, userIDs interface{}) *mockiTeamUserRepository_DeactivateTeamUsers_Call {
+	return &mockiTeamUserRepository_DeactivateTeamUsers_Call{Call: _e.mock.On("DeactivateTeamUsers", ctx, teamName, userIDs)}
+}
+
+func (_c *mockiTeamUserRepository_DeactivateTeamUsers_Call) Run(run func(ctx context.Context, teamName string, userIDs []string)) *mockiTeamUserRepository_DeactivateTeamUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_DeactivateTeamUsers_Call) Return(users []domain.User, err error) *mockiTeamUserRepository_DeactivateTeamUsers_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_DeactivateTeamUsers_Call) RunAndReturn(run func(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error)) *mockiTeamUserRepository_DeactivateTeamUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type mockiTeamUserRepository
+func (_mock *mockiTeamUserRepository) GetByID(ctx context.Context, userID string) (domain.User, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamUserRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type mockiTeamUserRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *mockiTeamUserRepository_Expecter) GetByID(ctx interface{}, userID interface{}) *mockiTeamUserRepository_GetByID_Call {
+	return &mockiTeamUserRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, userID)}
+}
+
+func (_c *mockiTeamUserRepository_GetByID_Call) Run(run func(ctx context.Context, userID string)) *mockiTeamUserRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_GetByID_Call) Return(user domain.User, err error) *mockiTeamUserRepository_GetByID_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, userID string) (domain.User, error)) *mockiTeamUserRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDs provides a mock function for the type mockiTeamUserRepository
+func (_mock *mockiTeamUserRepository) GetByIDs(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	ret := _mock.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]domain.User, error)); ok {
+		return returnFunc(ctx, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []domain.User); ok {
+		r0 = returnFunc(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamUserRepository_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type mockiTeamUserRepository_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *mockiTeamUserRepository_Expecter) GetByIDs(ctx interface{}, userIDs interface{}) *mockiTeamUserRepository_GetByIDs_Call {
+	return &mockiTeamUserRepository_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, userIDs)}
+}
+
+func (_c *mockiTeamUserRepository_GetByIDs_Call) Run(run func(ctx context.Context, userIDs []string)) *mockiTeamUserRepository_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_GetByIDs_Call) Return(users []domain.User, err error) *mockiTeamUserRepository_GetByIDs_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, userIDs []string) ([]domain.User, error)) *mockiTeamUserRepository_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByTeamName provides a mock function for the type mockiTeamUserRepository
+func (_mock *mockiTeamUserRepository) GetByTeamName(ctx context.Context, teamName string) ([]domain.User, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTeamName")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.User, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.User); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamUserRepository_GetByTeamName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTeamName'
+type mockiTeamUserRepository_GetByTeamName_Call struct {
+	*mock.Call
+}
+
+// GetByTeamName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *mockiTeamUserRepository_Expecter) GetByTeamName(ctx interface{}, teamName interface{}) *mockiTeamUserRepository_GetByTeamName_Call {
+	return &mockiTeamUserRepository_GetByTeamName_Call{Call: _e.mock.On("GetByTeamName", ctx, teamName)}
+}
+
+func (_c *mockiTeamUserRepository_GetByTeamName_Call) Run(run func(ctx context.Context, teamName string)) *mockiTeamUserRepository_GetByTeamName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_GetByTeamName_Call) Return(users []domain.User, err error) *mockiTeamUserRepository_GetByTeamName_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_GetByTeamName_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]domain.User, error)) *mockiTeamUserRepository_GetByTeamName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveDeletedUsers provides a mock function for the type mockiTeamUserRepository
+func (_mock *mockiTeamUserRepository) MoveDeletedUsers(ctx context.Context, teamName string, newTeamName string) ([]domain.User, error) {
+	ret := _mock.Called(ctx, teamName, newTeamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveDeletedUsers")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.User, error)); ok {
+		return returnFunc(ctx, teamName, newTeamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.User); ok {
+		r0 = returnFunc(ctx, teamName, newTeamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, teamName, newTeamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamUserRepository_MoveDeletedUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveDeletedUsers'
+type mockiTeamUserRepository_MoveDeletedUsers_Call struct {
+	*mock.Call
+}
+
+// MoveDeletedUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - newTeamName string
+func (_e *mockiTeamUserRepository_Expecter) MoveDeletedUsers(ctx interface{}, teamName interface{}, newTeamName interface{}) *mockiTeamUserRepository_MoveDeletedUsers_Call {
+	return &mockiTeamUserRepository_MoveDeletedUsers_Call{Call: _e.mock.On("MoveDeletedUsers", ctx, teamName, newTeamName)}
+}
+
+func (_c *mockiTeamUserRepository_MoveDeletedUsers_Call) Run(run func(ctx context.Context, teamName string, newTeamName string)) *mockiTeamUserRepository_MoveDeletedUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_MoveDeletedUsers_Call) Return(users []domain.User, err error) *mockiTeamUserRepository_MoveDeletedUsers_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_MoveDeletedUsers_Call) RunAndReturn(run func(ctx context.Context, teamName string, newTeamName string) ([]domain.User, error)) *mockiTeamUserRepository_MoveDeletedUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveUsersToTeam provides a mock function for the type mockiTeamUserRepository
+func (_mock *mockiTeamUserRepository) MoveUsersToTeam(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error) {
+	ret := _mock.Called(ctx, teamName, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveUsersToTeam")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]domain.User, error)); ok {
+		return returnFunc(ctx, teamName, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []domain.User); ok {
+		r0 = returnFunc(ctx, teamName, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, teamName, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamUserRepository_MoveUsersToTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveUsersToTeam'
+type mockiTeamUserRepository_MoveUsersToTeam_Call struct {
+	*mock.Call
+}
+
+// MoveUsersToTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - userIDs []string
+func (_e *mockiTeamUserRepository_Expecter) MoveUsersToTeam(ctx interface{}, teamName interface{}, userIDs interface{}) *mockiTeamUserRepository_MoveUsersToTeam_Call {
+	return &mockiTeamUserRepository_MoveUsersToTeam_Call{Call: _e.mock.On("MoveUsersToTeam", ctx, teamName, userIDs)}
+}
+
+func (_c *mockiTeamUserRepository_MoveUsersToTeam_Call) Run(run func(ctx context.Context, teamName string, userIDs []string)) *mockiTeamUserRepository_MoveUsersToTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_MoveUsersToTeam_Call) Return(users []domain.User, err error) *mockiTeamUserRepository_MoveUsersToTeam_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_MoveUsersToTeam_Call) RunAndReturn(run func(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error)) *mockiTeamUserRepository_MoveUsersToTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetIsActive provides a mock function for the type mockiTeamUserRepository
+func (_mock *mockiTeamUserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) (domain.User, error) {
+	ret := _mock.Called(ctx, userID, isActive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIsActive")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (domain.User, error)); ok {
+		return returnFunc(ctx, userID, isActive)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) domain.User); ok {
+		r0 = returnFunc(ctx, userID, isActive)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = returnFunc(ctx, userID, isActive)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamUserRepository_SetIsActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIsActive'
+type mockiTeamUserRepository_SetIsActive_Call struct {
+	*mock.Call
+}
+
+// SetIsActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - isActive bool
+func (_e *mockiTeamUserRepository_Expecter) SetIsActive(ctx interface{}, userID interface{}, isActive interface{}) *mockiTeamUserRepository_SetIsActive_Call {
+	return &mockiTeamUserRepository_SetIsActive_Call{Call: _e.mock.On("SetIsActive", ctx, userID, isActive)}
+}
+
+func (_c *mockiTeamUserRepository_SetIsActive_Call) Run(run func(ctx context.Context, userID string, isActive bool)) *mockiTeamUserRepository_SetIsActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_SetIsActive_Call) Return(user domain.User, err error) *mockiTeamUserRepository_SetIsActive_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *mockiTeamUserRepository_SetIsActive_Call) RunAndReturn(run func(ctx context.Context, userID string, isActive bool) (domain.User, error)) *mockiTeamUserRepository_SetIsActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiTeamFallbackRepository creates a new instance of mockiTeamFallbackRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiTeamFallbackRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiTeamFallbackRepository {
+	mock := &mockiTeamFallbackRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiTeamFallbackRepository is an autogenerated mock type for the iTeamFallbackRepository type
+type mockiTeamFallbackRepository struct {
+	mock.Mock
+}
+
+type mockiTeamFallbackRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiTeamFallbackRepository) EXPECT() *mockiTeamFallbackRepository_Expecter {
+	return &mockiTeamFallbackRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetTeamFallbacks provides a mock function for the type mockiTeamFallbackRepository
+func (_mock *mockiTeamFallbackRepository) GetTeamFallbacks(ctx context.Context, teamName string) ([]domain.ReviewerPool, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamFallbacks")
+	}
+
+	var r0 []domain.ReviewerPool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.ReviewerPool, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.ReviewerPool); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ReviewerPool)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiTeamFallbackRepository_GetTeamFallbacks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamFallbacks'
+type mockiTeamFallbackRepository_GetTeamFallbacks_Call struct {
+	*mock.Call
+}
+
+// GetTeamFallbacks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *mockiTeamFallbackRepository_Expecter) GetTeamFallbacks(ctx interface{}, teamName interface{}) *mockiTeamFallbackRepository_GetTeamFallbacks_Call {
+	return &mockiTeamFallbackRepository_GetTeamFallbacks_Call{Call: _e.mock.On("GetTeamFallbacks", ctx, teamName)}
+}
+
+func (_c *mockiTeamFallbackRepository_GetTeamFallbacks_Call) Run(run func(ctx context.Context, teamName string)) *mockiTeamFallbackRepository_GetTeamFallbacks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamFallbackRepository_GetTeamFallbacks_Call) Return(reviewerPools []domain.ReviewerPool, err error) *mockiTeamFallbackRepository_GetTeamFallbacks_Call {
+	_c.Call.Return(reviewerPools, err)
+	return _c
+}
+
+func (_c *mockiTeamFallbackRepository_GetTeamFallbacks_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]domain.ReviewerPool, error)) *mockiTeamFallbackRepository_GetTeamFallbacks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTeamFallbacks provides a mock function for the type mockiTeamFallbackRepository
+func (_mock *mockiTeamFallbackRepository) SetTeamFallbacks(ctx context.Context, teamName string, fallbacks []domain.ReviewerPool) error {
+	ret := _mock.Called(ctx, teamName, fallbacks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTeamFallbacks")
+	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = returnFunc(ctx, prID, newReviewerID, oldReviewerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []domain.ReviewerPool) error); ok {
+		r0 = returnFunc(ctx, teamName, fallbacks)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// mockiReviewRepository_Reassign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reassign'
-type mockiReviewRepository_Reassign_Call struct {
+// mockiTeamFallbackRepository_SetTeamFallbacks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTeamFallbacks'
+type mockiTeamFallbackRepository_SetTeamFallbacks_Call struct {
+	*mock.Call
+}
+
+// SetTeamFallbacks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - fallbacks []domain.ReviewerPool
+func (_e *mockiTeamFallbackRepository_Expecter) SetTeamFallbacks(ctx interface{}, teamName interface{}, fallbacks interface{}) *mockiTeamFallbackRepository_SetTeamFallbacks_Call {
+	return &mockiTeamFallbackRepository_SetTeamFallbacks_Call{Call: _e.mock.On("SetTeamFallbacks", ctx, teamName, fallbacks)}
+}
+
+func (_c *mockiTeamFallbackRepository_SetTeamFallbacks_Call) Run(run func(ctx context.Context, teamName string, fallbacks []domain.ReviewerPool)) *mockiTeamFallbackRepository_SetTeamFallbacks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []domain.ReviewerPool
+		if args[2] != nil {
+			arg2 = args[2].([]domain.ReviewerPool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiTeamFallbackRepository_SetTeamFallbacks_Call) Return(err error) *mockiTeamFallbackRepository_SetTeamFallbacks_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockiTeamFallbackRepository_SetTeamFallbacks_Call) RunAndReturn(run func(ctx context.Context, teamName string, fallbacks []domain.ReviewerPool) error) *mockiTeamFallbackRepository_SetTeamFallbacks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockiUserRepository creates a new instance of mockiUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiUserRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiUserRepository {
+	mock := &mockiUserRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiUserRepository is an autogenerated mock type for the iUserRepository type
+type mockiUserRepository struct {
+	mock.Mock
+}
+
+type mockiUserRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiUserRepository) EXPECT() *mockiUserRepository_Expecter {
+	return &mockiUserRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeactivateTeamUsers provides a mock function for the type mockiUserRepository
+func (_mock *mockiUserRepository) DeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error) {
+	ret := _mock.Called(ctx, teamName, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateTeamUsers")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]domain.User, error)); ok {
+		return returnFunc(ctx, teamName, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []domain.User); ok {
+		r0 = returnFunc(ctx, teamName, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, teamName, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiUserRepository_DeactivateTeamUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeactivateTeamUsers'
+type mockiUserRepository_DeactivateTeamUsers_Call struct {
 	*mock.Call
 }
 
-// Reassign is a helper method to define mock.On call
+// DeactivateTeamUsers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-//   - newReviewerID string
-//   - oldReviewerID string
-func (_e *mockiReviewRepository_Expecter) Reassign(ctx interface{}, prID interface{}, newReviewerID interface{}, oldReviewerID interface{}) *mockiReviewRepository_Reassign_Call {
-	return &mockiReviewRepository_Reassign_Call{Call: _e.mock.On("Reassign", ctx, prID, newReviewerID, oldReviewerID)}
+//   - teamName string
+//   - userIDs []string
+func (_e *mockiUserRepository_Expecter) DeactivateTeamUsers(ctx interface{}, teamName interface{}, userIDs interface{}) *mockiUserRepository_DeactivateTeamUsers_Call {
+	return &mockiUserRepository_DeactivateTeamUsers_Call{Call: _e.mock.On("DeactivateTeamUsers", ctx, teamName, userIDs)}
 }
 
-func (_c *mockiReviewRepository_Reassign_Call) Run(run func(ctx context.Context, prID string, newReviewerID string, oldReviewerID string)) *mockiReviewRepository_Reassign_Call {
+func (_c *mockiUserRepository_DeactivateTeamUsers_Call) Run(run func(ctx context.Context, teamName string, userIDs []string)) *mockiUserRepository_DeactivateTeamUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -569,78 +5745,112 @@ func (_c *mockiReviewRepository_Reassign_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 []string
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg2 = args[2].([]string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *mockiReviewRepository_Reassign_Call) Return(err error) *mockiReviewRepository_Reassign_Call {
-	_c.Call.Return(err)
+func (_c *mockiUserRepository_DeactivateTeamUsers_Call) Return(users []domain.User, err error) *mockiUserRepository_DeactivateTeamUsers_Call {
+	_c.Call.Return(users, err)
 	return _c
 }
 
-func (_c *mockiReviewRepository_Reassign_Call) RunAndReturn(run func(ctx context.Context, prID string, newReviewerID string, oldReviewerID string) error) *mockiReviewRepository_Reassign_Call {
+func (_c *mockiUserRepository_DeactivateTeamUsers_Call) RunAndReturn(run func(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error)) *mockiUserRepository_DeactivateTeamUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// newMockiPRUserRepository creates a new instance of mockiPRUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func newMockiPRUserRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *mockiPRUserRepository {
-	mock := &mockiPRUserRepository{}
-	mock.Mock.Test(t)
+// ExistsByID provides a mock function for the type mockiUserRepository
+func (_mock *mockiUserRepository) ExistsByID(ctx context.Context, userID string) (bool, error) {
+	ret := _mock.Called(ctx, userID)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for ExistsByID")
+	}
 
-	return mock
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// mockiPRUserRepository is an autogenerated mock type for the iPRUserRepository type
-type mockiPRUserRepository struct {
-	mock.Mock
+// mockiUserRepository_ExistsByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistsByID'
+type mockiUserRepository_ExistsByID_Call struct {
+	*mock.Call
 }
 
-type mockiPRUserRepository_Expecter struct {
-	mock *mock.Mock
+// ExistsByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *mockiUserRepository_Expecter) ExistsByID(ctx interface{}, userID interface{}) *mockiUserRepository_ExistsByID_Call {
+	return &mockiUserRepository_ExistsByID_Call{Call: _e.mock.On("ExistsByID", ctx, userID)}
 }
 
-func (_m *mockiPRUserRepository) EXPECT() *mockiPRUserRepository_Expecter {
-	return &mockiPRUserRepository_Expecter{mock: &_m.Mock}
+func (_c *mockiUserRepository_ExistsByID_Call) Run(run func(ctx context.Context, userID string)) *mockiUserRepository_ExistsByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
 }
 
-// ExistsByID provides a mock function for the type mockiPRUserRepository
-func (_mock *mockiPRUserRepository) ExistsByID(ctx context.Context, userID string) (bool, error) {
+func (_c *mockiUserRepository_ExistsByID_Call) Return(b bool, err error) *mockiUserRepository_ExistsByID_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *mockiUserRepository_ExistsByID_Call) RunAndReturn(run func(ctx context.Context, userID string) (bool, error)) *mockiUserRepository_ExistsByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type mockiUserRepository
+func (_mock *mockiUserRepository) GetByID(ctx context.Context, userID string) (domain.User, error) {
 	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ExistsByID")
+		panic("no return value specified for GetByID")
 	}
 
-	var r0 bool
+	var r0 domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
 		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
 		r0 = returnFunc(ctx, userID)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(domain.User)
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = returnFunc(ctx, userID)
@@ -650,19 +5860,19 @@ func (_mock *mockiPRUserRepository) ExistsByID(ctx context.Context, userID strin
 	return r0, r1
 }
 
-// mockiPRUserRepository_ExistsByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistsByID'
-type mockiPRUserRepository_ExistsByID_Call struct {
+// mockiUserRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type mockiUserRepository_GetByID_Call struct {
 	*mock.Call
 }
 
-// ExistsByID is a helper method to define mock.On call
+// GetByID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-func (_e *mockiPRUserRepository_Expecter) ExistsByID(ctx interface{}, userID interface{}) *mockiPRUserRepository_ExistsByID_Call {
-	return &mockiPRUserRepository_ExistsByID_Call{Call: _e.mock.On("ExistsByID", ctx, userID)}
+func (_e *mockiUserRepository_Expecter) GetByID(ctx interface{}, userID interface{}) *mockiUserRepository_GetByID_Call {
+	return &mockiUserRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, userID)}
 }
 
-func (_c *mockiPRUserRepository_ExistsByID_Call) Run(run func(ctx context.Context, userID string)) *mockiPRUserRepository_ExistsByID_Call {
+func (_c *mockiUserRepository_GetByID_Call) Run(run func(ctx context.Context, userID string)) *mockiUserRepository_GetByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -680,65 +5890,65 @@ func (_c *mockiPRUserRepository_ExistsByID_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *mockiPRUserRepository_ExistsByID_Call) Return(b bool, err error) *mockiPRUserRepository_ExistsByID_Call {
-	_c.Call.Return(b, err)
+func (_c *mockiUserRepository_GetByID_Call) Return(user domain.User, err error) *mockiUserRepository_GetByID_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *mockiPRUserRepository_ExistsByID_Call) RunAndReturn(run func(ctx context.Context, userID string) (bool, error)) *mockiPRUserRepository_ExistsByID_Call {
+func (_c *mockiUserRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, userID string) (domain.User, error)) *mockiUserRepository_GetByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetActiveByTeamName provides a mock function for the type mockiPRUserRepository
-func (_mock *mockiPRUserRepository) GetActiveByTeamName(ctx context.Context, teamName string) ([]domain.User, error) {
-	ret := _mock.Called(ctx, teamName)
+// List provides a mock function for the type mockiUserRepository
+func (_mock *mockiUserRepository) List(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetActiveByTeamName")
+		panic("no return value specified for List")
 	}
 
 	var r0 []domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.User, error)); ok {
-		return returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserFilter) ([]domain.User, error)); ok {
+		return returnFunc(ctx, filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.User); ok {
-		r0 = returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserFilter) []domain.User); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// mockiPRUserRepository_GetActiveByTeamName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveByTeamName'
-type mockiPRUserRepository_GetActiveByTeamName_Call struct {
+// mockiUserRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type mockiUserRepository_List_Call struct {
 	*mock.Call
 }
 
-// GetActiveByTeamName is a helper method to define mock.On call
+// List is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamName string
-func (_e *mockiPRUserRepository_Expecter) GetActiveByTeamName(ctx interface{}, teamName interface{}) *mockiPRUserRepository_GetActiveByTeamName_Call {
-	return &mockiPRUserRepository_GetActiveByTeamName_Call{Call: _e.mock.On("GetActiveByTeamName", ctx, teamName)}
+//   - filter domain.UserFilter
+func (_e *mockiUserRepository_Expecter) List(ctx interface{}, filter interface{}) *mockiUserRepository_List_Call {
+	return &mockiUserRepository_List_Call{Call: _e.mock.On("List", ctx, filter)}
 }
 
-func (_c *mockiPRUserRepository_GetActiveByTeamName_Call) Run(run func(ctx context.Context, teamName string)) *mockiPRUserRepository_GetActiveByTeamName_Call {
+func (_c *mockiUserRepository_List_Call) Run(run func(ctx context.Context, filter domain.UserFilter)) *mockiUserRepository_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 domain.UserFilter
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(domain.UserFilter)
 		}
 		run(
 			arg0,
@@ -748,55 +5958,56 @@ func (_c *mockiPRUserRepository_GetActiveByTeamName_Call) Run(run func(ctx conte
 	return _c
 }
 
-func (_c *mockiPRUserRepository_GetActiveByTeamName_Call) Return(users []domain.User, err error) *mockiPRUserRepository_GetActiveByTeamName_Call {
+func (_c *mockiUserRepository_List_Call) Return(users []domain.User, err error) *mockiUserRepository_List_Call {
 	_c.Call.Return(users, err)
 	return _c
 }
 
-func (_c *mockiPRUserRepository_GetActiveByTeamName_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]domain.User, error)) *mockiPRUserRepository_GetActiveByTeamName_Call {
+func (_c *mockiUserRepository_List_Call) RunAndReturn(run func(ctx context.Context, filter domain.UserFilter) ([]domain.User, error)) *mockiUserRepository_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function for the type mockiPRUserRepository
-func (_mock *mockiPRUserRepository) GetByID(ctx context.Context, userID string) (domain.User, error) {
-	ret := _mock.Called(ctx, userID)
+// SetIsActive provides a mock function for the type mockiUserRepository
+func (_mock *mockiUserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) (domain.User, error) {
+	ret := _mock.Called(ctx, userID, isActive)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for SetIsActive")
 	}
 
 	var r0 domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (domain.User, error)); ok {
+		return returnFunc(ctx, userID, isActive)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) domain.User); ok {
+		r0 = returnFunc(ctx, userID, isActive)
 	} else {
 		r0 = ret.Get(0).(domain.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = returnFunc(ctx, userID, isActive)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// mockiPRUserRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type mockiPRUserRepository_GetByID_Call struct {
+// mockiUserRepository_SetIsActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIsActive'
+type mockiUserRepository_SetIsActive_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
+// SetIsActive is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-func (_e *mockiPRUserRepository_Expecter) GetByID(ctx interface{}, userID interface{}) *mockiPRUserRepository_GetByID_Call {
-	return &mockiPRUserRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, userID)}
+//   - isActive bool
+func (_e *mockiUserRepository_Expecter) SetIsActive(ctx interface{}, userID interface{}, isActive interface{}) *mockiUserRepository_SetIsActive_Call {
+	return &mockiUserRepository_SetIsActive_Call{Call: _e.mock.On("SetIsActive", ctx, userID, isActive)}
 }
 
-func (_c *mockiPRUserRepository_GetByID_Call) Run(run func(ctx context.Context, userID string)) *mockiPRUserRepository_GetByID_Call {
+func (_c *mockiUserRepository_SetIsActive_Call) Run(run func(ctx context.Context, userID string, isActive bool)) *mockiUserRepository_SetIsActive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -806,98 +6017,148 @@ func (_c *mockiPRUserRepository_GetByID_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *mockiPRUserRepository_GetByID_Call) Return(user domain.User, err error) *mockiPRUserRepository_GetByID_Call {
+func (_c *mockiUserRepository_SetIsActive_Call) Return(user domain.User, err error) *mockiUserRepository_SetIsActive_Call {
 	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *mockiPRUserRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, userID string) (domain.User, error)) *mockiPRUserRepository_GetByID_Call {
+func (_c *mockiUserRepository_SetIsActive_Call) RunAndReturn(run func(ctx context.Context, userID string, isActive bool) (domain.User, error)) *mockiUserRepository_SetIsActive_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// newMockiTeamRepository creates a new instance of mockiTeamRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func newMockiTeamRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *mockiTeamRepository {
-	mock := &mockiTeamRepository{}
-	mock.Mock.Test(t)
+// SetMaxOpenReviews provides a mock function for the type mockiUserRepository
+func (_mock *mockiUserRepository) SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error) {
+	ret := _mock.Called(ctx, userID, maxOpenReviews)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for SetMaxOpenReviews")
+	}
 
-	return mock
+	var r0 domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (domain.User, error)); ok {
+		return returnFunc(ctx, userID, maxOpenReviews)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) domain.User); ok {
+		r0 = returnFunc(ctx, userID, maxOpenReviews)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, userID, maxOpenReviews)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// mockiTeamRepository is an autogenerated mock type for the iTeamRepository type
-type mockiTeamRepository struct {
-	mock.Mock
+// mockiUserRepository_SetMaxOpenReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMaxOpenReviews'
+type mockiUserRepository_SetMaxOpenReviews_Call struct {
+	*mock.Call
 }
 
-type mockiTeamRepository_Expecter struct {
-	mock *mock.Mock
+// SetMaxOpenReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - maxOpenReviews int
+func (_e *mockiUserRepository_Expecter) SetMaxOpenReviews(ctx interface{}, userID interface{}, maxOpenReviews interface{}) *mockiUserRepository_SetMaxOpenReviews_Call {
+	return &mockiUserRepository_SetMaxOpenReviews_Call{Call: _e.mock.On("SetMaxOpenReviews", ctx, userID, maxOpenReviews)}
 }
 
-func (_m *mockiTeamRepository) EXPECT() *mockiTeamRepository_Expecter {
-	return &mockiTeamRepository_Expecter{mock: &_m.Mock}
+func (_c *mockiUserRepository_SetMaxOpenReviews_Call) Run(run func(ctx context.Context, userID string, maxOpenReviews int)) *mockiUserRepository_SetMaxOpenReviews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
 }
 
-// Add provides a mock function for the type mockiTeamRepository
-func (_mock *mockiTeamRepository) Add(ctx context.Context, team domain.Team) (domain.Team, error) {
-	ret := _mock.Called(ctx, team)
+func (_c *mockiUserRepository_SetMaxOpenReviews_Call) Return(user domain.User, err error) *mockiUserRepository_SetMaxOpenReviews_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *mockiUserRepository_SetMaxOpenReviews_Call) RunAndReturn(run func(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error)) *mockiUserRepository_SetMaxOpenReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SoftDelete provides a mock function for the type mockiUserRepository
+func (_mock *mockiUserRepository) SoftDelete(ctx context.Context, userID string) (domain.User, error) {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Add")
+		panic("no return value specified for SoftDelete")
 	}
 
-	var r0 domain.Team
+	var r0 domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Team) (domain.Team, error)); ok {
-		return returnFunc(ctx, team)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
+		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Team) domain.Team); ok {
-		r0 = returnFunc(ctx, team)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
-		r0 = ret.Get(0).(domain.Team)
+		r0 = ret.Get(0).(domain.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Team) error); ok {
-		r1 = returnFunc(ctx, team)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// mockiTeamRepository_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
-type mockiTeamRepository_Add_Call struct {
+// mockiUserRepository_SoftDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SoftDelete'
+type mockiUserRepository_SoftDelete_Call struct {
 	*mock.Call
 }
 
-// Add is a helper method to define mock.On call
+// SoftDelete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - team domain.Team
-func (_e *mockiTeamRepository_Expecter) Add(ctx interface{}, team interface{}) *mockiTeamRepository_Add_Call {
-	return &mockiTeamRepository_Add_Call{Call: _e.mock.On("Add", ctx, team)}
+//   - userID string
+func (_e *mockiUserRepository_Expecter) SoftDelete(ctx interface{}, userID interface{}) *mockiUserRepository_SoftDelete_Call {
+	return &mockiUserRepository_SoftDelete_Call{Call: _e.mock.On("SoftDelete", ctx, userID)}
 }
 
-func (_c *mockiTeamRepository_Add_Call) Run(run func(ctx context.Context, team domain.Team)) *mockiTeamRepository_Add_Call {
+func (_c *mockiUserRepository_SoftDelete_Call) Run(run func(ctx context.Context, userID string)) *mockiUserRepository_SoftDelete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 domain.Team
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(domain.Team)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -907,99 +6168,136 @@ func (_c *mockiTeamRepository_Add_Call) Run(run func(ctx context.Context, team d
 	return _c
 }
 
-func (_c *mockiTeamRepository_Add_Call) Return(team1 domain.Team, err error) *mockiTeamRepository_Add_Call {
-	_c.Call.Return(team1, err)
+func (_c *mockiUserRepository_SoftDelete_Call) Return(user domain.User, err error) *mockiUserRepository_SoftDelete_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *mockiTeamRepository_Add_Call) RunAndReturn(run func(ctx context.Context, team domain.Team) (domain.Team, error)) *mockiTeamRepository_Add_Call {
+func (_c *mockiUserRepository_SoftDelete_Call) RunAndReturn(run func(ctx context.Context, userID string) (domain.User, error)) *mockiUserRepository_SoftDelete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Exists provides a mock function for the type mockiTeamRepository
-func (_mock *mockiTeamRepository) Exists(ctx context.Context, teamName string) (bool, error) {
-	ret := _mock.Called(ctx, teamName)
+// newMockiReviewerAssigner creates a new instance of mockiReviewerAssigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockiReviewerAssigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockiReviewerAssigner {
+	mock := &mockiReviewerAssigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockiReviewerAssigner is an autogenerated mock type for the iReviewerAssigner type
+type mockiReviewerAssigner struct {
+	mock.Mock
+}
+
+type mockiReviewerAssigner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockiReviewerAssigner) EXPECT() *mockiReviewerAssigner_Expecter {
+	return &mockiReviewerAssigner_Expecter{mock: &_m.Mock}
+}
+
+// HandOverReviews provides a mock function for the type mockiReviewerAssigner
+func (_mock *mockiReviewerAssigner) HandOverReviews(ctx context.Context, reviewerIDs []string, authorTeam string) ([]domain.ReviewerReplacement, error) {
+	ret := _mock.Called(ctx, reviewerIDs, authorTeam)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Exists")
+		panic("no return value specified for HandOverReviews")
 	}
 
-	var r0 bool
+	var r0 []domain.ReviewerReplacement
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
-		return returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string) ([]domain.ReviewerReplacement, error)); ok {
+		return returnFunc(ctx, reviewerIDs, authorTeam)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string) []domain.ReviewerReplacement); ok {
+		r0 = returnFunc(ctx, reviewerIDs, authorTeam)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ReviewerReplacement)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = returnFunc(ctx, reviewerIDs, authorTeam)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// mockiTeamRepository_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
-type mockiTeamRepository_Exists_Call struct {
+// mockiReviewerAssigner_HandOverReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandOverReviews'
+type mockiReviewerAssigner_HandOverReviews_Call struct {
 	*mock.Call
 }
 
-// Exists is a helper method to define mock.On call
+// HandOverReviews is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamName string
-func (_e *mockiTeamRepository_Expecter) Exists(ctx interface{}, teamName interface{}) *mockiTeamRepository_Exists_Call {
-	return &mockiTeamRepository_Exists_Call{Call: _e.mock.On("Exists", ctx, teamName)}
+//   - reviewerIDs []string
+//   - authorTeam string
+func (_e *mockiReviewerAssigner_Expecter) HandOverReviews(ctx interface{}, reviewerIDs interface{}, authorTeam interface{}) *mockiReviewerAssigner_HandOverReviews_Call {
+	return &mockiReviewerAssigner_HandOverReviews_Call{Call: _e.mock.On("HandOverReviews", ctx, reviewerIDs, authorTeam)}
 }
 
-func (_c *mockiTeamRepository_Exists_Call) Run(run func(ctx context.Context, teamName string)) *mockiTeamRepository_Exists_Call {
+func (_c *mockiReviewerAssigner_HandOverReviews_Call) Run(run func(ctx context.Context, reviewerIDs []string, authorTeam string)) *mockiReviewerAssigner_HandOverReviews_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *mockiTeamRepository_Exists_Call) Return(b bool, err error) *mockiTeamRepository_Exists_Call {
-	_c.Call.Return(b, err)
+func (_c *mockiReviewerAssigner_HandOverReviews_Call) Return(reviewerReplacements []domain.ReviewerReplacement, err error) *mockiReviewerAssigner_HandOverReviews_Call {
+	_c.Call.Return(reviewerReplacements, err)
 	return _c
 }
 
-func (_c *mockiTeamRepository_Exists_Call) RunAndReturn(run func(ctx context.Context, teamName string) (bool, error)) *mockiTeamRepository_Exists_Call {
+func (_c *mockiReviewerAssigner_HandOverReviews_Call) RunAndReturn(run func(ctx context.Context, reviewerIDs []string, authorTeam string) ([]domain.ReviewerReplacement, error)) *mockiReviewerAssigner_HandOverReviews_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Get provides a mock function for the type mockiTeamRepository
-func (_mock *mockiTeamRepository) Get(ctx context.Context, teamName string) (domain.Team, error) {
+// TopUpReviewers provides a mock function for the type mockiReviewerAssigner
+func (_mock *mockiReviewerAssigner) TopUpReviewers(ctx context.Context, teamName string) ([]domain.PullRequest, error) {
 	ret := _mock.Called(ctx, teamName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for TopUpReviewers")
 	}
 
-	var r0 domain.Team
+	var r0 []domain.PullRequest
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.Team, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.PullRequest, error)); ok {
 		return returnFunc(ctx, teamName)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.Team); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.PullRequest); ok {
 		r0 = returnFunc(ctx, teamName)
 	} else {
-		r0 = ret.Get(0).(domain.Team)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PullRequest)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = returnFunc(ctx, teamName)
@@ -1009,19 +6307,19 @@ func (_mock *mockiTeamRepository) Get(ctx context.Context, teamName string) (dom
 	return r0, r1
 }
 
-// mockiTeamRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type mockiTeamRepository_Get_Call struct {
+// mockiReviewerAssigner_TopUpReviewers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TopUpReviewers'
+type mockiReviewerAssigner_TopUpReviewers_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
+// TopUpReviewers is a helper method to define mock.On call
 //   - ctx context.Context
 //   - teamName string
-func (_e *mockiTeamRepository_Expecter) Get(ctx interface{}, teamName interface{}) *mockiTeamRepository_Get_Call {
-	return &mockiTeamRepository_Get_Call{Call: _e.mock.On("Get", ctx, teamName)}
+func (_e *mockiReviewerAssigner_Expecter) TopUpReviewers(ctx interface{}, teamName interface{}) *mockiReviewerAssigner_TopUpReviewers_Call {
+	return &mockiReviewerAssigner_TopUpReviewers_Call{Call: _e.mock.On("TopUpReviewers", ctx, teamName)}
 }
 
-func (_c *mockiTeamRepository_Get_Call) Run(run func(ctx context.Context, teamName string)) *mockiTeamRepository_Get_Call {
+func (_c *mockiReviewerAssigner_TopUpReviewers_Call) Run(run func(ctx context.Context, teamName string)) *mockiReviewerAssigner_TopUpReviewers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1039,23 +6337,23 @@ func (_c *mockiTeamRepository_Get_Call) Run(run func(ctx context.Context, teamNa
 	return _c
 }
 
-func (_c *mockiTeamRepository_Get_Call) Return(team domain.Team, err error) *mockiTeamRepository_Get_Call {
-	_c.Call.Return(team, err)
+func (_c *mockiReviewerAssigner_TopUpReviewers_Call) Return(pullRequests []domain.PullRequest, err error) *mockiReviewerAssigner_TopUpReviewers_Call {
+	_c.Call.Return(pullRequests, err)
 	return _c
 }
 
-func (_c *mockiTeamRepository_Get_Call) RunAndReturn(run func(ctx context.Context, teamName string) (domain.Team, error)) *mockiTeamRepository_Get_Call {
+func (_c *mockiReviewerAssigner_TopUpReviewers_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]domain.PullRequest, error)) *mockiReviewerAssigner_TopUpReviewers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// newMockiTeamUserRepository creates a new instance of mockiTeamUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// newMockiWebhookRepository creates a new instance of mockiWebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func newMockiTeamUserRepository(t interface {
+func newMockiWebhookRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *mockiTeamUserRepository {
-	mock := &mockiTeamUserRepository{}
+}) *mockiWebhookRepository {
+	mock := &mockiWebhookRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -1063,68 +6361,68 @@ func newMockiTeamUserRepository(t interface {
 	return mock
 }
 
-// mockiTeamUserRepository is an autogenerated mock type for the iTeamUserRepository type
-type mockiTeamUserRepository struct {
+// mockiWebhookRepository is an autogenerated mock type for the iWebhookRepository type
+type mockiWebhookRepository struct {
 	mock.Mock
 }
 
-type mockiTeamUserRepository_Expecter struct {
+type mockiWebhookRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *mockiTeamUserRepository) EXPECT() *mockiTeamUserRepository_Expecter {
-	return &mockiTeamUserRepository_Expecter{mock: &_m.Mock}
+func (_m *mockiWebhookRepository) EXPECT() *mockiWebhookRepository_Expecter {
+	return &mockiWebhookRepository_Expecter{mock: &_m.Mock}
 }
 
-// Add provides a mock function for the type mockiTeamUserRepository
-func (_mock *mockiTeamUserRepository) Add(ctx context.Context, users []domain.User) ([]domain.User, error) {
-	ret := _mock.Called(ctx, users)
+// ListDeliveries provides a mock function for the type mockiWebhookRepository
+func (_mock *mockiWebhookRepository) ListDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Add")
+		panic("no return value specified for ListDeliveries")
 	}
 
-	var r0 []domain.User
+	var r0 []domain.WebhookDelivery
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.User) ([]domain.User, error)); ok {
-		return returnFunc(ctx, users)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.User) []domain.User); ok {
-		r0 = returnFunc(ctx, users)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.WebhookDeliveryFilter) []domain.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.User)
+			r0 = ret.Get(0).([]domain.WebhookDelivery)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []domain.User) error); ok {
-		r1 = returnFunc(ctx, users)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.WebhookDeliveryFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// mockiTeamUserRepository_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
-type mockiTeamUserRepository_Add_Call struct {
+// mockiWebhookRepository_ListDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeliveries'
+type mockiWebhookRepository_ListDeliveries_Call struct {
 	*mock.Call
 }
 
-// Add is a helper method to define mock.On call
+// ListDeliveries is a helper method to define mock.On call
 //   - ctx context.Context
-//   - users []domain.User
-func (_e *mockiTeamUserRepository_Expecter) Add(ctx interface{}, users interface{}) *mockiTeamUserRepository_Add_Call {
-	return &mockiTeamUserRepository_Add_Call{Call: _e.mock.On("Add", ctx, users)}
+//   - filter domain.WebhookDeliveryFilter
+func (_e *mockiWebhookRepository_Expecter) ListDeliveries(ctx interface{}, filter interface{}) *mockiWebhookRepository_ListDeliveries_Call {
+	return &mockiWebhookRepository_ListDeliveries_Call{Call: _e.mock.On("ListDeliveries", ctx, filter)}
 }
 
-func (_c *mockiTeamUserRepository_Add_Call) Run(run func(ctx context.Context, users []domain.User)) *mockiTeamUserRepository_Add_Call {
+func (_c *mockiWebhookRepository_ListDeliveries_Call) Run(run func(ctx context.Context, filter domain.WebhookDeliveryFilter)) *mockiWebhookRepository_ListDeliveries_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []domain.User
+		var arg1 domain.WebhookDeliveryFilter
 		if args[1] != nil {
-			arg1 = args[1].([]domain.User)
+			arg1 = args[1].(domain.WebhookDeliveryFilter)
 		}
 		run(
 			arg0,
@@ -1134,124 +6432,125 @@ func (_c *mockiTeamUserRepository_Add_Call) Run(run func(ctx context.Context, us
 	return _c
 }
 
-func (_c *mockiTeamUserRepository_Add_Call) Return(users1 []domain.User, err error) *mockiTeamUserRepository_Add_Call {
-	_c.Call.Return(users1, err)
+func (_c *mockiWebhookRepository_ListDeliveries_Call) Return(webhookDeliverys []domain.WebhookDelivery, err error) *mockiWebhookRepository_ListDeliveries_Call {
+	_c.Call.Return(webhookDeliverys, err)
 	return _c
 }
 
-func (_c *mockiTeamUserRepository_Add_Call) RunAndReturn(run func(ctx context.Context, users []domain.User) ([]domain.User, error)) *mockiTeamUserRepository_Add_Call {
+func (_c *mockiWebhookRepository_ListDeliveries_Call) RunAndReturn(run func(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)) *mockiWebhookRepository_ListDeliveries_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// BatchExistsByID provides a mock function for the type mockiTeamUserRepository
-func (_mock *mockiTeamUserRepository) BatchExistsByID(ctx context.Context, users []domain.User) map[domain.User]bool {
-	ret := _mock.Called(ctx, users)
+// ListSubscriptions provides a mock function for the type mockiWebhookRepository
+func (_mock *mockiWebhookRepository) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for BatchExistsByID")
+		panic("no return value specified for ListSubscriptions")
 	}
 
-	var r0 map[domain.User]bool
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.User) map[domain.User]bool); ok {
-		r0 = returnFunc(ctx, users)
+	var r0 []domain.WebhookSubscription
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.WebhookSubscription, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.WebhookSubscription); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[domain.User]bool)
+			r0 = ret.Get(0).([]domain.WebhookSubscription)
 		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// mockiTeamUserRepository_BatchExistsByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchExistsByID'
-type mockiTeamUserRepository_BatchExistsByID_Call struct {
+// mockiWebhookRepository_ListSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSubscriptions'
+type mockiWebhookRepository_ListSubscriptions_Call struct {
 	*mock.Call
 }
 
-// BatchExistsByID is a helper method to define mock.On call
+// ListSubscriptions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - users []domain.User
-func (_e *mockiTeamUserRepository_Expecter) BatchExistsByID(ctx interface{}, users interface{}) *mockiTeamUserRepository_BatchExistsByID_Call {
-	return &mockiTeamUserRepository_BatchExistsByID_Call{Call: _e.mock.On("BatchExistsByID", ctx, users)}
+func (_e *mockiWebhookRepository_Expecter) ListSubscriptions(ctx interface{}) *mockiWebhookRepository_ListSubscriptions_Call {
+	return &mockiWebhookRepository_ListSubscriptions_Call{Call: _e.mock.On("ListSubscriptions", ctx)}
 }
 
-func (_c *mockiTeamUserRepository_BatchExistsByID_Call) Run(run func(ctx context.Context, users []domain.User)) *mockiTeamUserRepository_BatchExistsByID_Call {
+func (_c *mockiWebhookRepository_ListSubscriptions_Call) Run(run func(ctx context.Context)) *mockiWebhookRepository_ListSubscriptions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []domain.User
-		if args[1] != nil {
-			arg1 = args[1].([]domain.User)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *mockiTeamUserRepository_BatchExistsByID_Call) Return(userToBool map[domain.User]bool) *mockiTeamUserRepository_BatchExistsByID_Call {
-	_c.Call.Return(userToBool)
+func (_c *mockiWebhookRepository_ListSubscriptions_Call) Return(webhookSubscriptions []domain.WebhookSubscription, err error) *mockiWebhookRepository_ListSubscriptions_Call {
+	_c.Call.Return(webhookSubscriptions, err)
 	return _c
 }
 
-func (_c *mockiTeamUserRepository_BatchExistsByID_Call) RunAndReturn(run func(ctx context.Context, users []domain.User) map[domain.User]bool) *mockiTeamUserRepository_BatchExistsByID_Call {
+func (_c *mockiWebhookRepository_ListSubscriptions_Call) RunAndReturn(run func(ctx context.Context) ([]domain.WebhookSubscription, error)) *mockiWebhookRepository_ListSubscriptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByTeamName provides a mock function for the type mockiTeamUserRepository
-func (_mock *mockiTeamUserRepository) GetByTeamName(ctx context.Context, teamName string) ([]domain.User, error) {
-	ret := _mock.Called(ctx, teamName)
+// RetryDelivery provides a mock function for the type mockiWebhookRepository
+func (_mock *mockiWebhookRepository) RetryDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByTeamName")
+		panic("no return value specified for RetryDelivery")
 	}
 
-	var r0 []domain.User
+	var r0 domain.WebhookDelivery
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.User, error)); ok {
-		return returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (domain.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.User); ok {
-		r0 = returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) domain.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.User)
-		}
+		r0 = ret.Get(0).(domain.WebhookDelivery)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// mockiTeamUserRepository_GetByTeamName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTeamName'
-type mockiTeamUserRepository_GetByTeamName_Call struct {
+// mockiWebhookRepository_RetryDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryDelivery'
+type mockiWebhookRepository_RetryDelivery_Call struct {
 	*mock.Call
 }
 
-// GetByTeamName is a helper method to define mock.On call
+// RetryDelivery is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamName string
-func (_e *mockiTeamUserRepository_Expecter) GetByTeamName(ctx interface{}, teamName interface{}) *mockiTeamUserRepository_GetByTeamName_Call {
-	return &mockiTeamUserRepository_GetByTeamName_Call{Call: _e.mock.On("GetByTeamName", ctx, teamName)}
+//   - id int64
+func (_e *mockiWebhookRepository_Expecter) RetryDelivery(ctx interface{}, id interface{}) *mockiWebhookRepository_RetryDelivery_Call {
+	return &mockiWebhookRepository_RetryDelivery_Call{Call: _e.mock.On("RetryDelivery", ctx, id)}
 }
 
-func (_c *mockiTeamUserRepository_GetByTeamName_Call) Run(run func(ctx context.Context, teamName string)) *mockiTeamUserRepository_GetByTeamName_Call {
+func (_c *mockiWebhookRepository_RetryDelivery_Call) Run(run func(ctx context.Context, id int64)) *mockiWebhookRepository_RetryDelivery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int64)
 		}
 		run(
 			arg0,
@@ -1261,90 +6560,63 @@ func (_c *mockiTeamUserRepository_GetByTeamName_Call) Run(run func(ctx context.C
 	return _c
 }
 
-func (_c *mockiTeamUserRepository_GetByTeamName_Call) Return(users []domain.User, err error) *mockiTeamUserRepository_GetByTeamName_Call {
-	_c.Call.Return(users, err)
+func (_c *mockiWebhookRepository_RetryDelivery_Call) Return(webhookDelivery domain.WebhookDelivery, err error) *mockiWebhookRepository_RetryDelivery_Call {
+	_c.Call.Return(webhookDelivery, err)
 	return _c
 }
 
-func (_c *mockiTeamUserRepository_GetByTeamName_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]domain.User, error)) *mockiTeamUserRepository_GetByTeamName_Call {
+func (_c *mockiWebhookRepository_RetryDelivery_Call) RunAndReturn(run func(ctx context.Context, id int64) (domain.WebhookDelivery, error)) *mockiWebhookRepository_RetryDelivery_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// newMockiUserRepository creates a new instance of mockiUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func newMockiUserRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *mockiUserRepository {
-	mock := &mockiUserRepository{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// mockiUserRepository is an autogenerated mock type for the iUserRepository type
-type mockiUserRepository struct {
-	mock.Mock
-}
-
-type mockiUserRepository_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *mockiUserRepository) EXPECT() *mockiUserRepository_Expecter {
-	return &mockiUserRepository_Expecter{mock: &_m.Mock}
-}
-
-// ExistsByID provides a mock function for the type mockiUserRepository
-func (_mock *mockiUserRepository) ExistsByID(ctx context.Context, userID string) (bool, error) {
-	ret := _mock.Called(ctx, userID)
+// Subscribe provides a mock function for the type mockiWebhookRepository
+func (_mock *mockiWebhookRepository) Subscribe(ctx context.Context, subscription domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	ret := _mock.Called(ctx, subscription)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ExistsByID")
+		panic("no return value specified for Subscribe")
 	}
 
-	var r0 bool
+	var r0 domain.WebhookSubscription
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.WebhookSubscription) (domain.WebhookSubscription, error)); ok {
+		return returnFunc(ctx, subscription)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.WebhookSubscription) domain.WebhookSubscription); ok {
+		r0 = returnFunc(ctx, subscription)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(domain.WebhookSubscription)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.WebhookSubscription) error); ok {
+		r1 = returnFunc(ctx, subscription)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// mockiUserRepository_ExistsByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistsByID'
-type mockiUserRepository_ExistsByID_Call struct {
+// mockiWebhookRepository_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type mockiWebhookRepository_Subscribe_Call struct {
 	*mock.Call
 }
 
-// ExistsByID is a helper method to define mock.On call
+// Subscribe is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *mockiUserRepository_Expecter) ExistsByID(ctx interface{}, userID interface{}) *mockiUserRepository_ExistsByID_Call {
-	return &mockiUserRepository_ExistsByID_Call{Call: _e.mock.On("ExistsByID", ctx, userID)}
+//   - subscription domain.WebhookSubscription
+func (_e *mockiWebhookRepository_Expecter) Subscribe(ctx interface{}, subscription interface{}) *mockiWebhookRepository_Subscribe_Call {
+	return &mockiWebhookRepository_Subscribe_Call{Call: _e.mock.On("Subscribe", ctx, subscription)}
 }
 
-func (_c *mockiUserRepository_ExistsByID_Call) Run(run func(ctx context.Context, userID string)) *mockiUserRepository_ExistsByID_Call {
+func (_c *mockiWebhookRepository_Subscribe_Call) Run(run func(ctx context.Context, subscription domain.WebhookSubscription)) *mockiWebhookRepository_Subscribe_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 domain.WebhookSubscription
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(domain.WebhookSubscription)
 		}
 		run(
 			arg0,
@@ -1354,84 +6626,69 @@ func (_c *mockiUserRepository_ExistsByID_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *mockiUserRepository_ExistsByID_Call) Return(b bool, err error) *mockiUserRepository_ExistsByID_Call {
-	_c.Call.Return(b, err)
+func (_c *mockiWebhookRepository_Subscribe_Call) Return(webhookSubscription domain.WebhookSubscription, err error) *mockiWebhookRepository_Subscribe_Call {
+	_c.Call.Return(webhookSubscription, err)
 	return _c
 }
 
-func (_c *mockiUserRepository_ExistsByID_Call) RunAndReturn(run func(ctx context.Context, userID string) (bool, error)) *mockiUserRepository_ExistsByID_Call {
+func (_c *mockiWebhookRepository_Subscribe_Call) RunAndReturn(run func(ctx context.Context, subscription domain.WebhookSubscription) (domain.WebhookSubscription, error)) *mockiWebhookRepository_Subscribe_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetIsActive provides a mock function for the type mockiUserRepository
-func (_mock *mockiUserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) (domain.User, error) {
-	ret := _mock.Called(ctx, userID, isActive)
+// Unsubscribe provides a mock function for the type mockiWebhookRepository
+func (_mock *mockiWebhookRepository) Unsubscribe(ctx context.Context, id int64) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetIsActive")
+		panic("no return value specified for Unsubscribe")
 	}
 
-	var r0 domain.User
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (domain.User, error)); ok {
-		return returnFunc(ctx, userID, isActive)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) domain.User); ok {
-		r0 = returnFunc(ctx, userID, isActive)
-	} else {
-		r0 = ret.Get(0).(domain.User)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
-		r1 = returnFunc(ctx, userID, isActive)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// mockiUserRepository_SetIsActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIsActive'
-type mockiUserRepository_SetIsActive_Call struct {
+// mockiWebhookRepository_Unsubscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unsubscribe'
+type mockiWebhookRepository_Unsubscribe_Call struct {
 	*mock.Call
 }
 
-// SetIsActive is a helper method to define mock.On call
+// Unsubscribe is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - isActive bool
-func (_e *mockiUserRepository_Expecter) SetIsActive(ctx interface{}, userID interface{}, isActive interface{}) *mockiUserRepository_SetIsActive_Call {
-	return &mockiUserRepository_SetIsActive_Call{Call: _e.mock.On("SetIsActive", ctx, userID, isActive)}
+//   - id int64
+func (_e *mockiWebhookRepository_Expecter) Unsubscribe(ctx interface{}, id interface{}) *mockiWebhookRepository_Unsubscribe_Call {
+	return &mockiWebhookRepository_Unsubscribe_Call{Call: _e.mock.On("Unsubscribe", ctx, id)}
 }
 
-func (_c *mockiUserRepository_SetIsActive_Call) Run(run func(ctx context.Context, userID string, isActive bool)) *mockiUserRepository_SetIsActive_Call {
+func (_c *mockiWebhookRepository_Unsubscribe_Call) Run(run func(ctx context.Context, id int64)) *mockiWebhookRepository_Unsubscribe_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 bool
-		if args[2] != nil {
-			arg2 = args[2].(bool)
+			arg1 = args[1].(int64)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *mockiUserRepository_SetIsActive_Call) Return(user domain.User, err error) *mockiUserRepository_SetIsActive_Call {
-	_c.Call.Return(user, err)
+func (_c *mockiWebhookRepository_Unsubscribe_Call) Return(err error) *mockiWebhookRepository_Unsubscribe_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *mockiUserRepository_SetIsActive_Call) RunAndReturn(run func(ctx context.Context, userID string, isActive bool) (domain.User, error)) *mockiUserRepository_SetIsActive_Call {
+func (_c *mockiWebhookRepository_Unsubscribe_Call) RunAndReturn(run func(ctx context.Context, id int64) error) *mockiWebhookRepository_Unsubscribe_Call {
 	_c.Call.Return(run)
 	return _c
 }
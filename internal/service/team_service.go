@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/artmexbet/avito_test_task/internal/domain"
 )
@@ -11,61 +13,314 @@ type iTeamRepository interface {
 	Get(ctx context.Context, teamName string) (domain.Team, error)
 	Add(ctx context.Context, team domain.Team) (domain.Team, error)
 	Exists(ctx context.Context, teamName string) (bool, error)
+	Rename(ctx context.Context, teamName, newName string) (domain.Team, error)
+	Delete(ctx context.Context, teamName string) error
 }
 
 type iTeamUserRepository interface {
 	Add(ctx context.Context, users []domain.User) ([]domain.User, error)
-	BatchExistsByID(ctx context.Context, users []domain.User) map[domain.User]bool
+	GetByID(ctx context.Context, userID string) (domain.User, error)
+	GetByIDs(ctx context.Context, userIDs []string) ([]domain.User, error)
 	GetByTeamName(ctx context.Context, teamName string) ([]domain.User, error)
+	DeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error)
+	MoveUsersToTeam(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error)
+	MoveDeletedUsers(ctx context.Context, teamName, newTeamName string) ([]domain.User, error)
+	SetIsActive(ctx context.Context, userID string, isActive bool) (domain.User, error)
+}
+
+// iTeamFallbackRepository stores the fallback reviewer pools of teams
+type iTeamFallbackRepository interface {
+	GetTeamFallbacks(ctx context.Context, teamName string) ([]domain.ReviewerPool, error)
+	SetTeamFallbacks(ctx context.Context, teamName string, fallbacks []domain.ReviewerPool) error
 }
 
 type TeamService struct {
-	repository     iTeamRepository
-	userRepository iTeamUserRepository
+	repository         iTeamRepository
+	userRepository     iTeamUserRepository
+	fallbackRepository iTeamFallbackRepository
+	reviewerAssigner   iReviewerAssigner
+	auditRecorder      iAuditRecorder
+	transactor         iTransactor
 }
 
-func NewTeamService(repository iTeamRepository, userRepository iTeamUserRepository) *TeamService {
+func NewTeamService(
+	repository iTeamRepository,
+	userRepository iTeamUserRepository,
+	fallbackRepository iTeamFallbackRepository,
+	reviewerAssigner iReviewerAssigner,
+	auditRecorder iAuditRecorder,
+	transactor iTransactor,
+) *TeamService {
 	return &TeamService{
-		repository:     repository,
-		userRepository: userRepository,
+		repository:         repository,
+		userRepository:     userRepository,
+		fallbackRepository: fallbackRepository,
+		reviewerAssigner:   reviewerAssigner,
+		auditRecorder:      auditRecorder,
+		transactor:         transactor,
+	}
+}
+
+// Add adds the team in the given mode atomically, every change is recorded in the audit log.
+// Listed members that conflict with stored users are never applied: the whole call fails with
+// *domain.TeamConflictError. New and activated members may review pull requests of the team that lack reviewers
+func (s *TeamService) Add(
+	ctx context.Context,
+	team domain.Team,
+	mode domain.TeamAddMode,
+) (domain.TeamAddResult, error) {
+	var result domain.TeamAddResult
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.add(ctx, team, mode)
+		return err
+	})
+	if err != nil {
+		return domain.TeamAddResult{}, err
 	}
+	return result, nil
 }
 
-func (s *TeamService) Add(ctx context.Context, team domain.Team) (domain.Team, error) {
+func (s *TeamService) add(
+	ctx context.Context,
+	team domain.Team,
+	mode domain.TeamAddMode,
+) (domain.TeamAddResult, error) {
+	modes := []domain.TeamAddMode{domain.TeamAddModeCreateOnly, domain.TeamAddModeMerge, domain.TeamAddModeReplace}
+	if !slices.Contains(modes, mode) {
+		return domain.TeamAddResult{}, fmt.Errorf("%w: unknown mode %s", domain.ErrInvalidTeamChange, mode)
+	}
 	exists, err := s.repository.Exists(ctx, team.Name)
 	if err != nil {
-		return domain.Team{}, fmt.Errorf("failed to check if team exists by name %s: %w", team.Name, err)
+		return domain.TeamAddResult{}, fmt.Errorf("failed to check if team exists by name %s: %w", team.Name, err)
 	}
-	if exists {
-		return domain.Team{}, fmt.Errorf("team with name %s: %w", team.Name, domain.ErrTeamAlreadyExists)
+	if exists && mode == domain.TeamAddModeCreateOnly {
+		return domain.TeamAddResult{}, fmt.Errorf("team with name %s: %w", team.Name, domain.ErrTeamAlreadyExists)
 	}
 
-	teamFromDB, err := s.repository.Add(ctx, team)
+	result := domain.TeamAddResult{ //nolint:exhaustruct // Состав команды заполняется в конце
+		TeamCreated:  !exists,
+		Created:      []string{},
+		Moved:        []string{},
+		Untouched:    []string{},
+		Activated:    []string{},
+		Deactivated:  []string{},
+		Replacements: []domain.ReviewerReplacement{},
+	}
+	plan, err := s.planMembers(ctx, team, mode, &result)
 	if err != nil {
-		return domain.Team{}, fmt.Errorf("failed to add team to repository: %w", err)
+		return domain.TeamAddResult{}, err
 	}
-	teamFromDB.Members = team.Members
 
-	// Check if any user is not exist
-	userExistMap := s.userRepository.BatchExistsByID(ctx, team.Members)
-	var usersToAdd []domain.User
-	for _, user := range team.Members {
-		if !userExistMap[user] {
-			usersToAdd = append(usersToAdd, user)
+	if !exists {
+		teamFromDB, err := s.repository.Add(ctx, team)
+		if err != nil {
+			return domain.TeamAddResult{}, fmt.Errorf("failed to add team to repository: %w", err)
+		}
+		teamFromDB.Members = team.Members
+		if err := recordAudit(ctx, s.auditRecorder, domain.AuditActionTeamAdd, domain.AuditEntityTeam, team.Name,
+			nil, teamSnapshot(teamFromDB)); err != nil {
+			return domain.TeamAddResult{}, err
+		}
+	}
+	// неперечисленных выключаем первыми, чтобы их ревью не достались им же и новые участники могли их подхватить
+	if mode == domain.TeamAddModeReplace {
+		if err := s.deactivateUnlisted(ctx, team, &result); err != nil {
+			return domain.TeamAddResult{}, err
 		}
 	}
+	if err := s.createMembers(ctx, team.Name, plan.create); err != nil {
+		return domain.TeamAddResult{}, err
+	}
+	if len(plan.move) > 0 {
+		_, replacements, err := s.moveUsers(ctx, team.Name, plan.move)
+		if err != nil {
+			return domain.TeamAddResult{}, err
+		}
+		result.Replacements = append(result.Replacements, replacements...)
+	}
+	if err := s.activateMembers(ctx, team.Name, plan.activate); err != nil {
+		return domain.TeamAddResult{}, err
+	}
 
-	if len(usersToAdd) == 0 {
-		return teamFromDB, nil
+	result.Team, err = s.Get(ctx, team.Name)
+	if err != nil {
+		return domain.TeamAddResult{}, err
 	}
+	return result, nil
+}
 
-	// Add non-existing users
-	_, err = s.userRepository.Add(ctx, usersToAdd)
+// memberPlan is what adding a team does to the listed members
+type memberPlan struct {
+	create   []domain.User
+	move     []string
+	activate []string
+}
+
+// planMembers sorts the listed members into new users to create, users to move into the team and
+// inactive users to activate. Nothing is changed if any member conflicts with the stored user,
+// the conflicts are returned in *domain.TeamConflictError
+func (s *TeamService) planMembers(
+	ctx context.Context,
+	team domain.Team,
+	mode domain.TeamAddMode,
+	result *domain.TeamAddResult,
+) (memberPlan, error) {
+	ids := make([]string, len(team.Members))
+	for i, member := range team.Members {
+		ids[i] = member.ID
+	}
+	storedUsers, err := s.userRepository.GetByIDs(ctx, ids)
 	if err != nil {
-		return domain.Team{}, fmt.Errorf("failed to add team members to user repository: %w", err)
+		return memberPlan{}, fmt.Errorf("failed to get members of team %s: %w", team.Name, err)
 	}
+	stored := make(map[string]domain.User, len(storedUsers))
+	for _, user := range storedUsers {
+		stored[user.ID] = user
+	}
+
+	var (
+		plan      memberPlan
+		conflicts []domain.TeamMemberConflict
+	)
+	seen := make(map[string]bool, len(team.Members))
+	for _, member := range team.Members {
+		user, ok := stored[member.ID]
+		conflict := domain.TeamMemberConflict{UserID: member.ID, Reason: "", CurrentTeam: user.TeamName}
+		switch {
+		case seen[member.ID]:
+			conflict.Reason = domain.TeamConflictDuplicateMember
+		case !ok:
+			plan.create = append(plan.create, member)
+			result.Created = append(result.Created, member.ID)
+		default:
+			conflict.Reason = memberConflict(user, member, mode)
+		}
+		seen[member.ID] = true
+		if conflict.Reason != "" {
+			conflicts = append(conflicts, conflict)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		if user.TeamName != team.Name {
+			plan.move = append(plan.move, member.ID)
+			result.Moved = append(result.Moved, member.ID)
+		}
+		if !user.IsActive && member.IsActive {
+			plan.activate = append(plan.activate, member.ID)
+			result.Activated = append(result.Activated, member.ID)
+		}
+		if user.TeamName == team.Name && user.IsActive == member.IsActive {
+			result.Untouched = append(result.Untouched, member.ID)
+		}
+	}
+	if len(conflicts) > 0 {
+		return memberPlan{}, &domain.TeamConflictError{Conflicts: conflicts}
+	}
+	return plan, nil
+}
 
-	return teamFromDB, nil
+// memberConflict tells why the listed member can't be applied to the stored user, empty if it can.
+// Only an inactive user may be listed with another status: it gets activated
+func memberConflict(user, member domain.User, mode domain.TeamAddMode) domain.TeamConflictReason {
+	switch {
+	case user.IsDeleted():
+		return domain.TeamConflictUserDeleted
+	case mode == domain.TeamAddModeCreateOnly:
+		return domain.TeamConflictUserExists
+	case user.Username != member.Username || user.MaxOpenReviews != member.MaxOpenReviews ||
+		user.IsActive && !member.IsActive:
+		return domain.TeamConflictAttributesDiffer
+	}
+	return ""
+}
+
+// createMembers inserts new members of the team, active ones may review its pull requests that lack reviewers
+func (s *TeamService) createMembers(ctx context.Context, teamName string, users []domain.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+	addedUsers, err := s.userRepository.Add(ctx, users)
+	if err != nil {
+		return fmt.Errorf("failed to add team members to user repository: %w", err)
+	}
+	for _, user := range addedUsers {
+		if err := recordAudit(ctx, s.auditRecorder, domain.AuditActionUserUpsert, domain.AuditEntityUser, user.ID,
+			nil, userSnapshot(user)); err != nil {
+			return err
+		}
+	}
+
+	if !slices.ContainsFunc(users, func(user domain.User) bool { return user.IsActive }) {
+		return nil
+	}
+	if _, err := s.reviewerAssigner.TopUpReviewers(ctx, teamName); err != nil {
+		return fmt.Errorf("failed to top up reviewers of team %s: %w", teamName, err)
+	}
+	return nil
+}
+
+// activateMembers activates the members of the team, they may review its pull requests that lack reviewers
+func (s *TeamService) activateMembers(ctx context.Context, teamName string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	events := make([]domain.AuditEvent, 0, len(userIDs))
+	for _, userID := range userIDs {
+		before, err := s.userRepository.GetByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get user %s: %w", userID, err)
+		}
+		user, err := s.userRepository.SetIsActive(ctx, userID, true)
+		if err != nil {
+			return fmt.Errorf("failed to activate user %s: %w", userID, err)
+		}
+		event, err := newAuditEvent(ctx, domain.AuditActionUserSetIsActive, domain.AuditEntityUser, userID,
+			userSnapshot(before), userSnapshot(user))
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+	}
+	if err := recordAudits(ctx, s.auditRecorder, events); err != nil {
+		return err
+	}
+	if _, err := s.reviewerAssigner.TopUpReviewers(ctx, teamName); err != nil {
+		return fmt.Errorf("failed to top up reviewers of team %s: %w", teamName, err)
+	}
+	return nil
+}
+
+// deactivateUnlisted takes active members who are not listed in the team out of its roster: they are
+// deactivated and their open reviews are handed over, the way DeactivateUsers does. Every user belongs to a team,
+// so the users themselves stay in it and are never deleted. They are reported as deactivated
+func (s *TeamService) deactivateUnlisted(ctx context.Context, team domain.Team, result *domain.TeamAddResult) error {
+	members, err := s.userRepository.GetByTeamName(ctx, team.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get team members by team name %s: %w", team.Name, err)
+	}
+	var ids []string
+	for _, member := range members {
+		if member.IsActive &&
+			!slices.ContainsFunc(team.Members, func(user domain.User) bool { return user.ID == member.ID }) {
+			ids = append(ids, member.ID)
+		}
+	}
+	// пустой список выключил бы всю команду
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, replacements, err := s.deactivateMembers(ctx, team.Name, members, ids)
+	if err != nil {
+		return err
+	}
+	result.Deactivated = append(result.Deactivated, ids...)
+	result.Replacements = append(result.Replacements, replacements...)
+	return nil
 }
 
 func (s *TeamService) Get(ctx context.Context, teamName string) (domain.Team, error) {
@@ -79,5 +334,350 @@ func (s *TeamService) Get(ctx context.Context, teamName string) (domain.Team, er
 		return domain.Team{}, fmt.Errorf("failed to get team members by team name %s: %w", teamName, err)
 	}
 
+	team.Fallbacks, err = s.fallbackRepository.GetTeamFallbacks(ctx, teamName)
+	if err != nil {
+		return domain.Team{}, fmt.Errorf("failed to get fallback pools of team %s: %w", teamName, err)
+	}
+
+	return team, nil
+}
+
+// SetFallbacks replaces the pools reviewers of the team's pull requests are picked from, in order,
+// when the team itself can't supply enough of them. Pull requests of the team that lack reviewers
+// get them from the new pools right away
+func (s *TeamService) SetFallbacks(
+	ctx context.Context,
+	teamName string,
+	fallbacks []domain.ReviewerPool,
+) (domain.Team, error) {
+	if err := validateFallbacks(teamName, fallbacks); err != nil {
+		return domain.Team{}, err
+	}
+
+	var team domain.Team
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.repository.Get(ctx, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to get team by name %s: %w", teamName, err)
+		}
+		before.Fallbacks, err = s.fallbackRepository.GetTeamFallbacks(ctx, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to get fallback pools of team %s: %w", teamName, err)
+		}
+
+		if err := s.fallbackRepository.SetTeamFallbacks(ctx, teamName, fallbacks); err != nil {
+			return fmt.Errorf("failed to set fallback pools of team %s: %w", teamName, err)
+		}
+		after := before
+		after.Fallbacks = fallbacks
+		if err := recordAudit(ctx, s.auditRecorder, domain.AuditActionTeamFallbacks, domain.AuditEntityTeam,
+			teamName, teamFallbacksSnapshot(before), teamFallbacksSnapshot(after)); err != nil {
+			return err
+		}
+
+		if _, err := s.reviewerAssigner.TopUpReviewers(ctx, teamName); err != nil {
+			return fmt.Errorf("failed to top up reviewers of team %s: %w", teamName, err)
+		}
+		team, err = s.Get(ctx, teamName)
+		return err
+	})
+	if err != nil {
+		return domain.Team{}, err
+	}
 	return team, nil
 }
+
+// validateFallbacks checks that the team doesn't fall back to itself and lists every pool once
+func validateFallbacks(teamName string, fallbacks []domain.ReviewerPool) error {
+	if len(fallbacks) > domain.MaxTeamFallbacks {
+		return fmt.Errorf("%w: team may have at most %d fallback pools", domain.ErrInvalidTeamChange,
+			domain.MaxTeamFallbacks)
+	}
+	kinds := []domain.ReviewerPoolKind{domain.ReviewerPoolKindTeam, domain.ReviewerPoolKindShared}
+	for i, fallback := range fallbacks {
+		switch {
+		case !slices.Contains(kinds, fallback.Kind):
+			return fmt.Errorf("%w: unknown pool type %s", domain.ErrInvalidTeamChange, fallback.Kind)
+		case fallback.Name == "":
+			return fmt.Errorf("%w: fallback pool name is empty", domain.ErrInvalidTeamChange)
+		case fallback == domain.TeamPool(teamName):
+			return fmt.Errorf("%w: team %s can't fall back to itself", domain.ErrInvalidTeamChange, teamName)
+		case slices.Contains(fallbacks[:i], fallback):
+			return fmt.Errorf("%w: %s pool %s is listed twice", domain.ErrInvalidTeamChange, fallback.Kind,
+				fallback.Name)
+		}
+	}
+	return nil
+}
+
+// DeactivateUsers deactivates the given users of the team (or the whole team if userIDs is empty)
+// and reassigns open pull requests they review to active teammates. Every change is recorded in the audit log.
+func (s *TeamService) DeactivateUsers(
+	ctx context.Context,
+	teamName string,
+	userIDs []string,
+) ([]domain.User, []domain.ReviewerReplacement, error) {
+	var (
+		users        []domain.User
+		replacements []domain.ReviewerReplacement
+	)
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		users, replacements, err = s.deactivateUsers(ctx, teamName, userIDs)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return users, replacements, nil
+}
+
+func (s *TeamService) deactivateUsers(
+	ctx context.Context,
+	teamName string,
+	userIDs []string,
+) ([]domain.User, []domain.ReviewerReplacement, error) {
+	exists, err := s.repository.Exists(ctx, teamName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check if team exists by name %s: %w", teamName, err)
+	}
+	if !exists {
+		return nil, nil, fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotFound)
+	}
+
+	members, err := s.userRepository.GetByTeamName(ctx, teamName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get team members by team name %s: %w", teamName, err)
+	}
+	return s.deactivateMembers(ctx, teamName, members, userIDs)
+}
+
+// deactivateMembers deactivates the listed members of the team, or all of them when none are listed,
+// records the change and hands their open reviews over. Members are the team roster before the change
+func (s *TeamService) deactivateMembers(
+	ctx context.Context,
+	teamName string,
+	members []domain.User,
+	userIDs []string,
+) ([]domain.User, []domain.ReviewerReplacement, error) {
+	before := make(map[string]domain.User, len(members))
+	for _, member := range members {
+		before[member.ID] = member
+	}
+
+	users, err := s.userRepository.DeactivateTeamUsers(ctx, teamName, userIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to deactivate users of team %s: %w", teamName, err)
+	}
+	deactivatedIDs := make([]string, len(users))
+	events := make([]domain.AuditEvent, 0, len(users))
+	for i, user := range users {
+		deactivatedIDs[i] = user.ID
+		// уже неактивные участники не меняются и в журнал не попадают
+		if !before[user.ID].IsActive {
+			continue
+		}
+		event, err := newAuditEvent(ctx, domain.AuditActionUserSetIsActive, domain.AuditEntityUser, user.ID,
+			userSnapshot(before[user.ID]), userSnapshot(user))
+		if err != nil {
+			return nil, nil, err
+		}
+		events = append(events, event)
+	}
+	if err := recordAudits(ctx, s.auditRecorder, events); err != nil {
+		return nil, nil, err
+	}
+	replacements, err := s.reviewerAssigner.HandOverReviews(ctx, deactivatedIDs, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to hand over reviews of team %s: %w", teamName, err)
+	}
+	return users, replacements, nil
+}
+
+// Rename changes the name of the team, its members and pull requests stay with it
+func (s *TeamService) Rename(ctx context.Context, teamName, newName string) (domain.Team, error) {
+	if newName == "" || newName == teamName {
+		return domain.Team{}, fmt.Errorf("%w: new name must differ from %s", domain.ErrInvalidTeamChange, teamName)
+	}
+
+	var team domain.Team
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		team, err = s.repository.Rename(ctx, teamName, newName)
+		if err != nil {
+			return fmt.Errorf("failed to rename team %s: %w", teamName, err)
+		}
+		team.Members, err = s.userRepository.GetByTeamName(ctx, newName)
+		if err != nil {
+			return fmt.Errorf("failed to get team members by team name %s: %w", newName, err)
+		}
+		before := domain.Team{Name: teamName} //nolint:exhaustruct // Участники при переименовании не меняются
+		return recordAudit(ctx, s.auditRecorder, domain.AuditActionTeamRename, domain.AuditEntityTeam, newName,
+			teamSnapshot(before), teamSnapshot(team))
+	})
+	if err != nil {
+		return domain.Team{}, err
+	}
+	return team, nil
+}
+
+// Delete deletes the team. Members of a non-empty team are moved to the team moveTo first,
+// the same way MoveUsers does it, deleted users of the team go there too.
+// Without moveTo only a team without members, deleted ones included, can be deleted
+func (s *TeamService) Delete(
+	ctx context.Context,
+	teamName, moveTo string,
+) ([]domain.User, []domain.ReviewerReplacement, error) {
+	var (
+		moved        []domain.User
+		replacements []domain.ReviewerReplacement
+	)
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		exists, err := s.repository.Exists(ctx, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to check if team exists by name %s: %w", teamName, err)
+		}
+		if !exists {
+			return fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotFound)
+		}
+		// куда переводить, проверяем до любых изменений
+		if moveTo == teamName {
+			return fmt.Errorf("%w: members of team %s can't be moved to itself", domain.ErrInvalidTeamChange, teamName)
+		}
+		if moveTo != "" {
+			if err := s.requireTeam(ctx, moveTo); err != nil {
+				return err
+			}
+		}
+		team, err := s.Get(ctx, teamName)
+		if err != nil {
+			return err
+		}
+		moved, replacements = []domain.User{}, []domain.ReviewerReplacement{}
+		if len(team.Members) > 0 {
+			if moveTo == "" {
+				return fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotEmpty)
+			}
+			userIDs := make([]string, len(team.Members))
+			for i, member := range team.Members {
+				userIDs[i] = member.ID
+			}
+			moved, replacements, err = s.moveUsers(ctx, moveTo, userIDs)
+			if err != nil {
+				return err
+			}
+		}
+		// deleted users are not listed as members but still belong to the team
+		if moveTo != "" {
+			if _, err := s.userRepository.MoveDeletedUsers(ctx, teamName, moveTo); err != nil {
+				return fmt.Errorf("failed to move deleted users of team %s: %w", teamName, err)
+			}
+		}
+
+		if err := s.repository.Delete(ctx, teamName); err != nil {
+			return fmt.Errorf("failed to delete team %s: %w", teamName, err)
+		}
+		return recordAudit(ctx, s.auditRecorder, domain.AuditActionTeamDelete, domain.AuditEntityTeam, teamName,
+			teamSnapshot(team), nil)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return moved, replacements, nil
+}
+
+// MoveUsers moves the users to the team. Open reviews they hold on pull requests of their former teammates
+// are reassigned inside the former team, and the users may review pull requests of the new team that lack reviewers
+func (s *TeamService) MoveUsers(
+	ctx context.Context,
+	teamName string,
+	userIDs []string,
+) ([]domain.User, []domain.ReviewerReplacement, error) {
+	var (
+		moved        []domain.User
+		replacements []domain.ReviewerReplacement
+	)
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.requireTeam(ctx, teamName); err != nil {
+			return err
+		}
+		var err error
+		moved, replacements, err = s.moveUsers(ctx, teamName, userIDs)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return moved, replacements, nil
+}
+
+// requireTeam returns ErrTeamNotFound if there is no team with the name
+func (s *TeamService) requireTeam(ctx context.Context, teamName string) error {
+	exists, err := s.repository.Exists(ctx, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to check if team exists by name %s: %w", teamName, err)
+	}
+	if !exists {
+		return fmt.Errorf("team with name %s: %w", teamName, domain.ErrTeamNotFound)
+	}
+	return nil
+}
+
+// moveUsers moves the users to the team, the caller makes sure that the team exists
+func (s *TeamService) moveUsers(
+	ctx context.Context,
+	teamName string,
+	userIDs []string,
+) ([]domain.User, []domain.ReviewerReplacement, error) {
+	before := make(map[string]domain.User, len(userIDs))
+	for _, userID := range userIDs {
+		user, err := s.userRepository.GetByID(ctx, userID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get user %s: %w", userID, err)
+		}
+		if user.IsDeleted() {
+			return nil, nil, fmt.Errorf("user with ID %s: %w", userID, domain.ErrUserNotFound)
+		}
+		if user.TeamName == teamName {
+			return nil, nil, fmt.Errorf("%w: user %s is already a member of team %s", domain.ErrInvalidTeamChange,
+				userID, teamName)
+		}
+		before[userID] = user
+	}
+
+	moved, err := s.userRepository.MoveUsersToTeam(ctx, teamName, userIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to move users to team %s: %w", teamName, err)
+	}
+	formerTeams := make(map[string][]string)
+	events := make([]domain.AuditEvent, 0, len(moved))
+	for _, user := range moved {
+		event, err := newAuditEvent(ctx, domain.AuditActionUserMove, domain.AuditEntityUser, user.ID,
+			userSnapshot(before[user.ID]), userSnapshot(user))
+		if err != nil {
+			return nil, nil, err
+		}
+		events = append(events, event)
+		formerTeams[before[user.ID].TeamName] = append(formerTeams[before[user.ID].TeamName], user.ID)
+	}
+	if err := recordAudits(ctx, s.auditRecorder, events); err != nil {
+		return nil, nil, err
+	}
+	// ревью на PR бывших товарищей по команде передаём, ревью на PR самих переехавших остаются
+	replacements := make([]domain.ReviewerReplacement, 0)
+	for _, formerTeam := range slices.Sorted(maps.Keys(formerTeams)) {
+		replaced, err := s.reviewerAssigner.HandOverReviews(ctx, formerTeams[formerTeam], formerTeam)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to hand over reviews in team %s: %w", formerTeam, err)
+		}
+		replacements = append(replacements, replaced...)
+	}
+
+	if !slices.ContainsFunc(moved, func(user domain.User) bool { return user.IsActive }) {
+		return moved, replacements, nil
+	}
+	if _, err := s.reviewerAssigner.TopUpReviewers(ctx, teamName); err != nil {
+		return nil, nil, fmt.Errorf("failed to top up reviewers of team %s: %w", teamName, err)
+	}
+	return moved, replacements, nil
+}
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -22,136 +24,280 @@ func (s *TeamServiceTestSuite) SetupTest() {
 	s.ctx = context.Background()
 }
 
-// TestAdd проверяет метод Add
+// TestAdd проверяет метод Add во всех режимах
 func (s *TeamServiceTestSuite) TestAdd() {
+	alice := domain.User{ID: "user-1", Username: "alice", TeamName: "backend", IsActive: true}
+	bob := domain.User{ID: "user-2", Username: "bob", TeamName: "backend", IsActive: false}
+
+	s.Run("create_only - new users", func() {
+		service, mockTeamRepo, mockUserRepo, mockAssigner := s.newTeamServiceMocks()
+		team := domain.Team{Name: "backend", Members: []domain.User{alice, bob}}
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(false, nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"user-1", "user-2"}).Return([]domain.User{}, nil).Once()
+		mockTeamRepo.EXPECT().Add(s.ctx, team).Return(domain.Team{Name: "backend"}, nil).Once()
+		mockUserRepo.EXPECT().Add(s.ctx, []domain.User{alice, bob}).Return([]domain.User{alice, bob}, nil).Once()
+		// активный новичок может сразу получить ревью
+		mockAssigner.EXPECT().TopUpReviewers(s.ctx, "backend").Return(nil, nil).Once()
+		mockTeamRepo.EXPECT().Get(s.ctx, "backend").Return(domain.Team{Name: "backend"}, nil).Once()
+		mockUserRepo.EXPECT().GetByTeamName(s.ctx, "backend").Return([]domain.User{alice, bob}, nil).Once()
+
+		result, err := service.Add(s.ctx, team, domain.TeamAddModeCreateOnly)
+
+		s.Require().NoError(err)
+		s.True(result.TeamCreated)
+		s.Equal([]string{"user-1", "user-2"}, result.Created)
+		s.Empty(result.Moved)
+		s.Len(result.Team.Members, 2)
+	})
+
+	s.Run("create_only - team exists", func() {
+		service, mockTeamRepo, _, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+
+		_, err := service.Add(s.ctx, domain.Team{Name: "backend"}, domain.TeamAddModeCreateOnly)
+		s.ErrorIs(err, domain.ErrTeamAlreadyExists)
+	})
+
+	s.Run("create_only - existing user is a conflict", func() {
+		service, mockTeamRepo, mockUserRepo, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "frontend").Return(false, nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"user-1"}).Return([]domain.User{alice}, nil).Once()
+
+		newAlice := alice
+		newAlice.TeamName = "frontend"
+		_, err := service.Add(s.ctx, domain.Team{Name: "frontend", Members: []domain.User{newAlice}},
+			domain.TeamAddModeCreateOnly)
+
+		var conflictErr *domain.TeamConflictError
+		s.Require().ErrorAs(err, &conflictErr)
+		s.ErrorIs(err, domain.ErrTeamMemberConflict)
+		s.Equal([]domain.TeamMemberConflict{
+			{UserID: "user-1", Reason: domain.TeamConflictUserExists, CurrentTeam: "backend"},
+		}, conflictErr.Conflicts)
+	})
+
+	s.Run("merge - moves, keeps and creates", func() {
+		service, mockTeamRepo, mockUserRepo, mockAssigner := s.newTeamServiceMocks()
+		carol := domain.User{ID: "user-3", Username: "carol", TeamName: "frontend", IsActive: false}
+		frontendAlice := alice
+		frontendAlice.TeamName = "frontend"
+		team := domain.Team{Name: "frontend", Members: []domain.User{frontendAlice, carol}}
+		mockTeamRepo.EXPECT().Exists(s.ctx, "frontend").Return(true, nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"user-1", "user-3"}).Return([]domain.User{alice}, nil).Once()
+		mockUserRepo.EXPECT().Add(s.ctx, []domain.User{carol}).Return([]domain.User{carol}, nil).Once()
+		mockUserRepo.EXPECT().GetByID(s.ctx, "user-1").Return(alice, nil).Once()
+		mockUserRepo.EXPECT().MoveUsersToTeam(s.ctx, "frontend", []string{"user-1"}).
+			Return([]domain.User{frontendAlice}, nil).Once()
+		mockAssigner.EXPECT().HandOverReviews(s.ctx, []string{"user-1"}, "backend").Return(
+			[]domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-4"}}, nil,
+		).Once()
+		mockAssigner.EXPECT().TopUpReviewers(s.ctx, "frontend").Return(nil, nil).Once()
+		mockTeamRepo.EXPECT().Get(s.ctx, "frontend").Return(domain.Team{Name: "frontend"}, nil).Once()
+		mockUserRepo.EXPECT().GetByTeamName(s.ctx, "frontend").Return([]domain.User{frontendAlice, carol}, nil).Once()
+
+		result, err := service.Add(s.ctx, team, domain.TeamAddModeMerge)
+
+		s.Require().NoError(err)
+		s.False(result.TeamCreated)
+		s.Equal([]string{"user-3"}, result.Created)
+		s.Equal([]string{"user-1"}, result.Moved)
+		s.Len(result.Replacements, 1)
+	})
+
+	s.Run("merge - differing attributes and duplicates are conflicts", func() {
+		service, mockTeamRepo, mockUserRepo, _ := s.newTeamServiceMocks()
+		renamed := alice
+		renamed.Username = "alicia"
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"user-1", "user-2", "user-2"}).
+			Return([]domain.User{alice, bob}, nil).Once()
+
+		_, err := service.Add(s.ctx, domain.Team{Name: "backend", Members: []domain.User{renamed, bob, bob}},
+			domain.TeamAddModeMerge)
+
+		var conflictErr *domain.TeamConflictError
+		s.Require().ErrorAs(err, &conflictErr)
+		s.Equal([]domain.TeamMemberConflict{
+			{UserID: "user-1", Reason: domain.TeamConflictAttributesDiffer, CurrentTeam: "backend"},
+			{UserID: "user-2", Reason: domain.TeamConflictDuplicateMember, CurrentTeam: "backend"},
+		}, conflictErr.Conflicts)
+	})
+
+	s.Run("merge - activates inactive members", func() {
+		service, mockTeamRepo, mockUserRepo, mockAssigner := s.newTeamServiceMocks()
+		activeBob := bob
+		activeBob.IsActive = true
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"user-1", "user-2"}).Return([]domain.User{alice, bob}, nil).Once()
+		mockUserRepo.EXPECT().GetByID(s.ctx, "user-2").Return(bob, nil).Once()
+		mockUserRepo.EXPECT().SetIsActive(s.ctx, "user-2", true).Return(activeBob, nil).Once()
+		// включённый bob сразу добирается в ревьюверы PR команды
+		mockAssigner.EXPECT().TopUpReviewers(s.ctx, "backend").Return(nil, nil).Once()
+		mockTeamRepo.EXPECT().Get(s.ctx, "backend").Return(domain.Team{Name: "backend"}, nil).Once()
+		mockUserRepo.EXPECT().GetByTeamName(s.ctx, "backend").Return([]domain.User{alice, activeBob}, nil).Once()
+
+		result, err := service.Add(s.ctx, domain.Team{Name: "backend", Members: []domain.User{alice, activeBob}},
+			domain.TeamAddModeMerge)
+
+		s.Require().NoError(err)
+		s.Equal([]string{"user-1"}, result.Untouched)
+		s.Equal([]string{"user-2"}, result.Activated)
+		s.Empty(result.Moved)
+	})
+
+	s.Run("merge - deactivating a member is a conflict", func() {
+		service, mockTeamRepo, mockUserRepo, _ := s.newTeamServiceMocks()
+		inactiveAlice := alice
+		inactiveAlice.IsActive = false
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"user-1"}).Return([]domain.User{alice}, nil).Once()
+
+		_, err := service.Add(s.ctx, domain.Team{Name: "backend", Members: []domain.User{inactiveAlice}},
+			domain.TeamAddModeMerge)
+
+		var conflictErr *domain.TeamConflictError
+		s.Require().ErrorAs(err, &conflictErr)
+		s.Equal([]domain.TeamMemberConflict{
+			{UserID: "user-1", Reason: domain.TeamConflictAttributesDiffer, CurrentTeam: "backend"},
+		}, conflictErr.Conflicts)
+	})
+
+	s.Run("replace - deactivates unlisted members", func() {
+		service, mockTeamRepo, mockUserRepo, mockAssigner := s.newTeamServiceMocks()
+		dave := domain.User{ID: "user-4", Username: "dave", TeamName: "backend", IsActive: true}
+		inactiveDave := dave
+		inactiveDave.IsActive = false
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"user-1"}).Return([]domain.User{alice}, nil).Once()
+		mockUserRepo.EXPECT().GetByTeamName(s.ctx, "backend").Return([]domain.User{alice, bob, dave}, nil).Once()
+		// неактивный bob уже выключен и не трогается
+		mockUserRepo.EXPECT().DeactivateTeamUsers(s.ctx, "backend", []string{"user-4"}).
+			Return([]domain.User{inactiveDave}, nil).Once()
+		mockAssigner.EXPECT().HandOverReviews(s.ctx, []string{"user-4"}, "").Return(
+			[]domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-4", NewReviewerID: "user-1"}}, nil,
+		).Once()
+		mockTeamRepo.EXPECT().Get(s.ctx, "backend").Return(domain.Team{Name: "backend"}, nil).Once()
+		mockUserRepo.EXPECT().GetByTeamName(s.ctx, "backend").
+			Return([]domain.User{alice, bob, inactiveDave}, nil).Once()
+
+		result, err := service.Add(s.ctx, domain.Team{Name: "backend", Members: []domain.User{alice}},
+			domain.TeamAddModeReplace)
+
+		s.Require().NoError(err)
+		s.Equal([]string{"user-1"}, result.Untouched)
+		s.Equal([]string{"user-4"}, result.Deactivated)
+		s.Equal([]domain.User{alice, bob, inactiveDave}, result.Team.Members)
+		s.Len(result.Replacements, 1)
+	})
+
+	s.Run("replace - nothing to deactivate", func() {
+		service, mockTeamRepo, mockUserRepo, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{"user-1"}).Return([]domain.User{alice}, nil).Once()
+		mockUserRepo.EXPECT().GetByTeamName(s.ctx, "backend").Return([]domain.User{alice, bob}, nil).Once()
+		mockTeamRepo.EXPECT().Get(s.ctx, "backend").Return(domain.Team{Name: "backend"}, nil).Once()
+		mockUserRepo.EXPECT().GetByTeamName(s.ctx, "backend").Return([]domain.User{alice, bob}, nil).Once()
+
+		result, err := service.Add(s.ctx, domain.Team{Name: "backend", Members: []domain.User{alice}},
+			domain.TeamAddModeReplace)
+
+		s.Require().NoError(err)
+		s.Empty(result.Deactivated)
+		mockUserRepo.AssertNotCalled(s.T(), "DeactivateTeamUsers", s.ctx, "backend", mock.Anything)
+	})
+
+	s.Run("unknown mode", func() {
+		service, _, _, _ := s.newTeamServiceMocks()
+		_, err := service.Add(s.ctx, domain.Team{Name: "backend"}, "upsert")
+		s.ErrorIs(err, domain.ErrInvalidTeamChange)
+	})
+
+	s.Run("exists check error", func() {
+		service, mockTeamRepo, _, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(false, errors.New("database error")).Once()
+
+		result, err := service.Add(s.ctx, domain.Team{Name: "backend"}, domain.TeamAddModeCreateOnly)
+		s.Error(err)
+		s.Equal(domain.TeamAddResult{}, result)
+	})
+
+	s.Run("add team error", func() {
+		service, mockTeamRepo, mockUserRepo, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(false, nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, []string{}).Return([]domain.User{}, nil).Once()
+		mockTeamRepo.EXPECT().Add(s.ctx, domain.Team{Name: "backend", Members: []domain.User{}}).
+			Return(domain.Team{}, errors.New("insert error")).Once()
+
+		_, err := service.Add(s.ctx, domain.Team{Name: "backend", Members: []domain.User{}}, domain.TeamAddModeCreateOnly)
+		s.Error(err)
+	})
+
+	s.Run("add users error", func() {
+		service, mockTeamRepo, mockUserRepo, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(false, nil).Once()
+		mockUserRepo.EXPECT().GetByIDs(s.ctx, mock.Anything).Return([]domain.User{}, nil).Once()
+		mockTeamRepo.EXPECT().Add(s.ctx, mock.Anything).Return(domain.Team{Name: "backend"}, nil).Once()
+		mockUserRepo.EXPECT().Add(s.ctx, mock.Anything).Return(nil, errors.New("user insert error")).Once()
+
+		_, err := service.Add(s.ctx, domain.Team{Name: "backend", Members: []domain.User{alice}},
+			domain.TeamAddModeCreateOnly)
+		s.Error(err)
+	})
+}
+
+// TestGet проверяет метод Get
+func (s *TeamServiceTestSuite) TestGet() {
 	tests := []struct {
 		name        string
-		team        domain.Team
+		teamName    string
 		arrangeFunc func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository)
 		wantErr     bool
 		wantErrIs   error
 		checkResult func(result domain.Team)
 	}{
 		{
-			name: "success - all users exist",
-			team: domain.Team{
-				Name: "backend-team",
-				Members: []domain.User{
-					{ID: "user-1", Username: "alice"},
-					{ID: "user-2", Username: "bob"},
-				},
-			},
+			name:     "success",
+			teamName: "backend-team",
 			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository) {
-				mockTeamRepo.EXPECT().Exists(ctx, "backend-team").Return(false, nil).Once()
-				mockTeamRepo.EXPECT().Add(ctx, domain.Team{
-					Name: "backend-team",
-					Members: []domain.User{
-						{ID: "user-1", Username: "alice"},
-						{ID: "user-2", Username: "bob"},
-					},
-				}).Return(domain.Team{Name: "backend-team"}, nil).Once()
-				mockUserRepo.EXPECT().BatchExistsByID(ctx, []domain.User{
-					{ID: "user-1", Username: "alice"},
-					{ID: "user-2", Username: "bob"},
-				}).Return(map[domain.User]bool{
-					{ID: "user-1", Username: "alice"}: true,
-					{ID: "user-2", Username: "bob"}:   true,
-				}).Once()
+				mockTeamRepo.EXPECT().Get(ctx, "backend-team").Return(domain.Team{Name: "backend-team"}, nil).Once()
+				mockUserRepo.EXPECT().GetByTeamName(ctx, "backend-team").Return([]domain.User{
+					{ID: "user-1", Username: "alice", TeamName: "backend-team"},
+					{ID: "user-2", Username: "bob", TeamName: "backend-team"},
+				}, nil).Once()
 			},
 			wantErr: false,
 			checkResult: func(result domain.Team) {
 				s.Equal("backend-team", result.Name)
+				s.Len(result.Members, 2)
 			},
 		},
 		{
-			name: "success - with new users",
-			team: domain.Team{
-				Name: "new-team",
-				Members: []domain.User{
-					{ID: "user-1", Username: "alice"},
-					{ID: "user-2", Username: "bob"},
-					{ID: "user-3", Username: "charlie"},
-				},
-			},
+			name:     "success - empty team",
+			teamName: "empty-team",
 			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository) {
-				mockTeamRepo.EXPECT().Exists(ctx, "new-team").Return(false, nil).Once()
-				mockTeamRepo.EXPECT().Add(ctx, domain.Team{
-					Name: "new-team",
-					Members: []domain.User{
-						{ID: "user-1", Username: "alice"},
-						{ID: "user-2", Username: "bob"},
-						{ID: "user-3", Username: "charlie"},
-					},
-				}).Return(domain.Team{Name: "new-team"}, nil).Once()
-				mockUserRepo.EXPECT().BatchExistsByID(ctx, mock.Anything).Return(map[domain.User]bool{
-					{ID: "user-1", Username: "alice"}:   true,
-					{ID: "user-2", Username: "bob"}:     false,
-					{ID: "user-3", Username: "charlie"}: false,
-				}).Once()
-				mockUserRepo.EXPECT().Add(ctx, mock.MatchedBy(func(users []domain.User) bool {
-					if len(users) != 2 {
-						return false
-					}
-					ids := make(map[string]bool)
-					for _, u := range users {
-						ids[u.ID] = true
-					}
-					return ids["user-2"] && ids["user-3"]
-				})).Return([]domain.User{
-					{ID: "user-2", Username: "bob"},
-					{ID: "user-3", Username: "charlie"},
-				}, nil).Once()
+				mockTeamRepo.EXPECT().Get(ctx, "empty-team").Return(domain.Team{Name: "empty-team"}, nil).Once()
+				mockUserRepo.EXPECT().GetByTeamName(ctx, "empty-team").Return([]domain.User{}, nil).Once()
 			},
 			wantErr: false,
 			checkResult: func(result domain.Team) {
-				s.Equal("new-team", result.Name)
-			},
-		},
-		{
-			name: "team already exists",
-			team: domain.Team{
-				Name:    "existing-team",
-				Members: []domain.User{{ID: "user-1"}},
-			},
-			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository) {
-				mockTeamRepo.EXPECT().Exists(ctx, "existing-team").Return(true, nil).Once()
-			},
-			wantErr:   true,
-			wantErrIs: domain.ErrTeamAlreadyExists,
-		},
-		{
-			name: "exists check error",
-			team: domain.Team{Name: "test-team"},
-			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository) {
-				mockTeamRepo.EXPECT().Exists(ctx, "test-team").Return(false, errors.New("database error")).Once()
+				s.Equal("empty-team", result.Name)
+				s.Len(result.Members, 0)
 			},
-			wantErr: true,
 		},
 		{
-			name: "add team error",
-			team: domain.Team{Name: "test-team"},
+			name:     "team not found",
+			teamName: "non-existent-team",
 			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository) {
-				mockTeamRepo.EXPECT().Exists(ctx, "test-team").Return(false, nil).Once()
-				mockTeamRepo.EXPECT().Add(ctx, domain.Team{Name: "test-team"}).Return(domain.Team{}, errors.New("insert error")).Once()
+				mockTeamRepo.EXPECT().Get(ctx, "non-existent-team").Return(domain.Team{}, errors.New("team not found")).Once()
 			},
 			wantErr: true,
 		},
 		{
-			name: "add users error",
-			team: domain.Team{
-				Name:    "test-team",
-				Members: []domain.User{{ID: "user-1", Username: "alice"}},
-			},
+			name:     "get members error",
+			teamName: "backend-team",
 			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository) {
-				mockTeamRepo.EXPECT().Exists(ctx, "test-team").Return(false, nil).Once()
-				mockTeamRepo.EXPECT().Add(ctx, domain.Team{
-					Name:    "test-team",
-					Members: []domain.User{{ID: "user-1", Username: "alice"}},
-				}).Return(domain.Team{Name: "test-team"}, nil).Once()
-				mockUserRepo.EXPECT().BatchExistsByID(ctx, mock.Anything).Return(map[domain.User]bool{
-					{ID: "user-1", Username: "alice"}: false,
-				}).Once()
-				mockUserRepo.EXPECT().Add(ctx, mock.Anything).Return([]domain.User{}, errors.New("user insert error")).Once()
+				mockTeamRepo.EXPECT().Get(ctx, "backend-team").Return(domain.Team{Name: "backend-team"}, nil).Once()
+				mockUserRepo.EXPECT().GetByTeamName(ctx, "backend-team").Return([]domain.User{}, errors.New("failed to get members")).Once()
 			},
 			wantErr: true,
 		},
@@ -162,12 +308,13 @@ func (s *TeamServiceTestSuite) TestAdd() {
 			// Arrange
 			mockTeamRepo := newMockiTeamRepository(s.T())
 			mockUserRepo := newMockiTeamUserRepository(s.T())
-			service := NewTeamService(mockTeamRepo, mockUserRepo)
+			service := NewTeamService(mockTeamRepo, mockUserRepo, newNoTeamFallbacks(s.T()), newMockiReviewerAssigner(s.T()),
+				newAcceptingAuditRecorder(s.T()), newPassthroughTransactor(s.T()))
 
 			tt.arrangeFunc(s.ctx, mockTeamRepo, mockUserRepo)
 
 			// Act
-			result, err := service.Add(s.ctx, tt.team)
+			result, err := service.Get(s.ctx, tt.teamName)
 
 			// Assert
 			if tt.wantErr {
@@ -186,59 +333,74 @@ func (s *TeamServiceTestSuite) TestAdd() {
 	}
 }
 
-// TestGet проверяет метод Get
-func (s *TeamServiceTestSuite) TestGet() {
+// TestDeactivateUsers проверяет метод DeactivateUsers
+func (s *TeamServiceTestSuite) TestDeactivateUsers() {
 	tests := []struct {
 		name        string
 		teamName    string
-		arrangeFunc func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository)
+		userIDs     []string
+		arrangeFunc func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository,
+			mockAssigner *mockiReviewerAssigner)
 		wantErr     bool
 		wantErrIs   error
-		checkResult func(result domain.Team)
+		checkResult func(users []domain.User, replacements []domain.ReviewerReplacement)
 	}{
 		{
 			name:     "success",
 			teamName: "backend-team",
-			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository) {
-				mockTeamRepo.EXPECT().Get(ctx, "backend-team").Return(domain.Team{Name: "backend-team"}, nil).Once()
-				mockUserRepo.EXPECT().GetByTeamName(ctx, "backend-team").Return([]domain.User{
-					{ID: "user-1", Username: "alice", TeamName: "backend-team"},
-					{ID: "user-2", Username: "bob", TeamName: "backend-team"},
-				}, nil).Once()
+			userIDs:  []string{"user-1"},
+			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository,
+				mockAssigner *mockiReviewerAssigner) {
+				mockTeamRepo.EXPECT().Exists(ctx, "backend-team").Return(true, nil).Once()
+				mockUserRepo.EXPECT().GetByTeamName(ctx, "backend-team").
+					Return([]domain.User{{ID: "user-1", TeamName: "backend-team", IsActive: true}}, nil).Once()
+				mockUserRepo.EXPECT().DeactivateTeamUsers(ctx, "backend-team", []string{"user-1"}).
+					Return([]domain.User{{ID: "user-1", TeamName: "backend-team"}}, nil).Once()
+				mockAssigner.EXPECT().HandOverReviews(ctx, []string{"user-1"}, "").Return(
+					[]domain.ReviewerReplacement{
+						{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-3"},
+						{PullRequestID: "pr-2", OldReviewerID: "user-1"},
+					},
+					nil,
+				).Once()
 			},
 			wantErr: false,
-			checkResult: func(result domain.Team) {
-				s.Equal("backend-team", result.Name)
-				s.Len(result.Members, 2)
+			checkResult: func(users []domain.User, replacements []domain.ReviewerReplacement) {
+				s.Len(users, 1)
+				s.Len(replacements, 2)
+				s.Empty(replacements[1].NewReviewerID)
 			},
 		},
 		{
-			name:     "success - empty team",
-			teamName: "empty-team",
-			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository) {
-				mockTeamRepo.EXPECT().Get(ctx, "empty-team").Return(domain.Team{Name: "empty-team"}, nil).Once()
-				mockUserRepo.EXPECT().GetByTeamName(ctx, "empty-team").Return([]domain.User{}, nil).Once()
-			},
-			wantErr: false,
-			checkResult: func(result domain.Team) {
-				s.Equal("empty-team", result.Name)
-				s.Len(result.Members, 0)
+			name:     "team not found",
+			teamName: "non-existent-team",
+			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository,
+				mockAssigner *mockiReviewerAssigner) {
+				mockTeamRepo.EXPECT().Exists(ctx, "non-existent-team").Return(false, nil).Once()
 			},
+			wantErr:   true,
+			wantErrIs: domain.ErrTeamNotFound,
 		},
 		{
-			name:     "team not found",
-			teamName: "non-existent-team",
-			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository) {
-				mockTeamRepo.EXPECT().Get(ctx, "non-existent-team").Return(domain.Team{}, errors.New("team not found")).Once()
+			name:     "user is not a member",
+			teamName: "backend-team",
+			userIDs:  []string{"stranger"},
+			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository,
+				mockAssigner *mockiReviewerAssigner) {
+				mockTeamRepo.EXPECT().Exists(ctx, "backend-team").Return(true, nil).Once()
+				mockUserRepo.EXPECT().GetByTeamName(ctx, "backend-team").Return(nil, nil).Once()
+				mockUserRepo.EXPECT().DeactivateTeamUsers(ctx, "backend-team", []string{"stranger"}).
+					Return(nil, domain.ErrUserNotFound).Once()
 			},
-			wantErr: true,
+			wantErr:   true,
+			wantErrIs: domain.ErrUserNotFound,
 		},
 		{
-			name:     "get members error",
+			name:     "exists check error",
 			teamName: "backend-team",
-			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository) {
-				mockTeamRepo.EXPECT().Get(ctx, "backend-team").Return(domain.Team{Name: "backend-team"}, nil).Once()
-				mockUserRepo.EXPECT().GetByTeamName(ctx, "backend-team").Return([]domain.User{}, errors.New("failed to get members")).Once()
+			arrangeFunc: func(ctx context.Context, mockTeamRepo *mockiTeamRepository, mockUserRepo *mockiTeamUserRepository,
+				mockAssigner *mockiReviewerAssigner) {
+				mockTeamRepo.EXPECT().Exists(ctx, "backend-team").Return(false, errors.New("database error")).Once()
 			},
 			wantErr: true,
 		},
@@ -249,12 +411,14 @@ func (s *TeamServiceTestSuite) TestGet() {
 			// Arrange
 			mockTeamRepo := newMockiTeamRepository(s.T())
 			mockUserRepo := newMockiTeamUserRepository(s.T())
-			service := NewTeamService(mockTeamRepo, mockUserRepo)
+			mockAssigner := newMockiReviewerAssigner(s.T())
+			service := NewTeamService(mockTeamRepo, mockUserRepo, newNoTeamFallbacks(s.T()), mockAssigner,
+				newAcceptingAuditRecorder(s.T()), newPassthroughTransactor(s.T()))
 
-			tt.arrangeFunc(s.ctx, mockTeamRepo, mockUserRepo)
+			tt.arrangeFunc(s.ctx, mockTeamRepo, mockUserRepo, mockAssigner)
 
 			// Act
-			result, err := service.Get(s.ctx, tt.teamName)
+			users, replacements, err := service.DeactivateUsers(s.ctx, tt.teamName, tt.userIDs)
 
 			// Assert
 			if tt.wantErr {
@@ -262,17 +426,272 @@ func (s *TeamServiceTestSuite) TestGet() {
 				if tt.wantErrIs != nil {
 					s.ErrorIs(err, tt.wantErrIs)
 				}
-				s.Equal(domain.Team{}, result)
+				s.Nil(users)
+				s.Nil(replacements)
 			} else {
 				s.NoError(err)
 				if tt.checkResult != nil {
-					tt.checkResult(result)
+					tt.checkResult(users, replacements)
 				}
 			}
 		})
 	}
 }
 
+// TestDeactivateUsersAudit проверяет, что деактивация каждого участника попадает в журнал аудита
+func (s *TeamServiceTestSuite) TestDeactivateUsersAudit() {
+	mockTeamRepo := newMockiTeamRepository(s.T())
+	mockUserRepo := newMockiTeamUserRepository(s.T())
+	mockAssigner := newMockiReviewerAssigner(s.T())
+	mockRecorder := newMockiAuditRecorder(s.T())
+	service := NewTeamService(mockTeamRepo, mockUserRepo, newNoTeamFallbacks(s.T()), mockAssigner,
+		mockRecorder, newPassthroughTransactor(s.T()))
+	alice := domain.User{ID: "user-1", Username: "alice", TeamName: "backend", IsActive: true}
+	bob := domain.User{ID: "user-2", Username: "bob", TeamName: "backend"}
+	inactiveAlice := alice
+	inactiveAlice.IsActive = false
+
+	mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+	mockUserRepo.EXPECT().GetByTeamName(s.ctx, "backend").Return([]domain.User{alice, bob}, nil).Once()
+	mockUserRepo.EXPECT().DeactivateTeamUsers(s.ctx, "backend", []string(nil)).
+		Return([]domain.User{inactiveAlice, bob}, nil).Once()
+	// bob уже был неактивен, событие пишется только для alice, все события - одной пачкой
+	mockRecorder.EXPECT().AddBatch(s.ctx, mock.MatchedBy(func(events []domain.AuditEvent) bool {
+		return len(events) == 1 && events[0].Action == domain.AuditActionUserSetIsActive &&
+			events[0].EntityID == "user-1" &&
+			strings.Contains(string(events[0].Before), `"is_active":true`) &&
+			strings.Contains(string(events[0].After), `"is_active":false`)
+	})).Return(nil).Once()
+	mockAssigner.EXPECT().HandOverReviews(s.ctx, []string{"user-1", "user-2"}, "").Return(nil, nil).Once()
+
+	users, _, err := service.DeactivateUsers(s.ctx, "backend", nil)
+
+	s.Require().NoError(err)
+	s.Len(users, 2)
+}
+
+// newNoTeamFallbacks возвращает мок хранилища запасных пулов для команд без них
+func newNoTeamFallbacks(t *testing.T) *mockiTeamFallbackRepository {
+	fallbacks := newMockiTeamFallbackRepository(t)
+	fallbacks.EXPECT().GetTeamFallbacks(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return fallbacks
+}
+
+// newTeamServiceMocks создаёт сервис команд на моках, аудит принимает всё
+func (s *TeamServiceTestSuite) newTeamServiceMocks() (
+	*TeamService,
+	*mockiTeamRepository,
+	*mockiTeamUserRepository,
+	*mockiReviewerAssigner,
+) {
+	mockTeamRepo := newMockiTeamRepository(s.T())
+	mockUserRepo := newMockiTeamUserRepository(s.T())
+	mockAssigner := newMockiReviewerAssigner(s.T())
+	service := NewTeamService(mockTeamRepo, mockUserRepo, newNoTeamFallbacks(s.T()), mockAssigner,
+		newAcceptingAuditRecorder(s.T()), newPassthroughTransactor(s.T()))
+	return service, mockTeamRepo, mockUserRepo, mockAssigner
+}
+
+// TestRename проверяет переименование команды
+func (s *TeamServiceTestSuite) TestRename() {
+	s.Run("success", func() {
+		service, mockTeamRepo, mockUserRepo, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Rename(s.ctx, "backend", "platform").Return(domain.Team{Name: "platform"}, nil).Once()
+		mockUserRepo.EXPECT().GetByTeamName(s.ctx, "platform").
+			Return([]domain.User{{ID: "user-1", TeamName: "platform"}}, nil).Once()
+
+		team, err := service.Rename(s.ctx, "backend", "platform")
+
+		s.Require().NoError(err)
+		s.Equal("platform", team.Name)
+		s.Len(team.Members, 1)
+	})
+
+	s.Run("same name", func() {
+		service, _, _, _ := s.newTeamServiceMocks()
+		_, err := service.Rename(s.ctx, "backend", "backend")
+		s.ErrorIs(err, domain.ErrInvalidTeamChange)
+	})
+
+	s.Run("name is taken", func() {
+		service, mockTeamRepo, _, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Rename(s.ctx, "backend", "frontend").
+			Return(domain.Team{}, domain.ErrTeamAlreadyExists).Once()
+
+		_, err := service.Rename(s.ctx, "backend", "frontend")
+		s.ErrorIs(err, domain.ErrTeamAlreadyExists)
+	})
+}
+
+// TestMoveUsers проверяет перевод пользователей в другую команду
+func (s *TeamServiceTestSuite) TestMoveUsers() {
+	s.Run("success", func() {
+		service, mockTeamRepo, mockUserRepo, mockAssigner := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "frontend").Return(true, nil).Once()
+		mockUserRepo.EXPECT().GetByID(s.ctx, "user-1").
+			Return(domain.User{ID: "user-1", TeamName: "backend", IsActive: true}, nil).Once()
+		mockUserRepo.EXPECT().MoveUsersToTeam(s.ctx, "frontend", []string{"user-1"}).
+			Return([]domain.User{{ID: "user-1", TeamName: "frontend", IsActive: true}}, nil).Once()
+		// ревью передаются только на PR бывших товарищей по команде
+		mockAssigner.EXPECT().HandOverReviews(s.ctx, []string{"user-1"}, "backend").Return(
+			[]domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-2"}}, nil,
+		).Once()
+		// активный пользователь может сразу получить ревью в новой команде
+		mockAssigner.EXPECT().TopUpReviewers(s.ctx, "frontend").Return(nil, nil).Once()
+
+		users, replacements, err := service.MoveUsers(s.ctx, "frontend", []string{"user-1"})
+
+		s.Require().NoError(err)
+		s.Equal("frontend", users[0].TeamName)
+		s.Len(replacements, 1)
+	})
+
+	s.Run("already a member", func() {
+		service, mockTeamRepo, mockUserRepo, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+		mockUserRepo.EXPECT().GetByID(s.ctx, "user-1").Return(domain.User{ID: "user-1", TeamName: "backend"}, nil).Once()
+
+		_, _, err := service.MoveUsers(s.ctx, "backend", []string{"user-1"})
+		s.ErrorIs(err, domain.ErrInvalidTeamChange)
+	})
+
+	s.Run("team not found", func() {
+		service, mockTeamRepo, _, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "ghost").Return(false, nil).Once()
+
+		_, _, err := service.MoveUsers(s.ctx, "ghost", []string{"user-1"})
+		s.ErrorIs(err, domain.ErrTeamNotFound)
+	})
+}
+
+// TestDelete проверяет удаление команды с переводом участников
+func (s *TeamServiceTestSuite) TestDelete() {
+	members := []domain.User{{ID: "user-1", TeamName: "backend"}, {ID: "user-2", TeamName: "backend"}}
+
+	s.Run("members are moved", func() {
+		service, mockTeamRepo, mockUserRepo, mockAssigner := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+		mockTeamRepo.EXPECT().Get(s.ctx, "backend").Return(domain.Team{Name: "backend"}, nil).Once()
+		mockUserRepo.EXPECT().GetByTeamName(s.ctx, "backend").Return(members, nil).Once()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "frontend").Return(true, nil).Once()
+		mockUserRepo.EXPECT().GetByID(s.ctx, "user-1").Return(members[0], nil).Once()
+		mockUserRepo.EXPECT().GetByID(s.ctx, "user-2").Return(members[1], nil).Once()
+		mockUserRepo.EXPECT().MoveUsersToTeam(s.ctx, "frontend", []string{"user-1", "user-2"}).Return(
+			[]domain.User{{ID: "user-1", TeamName: "frontend"}, {ID: "user-2", TeamName: "frontend"}}, nil,
+		).Once()
+		mockAssigner.EXPECT().HandOverReviews(s.ctx, []string{"user-1", "user-2"}, "backend").
+			Return([]domain.ReviewerReplacement{}, nil).Once()
+		mockUserRepo.EXPECT().MoveDeletedUsers(s.ctx, "backend", "frontend").Return(
+			[]domain.User{{ID: "user-3", TeamName: "frontend", DeletedAt: time.Now()}}, nil).Once()
+		mockTeamRepo.EXPECT().Delete(s.ctx, "backend").Return(nil).Once()
+
+		users, replacements, err := service.Delete(s.ctx, "backend", "frontend")
+
+		s.Require().NoError(err)
+		s.Len(users, 2)
+		s.Empty(replacements)
+	})
+
+	s.Run("members without target", func() {
+		service, mockTeamRepo, mockUserRepo, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+		mockTeamRepo.EXPECT().Get(s.ctx, "backend").Return(domain.Team{Name: "backend"}, nil).Once()
+		mockUserRepo.EXPECT().GetByTeamName(s.ctx, "backend").Return(members, nil).Once()
+
+		_, _, err := service.Delete(s.ctx, "backend", "")
+		s.ErrorIs(err, domain.ErrTeamNotEmpty)
+	})
+
+	s.Run("empty team", func() {
+		service, mockTeamRepo, mockUserRepo, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+		mockTeamRepo.EXPECT().Get(s.ctx, "backend").Return(domain.Team{Name: "backend"}, nil).Once()
+		mockUserRepo.EXPECT().GetByTeamName(s.ctx, "backend").Return([]domain.User{}, nil).Once()
+		mockTeamRepo.EXPECT().Delete(s.ctx, "backend").Return(nil).Once()
+
+		users, _, err := service.Delete(s.ctx, "backend", "")
+
+		s.Require().NoError(err)
+		s.Empty(users)
+	})
+
+	s.Run("team not found", func() {
+		service, mockTeamRepo, _, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "ghost").Return(false, nil).Once()
+
+		_, _, err := service.Delete(s.ctx, "ghost", "frontend")
+		s.ErrorIs(err, domain.ErrTeamNotFound)
+	})
+
+	s.Run("target team not found", func() {
+		service, mockTeamRepo, _, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "ghost").Return(false, nil).Once()
+
+		// до состава команды и переноса дело не доходит
+		_, _, err := service.Delete(s.ctx, "backend", "ghost")
+		s.ErrorIs(err, domain.ErrTeamNotFound)
+	})
+
+	s.Run("target is the deleted team", func() {
+		service, mockTeamRepo, _, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Exists(s.ctx, "backend").Return(true, nil).Once()
+
+		_, _, err := service.Delete(s.ctx, "backend", "backend")
+		s.ErrorIs(err, domain.ErrInvalidTeamChange)
+	})
+}
+
+// TestSetFallbacks проверяет замену запасных пулов команды
+func (s *TeamServiceTestSuite) TestSetFallbacks() {
+	guild := domain.SharedPool("guild")
+
+	s.Run("success", func() {
+		mockTeamRepo := newMockiTeamRepository(s.T())
+		mockUserRepo := newMockiTeamUserRepository(s.T())
+		mockFallbacks := newMockiTeamFallbackRepository(s.T())
+		mockAssigner := newMockiReviewerAssigner(s.T())
+		service := NewTeamService(mockTeamRepo, mockUserRepo, mockFallbacks, mockAssigner,
+			newAcceptingAuditRecorder(s.T()), newPassthroughTransactor(s.T()))
+		fallbacks := []domain.ReviewerPool{domain.TeamPool("frontend"), guild}
+
+		mockTeamRepo.EXPECT().Get(s.ctx, "backend").Return(domain.Team{Name: "backend"}, nil).Twice()
+		mockFallbacks.EXPECT().GetTeamFallbacks(s.ctx, "backend").Return(nil, nil).Once()
+		mockFallbacks.EXPECT().SetTeamFallbacks(s.ctx, "backend", fallbacks).Return(nil).Once()
+		// PR команды, которым не хватает ревьюверов, добирают их из новых пулов
+		mockAssigner.EXPECT().TopUpReviewers(s.ctx, "backend").Return(nil, nil).Once()
+		mockUserRepo.EXPECT().GetByTeamName(s.ctx, "backend").Return([]domain.User{}, nil).Once()
+		mockFallbacks.EXPECT().GetTeamFallbacks(s.ctx, "backend").Return(fallbacks, nil).Once()
+
+		team, err := service.SetFallbacks(s.ctx, "backend", fallbacks)
+
+		s.Require().NoError(err)
+		s.Equal(fallbacks, team.Fallbacks)
+	})
+
+	s.Run("team not found", func() {
+		service, mockTeamRepo, _, _ := s.newTeamServiceMocks()
+		mockTeamRepo.EXPECT().Get(s.ctx, "ghost").Return(domain.Team{}, domain.ErrTeamNotFound).Once()
+
+		_, err := service.SetFallbacks(s.ctx, "ghost", []domain.ReviewerPool{guild})
+		s.ErrorIs(err, domain.ErrTeamNotFound)
+	})
+
+	invalid := map[string][]domain.ReviewerPool{
+		"self":         {domain.TeamPool("backend")},
+		"duplicate":    {guild, guild},
+		"unknown type": {{Kind: "group", Name: "guild"}},
+		"empty name":   {domain.SharedPool("")},
+	}
+	for name, fallbacks := range invalid {
+		s.Run(name, func() {
+			service, _, _, _ := s.newTeamServiceMocks()
+			_, err := service.SetFallbacks(s.ctx, "backend", fallbacks)
+			s.ErrorIs(err, domain.ErrInvalidTeamChange)
+		})
+	}
+}
+
 // TestTeamServiceSuite запускает test suite
 func TestTeamServiceSuite(t *testing.T) {
 	suite.Run(t, new(TeamServiceTestSuite))
@@ -9,15 +9,40 @@ import (
 
 type iUserRepository interface {
 	ExistsByID(ctx context.Context, userID string) (bool, error)
+	GetByID(ctx context.Context, userID string) (domain.User, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) (domain.User, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error)
+	List(ctx context.Context, filter domain.UserFilter) ([]domain.User, error)
+	DeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string) ([]domain.User, error)
+	SoftDelete(ctx context.Context, userID string) (domain.User, error)
+}
+
+// iReviewerAssigner assigns missing reviewers to open pull requests of a team and hands open reviews
+// of users who may not review anymore over to others
+type iReviewerAssigner interface {
+	TopUpReviewers(ctx context.Context, teamName string) ([]domain.PullRequest, error)
+	HandOverReviews(ctx context.Context, reviewerIDs []string, authorTeam string) ([]domain.ReviewerReplacement, error)
 }
 
 type UserService struct {
-	userRepo iUserRepository
+	userRepo         iUserRepository
+	reviewerAssigner iReviewerAssigner
+	auditRecorder    iAuditRecorder
+	transactor       iTransactor
 }
 
-func NewUserService(userRepo iUserRepository) *UserService {
-	return &UserService{userRepo: userRepo}
+func NewUserService(
+	userRepo iUserRepository,
+	reviewerAssigner iReviewerAssigner,
+	auditRecorder iAuditRecorder,
+	transactor iTransactor,
+) *UserService {
+	return &UserService{
+		userRepo:         userRepo,
+		reviewerAssigner: reviewerAssigner,
+		auditRecorder:    auditRecorder,
+		transactor:       transactor,
+	}
 }
 
 func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (domain.User, error) {
@@ -29,5 +54,160 @@ func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive b
 		return domain.User{}, fmt.Errorf("user with ID %s: %w", userID, domain.ErrUserNotFound)
 	}
 
-	return s.userRepo.SetIsActive(ctx, userID, isActive)
+	// Activated user may review pull requests of the team that lack reviewers
+	var user domain.User
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("error getting user %s: %w", userID, err)
+		}
+		user, err = s.userRepo.SetIsActive(ctx, userID, isActive)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, s.auditRecorder, domain.AuditActionUserSetIsActive, domain.AuditEntityUser, userID,
+			userSnapshot(before), userSnapshot(user)); err != nil {
+			return err
+		}
+		if !isActive {
+			return nil
+		}
+		if _, err := s.reviewerAssigner.TopUpReviewers(ctx, user.TeamName); err != nil {
+			return fmt.Errorf("error topping up reviewers of team %s: %w", user.TeamName, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+	return user, nil
+}
+
+// Get returns the user
+func (s *UserService) Get(ctx context.Context, userID string) (domain.User, error) {
+	exists, err := s.userRepo.ExistsByID(ctx, userID)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("error checking if user %s exists: %w", userID, err)
+	}
+	if !exists {
+		return domain.User{}, fmt.Errorf("user with ID %s: %w", userID, domain.ErrUserNotFound)
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("error getting user %s: %w", userID, err)
+	}
+	return user, nil
+}
+
+// List returns a page of users matching the filter ordered by ID, deleted users are not listed.
+// The page holds filter.Limit users at most, DefaultUserPageSize if the limit is not set
+func (s *UserService) List(ctx context.Context, filter domain.UserFilter) (domain.UserPage, error) {
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultUserPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > domain.MaxUserPageSize {
+		return domain.UserPage{}, fmt.Errorf("%w: limit must be between 1 and %d",
+			domain.ErrInvalidUserFilter, domain.MaxUserPageSize)
+	}
+
+	// one extra user tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	users, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		return domain.UserPage{}, fmt.Errorf("error listing users: %w", err)
+	}
+
+	page := domain.UserPage{Users: users, NextAfterID: ""}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		page.NextAfterID = page.Users[limit-1].ID
+	}
+	return page, nil
+}
+
+// Delete deletes the user softly: the user is deactivated and hidden, while their pull requests,
+// reviews and audit records stay. Open reviews of the user are handed over as on reassignment
+func (s *UserService) Delete(ctx context.Context, userID string) (domain.User, []domain.ReviewerReplacement, error) {
+	var (
+		user         domain.User
+		replacements []domain.ReviewerReplacement
+	)
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("error getting user %s: %w", userID, err)
+		}
+		if before.IsDeleted() {
+			return fmt.Errorf("user with ID %s: %w", userID, domain.ErrUserNotFound)
+		}
+
+		if _, err := s.userRepo.DeactivateTeamUsers(ctx, before.TeamName, []string{userID}); err != nil {
+			return fmt.Errorf("error deactivating user %s: %w", userID, err)
+		}
+		replacements, err = s.reviewerAssigner.HandOverReviews(ctx, []string{userID}, "")
+		if err != nil {
+			return fmt.Errorf("error handing over reviews of user %s: %w", userID, err)
+		}
+
+		user, err = s.userRepo.SoftDelete(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("error deleting user %s: %w", userID, err)
+		}
+		return recordAudit(ctx, s.auditRecorder, domain.AuditActionUserDelete, domain.AuditEntityUser, userID,
+			userSnapshot(before), nil)
+	})
+	if err != nil {
+		return domain.User{}, nil, err
+	}
+	return user, replacements, nil
+}
+
+// SetMaxOpenReviews limits the number of open pull requests the user reviews at once, 0 removes the limit.
+// Reviews the user already has are kept even above the limit. If the limit gets looser,
+// pull requests of the team that lack reviewers may get the user
+func (s *UserService) SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews int) (domain.User, error) {
+	if maxOpenReviews < 0 || maxOpenReviews > domain.MaxReviewLimit {
+		return domain.User{}, fmt.Errorf("%w: max_open_reviews must be between 0 and %d", domain.ErrInvalidReviewLimit,
+			domain.MaxReviewLimit)
+	}
+	exists, err := s.userRepo.ExistsByID(ctx, userID)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("error checking if user %s exists: %w", userID, err)
+	}
+	if !exists {
+		return domain.User{}, fmt.Errorf("user with ID %s: %w", userID, domain.ErrUserNotFound)
+	}
+
+	var user domain.User
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("error getting user %s: %w", userID, err)
+		}
+		user, err = s.userRepo.SetMaxOpenReviews(ctx, userID, maxOpenReviews)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, s.auditRecorder, domain.AuditActionUserSetLimit, domain.AuditEntityUser, userID,
+			userSnapshot(before), userSnapshot(user)); err != nil {
+			return err
+		}
+		if !user.IsActive || !loosened(before.MaxOpenReviews, user.MaxOpenReviews) {
+			return nil
+		}
+		if _, err := s.reviewerAssigner.TopUpReviewers(ctx, user.TeamName); err != nil {
+			return fmt.Errorf("error topping up reviewers of team %s: %w", user.TeamName, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+	return user, nil
+}
+
+// loosened reports whether the review limit after lets the user take more reviews than before
+func loosened(before, after int) bool {
+	return before != 0 && (after == 0 || after > before)
 }
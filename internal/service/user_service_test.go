@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -28,9 +29,11 @@ func (s *UserServiceTestSuite) TestSetIsActive() {
 		userID      string
 		isActive    bool
 		arrangeFunc func(ctx context.Context, mockRepo *mockiUserRepository)
-		wantErr     bool
-		wantErrIs   error
-		checkResult func(result domain.User)
+		// arrangeTopUp настраивает добор ревьюверов, если он ожидается
+		arrangeTopUp func(ctx context.Context, mockAssigner *mockiReviewerAssigner)
+		wantErr      bool
+		wantErrIs    error
+		checkResult  func(result domain.User)
 	}{
 		{
 			name:     "success - activate user",
@@ -41,14 +44,23 @@ func (s *UserServiceTestSuite) TestSetIsActive() {
 					ExistsByID(ctx, "user-123").
 					Return(true, nil).Once()
 
+				mockRepo.EXPECT().
+					GetByID(ctx, "user-123").
+					Return(domain.User{ID: "user-123", TeamName: "backend-team", IsActive: false}, nil).Once()
 				mockRepo.EXPECT().
 					SetIsActive(ctx, "user-123", true).
 					Return(domain.User{
 						ID:       "user-123",
 						Username: "testuser",
+						TeamName: "backend-team",
 						IsActive: true,
 					}, nil).Once()
 			},
+			arrangeTopUp: func(ctx context.Context, mockAssigner *mockiReviewerAssigner) {
+				mockAssigner.EXPECT().
+					TopUpReviewers(ctx, "backend-team").
+					Return([]domain.PullRequest{{ID: "pr-1", NeedMoreReviewers: false}}, nil).Once()
+			},
 			wantErr: false,
 			checkResult: func(result domain.User) {
 				s.Equal("user-123", result.ID)
@@ -63,6 +75,9 @@ func (s *UserServiceTestSuite) TestSetIsActive() {
 				mockRepo.EXPECT().
 					ExistsByID(ctx, "user-123").
 					Return(true, nil).Once()
+				mockRepo.EXPECT().
+					GetByID(ctx, "user-123").
+					Return(domain.User{ID: "user-123", TeamName: "backend-team", IsActive: true}, nil).Once()
 				mockRepo.EXPECT().
 					SetIsActive(ctx, "user-123", false).
 					Return(domain.User{
@@ -108,21 +123,51 @@ func (s *UserServiceTestSuite) TestSetIsActive() {
 				mockRepo.EXPECT().
 					ExistsByID(ctx, "user-123").
 					Return(true, nil).Once()
+				mockRepo.EXPECT().
+					GetByID(ctx, "user-123").
+					Return(domain.User{ID: "user-123", TeamName: "backend-team", IsActive: true}, nil).Once()
 				mockRepo.EXPECT().
 					SetIsActive(ctx, "user-123", false).
 					Return(domain.User{}, errors.New("update error")).Once()
 			},
 			wantErr: true,
 		},
+		{
+			name:     "top up error",
+			userID:   "user-123",
+			isActive: true,
+			arrangeFunc: func(ctx context.Context, mockRepo *mockiUserRepository) {
+				mockRepo.EXPECT().
+					ExistsByID(ctx, "user-123").
+					Return(true, nil).Once()
+				mockRepo.EXPECT().
+					GetByID(ctx, "user-123").
+					Return(domain.User{ID: "user-123", TeamName: "backend-team", IsActive: false}, nil).Once()
+				mockRepo.EXPECT().
+					SetIsActive(ctx, "user-123", true).
+					Return(domain.User{ID: "user-123", TeamName: "backend-team", IsActive: true}, nil).Once()
+			},
+			arrangeTopUp: func(ctx context.Context, mockAssigner *mockiReviewerAssigner) {
+				mockAssigner.EXPECT().
+					TopUpReviewers(ctx, "backend-team").
+					Return(nil, errors.New("database error")).Once()
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			// Arrange
 			mockRepo := newMockiUserRepository(s.T())
-			service := NewUserService(mockRepo)
+			mockAssigner := newMockiReviewerAssigner(s.T())
+			service := NewUserService(mockRepo, mockAssigner, newAcceptingAuditRecorder(s.T()),
+				newPassthroughTransactor(s.T()))
 
 			tt.arrangeFunc(s.ctx, mockRepo)
+			if tt.arrangeTopUp != nil {
+				tt.arrangeTopUp(s.ctx, mockAssigner)
+			}
 
 			// Act
 			result, err := service.SetIsActive(s.ctx, tt.userID, tt.isActive)
@@ -144,6 +189,157 @@ func (s *UserServiceTestSuite) TestSetIsActive() {
 	}
 }
 
+// TestSetMaxOpenReviews проверяет лимит открытых ревью и добор ревьюверов при его ослаблении
+func (s *UserServiceTestSuite) TestSetMaxOpenReviews() {
+	s.Run("invalid limit", func() {
+		service := NewUserService(newMockiUserRepository(s.T()), newMockiReviewerAssigner(s.T()),
+			newAcceptingAuditRecorder(s.T()), newPassthroughTransactor(s.T()))
+
+		for _, limit := range []int{-1, domain.MaxReviewLimit + 1} {
+			_, err := service.SetMaxOpenReviews(s.ctx, "user-1", limit)
+			s.ErrorIs(err, domain.ErrInvalidReviewLimit)
+		}
+	})
+
+	s.Run("user not found", func() {
+		mockRepo := newMockiUserRepository(s.T())
+		mockRepo.EXPECT().ExistsByID(s.ctx, "user-1").Return(false, nil).Once()
+		service := NewUserService(mockRepo, newMockiReviewerAssigner(s.T()),
+			newAcceptingAuditRecorder(s.T()), newPassthroughTransactor(s.T()))
+
+		_, err := service.SetMaxOpenReviews(s.ctx, "user-1", 3)
+		s.ErrorIs(err, domain.ErrUserNotFound)
+	})
+
+	tests := []struct {
+		name      string
+		before    int
+		after     int
+		isActive  bool
+		wantTopUp bool
+	}{
+		{name: "limit set", before: 0, after: 2, isActive: true, wantTopUp: false},
+		{name: "limit tightened", before: 3, after: 2, isActive: true, wantTopUp: false},
+		{name: "limit raised", before: 2, after: 3, isActive: true, wantTopUp: true},
+		{name: "limit removed", before: 2, after: 0, isActive: true, wantTopUp: true},
+		{name: "inactive user", before: 2, after: 0, isActive: false, wantTopUp: false},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockRepo := newMockiUserRepository(s.T())
+			mockAssigner := newMockiReviewerAssigner(s.T())
+			service := NewUserService(mockRepo, mockAssigner, newAcceptingAuditRecorder(s.T()),
+				newPassthroughTransactor(s.T()))
+
+			user := domain.User{ID: "user-1", TeamName: "backend-team", IsActive: tt.isActive, MaxOpenReviews: tt.before}
+			mockRepo.EXPECT().ExistsByID(s.ctx, "user-1").Return(true, nil).Once()
+			mockRepo.EXPECT().GetByID(s.ctx, "user-1").Return(user, nil).Once()
+			user.MaxOpenReviews = tt.after
+			mockRepo.EXPECT().SetMaxOpenReviews(s.ctx, "user-1", tt.after).Return(user, nil).Once()
+			if tt.wantTopUp {
+				mockAssigner.EXPECT().TopUpReviewers(s.ctx, "backend-team").Return(nil, nil).Once()
+			}
+
+			result, err := service.SetMaxOpenReviews(s.ctx, "user-1", tt.after)
+
+			s.Require().NoError(err)
+			s.Equal(tt.after, result.MaxOpenReviews)
+		})
+	}
+}
+
+// TestList проверяет проверку лимита и курсор следующей страницы
+func (s *UserServiceTestSuite) TestList() {
+	newService := func(mockRepo *mockiUserRepository) *UserService {
+		return NewUserService(mockRepo, newMockiReviewerAssigner(s.T()), newMockiAuditRecorder(s.T()),
+			newMockiTransactor(s.T()))
+	}
+	users := []domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}}
+
+	s.Run("invalid limit", func() {
+		service := newService(newMockiUserRepository(s.T()))
+
+		for _, limit := range []int{-1, domain.MaxUserPageSize + 1} {
+			_, err := service.List(s.ctx, domain.UserFilter{Limit: limit})
+			s.ErrorIs(err, domain.ErrInvalidUserFilter)
+		}
+	})
+
+	s.Run("default limit", func() {
+		mockRepo := newMockiUserRepository(s.T())
+		mockRepo.EXPECT().List(s.ctx, domain.UserFilter{Limit: domain.DefaultUserPageSize + 1}).
+			Return(users, nil).Once()
+
+		page, err := newService(mockRepo).List(s.ctx, domain.UserFilter{})
+
+		s.Require().NoError(err)
+		s.Len(page.Users, 3)
+		s.Empty(page.NextAfterID)
+	})
+
+	s.Run("next page", func() {
+		mockRepo := newMockiUserRepository(s.T())
+		filter := domain.UserFilter{TeamName: "backend", Limit: 2, AfterID: "u0"}
+		mockRepo.EXPECT().List(s.ctx, domain.UserFilter{TeamName: "backend", Limit: 3, AfterID: "u0"}).
+			Return(users, nil).Once()
+
+		page, err := newService(mockRepo).List(s.ctx, filter)
+
+		s.Require().NoError(err)
+		s.Equal([]domain.User{{ID: "u1"}, {ID: "u2"}}, page.Users)
+		s.Equal("u2", page.NextAfterID)
+	})
+}
+
+// TestDelete проверяет мягкое удаление с передачей открытых ревью
+func (s *UserServiceTestSuite) TestDelete() {
+	user := domain.User{ID: "user-1", TeamName: "backend", IsActive: true}
+
+	s.Run("success", func() {
+		mockRepo := newMockiUserRepository(s.T())
+		mockAssigner := newMockiReviewerAssigner(s.T())
+		service := NewUserService(mockRepo, mockAssigner, newAcceptingAuditRecorder(s.T()),
+			newPassthroughTransactor(s.T()))
+
+		replacements := []domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-2"}}
+		deleted := domain.User{ID: "user-1", TeamName: "backend", IsActive: false, DeletedAt: time.Now()}
+		mockRepo.EXPECT().GetByID(s.ctx, "user-1").Return(user, nil).Once()
+		mockRepo.EXPECT().DeactivateTeamUsers(s.ctx, "backend", []string{"user-1"}).
+			Return([]domain.User{{ID: "user-1", TeamName: "backend"}}, nil).Once()
+		mockAssigner.EXPECT().HandOverReviews(s.ctx, []string{"user-1"}, "").Return(replacements, nil).Once()
+		mockRepo.EXPECT().SoftDelete(s.ctx, "user-1").Return(deleted, nil).Once()
+
+		result, gotReplacements, err := service.Delete(s.ctx, "user-1")
+
+		s.Require().NoError(err)
+		s.True(result.IsDeleted())
+		s.False(result.IsActive)
+		s.Equal(replacements, gotReplacements)
+	})
+
+	s.Run("already deleted", func() {
+		mockRepo := newMockiUserRepository(s.T())
+		service := NewUserService(mockRepo, newMockiReviewerAssigner(s.T()), newMockiAuditRecorder(s.T()), newPassthroughTransactor(s.T()))
+
+		deleted := user
+		deleted.DeletedAt = time.Now()
+		mockRepo.EXPECT().GetByID(s.ctx, "user-1").Return(deleted, nil).Once()
+
+		_, _, err := service.Delete(s.ctx, "user-1")
+		s.ErrorIs(err, domain.ErrUserNotFound)
+	})
+
+	s.Run("user not found", func() {
+		mockRepo := newMockiUserRepository(s.T())
+		service := NewUserService(mockRepo, newMockiReviewerAssigner(s.T()), newMockiAuditRecorder(s.T()), newPassthroughTransactor(s.T()))
+
+		mockRepo.EXPECT().GetByID(s.ctx, "ghost").Return(domain.User{}, domain.ErrUserNotFound).Once()
+
+		_, _, err := service.Delete(s.ctx, "ghost")
+		s.ErrorIs(err, domain.ErrUserNotFound)
+	})
+}
+
 // TestUserServiceSuite запускает test suite
 func TestUserServiceSuite(t *testing.T) {
 	suite.Run(t, new(UserServiceTestSuite))
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+type iWebhookRepository interface {
+	Subscribe(ctx context.Context, subscription domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	Unsubscribe(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)
+	RetryDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error)
+}
+
+// secretSize is the number of random bytes in a generated subscription secret
+const secretSize = 32
+
+// WebhookService manages webhook subscriptions and shows how their deliveries go.
+// Events are emitted by the services that make the mutations and delivered by webhook.Dispatcher
+type WebhookService struct {
+	repository iWebhookRepository
+}
+
+func NewWebhookService(repository iWebhookRepository) *WebhookService {
+	return &WebhookService{repository: repository}
+}
+
+// Subscribe registers the URL to receive events of the given types.
+// A random secret is generated if none is given, it is returned only here
+func (s *WebhookService) Subscribe(
+	ctx context.Context,
+	subscription domain.WebhookSubscription,
+) (domain.WebhookSubscription, error) {
+	target, err := url.Parse(subscription.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return domain.WebhookSubscription{}, fmt.Errorf("%w: url must be an absolute http(s) URL",
+			domain.ErrInvalidWebhook)
+	}
+	if len(subscription.EventTypes) == 0 {
+		return domain.WebhookSubscription{}, fmt.Errorf("%w: at least one event type is required",
+			domain.ErrInvalidWebhook)
+	}
+	for _, eventType := range subscription.EventTypes {
+		if !domain.IsValidEventType(eventType) {
+			return domain.WebhookSubscription{}, fmt.Errorf("%w: unknown event type %q",
+				domain.ErrInvalidWebhook, eventType)
+		}
+	}
+	slices.Sort(subscription.EventTypes)
+	subscription.EventTypes = slices.Compact(subscription.EventTypes)
+
+	if subscription.Secret == "" {
+		secret := make([]byte, secretSize)
+		if _, err := rand.Read(secret); err != nil {
+			return domain.WebhookSubscription{}, fmt.Errorf("error generating webhook secret: %w", err)
+		}
+		subscription.Secret = hex.EncodeToString(secret)
+	}
+
+	created, err := s.repository.Subscribe(ctx, subscription)
+	if err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("error creating webhook subscription: %w", err)
+	}
+	return created, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	subscriptions, err := s.repository.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// Unsubscribe removes the subscription, its pending deliveries are dropped
+func (s *WebhookService) Unsubscribe(ctx context.Context, id int64) error {
+	if err := s.repository.Unsubscribe(ctx, id); err != nil {
+		return fmt.Errorf("error deleting webhook subscription %d: %w", id, err)
+	}
+	return nil
+}
+
+// ListDeliveries returns a page of deliveries matching the filter, newest first.
+// The page holds filter.Limit deliveries at most, DefaultDeliveryPageSize if the limit is not set
+func (s *WebhookService) ListDeliveries(
+	ctx context.Context,
+	filter domain.WebhookDeliveryFilter,
+) (domain.WebhookDeliveryPage, error) {
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultDeliveryPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > domain.MaxDeliveryPageSize {
+		return domain.WebhookDeliveryPage{}, fmt.Errorf("%w: limit must be between 1 and %d",
+			domain.ErrInvalidWebhook, domain.MaxDeliveryPageSize)
+	}
+	if filter.AfterID < 0 {
+		return domain.WebhookDeliveryPage{}, fmt.Errorf("%w: cursor must be positive", domain.ErrInvalidWebhook)
+	}
+	if filter.Status != "" && !domain.IsValidDeliveryStatus(filter.Status) {
+		return domain.WebhookDeliveryPage{}, fmt.Errorf("%w: unknown delivery status %q",
+			domain.ErrInvalidWebhook, filter.Status)
+	}
+
+	// one extra delivery tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	deliveries, err := s.repository.ListDeliveries(ctx, filter)
+	if err != nil {
+		return domain.WebhookDeliveryPage{}, fmt.Errorf("error listing webhook deliveries: %w", err)
+	}
+
+	page := domain.WebhookDeliveryPage{Deliveries: deliveries, NextAfterID: 0}
+	if len(deliveries) > limit {
+		page.Deliveries = deliveries[:limit]
+		page.NextAfterID = page.Deliveries[limit-1].ID
+	}
+	return page, nil
+}
+
+// Redeliver puts a dead delivery back to the queue, the dispatcher picks it up on the next run
+func (s *WebhookService) Redeliver(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	delivery, err := s.repository.RetryDelivery(ctx, id)
+	if err != nil {
+		return domain.WebhookDelivery{}, fmt.Errorf("error redelivering webhook delivery %d: %w", id, err)
+	}
+	return delivery, nil
+}
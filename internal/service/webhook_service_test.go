@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+)
+
+// WebhookServiceTestSuite определяет test suite для WebhookService и записи событий в outbox
+type WebhookServiceTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+// SetupTest выполняется перед каждым тестом
+func (s *WebhookServiceTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+// TestSubscribe проверяет проверку URL и типов событий, дедупликацию типов и генерацию секрета
+func (s *WebhookServiceTestSuite) TestSubscribe() {
+	tests := []struct {
+		name         string
+		subscription domain.WebhookSubscription
+		wantErr      bool
+	}{
+		{
+			name: "relative URL",
+			subscription: domain.WebhookSubscription{
+				URL:        "/hooks",
+				EventTypes: []domain.EventType{domain.EventPullRequestMerged},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported scheme",
+			subscription: domain.WebhookSubscription{
+				URL:        "ftp://example.com/hooks",
+				EventTypes: []domain.EventType{domain.EventPullRequestMerged},
+			},
+			wantErr: true,
+		},
+		{
+			name:         "no event types",
+			subscription: domain.WebhookSubscription{URL: "https://example.com/hooks"},
+			wantErr:      true,
+		},
+		{
+			name: "unknown event type",
+			subscription: domain.WebhookSubscription{
+				URL:        "https://example.com/hooks",
+				EventTypes: []domain.EventType{"pull_request.deleted"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			service := NewWebhookService(newMockiWebhookRepository(s.T()))
+
+			_, err := service.Subscribe(s.ctx, tt.subscription)
+
+			s.ErrorIs(err, domain.ErrInvalidWebhook)
+		})
+	}
+
+	s.Run("secret is generated and event types are deduplicated", func() {
+		mockRepo := newMockiWebhookRepository(s.T())
+		mockRepo.EXPECT().
+			Subscribe(s.ctx, mock.Anything).
+			RunAndReturn(func(_ context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+				sub.ID = 1
+				return sub, nil
+			}).Once()
+		service := NewWebhookService(mockRepo)
+
+		created, err := service.Subscribe(s.ctx, domain.WebhookSubscription{
+			URL: "https://example.com/hooks",
+			EventTypes: []domain.EventType{
+				domain.EventPullRequestMerged, domain.EventReviewersAssigned, domain.EventPullRequestMerged,
+			},
+		})
+
+		s.Require().NoError(err)
+		s.Equal(int64(1), created.ID)
+		s.Len(created.Secret, 2*secretSize)
+		s.Equal([]domain.EventType{domain.EventPullRequestMerged, domain.EventReviewersAssigned}, created.EventTypes)
+	})
+
+	s.Run("given secret is kept", func() {
+		mockRepo := newMockiWebhookRepository(s.T())
+		mockRepo.EXPECT().
+			Subscribe(s.ctx, mock.MatchedBy(func(sub domain.WebhookSubscription) bool {
+				return sub.Secret == "my-secret"
+			})).
+			RunAndReturn(func(_ context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+				return sub, nil
+			}).Once()
+		service := NewWebhookService(mockRepo)
+
+		_, err := service.Subscribe(s.ctx, domain.WebhookSubscription{
+			URL:        "http://localhost:8081/hooks",
+			Secret:     "my-secret",
+			EventTypes: []domain.EventType{domain.EventReviewerReassigned},
+		})
+
+		s.NoError(err)
+	})
+}
+
+// TestListDeliveries проверяет значения по умолчанию, проверку фильтра и курсор следующей страницы
+func (s *WebhookServiceTestSuite) TestListDeliveries() {
+	deliveries := []domain.WebhookDelivery{{ID: 5}, {ID: 4}, {ID: 3}}
+
+	s.Run("default limit", func() {
+		mockRepo := newMockiWebhookRepository(s.T())
+		mockRepo.EXPECT().
+			ListDeliveries(s.ctx, domain.WebhookDeliveryFilter{Limit: domain.DefaultDeliveryPageSize + 1}).
+			Return(deliveries, nil).Once()
+		service := NewWebhookService(mockRepo)
+
+		page, err := service.ListDeliveries(s.ctx, domain.WebhookDeliveryFilter{})
+
+		s.Require().NoError(err)
+		s.Equal(deliveries, page.Deliveries)
+		s.Zero(page.NextAfterID)
+	})
+
+	s.Run("next page", func() {
+		mockRepo := newMockiWebhookRepository(s.T())
+		mockRepo.EXPECT().
+			ListDeliveries(s.ctx, domain.WebhookDeliveryFilter{Status: domain.DeliveryStatusDead, Limit: 3}).
+			Return(deliveries, nil).Once()
+		service := NewWebhookService(mockRepo)
+
+		page, err := service.ListDeliveries(s.ctx, domain.WebhookDeliveryFilter{
+			Status: domain.DeliveryStatusDead,
+			Limit:  2,
+		})
+
+		s.Require().NoError(err)
+		s.Equal(deliveries[:2], page.Deliveries)
+		s.Equal(int64(4), page.NextAfterID)
+	})
+
+	for name, filter := range map[string]domain.WebhookDeliveryFilter{
+		"limit too big":   {Limit: domain.MaxDeliveryPageSize + 1},
+		"negative cursor": {AfterID: -1},
+		"unknown status":  {Status: "LOST"},
+	} {
+		s.Run(name, func() {
+			service := NewWebhookService(newMockiWebhookRepository(s.T()))
+
+			_, err := service.ListDeliveries(s.ctx, filter)
+
+			s.ErrorIs(err, domain.ErrInvalidWebhook)
+		})
+	}
+}
+
+// TestEmitEvent проверяет кодирование полезной нагрузки события и ошибку записи в outbox
+func (s *WebhookServiceTestSuite) TestEmitEvent() {
+	var emitted domain.OutboxEvent
+	mockOutbox := newMockiEventOutbox(s.T())
+	mockOutbox.EXPECT().
+		AddEvent(s.ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, event domain.OutboxEvent) (domain.OutboxEvent, error) {
+			emitted = event
+			return event, nil
+		}).Once()
+
+	err := emitReviewerReassigned(s.ctx, mockOutbox, domain.ReviewerReplacement{
+		PullRequestID: "pr-1",
+		OldReviewerID: "user-1",
+	})
+
+	s.Require().NoError(err)
+	s.Equal(domain.EventReviewerReassigned, emitted.Type)
+	var payload map[string]any
+	s.Require().NoError(json.Unmarshal(emitted.Payload, &payload))
+	s.Equal(map[string]any{"pull_request_id": "pr-1", "old_reviewer_id": "user-1"}, payload)
+
+	mockOutbox = newMockiEventOutbox(s.T())
+	mockOutbox.EXPECT().AddEvent(s.ctx, mock.Anything).Return(domain.OutboxEvent{}, errors.New("database error")).Once()
+
+	err = emitReviewersAssigned(s.ctx, mockOutbox, "pr-1", []string{"user-2"})
+
+	s.Error(err)
+}
+
+// TestWebhookServiceSuite запускает test suite
+func TestWebhookServiceSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceTestSuite))
+}
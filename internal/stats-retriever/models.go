@@ -1,5 +1,36 @@
 package stats_retriever
 
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidFilter is returned when the stats filter cannot be applied
+var ErrInvalidFilter = errors.New("invalid stats filter")
+
+// Period is the granularity of the stats time series.
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+// Filter narrows the stats down. Zero values mean no restriction.
+//
+// Time bounds apply to the event the metric is about: created_at for opened pull requests,
+// merged_at for merged ones and time to merge, assigned_at for assignments,
+// the first verdict for time to first review and the latest verdict for approvals.
+// Snapshot counters (user, team and assignment stats) ignore the time bounds.
+type Filter struct {
+	From     time.Time // inclusive
+	To       time.Time // exclusive
+	TeamName string
+	UserID   string
+	Period   Period
+}
+
 type AssignmentStats struct {
 	ReviewerID string `json:"reviewer_id"`
 	IsActive   bool   `json:"is_active"`
@@ -16,9 +47,67 @@ type UsersStats struct {
 	Total    int  `json:"total"`
 }
 
+// TimeToMerge describes how long it takes pull requests to get merged.
+type TimeToMerge struct {
+	MergedCount   int     `json:"merged_count"`
+	MedianSeconds float64 `json:"median_seconds"`
+	P90Seconds    float64 `json:"p90_seconds"`
+}
+
+// TimeToFirstReview describes how long pull requests wait for the first verdict of a reviewer.
+type TimeToFirstReview struct {
+	ReviewedCount int     `json:"reviewed_count"`
+	MedianSeconds float64 `json:"median_seconds"`
+	P90Seconds    float64 `json:"p90_seconds"`
+}
+
+// ReviewerApprovalStats is the share of pull requests the reviewer approved among the ones they reviewed.
+// Only the latest verdict of the reviewer on each pull request counts.
+type ReviewerApprovalStats struct {
+	ReviewerID   string  `json:"reviewer_id"`
+	Reviewed     int     `json:"reviewed"`
+	Approved     int     `json:"approved"`
+	ApprovalRate float64 `json:"approval_rate"`
+}
+
+// NewReviewerApprovalStats creates ReviewerApprovalStats calculating the approval rate
+func NewReviewerApprovalStats(reviewerID string, reviewed, approved int) ReviewerApprovalStats {
+	s := ReviewerApprovalStats{ReviewerID: reviewerID, Reviewed: reviewed, Approved: approved}
+	if reviewed > 0 {
+		s.ApprovalRate = float64(approved) / float64(reviewed)
+	}
+	return s
+}
+
+// PullRequestsPeriodStats is the number of pull requests opened and merged during a period.
+type PullRequestsPeriodStats struct {
+	PeriodStart time.Time `json:"period_start"`
+	Opened      int       `json:"opened"`
+	Merged      int       `json:"merged"`
+}
+
+// AssignmentsPeriodStats is the number of reviews assigned to a reviewer during a period.
+type AssignmentsPeriodStats struct {
+	PeriodStart time.Time `json:"period_start"`
+	ReviewerID  string    `json:"reviewer_id"`
+	Assignments int       `json:"assignments"`
+}
+
 // Stats represents system statistics.
 type Stats struct {
-	UserStats   []UsersStats      `json:"user_stats"`
-	TeamStats   []TeamsStats      `json:"team_stats"`
-	AssignStats []AssignmentStats `json:"assignment_stats"`
+	UserStats    []UsersStats              `json:"user_stats"`
+	TeamStats    []TeamsStats              `json:"team_stats"`
+	AssignStats  []AssignmentStats         `json:"assignment_stats"`
+	TimeToMerge  TimeToMerge               `json:"time_to_merge"`
+	PullRequests []PullRequestsPeriodStats `json:"pull_requests_by_period"`
+	Assignments  []AssignmentsPeriodStats  `json:"assignments_by_period"`
+
+	TimeToFirstReview TimeToFirstReview       `json:"time_to_first_review"`
+	Approvals         []ReviewerApprovalStats `json:"reviewer_approvals"`
+}
+
+// ReconcileResult reports how many stats counters were rebuilt because they had drifted from the data.
+type ReconcileResult struct {
+	ReviewersFixed int `json:"reviewers_fixed"`
+	TeamsFixed     int `json:"teams_fixed"`
 }
@@ -2,12 +2,16 @@ package stats_retriever
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"time"
 )
 
 // Скрываю всё абстракциями, в README опишу, почему сделал так.
 
 type iStatsRepository interface {
-	Get(ctx context.Context) ([]Stats, error)
+	Get(ctx context.Context, filter Filter) (Stats, error)
+	Reconcile(ctx context.Context) (ReconcileResult, error)
 }
 
 type StatsRetriever struct {
@@ -20,6 +24,47 @@ func NewStatsRetriever(repo iStatsRepository) *StatsRetriever {
 	}
 }
 
-func (sr *StatsRetriever) RetrieveStats(ctx context.Context) ([]Stats, error) {
-	return sr.repo.Get(ctx)
+// RetrieveStats returns the stats narrowed down by the filter. An empty period defaults to a day.
+func (sr *StatsRetriever) RetrieveStats(ctx context.Context, filter Filter) (Stats, error) {
+	switch filter.Period {
+	case "":
+		filter.Period = PeriodDay
+	case PeriodDay, PeriodWeek, PeriodMonth:
+	default:
+		return Stats{}, fmt.Errorf("%w: unknown period %q", ErrInvalidFilter, filter.Period)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return Stats{}, fmt.Errorf("%w: from must be before to", ErrInvalidFilter)
+	}
+	filter.From, filter.To = filter.From.UTC(), filter.To.UTC()
+
+	return sr.repo.Get(ctx, filter)
+}
+
+// Reconcile rebuilds the stats counters from the source data
+func (sr *StatsRetriever) Reconcile(ctx context.Context) (ReconcileResult, error) {
+	return sr.repo.Reconcile(ctx)
+}
+
+// RunReconciliation reconciles the stats counters every interval until ctx is done
+func (sr *StatsRetriever) RunReconciliation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			res, err := sr.Reconcile(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to reconcile stats", "error", err)
+				continue
+			}
+			if res.ReviewersFixed > 0 || res.TeamsFixed > 0 {
+				slog.WarnContext(ctx, "stats counters drifted and were rebuilt",
+					"reviewers_fixed", res.ReviewersFixed, "teams_fixed", res.TeamsFixed)
+			}
+		}
+	}
 }
@@ -0,0 +1,235 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/pkg/config"
+)
+
+// Headers of a delivery request
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderEventID   = "X-Webhook-Event-ID"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature holds "sha256=" and the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the subscription secret
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// maxErrorLength limits the error stored with a failed delivery
+const maxErrorLength = 500
+
+type iWebhookRepository interface {
+	FanOutEvents(ctx context.Context, batchSize int) (int, error)
+	ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, batchSize int) ([]domain.ClaimedDelivery, error)
+	CompleteDelivery(ctx context.Context, id int64) error
+	FailDelivery(
+		ctx context.Context,
+		id int64,
+		status domain.DeliveryStatus,
+		nextAttemptAt time.Time,
+		lastError string,
+	) error
+}
+
+// Envelope is the body of a delivery request
+type Envelope struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// DispatchResult tells what a dispatch run did
+type DispatchResult struct {
+	// FannedOut is the number of outbox events turned into deliveries
+	FannedOut int
+	Delivered int
+	// Failed is the number of deliveries that failed and will be retried
+	Failed int
+	// Dead is the number of deliveries that failed for the last time
+	Dead int
+}
+
+// Dispatcher delivers outbox events to webhook subscribers. Deliveries are claimed with a lease,
+// so several instances of the service may run dispatchers over the same storage
+type Dispatcher struct {
+	repo   iWebhookRepository
+	cfg    config.WebhooksConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewDispatcher(repo iWebhookRepository, cfg config.WebhooksConfig) *Dispatcher {
+	return &Dispatcher{
+		repo:   repo,
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// Run dispatches events every cfg.DispatchInterval until ctx is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.DispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			res, err := d.DispatchOnce(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to dispatch webhooks", "error", err)
+				continue
+			}
+			if res.Dead > 0 {
+				slog.WarnContext(ctx, "webhook deliveries failed for the last time", "dead", res.Dead)
+			}
+		}
+	}
+}
+
+// DispatchOnce fans new outbox events out to the subscribers and sends the deliveries that are due.
+// Requests are sent in parallel outside of any transaction, each of them is limited by cfg.Timeout
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (DispatchResult, error) {
+	var res DispatchResult
+	fannedOut, err := d.repo.FanOutEvents(ctx, d.cfg.BatchSize)
+	if err != nil {
+		return res, fmt.Errorf("error fanning out events: %w", err)
+	}
+	res.FannedOut = fannedOut
+
+	// the lease outlives the requests, so that nobody else sends the deliveries until we record the outcome
+	now := d.now()
+	claimed, err := d.repo.ClaimDeliveries(ctx, now, now.Add(2*d.cfg.Timeout), d.cfg.BatchSize)
+	if err != nil {
+		return res, fmt.Errorf("error claiming deliveries: %w", err)
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+	)
+	for _, delivery := range claimed {
+		wg.Go(func() {
+			status, err := d.deliver(ctx, delivery)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			switch status {
+			case domain.DeliveryStatusDelivered:
+				res.Delivered++
+			case domain.DeliveryStatusPending:
+				res.Failed++
+			case domain.DeliveryStatusDead:
+				res.Dead++
+			}
+		})
+	}
+	wg.Wait()
+	return res, errors.Join(errs...)
+}
+
+// deliver sends the delivery and records the outcome. The returned error is about recording it
+func (d *Dispatcher) deliver(ctx context.Context, delivery domain.ClaimedDelivery) (domain.DeliveryStatus, error) {
+	sendErr := d.send(ctx, delivery)
+	if sendErr == nil {
+		if err := d.repo.CompleteDelivery(ctx, delivery.ID); err != nil {
+			return "", fmt.Errorf("error completing delivery %d: %w", delivery.ID, err)
+		}
+		return domain.DeliveryStatusDelivered, nil
+	}
+
+	attempts := delivery.Attempts + 1
+	status, nextAttemptAt := domain.DeliveryStatusPending, d.now().Add(d.backoff(attempts))
+	if attempts >= d.cfg.MaxAttempts {
+		status = domain.DeliveryStatusDead
+	}
+	lastError := sendErr.Error()
+	if len(lastError) > maxErrorLength {
+		lastError = lastError[:maxErrorLength]
+	}
+	if err := d.repo.FailDelivery(ctx, delivery.ID, status, nextAttemptAt, lastError); err != nil {
+		return "", fmt.Errorf("error failing delivery %d: %w", delivery.ID, err)
+	}
+	return status, nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery domain.ClaimedDelivery) error {
+	body, err := json.Marshal(Envelope{
+		ID:        delivery.Event.ID,
+		Type:      string(delivery.Event.Type),
+		CreatedAt: delivery.Event.CreatedAt,
+		Data:      delivery.Event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(delivery.Event.Type))
+	req.Header.Set(HeaderEventID, strconv.FormatInt(delivery.Event.ID, 10))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+	// тело дочитывается, чтобы соединение вернулось в пул
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// backoff returns the delay before the next attempt after the given number of failed ones
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.BaseBackoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.MaxBackoff)
+}
+
+// Sign returns the value of HeaderSignature for the body sent at timestamp.
+// Receivers compute it the same way and compare with hmac.Equal
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
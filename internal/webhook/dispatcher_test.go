@@ -0,0 +1,240 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/artmexbet/avito_test_task/internal/domain"
+	"github.com/artmexbet/avito_test_task/internal/memory"
+	"github.com/artmexbet/avito_test_task/internal/repository"
+	"github.com/artmexbet/avito_test_task/pkg/config"
+)
+
+// receivedRequest — запрос, пришедший на тестовый приёмник
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// DispatcherTestSuite проверяет доставку событий на локальный httptest-приёмник поверх хранилища в памяти
+type DispatcherTestSuite struct {
+	suite.Suite
+	ctx        context.Context
+	repo       *repository.WebhookRepository
+	dispatcher *Dispatcher
+	clock      time.Time
+
+	server   *httptest.Server
+	status   atomic.Int32
+	mu       sync.Mutex
+	received []receivedRequest
+}
+
+// SetupTest выполняется перед каждым тестом
+func (s *DispatcherTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = repository.NewWebhookRepository(memory.New())
+	s.dispatcher = NewDispatcher(s.repo, config.WebhooksConfig{
+		DispatchInterval: time.Second,
+		BatchSize:        10,
+		Timeout:          time.Second,
+		MaxAttempts:      3,
+		BaseBackoff:      time.Minute,
+		MaxBackoff:       90 * time.Second,
+	})
+	// часы диспетчера двигаются только вручную и не отстают от хранилища, которое берёт реальное время
+	s.clock = time.Now().UTC().Add(time.Second)
+	s.dispatcher.now = func() time.Time { return s.clock }
+
+	s.status.Store(http.StatusOK)
+	s.received = nil
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.received = append(s.received, receivedRequest{header: r.Header.Clone(), body: body})
+		s.mu.Unlock()
+		w.WriteHeader(int(s.status.Load()))
+	}))
+}
+
+// TearDownTest выполняется после каждого теста
+func (s *DispatcherTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *DispatcherTestSuite) subscribe(secret string, eventTypes ...domain.EventType) domain.WebhookSubscription {
+	subscription, err := s.repo.Subscribe(s.ctx, domain.WebhookSubscription{
+		URL:        s.server.URL + "/hooks",
+		Secret:     secret,
+		EventTypes: eventTypes,
+	})
+	s.Require().NoError(err)
+	return subscription
+}
+
+func (s *DispatcherTestSuite) emit(eventType domain.EventType, payload string) domain.OutboxEvent {
+	event, err := s.repo.AddEvent(s.ctx, domain.OutboxEvent{Type: eventType, Payload: json.RawMessage(payload)})
+	s.Require().NoError(err)
+	return event
+}
+
+func (s *DispatcherTestSuite) dispatch() DispatchResult {
+	res, err := s.dispatcher.DispatchOnce(s.ctx)
+	s.Require().NoError(err)
+	return res
+}
+
+func (s *DispatcherTestSuite) deliveries() []domain.WebhookDelivery {
+	deliveries, err := s.repo.ListDeliveries(s.ctx, domain.WebhookDeliveryFilter{Limit: 100})
+	s.Require().NoError(err)
+	return deliveries
+}
+
+// TestDeliver проверяет раскладку событий по подпискам, заголовки, подпись и тело запроса
+func (s *DispatcherTestSuite) TestDeliver() {
+	merges := s.subscribe("merge-secret", domain.EventPullRequestMerged)
+	s.subscribe("assign-secret", domain.EventReviewersAssigned)
+	event := s.emit(domain.EventPullRequestMerged, `{"pull_request_id":"pr-1"}`)
+
+	res := s.dispatch()
+
+	s.Equal(DispatchResult{FannedOut: 1, Delivered: 1}, res)
+	s.Require().Len(s.received, 1)
+	req := s.received[0]
+	s.Equal("application/json", req.header.Get("Content-Type"))
+	s.Equal(string(domain.EventPullRequestMerged), req.header.Get(HeaderEvent))
+	s.Equal(strconv.FormatInt(event.ID, 10), req.header.Get(HeaderEventID))
+	s.Equal(strconv.FormatInt(s.clock.Unix(), 10), req.header.Get(HeaderTimestamp))
+
+	// приёмник проверяет подпись своим секретом, чужой секрет подпись не сходится
+	timestamp, err := strconv.ParseInt(req.header.Get(HeaderTimestamp), 10, 64)
+	s.Require().NoError(err)
+	signature := req.header.Get(HeaderSignature)
+	s.True(hmac.Equal([]byte(Sign("merge-secret", timestamp, req.body)), []byte(signature)))
+	s.False(hmac.Equal([]byte(Sign("assign-secret", timestamp, req.body)), []byte(signature)))
+
+	var envelope Envelope
+	s.Require().NoError(json.Unmarshal(req.body, &envelope))
+	s.Equal(event.ID, envelope.ID)
+	s.Equal(string(domain.EventPullRequestMerged), envelope.Type)
+	s.JSONEq(`{"pull_request_id":"pr-1"}`, string(envelope.Data))
+
+	deliveries := s.deliveries()
+	s.Require().Len(deliveries, 1)
+	s.Equal(merges.ID, deliveries[0].SubscriptionID)
+	s.Equal(domain.DeliveryStatusDelivered, deliveries[0].Status)
+	s.Equal(1, deliveries[0].Attempts)
+	s.False(deliveries[0].DeliveredAt.IsZero())
+
+	// повторный запуск ничего не отправляет
+	s.Equal(DispatchResult{}, s.dispatch())
+	s.Len(s.received, 1)
+}
+
+// TestRetryAndDeadLetter проверяет экспоненциальную задержку между попытками, уход в DEAD и ручной повтор
+func (s *DispatcherTestSuite) TestRetryAndDeadLetter() {
+	s.subscribe("secret", domain.EventReviewerReassigned)
+	s.emit(domain.EventReviewerReassigned, `{"pull_request_id":"pr-1","old_reviewer_id":"user-1"}`)
+	s.status.Store(http.StatusInternalServerError)
+
+	// первая неудача: следующая попытка через BaseBackoff
+	s.Equal(DispatchResult{FannedOut: 1, Failed: 1}, s.dispatch())
+	delivery := s.deliveries()[0]
+	s.Equal(domain.DeliveryStatusPending, delivery.Status)
+	s.Equal(1, delivery.Attempts)
+	s.Equal(s.clock.Add(time.Minute), delivery.NextAttemptAt)
+	s.Contains(delivery.LastError, "500")
+
+	// до истечения задержки доставка не отправляется
+	s.clock = s.clock.Add(59 * time.Second)
+	s.Equal(DispatchResult{}, s.dispatch())
+	s.Len(s.received, 1)
+
+	// вторая неудача: задержка удваивается, но не превышает MaxBackoff
+	s.clock = s.clock.Add(time.Second)
+	s.Equal(DispatchResult{Failed: 1}, s.dispatch())
+	delivery = s.deliveries()[0]
+	s.Equal(2, delivery.Attempts)
+	s.Equal(s.clock.Add(90*time.Second), delivery.NextAttemptAt)
+
+	// третья неудача исчерпывает попытки
+	s.clock = s.clock.Add(90 * time.Second)
+	s.Equal(DispatchResult{Dead: 1}, s.dispatch())
+	delivery = s.deliveries()[0]
+	s.Equal(domain.DeliveryStatusDead, delivery.Status)
+	s.Equal(3, delivery.Attempts)
+
+	// мёртвая доставка больше не отправляется
+	s.clock = s.clock.Add(time.Hour)
+	s.Equal(DispatchResult{}, s.dispatch())
+	s.Len(s.received, 3)
+
+	// после ручного повтора доставка уходит, когда приёмник оживает
+	_, err := s.repo.RetryDelivery(s.ctx, delivery.ID)
+	s.Require().NoError(err)
+	_, err = s.repo.RetryDelivery(s.ctx, delivery.ID)
+	s.ErrorIs(err, domain.ErrWebhookDeliveryNotFound)
+
+	s.status.Store(http.StatusNoContent)
+	s.Equal(DispatchResult{Delivered: 1}, s.dispatch())
+	delivery = s.deliveries()[0]
+	s.Equal(domain.DeliveryStatusDelivered, delivery.Status)
+	s.Equal(1, delivery.Attempts)
+	s.Empty(delivery.LastError)
+}
+
+// TestUnreachableReceiver проверяет, что сетевая ошибка считается неудачной попыткой
+func (s *DispatcherTestSuite) TestUnreachableReceiver() {
+	s.subscribe("secret", domain.EventReviewersAssigned)
+	s.emit(domain.EventReviewersAssigned, `{"pull_request_id":"pr-1","reviewer_ids":["user-2"]}`)
+	s.server.Close()
+
+	s.Equal(DispatchResult{FannedOut: 1, Failed: 1}, s.dispatch())
+	delivery := s.deliveries()[0]
+	s.Equal(domain.DeliveryStatusPending, delivery.Status)
+	s.NotEmpty(delivery.LastError)
+}
+
+// TestUnsubscribe проверяет, что после отписки события не доставляются
+func (s *DispatcherTestSuite) TestUnsubscribe() {
+	subscription := s.subscribe("secret", domain.EventPullRequestMerged)
+	s.emit(domain.EventPullRequestMerged, `{"pull_request_id":"pr-1"}`)
+	s.status.Store(http.StatusBadGateway)
+	s.Equal(DispatchResult{FannedOut: 1, Failed: 1}, s.dispatch())
+
+	s.Require().NoError(s.repo.Unsubscribe(s.ctx, subscription.ID))
+	s.ErrorIs(s.repo.Unsubscribe(s.ctx, subscription.ID), domain.ErrWebhookSubscriptionNotFound)
+	s.Empty(s.deliveries())
+
+	s.emit(domain.EventPullRequestMerged, `{"pull_request_id":"pr-2"}`)
+	s.clock = s.clock.Add(time.Hour)
+	s.Equal(DispatchResult{FannedOut: 1}, s.dispatch())
+	s.Len(s.received, 1)
+}
+
+// TestBackoff проверяет рост задержки и её ограничение сверху
+func (s *DispatcherTestSuite) TestBackoff() {
+	d := NewDispatcher(s.repo, config.WebhooksConfig{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second})
+
+	s.Equal(time.Second, d.backoff(1))
+	s.Equal(2*time.Second, d.backoff(2))
+	s.Equal(8*time.Second, d.backoff(4))
+	s.Equal(10*time.Second, d.backoff(5))
+	s.Equal(10*time.Second, d.backoff(100))
+}
+
+// TestDispatcherSuite запускает test suite
+func TestDispatcherSuite(t *testing.T) {
+	suite.Run(t, new(DispatcherTestSuite))
+}
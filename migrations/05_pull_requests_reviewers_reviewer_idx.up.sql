@@ -0,0 +1,2 @@
+-- PK начинается с pull_request_id, поэтому для подсчёта нагрузки по ревьюверу нужен отдельный индекс
+CREATE INDEX IF NOT EXISTS idx_pull_requests_reviewers_reviewer_id ON pull_requests_reviewers(reviewer_id);
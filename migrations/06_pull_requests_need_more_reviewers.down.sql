@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_pull_requests_need_more_reviewers;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS need_more_reviewers;
\ No newline at end of file
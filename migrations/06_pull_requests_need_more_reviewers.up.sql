@@ -0,0 +1,5 @@
+-- Флаг ставится, когда на PR назначено меньше двух ревьюверов - их добираем, как только в команде появятся активные
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS need_more_reviewers BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_need_more_reviewers ON pull_requests(author_id)
+    WHERE need_more_reviewers AND merged_at IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS team_stats;
+DROP TABLE IF EXISTS reviewer_stats;
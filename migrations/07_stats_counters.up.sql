@@ -0,0 +1,33 @@
+-- Счётчики для /stats/get, чтобы не гонять GROUP BY по всем назначениям на каждый запрос.
+-- Обновляются в тех же транзакциях, что и назначения, переназначения и мерджи
+CREATE TABLE IF NOT EXISTS reviewer_stats (
+    reviewer_id VARCHAR(50) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    assigned_reviews BIGINT NOT NULL DEFAULT 0,
+    open_reviews BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS team_stats (
+    team_name VARCHAR(100) PRIMARY KEY REFERENCES teams(name) ON DELETE CASCADE,
+    active_members BIGINT NOT NULL DEFAULT 0,
+    inactive_members BIGINT NOT NULL DEFAULT 0,
+    -- ревью, назначенные текущим участникам команды
+    assigned_reviews BIGINT NOT NULL DEFAULT 0
+);
+
+INSERT INTO reviewer_stats (reviewer_id, assigned_reviews, open_reviews)
+SELECT prr.reviewer_id, COUNT(*), COUNT(*) FILTER (WHERE pr.merged_at IS NULL)
+FROM pull_requests_reviewers prr
+         JOIN pull_requests pr ON pr.id = prr.pull_request_id
+GROUP BY prr.reviewer_id
+ON CONFLICT (reviewer_id) DO NOTHING;
+
+INSERT INTO team_stats (team_name, active_members, inactive_members, assigned_reviews)
+SELECT t.name,
+       COUNT(u.id) FILTER (WHERE u.is_active),
+       COUNT(u.id) FILTER (WHERE NOT u.is_active),
+       COALESCE(SUM(rs.assigned_reviews), 0)
+FROM teams t
+         LEFT JOIN users u ON u.team_name = t.name
+         LEFT JOIN reviewer_stats rs ON rs.reviewer_id = u.id
+GROUP BY t.name
+ON CONFLICT (team_name) DO NOTHING;
@@ -0,0 +1,13 @@
+-- Вердикты ревьюверов. Храним всю историю: время до первого ревью берём из самой ранней записи,
+-- а текущим вердиктом ревьювера считаем последнюю
+CREATE TABLE IF NOT EXISTS pull_request_reviews (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    verdict VARCHAR(20) NOT NULL CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    submitted_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_reviews_pull_request_id
+    ON pull_request_reviews(pull_request_id, reviewer_id, submitted_at);
+CREATE INDEX IF NOT EXISTS idx_pull_request_reviews_reviewer_id ON pull_request_reviews(reviewer_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_pull_requests_need_more_reviewers;
+CREATE INDEX IF NOT EXISTS idx_pull_requests_need_more_reviewers ON pull_requests(author_id)
+    WHERE need_more_reviewers AND merged_at IS NULL;
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS closed_at;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS status;
\ No newline at end of file
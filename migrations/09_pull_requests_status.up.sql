@@ -0,0 +1,12 @@
+-- DRAFT и CLOSED по датам уже не отличить, поэтому статус теперь храним явно
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS status VARCHAR(10) NOT NULL DEFAULT 'OPEN'
+    CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED'));
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP WITHOUT TIME ZONE;
+
+UPDATE pull_requests
+SET status = 'MERGED'
+WHERE merged_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_pull_requests_need_more_reviewers;
+CREATE INDEX IF NOT EXISTS idx_pull_requests_need_more_reviewers ON pull_requests(author_id)
+    WHERE need_more_reviewers AND status = 'OPEN';
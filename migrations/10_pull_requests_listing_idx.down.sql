@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_pull_requests_merged_at;
+DROP INDEX IF EXISTS idx_pull_requests_author_id_created_at_id;
+DROP INDEX IF EXISTS idx_pull_requests_status_created_at_id;
+DROP INDEX IF EXISTS idx_pull_requests_created_at_id;
\ No newline at end of file
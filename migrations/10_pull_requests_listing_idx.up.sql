@@ -0,0 +1,8 @@
+-- Индексы под /pullRequest/list: лента идёт по (created_at, id) от новых к старым,
+-- фильтры по статусу и автору используют тот же порядок, чтобы keyset-пагинация не сортировала выборку
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created_at_id ON pull_requests(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_status_created_at_id ON pull_requests(status, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_id_created_at_id
+    ON pull_requests(author_id, created_at DESC, id DESC);
+-- Смердженных PR обычно меньшинство среди всех, поэтому индекс частичный
+CREATE INDEX IF NOT EXISTS idx_pull_requests_merged_at ON pull_requests(merged_at) WHERE merged_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- Соответствие логинов GitHub/GitLab пользователям сервиса, по нему входящие вебхуки находят автора PR
+CREATE TABLE IF NOT EXISTS external_logins (
+    provider VARCHAR(20) NOT NULL CHECK (provider IN ('github', 'gitlab')),
+    login VARCHAR(255) NOT NULL,
+    user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, login)
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_logins_user_id ON external_logins(user_id);
@@ -0,0 +1,16 @@
+-- Периоды отсутствия (отпуск, больничный): пока период идёт, пользователь не получает новых ревью.
+-- reassigned_at выставляет фоновая задача, когда передала открытые ревью пользователя коллегам
+CREATE TABLE IF NOT EXISTS user_absences (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    reassigned_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_absences_user_id ON user_absences(user_id, ends_at);
+CREATE INDEX IF NOT EXISTS idx_user_absences_not_reassigned ON user_absences(starts_at) WHERE reassigned_at IS NULL;
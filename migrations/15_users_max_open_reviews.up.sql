@@ -0,0 +1,2 @@
+-- Сколько открытых ревью пользователь может держать одновременно, NULL - без ограничения
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER CHECK (max_open_reviews > 0);
@@ -0,0 +1,7 @@
+ALTER TABLE team_stats
+    DROP CONSTRAINT IF EXISTS team_stats_team_name_fkey,
+    ADD CONSTRAINT team_stats_team_name_fkey FOREIGN KEY (team_name) REFERENCES teams (name) ON DELETE CASCADE;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_team_name_fkey,
+    ADD CONSTRAINT users_team_name_fkey FOREIGN KEY (team_name) REFERENCES teams (name) ON DELETE CASCADE;
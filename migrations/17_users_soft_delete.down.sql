@@ -0,0 +1,5 @@
+ALTER TABLE pull_requests
+    DROP CONSTRAINT IF EXISTS pull_requests_author_id_fkey,
+    ADD CONSTRAINT pull_requests_author_id_fkey FOREIGN KEY (author_id) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
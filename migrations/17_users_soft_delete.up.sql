@@ -0,0 +1,8 @@
+-- Пользователи удаляются мягко: за ними остаются авторство PR, ревью и аудит
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITHOUT TIME ZONE;
+
+-- author_id NOT NULL не сочетается с ON DELETE SET NULL - удаление автора всё равно падало.
+-- Физически пользователей больше не удаляем, так что запрещаем это явно
+ALTER TABLE pull_requests
+    DROP CONSTRAINT IF EXISTS pull_requests_author_id_fkey,
+    ADD CONSTRAINT pull_requests_author_id_fkey FOREIGN KEY (author_id) REFERENCES users (id) ON DELETE RESTRICT;
@@ -0,0 +1,7 @@
+ALTER TABLE pull_requests_reviewers
+    DROP COLUMN IF EXISTS pool_kind,
+    DROP COLUMN IF EXISTS pool_name;
+
+DROP TABLE IF EXISTS team_fallback_pools;
+DROP TABLE IF EXISTS reviewer_pool_members;
+DROP TABLE IF EXISTS reviewer_pools;
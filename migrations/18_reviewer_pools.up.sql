@@ -0,0 +1,37 @@
+-- Общие пулы ревьюверов: участники остаются в своих командах и подстраховывают команды, которые ссылаются на пул
+CREATE TABLE IF NOT EXISTS reviewer_pools (
+    name VARCHAR(100) NOT NULL PRIMARY KEY,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS reviewer_pool_members (
+    pool_name VARCHAR(100) NOT NULL REFERENCES reviewer_pools(name) ON DELETE CASCADE,
+    user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (pool_name, user_id)
+);
+
+-- Запасные пулы команды по порядку: другая команда или общий пул, ровно что-то одно
+CREATE TABLE IF NOT EXISTS team_fallback_pools (
+    team_name VARCHAR(100) NOT NULL REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    position INT NOT NULL,
+    fallback_team_name VARCHAR(100) REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    pool_name VARCHAR(100) REFERENCES reviewer_pools(name) ON DELETE CASCADE,
+    PRIMARY KEY (team_name, position),
+    CHECK ((fallback_team_name IS NULL) <> (pool_name IS NULL))
+);
+
+-- Пул, из которого взят ревьювер. Старые назначения считаем взятыми из команды ревьювера
+ALTER TABLE pull_requests_reviewers
+    ADD COLUMN IF NOT EXISTS pool_kind VARCHAR(10) NOT NULL DEFAULT 'team' CHECK (pool_kind IN ('team', 'shared')),
+    ADD COLUMN IF NOT EXISTS pool_name VARCHAR(100);
+
+UPDATE pull_requests_reviewers prr
+SET pool_name = u.team_name
+FROM users u
+WHERE u.id = prr.reviewer_id
+  AND prr.pool_name IS NULL;
+
+ALTER TABLE pull_requests_reviewers
+    ALTER COLUMN pool_kind DROP DEFAULT,
+    ALTER COLUMN pool_name SET NOT NULL;
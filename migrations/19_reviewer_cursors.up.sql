@@ -0,0 +1,9 @@
+-- Курсоры очереди round-robin: последний выбранный участник пула. Курсор меняется в той же транзакции, что и
+-- назначение, поэтому откат или повтор транзакции не сдвигает очередь, а все экземпляры сервиса идут по одной очереди
+CREATE TABLE IF NOT EXISTS reviewer_cursors (
+    pool_kind VARCHAR(10) NOT NULL CHECK (pool_kind IN ('team', 'shared')),
+    pool_name VARCHAR(100) NOT NULL,
+    last_reviewer_id VARCHAR(50) NOT NULL,
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (pool_kind, pool_name)
+);
@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS contains "<version>_<name>.up.sql" and "<version>_<name>.down.sql" files.
+//
+//go:embed *.sql
+var FS embed.FS
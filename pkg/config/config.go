@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
@@ -29,14 +32,176 @@ type PostgresConfig struct {
 	SSLMode  string `yaml:"sslmode" env:"SSLMODE"`
 }
 
+// LogValue hides the password when the config is logged
+func (cfg PostgresConfig) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("host", cfg.Host),
+		slog.Int("port", cfg.Port),
+		slog.String("user", cfg.User),
+		slog.String("password", redacted(cfg.Password)),
+		slog.String("dbname", cfg.DBName),
+		slog.String("sslmode", cfg.SSLMode),
+	)
+}
+
 func (cfg *PostgresConfig) DSN() string {
 	return fmt.Sprintf("postgresql://%s:%s@%s:%d/%s?sslmode=%s",
 		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.DBName, cfg.SSLMode)
 }
 
+// ReviewerStrategy defines how reviewers are picked out of the candidates.
+type ReviewerStrategy string
+
+// Possible values for ReviewerStrategy
+const (
+	ReviewerStrategyRandom      ReviewerStrategy = "random"
+	ReviewerStrategyRoundRobin  ReviewerStrategy = "round_robin"
+	ReviewerStrategyLeastLoaded ReviewerStrategy = "least_loaded"
+	ReviewerStrategyWeighted    ReviewerStrategy = "weighted"
+)
+
+type ReviewersConfig struct {
+	Strategy ReviewerStrategy `yaml:"strategy" env:"STRATEGY" env-default:"random"`
+	// Weights is used only by the weighted strategy. Users without a weight get 1.
+	Weights map[string]int `yaml:"weights" env:"WEIGHTS"`
+}
+
+type MigrationsConfig struct {
+	// Auto applies pending migrations on startup before serving requests
+	Auto bool `yaml:"auto" env:"AUTO" env-default:"false"`
+}
+
+// StorageBackend defines where the service keeps its data.
+type StorageBackend string
+
+// Possible values for StorageBackend
+const (
+	StorageBackendPostgres StorageBackend = "postgres"
+	// StorageBackendMemory keeps everything in the process memory, the data is lost on restart
+	StorageBackendMemory StorageBackend = "memory"
+)
+
+type StorageConfig struct {
+	Backend StorageBackend `yaml:"backend" env:"BACKEND" env-default:"postgres"`
+}
+
+type StatsConfig struct {
+	// ReconcileInterval is how often stats counters are rebuilt from the data, 0 disables the job
+	ReconcileInterval time.Duration `yaml:"reconcile_interval" env:"RECONCILE_INTERVAL" env-default:"0"`
+}
+
+// MergePolicyConfig defines what an open pull request needs to be merged. Admins can bypass it with force.
+type MergePolicyConfig struct {
+	MinApprovals            int  `yaml:"min_approvals" env:"MIN_APPROVALS" env-default:"0"`
+	BlockOnChangesRequested bool `yaml:"block_on_changes_requested" env:"BLOCK_ON_CHANGES_REQUESTED" env-default:"true"`
+	RequireOutsideApproval  bool `yaml:"require_outside_approval" env:"REQUIRE_OUTSIDE_APPROVAL" env-default:"false"`
+}
+
+// WebhooksConfig tunes delivery of domain events to webhook subscribers
+type WebhooksConfig struct {
+	// DispatchInterval is how often the outbox is polled, 0 disables delivery
+	DispatchInterval time.Duration `yaml:"dispatch_interval" env:"DISPATCH_INTERVAL" env-default:"1s"`
+	// BatchSize is the maximum number of events fanned out and deliveries sent per poll
+	BatchSize int `yaml:"batch_size" env:"BATCH_SIZE" env-default:"100"`
+	// Timeout limits a single HTTP request to a subscriber
+	Timeout time.Duration `yaml:"timeout" env:"TIMEOUT" env-default:"5s"`
+	// MaxAttempts is the number of failed sends after which a delivery becomes DEAD
+	MaxAttempts int `yaml:"max_attempts" env:"MAX_ATTEMPTS" env-default:"8"`
+	// BaseBackoff is the delay after the first failure, it doubles with every next one up to MaxBackoff
+	BaseBackoff time.Duration `yaml:"base_backoff" env:"BASE_BACKOFF" env-default:"1s"`
+	MaxBackoff  time.Duration `yaml:"max_backoff" env:"MAX_BACKOFF" env-default:"10m"`
+}
+
+// IngestConfig holds the credentials of code hostings that send pull request events to the service.
+// A provider with an empty credential is disabled
+type IngestConfig struct {
+	// GitHubSecret is the secret of the GitHub webhook, it signs the payloads with HMAC-SHA256
+	GitHubSecret string `yaml:"github_secret" env:"GITHUB_SECRET"`
+	// GitLabToken is the secret token of the GitLab webhook, it is sent as is in a header
+	GitLabToken string `yaml:"gitlab_token" env:"GITLAB_TOKEN"`
+}
+
+// LogValue hides the webhook credentials when the config is logged
+func (cfg IngestConfig) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("github_secret", redacted(cfg.GitHubSecret)),
+		slog.String("gitlab_token", redacted(cfg.GitLabToken)),
+	)
+}
+
+// AbsencesConfig tunes the job that hands over open reviews of users whose absence has started
+type AbsencesConfig struct {
+	// ReassignInterval is how often started absences are looked for, 0 disables the job
+	ReassignInterval time.Duration `yaml:"reassign_interval" env:"REASSIGN_INTERVAL" env-default:"0"`
+	// BatchSize is the maximum number of absences handled per run
+	BatchSize int `yaml:"batch_size" env:"BATCH_SIZE" env-default:"100"`
+}
+
+// AuthConfig defines how API callers are authenticated. Static admin tokens are meant for scripts,
+// people use bearer JWTs issued elsewhere and verified against the keys of a local JWKS file
+type AuthConfig struct {
+	// Disabled serves every request as an anonymous admin. Only for local runs
+	Disabled bool `yaml:"disabled" env:"DISABLED" env-default:"false"`
+	// AdminTokens maps names of static admin tokens onto the tokens, the name is written to the audit log
+	AdminTokens map[string]string `yaml:"admin_tokens" env:"ADMIN_TOKENS"`
+	// JWKSFile is the path of a JSON Web Key Set with the public keys that sign bearer tokens
+	JWKSFile string `yaml:"jwks_file" env:"JWKS_FILE"`
+	// Issuer and Audience are checked against the iss and aud claims if set
+	Issuer   string `yaml:"issuer" env:"ISSUER"`
+	Audience string `yaml:"audience" env:"AUDIENCE"`
+	// Leeway is the allowed clock skew when checking exp and nbf
+	Leeway time.Duration `yaml:"leeway" env:"LEEWAY" env-default:"30s"`
+}
+
+// LogValue hides the static admin tokens when the config is logged, only their names are kept
+func (cfg AuthConfig) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Bool("disabled", cfg.Disabled),
+		slog.Any("admin_tokens", slices.Sorted(maps.Keys(cfg.AdminTokens))),
+		slog.String("jwks_file", cfg.JWKSFile),
+		slog.String("issuer", cfg.Issuer),
+		slog.String("audience", cfg.Audience),
+		slog.Duration("leeway", cfg.Leeway),
+	)
+}
+
+// redacted replaces a secret in logs. An empty secret stays empty to show the setting is off
+func redacted(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
 type Config struct {
-	Router   RouterConfig   `yaml:"router" env-prefix:"ROUTER_"`
-	Postgres PostgresConfig `yaml:"postgres" env-prefix:"POSTGRES_"`
+	Router      RouterConfig      `yaml:"router" env-prefix:"ROUTER_"`
+	Storage     StorageConfig     `yaml:"storage" env-prefix:"STORAGE_"`
+	Postgres    PostgresConfig    `yaml:"postgres" env-prefix:"POSTGRES_"`
+	Reviewers   ReviewersConfig   `yaml:"reviewers" env-prefix:"REVIEWERS_"`
+	Migrations  MigrationsConfig  `yaml:"migrations" env-prefix:"MIGRATIONS_"`
+	Stats       StatsConfig       `yaml:"stats" env-prefix:"STATS_"`
+	MergePolicy MergePolicyConfig `yaml:"merge_policy" env-prefix:"MERGE_POLICY_"`
+	Webhooks    WebhooksConfig    `yaml:"webhooks" env-prefix:"WEBHOOKS_"`
+	Ingest      IngestConfig      `yaml:"ingest" env-prefix:"INGEST_"`
+	Auth        AuthConfig        `yaml:"auth" env-prefix:"AUTH_"`
+	Absences    AbsencesConfig    `yaml:"absences" env-prefix:"ABSENCES_"`
+}
+
+// LogValue logs the config section by section, so the sections with secrets can hide them
+func (cfg Config) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("router", cfg.Router),
+		slog.Any("storage", cfg.Storage),
+		slog.Any("postgres", cfg.Postgres),
+		slog.Any("reviewers", cfg.Reviewers),
+		slog.Any("migrations", cfg.Migrations),
+		slog.Any("stats", cfg.Stats),
+		slog.Any("merge_policy", cfg.MergePolicy),
+		slog.Any("webhooks", cfg.Webhooks),
+		slog.Any("ingest", cfg.Ingest),
+		slog.Any("auth", cfg.Auth),
+		slog.Any("absences", cfg.Absences),
+	)
 }
 
 func MustParseConfig(source Source, path ...string) Config {
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigLogValue(t *testing.T) {
+	var cfg Config
+	cfg.Postgres.Password = "pg-password"
+	cfg.Ingest.GitHubSecret = "github-secret"
+	cfg.Auth.AdminTokens = map[string]string{"deploy": "admin-token"}
+	cfg.Auth.Issuer = "https://issuer.example.com"
+
+	for name, handler := range map[string]func(*bytes.Buffer) slog.Handler{
+		"text": func(buf *bytes.Buffer) slog.Handler { return slog.NewTextHandler(buf, nil) },
+		"json": func(buf *bytes.Buffer) slog.Handler { return slog.NewJSONHandler(buf, nil) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			slog.New(handler(&buf)).Info("config", "config", cfg)
+
+			// секреты не попадают в лог, имена токенов и прочие настройки остаются
+			out := buf.String()
+			for _, secret := range []string{"pg-password", "github-secret", "admin-token"} {
+				assert.NotContains(t, out, secret)
+			}
+			assert.Contains(t, out, "[REDACTED]")
+			assert.Contains(t, out, "deploy")
+			assert.Contains(t, out, "https://issuer.example.com")
+		})
+	}
+}